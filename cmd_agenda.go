@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/internal/bootstrap"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+// agendaDateLayout is the YYYY-MM-DD layout of agenda days.
+const agendaDateLayout = "2006-01-02"
+
+// AgendaOpts holds parsed arguments for the agenda subcommand.
+type AgendaOpts struct {
+	Days int    // upcoming days listed after today's
+	User string // "me", an assignee name, or empty for everyone
+	JSON bool
+}
+
+// parseAgendaArgs parses `tiki agenda [--days N] [--user me|NAME] [--format table|json]`.
+func parseAgendaArgs(args []string) (AgendaOpts, error) {
+	opts := AgendaOpts{Days: 7}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch name {
+		case "--help", "-h":
+			return AgendaOpts{}, errHelpRequested
+		case "--days", "--user", "--format":
+			if !hasValue {
+				i++
+				if i >= len(args) {
+					return AgendaOpts{}, fmt.Errorf("%s requires a value", name)
+				}
+				value = args[i] //nolint:gosec // G602: bounds checked above
+			}
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return AgendaOpts{}, fmt.Errorf("unknown flag: %s", arg)
+			}
+			return AgendaOpts{}, fmt.Errorf("unexpected argument: %s", arg)
+		}
+		switch name {
+		case "--days":
+			days, err := strconv.Atoi(value)
+			if err != nil || days < 0 {
+				return AgendaOpts{}, fmt.Errorf("--days must be a whole number of days, got %q", value)
+			}
+			opts.Days = days
+		case "--user":
+			if strings.TrimSpace(value) == "" {
+				return AgendaOpts{}, fmt.Errorf("--user requires a value")
+			}
+			opts.User = strings.TrimSpace(value)
+		case "--format":
+			switch value {
+			case "table":
+				opts.JSON = false
+			case "json":
+				opts.JSON = true
+			default:
+				return AgendaOpts{}, fmt.Errorf("unsupported format %q (supported: table, json)", value)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// runAgenda implements `tiki agenda`. Returns an exit code.
+func runAgenda(args []string) int {
+	opts, err := parseAgendaArgs(args)
+	if err != nil {
+		if errors.Is(err, errHelpRequested) {
+			printAgendaUsage()
+			return exitOK
+		}
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		printAgendaUsage()
+		return exitUsage
+	}
+
+	cfg, err := bootstrap.LoadConfig()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load config: %v\n", err)
+		return exitStartupFailure
+	}
+
+	bootstrap.InitCLILogging(cfg)
+
+	if err := config.LoadWorkflowFields(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load workflow registries: %v\n", err)
+		return exitStartupFailure
+	}
+	agendaCfg, ok := config.WorkflowAgenda()
+	if !ok {
+		_, _ = fmt.Fprintln(os.Stderr, "error: the workflow has no due field; name one in an agenda: section")
+		return exitStartupFailure
+	}
+	if opts.User != "" && agendaCfg.AssigneeField == "" {
+		_, _ = fmt.Fprintln(os.Stderr, "error: --user needs an assignee field; name one in the agenda: section")
+		return exitStartupFailure
+	}
+
+	_, tikiStore, err := bootstrap.InitStores()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: initialize store: %v\n", err)
+		return exitStartupFailure
+	}
+
+	assignee := opts.User
+	if assignee == "me" {
+		assignee, err = store.CurrentUserDisplay(tikiStore)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "error: resolve current user: %v\n", err)
+			return exitStartupFailure
+		}
+		if assignee == "" {
+			_, _ = fmt.Fprintln(os.Stderr, "error: --user me: no current user is configured")
+			return exitStartupFailure
+		}
+	}
+
+	agenda := store.BuildAgenda(tikiStore.GetAllTikis(), agendaCfg, assignee, time.Now(), opts.Days+1)
+	if opts.JSON {
+		err = writeAgendaJSON(os.Stdout, agenda)
+	} else {
+		err = writeAgendaTable(os.Stdout, agenda, agendaCfg, opts.Days)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		return exitInternal
+	}
+	return exitOK
+}
+
+// writeAgendaTable prints the overdue tikis, then one block per day with
+// deadlines. A date read from a due field other than the first is labelled
+// with the field name; recurrence occurrences are marked as recurring.
+func writeAgendaTable(w io.Writer, agenda store.Agenda, cfg config.AgendaConfig, days int) error {
+	if len(agenda.Overdue) == 0 && len(agenda.Days) == 0 {
+		_, err := fmt.Fprintf(w, "nothing due in the next %d days\n", days)
+		return err
+	}
+	line := func(e store.AgendaEntry, prefix string) string {
+		s := fmt.Sprintf("  %s%-8s %s", prefix, store.DisplayID(e.Tiki), e.Tiki.Title())
+		if len(cfg.DueFields) > 0 && e.Field != cfg.DueFields[0] {
+			s += " [" + e.Field + "]"
+		}
+		if e.Occurrence {
+			s += " (recurring)"
+		}
+		return s + "\n"
+	}
+
+	var b strings.Builder
+	if len(agenda.Overdue) > 0 {
+		b.WriteString("Overdue\n")
+		for _, e := range agenda.Overdue {
+			b.WriteString(line(e, e.Day.Format(agendaDateLayout)+"  "))
+		}
+	}
+	for _, day := range agenda.Days {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		heading := day.Day.Format("Mon " + agendaDateLayout)
+		if day.Day.Equal(agenda.Today) {
+			heading = "Today, " + heading
+		}
+		b.WriteString(heading + "\n")
+		for _, e := range day.Entries {
+			b.WriteString(line(e, ""))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// agendaJSONEntry is one deadline in `--format json` output.
+type agendaJSONEntry struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Field      string `json:"field"`
+	Date       string `json:"date"`
+	Occurrence bool   `json:"occurrence,omitempty"`
+}
+
+// agendaJSONDay is one day in `--format json` output.
+type agendaJSONDay struct {
+	Date  string            `json:"date"`
+	Tikis []agendaJSONEntry `json:"tikis"`
+}
+
+// writeAgendaJSON prints the agenda as one JSON object with today's date,
+// the overdue entries and the upcoming days.
+func writeAgendaJSON(w io.Writer, agenda store.Agenda) error {
+	entries := func(es []store.AgendaEntry) []agendaJSONEntry {
+		out := make([]agendaJSONEntry, len(es))
+		for i, e := range es {
+			out[i] = agendaJSONEntry{
+				ID:         store.DisplayID(e.Tiki),
+				Title:      e.Tiki.Title(),
+				Field:      e.Field,
+				Date:       e.Day.Format(agendaDateLayout),
+				Occurrence: e.Occurrence,
+			}
+		}
+		return out
+	}
+	out := struct {
+		Today   string            `json:"today"`
+		Overdue []agendaJSONEntry `json:"overdue"`
+		Days    []agendaJSONDay   `json:"days"`
+	}{
+		Today:   agenda.Today.Format(agendaDateLayout),
+		Overdue: entries(agenda.Overdue),
+		Days:    make([]agendaJSONDay, len(agenda.Days)),
+	}
+	for i, day := range agenda.Days {
+		out.Days[i] = agendaJSONDay{Date: day.Day.Format(agendaDateLayout), Tikis: entries(day.Entries)}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// printAgendaUsage prints usage for the agenda subcommand.
+func printAgendaUsage() {
+	fmt.Print(`Usage: tiki agenda [options]
+
+List the tikis that are overdue, due today and due in the coming days,
+grouped by day. Recurring tikis are listed on each upcoming occurrence. Done
+tikis are left out. The due fields come from the workflow's agenda: section
+(default: the due field).
+
+Options:
+  --days N          Upcoming days to list after today (default: 7)
+  --user me|NAME    Only tikis assigned to you or to NAME
+  --format FORMAT   Output format: table (default) or json
+  -h, --help        Show this help message
+
+Examples:
+  tiki agenda
+  tiki agenda --days 14 --user me
+  tiki agenda --format json
+`)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func TestParseAgendaArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		want      AgendaOpts
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "defaults", args: nil, want: AgendaOpts{Days: 7}},
+		{name: "days and user", args: []string{"--days", "14", "--user", "me"}, want: AgendaOpts{Days: 14, User: "me"}},
+		{name: "equals form", args: []string{"--days=0", "--user=alice", "--format=json"}, want: AgendaOpts{Days: 0, User: "alice", JSON: true}},
+		{name: "help", args: []string{"--days", "3", "-h"}, wantErr: errHelpRequested},
+		{name: "bad days", args: []string{"--days", "soon"}, errSubstr: "--days must be a whole number"},
+		{name: "negative days", args: []string{"--days=-1"}, errSubstr: "--days must be a whole number"},
+		{name: "missing value", args: []string{"--user"}, errSubstr: "--user requires a value"},
+		{name: "bad format", args: []string{"--format", "csv"}, errSubstr: `unsupported format "csv"`},
+		{name: "unknown flag", args: []string{"--all"}, errSubstr: "unknown flag: --all"},
+		{name: "positional", args: []string{"today"}, errSubstr: "unexpected argument: today"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAgendaArgs(tt.args)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			case tt.errSubstr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("err = %v, want substring %q", err, tt.errSubstr)
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func agendaCommandFixture() store.Agenda {
+	tk := func(id, title string) *tikipkg.Tiki {
+		t := tikipkg.New()
+		t.SetID(id)
+		t.SetTitle(title)
+		return t
+	}
+	standup := tk("WEEK01", "Standup")
+	return store.Agenda{
+		Today:   time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		Overdue: []store.AgendaEntry{{Tiki: tk("LATE01", "Renew cert"), Field: "due", Day: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}},
+		Days: []store.AgendaDay{
+			{Day: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), Entries: []store.AgendaEntry{{Tiki: standup, Field: "due", Day: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)}}},
+			{Day: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), Entries: []store.AgendaEntry{{Tiki: standup, Field: "due", Day: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), Occurrence: true}}},
+		},
+	}
+}
+
+func TestWriteAgendaTable(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := config.AgendaConfig{DueFields: []string{"due"}}
+	if err := writeAgendaTable(&buf, agendaCommandFixture(), cfg, 7); err != nil {
+		t.Fatal(err)
+	}
+	want := "Overdue\n" +
+		"  2026-03-01  LATE01   Renew cert\n" +
+		"\nToday, Wed 2026-03-04\n" +
+		"  WEEK01   Standup\n" +
+		"\nWed 2026-03-11\n" +
+		"  WEEK01   Standup (recurring)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("table =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := writeAgendaTable(&buf, store.Agenda{}, cfg, 7); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "nothing due in the next 7 days\n" {
+		t.Errorf("empty agenda = %q", got)
+	}
+}
+
+func TestWriteAgendaJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeAgendaJSON(&buf, agendaCommandFixture()); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Today   string            `json:"today"`
+		Overdue []agendaJSONEntry `json:"overdue"`
+		Days    []agendaJSONDay   `json:"days"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %s: %v", buf.String(), err)
+	}
+	if got.Today != "2026-03-04" || len(got.Overdue) != 1 || got.Overdue[0].ID != "LATE01" {
+		t.Errorf("today/overdue = %q %+v", got.Today, got.Overdue)
+	}
+	if len(got.Days) != 2 || got.Days[1].Date != "2026-03-11" || !got.Days[1].Tikis[0].Occurrence {
+		t.Errorf("days = %+v", got.Days)
+	}
+}
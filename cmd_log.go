@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/internal/bootstrap"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+// LogOpts holds parsed arguments for the log subcommand. Since and Until
+// are kept as written and resolved against the clock when the log runs.
+type LogOpts struct {
+	Since string // "today", a ruki duration or a date; never empty
+	Until string // same forms as Since; empty means now
+	User  string // "me", an actor name, or empty for everyone
+	Field string // only changes to this field, or empty for all
+	JSON  bool
+}
+
+// parseLogArgs parses `tiki log [--since WHEN] [--until WHEN] [--user me|NAME] [--field NAME] [--format table|json]`.
+func parseLogArgs(args []string) (LogOpts, error) {
+	opts := LogOpts{Since: "today"}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch name {
+		case "--help", "-h":
+			return LogOpts{}, errHelpRequested
+		case "--since", "--until", "--user", "--field", "--format":
+			if !hasValue {
+				i++
+				if i >= len(args) {
+					return LogOpts{}, fmt.Errorf("%s requires a value", name)
+				}
+				value = args[i] //nolint:gosec // G602: bounds checked above
+			}
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return LogOpts{}, fmt.Errorf("unknown flag: %s", arg)
+			}
+			return LogOpts{}, fmt.Errorf("unexpected argument: %s", arg)
+		}
+		value = strings.TrimSpace(value)
+		switch name {
+		case "--since", "--until":
+			if _, err := store.ParseActivityTime(value, time.Now()); err != nil {
+				return LogOpts{}, fmt.Errorf("%s: %w", name, err)
+			}
+			if name == "--since" {
+				opts.Since = value
+			} else {
+				opts.Until = value
+			}
+		case "--user", "--field":
+			if value == "" {
+				return LogOpts{}, fmt.Errorf("%s requires a value", name)
+			}
+			if name == "--user" {
+				opts.User = value
+			} else {
+				opts.Field = value
+			}
+		case "--format":
+			switch value {
+			case "table":
+				opts.JSON = false
+			case "json":
+				opts.JSON = true
+			default:
+				return LogOpts{}, fmt.Errorf("unsupported format %q (supported: table, json)", value)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// runLog implements `tiki log`. Returns an exit code.
+func runLog(args []string) int {
+	opts, err := parseLogArgs(args)
+	if err != nil {
+		if errors.Is(err, errHelpRequested) {
+			printLogUsage()
+			return exitOK
+		}
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		printLogUsage()
+		return exitUsage
+	}
+
+	cfg, err := bootstrap.LoadConfig()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load config: %v\n", err)
+		return exitStartupFailure
+	}
+
+	bootstrap.InitCLILogging(cfg)
+
+	if err := config.LoadWorkflowFields(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load workflow registries: %v\n", err)
+		return exitStartupFailure
+	}
+
+	_, tikiStore, err := bootstrap.InitStores()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: initialize store: %v\n", err)
+		return exitStartupFailure
+	}
+
+	now := time.Now()
+	filter := store.ActivityFilter{User: opts.User, Field: opts.Field}
+	// both bounds were validated by parseLogArgs
+	filter.Since, _ = store.ParseActivityTime(opts.Since, now)
+	if opts.Until != "" {
+		filter.Until, _ = store.ParseActivityTime(opts.Until, now)
+	}
+	if filter.User == "me" {
+		filter.User, err = store.CurrentUserDisplay(tikiStore)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "error: resolve current user: %v\n", err)
+			return exitStartupFailure
+		}
+		if filter.User == "" {
+			_, _ = fmt.Fprintln(os.Stderr, "error: --user me: no current user is configured")
+			return exitStartupFailure
+		}
+	}
+
+	// a one-shot command has no session of its own, so the log is the
+	// committed history alone
+	events := store.FilterActivity(service.Activity(tikiStore, nil, filter.Since), filter)
+	if opts.JSON {
+		err = writeLogJSON(os.Stdout, events)
+	} else {
+		err = writeLogTable(os.Stdout, events)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		return exitInternal
+	}
+	return exitOK
+}
+
+// writeLogTable prints one line per change, newest first: time, actor, tiki
+// and what changed.
+func writeLogTable(w io.Writer, events []store.ActivityEvent) error {
+	if len(events) == 0 {
+		_, err := fmt.Fprintln(w, "no changes")
+		return err
+	}
+	var b strings.Builder
+	for _, e := range events {
+		change := string(e.Kind)
+		if len(e.Fields) > 0 {
+			change += " " + strings.Join(e.Fields, ", ")
+		}
+		fmt.Fprintf(&b, "%s  %-12s %-8s %s · %s\n",
+			e.When.Local().Format("2006-01-02 15:04"), logActor(e), e.TikiID, e.Title, change)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// logActor is the actor column, with a placeholder for commits without an
+// author.
+func logActor(e store.ActivityEvent) string {
+	if e.Actor == "" {
+		return "unknown"
+	}
+	return e.Actor
+}
+
+// logJSONEvent is one change in `--format json` output.
+type logJSONEvent struct {
+	Time   string   `json:"time"`
+	Actor  string   `json:"actor"`
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Kind   string   `json:"kind"`
+	Fields []string `json:"fields"`
+}
+
+// writeLogJSON prints the changes as a JSON array, newest first.
+func writeLogJSON(w io.Writer, events []store.ActivityEvent) error {
+	out := make([]logJSONEvent, len(events))
+	for i, e := range events {
+		fields := e.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+		out[i] = logJSONEvent{
+			Time:   e.When.UTC().Format(time.RFC3339),
+			Actor:  e.Actor,
+			ID:     e.TikiID,
+			Title:  e.Title,
+			Kind:   string(e.Kind),
+			Fields: fields,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// printLogUsage prints usage for the log subcommand.
+func printLogUsage() {
+	fmt.Print(`Usage: tiki log [options]
+
+List recent changes to tikis from git history, newest first: when, who,
+which tiki and which fields changed. Every commit that adds, changes or
+removes a tiki file is listed.
+
+WHEN is today, a duration back from now (2day, 12hour, 1week) or a date
+(2006-01-02).
+
+Options:
+  --since WHEN      Start of the range (default: today)
+  --until WHEN      End of the range (default: now)
+  --user me|NAME    Only changes by you or by NAME
+  --field NAME      Only changes to the field NAME
+  --format FORMAT   Output format: table (default) or json
+  -h, --help        Show this help message
+
+Examples:
+  tiki log
+  tiki log --since 1week --user me
+  tiki log --since 2026-01-05 --until 2026-01-12 --field status
+`)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/store"
+)
+
+func TestParseLogArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		want      LogOpts
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "defaults", args: nil, want: LogOpts{Since: "today"}},
+		{name: "range and filters", args: []string{"--since", "1week", "--until", "2026-03-01", "--user", "me", "--field", "status"},
+			want: LogOpts{Since: "1week", Until: "2026-03-01", User: "me", Field: "status"}},
+		{name: "equals form", args: []string{"--since=12hour", "--format=json"}, want: LogOpts{Since: "12hour", JSON: true}},
+		{name: "help", args: []string{"--since", "1day", "-h"}, wantErr: errHelpRequested},
+		{name: "bad since", args: []string{"--since", "lately"}, errSubstr: "--since:"},
+		{name: "bad until", args: []string{"--until=soon"}, errSubstr: "--until:"},
+		{name: "missing value", args: []string{"--field"}, errSubstr: "--field requires a value"},
+		{name: "empty user", args: []string{"--user="}, errSubstr: "--user requires a value"},
+		{name: "bad format", args: []string{"--format", "csv"}, errSubstr: `unsupported format "csv"`},
+		{name: "unknown flag", args: []string{"--all"}, errSubstr: "unknown flag: --all"},
+		{name: "positional", args: []string{"ABC123"}, errSubstr: "unexpected argument: ABC123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLogArgs(tt.args)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			case tt.errSubstr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("err = %v, want substring %q", err, tt.errSubstr)
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func logCommandFixture() []store.ActivityEvent {
+	at := func(h int) time.Time { return time.Date(2026, 3, 2, h, 30, 0, 0, time.Local) }
+	return []store.ActivityEvent{
+		{When: at(15), Actor: "alice", TikiID: "AAA001", Title: "Login", Kind: store.ActivityUpdated, Fields: []string{"priority", "status"}, Committed: true},
+		{When: at(9), TikiID: "BBB001", Title: "Signup", Kind: store.ActivityCreated, Committed: true},
+	}
+}
+
+func TestWriteLogTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeLogTable(&buf, logCommandFixture()); err != nil {
+		t.Fatal(err)
+	}
+	want := "2026-03-02 15:30  alice        AAA001   Login · updated priority, status\n" +
+		"2026-03-02 09:30  unknown      BBB001   Signup · created\n"
+	if got := buf.String(); got != want {
+		t.Errorf("table =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := writeLogTable(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "no changes\n" {
+		t.Errorf("empty log = %q", got)
+	}
+}
+
+func TestWriteLogJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeLogJSON(&buf, logCommandFixture()); err != nil {
+		t.Fatal(err)
+	}
+	var got []logJSONEvent
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %s: %v", buf.String(), err)
+	}
+	if len(got) != 2 || got[0].ID != "AAA001" || got[0].Kind != "updated" || len(got[0].Fields) != 2 {
+		t.Errorf("events = %+v", got)
+	}
+	if got[1].Fields == nil || len(got[1].Fields) != 0 {
+		t.Errorf("created event fields = %#v, want an empty list", got[1].Fields)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/internal/bootstrap"
+	"github.com/boolean-maybe/tiki/internal/publish"
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/theme"
+)
+
+// defaultPublishDir is where `tiki publish` writes when --out is omitted.
+const defaultPublishDir = "site"
+
+// PublishOpts holds parsed arguments for the publish subcommand.
+type PublishOpts struct {
+	OutDir string
+	Title  string
+}
+
+// parsePublishArgs parses `tiki publish` arguments: `--out <dir>` and
+// `--title <text>`, each also accepted in `--flag=value` form.
+func parsePublishArgs(args []string) (PublishOpts, error) {
+	opts := PublishOpts{OutDir: defaultPublishDir}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--help" || arg == "-h":
+			return PublishOpts{}, errHelpRequested
+		case arg == "--out" || arg == "--title":
+			i++
+			if i >= len(args) {
+				return PublishOpts{}, fmt.Errorf("%s requires a value", arg)
+			}
+			setPublishFlag(&opts, arg, args[i]) //nolint:gosec // G602: bounds checked above
+		case strings.HasPrefix(arg, "--out=") || strings.HasPrefix(arg, "--title="):
+			name, value, _ := strings.Cut(arg, "=")
+			setPublishFlag(&opts, name, value)
+		case strings.HasPrefix(arg, "-"):
+			return PublishOpts{}, fmt.Errorf("unknown flag: %s", arg)
+		default:
+			return PublishOpts{}, fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+	if opts.OutDir == "" {
+		return PublishOpts{}, fmt.Errorf("--out must not be empty")
+	}
+	return opts, nil
+}
+
+func setPublishFlag(opts *PublishOpts, name, value string) {
+	switch name {
+	case "--out":
+		opts.OutDir = value
+	case "--title":
+		opts.Title = value
+	}
+}
+
+// runPublish implements `tiki publish [--out dir] [--title text]`. Returns an exit code.
+func runPublish(args []string) int {
+	opts, err := parsePublishArgs(args)
+	if err != nil {
+		if errors.Is(err, errHelpRequested) {
+			printPublishUsage()
+			return exitOK
+		}
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		printPublishUsage()
+		return exitUsage
+	}
+
+	cfg, err := bootstrap.LoadConfig()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load config: %v\n", err)
+		return exitStartupFailure
+	}
+
+	bootstrap.InitCLILogging(cfg)
+
+	if name := config.GetStoreName(); name != "tiki" {
+		_, _ = fmt.Fprintf(os.Stderr, "error: unknown store backend: %q (supported: tiki)\n", name)
+		return exitStartupFailure
+	}
+
+	if err := config.LoadWorkflowFields(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load workflow registries: %v\n", err)
+		return exitStartupFailure
+	}
+
+	_, tikiStore, err := bootstrap.InitStores()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: initialize store: %v\n", err)
+		return exitStartupFailure
+	}
+
+	schema := rukiRuntime.NewSchema()
+	views, _, err := plugin.LoadPluginsAndGlobals(schema)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load views: %v\n", err)
+		return exitStartupFailure
+	}
+	userFunc, err := store.CurrentUserDisplayFunc(tikiStore)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: resolve current user: %v\n", err)
+		return exitStartupFailure
+	}
+
+	result, err := publish.Publish(publish.Options{
+		Root:      config.GetDocDir(),
+		OutDir:    opts.OutDir,
+		Title:     opts.Title,
+		Store:     tikiStore,
+		Views:     views,
+		Schema:    schema,
+		User:      userFunc,
+		Theme:     theme.LoadByName(config.GetEffectiveTheme()),
+		Light:     config.IsLightTheme(),
+		CodeTheme: config.GetCodeBlockTheme(),
+	})
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		return exitInternal
+	}
+	fmt.Printf("published %d documents, %d views and %d files to %s\n",
+		result.Documents, result.Views, result.Files, opts.OutDir)
+	return exitOK
+}
+
+// printPublishUsage prints usage for the publish subcommand.
+func printPublishUsage() {
+	fmt.Print(`Usage: tiki publish [options]
+
+Render every document, an index page per board and list view, and a search
+index to a static HTML site. Requires an initialized project.
+
+Options:
+  --out <dir>       Output directory (default: site). Must be empty or a
+                    previously published site, which is replaced
+  --title <text>    Site title (default: the project directory name)
+  -h, --help        Show this help message
+
+Examples:
+  tiki publish
+  tiki publish --out public --title "Team docs"
+`)
+}
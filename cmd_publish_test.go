@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParsePublishArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		want      PublishOpts
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "defaults", args: nil, want: PublishOpts{OutDir: "site"}},
+		{name: "out and title", args: []string{"--out", "public", "--title", "Team docs"}, want: PublishOpts{OutDir: "public", Title: "Team docs"}},
+		{name: "equals form", args: []string{"--out=public", "--title=Docs"}, want: PublishOpts{OutDir: "public", Title: "Docs"}},
+		{name: "help", args: []string{"--help"}, wantErr: errHelpRequested},
+		{name: "missing value", args: []string{"--out"}, errSubstr: "--out requires a value"},
+		{name: "empty out", args: []string{"--out="}, errSubstr: "--out must not be empty"},
+		{name: "unknown flag", args: []string{"--format", "json"}, errSubstr: "unknown flag: --format"},
+		{name: "positional", args: []string{"site"}, errSubstr: "unexpected argument: site"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePublishArgs(tt.args)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			case tt.errSubstr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("err = %v, want substring %q", err, tt.errSubstr)
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
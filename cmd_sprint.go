@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/internal/bootstrap"
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+// SprintOpts holds parsed arguments for the sprint subcommand.
+type SprintOpts struct {
+	Close bool   // `tiki sprint close`; otherwise `tiki sprint` prints status
+	Name  string // sprint to close; empty means the current or last ended one
+}
+
+// parseSprintArgs parses `tiki sprint` and `tiki sprint close [NAME]`.
+func parseSprintArgs(args []string) (SprintOpts, error) {
+	var opts SprintOpts
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "--help" || arg == "-h":
+			return SprintOpts{}, errHelpRequested
+		case len(arg) > 1 && arg[0] == '-':
+			return SprintOpts{}, fmt.Errorf("unknown flag: %s", arg)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return opts, nil
+	}
+	switch positional[0] {
+	case "status":
+		if len(positional) > 1 {
+			return SprintOpts{}, fmt.Errorf("unexpected argument: %s", positional[1])
+		}
+	case "close":
+		opts.Close = true
+		if len(positional) > 2 {
+			return SprintOpts{}, fmt.Errorf("unexpected argument: %s", positional[2])
+		}
+		if len(positional) == 2 {
+			opts.Name = positional[1]
+		}
+	default:
+		return SprintOpts{}, fmt.Errorf("unknown sprint command: %s", positional[0])
+	}
+	return opts, nil
+}
+
+// runSprint implements `tiki sprint [status]` and `tiki sprint close [NAME]`.
+// Returns an exit code.
+func runSprint(args []string) int {
+	opts, err := parseSprintArgs(args)
+	if err != nil {
+		if errors.Is(err, errHelpRequested) {
+			printSprintUsage()
+			return exitOK
+		}
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		printSprintUsage()
+		return exitUsage
+	}
+
+	cfg, err := bootstrap.LoadConfig()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load config: %v\n", err)
+		return exitStartupFailure
+	}
+
+	bootstrap.InitCLILogging(cfg)
+
+	if err := config.LoadWorkflowFields(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load workflow registries: %v\n", err)
+		return exitStartupFailure
+	}
+	sprintCfg, ok := config.WorkflowSprints()
+	if !ok {
+		_, _ = fmt.Fprintln(os.Stderr, "error: the workflow declares no sprints: section")
+		return exitStartupFailure
+	}
+
+	gate := service.BuildGate()
+
+	_, tikiStore, err := bootstrap.InitStores()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: initialize store: %v\n", err)
+		return exitStartupFailure
+	}
+	gate.SetStore(tikiStore)
+
+	now := time.Now()
+	if !opts.Close {
+		printSprintStatus(tikiStore, sprintCfg, now)
+		return exitOK
+	}
+
+	// closing moves tikis and creates a document, so triggers and hooks fire
+	// as they would for the same edits made in the TUI
+	schema := rukiRuntime.NewSchema()
+	userFunc, err := store.CurrentUserDisplayFunc(tikiStore)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: resolve current user: %v\n", err)
+		return exitStartupFailure
+	}
+	if _, _, err := service.LoadAndRegisterTriggers(gate, schema, userFunc); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load triggers: %v\n", err)
+		return exitStartupFailure
+	}
+	webhooks, err := service.LoadAndRegisterHooks(gate, schema, config.GetWebhookQueueDir(), service.StoreWebhookActor(tikiStore))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load hooks: %v\n", err)
+		return exitStartupFailure
+	}
+
+	closed, closeErr := service.CloseSprint(context.Background(), gate, sprintCfg, opts.Name, now)
+	if err := webhooks.FlushPending(service.CLIWebhookFlushTimeout); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: webhook delivery deferred: %v\n", err)
+	}
+	if closeErr != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "error:", closeErr)
+		return exitInternal
+	}
+	fmt.Printf("closed sprint %s: %s of %s points completed, %d tikis carried over to %s, summary %s\n",
+		closed.Plan.Sprint.Name, store.FormatPoints(closed.Plan.Completed), store.FormatPoints(closed.Plan.Committed),
+		len(closed.Carried), closed.Next.Name, closed.Summary.ID())
+	return exitOK
+}
+
+// printSprintStatus prints one line per sprint: dates, committed points
+// against capacity and completed points, with the current sprint marked.
+func printSprintStatus(rs store.ReadStore, cfg config.SprintConfig, now time.Time) {
+	tikis := rs.GetAllTikis()
+	sprints := store.Sprints(tikis, cfg)
+	if len(sprints) == 0 {
+		fmt.Println("no sprints")
+		return
+	}
+	for _, plan := range store.PlanSprints(tikis, cfg, sprints) {
+		s := plan.Sprint
+		marker := " "
+		if s.Contains(now) {
+			marker = "*"
+		}
+		fmt.Printf("%s %-16s %s..%s  %5s / %-5s committed  %5s completed  %d open\n",
+			marker, s.Name, s.Start.Format("2006-01-02"), s.End.Format("2006-01-02"),
+			store.FormatPoints(plan.Committed), store.FormatPoints(s.Capacity),
+			store.FormatPoints(plan.Completed), len(plan.Open))
+	}
+}
+
+// printSprintUsage prints usage for the sprint subcommand.
+func printSprintUsage() {
+	fmt.Print(`Usage: tiki sprint [status]
+       tiki sprint close [NAME]
+
+Show the sprints of the workflow's sprints: section with their committed
+points against capacity (the current sprint is marked with *), or close a
+sprint. Closing moves every unfinished tiki of the sprint to the next one
+and writes a summary document with the completed points.
+
+Arguments:
+  NAME              Sprint to close (default: the current sprint, or the
+                    one that ended last)
+
+Options:
+  -h, --help        Show this help message
+
+Examples:
+  tiki sprint
+  tiki sprint close
+  tiki sprint close 2026-S3
+`)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSprintArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		want      SprintOpts
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "status by default", args: nil, want: SprintOpts{}},
+		{name: "explicit status", args: []string{"status"}, want: SprintOpts{}},
+		{name: "close latest", args: []string{"close"}, want: SprintOpts{Close: true}},
+		{name: "close named", args: []string{"close", "2026-S3"}, want: SprintOpts{Close: true, Name: "2026-S3"}},
+		{name: "help", args: []string{"close", "--help"}, wantErr: errHelpRequested},
+		{name: "unknown command", args: []string{"open"}, errSubstr: "unknown sprint command: open"},
+		{name: "unknown flag", args: []string{"close", "--force"}, errSubstr: "unknown flag: --force"},
+		{name: "extra argument", args: []string{"close", "a", "b"}, errSubstr: "unexpected argument: b"},
+		{name: "status argument", args: []string{"status", "a"}, errSubstr: "unexpected argument: a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSprintArgs(tt.args)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			case tt.errSubstr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("err = %v, want substring %q", err, tt.errSubstr)
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
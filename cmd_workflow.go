@@ -11,6 +11,8 @@ import (
 	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/internal/ruki/runtime"
 	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/workflow"
 )
 
@@ -244,8 +246,19 @@ func parsePositionalOnly(args []string) (string, error) {
 // and the raw YAML content (written to a temp file for plugin loading).
 func validateWorkflowViews(vw *config.ValidatedWorkflow, content string) error {
 	fields := append(workflow.SystemFields(), vw.FieldDefs...)
+	if vw.Dependencies != nil {
+		fields = append(fields, workflow.DerivedFields()...)
+	}
+	if vw.Sprints != nil {
+		fields = append(fields, workflow.SprintFields()...)
+	}
+	fields = append(fields, workflow.ChecklistFields()...)
 	schema := runtime.NewSchemaFromFields(fields)
 
+	if _, err := store.CompileComputedFields(schema, vw.FieldDefs); err != nil {
+		return err
+	}
+
 	if len(vw.TriggerDefs) > 0 {
 		parser := ruki.NewParser(schema)
 		for i, def := range vw.TriggerDefs {
@@ -259,6 +272,18 @@ func validateWorkflowViews(vw *config.ValidatedWorkflow, content string) error {
 		}
 	}
 
+	if len(vw.HookDefs) > 0 {
+		parser := ruki.NewParser(schema)
+		for i, def := range vw.HookDefs {
+			if def.Filter == "" {
+				continue
+			}
+			if _, err := service.ParseHookFilter(parser, def.Filter); err != nil {
+				return fmt.Errorf("hook %q: %w", config.HookLabel(def, i), err)
+			}
+		}
+	}
+
 	tmp, err := os.CreateTemp("", "tiki-validate-*.yaml")
 	if err != nil {
 		return fmt.Errorf("create temp file: %w", err)
@@ -276,7 +301,10 @@ func validateWorkflowViews(vw *config.ValidatedWorkflow, content string) error {
 	// suppress INFO logs from plugin loader during validation-only pass
 	prev := slog.Default()
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	// views resolve `create from "<template>"` against the new workflow's templates
+	restoreTemplates := config.SwapWorkflowTemplates(vw.Templates)
 	_, err = plugin.LoadPluginsFromFile(tmp.Name(), schema)
+	restoreTemplates()
 	slog.SetDefault(prev)
 
 	return err
@@ -362,6 +362,38 @@ triggers:
 	}
 }
 
+func TestRunWorkflowInstall_InvalidComputedField(t *testing.T) {
+	_ = setupWorkflowTest(t)
+
+	content := `version: 0.6.0
+fields:
+  - name: status
+    type: enum
+    values:
+      - value: todo
+        label: Todo
+        default: true
+      - value: done
+        label: Done
+  - name: type
+    type: enum
+    values:
+      - value: tiki
+        label: Tiki
+  - name: score
+    type: integer
+    computed: 'title + 1'
+`
+	srcFile := filepath.Join(t.TempDir(), "bad-computed.yaml")
+	if err := os.WriteFile(srcFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := runWorkflowInstall([]string{srcFile, "--global"}); code != exitInternal {
+		t.Errorf("exit code = %d, want %d", code, exitInternal)
+	}
+}
+
 func TestRunWorkflowInstall_InvalidPluginFilter(t *testing.T) {
 	_ = setupWorkflowTest(t)
 
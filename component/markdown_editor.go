@@ -0,0 +1,986 @@
+package component
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/boolean-maybe/tiki/theme"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/tview"
+)
+
+const (
+	maxEditorUndo    = 500 // undo steps kept per editor
+	maxCompletions   = 5   // `[[ID]]` candidates shown at once
+	maxWikilinkQuery = 40  // longer `[[...` runs are not treated as a link being typed
+)
+
+// Completion is one `[[ID]]` suggestion offered while a wikilink is typed.
+type Completion struct {
+	ID    string
+	Title string
+}
+
+// editKind groups consecutive edits into one undo step: a run of typed
+// characters undoes as a whole word, every other edit undoes on its own.
+type editKind int
+
+const (
+	editNone editKind = iota
+	editTyping
+	editOther
+)
+
+// editorState is one undo/redo snapshot.
+type editorState struct {
+	text   string
+	cursor int
+}
+
+// visualRow is one soft-wrapped screen row: the rune range [start, end) of
+// the buffer it shows, and whether it is the last row of its source line.
+type visualRow struct {
+	start, end int
+	last       bool
+}
+
+// MarkdownEditor is a multi-line markdown editor with soft wrap, undo,
+// emacs-style motions (or vim-style modal editing), markdown syntax
+// highlighting and `[[ID]]` completion.
+//
+// The buffer is a single rune slice with a cursor offset into it; lines and
+// wrapped rows are derived on demand, which keeps every edit a slice splice.
+// Descriptions are small enough that the linear rescans are not noticeable.
+type MarkdownEditor struct {
+	*tview.Box
+
+	text   []rune
+	cursor int // rune offset into text, 0..len(text)
+	goalX  int // display column kept across vertical moves; -1 = take it from the cursor
+	offset int // first visible visual row
+	width  int // wrap width from the last Draw; 0 = not drawn yet, no wrapping
+	height int // text rows from the last Draw, for page moves
+
+	vim       bool
+	normal    bool // vim normal mode; false = inserting
+	pending   rune // vim operator waiting for its second key ('d', 'y', 'c', 'g')
+	clipboard string
+	clipLine  bool // clipboard holds whole lines (dd/yy) rather than a span
+
+	undo, redo []editorState
+	lastEdit   editKind
+
+	complete    func(prefix string) []Completion
+	completions []Completion
+	selected    int
+	dismissed   bool // Esc closed the completions for the link being typed
+
+	changed       func()
+	togglePreview func()
+
+	styles        []tcell.Style // highlight cache, one style per rune
+	stylesVersion int
+	version       int // bumped on every buffer change
+}
+
+// NewMarkdownEditor returns an empty editor using emacs-style keys.
+func NewMarkdownEditor() *MarkdownEditor {
+	return &MarkdownEditor{Box: tview.NewBox(), goalX: -1}
+}
+
+// SetVimKeys switches the editor to vim-style modal editing. The editor
+// starts in insert mode so typing works right away; Esc enters normal mode.
+func (m *MarkdownEditor) SetVimKeys(vim bool) *MarkdownEditor {
+	m.vim = vim
+	m.normal = false
+	m.pending = 0
+	return m
+}
+
+// SetText replaces the buffer, puts the cursor at the start and clears the
+// undo history. The changed func is not called.
+func (m *MarkdownEditor) SetText(text string) *MarkdownEditor {
+	m.text = []rune(text)
+	m.cursor = 0
+	m.offset = 0
+	m.goalX = -1
+	m.undo, m.redo = nil, nil
+	m.lastEdit = editNone
+	m.completions = nil
+	m.version++
+	return m
+}
+
+// GetText returns the buffer.
+func (m *MarkdownEditor) GetText() string {
+	return string(m.text)
+}
+
+// SetChangedFunc sets the callback invoked after every edit, undo and redo.
+func (m *MarkdownEditor) SetChangedFunc(fn func()) *MarkdownEditor {
+	m.changed = fn
+	return m
+}
+
+// SetCompleteFunc sets the source of `[[ID]]` completions. fn receives what
+// was typed after `[[` and returns the matching candidates, best first.
+func (m *MarkdownEditor) SetCompleteFunc(fn func(prefix string) []Completion) *MarkdownEditor {
+	m.complete = fn
+	return m
+}
+
+// SetTogglePreviewFunc sets the callback for Alt-P, which the detail view
+// uses to show or hide its rendered preview next to the editor.
+func (m *MarkdownEditor) SetTogglePreviewFunc(fn func()) *MarkdownEditor {
+	m.togglePreview = fn
+	return m
+}
+
+// Completions returns the candidates currently offered, if any.
+func (m *MarkdownEditor) Completions() []Completion {
+	return m.completions
+}
+
+// InNormalMode reports whether vim-style editing is on and in normal mode.
+func (m *MarkdownEditor) InNormalMode() bool {
+	return m.vim && m.normal
+}
+
+// CapturesKey reports whether the editor consumes event itself. The detail
+// view's edit mode binds Tab, Enter, Esc, Up and Down to field traversal,
+// save and cancel; the input router asks the focused editor first so that
+// newlines, cursor keys and the editor's own Ctrl/Alt motions reach it.
+// Tab, Shift-Tab and Ctrl-S always belong to edit mode, and so does Esc
+// unless it closes completions or leaves vim insert mode.
+func (m *MarkdownEditor) CapturesKey(event *tcell.EventKey) bool {
+	key := event.Key()
+	if len(m.completions) > 0 {
+		switch key {
+		case tcell.KeyTab, tcell.KeyEnter, tcell.KeyEscape, tcell.KeyUp, tcell.KeyDown, tcell.KeyCtrlN, tcell.KeyCtrlP:
+			return true
+		}
+	}
+	switch key {
+	case tcell.KeyRune:
+		return true
+	case tcell.KeyEscape:
+		return m.vim && (!m.normal || m.pending != 0)
+	case tcell.KeyCtrlR:
+		return m.InNormalMode()
+	}
+	return editorKeys[key]
+}
+
+// editorKeys are the non-rune keys the editor handles in every mode.
+var editorKeys = map[tcell.Key]bool{
+	tcell.KeyEnter: true, tcell.KeyBackspace: true, tcell.KeyBackspace2: true, tcell.KeyDelete: true,
+	tcell.KeyLeft: true, tcell.KeyRight: true, tcell.KeyUp: true, tcell.KeyDown: true,
+	tcell.KeyHome: true, tcell.KeyEnd: true, tcell.KeyPgUp: true, tcell.KeyPgDn: true,
+	tcell.KeyCtrlA: true, tcell.KeyCtrlE: true, tcell.KeyCtrlB: true, tcell.KeyCtrlF: true,
+	tcell.KeyCtrlN: true, tcell.KeyCtrlP: true, tcell.KeyCtrlD: true, tcell.KeyCtrlK: true,
+	tcell.KeyCtrlU: true, tcell.KeyCtrlW: true, tcell.KeyCtrlY: true, tcell.KeyCtrlZ: true,
+	tcell.KeyCtrlUnderscore: true,
+}
+
+// InputHandler routes keys to the completion list, then to the active keymap.
+func (m *MarkdownEditor) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, _ func(p tview.Primitive)) {
+		if len(m.completions) > 0 && m.handleCompletionKey(event) {
+			return
+		}
+		if m.InNormalMode() {
+			m.handleNormalKey(event)
+		} else {
+			m.handleInsertKey(event)
+		}
+		m.updateCompletions()
+	})
+}
+
+// handleCompletionKey picks, cycles or dismisses completions. Returns false
+// for keys that should edit as usual (typing narrows the candidates).
+func (m *MarkdownEditor) handleCompletionKey(event *tcell.EventKey) bool {
+	switch event.Key() {
+	case tcell.KeyTab, tcell.KeyEnter:
+		m.acceptCompletion()
+	case tcell.KeyDown, tcell.KeyCtrlN:
+		m.selected = (m.selected + 1) % len(m.completions)
+	case tcell.KeyUp, tcell.KeyCtrlP:
+		m.selected = (m.selected + len(m.completions) - 1) % len(m.completions)
+	case tcell.KeyEscape:
+		m.completions = nil
+		m.dismissed = true
+	default:
+		return false
+	}
+	return true
+}
+
+// handleInsertKey handles typing and the emacs-style motions. Vim insert
+// mode shares it; Esc there switches to normal mode.
+func (m *MarkdownEditor) handleInsertKey(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyRune:
+		if event.Modifiers()&tcell.ModAlt != 0 {
+			m.handleAltKey(event.Rune())
+			return
+		}
+		m.typeRune(event.Rune())
+	case tcell.KeyEnter:
+		m.replace(m.cursor, m.cursor, "\n", editOther)
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if m.cursor > 0 {
+			m.replace(m.cursor-1, m.cursor, "", editOther)
+		}
+	case tcell.KeyDelete, tcell.KeyCtrlD:
+		if m.cursor < len(m.text) {
+			m.replace(m.cursor, m.cursor+1, "", editOther)
+		}
+	case tcell.KeyCtrlK:
+		_, end := m.lineBounds(m.cursor)
+		if end == m.cursor && end < len(m.text) {
+			end++ // at the end of a line, kill the line break
+		}
+		m.kill(m.cursor, end)
+	case tcell.KeyCtrlU:
+		start, _ := m.lineBounds(m.cursor)
+		m.kill(start, m.cursor)
+	case tcell.KeyCtrlW:
+		m.kill(m.wordBackward(m.cursor), m.cursor)
+	case tcell.KeyCtrlY:
+		m.replace(m.cursor, m.cursor, m.clipboard, editOther)
+	case tcell.KeyCtrlZ, tcell.KeyCtrlUnderscore:
+		m.undoEdit()
+	case tcell.KeyEscape:
+		if m.vim {
+			m.normal = true
+			m.moveTo(m.cursor - 1)
+			m.clampNormal()
+		}
+	default:
+		m.handleMotionKey(event.Key())
+	}
+}
+
+// handleMotionKey handles the cursor keys shared by every mode.
+func (m *MarkdownEditor) handleMotionKey(key tcell.Key) {
+	switch key {
+	case tcell.KeyLeft, tcell.KeyCtrlB:
+		m.moveTo(m.cursor - 1)
+	case tcell.KeyRight, tcell.KeyCtrlF:
+		m.moveTo(m.cursor + 1)
+	case tcell.KeyUp, tcell.KeyCtrlP:
+		m.moveVertical(-1)
+	case tcell.KeyDown, tcell.KeyCtrlN:
+		m.moveVertical(1)
+	case tcell.KeyPgUp:
+		m.moveVertical(-max(m.height-1, 1))
+	case tcell.KeyPgDn:
+		m.moveVertical(max(m.height-1, 1))
+	case tcell.KeyHome, tcell.KeyCtrlA:
+		start, _ := m.lineBounds(m.cursor)
+		m.moveTo(start)
+	case tcell.KeyEnd, tcell.KeyCtrlE:
+		_, end := m.lineBounds(m.cursor)
+		m.moveTo(end)
+	}
+}
+
+// handleAltKey handles the emacs Meta bindings.
+func (m *MarkdownEditor) handleAltKey(r rune) {
+	switch r {
+	case 'f':
+		m.moveTo(m.wordEnd(m.cursor))
+	case 'b':
+		m.moveTo(m.wordBackward(m.cursor))
+	case 'd':
+		m.kill(m.cursor, m.wordEnd(m.cursor))
+	case '<':
+		m.moveTo(0)
+	case '>':
+		m.moveTo(len(m.text))
+	case '_':
+		m.redoEdit()
+	case 'p':
+		if m.togglePreview != nil {
+			m.togglePreview()
+		}
+	}
+}
+
+// handleNormalKey handles vim normal mode.
+func (m *MarkdownEditor) handleNormalKey(event *tcell.EventKey) {
+	defer m.clampNormal()
+	switch event.Key() {
+	case tcell.KeyRune:
+	case tcell.KeyEscape:
+		m.pending = 0
+		return
+	case tcell.KeyCtrlR:
+		m.redoEdit()
+		return
+	case tcell.KeyEnter:
+		m.moveVertical(1)
+		return
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		m.moveTo(m.cursor - 1)
+		return
+	case tcell.KeyDelete:
+		m.deleteUnderCursor()
+		return
+	case tcell.KeyCtrlZ, tcell.KeyCtrlUnderscore:
+		m.undoEdit()
+		return
+	default:
+		m.handleMotionKey(event.Key())
+		return
+	}
+
+	r := event.Rune()
+	if event.Modifiers()&tcell.ModAlt != 0 {
+		m.handleAltKey(r)
+		return
+	}
+	if op := m.pending; op != 0 {
+		m.pending = 0
+		m.applyOperator(op, r)
+		return
+	}
+	start, end := m.lineBounds(m.cursor)
+	switch r {
+	case 'h':
+		if m.cursor > start {
+			m.moveTo(m.cursor - 1)
+		}
+	case 'l':
+		if m.cursor < end-1 {
+			m.moveTo(m.cursor + 1)
+		}
+	case 'j':
+		m.moveVertical(1)
+	case 'k':
+		m.moveVertical(-1)
+	case 'w':
+		m.moveTo(m.wordForward(m.cursor))
+	case 'b':
+		m.moveTo(m.wordBackward(m.cursor))
+	case 'e':
+		m.moveTo(max(m.wordEnd(m.cursor+1)-1, m.cursor))
+	case '0':
+		m.moveTo(start)
+	case '^':
+		m.moveTo(m.firstNonBlank(start))
+	case '$':
+		m.moveTo(end)
+	case 'G':
+		last, _ := m.lineBounds(len(m.text))
+		m.moveTo(last)
+	case 'g', 'd', 'y', 'c':
+		m.pending = r
+	case 'x':
+		m.deleteUnderCursor()
+	case 'X':
+		if m.cursor > start {
+			m.kill(m.cursor-1, m.cursor)
+		}
+	case 'D':
+		m.kill(m.cursor, end)
+	case 'C':
+		m.kill(m.cursor, end)
+		m.normal = false
+	case 'i':
+		m.normal = false
+	case 'a':
+		m.normal = false
+		if m.cursor < end {
+			m.moveTo(m.cursor + 1)
+		}
+	case 'A':
+		m.normal = false
+		m.moveTo(end)
+	case 'I':
+		m.normal = false
+		m.moveTo(m.firstNonBlank(start))
+	case 'o':
+		m.normal = false
+		m.replace(end, end, "\n", editOther)
+	case 'O':
+		m.normal = false
+		m.replace(start, start, "\n", editOther)
+		m.moveTo(start)
+	case 'p':
+		m.paste(true)
+	case 'P':
+		m.paste(false)
+	case 'J':
+		if end < len(m.text) {
+			m.replace(end, m.firstNonBlank(end+1), " ", editOther)
+			m.moveTo(end)
+		}
+	case 'u':
+		m.undoEdit()
+	}
+}
+
+// applyOperator completes a two-key vim command (gg, dd, dw, d$, yy, cc, cw).
+func (m *MarkdownEditor) applyOperator(op, r rune) {
+	start, end := m.lineBounds(m.cursor)
+	switch string([]rune{op, r}) {
+	case "gg":
+		m.moveTo(0)
+	case "dd":
+		m.yankLine(start, end)
+		from, to := start, end
+		if to < len(m.text) {
+			to++ // the line and its break
+		} else if from > 0 {
+			from-- // the last line and the break before it
+		}
+		m.replace(from, to, "", editOther)
+		lineStart, _ := m.lineBounds(min(from, len(m.text)))
+		m.moveTo(m.firstNonBlank(lineStart))
+	case "yy":
+		m.yankLine(start, end)
+	case "cc":
+		m.kill(m.firstNonBlank(start), end)
+		m.normal = false
+	case "dw":
+		m.kill(m.cursor, min(m.wordForward(m.cursor), max(end, m.cursor+1)))
+	case "cw":
+		m.kill(m.cursor, m.wordEnd(m.cursor))
+		m.normal = false
+	case "d$":
+		m.kill(m.cursor, end)
+	}
+}
+
+// clampNormal keeps the vim normal-mode cursor on a character rather than
+// past the end of the line.
+func (m *MarkdownEditor) clampNormal() {
+	if !m.InNormalMode() {
+		return
+	}
+	start, end := m.lineBounds(m.cursor)
+	if m.cursor >= end && end > start {
+		m.cursor = end - 1
+	}
+}
+
+// --- editing primitives ---
+
+// replace swaps text[from:to] for s, leaves the cursor after the inserted
+// text and records an undo step of the given kind.
+func (m *MarkdownEditor) replace(from, to int, s string, kind editKind) {
+	if from == to && s == "" {
+		return
+	}
+	m.snapshot(kind)
+	ins := []rune(s)
+	next := make([]rune, 0, len(m.text)-(to-from)+len(ins))
+	next = append(next, m.text[:from]...)
+	next = append(next, ins...)
+	next = append(next, m.text[to:]...)
+	m.text = next
+	m.cursor = from + len(ins)
+	m.goalX = -1
+	m.version++
+	if m.changed != nil {
+		m.changed()
+	}
+}
+
+// typeRune inserts a typed character. A run of typing is one undo step; a
+// space closes the word so undo takes text back a word at a time.
+func (m *MarkdownEditor) typeRune(r rune) {
+	m.replace(m.cursor, m.cursor, string(r), editTyping)
+	if unicode.IsSpace(r) {
+		m.lastEdit = editNone
+	}
+}
+
+// kill deletes text[from:to] into the clipboard.
+func (m *MarkdownEditor) kill(from, to int) {
+	if from > to {
+		from, to = to, from
+	}
+	from, to = max(from, 0), min(to, len(m.text))
+	if from == to {
+		return
+	}
+	m.clipboard = string(m.text[from:to])
+	m.clipLine = false
+	m.replace(from, to, "", editOther)
+}
+
+func (m *MarkdownEditor) deleteUnderCursor() {
+	if _, end := m.lineBounds(m.cursor); m.cursor < end {
+		m.kill(m.cursor, m.cursor+1)
+	}
+}
+
+func (m *MarkdownEditor) yankLine(start, end int) {
+	m.clipboard = string(m.text[start:end]) + "\n"
+	m.clipLine = true
+}
+
+// paste inserts the clipboard after (p) or before (P) the cursor; whole
+// lines go below or above the current line.
+func (m *MarkdownEditor) paste(after bool) {
+	if m.clipboard == "" {
+		return
+	}
+	start, end := m.lineBounds(m.cursor)
+	if !m.clipLine {
+		at := m.cursor
+		if after && m.cursor < end {
+			at++
+		}
+		m.replace(at, at, m.clipboard, editOther)
+		m.moveTo(m.cursor - 1)
+		return
+	}
+	if after {
+		m.replace(end, end, "\n"+strings.TrimSuffix(m.clipboard, "\n"), editOther)
+		m.moveTo(end + 1)
+		return
+	}
+	m.replace(start, start, m.clipboard, editOther)
+	m.moveTo(start)
+}
+
+// snapshot records the state before an edit. Consecutive typing shares the
+// snapshot taken before its first character.
+func (m *MarkdownEditor) snapshot(kind editKind) {
+	if kind == editTyping && m.lastEdit == editTyping {
+		return
+	}
+	m.undo = append(m.undo, editorState{text: string(m.text), cursor: m.cursor})
+	if len(m.undo) > maxEditorUndo {
+		m.undo = m.undo[len(m.undo)-maxEditorUndo:]
+	}
+	m.redo = nil
+	m.lastEdit = kind
+}
+
+func (m *MarkdownEditor) undoEdit() {
+	if len(m.undo) == 0 {
+		return
+	}
+	m.redo = append(m.redo, editorState{text: string(m.text), cursor: m.cursor})
+	m.restore(m.undo[len(m.undo)-1])
+	m.undo = m.undo[:len(m.undo)-1]
+}
+
+func (m *MarkdownEditor) redoEdit() {
+	if len(m.redo) == 0 {
+		return
+	}
+	m.undo = append(m.undo, editorState{text: string(m.text), cursor: m.cursor})
+	m.restore(m.redo[len(m.redo)-1])
+	m.redo = m.redo[:len(m.redo)-1]
+}
+
+func (m *MarkdownEditor) restore(s editorState) {
+	m.text = []rune(s.text)
+	m.cursor = min(s.cursor, len(m.text))
+	m.goalX = -1
+	m.lastEdit = editNone
+	m.version++
+	if m.changed != nil {
+		m.changed()
+	}
+}
+
+// --- motions ---
+
+// moveTo places the cursor at off, clamped to the buffer. Moving ends the
+// current typing run, so the next characters start a new undo step.
+func (m *MarkdownEditor) moveTo(off int) {
+	m.cursor = max(0, min(off, len(m.text)))
+	m.goalX = -1
+	m.lastEdit = editNone
+}
+
+// lineBounds returns the [start, end) offsets of the line holding off,
+// excluding its line break.
+func (m *MarkdownEditor) lineBounds(off int) (int, int) {
+	start := off
+	for start > 0 && m.text[start-1] != '\n' {
+		start--
+	}
+	end := off
+	for end < len(m.text) && m.text[end] != '\n' {
+		end++
+	}
+	return start, end
+}
+
+func (m *MarkdownEditor) firstNonBlank(start int) int {
+	i := start
+	for i < len(m.text) && (m.text[i] == ' ' || m.text[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// wordForward returns the start of the next word (vim w).
+func (m *MarkdownEditor) wordForward(off int) int {
+	i := off
+	for i < len(m.text) && isWordRune(m.text[i]) {
+		i++
+	}
+	for i < len(m.text) && !isWordRune(m.text[i]) {
+		i++
+	}
+	return i
+}
+
+// wordEnd returns the offset just past the end of the word at or after off
+// (emacs Meta-f).
+func (m *MarkdownEditor) wordEnd(off int) int {
+	i := off
+	for i < len(m.text) && !isWordRune(m.text[i]) {
+		i++
+	}
+	for i < len(m.text) && isWordRune(m.text[i]) {
+		i++
+	}
+	return i
+}
+
+// wordBackward returns the start of the word before off.
+func (m *MarkdownEditor) wordBackward(off int) int {
+	i := off
+	for i > 0 && !isWordRune(m.text[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(m.text[i-1]) {
+		i--
+	}
+	return i
+}
+
+// moveVertical moves the cursor by delta wrapped rows, keeping the display
+// column it started from.
+func (m *MarkdownEditor) moveVertical(delta int) {
+	rows := m.layout()
+	idx, x := m.cursorRow(rows)
+	goal := m.goalX
+	if goal < 0 {
+		goal = x
+	}
+	target := max(0, min(idx+delta, len(rows)-1))
+	r := rows[target]
+	col, w := r.start, 0
+	for col < r.end {
+		rw := runewidth.RuneWidth(m.text[col])
+		if w+rw > goal {
+			break
+		}
+		w += rw
+		col++
+	}
+	if col == r.end && !r.last && col > r.start {
+		col-- // the end of a wrapped row is the start of the next one
+	}
+	m.cursor = col
+	m.goalX = goal
+	m.lastEdit = editNone
+}
+
+// --- layout ---
+
+// layout soft-wraps the buffer at the last drawn width, breaking after a
+// space where possible.
+func (m *MarkdownEditor) layout() []visualRow {
+	var rows []visualRow
+	start := 0
+	for {
+		end := start
+		for end < len(m.text) && m.text[end] != '\n' {
+			end++
+		}
+		spans := wrapRunes(m.text[start:end], m.width)
+		for i, s := range spans {
+			rows = append(rows, visualRow{start: start + s[0], end: start + s[1], last: i == len(spans)-1})
+		}
+		if end == len(m.text) {
+			return rows
+		}
+		start = end + 1
+	}
+}
+
+// wrapRunes splits line into [start, end) spans no wider than width cells.
+func wrapRunes(line []rune, width int) [][2]int {
+	if width <= 0 || len(line) == 0 {
+		return [][2]int{{0, len(line)}}
+	}
+	var spans [][2]int
+	start := 0
+	for start < len(line) {
+		w, end, brk := 0, start, -1
+		for end < len(line) {
+			rw := runewidth.RuneWidth(line[end])
+			if w+rw > width && end > start {
+				break
+			}
+			w += rw
+			if line[end] == ' ' {
+				brk = end + 1
+			}
+			end++
+		}
+		if end < len(line) && brk > start {
+			end = brk
+		}
+		spans = append(spans, [2]int{start, end})
+		start = end
+	}
+	return spans
+}
+
+// cursorRow returns the index of the wrapped row holding the cursor and the
+// cursor's display column within it.
+func (m *MarkdownEditor) cursorRow(rows []visualRow) (int, int) {
+	for i, r := range rows {
+		if m.cursor >= r.start && (m.cursor < r.end || (m.cursor == r.end && r.last)) {
+			return i, runewidth.StringWidth(string(m.text[r.start:m.cursor]))
+		}
+	}
+	return len(rows) - 1, 0
+}
+
+// --- completion ---
+
+// wikilinkPrefix returns what was typed after an unclosed `[[` before the
+// cursor on the current line.
+func (m *MarkdownEditor) wikilinkPrefix() (string, bool) {
+	start, _ := m.lineBounds(m.cursor)
+	before := string(m.text[start:m.cursor])
+	i := strings.LastIndex(before, "[[")
+	if i < 0 {
+		return "", false
+	}
+	prefix := before[i+2:]
+	if strings.ContainsAny(prefix, "[]") || utf8.RuneCountInString(prefix) > maxWikilinkQuery {
+		return "", false
+	}
+	return prefix, true
+}
+
+// updateCompletions refreshes the candidates after a key. Leaving the link
+// re-arms completions that Esc dismissed.
+func (m *MarkdownEditor) updateCompletions() {
+	prefix, ok := m.wikilinkPrefix()
+	if !ok || m.complete == nil {
+		m.completions = nil
+		m.dismissed = false
+		return
+	}
+	if m.dismissed {
+		return
+	}
+	found := m.complete(prefix)
+	if len(found) > maxCompletions {
+		found = found[:maxCompletions]
+	}
+	m.completions = found
+	if m.selected >= len(found) {
+		m.selected = 0
+	}
+}
+
+// acceptCompletion replaces the typed prefix with the selected id and
+// closes the link.
+func (m *MarkdownEditor) acceptCompletion() {
+	prefix, ok := m.wikilinkPrefix()
+	if !ok || m.selected >= len(m.completions) {
+		m.completions = nil
+		return
+	}
+	id := m.completions[m.selected].ID
+	m.completions = nil
+	m.selected = 0
+	from := m.cursor - utf8.RuneCountInString(prefix)
+	if strings.HasPrefix(string(m.text[m.cursor:min(m.cursor+2, len(m.text))]), "]]") {
+		m.replace(from, m.cursor, id, editOther)
+		m.moveTo(m.cursor + 2)
+		return
+	}
+	m.replace(from, m.cursor, id+"]]", editOther)
+}
+
+// --- drawing ---
+
+// Draw paints the wrapped, highlighted buffer and, on the bottom row, the
+// completion candidates or the vim mode.
+func (m *MarkdownEditor) Draw(screen tcell.Screen) {
+	m.DrawForSubclass(screen, m)
+	x, y, width, height := m.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	base, active := highlightStyles()
+	if status := m.statusCells(base, active); len(status) > 0 && height > 1 {
+		height--
+		col := x
+		for _, c := range status {
+			if col >= x+width {
+				break
+			}
+			screen.SetContent(col, y+height, c.r, nil, c.style)
+			col += runewidth.RuneWidth(c.r)
+		}
+	}
+	m.width, m.height = width, height
+
+	rows := m.layout()
+	idx, cx := m.cursorRow(rows)
+	if idx < m.offset {
+		m.offset = idx
+	}
+	if idx >= m.offset+height {
+		m.offset = idx - height + 1
+	}
+	styles := m.highlight(base)
+	for i := 0; i < height && m.offset+i < len(rows); i++ {
+		r := rows[m.offset+i]
+		col := x
+		for j := r.start; j < r.end; j++ {
+			ch := m.text[j]
+			rw := runewidth.RuneWidth(ch)
+			if col+rw > x+width {
+				break
+			}
+			if ch == '\t' {
+				ch, rw = ' ', 1
+			}
+			screen.SetContent(col, y+i, ch, nil, styles[j])
+			col += rw
+		}
+	}
+	if m.HasFocus() {
+		screen.ShowCursor(x+min(cx, width-1), y+idx-m.offset)
+	}
+}
+
+type styledCell struct {
+	r     rune
+	style tcell.Style
+}
+
+// statusCells returns the bottom-row contents: the completions with the
+// selected one highlighted, else the vim mode, else nothing.
+func (m *MarkdownEditor) statusCells(base, active tcell.Style) []styledCell {
+	var cells []styledCell
+	add := func(s string, style tcell.Style) {
+		for _, r := range s {
+			cells = append(cells, styledCell{r: r, style: style})
+		}
+	}
+	muted := base.Foreground(theme.Roles().TextMuted().TCell())
+	if len(m.completions) > 0 {
+		id := base.Foreground(theme.Roles().TikiID().TCell())
+		for i, c := range m.completions {
+			st, idStyle := base, id
+			if i == m.selected {
+				st, idStyle = active, active.Foreground(theme.Roles().TikiID().TCell())
+			}
+			add(" ", st)
+			add(c.ID, idStyle)
+			add(" "+c.Title+" ", st)
+			add(" ", base)
+		}
+		return cells
+	}
+	if !m.vim {
+		return nil
+	}
+	mode := "-- INSERT --"
+	if m.normal {
+		mode = "-- NORMAL --"
+		if m.pending != 0 {
+			mode += " " + string(m.pending)
+		}
+	}
+	add(mode, muted)
+	return cells
+}
+
+var wikilinkPattern = regexp.MustCompile(`\[\[[^\[\]\n]+\]\]`)
+
+// highlight returns one style per rune of the buffer, from chroma's markdown
+// lexer mapped onto theme roles, with `[[ID]]` links in the tiki id color.
+// The result is cached until the buffer changes.
+func (m *MarkdownEditor) highlight(base tcell.Style) []tcell.Style {
+	if m.styles != nil && m.stylesVersion == m.version {
+		return m.styles
+	}
+	styles := make([]tcell.Style, len(m.text))
+	for i := range styles {
+		styles[i] = base
+	}
+	text := string(m.text)
+	if lexer := lexers.Get("markdown"); lexer != nil {
+		if it, err := lexer.Tokenise(nil, text); err == nil {
+			i := 0
+			for _, tok := range it.Tokens() {
+				st := markdownTokenStyle(tok.Type, base)
+				for range tok.Value {
+					if i >= len(styles) {
+						break
+					}
+					styles[i] = st
+					i++
+				}
+			}
+		}
+	}
+	id := base.Foreground(theme.Roles().TikiID().TCell())
+	for _, loc := range wikilinkPattern.FindAllStringIndex(text, -1) {
+		from := utf8.RuneCountInString(text[:loc[0]])
+		to := from + utf8.RuneCountInString(text[loc[0]:loc[1]])
+		for i := from; i < to; i++ {
+			styles[i] = id
+		}
+	}
+	m.styles = styles
+	m.stylesVersion = m.version
+	return styles
+}
+
+// markdownTokenStyle maps a chroma markdown token onto the theme roles.
+// Tokens inside fenced code blocks come from the fence's language lexer and
+// share the code color.
+func markdownTokenStyle(tt chroma.TokenType, base tcell.Style) tcell.Style {
+	roles := theme.Roles()
+	switch {
+	case tt == chroma.GenericHeading || tt == chroma.GenericSubheading:
+		return base.Foreground(roles.Highlight().TCell()).Bold(true)
+	case tt == chroma.GenericStrong:
+		return base.Bold(true)
+	case tt == chroma.GenericEmph:
+		return base.Italic(true)
+	case tt == chroma.NameTag:
+		return base.Foreground(roles.AccentAction().TCell()).Underline(true)
+	case tt == chroma.NameAttribute:
+		return base.Foreground(roles.TextMuted().TCell())
+	case tt == chroma.Keyword:
+		return base.Foreground(roles.AccentAction().TCell())
+	case tt.InCategory(chroma.Text) || tt == chroma.Other:
+		return base
+	default:
+		return base.Foreground(roles.AccentTag().TCell())
+	}
+}
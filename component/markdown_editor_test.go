@@ -0,0 +1,167 @@
+package component
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func pressEditor(m *MarkdownEditor, key tcell.Key, r rune, mod tcell.ModMask) {
+	m.InputHandler()(tcell.NewEventKey(key, r, mod), func(p tview.Primitive) {})
+}
+
+func typeEditor(m *MarkdownEditor, s string) {
+	for _, r := range s {
+		if r == '\n' {
+			pressEditor(m, tcell.KeyEnter, 0, tcell.ModNone)
+			continue
+		}
+		pressEditor(m, tcell.KeyRune, r, tcell.ModNone)
+	}
+}
+
+func TestMarkdownEditor_TypingAndEmacsMotions(t *testing.T) {
+	m := NewMarkdownEditor()
+	changes := 0
+	m.SetChangedFunc(func() { changes++ })
+	typeEditor(m, "hello world\nsecond")
+
+	pressEditor(m, tcell.KeyCtrlP, 0, tcell.ModNone) // up to the first line
+	pressEditor(m, tcell.KeyCtrlA, 0, tcell.ModNone)
+	pressEditor(m, tcell.KeyRune, 'f', tcell.ModAlt) // past "hello"
+	pressEditor(m, tcell.KeyCtrlK, 0, tcell.ModNone)
+	if got := m.GetText(); got != "hello\nsecond" {
+		t.Fatalf("after Ctrl-K = %q", got)
+	}
+	pressEditor(m, tcell.KeyCtrlE, 0, tcell.ModNone)
+	pressEditor(m, tcell.KeyCtrlY, 0, tcell.ModNone)
+	if got := m.GetText(); got != "hello world\nsecond" {
+		t.Errorf("after Ctrl-Y = %q", got)
+	}
+	if changes == 0 {
+		t.Error("changed func never called")
+	}
+}
+
+func TestMarkdownEditor_UndoGroupsTypedWords(t *testing.T) {
+	m := NewMarkdownEditor().SetText("")
+	typeEditor(m, "one two")
+
+	pressEditor(m, tcell.KeyCtrlZ, 0, tcell.ModNone)
+	if got := m.GetText(); got != "one " {
+		t.Fatalf("first undo = %q, want %q", got, "one ")
+	}
+	pressEditor(m, tcell.KeyCtrlZ, 0, tcell.ModNone)
+	if got := m.GetText(); got != "" {
+		t.Fatalf("second undo = %q, want empty", got)
+	}
+	pressEditor(m, tcell.KeyRune, '_', tcell.ModAlt)
+	if got := m.GetText(); got != "one " {
+		t.Errorf("redo = %q, want %q", got, "one ")
+	}
+}
+
+func TestMarkdownEditor_SoftWrapMovesByVisualRow(t *testing.T) {
+	m := NewMarkdownEditor().SetText("alpha beta gamma\nend")
+	m.SetRect(0, 0, 8, 5)
+	m.Draw(tcell.NewSimulationScreen(""))
+
+	rows := m.layout()
+	if len(rows) != 4 || string(m.text[rows[1].start:rows[1].end]) != "beta " {
+		t.Fatalf("wrapped rows = %+v", rows)
+	}
+	pressEditor(m, tcell.KeyDown, 0, tcell.ModNone)
+	if m.cursor != rows[1].start {
+		t.Errorf("Down from the first row moved to %d, want the start of the wrapped row %d", m.cursor, rows[1].start)
+	}
+	pressEditor(m, tcell.KeyDown, 0, tcell.ModNone)
+	pressEditor(m, tcell.KeyDown, 0, tcell.ModNone)
+	if got := string(m.text[m.cursor:]); got != "end" {
+		t.Errorf("three rows down lands at %q, want the next line", got)
+	}
+}
+
+func TestMarkdownEditor_VimNormalMode(t *testing.T) {
+	m := NewMarkdownEditor().SetVimKeys(true)
+	typeEditor(m, "first line\nsecond line")
+	pressEditor(m, tcell.KeyEscape, 0, tcell.ModNone)
+	if !m.InNormalMode() {
+		t.Fatal("Esc in insert mode should enter normal mode")
+	}
+	if m.CapturesKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)) {
+		t.Error("Esc in normal mode should be left to the edit mode (cancel)")
+	}
+
+	typeEditor(m, "ggdd")
+	if got := m.GetText(); got != "second line" {
+		t.Fatalf("gg dd = %q", got)
+	}
+	typeEditor(m, "p")
+	if got := m.GetText(); got != "second line\nfirst line" {
+		t.Fatalf("p after dd = %q", got)
+	}
+	typeEditor(m, "u")
+	if got := m.GetText(); got != "second line" {
+		t.Fatalf("u = %q", got)
+	}
+	typeEditor(m, "wcwpage")
+	if got := m.GetText(); got != "second page" {
+		t.Errorf("w cw = %q", got)
+	}
+	if m.InNormalMode() {
+		t.Error("cw should leave the editor in insert mode")
+	}
+}
+
+func TestMarkdownEditor_WikilinkCompletion(t *testing.T) {
+	m := NewMarkdownEditor()
+	var asked []string
+	m.SetCompleteFunc(func(prefix string) []Completion {
+		asked = append(asked, prefix)
+		return []Completion{{ID: "ABC123", Title: "Login"}, {ID: "ABD456", Title: "Logout"}}
+	})
+	typeEditor(m, "see [[AB")
+	if len(m.Completions()) != 2 || asked[len(asked)-1] != "AB" {
+		t.Fatalf("completions = %+v after asking %q", m.Completions(), asked)
+	}
+	if !m.CapturesKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)) {
+		t.Error("Tab should pick a completion while the list is open")
+	}
+	pressEditor(m, tcell.KeyDown, 0, tcell.ModNone)
+	pressEditor(m, tcell.KeyTab, 0, tcell.ModNone)
+	if got := m.GetText(); got != "see [[ABD456]]" {
+		t.Errorf("accepted completion = %q", got)
+	}
+	if len(m.Completions()) != 0 {
+		t.Error("completions should close after accepting")
+	}
+	if m.CapturesKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)) {
+		t.Error("Tab should move to the next field once completions are closed")
+	}
+
+	typeEditor(m, " [[A")
+	pressEditor(m, tcell.KeyEscape, 0, tcell.ModNone)
+	typeEditor(m, "B")
+	if len(m.Completions()) != 0 {
+		t.Error("dismissed completions reopened while still typing the same link")
+	}
+}
+
+func TestMarkdownEditor_HighlightsMarkdown(t *testing.T) {
+	m := NewMarkdownEditor().SetText("# Title\nplain [[ABC123]]")
+	base, _ := highlightStyles()
+	styles := m.highlight(base)
+	if styles[0] == base {
+		t.Error("heading not highlighted")
+	}
+	plain := strings.Index(m.GetText(), "plain")
+	if styles[plain] != base {
+		t.Error("plain text should use the base style")
+	}
+	link := strings.Index(m.GetText(), "[[")
+	if styles[link] == base {
+		t.Error("wikilink not highlighted")
+	}
+}
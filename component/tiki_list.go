@@ -7,6 +7,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/theme"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
 	"github.com/boolean-maybe/tiki/util"
@@ -41,8 +42,9 @@ func DefaultTikiRowColors() TikiRowColors {
 // ordinary enum field — workflows that want a glyph can use the enum
 // value's emoji metadata.
 func RenderTikiRow(tk *tikipkg.Tiki, selected bool, width int, idColumnWidth int, colors TikiRowColors) string {
-	idText := colors.IDPaint.PaintString(tk.ID())
-	if padding := idColumnWidth - len(tk.ID()); padding > 0 {
+	id := store.DisplayID(tk)
+	idText := colors.IDPaint.PaintString(id)
+	if padding := idColumnWidth - len(id); padding > 0 {
 		idText += fmt.Sprintf("%*s", padding, "")
 	}
 
@@ -67,9 +69,7 @@ func RenderTikiRow(tk *tikipkg.Tiki, selected bool, width int, idColumnWidth int
 func ComputeIDColumnWidth(tikis []*tikipkg.Tiki) int {
 	w := 0
 	for _, tk := range tikis {
-		if len(tk.ID()) > w {
-			w = len(tk.ID())
-		}
+		w = max(w, len(store.DisplayID(tk)))
 	}
 	return w
 }
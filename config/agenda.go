@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/boolean-maybe/tiki/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// agendaYAML represents the workflow.yaml agenda: section.
+type agendaYAML struct {
+	Due        []string `yaml:"due,omitempty"`
+	Recurrence string   `yaml:"recurrence,omitempty"`
+	Assignee   string   `yaml:"assignee,omitempty"`
+}
+
+// agendaFileData is the minimal YAML structure for reading the agenda
+// section from workflow.yaml.
+type agendaFileData struct {
+	Agenda *agendaYAML `yaml:"agenda"`
+}
+
+// AgendaConfig says which tikis have deadlines. DueFields are the date
+// fields whose values are deadlines, in priority order; RecurrenceField,
+// when set, names the recurrence whose upcoming occurrences are listed too.
+// AssigneeField is the user field `--user` and the overdue stat match, and a
+// tiki the workflow's done: section calls finished is never overdue. Without
+// that section DoneStatus is empty and no tiki is finished.
+type AgendaConfig struct {
+	DueFields       []string
+	RecurrenceField string
+	AssigneeField   string
+	DoneStatus
+}
+
+var (
+	agendaMu     sync.RWMutex
+	loadedAgenda *AgendaConfig
+)
+
+// WorkflowAgenda returns the agenda settings loaded alongside the workflow
+// field catalog. ok is false when the workflow has no due field.
+func WorkflowAgenda() (AgendaConfig, bool) {
+	agendaMu.RLock()
+	defer agendaMu.RUnlock()
+	if loadedAgenda == nil {
+		return AgendaConfig{}, false
+	}
+	c := *loadedAgenda
+	c.DueFields = append([]string(nil), loadedAgenda.DueFields...)
+	c.Done = append([]string(nil), loadedAgenda.Done...)
+	return c, true
+}
+
+// setWorkflowAgenda replaces the loaded agenda settings.
+func setWorkflowAgenda(c *AgendaConfig) {
+	agendaMu.Lock()
+	defer agendaMu.Unlock()
+	loadedAgenda = c
+}
+
+// ResetWorkflowAgendaForTest replaces the loaded agenda settings (nil
+// clears them). Intended for tests only.
+func ResetWorkflowAgendaForTest(c *AgendaConfig) {
+	setWorkflowAgenda(c)
+}
+
+// DefaultAgenda derives the agenda settings of a workflow without an
+// agenda: section: a date field called due, the recurrence and assignee
+// fields when declared with those names, and the terminal statuses of the
+// done section. Returns nil when there is no due date field.
+func DefaultAgenda(fields []workflow.FieldDef, done *DoneStatus) *AgendaConfig {
+	byName := make(map[string]workflow.FieldDef, len(fields))
+	for _, fd := range fields {
+		byName[fd.Name] = fd
+	}
+	if fd, ok := byName["due"]; !ok || !isDueType(fd.Type) {
+		return nil
+	}
+	c := &AgendaConfig{DueFields: []string{"due"}}
+	if fd, ok := byName["recurrence"]; ok && fd.Type == workflow.TypeRecurrence {
+		c.RecurrenceField = fd.Name
+	}
+	if fd, ok := byName["assignee"]; ok && fd.Type == workflow.TypeUser {
+		c.AssigneeField = fd.Name
+	}
+	if done != nil {
+		c.DoneStatus = *done
+	}
+	return c
+}
+
+// isDueType reports whether a field of type t can hold a deadline.
+func isDueType(t workflow.ValueType) bool {
+	return t == workflow.TypeDate || t == workflow.TypeTimestamp
+}
+
+// LoadAgendaFromFile reads and validates the agenda: section of an explicit
+// workflow file against the given field catalog, without touching global
+// state. Returns nil when the section is absent.
+func LoadAgendaFromFile(path string, fields []workflow.FieldDef) (*AgendaConfig, error) {
+	c, err := readAgendaFromFile(path, fields)
+	if err != nil {
+		return nil, fmt.Errorf("reading agenda from %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// readAgendaFromFile reads a workflow.yaml and returns its validated agenda
+// section.
+func readAgendaFromFile(path string, fields []workflow.FieldDef) (*AgendaConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var af agendaFileData
+	if err := yaml.Unmarshal(data, &af); err != nil {
+		return nil, fmt.Errorf("parsing agenda: %w", err)
+	}
+	if af.Agenda == nil {
+		return nil, nil
+	}
+	done, err := readDoneFromFile(path, fields)
+	if err != nil {
+		return nil, err
+	}
+	return convertAgenda(*af.Agenda, done, fields)
+}
+
+// convertAgenda validates the section against the field catalog. due
+// defaults to [due]; recurrence and assignee default to the fields of those
+// names when the catalog declares them with a fitting type. done is the
+// optional done section.
+func convertAgenda(raw agendaYAML, done *DoneStatus, fields []workflow.FieldDef) (*AgendaConfig, error) {
+	byName := make(map[string]workflow.FieldDef, len(fields))
+	for _, fd := range fields {
+		byName[fd.Name] = fd
+	}
+
+	c := &AgendaConfig{
+		RecurrenceField: strings.TrimSpace(raw.Recurrence),
+		AssigneeField:   strings.TrimSpace(raw.Assignee),
+	}
+	if done != nil {
+		c.DoneStatus = *done
+	}
+	due := raw.Due
+	if len(due) == 0 {
+		due = []string{"due"}
+	}
+	seen := make(map[string]bool, len(due))
+	for _, name := range due {
+		name = strings.TrimSpace(name)
+		fd, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("due: unknown field %q", name)
+		}
+		if !isDueType(fd.Type) {
+			return nil, fmt.Errorf("due: %q must be a date or datetime", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("due: %q is listed twice", name)
+		}
+		seen[name] = true
+		c.DueFields = append(c.DueFields, name)
+	}
+
+	if c.RecurrenceField == "" {
+		if fd, ok := byName["recurrence"]; ok && fd.Type == workflow.TypeRecurrence {
+			c.RecurrenceField = fd.Name
+		}
+	} else if fd, ok := byName[c.RecurrenceField]; !ok {
+		return nil, fmt.Errorf("recurrence: unknown field %q", c.RecurrenceField)
+	} else if fd.Type != workflow.TypeRecurrence {
+		return nil, fmt.Errorf("recurrence: %q must be a recurrence", c.RecurrenceField)
+	}
+
+	if c.AssigneeField == "" {
+		if fd, ok := byName["assignee"]; ok && fd.Type == workflow.TypeUser {
+			c.AssigneeField = fd.Name
+		}
+	} else if fd, ok := byName[c.AssigneeField]; !ok {
+		return nil, fmt.Errorf("assignee: unknown field %q", c.AssigneeField)
+	} else if fd.Type != workflow.TypeUser && fd.Type != workflow.TypeString {
+		return nil, fmt.Errorf("assignee: %q must be a user or text field", c.AssigneeField)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func TestReadAgendaFromFile_Absent(t *testing.T) {
+	c, err := readAgendaFromFile(writeTemplatesWorkflow(t, "views: []\n"), workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c != nil {
+		t.Fatalf("expected no agenda config, got %+v", c)
+	}
+}
+
+func TestReadAgendaFromFile_Defaults(t *testing.T) {
+	yaml := "done:\n  values: [done]\nagenda:\n  due: [dueBy, due]\n"
+	c, err := readAgendaFromFile(writeTemplatesWorkflow(t, yaml), workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(c.DueFields, []string{"dueBy", "due"}) {
+		t.Errorf("due fields = %v, want the declared order", c.DueFields)
+	}
+	if c.RecurrenceField != "recurrence" || c.AssigneeField != "assignee" || c.StatusField != "status" {
+		t.Errorf("defaults = %q/%q/%q, want recurrence/assignee/status", c.RecurrenceField, c.AssigneeField, c.StatusField)
+	}
+	if !c.IsDone("Done") || c.IsDone("open") {
+		t.Errorf("IsDone does not follow done: %v", c.Done)
+	}
+}
+
+func TestReadAgendaFromFile_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"unknown due field", "agenda:\n  due: [deadline]\n", `unknown field "deadline"`},
+		{"due not a date", "agenda:\n  due: [tags]\n", "must be a date or datetime"},
+		{"due twice", "agenda:\n  due: [due, due]\n", "listed twice"},
+		{"recurrence not a recurrence", "agenda:\n  recurrence: due\n", "must be a recurrence"},
+		{"assignee not a user", "agenda:\n  assignee: tags\n", "must be a user or text field"},
+		{"unknown done value", "done:\n  values: [closed]\nagenda: {}\n", "is not a status value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readAgendaFromFile(writeTemplatesWorkflow(t, tt.yaml), workflowTestFields())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultAgenda(t *testing.T) {
+	c := DefaultAgenda(workflowTestFields(), &DoneStatus{StatusField: "status", Done: []string{"done"}})
+	if c == nil {
+		t.Fatal("a workflow with a due date field should get an agenda")
+	}
+	if !slices.Equal(c.DueFields, []string{"due"}) || c.RecurrenceField != "recurrence" || c.AssigneeField != "assignee" {
+		t.Errorf("default agenda = %+v", c)
+	}
+	if !c.IsDone("done") {
+		t.Errorf("done statuses should come from the done section, got %v", c.Done)
+	}
+
+	noDue := []workflow.FieldDef{{Name: "due", Type: workflow.TypeString, Custom: true}}
+	if c := DefaultAgenda(noDue, nil); c != nil {
+		t.Errorf("a text due field is not a deadline, got %+v", c)
+	}
+}
@@ -1,12 +1,83 @@
 package config
 
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Prompt delivery modes for an AI tool: the context prompt is passed after a
+// flag, as the last positional argument, on stdin, or in an environment
+// variable.
+const (
+	PromptViaFlag       = "flag"
+	PromptViaPositional = "positional"
+	PromptViaStdin      = "stdin"
+	PromptViaEnv        = "env"
+)
+
+// DefaultPromptEnv is the variable that carries the prompt in env mode when
+// promptEnv: is omitted.
+const DefaultPromptEnv = "TIKI_PROMPT"
+
+// DefaultAIContext is the context prompt of tools that do not declare their
+// own. See AgentContext for the placeholders.
+const DefaultAIContext = "Read the tiki at {path} first. " +
+	"This is the tiki the user is currently viewing. " +
+	"When the user asks to modify something without specifying a file, " +
+	"they mean this tiki. " +
+	"After reading, chat with the user about it."
+
 // AITool defines a supported AI coding assistant.
-// To add a new tool, add an entry to the aiTools slice below.
+// Built-in tools are listed in the aiTools slice below; `ai.agents:` in
+// config.yaml adds more, or replaces a built-in of the same key.
 // NOTE: the action palette (press Ctrl+A) surfaces available actions; update docs if tool names change.
 type AITool struct {
-	Key        string // config identifier: "claude", "gemini", "codex", "opencode"
-	Command    string // CLI binary name
-	PromptFlag string // flag preceding the prompt arg, or "" for positional
+	Key        string   // config identifier: "claude", "gemini", "codex", "opencode"
+	Command    string   // CLI binary name
+	PromptFlag string   // flag preceding the prompt arg, or "" for positional
+	Args       []string // arguments before the prompt; may use AgentContext placeholders
+	PromptMode string   // one of the PromptVia* modes; "" infers flag or positional from PromptFlag
+	PromptEnv  string   // variable for PromptViaEnv; "" means DefaultPromptEnv
+	Env        []string // extra KEY=VALUE entries; values may use placeholders and $VAR
+	Context    string   // context prompt template; "" means DefaultAIContext
+	Print      []string // arguments, after Args, that make the tool answer once and exit
+	PrintFlag  string   // flag preceding the prompt in print mode, or "" for positional
+}
+
+// AIAgentConfig is one entry of the `ai.agents:` map in config.yaml. Env is
+// a KEY=VALUE list rather than a map because config keys are
+// case-insensitive and would lose the case of variable names.
+type AIAgentConfig struct {
+	Command    string   `mapstructure:"command"`
+	Args       []string `mapstructure:"args"`
+	Prompt     string   `mapstructure:"prompt"`
+	PromptFlag string   `mapstructure:"promptFlag"`
+	PromptEnv  string   `mapstructure:"promptEnv"`
+	Env        []string `mapstructure:"env"`
+	Context    string   `mapstructure:"context"`
+	Print      []string `mapstructure:"print"`
+}
+
+// AgentContext is what an agent is told about the tiki it is started on.
+// Templates refer to the fields as {path}, {id}, {title} and {workflow}
+// (the path of the active workflow.yaml).
+type AgentContext struct {
+	Path     string
+	ID       string
+	Title    string
+	Workflow string
+}
+
+// AgentInvocation is a fully resolved agent command line.
+type AgentInvocation struct {
+	Command string
+	Args    []string
+	Env     []string // added to the inherited environment
+	Stdin   string   // written to the agent's stdin when non-empty
 }
 
 // aiTools is the single source of truth for all supported AI tools.
@@ -15,21 +86,25 @@ var aiTools = []AITool{
 		Key:        "claude",
 		Command:    "claude",
 		PromptFlag: "--append-system-prompt",
+		Print:      []string{"-p"},
 	},
 	{
 		Key:        "gemini",
 		Command:    "gemini",
 		PromptFlag: "-i",
+		Print:      []string{"-p"},
 	},
 	{
 		Key:        "codex",
 		Command:    "codex",
 		PromptFlag: "",
+		Print:      []string{"exec"},
 	},
 	{
 		Key:        "opencode",
 		Command:    "opencode",
 		PromptFlag: "--prompt",
+		Print:      []string{"run"},
 	},
 }
 
@@ -42,13 +117,99 @@ func (t AITool) PromptArgs(prompt string) []string {
 	return []string{prompt}
 }
 
-// AITools returns all supported AI tools.
+// promptMode returns the effective prompt delivery mode.
+func (t AITool) promptMode() string {
+	if t.PromptMode != "" {
+		return t.PromptMode
+	}
+	if t.PromptFlag != "" {
+		return PromptViaFlag
+	}
+	return PromptViaPositional
+}
+
+// ContextPrompt renders the tool's context prompt for ctx.
+func (t AITool) ContextPrompt(ctx AgentContext) string {
+	tmpl := t.Context
+	if tmpl == "" {
+		tmpl = DefaultAIContext
+	}
+	return ctx.expand(tmpl)
+}
+
+// Invocation builds the command line that starts the tool on ctx with the
+// given prompt.
+func (t AITool) Invocation(ctx AgentContext, prompt string) AgentInvocation {
+	inv := AgentInvocation{Command: t.Command}
+	for _, a := range t.Args {
+		inv.Args = append(inv.Args, ctx.expand(a))
+	}
+	// expand $VAR in the configured template only: a tiki title or path is
+	// substituted afterwards, so a `$` in it stays literal
+	for _, e := range t.Env {
+		inv.Env = append(inv.Env, ctx.expand(os.ExpandEnv(e)))
+	}
+	switch t.promptMode() {
+	case PromptViaStdin:
+		inv.Stdin = prompt
+	case PromptViaEnv:
+		name := t.PromptEnv
+		if name == "" {
+			name = DefaultPromptEnv
+		}
+		inv.Env = append(inv.Env, name+"="+prompt)
+	default:
+		inv.Args = append(inv.Args, t.PromptArgs(prompt)...)
+	}
+	return inv
+}
+
+// PrintInvocation builds the command line that runs the tool
+// non-interactively: it answers prompt on stdout and exits. Used by
+// `kind: propose` actions.
+func (t AITool) PrintInvocation(ctx AgentContext, prompt string) AgentInvocation {
+	headless := t
+	headless.Args = append(append([]string(nil), t.Args...), t.Print...)
+	headless.PromptFlag = t.PrintFlag
+	return headless.Invocation(ctx, prompt)
+}
+
+func (ctx AgentContext) expand(tmpl string) string {
+	return strings.NewReplacer(
+		"{path}", ctx.Path,
+		"{id}", ctx.ID,
+		"{title}", ctx.Title,
+		"{workflow}", ctx.Workflow,
+	).Replace(tmpl)
+}
+
+// AITools returns all supported AI tools: the built-ins followed by the
+// agents declared in config.yaml, sorted by key.
 func AITools() []AITool {
-	return aiTools
+	agents := configuredAgents()
+	tools := make([]AITool, 0, len(aiTools)+len(agents))
+	for _, t := range aiTools {
+		if _, overridden := agents[t.Key]; !overridden {
+			tools = append(tools, t)
+		}
+	}
+	keys := make([]string, 0, len(agents))
+	for k := range agents {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		tools = append(tools, agents[k])
+	}
+	return tools
 }
 
-// LookupAITool finds a tool by its config key. Returns false if not found.
+// LookupAITool finds a tool by its config key. Agents declared in
+// config.yaml take precedence over built-ins. Returns false if not found.
 func LookupAITool(key string) (AITool, bool) {
+	if t, ok := configuredAgents()[strings.ToLower(key)]; ok {
+		return t, true
+	}
 	for _, t := range aiTools {
 		if t.Key == key {
 			return t, true
@@ -56,3 +217,76 @@ func LookupAITool(key string) (AITool, bool) {
 	}
 	return AITool{}, false
 }
+
+// configuredAgents reads `ai.agents:`. Entries that fail ValidateAIAgents
+// are skipped here; startup reports them.
+func configuredAgents() map[string]AITool {
+	var raw map[string]AIAgentConfig
+	if err := viper.UnmarshalKey("ai.agents", &raw); err != nil || len(raw) == 0 {
+		return nil
+	}
+	out := make(map[string]AITool, len(raw))
+	for key, cfg := range raw {
+		if cfg.validate() != nil {
+			continue
+		}
+		out[strings.ToLower(key)] = cfg.tool(strings.ToLower(key))
+	}
+	return out
+}
+
+func (c AIAgentConfig) tool(key string) AITool {
+	return AITool{
+		Key:        key,
+		Command:    os.ExpandEnv(c.Command),
+		PromptFlag: c.PromptFlag,
+		Args:       c.Args,
+		PromptMode: strings.ToLower(c.Prompt),
+		PromptEnv:  c.PromptEnv,
+		Env:        c.Env,
+		Context:    c.Context,
+		Print:      c.Print,
+		PrintFlag:  c.PromptFlag,
+	}
+}
+
+func (c AIAgentConfig) validate() error {
+	if strings.TrimSpace(c.Command) == "" {
+		return fmt.Errorf("command is required")
+	}
+	switch strings.ToLower(c.Prompt) {
+	case "", PromptViaPositional, PromptViaStdin:
+	case PromptViaFlag:
+		if c.PromptFlag == "" {
+			return fmt.Errorf("prompt: flag needs promptFlag")
+		}
+	case PromptViaEnv:
+	default:
+		return fmt.Errorf("unknown prompt mode %q (valid: flag, positional, stdin, env)", c.Prompt)
+	}
+	for _, e := range c.Env {
+		if name, _, ok := strings.Cut(e, "="); !ok || name == "" {
+			return fmt.Errorf("env entry %q must be KEY=VALUE", e)
+		}
+	}
+	return nil
+}
+
+// ValidateAIAgents checks the `ai.agents:` section of config.yaml.
+func ValidateAIAgents() error {
+	var raw map[string]AIAgentConfig
+	if err := viper.UnmarshalKey("ai.agents", &raw); err != nil {
+		return fmt.Errorf("ai.agents: %w", err)
+	}
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := raw[k].validate(); err != nil {
+			return fmt.Errorf("ai.agents.%s: %w", k, err)
+		}
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestAITools_ReturnsAllTools(t *testing.T) {
@@ -63,3 +66,144 @@ func TestAITool_PromptArgs_Positional(t *testing.T) {
 		t.Errorf("expected prompt 'hello', got %q", args[0])
 	}
 }
+
+func setTestAgents(t *testing.T, agents map[string]interface{}) {
+	t.Helper()
+	viper.Set("ai.agents", agents)
+	t.Cleanup(func() { viper.Set("ai.agents", nil) })
+}
+
+func TestAIAgents_Configured(t *testing.T) {
+	setTestAgents(t, map[string]interface{}{
+		"LLM": map[string]interface{}{
+			"command":    "llm-wrapper",
+			"args":       []interface{}{"chat", "--project", "{workflow}"},
+			"prompt":     "flag",
+			"promptFlag": "--system",
+			"env":        []interface{}{"LLM_TIKI={id}"},
+			"context":    "Work on {id} ({title}) in {path}.",
+		},
+		"claude": map[string]interface{}{"command": "claude-proxy"},
+	})
+
+	tools := AITools()
+	if len(tools) != 5 || tools[len(tools)-1].Key != "llm" {
+		t.Fatalf("tools = %+v, want 3 built-ins then claude and llm", tools)
+	}
+	if claude, _ := LookupAITool("claude"); claude.Command != "claude-proxy" {
+		t.Errorf("claude command = %q, want the configured override", claude.Command)
+	}
+
+	tool, ok := LookupAITool("llm")
+	if !ok {
+		t.Fatal("expected to find llm (agent names are case-insensitive)")
+	}
+	ctx := AgentContext{Path: "/w/.doc/ABC123.md", ID: "ABC123", Title: "Fix login", Workflow: "/w/workflow.yaml"}
+	inv := tool.Invocation(ctx, tool.ContextPrompt(ctx))
+	want := []string{"chat", "--project", "/w/workflow.yaml", "--system", "Work on ABC123 (Fix login) in /w/.doc/ABC123.md."}
+	if inv.Command != "llm-wrapper" || strings.Join(inv.Args, "|") != strings.Join(want, "|") {
+		t.Errorf("invocation = %q %q, want llm-wrapper %q", inv.Command, inv.Args, want)
+	}
+	if len(inv.Env) != 1 || inv.Env[0] != "LLM_TIKI=ABC123" || inv.Stdin != "" {
+		t.Errorf("env = %q, stdin = %q", inv.Env, inv.Stdin)
+	}
+}
+
+func TestAITool_EnvExpandsVarsBeforePlaceholders(t *testing.T) {
+	t.Setenv("TIKI_TEST_PROFILE", "work")
+	t.Setenv("TIKI_TEST_SECRET", "hunter2")
+	tool := AITool{Command: "a", Env: []string{"PROFILE=$TIKI_TEST_PROFILE", "TASK={title}"}}
+	ctx := AgentContext{ID: "ABC123", Title: "Leak $TIKI_TEST_SECRET"}
+	got := strings.Join(tool.Invocation(ctx, "P").Env, "|")
+	if want := "PROFILE=work|TASK=Leak $TIKI_TEST_SECRET"; got != want {
+		t.Errorf("env = %q, want %q with the title left unexpanded", got, want)
+	}
+}
+
+func TestAITool_PromptModes(t *testing.T) {
+	ctx := AgentContext{Path: "/p.md"}
+	tests := []struct {
+		tool      AITool
+		wantArgs  string
+		wantEnv   string
+		wantStdin string
+	}{
+		{tool: AITool{Command: "a", Args: []string{"run"}}, wantArgs: "run|P"},
+		{tool: AITool{Command: "a", PromptFlag: "-p"}, wantArgs: "-p|P"},
+		{tool: AITool{Command: "a", PromptMode: PromptViaStdin}, wantStdin: "P"},
+		{tool: AITool{Command: "a", PromptMode: PromptViaEnv}, wantEnv: "TIKI_PROMPT=P"},
+		{tool: AITool{Command: "a", PromptMode: PromptViaEnv, PromptEnv: "MY_PROMPT"}, wantEnv: "MY_PROMPT=P"},
+	}
+	for _, tt := range tests {
+		inv := tt.tool.Invocation(ctx, "P")
+		if got := strings.Join(inv.Args, "|"); got != tt.wantArgs {
+			t.Errorf("%+v args = %q, want %q", tt.tool, got, tt.wantArgs)
+		}
+		if got := strings.Join(inv.Env, "|"); got != tt.wantEnv {
+			t.Errorf("%+v env = %q, want %q", tt.tool, got, tt.wantEnv)
+		}
+		if inv.Stdin != tt.wantStdin {
+			t.Errorf("%+v stdin = %q, want %q", tt.tool, inv.Stdin, tt.wantStdin)
+		}
+	}
+
+	if got := (AITool{}).ContextPrompt(ctx); !strings.Contains(got, "/p.md") {
+		t.Errorf("default context = %q, want the tiki path", got)
+	}
+}
+
+func TestAITool_PrintInvocation(t *testing.T) {
+	ctx := AgentContext{Path: "/p.md"}
+	for key, want := range map[string]string{
+		"claude":   "-p|P",
+		"gemini":   "-p|P",
+		"codex":    "exec|P",
+		"opencode": "run|P",
+	} {
+		tool, _ := LookupAITool(key)
+		if got := strings.Join(tool.PrintInvocation(ctx, "P").Args, "|"); got != want {
+			t.Errorf("%s print args = %q, want %q", key, got, want)
+		}
+	}
+
+	setTestAgents(t, map[string]interface{}{
+		"fake": map[string]interface{}{
+			"command":    "fake-agent",
+			"args":       []interface{}{"--project", "{path}"},
+			"print":      []interface{}{"--once"},
+			"promptFlag": "--ask",
+		},
+	})
+	tool, _ := LookupAITool("fake")
+	want := "--project|/p.md|--once|--ask|P"
+	if got := strings.Join(tool.PrintInvocation(ctx, "P").Args, "|"); got != want {
+		t.Errorf("configured print args = %q, want %q", got, want)
+	}
+}
+
+func TestValidateAIAgents(t *testing.T) {
+	tests := []struct {
+		agent map[string]interface{}
+		want  string
+	}{
+		{map[string]interface{}{"args": []interface{}{"x"}}, "command is required"},
+		{map[string]interface{}{"command": "x", "prompt": "pipe"}, `unknown prompt mode "pipe"`},
+		{map[string]interface{}{"command": "x", "prompt": "flag"}, "needs promptFlag"},
+		{map[string]interface{}{"command": "x", "env": []interface{}{"NOVALUE"}}, "KEY=VALUE"},
+	}
+	for _, tt := range tests {
+		setTestAgents(t, map[string]interface{}{"bad": tt.agent})
+		err := ValidateAIAgents()
+		if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), "ai.agents.bad") {
+			t.Errorf("agent %v: err = %v, want containing %q", tt.agent, err, tt.want)
+		}
+		if _, ok := LookupAITool("bad"); ok {
+			t.Errorf("invalid agent %v should not resolve", tt.agent)
+		}
+	}
+
+	setTestAgents(t, map[string]interface{}{"ok": map[string]interface{}{"command": "x", "prompt": "stdin"}})
+	if err := ValidateAIAgents(); err != nil {
+		t.Errorf("valid agent: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/boolean-maybe/tiki/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// dependenciesYAML represents the workflow.yaml dependencies: section.
+type dependenciesYAML struct {
+	Field string `yaml:"field,omitempty"`
+}
+
+// dependenciesFileData is the minimal YAML structure for reading the
+// dependencies section from workflow.yaml.
+type dependenciesFileData struct {
+	Dependencies *dependenciesYAML `yaml:"dependencies"`
+}
+
+// DependencyConfig is the validated dependencies: section. Field is the
+// tikiIdList field listing a tiki's dependencies; a dependency is finished
+// when the workflow's done: section says its status is terminal.
+type DependencyConfig struct {
+	Field string
+	DoneStatus
+}
+
+var (
+	dependenciesMu     sync.RWMutex
+	loadedDependencies *DependencyConfig
+)
+
+// WorkflowDependencies returns the dependencies section loaded alongside the
+// workflow field catalog. ok is false when the workflow declares none.
+func WorkflowDependencies() (DependencyConfig, bool) {
+	dependenciesMu.RLock()
+	defer dependenciesMu.RUnlock()
+	if loadedDependencies == nil {
+		return DependencyConfig{}, false
+	}
+	c := *loadedDependencies
+	c.Done = append([]string(nil), loadedDependencies.Done...)
+	return c, true
+}
+
+// setWorkflowDependencies replaces the loaded dependencies section and
+// enables or disables the derived dependency fields to match.
+func setWorkflowDependencies(c *DependencyConfig) error {
+	dependenciesMu.Lock()
+	defer dependenciesMu.Unlock()
+	if c == nil {
+		workflow.DisableDerivedFields()
+		loadedDependencies = nil
+		return nil
+	}
+	if err := workflow.EnableDerivedFields(); err != nil {
+		return err
+	}
+	loadedDependencies = c
+	return nil
+}
+
+// ResetWorkflowDependenciesForTest replaces the loaded dependencies section
+// (nil clears it). Intended for tests only.
+func ResetWorkflowDependenciesForTest(c *DependencyConfig) {
+	if err := setWorkflowDependencies(c); err != nil {
+		panic(fmt.Sprintf("ResetWorkflowDependenciesForTest: %v", err))
+	}
+}
+
+// LoadDependenciesFromFile reads and validates the dependencies: section of
+// an explicit workflow file against the given field catalog, without touching
+// global state. Returns nil when the section is absent.
+func LoadDependenciesFromFile(path string, fields []workflow.FieldDef) (*DependencyConfig, error) {
+	c, err := readDependenciesFromFile(path, fields)
+	if err != nil {
+		return nil, fmt.Errorf("reading dependencies from %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// readDependenciesFromFile reads a workflow.yaml and returns its validated
+// dependencies section.
+func readDependenciesFromFile(path string, fields []workflow.FieldDef) (*DependencyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var df dependenciesFileData
+	if err := yaml.Unmarshal(data, &df); err != nil {
+		return nil, fmt.Errorf("parsing dependencies: %w", err)
+	}
+	if df.Dependencies == nil {
+		return nil, nil
+	}
+	done, err := requireDone(path, fields)
+	if err != nil {
+		return nil, err
+	}
+	return convertDependencies(*df.Dependencies, done, fields)
+}
+
+// convertDependencies validates the section against the field catalog.
+// field defaults to dependsOn.
+func convertDependencies(raw dependenciesYAML, done DoneStatus, fields []workflow.FieldDef) (*DependencyConfig, error) {
+	byName := make(map[string]workflow.FieldDef, len(fields))
+	for _, fd := range fields {
+		byName[fd.Name] = fd
+		if workflow.IsDerivedFieldName(fd.Name) {
+			return nil, fmt.Errorf("field %q is reserved for the derived dependency fields (%s)",
+				fd.Name, strings.Join(workflow.DerivedFieldNames(), ", "))
+		}
+	}
+
+	c := &DependencyConfig{Field: strings.TrimSpace(raw.Field), DoneStatus: done}
+	if c.Field == "" {
+		c.Field = "dependsOn"
+	}
+
+	depField, ok := byName[c.Field]
+	if !ok {
+		return nil, fmt.Errorf("field: unknown field %q", c.Field)
+	}
+	if depField.Type != workflow.TypeListRef {
+		return nil, fmt.Errorf("field: %q must be a tikiIdList", c.Field)
+	}
+	return c, nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func TestReadDependenciesFromFile_Absent(t *testing.T) {
+	path := writeTemplatesWorkflow(t, "views: []\n")
+	c, err := readDependenciesFromFile(path, workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c != nil {
+		t.Fatalf("expected no dependencies config, got %+v", c)
+	}
+}
+
+func TestReadDependenciesFromFile_DefaultsAndDone(t *testing.T) {
+	path := writeTemplatesWorkflow(t, "done:\n  values: [done, wontFix]\ndependencies: {}\n")
+	c, err := readDependenciesFromFile(path, workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Field != "dependsOn" || c.StatusField != "status" {
+		t.Errorf("defaults = %q/%q, want dependsOn/status", c.Field, c.StatusField)
+	}
+	if !c.IsDone("done") || !c.IsDone("wontFix") || c.IsDone("open") {
+		t.Errorf("IsDone wrong for %v", c.Done)
+	}
+}
+
+func TestReadDependenciesFromFile_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		fields  []workflow.FieldDef
+		wantErr string
+	}{
+		{"no done section", "dependencies:\n  field: dependsOn\n", nil, "top-level done: section"},
+		{"unknown done value", "done:\n  values: [closed]\ndependencies: {}\n", nil, `"closed" is not a status value`},
+		{"field not a tikiIdList", "done:\n  values: [done]\ndependencies:\n  field: tags\n", nil, "must be a tikiIdList"},
+		{"unknown field", "done:\n  values: [done]\ndependencies:\n  field: blockedBy\n", nil, "unknown field"},
+		{"derived name taken", "done:\n  values: [done]\ndependencies: {}\n",
+			append(workflowTestFields(), workflow.FieldDef{Name: "blocked", Type: workflow.TypeBool, Custom: true}),
+			"reserved for the derived dependency fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := tt.fields
+			if fields == nil {
+				fields = workflowTestFields()
+			}
+			_, err := readDependenciesFromFile(writeTemplatesWorkflow(t, tt.yaml), fields)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetWorkflowDependencies_TogglesDerivedFields(t *testing.T) {
+	ResetWorkflowFieldsForTest(workflowTestFields())
+	t.Cleanup(ClearWorkflowFields)
+
+	ResetWorkflowDependenciesForTest(&DependencyConfig{Field: "dependsOn", DoneStatus: DoneStatus{StatusField: "status", Done: []string{"done"}}})
+	if fd, ok := workflow.Field(workflow.FieldBlocked); !ok || !fd.Derived {
+		t.Fatalf("blocked not registered as a derived field: %+v %v", fd, ok)
+	}
+	if _, ok := WorkflowDependencies(); !ok {
+		t.Fatal("WorkflowDependencies() reported no config after set")
+	}
+
+	ResetWorkflowDependenciesForTest(nil)
+	if _, ok := workflow.Field(workflow.FieldBlocked); ok {
+		t.Error("blocked still registered after clearing dependencies")
+	}
+}
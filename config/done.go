@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// doneYAML represents the workflow.yaml done: section.
+type doneYAML struct {
+	Status string   `yaml:"status,omitempty"`
+	Values []string `yaml:"values"`
+}
+
+// doneFileData is the minimal YAML structure for reading the done section
+// from workflow.yaml.
+type doneFileData struct {
+	Done *doneYAML `yaml:"done"`
+}
+
+// DoneStatus says which statuses are terminal: a tiki is finished when its
+// StatusField value is one of Done. Status semantics are not built into the
+// runtime, so the done: section is the only place that says which statuses
+// are terminal. Dependencies, sprints and the agenda all read it.
+type DoneStatus struct {
+	StatusField string
+	Done        []string
+}
+
+// IsDone reports whether status is one of the configured terminal values.
+func (d DoneStatus) IsDone(status string) bool {
+	for _, v := range d.Done {
+		if strings.EqualFold(v, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadDoneFromFile reads and validates the done: section of an explicit
+// workflow file against the given field catalog, without touching global
+// state. Returns nil when the section is absent.
+func LoadDoneFromFile(path string, fields []workflow.FieldDef) (*DoneStatus, error) {
+	d, err := readDoneFromFile(path, fields)
+	if err != nil {
+		return nil, fmt.Errorf("reading done from %s: %w", path, err)
+	}
+	return d, nil
+}
+
+// readDoneFromFile reads a workflow.yaml and returns its validated done
+// section.
+func readDoneFromFile(path string, fields []workflow.FieldDef) (*DoneStatus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var df doneFileData
+	if err := yaml.Unmarshal(data, &df); err != nil {
+		return nil, fmt.Errorf("parsing done: %w", err)
+	}
+	if df.Done == nil {
+		return nil, nil
+	}
+	return convertDone(*df.Done, fields)
+}
+
+// requireDone reads the done section for a section that cannot work
+// without it.
+func requireDone(path string, fields []workflow.FieldDef) (DoneStatus, error) {
+	d, err := readDoneFromFile(path, fields)
+	if err != nil {
+		return DoneStatus{}, err
+	}
+	if d == nil {
+		return DoneStatus{}, fmt.Errorf("add a top-level done: section listing the terminal status values")
+	}
+	return *d, nil
+}
+
+// convertDone validates the section against the field catalog. status
+// defaults to status.
+func convertDone(raw doneYAML, fields []workflow.FieldDef) (*DoneStatus, error) {
+	d := &DoneStatus{StatusField: strings.TrimSpace(raw.Status)}
+	if d.StatusField == "" {
+		d.StatusField = "status"
+	}
+	var statusField workflow.FieldDef
+	found := false
+	for _, fd := range fields {
+		if fd.Name == d.StatusField {
+			statusField, found = fd, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("done: status: unknown field %q", d.StatusField)
+	}
+	if statusField.Type != workflow.TypeEnum {
+		return nil, fmt.Errorf("done: status: %q must be an enum", d.StatusField)
+	}
+	if len(raw.Values) == 0 {
+		return nil, fmt.Errorf("done: values: list at least one terminal %s value", d.StatusField)
+	}
+	for _, v := range raw.Values {
+		if !statusField.IsValidEnum(v) {
+			return nil, fmt.Errorf("done: values: %q is not a %s value (valid: %s)",
+				v, d.StatusField, strings.Join(statusField.AllowedValues(), ", "))
+		}
+		d.Done = append(d.Done, v)
+	}
+	return d, nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// workflowTestFields is the catalog the done, dependencies, sprints, agenda
+// and permissions sections are validated against in tests.
+func workflowTestFields() []workflow.FieldDef {
+	return []workflow.FieldDef{
+		{Name: "status", Type: workflow.TypeEnum, Custom: true, EnumValues: []workflow.EnumValue{
+			{Value: "open", Default: true}, {Value: "done"}, {Value: "wontFix"},
+		}},
+		{Name: "type", Type: workflow.TypeEnum, Custom: true, EnumValues: []workflow.EnumValue{
+			{Value: "story", Default: true}, {Value: "sprint"}, {Value: "project"},
+		}},
+		{Name: "priority", Type: workflow.TypeEnum, Custom: true, EnumValues: []workflow.EnumValue{
+			{Value: "low"}, {Value: "high"},
+		}},
+		{Name: "iteration", Type: workflow.TypeEnum, Custom: true, EnumValues: []workflow.EnumValue{
+			{Value: "S1"}, {Value: "S2"},
+		}},
+		{Name: "sprint", Type: workflow.TypeString, Custom: true},
+		{Name: "points", Type: workflow.TypeInt, Custom: true},
+		{Name: "start", Type: workflow.TypeDate, Custom: true},
+		{Name: "due", Type: workflow.TypeDate, Custom: true},
+		{Name: "dueBy", Type: workflow.TypeDate, Custom: true},
+		{Name: "recurrence", Type: workflow.TypeRecurrence, Custom: true},
+		{Name: "assignee", Type: workflow.TypeUser, Custom: true},
+		{Name: "dependsOn", Type: workflow.TypeListRef, Custom: true},
+		{Name: "tags", Type: workflow.TypeListString, Custom: true},
+	}
+}
+
+func TestReadDoneFromFile(t *testing.T) {
+	path := writeTemplatesWorkflow(t, "done:\n  values: [done, wontFix]\n")
+	d, err := readDoneFromFile(path, workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.StatusField != "status" {
+		t.Errorf("status = %q, want the status default", d.StatusField)
+	}
+	if !d.IsDone("done") || !d.IsDone("WONTFIX") || d.IsDone("open") {
+		t.Errorf("IsDone wrong for %v", d.Done)
+	}
+
+	absent, err := readDoneFromFile(writeTemplatesWorkflow(t, "views: []\n"), workflowTestFields())
+	if err != nil || absent != nil {
+		t.Fatalf("absent section = %+v, %v; want nil, nil", absent, err)
+	}
+}
+
+func TestReadDoneFromFile_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"no values", "done:\n  status: status\n", "list at least one terminal status value"},
+		{"unknown value", "done:\n  values: [closed]\n", `"closed" is not a status value`},
+		{"status not an enum", "done:\n  status: tags\n  values: [done]\n", "must be an enum"},
+		{"unknown status field", "done:\n  status: state\n  values: [done]\n", "unknown field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readDoneFromFile(writeTemplatesWorkflow(t, tt.yaml), workflowTestFields())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -484,3 +484,86 @@ func TestConvertWorkflowFieldDef_Caption(t *testing.T) {
 		t.Errorf("DisplayCaption = %q, want %q", def.DisplayCaption(), "Deps:")
 	}
 }
+
+func TestLoadWorkflowFields_LinkAndMeasureTypes(t *testing.T) {
+	cwdDir := setupLoadWorkflowFieldsTest(t)
+
+	content := `
+fields:
+  - name: epic
+    type: tikiId
+  - name: components
+    type: enumList
+    values:
+      - value: api
+        visual: "⚙"
+      - ui
+      - docs
+    default: [ui]
+  - name: spec
+    type: url
+  - name: cost
+    type: number
+    unit: h
+    default: 1.5
+`
+	if err := os.WriteFile(filepath.Join(cwdDir, "workflow.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadWorkflowFields(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]workflow.ValueType{
+		"epic":       workflow.TypeRef,
+		"components": workflow.TypeEnumList,
+		"spec":       workflow.TypeURL,
+		"cost":       workflow.TypeNumber,
+	}
+	for name, vt := range want {
+		f, ok := workflow.Field(name)
+		if !ok || f.Type != vt {
+			t.Errorf("Field(%q) = %+v, want type %v", name, f, vt)
+		}
+	}
+
+	components, _ := workflow.Field("components")
+	if got := components.AllowedValues(); !reflect.DeepEqual(got, []string{"api", "ui", "docs"}) {
+		t.Errorf("components values = %v", got)
+	}
+	if !reflect.DeepEqual(components.DefaultValue, []string{"ui"}) {
+		t.Errorf("components default = %#v", components.DefaultValue)
+	}
+	cost, _ := workflow.Field("cost")
+	if cost.Unit != "h" || cost.DefaultValue != 1.5 {
+		t.Errorf("cost = unit %q default %#v", cost.Unit, cost.DefaultValue)
+	}
+}
+
+func TestConvertWorkflowFieldDef_NewTypeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     customFieldYAML
+		wantErr string
+	}{
+		{"unit on non-number", customFieldYAML{Name: "x", Type: "integer", Unit: "h"}, "unit is only valid for number"},
+		{"enumList without values", customFieldYAML{Name: "x", Type: "enumList"}, "requires non-empty values"},
+		{"enumList default outside values", customFieldYAML{Name: "x", Type: "enumList",
+			Values: []enumValueYAML{{Value: "a"}}, Default: []interface{}{"b"}}, `"b" is not one of a`},
+		{"enumList value default", customFieldYAML{Name: "x", Type: "enumList",
+			Values: []enumValueYAML{{Value: "a", Default: true}}}, "cannot be marked default"},
+		{"bad url default", customFieldYAML{Name: "x", Type: "url", Default: "example.com"}, "no scheme"},
+		{"non-numeric number default", customFieldYAML{Name: "x", Type: "number", Default: "lots"}, "expected number"},
+		{"computed with default", customFieldYAML{Name: "x", Type: "integer", Computed: "1", Default: 2},
+			"computed fields cannot have a default"},
+		{"duration not computed", customFieldYAML{Name: "x", Type: "duration"}, "duration fields must be computed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := convertWorkflowFieldDef(tt.def)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
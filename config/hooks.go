@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// default delivery settings for hooks that omit retries:/timeout:.
+const (
+	DefaultHookRetries = 3
+	DefaultHookTimeout = 10 * time.Second
+)
+
+// HookDef represents a single outbound webhook entry in workflow.yaml hooks:.
+// Events lists the mutation kinds the hook subscribes to (create, update,
+// delete); an empty list subscribes to all three. Filter is an optional ruki
+// SELECT statement — the event fires only when the mutated tiki matches it.
+// Secret enables HMAC-SHA256 signing and accepts $VAR / ${VAR} references so
+// credentials can stay out of the workflow file.
+type HookDef struct {
+	Description string            `yaml:"description"`
+	Events      []string          `yaml:"events,omitempty"`
+	Filter      string            `yaml:"filter,omitempty"`
+	URL         string            `yaml:"url"`
+	Secret      string            `yaml:"secret,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+	Retries     *int              `yaml:"retries,omitempty"`
+	Timeout     string            `yaml:"timeout,omitempty"`
+}
+
+// hookFileData is the minimal YAML structure for reading hooks from workflow.yaml.
+type hookFileData struct {
+	Hooks []HookDef `yaml:"hooks"`
+}
+
+// validHookEvents are the mutation kinds a hook may subscribe to.
+var validHookEvents = map[string]bool{"create": true, "update": true, "delete": true}
+
+// Subscribes reports whether the hook wants the given event kind.
+func (h HookDef) Subscribes(event string) bool {
+	if len(h.Events) == 0 {
+		return true
+	}
+	for _, e := range h.Events {
+		if strings.EqualFold(e, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvedSecret returns the signing secret with environment references expanded.
+func (h HookDef) ResolvedSecret() string {
+	return os.ExpandEnv(h.Secret)
+}
+
+// ResolvedURL returns the target URL with environment references expanded.
+func (h HookDef) ResolvedURL() string {
+	return os.ExpandEnv(h.URL)
+}
+
+// RetryCount returns the configured number of delivery retries, or
+// DefaultHookRetries when retries: is omitted.
+func (h HookDef) RetryCount() int {
+	if h.Retries == nil {
+		return DefaultHookRetries
+	}
+	return *h.Retries
+}
+
+// TimeoutDuration returns the per-request timeout, or DefaultHookTimeout when
+// timeout: is omitted. Validate has already rejected unparseable values.
+func (h HookDef) TimeoutDuration() time.Duration {
+	if h.Timeout == "" {
+		return DefaultHookTimeout
+	}
+	d, err := time.ParseDuration(h.Timeout)
+	if err != nil || d <= 0 {
+		return DefaultHookTimeout
+	}
+	return d
+}
+
+// Validate checks the static shape of a hook definition. The ruki filter is
+// validated separately by the service layer, which owns the schema.
+func (h HookDef) Validate() error {
+	if strings.TrimSpace(h.URL) == "" {
+		return fmt.Errorf("url is required")
+	}
+	url := h.ResolvedURL()
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("url %q must start with http:// or https://", h.URL)
+	}
+	for _, e := range h.Events {
+		if !validHookEvents[strings.ToLower(e)] {
+			return fmt.Errorf("unknown event %q (valid: create, update, delete)", e)
+		}
+	}
+	if h.Retries != nil && *h.Retries < 0 {
+		return fmt.Errorf("retries must be >= 0, got %d", *h.Retries)
+	}
+	if h.Timeout != "" {
+		d, err := time.ParseDuration(h.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", h.Timeout, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("timeout must be positive, got %q", h.Timeout)
+		}
+	}
+	return nil
+}
+
+// LoadHookDefs reads webhook definitions from the single highest-priority
+// workflow.yaml. Missing hooks: section means no hooks.
+func LoadHookDefs() ([]HookDef, error) {
+	path := FindWorkflowFile()
+	if path == "" {
+		return nil, nil
+	}
+	defs, err := readHooksFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading hooks from %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+// LoadHookDefsFromFile reads webhook definitions from an explicit workflow
+// file path. Used by workflow validation without global path discovery.
+func LoadHookDefsFromFile(path string) ([]HookDef, error) {
+	defs, err := readHooksFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading hooks from %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+// readHooksFromFile reads a workflow.yaml and returns its validated hooks section.
+func readHooksFromFile(path string) ([]HookDef, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var hf hookFileData
+	if err := yaml.Unmarshal(data, &hf); err != nil {
+		return nil, fmt.Errorf("parsing hooks: %w", err)
+	}
+	for i, h := range hf.Hooks {
+		if err := h.Validate(); err != nil {
+			return nil, fmt.Errorf("hook %q: %w", HookLabel(h, i), err)
+		}
+	}
+	return hf.Hooks, nil
+}
+
+// HookLabel returns the hook's description, or a positional "#N" label when
+// the description is empty. Used in log and error messages.
+func HookLabel(h HookDef, index int) string {
+	if h.Description != "" {
+		return h.Description
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
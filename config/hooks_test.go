@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeHooksWorkflow(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "workflow.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadHooksFromFile_NoHooksKey(t *testing.T) {
+	path := writeHooksWorkflow(t, "views: []\n")
+	defs, err := readHooksFromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(defs) != 0 {
+		t.Fatalf("expected no hooks, got %d", len(defs))
+	}
+}
+
+func TestReadHooksFromFile_ParsesAllKeys(t *testing.T) {
+	path := writeHooksWorkflow(t, `hooks:
+  - description: team channel
+    events: [update, delete]
+    filter: select where status = "done"
+    url: https://example.com/hook
+    secret: s3cret
+    headers:
+      X-Team: platform
+    retries: 5
+    timeout: 2s
+`)
+	defs, err := readHooksFromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(defs) != 1 {
+		t.Fatalf("expected 1 hook, got %d", len(defs))
+	}
+	h := defs[0]
+	if h.Description != "team channel" || h.URL != "https://example.com/hook" {
+		t.Errorf("unexpected hook: %+v", h)
+	}
+	if !h.Subscribes("update") || !h.Subscribes("delete") || h.Subscribes("create") {
+		t.Errorf("unexpected event subscription: %v", h.Events)
+	}
+	if h.RetryCount() != 5 {
+		t.Errorf("RetryCount = %d, want 5", h.RetryCount())
+	}
+	if h.TimeoutDuration() != 2*time.Second {
+		t.Errorf("TimeoutDuration = %v, want 2s", h.TimeoutDuration())
+	}
+	if h.Headers["X-Team"] != "platform" {
+		t.Errorf("headers = %v", h.Headers)
+	}
+}
+
+func TestHookDef_Defaults(t *testing.T) {
+	h := HookDef{URL: "https://example.com"}
+	if !h.Subscribes("create") || !h.Subscribes("update") || !h.Subscribes("delete") {
+		t.Error("empty events should subscribe to everything")
+	}
+	if h.RetryCount() != DefaultHookRetries {
+		t.Errorf("RetryCount = %d, want %d", h.RetryCount(), DefaultHookRetries)
+	}
+	if h.TimeoutDuration() != DefaultHookTimeout {
+		t.Errorf("TimeoutDuration = %v, want %v", h.TimeoutDuration(), DefaultHookTimeout)
+	}
+}
+
+func TestHookDef_ExpandsEnv(t *testing.T) {
+	t.Setenv("TIKI_TEST_HOOK_SECRET", "abc")
+	t.Setenv("TIKI_TEST_HOOK_HOST", "hooks.example.com")
+	h := HookDef{URL: "https://${TIKI_TEST_HOOK_HOST}/x", Secret: "$TIKI_TEST_HOOK_SECRET"}
+	if got := h.ResolvedURL(); got != "https://hooks.example.com/x" {
+		t.Errorf("ResolvedURL = %q", got)
+	}
+	if got := h.ResolvedSecret(); got != "abc" {
+		t.Errorf("ResolvedSecret = %q", got)
+	}
+}
+
+func TestReadHooksFromFile_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"missing url", "hooks:\n  - description: x\n", "url is required"},
+		{"bad scheme", "hooks:\n  - url: ftp://example.com\n", "must start with http"},
+		{"bad event", "hooks:\n  - url: https://example.com\n    events: [move]\n", `unknown event "move"`},
+		{"negative retries", "hooks:\n  - url: https://example.com\n    retries: -1\n", "retries must be >= 0"},
+		{"bad timeout", "hooks:\n  - url: https://example.com\n    timeout: soon\n", "invalid timeout"},
+		{"positional label", "hooks:\n  - url: https://example.com\n  - url: nope\n", `hook "#2"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readHooksFromFile(writeHooksWorkflow(t, tt.yaml))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
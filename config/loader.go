@@ -35,6 +35,11 @@ type Config struct {
 		Visible bool `mapstructure:"visible"`
 	} `mapstructure:"header"`
 
+	// Mouse configuration
+	Mouse struct {
+		Enabled bool `mapstructure:"enabled"` // opt-in; keyboard-only when false
+	} `mapstructure:"mouse"`
+
 	// Tiki configuration
 	Tiki struct {
 		MaxImageRows int `mapstructure:"maxImageRows"`
@@ -53,7 +58,8 @@ type Config struct {
 
 	// AI agent configuration — valid keys defined in aitools.go via AITools()
 	AI struct {
-		Agent string `mapstructure:"agent"`
+		Agent  string                   `mapstructure:"agent"`
+		Agents map[string]AIAgentConfig `mapstructure:"agents"` // user-defined tools, see AITools()
 	} `mapstructure:"ai"`
 
 	// Store backend configuration
@@ -61,11 +67,20 @@ type Config struct {
 		Name string `mapstructure:"name"` // backend name — only "tiki" supported
 	} `mapstructure:"store"`
 
+	// Workspace roots mounted into one store — see GetWorkspaces
+	Workspaces []WorkspaceConfig `mapstructure:"workspaces"`
+
 	// Identity configuration — preferred source for `user()` and UI attribution
 	Identity struct {
 		Name  string `mapstructure:"name"`  // display name for the current user
 		Email string `mapstructure:"email"` // email for the current user
 	} `mapstructure:"identity"`
+
+	// Key binding configuration — parsed and validated by controller.LoadKeymap
+	Keys struct {
+		Profile  string                 `mapstructure:"profile"`  // "default", "vim" or "emacs"
+		Bindings map[string]interface{} `mapstructure:"bindings"` // action id → key or list of keys
+	} `mapstructure:"keys"`
 }
 
 var appConfig *Config
@@ -131,6 +146,9 @@ func setDefaults() {
 	// Header defaults
 	viper.SetDefault("header.visible", true)
 
+	// Mouse defaults
+	viper.SetDefault("mouse.enabled", false)
+
 	// Tiki defaults
 	viper.SetDefault("tiki.maxImageRows", 40)
 
@@ -192,6 +210,14 @@ type workflowFileData struct {
 	Actions     []map[string]interface{} `yaml:"actions,omitempty"`
 	Triggers    []map[string]interface{} `yaml:"triggers,omitempty"`
 	Fields      []map[string]interface{} `yaml:"fields,omitempty"`
+	Hooks       []map[string]interface{} `yaml:"hooks,omitempty"`
+	Templates   []map[string]interface{} `yaml:"templates,omitempty"`
+	// the remaining sections are mappings, not lists.
+	Done         map[string]interface{} `yaml:"done,omitempty"`
+	Dependencies map[string]interface{} `yaml:"dependencies,omitempty"`
+	Sprints      map[string]interface{} `yaml:"sprints,omitempty"`
+	Agenda       map[string]interface{} `yaml:"agenda,omitempty"`
+	Permissions  map[string]interface{} `yaml:"permissions,omitempty"`
 }
 
 // readWorkflowFile reads and unmarshals workflow.yaml from the given path.
@@ -284,6 +310,11 @@ func GetCodeBlockBorder() string {
 	return viper.GetString("appearance.codeBlock.border")
 }
 
+// GetMouseEnabled returns whether mouse input is enabled (off by default).
+func GetMouseEnabled() bool {
+	return viper.GetBool("mouse.enabled")
+}
+
 // GetAIAgent returns the configured AI agent tool name, or empty string if not configured
 func GetAIAgent() string {
 	return viper.GetString("ai.agent")
@@ -315,3 +346,37 @@ func GetIdentityEmail() string {
 	}
 	return ""
 }
+
+// GetKeyProfile returns the configured preset key profile, or empty string
+// for the default bindings.
+func GetKeyProfile() string {
+	if appConfig != nil {
+		return strings.TrimSpace(appConfig.Keys.Profile)
+	}
+	return ""
+}
+
+// GetKeyBindings returns the per-action key overrides from `keys.bindings`.
+// A single key is returned as a one-element list; an empty list (or null)
+// means the action is unbound.
+func GetKeyBindings() map[string][]string {
+	if appConfig == nil || len(appConfig.Keys.Bindings) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(appConfig.Keys.Bindings))
+	for action, v := range appConfig.Keys.Bindings {
+		switch val := v.(type) {
+		case nil:
+			out[action] = nil
+		case []interface{}:
+			keys := make([]string, 0, len(val))
+			for _, k := range val {
+				keys = append(keys, fmt.Sprint(k))
+			}
+			out[action] = keys
+		default:
+			out[action] = []string{fmt.Sprint(val)}
+		}
+	}
+	return out
+}
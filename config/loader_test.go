@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -559,3 +562,138 @@ func TestLoadConfigIdentityEnvOverride(t *testing.T) {
 // note: the former TestLoadConfigStoreEnvOverride was removed. The store.git
 // flag (and its TIKI_STORE_GIT env override) no longer exists — git
 // integration is automatic and read-only, with no enable/disable switch.
+
+func TestLoadConfigKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+keys:
+  profile: vim
+  bindings:
+    quit: "Ctrl-Q"
+    refresh: ["r", "g r"]
+    edit_workflow: []
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	_ = os.Chdir(tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	appConfig = nil
+	ResetPathManager()
+	defer func() { appConfig = nil }()
+
+	if _, err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if got := GetKeyProfile(); got != "vim" {
+		t.Errorf("profile = %q, want vim", got)
+	}
+	bindings := GetKeyBindings()
+	if got := bindings["quit"]; len(got) != 1 || got[0] != "Ctrl-Q" {
+		t.Errorf("quit = %v, want [Ctrl-Q]", got)
+	}
+	if got := bindings["refresh"]; len(got) != 2 || got[1] != "g r" {
+		t.Errorf("refresh = %v, want [r, g r]", got)
+	}
+	if got, ok := bindings["edit_workflow"]; !ok || len(got) != 0 {
+		t.Errorf("edit_workflow = %v (present %v), want empty list", got, ok)
+	}
+}
+
+func TestLoadConfigMouse(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	_ = os.Chdir(tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	appConfig = nil
+	ResetPathManager()
+	defer func() { appConfig = nil }()
+
+	if _, err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if GetMouseEnabled() {
+		t.Error("mouse should be disabled by default")
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.yaml"), []byte("mouse:\n  enabled: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !cfg.Mouse.Enabled || !GetMouseEnabled() {
+		t.Errorf("mouse.enabled = %v / GetMouseEnabled() = %v, want true", cfg.Mouse.Enabled, GetMouseEnabled())
+	}
+}
+
+func TestWorkflowFileData_RoundTripsEverySection(t *testing.T) {
+	const workflow = `version: "0.6.1"
+description: all sections
+fields:
+  - name: status
+    type: enum
+    values: [open, done]
+views:
+  - name: Board
+    kind: board
+actions:
+  - key: "y"
+    label: Copy
+    kind: ruki
+    action: select
+triggers:
+  - description: noop
+    ruki: after create select
+hooks:
+  - name: notify
+    url: https://example.com/hook
+templates:
+  - name: bug
+    fields: {status: open}
+done:
+  status: status
+  values: [done]
+dependencies:
+  field: dependsOn
+sprints:
+  list:
+    - {name: S1, start: 2026-01-05, end: 2026-01-16, capacity: 10}
+agenda:
+  due: [due]
+permissions:
+  roles:
+    admin: [alice]
+`
+	path := filepath.Join(t.TempDir(), "workflow.yaml")
+	if err := os.WriteFile(path, []byte(workflow), 0644); err != nil {
+		t.Fatalf("write workflow: %v", err)
+	}
+	wf, err := readWorkflowFile(path)
+	if err != nil {
+		t.Fatalf("readWorkflowFile failed: %v", err)
+	}
+	out, err := yaml.Marshal(wf)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var want, got map[string]interface{}
+	if err := yaml.Unmarshal([]byte(workflow), &want); err != nil {
+		t.Fatalf("unmarshal source: %v", err)
+	}
+	if err := yaml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("unmarshal round trip: %v", err)
+	}
+	for key := range want {
+		if !reflect.DeepEqual(want[key], got[key]) {
+			t.Errorf("section %s did not survive the round trip:\nwant %#v\ngot  %#v", key, want[key], got[key])
+		}
+	}
+}
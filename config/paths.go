@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -147,6 +149,14 @@ func (pm *PathManager) ConfigFile() string {
 	return filepath.Join(pm.configDir, "config.yaml")
 }
 
+// WorkspaceCacheDir returns a cache subdirectory private to the current
+// project root. The root path is hashed so distinct checkouts never share
+// queued or persisted state.
+func (pm *PathManager) WorkspaceCacheDir() string {
+	sum := sha256.Sum256([]byte(pm.projectRoot))
+	return filepath.Join(pm.cacheDir, "workspaces", hex.EncodeToString(sum[:8]))
+}
+
 // DocDir returns the document scan/write root, which is the current working
 // directory itself. All `.md` files under it are candidate documents.
 func (pm *PathManager) DocDir() string {
@@ -256,6 +266,23 @@ func GetCacheDir() string {
 	return mustGetPathManager().CacheDir()
 }
 
+// GetWorkspaceCacheDir returns the per-workspace cache directory
+func GetWorkspaceCacheDir() string {
+	return mustGetPathManager().WorkspaceCacheDir()
+}
+
+// GetWebhookQueueDir returns the directory holding undelivered webhook
+// payloads for the current workspace
+func GetWebhookQueueDir() string {
+	return filepath.Join(GetWorkspaceCacheDir(), "webhooks")
+}
+
+// GetSessionStateFile returns the file holding the UI session state saved on
+// exit for the current workspace
+func GetSessionStateFile() string {
+	return filepath.Join(GetWorkspaceCacheDir(), "session.json")
+}
+
 // GetDocDir returns the document scan/write root — the current working
 // directory. This is the single scan root for the document store; brand-new
 // documents are written at <cwd>/<ID>.md, while loading is filename-agnostic —
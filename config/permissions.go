@@ -0,0 +1,307 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/boolean-maybe/tiki/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// PermissionSet and PermissionDelete are the mutations a permission rule
+// restricts.
+const (
+	PermissionSet    = "set"
+	PermissionDelete = "delete"
+)
+
+// permissionRuleYAML represents one entry of permissions.rules.
+type permissionRuleYAML struct {
+	Action string   `yaml:"action,omitempty"`
+	Field  string   `yaml:"field,omitempty"`
+	Value  string   `yaml:"value,omitempty"`
+	Allow  []string `yaml:"allow"`
+}
+
+// permissionsYAML represents the workflow.yaml permissions: section.
+type permissionsYAML struct {
+	Groups map[string][]string  `yaml:"groups,omitempty"`
+	Roles  map[string][]string  `yaml:"roles,omitempty"`
+	Rules  []permissionRuleYAML `yaml:"rules"`
+}
+
+// permissionsFileData is the minimal YAML structure for reading the
+// permissions section from workflow.yaml.
+type permissionsFileData struct {
+	Permissions *permissionsYAML `yaml:"permissions"`
+}
+
+// PermissionRule restricts one kind of mutation to the users holding one of
+// AllowRoles, or named in one of AllowFields on the tiki.
+//
+// A set rule applies when a mutation gives Field a value it did not have
+// before, or Value when Value is non-empty. A delete rule applies to tikis
+// whose Field holds Value, or to every tiki when Field is empty. With no
+// roles and no fields allowed, nobody may make the mutation.
+type PermissionRule struct {
+	Action      string
+	Field       string
+	Value       string
+	AllowRoles  []string
+	AllowFields []string
+}
+
+// Token names the rule in `denied:` requirement attributes, e.g.
+// "set:priority=high" or "delete".
+func (r PermissionRule) Token() string {
+	if r.Action == PermissionDelete {
+		return PermissionDelete
+	}
+	if r.Value == "" {
+		return PermissionSet + ":" + r.Field
+	}
+	return PermissionSet + ":" + r.Field + "=" + r.Value
+}
+
+// Describe returns the rule as a sentence fragment for rejection messages:
+// "set priority to high", "delete tikis with type project".
+func (r PermissionRule) Describe() string {
+	if r.Action == PermissionDelete {
+		if r.Field == "" {
+			return "delete tikis"
+		}
+		return fmt.Sprintf("delete tikis with %s %s", r.Field, r.Value)
+	}
+	if r.Value == "" {
+		return "change " + r.Field
+	}
+	return fmt.Sprintf("set %s to %s", r.Field, r.Value)
+}
+
+// Allowed returns who may make the mutation, e.g. "lead or assignee", or
+// "nobody".
+func (r PermissionRule) Allowed() string {
+	who := append(append([]string(nil), r.AllowRoles...), r.AllowFields...)
+	if len(who) == 0 {
+		return "nobody"
+	}
+	return strings.Join(who, " or ")
+}
+
+// PermissionConfig is the validated permissions: section. Roles maps each
+// role to the identities holding it, with groups already expanded; Rules
+// are checked in order and every failing rule is reported.
+type PermissionConfig struct {
+	Roles map[string][]string
+	Rules []PermissionRule
+}
+
+// RolesOf returns the roles held by a user known by any of identities (a
+// name and an email), matched case-insensitively, in sorted order.
+func (c PermissionConfig) RolesOf(identities ...string) []string {
+	var roles []string
+	for role, members := range c.Roles {
+		if identityMatches(members, identities) {
+			roles = append(roles, role)
+		}
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// identityMatches reports whether any of identities is one of members.
+func identityMatches(members, identities []string) bool {
+	for _, id := range identities {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		for _, m := range members {
+			if strings.EqualFold(m, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	permissionsMu     sync.RWMutex
+	loadedPermissions *PermissionConfig
+)
+
+// WorkflowPermissions returns the permissions section loaded alongside the
+// workflow field catalog. ok is false when the workflow declares none.
+func WorkflowPermissions() (PermissionConfig, bool) {
+	permissionsMu.RLock()
+	defer permissionsMu.RUnlock()
+	if loadedPermissions == nil {
+		return PermissionConfig{}, false
+	}
+	return *loadedPermissions, true
+}
+
+// setWorkflowPermissions replaces the loaded permissions section.
+func setWorkflowPermissions(c *PermissionConfig) {
+	permissionsMu.Lock()
+	defer permissionsMu.Unlock()
+	loadedPermissions = c
+}
+
+// ResetWorkflowPermissionsForTest replaces the loaded permissions section
+// (nil clears it). Intended for tests only.
+func ResetWorkflowPermissionsForTest(c *PermissionConfig) {
+	setWorkflowPermissions(c)
+}
+
+// LoadPermissionsFromFile reads and validates the permissions: section of an
+// explicit workflow file against the given field catalog, without touching
+// global state. Returns nil when the section is absent.
+func LoadPermissionsFromFile(path string, fields []workflow.FieldDef) (*PermissionConfig, error) {
+	c, err := readPermissionsFromFile(path, fields)
+	if err != nil {
+		return nil, fmt.Errorf("reading permissions from %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// readPermissionsFromFile reads a workflow.yaml and returns its validated
+// permissions section.
+func readPermissionsFromFile(path string, fields []workflow.FieldDef) (*PermissionConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var df permissionsFileData
+	if err := yaml.Unmarshal(data, &df); err != nil {
+		return nil, fmt.Errorf("parsing permissions: %w", err)
+	}
+	if df.Permissions == nil {
+		return nil, nil
+	}
+	return convertPermissions(*df.Permissions, fields)
+}
+
+// convertPermissions validates the section against the field catalog and
+// expands groups into the roles that name them.
+func convertPermissions(raw permissionsYAML, fields []workflow.FieldDef) (*PermissionConfig, error) {
+	byName := make(map[string]workflow.FieldDef, len(fields))
+	for _, fd := range fields {
+		byName[fd.Name] = fd
+	}
+
+	groups := make(map[string][]string, len(raw.Groups))
+	for name, members := range raw.Groups {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("groups: empty group name")
+		}
+		groups[name] = []string{}
+		for _, m := range members {
+			m = strings.TrimSpace(m)
+			if m == "" {
+				return nil, fmt.Errorf("groups: %s: empty member", name)
+			}
+			if _, nested := raw.Groups[m]; nested {
+				return nil, fmt.Errorf("groups: %s: groups cannot contain groups (%s)", name, m)
+			}
+			groups[name] = append(groups[name], m)
+		}
+	}
+
+	c := &PermissionConfig{Roles: make(map[string][]string, len(raw.Roles))}
+	for role, members := range raw.Roles {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			return nil, fmt.Errorf("roles: empty role name")
+		}
+		if fd, ok := byName[role]; ok && fd.Type == workflow.TypeUser {
+			return nil, fmt.Errorf("roles: %q is also a user field, so allow: %s would be ambiguous", role, role)
+		}
+		c.Roles[role] = []string{}
+		for _, m := range members {
+			m = strings.TrimSpace(m)
+			if m == "" {
+				return nil, fmt.Errorf("roles: %s: empty member", role)
+			}
+			if expanded, ok := groups[m]; ok {
+				c.Roles[role] = append(c.Roles[role], expanded...)
+			} else {
+				c.Roles[role] = append(c.Roles[role], m)
+			}
+		}
+	}
+
+	if len(raw.Rules) == 0 {
+		return nil, fmt.Errorf("rules: list at least one rule")
+	}
+	for i, r := range raw.Rules {
+		rule, err := convertPermissionRule(r, byName, c.Roles)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		c.Rules = append(c.Rules, rule)
+	}
+	return c, nil
+}
+
+// convertPermissionRule validates one rule. action defaults to set.
+func convertPermissionRule(raw permissionRuleYAML, byName map[string]workflow.FieldDef, roles map[string][]string) (PermissionRule, error) {
+	rule := PermissionRule{
+		Action: strings.TrimSpace(raw.Action),
+		Field:  strings.TrimSpace(raw.Field),
+		Value:  strings.TrimSpace(raw.Value),
+	}
+	if rule.Action == "" {
+		rule.Action = PermissionSet
+	}
+	switch rule.Action {
+	case PermissionSet:
+		if rule.Field == "" {
+			return rule, fmt.Errorf("set rules need a field")
+		}
+	case PermissionDelete:
+		if rule.Field == "" && rule.Value != "" {
+			return rule, fmt.Errorf("value %q needs a field", rule.Value)
+		}
+		if rule.Field != "" && rule.Value == "" {
+			return rule, fmt.Errorf("delete rules with a field need a value")
+		}
+	default:
+		return rule, fmt.Errorf("unknown action %q (valid: %s, %s)", rule.Action, PermissionSet, PermissionDelete)
+	}
+
+	if rule.Field != "" {
+		fd, ok := byName[rule.Field]
+		if !ok {
+			return rule, fmt.Errorf("unknown field %q", rule.Field)
+		}
+		if fd.IsComputed() {
+			return rule, fmt.Errorf("field %q is computed and cannot be set", rule.Field)
+		}
+		if rule.Value != "" && (fd.Type == workflow.TypeEnum || fd.Type == workflow.TypeEnumList) && !fd.IsValidEnum(rule.Value) {
+			return rule, fmt.Errorf("%q is not a %s value (valid: %s)",
+				rule.Value, rule.Field, strings.Join(fd.AllowedValues(), ", "))
+		}
+	}
+
+	for _, a := range raw.Allow {
+		a = strings.TrimSpace(a)
+		if _, ok := roles[a]; ok {
+			rule.AllowRoles = append(rule.AllowRoles, a)
+			continue
+		}
+		if fd, ok := byName[a]; ok && fd.Type == workflow.TypeUser {
+			rule.AllowFields = append(rule.AllowFields, a)
+			continue
+		}
+		return rule, fmt.Errorf("allow: %q is neither a role nor a user field", a)
+	}
+	return rule, nil
+}
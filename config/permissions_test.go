@@ -0,0 +1,84 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const permissionTestYAML = `permissions:
+  groups:
+    platform: [carol, dave@example.com]
+  roles:
+    lead: [alice, platform]
+    auditor: []
+  rules:
+    - field: priority
+      value: high
+      allow: [lead]
+    - field: status
+      value: done
+      allow: [assignee, lead]
+    - action: delete
+      field: type
+      value: project
+      allow: []
+`
+
+func TestReadPermissionsFromFile(t *testing.T) {
+	c, err := readPermissionsFromFile(writeTemplatesWorkflow(t, permissionTestYAML), workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(c.Roles["lead"], []string{"alice", "carol", "dave@example.com"}) {
+		t.Errorf("lead = %v, want alice and the platform group", c.Roles["lead"])
+	}
+	if got := c.RolesOf("Dave", "DAVE@example.com"); !slices.Equal(got, []string{"lead"}) {
+		t.Errorf("roles of dave = %v, want [lead] matched by email", got)
+	}
+	if len(c.Rules) != 3 {
+		t.Fatalf("rules = %+v, want 3", c.Rules)
+	}
+	if r := c.Rules[0]; r.Action != PermissionSet || r.Token() != "set:priority=high" || r.Allowed() != "lead" {
+		t.Errorf("rule 0 = %+v", r)
+	}
+	if r := c.Rules[1]; !slices.Equal(r.AllowFields, []string{"assignee"}) || !slices.Equal(r.AllowRoles, []string{"lead"}) {
+		t.Errorf("rule 1 = %+v, want assignee as a field and lead as a role", r)
+	}
+	if r := c.Rules[2]; r.Token() != "delete" || r.Allowed() != "nobody" || r.Describe() != "delete tikis with type project" {
+		t.Errorf("rule 2 = %+v", r)
+	}
+}
+
+func TestReadPermissionsFromFile_Absent(t *testing.T) {
+	c, err := readPermissionsFromFile(writeTemplatesWorkflow(t, "views: []\n"), workflowTestFields())
+	if err != nil || c != nil {
+		t.Fatalf("expected no permissions, got %+v, %v", c, err)
+	}
+}
+
+func TestReadPermissionsFromFile_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"no rules", "permissions:\n  roles:\n    lead: [alice]\n", "list at least one rule"},
+		{"unknown action", "permissions:\n  rules:\n    - action: move\n      field: status\n", `unknown action "move"`},
+		{"set without field", "permissions:\n  rules:\n    - value: high\n", "need a field"},
+		{"unknown field", "permissions:\n  rules:\n    - field: severity\n", `unknown field "severity"`},
+		{"bad enum value", "permissions:\n  rules:\n    - field: priority\n      value: urgent\n", "is not a priority value"},
+		{"delete field without value", "permissions:\n  rules:\n    - action: delete\n      field: type\n", "need a value"},
+		{"unknown allow", "permissions:\n  rules:\n    - field: points\n      allow: [lead]\n", "neither a role nor a user field"},
+		{"role named like a user field", "permissions:\n  roles:\n    assignee: [alice]\n  rules:\n    - field: points\n", "ambiguous"},
+		{"nested group", "permissions:\n  groups:\n    a: [b]\n    b: [alice]\n  rules:\n    - field: points\n", "cannot contain groups"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readPermissionsFromFile(writeTemplatesWorkflow(t, tt.yaml), workflowTestFields())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
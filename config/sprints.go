@@ -0,0 +1,393 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boolean-maybe/tiki/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// sprintDateLayout is the YYYY-MM-DD layout of sprint start and end dates.
+const sprintDateLayout = "2006-01-02"
+
+// sprintsYAML represents the workflow.yaml sprints: section.
+type sprintsYAML struct {
+	Field  string           `yaml:"field,omitempty"`
+	Points string           `yaml:"points,omitempty"`
+	List   []sprintYAML     `yaml:"list,omitempty"`
+	Tikis  *sprintTikisYAML `yaml:"tikis,omitempty"`
+}
+
+// sprintYAML is one entry of sprints.list.
+type sprintYAML struct {
+	Name     string  `yaml:"name"`
+	Start    string  `yaml:"start"`
+	End      string  `yaml:"end"`
+	Capacity float64 `yaml:"capacity"`
+}
+
+// sprintTikisYAML is the sprints.tikis: subsection declaring sprints as tikis.
+type sprintTikisYAML struct {
+	Type     string `yaml:"type"`
+	Start    string `yaml:"start"`
+	End      string `yaml:"end"`
+	Capacity string `yaml:"capacity,omitempty"`
+}
+
+// sprintsFileData is the minimal YAML structure for reading the sprints
+// section from workflow.yaml.
+type sprintsFileData struct {
+	Sprints *sprintsYAML `yaml:"sprints"`
+}
+
+// Sprint is one iteration: an inclusive date range with a capacity in
+// points. ID is set for sprints declared as tikis and empty for sprints
+// listed in workflow.yaml.
+type Sprint struct {
+	Name     string
+	Start    time.Time
+	End      time.Time
+	Capacity float64
+	ID       string
+}
+
+// Contains reports whether the calendar day of t falls within the sprint.
+func (s Sprint) Contains(t time.Time) bool {
+	day := sprintDay(t)
+	return !day.Before(s.Start) && !day.After(s.End)
+}
+
+// SprintTikiSource says which tikis declare sprints: those whose type field
+// is Type. The tiki's title is the sprint name; its StartField and EndField
+// dates bound the sprint and the optional CapacityField holds its capacity.
+type SprintTikiSource struct {
+	Type          string
+	StartField    string
+	EndField      string
+	CapacityField string
+}
+
+// SprintConfig is the validated sprints: section. Field holds the name of
+// the sprint a tiki is committed to and PointsField its estimate; a tiki
+// counts as completed when the workflow's done: section says its status is
+// terminal. List is sorted by start date and free of overlaps; Tikis is nil
+// unless sprints are also declared as tikis.
+type SprintConfig struct {
+	Field       string
+	PointsField string
+	DoneStatus
+	List  []Sprint
+	Tikis *SprintTikiSource
+}
+
+// SprintList is a set of sprints sorted by start date.
+type SprintList []Sprint
+
+// SortSprints sorts sprints by start date, then name, and returns them as a
+// SprintList.
+func SortSprints(sprints []Sprint) SprintList {
+	sort.SliceStable(sprints, func(i, j int) bool {
+		if !sprints[i].Start.Equal(sprints[j].Start) {
+			return sprints[i].Start.Before(sprints[j].Start)
+		}
+		return sprints[i].Name < sprints[j].Name
+	})
+	return SprintList(sprints)
+}
+
+// Find returns the sprint called name.
+func (l SprintList) Find(name string) (Sprint, bool) {
+	for _, s := range l {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Sprint{}, false
+}
+
+// Current returns the first sprint whose dates contain now.
+func (l SprintList) Current(now time.Time) (Sprint, bool) {
+	for _, s := range l {
+		if s.Contains(now) {
+			return s, true
+		}
+	}
+	return Sprint{}, false
+}
+
+// Latest returns the current sprint, or else the one that ended most
+// recently before now — the sprint a "close sprint" without a name means.
+func (l SprintList) Latest(now time.Time) (Sprint, bool) {
+	if s, ok := l.Current(now); ok {
+		return s, true
+	}
+	day := sprintDay(now)
+	var latest Sprint
+	found := false
+	for _, s := range l {
+		if s.End.Before(day) && (!found || s.End.After(latest.End)) {
+			latest, found = s, true
+		}
+	}
+	return latest, found
+}
+
+// Next returns the sprint following the one called name.
+func (l SprintList) Next(name string) (Sprint, bool) {
+	for i, s := range l {
+		if s.Name == name && i+1 < len(l) {
+			return l[i+1], true
+		}
+	}
+	return Sprint{}, false
+}
+
+// sprintDay truncates t to its calendar day, in UTC like date fields.
+func sprintDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+var (
+	sprintsMu     sync.RWMutex
+	loadedSprints *SprintConfig
+)
+
+// WorkflowSprints returns the sprints section loaded alongside the workflow
+// field catalog. ok is false when the workflow declares none.
+func WorkflowSprints() (SprintConfig, bool) {
+	sprintsMu.RLock()
+	defer sprintsMu.RUnlock()
+	if loadedSprints == nil {
+		return SprintConfig{}, false
+	}
+	c := *loadedSprints
+	c.Done = append([]string(nil), loadedSprints.Done...)
+	c.List = append([]Sprint(nil), loadedSprints.List...)
+	if loadedSprints.Tikis != nil {
+		src := *loadedSprints.Tikis
+		c.Tikis = &src
+	}
+	return c, true
+}
+
+// setWorkflowSprints replaces the loaded sprints section and enables or
+// disables the derived currentSprint field to match.
+func setWorkflowSprints(c *SprintConfig) error {
+	sprintsMu.Lock()
+	defer sprintsMu.Unlock()
+	if c == nil {
+		workflow.DisableSprintField()
+		loadedSprints = nil
+		return nil
+	}
+	if err := workflow.EnableSprintField(); err != nil {
+		return err
+	}
+	loadedSprints = c
+	return nil
+}
+
+// ResetWorkflowSprintsForTest replaces the loaded sprints section (nil
+// clears it). Intended for tests only.
+func ResetWorkflowSprintsForTest(c *SprintConfig) {
+	if err := setWorkflowSprints(c); err != nil {
+		panic(fmt.Sprintf("ResetWorkflowSprintsForTest: %v", err))
+	}
+}
+
+// LoadSprintsFromFile reads and validates the sprints: section of an
+// explicit workflow file against the given field catalog, without touching
+// global state. Returns nil when the section is absent.
+func LoadSprintsFromFile(path string, fields []workflow.FieldDef) (*SprintConfig, error) {
+	c, err := readSprintsFromFile(path, fields)
+	if err != nil {
+		return nil, fmt.Errorf("reading sprints from %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// readSprintsFromFile reads a workflow.yaml and returns its validated
+// sprints section.
+func readSprintsFromFile(path string, fields []workflow.FieldDef) (*SprintConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var sf sprintsFileData
+	if err := yaml.Unmarshal(data, &sf); err != nil {
+		return nil, fmt.Errorf("parsing sprints: %w", err)
+	}
+	if sf.Sprints == nil {
+		return nil, nil
+	}
+	done, err := requireDone(path, fields)
+	if err != nil {
+		return nil, err
+	}
+	return convertSprints(*sf.Sprints, done, fields)
+}
+
+// convertSprints validates the section against the field catalog. field
+// defaults to sprint and points to points.
+func convertSprints(raw sprintsYAML, done DoneStatus, fields []workflow.FieldDef) (*SprintConfig, error) {
+	byName := make(map[string]workflow.FieldDef, len(fields))
+	for _, fd := range fields {
+		byName[fd.Name] = fd
+		if fd.Name == workflow.FieldCurrentSprint {
+			return nil, fmt.Errorf("field %q is reserved for the derived current sprint field", fd.Name)
+		}
+	}
+
+	c := &SprintConfig{
+		Field:       strings.TrimSpace(raw.Field),
+		PointsField: strings.TrimSpace(raw.Points),
+		DoneStatus:  done,
+	}
+	if c.Field == "" {
+		c.Field = "sprint"
+	}
+	if c.PointsField == "" {
+		c.PointsField = "points"
+	}
+
+	sprintField, ok := byName[c.Field]
+	if !ok {
+		return nil, fmt.Errorf("field: unknown field %q", c.Field)
+	}
+	// text or enum. ruki only compares an enum field with literals, so an
+	// enum sprint field cannot be matched against currentSprint.
+	switch sprintField.Type {
+	case workflow.TypeString, workflow.TypeEnum:
+	default:
+		return nil, fmt.Errorf("field: %q must be a text or enum field", c.Field)
+	}
+	pointsField, ok := byName[c.PointsField]
+	if !ok {
+		return nil, fmt.Errorf("points: unknown field %q", c.PointsField)
+	}
+	switch pointsField.Type {
+	case workflow.TypeInt, workflow.TypeNumber, workflow.TypeEnum:
+	default:
+		return nil, fmt.Errorf("points: %q must be an integer, number or enum", c.PointsField)
+	}
+	list, err := convertSprintList(raw.List)
+	if err != nil {
+		return nil, err
+	}
+	c.List = list
+	if sprintField.Type == workflow.TypeEnum {
+		// closing a sprint writes the next sprint's name into the field
+		for _, sp := range c.List {
+			if !sprintField.IsValidEnum(sp.Name) {
+				return nil, fmt.Errorf("list: sprint %q is not a %s value (valid: %s)",
+					sp.Name, c.Field, strings.Join(sprintField.AllowedValues(), ", "))
+			}
+		}
+		if raw.Tikis != nil {
+			return nil, fmt.Errorf("tikis: sprint tikis need a text %s field; an enum cannot hold new sprint names", c.Field)
+		}
+	}
+
+	if raw.Tikis != nil {
+		src, err := convertSprintTikis(*raw.Tikis, byName)
+		if err != nil {
+			return nil, fmt.Errorf("tikis: %w", err)
+		}
+		c.Tikis = src
+	}
+	if len(c.List) == 0 && c.Tikis == nil {
+		return nil, fmt.Errorf("declare sprints in list:, as tikis:, or both")
+	}
+	return c, nil
+}
+
+// convertSprintList parses and checks the listed sprints: names are unique,
+// dates are YYYY-MM-DD with start
+// on or before end, capacities are not negative and no two sprints overlap.
+func convertSprintList(raw []sprintYAML) ([]Sprint, error) {
+	seen := make(map[string]bool, len(raw))
+	sprints := make([]Sprint, 0, len(raw))
+	for i, r := range raw {
+		name := strings.TrimSpace(r.Name)
+		if name == "" {
+			return nil, fmt.Errorf("list[%d]: name is required", i)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("list: duplicate sprint %q", name)
+		}
+		seen[name] = true
+		start, err := time.Parse(sprintDateLayout, strings.TrimSpace(r.Start))
+		if err != nil {
+			return nil, fmt.Errorf("list: sprint %q: start must be YYYY-MM-DD", name)
+		}
+		end, err := time.Parse(sprintDateLayout, strings.TrimSpace(r.End))
+		if err != nil {
+			return nil, fmt.Errorf("list: sprint %q: end must be YYYY-MM-DD", name)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("list: sprint %q ends before it starts", name)
+		}
+		if r.Capacity < 0 {
+			return nil, fmt.Errorf("list: sprint %q: capacity cannot be negative", name)
+		}
+		sprints = append(sprints, Sprint{Name: name, Start: start, End: end, Capacity: r.Capacity})
+	}
+	sorted := SortSprints(sprints)
+	for i := 1; i < len(sorted); i++ {
+		if !sorted[i].Start.After(sorted[i-1].End) {
+			return nil, fmt.Errorf("list: sprints %q and %q overlap", sorted[i-1].Name, sorted[i].Name)
+		}
+	}
+	return sorted, nil
+}
+
+// convertSprintTikis checks the fields a sprint tiki is read from.
+func convertSprintTikis(raw sprintTikisYAML, byName map[string]workflow.FieldDef) (*SprintTikiSource, error) {
+	src := &SprintTikiSource{
+		Type:          strings.TrimSpace(raw.Type),
+		StartField:    strings.TrimSpace(raw.Start),
+		EndField:      strings.TrimSpace(raw.End),
+		CapacityField: strings.TrimSpace(raw.Capacity),
+	}
+	typeField, ok := byName["type"]
+	if !ok {
+		return nil, fmt.Errorf("the workflow has no type field to mark sprint tikis with")
+	}
+	if src.Type == "" {
+		return nil, fmt.Errorf("type: name the type value of sprint tikis")
+	}
+	if typeField.Type == workflow.TypeEnum && !typeField.IsValidEnum(src.Type) {
+		return nil, fmt.Errorf("type: %q is not a type value (valid: %s)",
+			src.Type, strings.Join(typeField.AllowedValues(), ", "))
+	}
+	for _, f := range []struct{ key, name string }{{"start", src.StartField}, {"end", src.EndField}} {
+		fd, ok := byName[f.name]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown field %q", f.key, f.name)
+		}
+		if fd.Type != workflow.TypeDate {
+			return nil, fmt.Errorf("%s: %q must be a date", f.key, f.name)
+		}
+	}
+	if src.CapacityField != "" {
+		fd, ok := byName[src.CapacityField]
+		if !ok {
+			return nil, fmt.Errorf("capacity: unknown field %q", src.CapacityField)
+		}
+		switch fd.Type {
+		case workflow.TypeInt, workflow.TypeNumber, workflow.TypeEnum:
+		default:
+			return nil, fmt.Errorf("capacity: %q must be an integer, number or enum", src.CapacityField)
+		}
+	}
+	return src, nil
+}
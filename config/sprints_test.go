@@ -0,0 +1,142 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+const sprintTestYAML = `done:
+  values: [done]
+sprints:
+  list:
+    - {name: S2, start: 2026-01-19, end: 2026-01-30, capacity: 25}
+    - {name: S1, start: 2026-01-05, end: 2026-01-16, capacity: 30}
+`
+
+func TestReadSprintsFromFile_Absent(t *testing.T) {
+	c, err := readSprintsFromFile(writeTemplatesWorkflow(t, "views: []\n"), workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c != nil {
+		t.Fatalf("expected no sprints config, got %+v", c)
+	}
+}
+
+func TestReadSprintsFromFile_DefaultsAndSortedList(t *testing.T) {
+	c, err := readSprintsFromFile(writeTemplatesWorkflow(t, sprintTestYAML), workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Field != "sprint" || c.PointsField != "points" || c.StatusField != "status" {
+		t.Errorf("defaults = %q/%q/%q, want sprint/points/status", c.Field, c.PointsField, c.StatusField)
+	}
+	if len(c.List) != 2 || c.List[0].Name != "S1" || c.List[1].Name != "S2" {
+		t.Fatalf("list not sorted by start: %+v", c.List)
+	}
+	if c.List[0].Capacity != 30 || !c.List[0].End.Equal(time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("S1 = %+v", c.List[0])
+	}
+}
+
+func TestReadSprintsFromFile_Tikis(t *testing.T) {
+	yaml := "done:\n  values: [done]\nsprints:\n  tikis: {type: sprint, start: start, end: due, capacity: points}\n"
+	c, err := readSprintsFromFile(writeTemplatesWorkflow(t, yaml), workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := SprintTikiSource{Type: "sprint", StartField: "start", EndField: "due", CapacityField: "points"}
+	if c.Tikis == nil || *c.Tikis != want {
+		t.Errorf("tikis = %+v, want %+v", c.Tikis, want)
+	}
+}
+
+func TestReadSprintsFromFile_EnumField(t *testing.T) {
+	yaml := "done:\n  values: [done]\nsprints:\n  field: iteration\n  list: [{name: S1, start: 2026-01-05, end: 2026-01-16}]\n"
+	c, err := readSprintsFromFile(writeTemplatesWorkflow(t, yaml), workflowTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Field != "iteration" {
+		t.Errorf("field = %q, want iteration", c.Field)
+	}
+}
+
+func TestReadSprintsFromFile_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"no sprints", "done:\n  values: [done]\nsprints: {}\n", "declare sprints"},
+		{"no done section", "sprints:\n  list: [{name: S1, start: 2026-01-05, end: 2026-01-16}]\n", "top-level done: section"},
+		{"unknown sprint field", "done:\n  values: [done]\nsprints:\n  field: cycle\n", `unknown field "cycle"`},
+		{"sprint field not text", "done:\n  values: [done]\nsprints:\n  field: tags\n", "must be a text or enum field"},
+		{"enum sprint missing value", "done:\n  values: [done]\nsprints:\n  field: iteration\n  list: [{name: S3, start: 2026-02-02, end: 2026-02-13}]\n", `sprint "S3" is not a iteration value`},
+		{"enum sprint with tikis", "done:\n  values: [done]\nsprints:\n  field: iteration\n  tikis: {type: sprint, start: start, end: due}\n", "need a text iteration field"},
+		{"points not numeric", "done:\n  values: [done]\nsprints:\n  points: tags\n", "must be an integer, number or enum"},
+		{"bad date", "done:\n  values: [done]\nsprints:\n  list: [{name: S1, start: Jan 5, end: 2026-01-16}]\n", "start must be YYYY-MM-DD"},
+		{"ends before start", "done:\n  values: [done]\nsprints:\n  list: [{name: S1, start: 2026-01-16, end: 2026-01-05}]\n", "ends before it starts"},
+		{"negative capacity", "done:\n  values: [done]\nsprints:\n  list: [{name: S1, start: 2026-01-05, end: 2026-01-16, capacity: -1}]\n", "cannot be negative"},
+		{"duplicate", "done:\n  values: [done]\nsprints:\n  list:\n    - {name: S1, start: 2026-01-05, end: 2026-01-09}\n    - {name: S1, start: 2026-01-12, end: 2026-01-16}\n", "duplicate sprint"},
+		{"overlap", "done:\n  values: [done]\nsprints:\n  list:\n    - {name: S1, start: 2026-01-05, end: 2026-01-16}\n    - {name: S2, start: 2026-01-16, end: 2026-01-30}\n", "overlap"},
+		{"tiki start not a date", "done:\n  values: [done]\nsprints:\n  tikis: {type: sprint, start: tags, end: due}\n", `start: "tags" must be a date`},
+		{"unknown tiki type", "done:\n  values: [done]\nsprints:\n  tikis: {type: iteration, start: start, end: due}\n", "is not a type value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readSprintsFromFile(writeTemplatesWorkflow(t, tt.yaml), workflowTestFields())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSprintList_CurrentLatestNext(t *testing.T) {
+	c, err := readSprintsFromFile(writeTemplatesWorkflow(t, sprintTestYAML), workflowTestFields())
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := SprintList(c.List)
+
+	if s, ok := list.Current(time.Date(2026, 1, 16, 23, 0, 0, 0, time.UTC)); !ok || s.Name != "S1" {
+		t.Errorf("Current(last day of S1) = %q %v, want S1", s.Name, ok)
+	}
+	if _, ok := list.Current(time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("Current between sprints should report none")
+	}
+	if s, ok := list.Latest(time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)); !ok || s.Name != "S1" {
+		t.Errorf("Latest between sprints = %q %v, want S1", s.Name, ok)
+	}
+	if _, ok := list.Latest(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("Latest before the first sprint should report none")
+	}
+	if s, ok := list.Next("S1"); !ok || s.Name != "S2" {
+		t.Errorf("Next(S1) = %q %v, want S2", s.Name, ok)
+	}
+	if _, ok := list.Next("S2"); ok {
+		t.Error("Next of the last sprint should report none")
+	}
+}
+
+func TestSetWorkflowSprints_TogglesCurrentSprintField(t *testing.T) {
+	ResetWorkflowFieldsForTest(workflowTestFields())
+	t.Cleanup(ClearWorkflowFields)
+
+	ResetWorkflowSprintsForTest(&SprintConfig{Field: "sprint", PointsField: "points", DoneStatus: DoneStatus{StatusField: "status", Done: []string{"done"}}})
+	if fd, ok := workflow.Field(workflow.FieldCurrentSprint); !ok || !fd.Derived {
+		t.Fatalf("currentSprint not registered as a derived field: %+v %v", fd, ok)
+	}
+	if _, ok := WorkflowSprints(); !ok {
+		t.Fatal("WorkflowSprints() reported no config after set")
+	}
+
+	ResetWorkflowSprintsForTest(nil)
+	if _, ok := workflow.Field(workflow.FieldCurrentSprint); ok {
+		t.Error("currentSprint still registered after clearing sprints")
+	}
+}
@@ -0,0 +1,284 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/boolean-maybe/tiki/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// templateYAML represents a single entry in workflow.yaml templates:.
+type templateYAML struct {
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description,omitempty"`
+	Title       string                 `yaml:"title,omitempty"`
+	Folder      string                 `yaml:"folder,omitempty"`
+	Fields      map[string]interface{} `yaml:"fields,omitempty"`
+	Body        string                 `yaml:"body,omitempty"`
+}
+
+// templateFileData is the minimal YAML structure for reading templates from workflow.yaml.
+type templateFileData struct {
+	Templates []templateYAML `yaml:"templates"`
+}
+
+// TikiTemplate is a validated named creation template. Fields holds preset
+// values already coerced through the workflow field catalog, so they can be
+// applied to a tiki with Set as-is. Title is a pattern that may contain the
+// {title}, {date}, {user} and {id} placeholders; Folder is a clean path
+// relative to the tiki directory ("" means the directory root).
+type TikiTemplate struct {
+	Name        string
+	Description string
+	Title       string
+	Folder      string
+	Fields      map[string]interface{}
+	Body        string
+}
+
+// Label returns the description, or the template name when no description is set.
+func (t TikiTemplate) Label() string {
+	if t.Description != "" {
+		return t.Description
+	}
+	return t.Name
+}
+
+// templateNamePattern restricts template names to something that can be typed
+// on a command line and quoted in a ruki string without escaping.
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+var (
+	templatesMu     sync.RWMutex
+	loadedTemplates []TikiTemplate
+)
+
+// WorkflowTemplates returns the templates loaded alongside the workflow field
+// catalog, in declaration order. Returns nil when the workflow declares none.
+func WorkflowTemplates() []TikiTemplate {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	if len(loadedTemplates) == 0 {
+		return nil
+	}
+	out := make([]TikiTemplate, len(loadedTemplates))
+	copy(out, loadedTemplates)
+	return out
+}
+
+// FindTemplate returns the loaded template with the given name (case-insensitive).
+func FindTemplate(name string) (TikiTemplate, bool) {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	for _, t := range loadedTemplates {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return TikiTemplate{}, false
+}
+
+// setWorkflowTemplates replaces the loaded template set.
+func setWorkflowTemplates(templates []TikiTemplate) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	loadedTemplates = templates
+}
+
+// SwapWorkflowTemplates installs templates as the loaded set and returns a
+// function restoring the previous one. Used to validate the views of a
+// workflow that is not installed yet.
+func SwapWorkflowTemplates(templates []TikiTemplate) (restore func()) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	prev := loadedTemplates
+	loadedTemplates = templates
+	return func() { setWorkflowTemplates(prev) }
+}
+
+// ResetWorkflowTemplatesForTest replaces the loaded templates. Intended for tests only.
+func ResetWorkflowTemplatesForTest(templates []TikiTemplate) {
+	setWorkflowTemplates(templates)
+}
+
+// LoadTemplatesFromFile reads and validates the templates: section of an
+// explicit workflow file against the given field catalog, without touching
+// global state. Missing templates: section means no templates.
+func LoadTemplatesFromFile(path string, fields []workflow.FieldDef) ([]TikiTemplate, error) {
+	templates, err := readTemplatesFromFile(path, fields)
+	if err != nil {
+		return nil, fmt.Errorf("reading templates from %s: %w", path, err)
+	}
+	return templates, nil
+}
+
+// readTemplatesFromFile reads a workflow.yaml and returns its validated templates section.
+func readTemplatesFromFile(path string, fields []workflow.FieldDef) ([]TikiTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var tf templateFileData
+	if err := yaml.Unmarshal(data, &tf); err != nil {
+		return nil, fmt.Errorf("parsing templates: %w", err)
+	}
+	if len(tf.Templates) == 0 {
+		return nil, nil
+	}
+
+	byName := make(map[string]workflow.FieldDef, len(fields))
+	for _, fd := range fields {
+		byName[fd.Name] = fd
+	}
+
+	seen := make(map[string]bool, len(tf.Templates))
+	templates := make([]TikiTemplate, 0, len(tf.Templates))
+	for i, raw := range tf.Templates {
+		t, err := convertTemplate(raw, byName)
+		if err != nil {
+			label := raw.Name
+			if label == "" {
+				label = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("template %q: %w", label, err)
+		}
+		key := strings.ToLower(t.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate template name %q", t.Name)
+		}
+		seen[key] = true
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// convertTemplate validates one templates: entry and coerces its preset
+// field values through the workflow field catalog.
+func convertTemplate(raw templateYAML, fields map[string]workflow.FieldDef) (TikiTemplate, error) {
+	name := strings.TrimSpace(raw.Name)
+	if name == "" {
+		return TikiTemplate{}, fmt.Errorf("name is required")
+	}
+	if !templateNamePattern.MatchString(name) {
+		return TikiTemplate{}, fmt.Errorf("name %q must contain only letters, digits, '-' and '_'", name)
+	}
+	folder, err := cleanTemplateFolder(raw.Folder)
+	if err != nil {
+		return TikiTemplate{}, err
+	}
+
+	t := TikiTemplate{
+		Name:        name,
+		Description: raw.Description,
+		Title:       raw.Title,
+		Folder:      folder,
+		Body:        strings.TrimRight(raw.Body, "\n"),
+	}
+	if len(raw.Fields) > 0 {
+		t.Fields = make(map[string]interface{}, len(raw.Fields))
+		for key, value := range raw.Fields {
+			fd, ok := fields[key]
+			if !ok {
+				if workflow.IsSystemField(key) {
+					return TikiTemplate{}, fmt.Errorf("field %q is a system field and cannot be preset", key)
+				}
+				return TikiTemplate{}, fmt.Errorf("unknown field %q", key)
+			}
+			coerced, err := coerceTemplateValue(fd, value)
+			if err != nil {
+				return TikiTemplate{}, fmt.Errorf("field %q: %w", key, err)
+			}
+			t.Fields[key] = coerced
+		}
+	}
+	return t, nil
+}
+
+// coerceTemplateValue coerces a preset value for fd. Enum presets must name
+// one of the declared values; other types follow the field-default rules.
+// Computed fields cannot be preset.
+func coerceTemplateValue(fd workflow.FieldDef, raw interface{}) (interface{}, error) {
+	if fd.IsComputed() {
+		return nil, fmt.Errorf("%s is computed and cannot be preset", fd.Name)
+	}
+	if fd.Type == workflow.TypeEnum {
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", raw)
+		}
+		if !fd.IsValidEnum(s) {
+			return nil, fmt.Errorf("value %q is not one of %s", s, strings.Join(fd.AllowedValues(), ", "))
+		}
+		return s, nil
+	}
+	return coerceFieldDefault(fd.Type, raw, fd.AllowedValues())
+}
+
+// CoerceFieldValue coerces a value decoded from JSON or YAML for the
+// workflow field fd, such as a field update proposed by an AI agent. It
+// accepts what template presets accept, plus recurrence and duration
+// strings.
+func CoerceFieldValue(fd workflow.FieldDef, raw interface{}) (interface{}, error) {
+	if fd.IsComputed() {
+		return nil, fmt.Errorf("%s is computed and cannot be set", fd.Name)
+	}
+	switch fd.Type {
+	case workflow.TypeRecurrence, workflow.TypeDuration, workflow.TypeID:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", raw)
+		}
+		return s, nil
+	}
+	return coerceTemplateValue(fd, raw)
+}
+
+// cleanTemplateFolder normalizes a template folder and rejects absolute
+// paths and paths that escape the tiki directory.
+func cleanTemplateFolder(folder string) (string, error) {
+	folder = strings.TrimSpace(folder)
+	if folder == "" {
+		return "", nil
+	}
+	if filepath.IsAbs(folder) || strings.HasPrefix(folder, "/") {
+		return "", fmt.Errorf("folder %q must be relative to the tiki directory", folder)
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(folder))
+	if cleaned == "." {
+		return "", nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("folder %q must not leave the tiki directory", folder)
+	}
+	return cleaned, nil
+}
+
+// createFromPattern matches the host-side `create from "<template>"` prefix.
+var createFromPattern = regexp.MustCompile(`^(\s*create)\s+from\s+"([^"]*)"`)
+
+// SplitCreateFrom recognizes the `create from "<template>"` form, which the
+// ruki grammar does not know about, and rewrites it into a plain create
+// statement. It returns the rewritten statement and the template name, or
+// src unchanged and "" when the statement does not use the form. A bare
+// `create from "<template>"` with no assignments is rewritten to
+// `create title=title` so the template's own title carries through.
+func SplitCreateFrom(src string) (stmt string, template string) {
+	m := createFromPattern.FindStringSubmatchIndex(src)
+	if m == nil {
+		return src, ""
+	}
+	template = src[m[4]:m[5]]
+	rest := src[m[1]:]
+	if strings.TrimSpace(rest) == "" {
+		rest = " title=title"
+	}
+	return src[m[2]:m[3]] + rest, template
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func writeTemplatesWorkflow(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "workflow.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func templateTestFields() []workflow.FieldDef {
+	return []workflow.FieldDef{
+		{Name: "type", Type: workflow.TypeEnum, Custom: true, EnumValues: []workflow.EnumValue{
+			{Value: "story", Default: true}, {Value: "bug"},
+		}},
+		{Name: "points", Type: workflow.TypeInt, Custom: true},
+		{Name: "tags", Type: workflow.TypeListString, Custom: true},
+	}
+}
+
+func TestReadTemplatesFromFile_NoTemplatesKey(t *testing.T) {
+	path := writeTemplatesWorkflow(t, "views: []\n")
+	templates, err := readTemplatesFromFile(path, templateTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templates != nil {
+		t.Fatalf("expected no templates, got %d", len(templates))
+	}
+}
+
+func TestReadTemplatesFromFile_ParsesAndCoerces(t *testing.T) {
+	path := writeTemplatesWorkflow(t, `templates:
+  - name: bug
+    description: Bug report
+    title: "Bug: {title}"
+    folder: ./bugs/
+    fields:
+      type: bug
+      points: 3
+      tags: [triage, triage, ui]
+    body: |
+      ## Steps to reproduce
+
+      ## Expected
+`)
+	templates, err := readTemplatesFromFile(path, templateTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("expected 1 template, got %d", len(templates))
+	}
+	tmpl := templates[0]
+	if tmpl.Name != "bug" || tmpl.Label() != "Bug report" || tmpl.Title != "Bug: {title}" {
+		t.Errorf("unexpected template header: %+v", tmpl)
+	}
+	if tmpl.Folder != "bugs" {
+		t.Errorf("folder = %q, want cleaned %q", tmpl.Folder, "bugs")
+	}
+	if tmpl.Fields["type"] != "bug" || tmpl.Fields["points"] != 3 {
+		t.Errorf("unexpected fields: %v", tmpl.Fields)
+	}
+	if tags, ok := tmpl.Fields["tags"].([]string); !ok || len(tags) != 2 {
+		t.Errorf("tags = %#v, want normalized 2-element list", tmpl.Fields["tags"])
+	}
+	if strings.HasSuffix(tmpl.Body, "\n") {
+		t.Errorf("body should have trailing newlines trimmed, got %q", tmpl.Body)
+	}
+}
+
+func TestReadTemplatesFromFile_LabelFallsBackToName(t *testing.T) {
+	path := writeTemplatesWorkflow(t, "templates:\n  - name: spike\n")
+	templates, err := readTemplatesFromFile(path, templateTestFields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templates[0].Label() != "spike" {
+		t.Errorf("Label() = %q, want %q", templates[0].Label(), "spike")
+	}
+}
+
+func TestReadTemplatesFromFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing name", "templates:\n  - title: x\n", "name is required"},
+		{"bad name", "templates:\n  - name: \"my bug\"\n", "must contain only"},
+		{"duplicate", "templates:\n  - name: bug\n  - name: BUG\n", "duplicate template name"},
+		{"unknown field", "templates:\n  - name: bug\n    fields:\n      severity: high\n", "unknown field"},
+		{"system field", "templates:\n  - name: bug\n    fields:\n      title: x\n", "system field"},
+		{"bad enum", "templates:\n  - name: bug\n    fields:\n      type: epic\n", "not one of"},
+		{"bad int", "templates:\n  - name: bug\n    fields:\n      points: many\n", "expected integer"},
+		{"absolute folder", "templates:\n  - name: bug\n    folder: /tmp\n", "must be relative"},
+		{"escaping folder", "templates:\n  - name: bug\n    folder: ../elsewhere\n", "must not leave"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTemplatesWorkflow(t, tt.content)
+			_, err := readTemplatesFromFile(path, templateTestFields())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindTemplate_CaseInsensitive(t *testing.T) {
+	ResetWorkflowTemplatesForTest([]TikiTemplate{{Name: "bug"}})
+	t.Cleanup(func() { ResetWorkflowTemplatesForTest(nil) })
+
+	if _, ok := FindTemplate("Bug"); !ok {
+		t.Error("expected FindTemplate to match case-insensitively")
+	}
+	if _, ok := FindTemplate("spike"); ok {
+		t.Error("expected unknown template to be absent")
+	}
+}
+
+func TestSplitCreateFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantStmt string
+		wantName string
+	}{
+		{"plain create", `create title="x"`, `create title="x"`, ""},
+		{"select untouched", `select where title = "from"`, `select where title = "from"`, ""},
+		{"with assignments", `create from "bug" title="Crash"`, `create title="Crash"`, "bug"},
+		{"leading whitespace", `  create  from  "bug" priority="high"`, `  create priority="high"`, "bug"},
+		{"bare", `create from "bug"`, `create title=title`, "bug"},
+		{"bare trailing space", "create from \"bug\"  \n", `create title=title`, "bug"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, name := SplitCreateFrom(tt.src)
+			if stmt != tt.wantStmt || name != tt.wantName {
+				t.Errorf("SplitCreateFrom(%q) = (%q, %q), want (%q, %q)", tt.src, stmt, name, tt.wantStmt, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestCoerceFieldValue(t *testing.T) {
+	fields := templateTestFields()
+	typeField, points, tags := fields[0], fields[1], fields[2]
+	recurrence := workflow.FieldDef{Name: "recurrence", Type: workflow.TypeRecurrence, Custom: true}
+
+	if v, err := CoerceFieldValue(points, float64(5)); err != nil || v != 5 {
+		t.Errorf("points = %v, %v; want 5", v, err)
+	}
+	if v, err := CoerceFieldValue(tags, []interface{}{"a", "b"}); err != nil || strings.Join(v.([]string), ",") != "a,b" {
+		t.Errorf("tags = %v, %v; want [a b]", v, err)
+	}
+	if v, err := CoerceFieldValue(recurrence, "0 0 * * MON"); err != nil || v != "0 0 * * MON" {
+		t.Errorf("recurrence = %v, %v", v, err)
+	}
+	if _, err := CoerceFieldValue(typeField, "epic"); err == nil {
+		t.Error("expected an unknown enum value to be rejected")
+	}
+	if _, err := CoerceFieldValue(points, "five"); err == nil {
+		t.Error("expected a string to be rejected for an integer field")
+	}
+}
@@ -4,22 +4,26 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
 
 	collectionutil "github.com/boolean-maybe/ruki/collections"
 	"github.com/boolean-maybe/tiki/workflow"
+	"github.com/boolean-maybe/tiki/workflow/value"
 	"gopkg.in/yaml.v3"
 )
 
 // customFieldYAML represents a single field entry in workflow.yaml fields:.
 type customFieldYAML struct {
-	Name    string          `yaml:"name"`
-	Type    string          `yaml:"type"`
-	Caption string          `yaml:"caption,omitempty"` // optional display caption; defaults to Name
-	Values  []enumValueYAML `yaml:"values,omitempty"`  // enum only
-	Default interface{}     `yaml:"default,omitempty"` // creation default for non-enum
+	Name     string          `yaml:"name"`
+	Type     string          `yaml:"type"`
+	Caption  string          `yaml:"caption,omitempty"`  // optional display caption; defaults to Name
+	Values   []enumValueYAML `yaml:"values,omitempty"`   // enum and enumList only
+	Default  interface{}     `yaml:"default,omitempty"`  // creation default for non-enum
+	Unit     string          `yaml:"unit,omitempty"`     // number only
+	Computed string          `yaml:"computed,omitempty"` // ruki expression; makes the field read-only
 }
 
 // customFieldFileData is the minimal YAML structure for reading fields from
@@ -136,8 +140,62 @@ func LoadWorkflowFields() error {
 		return fmt.Errorf("registering workflow fields from %s: %w", files[0], err)
 	}
 
+	// templates preset field values, so they are validated against the
+	// catalog that was just registered and loaded together with it.
+	templates, err := LoadTemplatesFromFile(files[0], defs)
+	if err != nil {
+		return err
+	}
+	setWorkflowTemplates(templates)
+
+	// the dependencies section names a tikiIdList field and reads the
+	// terminal statuses of the done section, so it is validated against the
+	// same catalog; it also toggles the derived blocked/blockers/depth fields.
+	deps, err := LoadDependenciesFromFile(files[0], defs)
+	if err != nil {
+		return err
+	}
+	if err := setWorkflowDependencies(deps); err != nil {
+		return fmt.Errorf("registering dependencies from %s: %w", files[0], err)
+	}
+
+	// the sprints section names the sprint and points fields, reads the done
+	// section and toggles the derived currentSprint field.
+	sprints, err := LoadSprintsFromFile(files[0], defs)
+	if err != nil {
+		return err
+	}
+	if err := setWorkflowSprints(sprints); err != nil {
+		return fmt.Errorf("registering sprints from %s: %w", files[0], err)
+	}
+
+	// the agenda section names the due fields; without one the conventional
+	// due, recurrence and assignee fields are used when the catalog has them.
+	agenda, err := LoadAgendaFromFile(files[0], defs)
+	if err != nil {
+		return err
+	}
+	if agenda == nil {
+		done, err := LoadDoneFromFile(files[0], defs)
+		if err != nil {
+			return err
+		}
+		agenda = DefaultAgenda(defs, done)
+	}
+	setWorkflowAgenda(agenda)
+
+	// permission rules name fields and enum values, so they are validated
+	// against the same catalog.
+	permissions, err := LoadPermissionsFromFile(files[0], defs)
+	if err != nil {
+		return err
+	}
+	setWorkflowPermissions(permissions)
+
 	workflowFieldsLoaded.Store(true)
-	slog.Debug("loaded workflow fields", "count", len(defs), "file", files[0])
+	slog.Debug("loaded workflow fields", "count", len(defs), "templates", len(templates),
+		"dependencies", deps != nil, "sprints", sprints != nil, "agenda", agenda != nil,
+		"permissions", permissions != nil, "file", files[0])
 	return nil
 }
 
@@ -162,6 +220,11 @@ func LoadWorkflowFieldsFromFile(path string) ([]workflow.FieldDef, error) {
 // test teardown.
 func ClearWorkflowFields() {
 	workflow.ClearWorkflowFields()
+	setWorkflowTemplates(nil)
+	_ = setWorkflowDependencies(nil)
+	_ = setWorkflowSprints(nil)
+	setWorkflowAgenda(nil)
+	setWorkflowPermissions(nil)
 	workflowFieldsLoaded.Store(false)
 }
 
@@ -247,12 +310,29 @@ func convertWorkflowFieldDef(def customFieldYAML) (workflow.FieldDef, error) {
 		Custom:  true,
 		Caption: def.Caption,
 	}
+	if def.Unit != "" {
+		if vt != workflow.TypeNumber {
+			return workflow.FieldDef{}, fmt.Errorf("unit is only valid for number fields")
+		}
+		fd.Unit = strings.TrimSpace(def.Unit)
+	}
+	if expr := strings.TrimSpace(def.Computed); expr != "" {
+		if def.Default != nil {
+			return workflow.FieldDef{}, fmt.Errorf("computed fields cannot have a default")
+		}
+		if hasEnumDefault(def.Values) {
+			return workflow.FieldDef{}, fmt.Errorf("computed fields cannot mark a value default")
+		}
+		fd.Computed = expr
+	} else if vt == workflow.TypeDuration {
+		return workflow.FieldDef{}, fmt.Errorf("duration fields must be computed")
+	}
 
-	if vt == workflow.TypeEnum {
+	if vt.IsEnum() {
 		if len(def.Values) == 0 {
-			return workflow.FieldDef{}, fmt.Errorf("enum field requires non-empty values list")
+			return workflow.FieldDef{}, fmt.Errorf("%s field requires non-empty values list", def.Type)
 		}
-		if def.Default != nil {
+		if vt == workflow.TypeEnum && def.Default != nil {
 			return workflow.FieldDef{}, fmt.Errorf("field-level default is not supported for enum fields; mark one enum value default: true")
 		}
 		fd.EnumValues = make([]workflow.EnumValue, 0, len(def.Values))
@@ -260,6 +340,9 @@ func convertWorkflowFieldDef(def customFieldYAML) (workflow.FieldDef, error) {
 			if v.Value == "" {
 				return workflow.FieldDef{}, fmt.Errorf("enum value has empty value")
 			}
+			if vt == workflow.TypeEnumList && v.Default {
+				return workflow.FieldDef{}, fmt.Errorf("enumList values cannot be marked default; use a field-level default list")
+			}
 			visual := strings.TrimSpace(v.Visual)
 			if err := workflow.ValidateVisualMarkup(visual); err != nil {
 				return workflow.FieldDef{}, fmt.Errorf("enum value %q: %w", v.Value, err)
@@ -271,14 +354,14 @@ func convertWorkflowFieldDef(def customFieldYAML) (workflow.FieldDef, error) {
 				Default: v.Default,
 			})
 		}
-		return fd, nil
-	}
-
-	if len(def.Values) > 0 {
-		return workflow.FieldDef{}, fmt.Errorf("values list is only valid for enum fields")
+		if vt == workflow.TypeEnum {
+			return fd, nil
+		}
+	} else if len(def.Values) > 0 {
+		return workflow.FieldDef{}, fmt.Errorf("values list is only valid for enum and enumList fields")
 	}
 	if def.Default != nil {
-		coerced, err := coerceFieldDefault(vt, def.Default, nil)
+		coerced, err := coerceFieldDefault(vt, def.Default, fd.AllowedValues())
 		if err != nil {
 			return workflow.FieldDef{}, fmt.Errorf("invalid default: %w", err)
 		}
@@ -287,6 +370,16 @@ func convertWorkflowFieldDef(def customFieldYAML) (workflow.FieldDef, error) {
 	return fd, nil
 }
 
+// hasEnumDefault reports whether any enum value is marked default: true.
+func hasEnumDefault(values []enumValueYAML) bool {
+	for _, v := range values {
+		if v.Default {
+			return true
+		}
+	}
+	return false
+}
+
 // parseFieldType maps workflow.yaml type strings to workflow.ValueType.
 func parseFieldType(s string) (workflow.ValueType, error) {
 	switch strings.ToLower(s) {
@@ -310,8 +403,18 @@ func parseFieldType(s string) (workflow.ValueType, error) {
 		return workflow.TypeListRef, nil
 	case "recurrence":
 		return workflow.TypeRecurrence, nil
+	case "tikiid":
+		return workflow.TypeRef, nil
+	case "enumlist":
+		return workflow.TypeEnumList, nil
+	case "url":
+		return workflow.TypeURL, nil
+	case "number":
+		return workflow.TypeNumber, nil
+	case "duration":
+		return workflow.TypeDuration, nil
 	default:
-		return 0, fmt.Errorf("unknown field type %q (valid: text, user, integer, boolean, date, datetime, enum, stringList, tikiIdList, recurrence)", s)
+		return 0, fmt.Errorf("unknown field type %q (valid: text, user, integer, number, boolean, date, datetime, duration, enum, enumList, stringList, tikiId, tikiIdList, url, recurrence)", s)
 	}
 }
 
@@ -369,8 +472,43 @@ func coerceFieldDefault(vt workflow.ValueType, raw interface{}, allowed []string
 		}
 		return collectionutil.NormalizeRefSet(ss), nil
 
+	case workflow.TypeRef:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected tiki id string, got %T", raw)
+		}
+		return strings.ToUpper(strings.TrimSpace(s)), nil
+
+	case workflow.TypeEnumList:
+		ss, err := coerceStringList(raw)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range ss {
+			if !slices.Contains(allowed, s) {
+				return nil, fmt.Errorf("value %q is not one of %s", s, strings.Join(allowed, ", "))
+			}
+		}
+		return ss, nil
+
+	case workflow.TypeURL:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected URL string, got %T", raw)
+		}
+		if err := value.ValidateURL(s); err != nil {
+			return nil, err
+		}
+		return strings.TrimSpace(s), nil
+
+	case workflow.TypeNumber:
+		f, ok := value.NumberOf(raw)
+		if !ok {
+			return nil, fmt.Errorf("expected number, got %v", raw)
+		}
+		return f, nil
+
 	default:
-		_ = allowed
 		return nil, fmt.Errorf("default values not supported for field type %d", vt)
 	}
 }
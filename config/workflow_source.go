@@ -115,16 +115,58 @@ func ValidateWorkflowContent(content string) (*ValidatedWorkflow, error) {
 		return nil, err
 	}
 
+	hookDefs, err := LoadHookDefsFromFile(tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := LoadTemplatesFromFile(tmp.Name(), fieldDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := LoadDependenciesFromFile(tmp.Name(), fieldDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	sprints, err := LoadSprintsFromFile(tmp.Name(), fieldDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	agenda, err := LoadAgendaFromFile(tmp.Name(), fieldDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := LoadPermissionsFromFile(tmp.Name(), fieldDefs)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ValidatedWorkflow{
-		FieldDefs:   fieldDefs,
-		TriggerDefs: triggerDefs,
+		FieldDefs:    fieldDefs,
+		TriggerDefs:  triggerDefs,
+		HookDefs:     hookDefs,
+		Templates:    templates,
+		Dependencies: dependencies,
+		Sprints:      sprints,
+		Agenda:       agenda,
+		Permissions:  permissions,
 	}, nil
 }
 
 // ValidatedWorkflow holds the parsed components of a validated workflow file.
 type ValidatedWorkflow struct {
-	FieldDefs   []workflow.FieldDef
-	TriggerDefs []TriggerDef
+	FieldDefs    []workflow.FieldDef
+	TriggerDefs  []TriggerDef
+	HookDefs     []HookDef
+	Templates    []TikiTemplate
+	Dependencies *DependencyConfig // nil when the workflow declares no dependencies: section
+	Sprints      *SprintConfig     // nil when the workflow declares no sprints: section
+	Agenda       *AgendaConfig     // nil when the workflow declares no agenda: section
+	Permissions  *PermissionConfig // nil when the workflow declares no permissions: section
 }
 
 func fetchWorkflowURL(url string) (string, error) {
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// WorkspaceConfig is one entry of the `workspaces:` list in config.yaml: a
+// document root mounted into the store under a short prefix.
+type WorkspaceConfig struct {
+	Prefix string `mapstructure:"prefix"`
+	Path   string `mapstructure:"path"`
+}
+
+// Workspace is a validated workspace. Prefix is upper-cased and Dir is
+// absolute, with a relative `path:` resolved against the document root.
+type Workspace struct {
+	Prefix string
+	Dir    string
+}
+
+// workspacePrefixPattern keeps prefixes short and unambiguous in qualified
+// ids (`BE:ABC123`): a letter followed by up to seven letters or digits.
+var workspacePrefixPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,7}$`)
+
+// GetWorkspaces returns the workspaces mounted by config.yaml, in declaration
+// order, or nil when the section is absent. The first workspace is the home
+// workspace: new tikis are created there.
+func GetWorkspaces() ([]Workspace, error) {
+	var raw []WorkspaceConfig
+	if err := viper.UnmarshalKey("workspaces", &raw); err != nil {
+		return nil, fmt.Errorf("workspaces: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	return resolveWorkspaces(raw, GetDocDir())
+}
+
+// resolveWorkspaces validates the entries and resolves their paths against
+// root. Prefixes and directories must be unique, and no workspace may live
+// inside another one — its documents would load twice.
+func resolveWorkspaces(raw []WorkspaceConfig, root string) ([]Workspace, error) {
+	out := make([]Workspace, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for i, entry := range raw {
+		prefix := strings.ToUpper(strings.TrimSpace(entry.Prefix))
+		if !workspacePrefixPattern.MatchString(prefix) {
+			return nil, fmt.Errorf("workspaces[%d]: prefix %q must be a letter followed by up to 7 letters or digits", i, entry.Prefix)
+		}
+		if seen[prefix] {
+			return nil, fmt.Errorf("workspaces[%d]: duplicate prefix %q", i, prefix)
+		}
+		seen[prefix] = true
+
+		path := strings.TrimSpace(os.ExpandEnv(entry.Path))
+		if path == "" {
+			return nil, fmt.Errorf("workspaces[%d] (%s): path is required", i, prefix)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		dir, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("workspaces[%d] (%s): %w", i, prefix, err)
+		}
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("workspaces[%d] (%s): %w", i, prefix, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("workspaces[%d] (%s): %s is not a directory", i, prefix, dir)
+		}
+		for _, other := range out {
+			if dirContains(other.Dir, dir) || dirContains(dir, other.Dir) {
+				return nil, fmt.Errorf("workspaces[%d] (%s): %s overlaps workspace %s at %s", i, prefix, dir, other.Prefix, other.Dir)
+			}
+		}
+		out = append(out, Workspace{Prefix: prefix, Dir: dir})
+	}
+	return out, nil
+}
+
+// dirContains reports whether path is dir itself or lies below it.
+func dirContains(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
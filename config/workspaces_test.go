@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{"backend", "frontend", "backend/docs"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "notes.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := resolveWorkspaces([]WorkspaceConfig{
+		{Prefix: "be", Path: "backend"},
+		{Prefix: "FE", Path: filepath.Join(root, "frontend")},
+	}, root)
+	if err != nil {
+		t.Fatalf("resolveWorkspaces: %v", err)
+	}
+	want := []Workspace{
+		{Prefix: "BE", Dir: filepath.Join(root, "backend")},
+		{Prefix: "FE", Dir: filepath.Join(root, "frontend")},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("resolveWorkspaces = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		raw  []WorkspaceConfig
+		want string
+	}{
+		{"bad prefix", []WorkspaceConfig{{Prefix: "back-end", Path: "backend"}}, "must be a letter"},
+		{"duplicate prefix", []WorkspaceConfig{{Prefix: "BE", Path: "backend"}, {Prefix: "be", Path: "frontend"}}, "duplicate prefix"},
+		{"missing path", []WorkspaceConfig{{Prefix: "BE"}}, "path is required"},
+		{"missing dir", []WorkspaceConfig{{Prefix: "BE", Path: "nowhere"}}, "no such file"},
+		{"not a dir", []WorkspaceConfig{{Prefix: "BE", Path: "notes.md"}}, "not a directory"},
+		{"nested", []WorkspaceConfig{{Prefix: "BE", Path: "backend"}, {Prefix: "DOCS", Path: "backend/docs"}}, "overlaps workspace BE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveWorkspaces(tt.raw, root)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
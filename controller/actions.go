@@ -1,8 +1,13 @@
 package controller
 
 import (
+	"slices"
+
 	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/util"
+	"github.com/boolean-maybe/tiki/workflow"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -20,6 +25,7 @@ const (
 	ActionToggleHeader ActionID = "toggle_header"
 	ActionOpenPalette  ActionID = "open_palette"
 	ActionEditWorkflow ActionID = "edit_workflow"
+	ActionShowOverdue  ActionID = "show_overdue"
 
 	ActionOpenMarkdownTree ActionID = "open_markdown_tree"
 )
@@ -28,11 +34,27 @@ const (
 const (
 	ActionMoveTikiLeft  ActionID = "move_tiki_left"
 	ActionMoveTikiRight ActionID = "move_tiki_right"
+	ActionMoveTikiUp    ActionID = "move_tiki_up"
+	ActionMoveTikiDown  ActionID = "move_tiki_down"
 	ActionNewTiki       ActionID = "new_tiki"
 	ActionNavLeft       ActionID = "nav_left"
 	ActionNavRight      ActionID = "nav_right"
 	ActionNavUp         ActionID = "nav_up"
 	ActionNavDown       ActionID = "nav_down"
+
+	ActionToggleSwimlane  ActionID = "toggle_swimlane"
+	ActionExpandSwimlanes ActionID = "expand_swimlanes"
+)
+
+// ActionID values for calendar views. Day navigation and rescheduling reuse
+// the board's nav_* and move_tiki_* actions.
+const (
+	ActionCalendarToday      ActionID = "calendar_today"
+	ActionCalendarToggleMode ActionID = "calendar_toggle_mode"
+	ActionCalendarPrevPeriod ActionID = "calendar_prev_period"
+	ActionCalendarNextPeriod ActionID = "calendar_next_period"
+	ActionCalendarNextEntry  ActionID = "calendar_next_entry"
+	ActionCalendarPrevEntry  ActionID = "calendar_prev_entry"
 )
 
 // ActionID values for tiki detail view actions.
@@ -42,6 +64,9 @@ const (
 	ActionFullscreen ActionID = "fullscreen"
 	ActionCloneTiki  ActionID = "clone_tiki"
 	ActionChat       ActionID = "chat"
+	ActionAttach     ActionID = "attach"
+	ActionOpenLink   ActionID = "open_link"
+	ActionChecklist  ActionID = "toggle_checklist"
 
 	// ActionDetailEditStub: registered on configurable detail views so the
 	// Edit keybinding stays reserved during Phase 1. Phase 2 replaces the
@@ -92,6 +117,18 @@ const (
 const (
 	ActionNavigateBack    ActionID = "navigate_back"
 	ActionNavigateForward ActionID = "navigate_forward"
+	ActionFindInDocument  ActionID = "find_in_document"
+	ActionFindNext        ActionID = "find_next"
+	ActionFindPrevious    ActionID = "find_previous"
+	ActionToggleOutline   ActionID = "toggle_outline"
+)
+
+// ActionID values for the filters of activity views.
+const (
+	ActionActivityUser  ActionID = "activity_user"
+	ActionActivityField ActionID = "activity_field"
+	ActionActivitySince ActionID = "activity_since"
+	ActionActivityUntil ActionID = "activity_until"
 )
 
 // PluginInfo provides the minimal info needed to register plugin actions.
@@ -264,6 +301,12 @@ const (
 	RequireSelectionMany Requirement = "selection:many"
 	RequireDetailPlugin  Requirement = "detail-plugin"
 	RequireSingleLane    Requirement = "single-lane"
+	// RequireAttachments marks a workflow that declares the `attachments`
+	// stringList field, so the Attach action has somewhere to record files.
+	RequireAttachments Requirement = "attachments"
+	// RequireLinks marks a workflow that declares at least one url field, so
+	// Open link has a field to read.
+	RequireLinks Requirement = "links"
 	// RequireLaneMoveActions marks a board whose lanes carry move actions, so
 	// Move ←/→ can actually relocate a tiki. Boards whose lanes are pure
 	// filters (e.g. SLA Watch's dueBy ranges) have no target value to set, so
@@ -405,11 +448,54 @@ func SetLaneMoveablePredicate(fn func(model.ViewID) bool) {
 	laneMoveablePredicate = fn
 }
 
+// deniedAttrPrefix prefixes the attributes naming the permission rules the
+// current user fails on the selected tiki, e.g. "denied:delete" or
+// "denied:set:priority=high". Actions gate on them with a negated token.
+const deniedAttrPrefix = "denied:"
+
+// deniedPredicate returns the permission rule tokens the current user fails
+// on the tiki with the given id. Set at bootstrap. Defaults to none, so
+// workflows without permissions and tests deny nothing.
+var deniedPredicate = func(string) []string { return nil }
+
+// SetDeniedPredicate installs the predicate used by BuildAppContext to grey
+// out actions the workflow's permissions forbid. Bootstrap wires this once
+// per session. Passing nil resets to the default.
+func SetDeniedPredicate(fn func(tikiID string) []string) {
+	if fn == nil {
+		deniedPredicate = func(string) []string { return nil }
+		return
+	}
+	deniedPredicate = fn
+}
+
+// deniedRequirementsMet reports whether the tiki with the given id passes
+// require, the negated `denied:` requirements inferred from a lane or band
+// action.
+func deniedRequirementsMet(require []string, tikiID string) bool {
+	ctx := NewAppContext()
+	for _, token := range deniedPredicate(tikiID) {
+		ctx.Set(deniedAttrPrefix + token)
+	}
+	reqs := make([]Requirement, len(require))
+	for i, r := range require {
+		reqs[i] = Requirement(r)
+	}
+	return ActionEnabled(Action{Require: reqs}, ctx)
+}
+
+// notBlocked is the requirement greying out a move the active view's
+// MoveGuard reports as blocked.
+func notBlocked(id ActionID) Requirement {
+	return Requirement("!" + deniedAttrPrefix + string(id))
+}
+
 // BuildAppContext constructs an AppContext from the current UI state.
 func BuildAppContext(currentView *ViewEntry, activeView View) AppContext {
 	ctx := NewAppContext()
 
 	selectedCount := 0
+	selectedID := ""
 	// A live SelectableView is authoritative about its own selection: when the
 	// active view can answer, its answer wins and the params fallback below is
 	// skipped entirely. Otherwise a stale TikiID left in the nav params would
@@ -418,11 +504,13 @@ func BuildAppContext(currentView *ViewEntry, activeView View) AppContext {
 	_, activeViewIsSelectable := activeView.(SelectableView)
 	if sv, ok := activeView.(SelectableView); ok && sv.GetSelectedID() != "" {
 		selectedCount = 1
+		selectedID = sv.GetSelectedID()
 	}
 
 	if !activeViewIsSelectable && selectedCount == 0 && currentView != nil && IsDetailView(currentView.ViewID) {
-		if model.DecodePluginViewParams(currentView.Params).TikiID != "" {
+		if id := model.DecodePluginViewParams(currentView.Params).TikiID; id != "" {
 			selectedCount = 1
+			selectedID = id
 		}
 	}
 
@@ -434,12 +522,23 @@ func BuildAppContext(currentView *ViewEntry, activeView View) AppContext {
 	// selection via nav passthrough without overriding a live "nothing
 	// selected" answer.
 	if !activeViewIsSelectable && selectedCount == 0 && currentView != nil && model.IsPluginViewID(currentView.ViewID) {
-		if model.DecodePluginViewParams(currentView.Params).TikiID != "" {
+		if id := model.DecodePluginViewParams(currentView.Params).TikiID; id != "" {
 			selectedCount = 1
+			selectedID = id
 		}
 	}
 
 	applySelectionCardinality(ctx, selectedCount)
+	if selectedID != "" {
+		for _, token := range deniedPredicate(selectedID) {
+			ctx.Set(deniedAttrPrefix + token)
+		}
+	}
+	if mg, ok := activeView.(MoveGuard); ok {
+		for _, id := range mg.BlockedMoves() {
+			ctx.Set(deniedAttrPrefix + string(id))
+		}
+	}
 
 	if config.GetAIAgent() != "" {
 		ctx.Set(string(RequireAI))
@@ -449,6 +548,14 @@ func BuildAppContext(currentView *ViewEntry, activeView View) AppContext {
 		ctx.Set(string(RequireDetailPlugin))
 	}
 
+	if fd, ok := workflow.Field(service.AttachmentsField); ok && fd.Type == workflow.TypeListString {
+		ctx.Set(string(RequireAttachments))
+	}
+
+	if len(workflow.FieldsOfType(workflow.TypeURL)) > 0 {
+		ctx.Set(string(RequireLinks))
+	}
+
 	if currentView != nil {
 		ctx.Set("view:" + string(currentView.ViewID))
 		if singleLanePredicate(currentView.ViewID) {
@@ -494,6 +601,19 @@ type Action struct {
 	// view — e.g. single-lane boards have no left/right neighbor to move to.
 	// HideRequire does NOT affect keypress dispatch; only header rendering.
 	HideRequire []Requirement
+	// Then holds the remaining keys of a multi-key binding; Key/Rune/Modifier
+	// is the first key. Sequences are matched by InputRouter, not Match.
+	Then []model.KeyStroke
+}
+
+// KeyString formats the action's binding for display, joining the keys of a
+// sequence with spaces (e.g. "g g").
+func (a Action) KeyString() string {
+	s := util.FormatKeyBinding(a.Key, a.Rune, a.Modifier)
+	for _, k := range a.Then {
+		s += " " + util.FormatKeyBinding(k.Key, k.Rune, k.Modifier)
+	}
+	return s
 }
 
 // keyWithMod is a composite map key for special-key lookups, disambiguating
@@ -513,29 +633,85 @@ type runeWithMod struct {
 // ActionRegistry holds the available actions for a view.
 // - actions slice preserves registration order (needed for header display)
 // - byKey/byRune maps provide O(1) lookups for keyboard matching
+// - sequences holds multi-key bindings, matched key by key by InputRouter
 type ActionRegistry struct {
-	actions []Action               // all registered actions in order
-	byKey   map[keyWithMod]Action  // fast lookup for special keys (arrow keys, function keys, etc.)
-	byRune  map[runeWithMod]Action // fast lookup for character keys (letters, symbols)
+	actions   []Action               // all registered actions in order
+	byKey     map[keyWithMod]Action  // fast lookup for special keys (arrow keys, function keys, etc.)
+	byRune    map[runeWithMod]Action // fast lookup for character keys (letters, symbols)
+	sequences []Action               // multi-key bindings
+
+	// keymap is the user key configuration in effect when the registry was
+	// created; remapped tracks which of its actions were already expanded.
+	keymap   Keymap
+	remapped map[ActionID]bool
 }
 
-// NewActionRegistry creates a new action registry
+// NewActionRegistry creates a new action registry. Actions registered on it
+// take their keys from the installed Keymap when it remaps their ID.
 func NewActionRegistry() *ActionRegistry {
+	return newActionRegistry(activeKeymap)
+}
+
+func newActionRegistry(km Keymap) *ActionRegistry {
 	return &ActionRegistry{
-		actions: make([]Action, 0),
-		byKey:   make(map[keyWithMod]Action),
-		byRune:  make(map[runeWithMod]Action),
+		actions:  make([]Action, 0),
+		byKey:    make(map[keyWithMod]Action),
+		byRune:   make(map[runeWithMod]Action),
+		keymap:   km,
+		remapped: make(map[ActionID]bool),
 	}
 }
 
 // Register adds an action to the registry.
 // The binding is normalized before storage so lookups are always consistent.
+// When the keymap remaps the action's ID, the configured keys replace every
+// default binding of that ID: the first registration expands to one entry per
+// configured key (only the first shown in header and palette) and later
+// registrations of the same ID are dropped.
 func (r *ActionRegistry) Register(action Action) {
+	seqs, ok := r.keymap[action.ID]
+	if !ok {
+		r.register(action)
+		return
+	}
+	if r.remapped[action.ID] {
+		return
+	}
+	r.remapped[action.ID] = true
+	if len(seqs) == 0 {
+		action.Key, action.Rune, action.Modifier, action.Then = 0, 0, 0, nil
+		r.register(action)
+		return
+	}
+	for i, seq := range seqs {
+		bound := action
+		bound.Key, bound.Rune, bound.Modifier = seq[0].Key, seq[0].Rune, seq[0].Modifier
+		bound.Then = seq[1:]
+		if i > 0 {
+			bound.ShowInHeader = false
+			bound.HideFromPalette = true
+		}
+		r.register(bound)
+	}
+}
+
+func (r *ActionRegistry) register(action Action) {
 	action.Key, action.Rune, action.Modifier = normalizeBinding(action.Key, action.Rune, action.Modifier)
+	if len(action.Then) > 0 {
+		then := make([]model.KeyStroke, len(action.Then))
+		for i, k := range action.Then {
+			then[i] = normalizeStroke(k)
+		}
+		action.Then = then
+	}
 	r.actions = append(r.actions, action)
 	if action.Key == 0 && action.Rune == 0 {
 		return // palette-only action — no keybinding to index
 	}
+	if len(action.Then) > 0 {
+		r.sequences = append(r.sequences, action)
+		return
+	}
 	if action.Key == tcell.KeyRune {
 		r.byRune[runeWithMod{action.Rune, action.Modifier}] = action
 	} else {
@@ -606,6 +782,40 @@ func normalizeBinding(key tcell.Key, ch rune, mod tcell.ModMask) (tcell.Key, run
 	return key, ch, mod
 }
 
+// normalizeStroke applies normalizeBinding to a single sequence key.
+func normalizeStroke(k model.KeyStroke) model.KeyStroke {
+	k.Key, k.Rune, k.Modifier = normalizeBinding(k.Key, k.Rune, k.Modifier)
+	return k
+}
+
+// MatchSequence matches typed keys against the registry's multi-key
+// bindings. It returns the action when keys complete a sequence, and reports
+// whether keys are a proper prefix of some sequence (more keys expected).
+func (r *ActionRegistry) MatchSequence(keys []model.KeyStroke) (action *Action, prefix bool) {
+	for i := range r.sequences {
+		a := r.sequences[i]
+		seq := append([]model.KeyStroke{{Key: a.Key, Rune: a.Rune, Modifier: a.Modifier}}, a.Then...)
+		if len(keys) > len(seq) {
+			continue
+		}
+		matched := true
+		for j, k := range keys {
+			if normalizeStroke(k) != seq[j] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if len(keys) == len(seq) {
+			return &a, false
+		}
+		prefix = true
+	}
+	return nil, prefix
+}
+
 // matchBinding is the shared core lookup logic used by both Match() and MatchBinding().
 func (r *ActionRegistry) matchBinding(key tcell.Key, ch rune, mod tcell.ModMask) *Action {
 	key, ch, mod = normalizeBinding(key, ch, mod)
@@ -651,6 +861,7 @@ func DefaultGlobalActions() *ActionRegistry {
 	r.Register(Action{ID: ActionOpenPalette, Key: tcell.KeyCtrlA, Modifier: tcell.ModCtrl, Label: "All actions", ShowInHeader: true, HideFromPalette: true})
 	r.Register(Action{ID: ActionOpenMarkdownTree, Key: tcell.KeyCtrlO, Modifier: tcell.ModCtrl, Label: "Open", ShowInHeader: true})
 	r.Register(Action{ID: ActionEditWorkflow, Label: "Edit Workflow"})
+	r.Register(Action{ID: ActionShowOverdue, Label: "Overdue"})
 	return r
 }
 
@@ -737,6 +948,7 @@ func (r *ActionRegistry) ToHeaderActionsForContext(ctx AppContext) []model.Heade
 			Modifier:     a.Modifier,
 			ShowInHeader: a.ShowInHeader,
 			Enabled:      ActionEnabled(a, ctx),
+			Then:         a.Then,
 		})
 	}
 	return result
@@ -802,8 +1014,8 @@ func PluginViewActions() *ActionRegistry {
 	// boards (no neighbor) and boards whose lanes carry no move actions (nothing
 	// to set — e.g. SLA Watch's filter-only dueBy lanes).
 	hideWhenNotMoveable := []Requirement{notSingleLane, RequireLaneMoveActions}
-	r.Register(Action{ID: ActionMoveTikiLeft, Key: tcell.KeyLeft, Modifier: tcell.ModShift, Label: "Move ←", ShowInHeader: true, Require: moveReq, HideRequire: hideWhenNotMoveable})
-	r.Register(Action{ID: ActionMoveTikiRight, Key: tcell.KeyRight, Modifier: tcell.ModShift, Label: "Move →", ShowInHeader: true, Require: moveReq, HideRequire: hideWhenNotMoveable})
+	r.Register(Action{ID: ActionMoveTikiLeft, Key: tcell.KeyLeft, Modifier: tcell.ModShift, Label: "Move ←", ShowInHeader: true, Require: append(slices.Clip(moveReq), notBlocked(ActionMoveTikiLeft)), HideRequire: hideWhenNotMoveable})
+	r.Register(Action{ID: ActionMoveTikiRight, Key: tcell.KeyRight, Modifier: tcell.ModShift, Label: "Move →", ShowInHeader: true, Require: append(slices.Clip(moveReq), notBlocked(ActionMoveTikiRight)), HideRequire: hideWhenNotMoveable})
 	r.Register(Action{ID: ActionSearch, Key: tcell.KeyRune, Rune: '/', Label: "Search", ShowInHeader: true})
 	r.Register(Action{ID: ActionExecute, Key: tcell.KeyRune, Rune: '!', Label: "Execute", ShowInHeader: true})
 
@@ -813,6 +1025,48 @@ func PluginViewActions() *ActionRegistry {
 	return r
 }
 
+// registerSwimlaneActions adds the band moves and folds to the registry of a
+// board with swimlanes. Boards without swimlanes leave these keys to the
+// workflow.
+func registerSwimlaneActions(r *ActionRegistry) {
+	r.Register(Action{ID: ActionMoveTikiUp, Key: tcell.KeyUp, Modifier: tcell.ModShift, Label: "Move ↑", ShowInHeader: true, Require: []Requirement{RequireID, notBlocked(ActionMoveTikiUp)}})
+	r.Register(Action{ID: ActionMoveTikiDown, Key: tcell.KeyDown, Modifier: tcell.ModShift, Label: "Move ↓", ShowInHeader: true, Require: []Requirement{RequireID, notBlocked(ActionMoveTikiDown)}})
+	r.Register(Action{ID: ActionToggleSwimlane, Key: tcell.KeyRune, Rune: 'z', Label: "Fold", ShowInHeader: true, Require: []Requirement{RequireID}})
+	r.Register(Action{ID: ActionExpandSwimlanes, Key: tcell.KeyRune, Rune: 'Z', Label: "Unfold all", ShowInHeader: true})
+}
+
+// CalendarViewActions returns the action registry for calendar views: arrows
+// move the focused day, Shift-arrows reschedule the selected tiki.
+func CalendarViewActions() *ActionRegistry {
+	r := NewActionRegistry()
+
+	r.Register(Action{ID: ActionNavUp, Key: tcell.KeyUp, Label: "↑", HideFromPalette: true})
+	r.Register(Action{ID: ActionNavDown, Key: tcell.KeyDown, Label: "↓", HideFromPalette: true})
+	r.Register(Action{ID: ActionNavLeft, Key: tcell.KeyLeft, Label: "←", HideFromPalette: true})
+	r.Register(Action{ID: ActionNavRight, Key: tcell.KeyRight, Label: "→", HideFromPalette: true})
+	r.Register(Action{ID: ActionNavUp, Key: tcell.KeyRune, Rune: 'k', Label: "↑", HideFromPalette: true})
+	r.Register(Action{ID: ActionNavDown, Key: tcell.KeyRune, Rune: 'j', Label: "↓", HideFromPalette: true})
+	r.Register(Action{ID: ActionNavLeft, Key: tcell.KeyRune, Rune: 'h', Label: "←", HideFromPalette: true})
+	r.Register(Action{ID: ActionNavRight, Key: tcell.KeyRune, Rune: 'l', Label: "→", HideFromPalette: true})
+	r.Register(Action{ID: ActionCalendarNextEntry, Key: tcell.KeyTab, Label: "Next tiki", HideFromPalette: true})
+	r.Register(Action{ID: ActionCalendarPrevEntry, Key: tcell.KeyBacktab, Label: "Prev tiki", HideFromPalette: true})
+
+	moveReq := []Requirement{RequireID}
+	r.Register(Action{ID: ActionMoveTikiLeft, Key: tcell.KeyLeft, Modifier: tcell.ModShift, Label: "Move ←", ShowInHeader: true, Require: moveReq})
+	r.Register(Action{ID: ActionMoveTikiRight, Key: tcell.KeyRight, Modifier: tcell.ModShift, Label: "Move →", ShowInHeader: true, Require: moveReq})
+	r.Register(Action{ID: ActionMoveTikiUp, Key: tcell.KeyUp, Modifier: tcell.ModShift, Label: "Move ↑", ShowInHeader: true, Require: moveReq})
+	r.Register(Action{ID: ActionMoveTikiDown, Key: tcell.KeyDown, Modifier: tcell.ModShift, Label: "Move ↓", ShowInHeader: true, Require: moveReq})
+	r.Register(Action{ID: ActionCalendarPrevPeriod, Key: tcell.KeyPgUp, Label: "Prev", ShowInHeader: true})
+	r.Register(Action{ID: ActionCalendarNextPeriod, Key: tcell.KeyPgDn, Label: "Next", ShowInHeader: true})
+	r.Register(Action{ID: ActionCalendarToday, Key: tcell.KeyRune, Rune: 't', Label: "Today", ShowInHeader: true})
+	r.Register(Action{ID: ActionCalendarToggleMode, Key: tcell.KeyRune, Rune: 'w', Label: "Month/Week", ShowInHeader: true})
+
+	// plugin activation keys are merged dynamically after plugins load
+	r.MergePluginActions()
+
+	return r
+}
+
 // WikiViewActions returns the action registry for wiki plugin views.
 // Wiki views primarily handle navigation through the NavigableMarkdown component.
 func WikiViewActions() *ActionRegistry {
@@ -825,8 +1079,25 @@ func WikiViewActions() *ActionRegistry {
 	r.Register(Action{ID: ActionNavigateBack, Key: tcell.KeyLeft, Label: "← Back", ShowInHeader: true})
 	r.Register(Action{ID: ActionNavigateForward, Key: tcell.KeyRight, Label: "Forward →", ShowInHeader: true})
 
+	// in-document search and the heading outline (handled by the view)
+	r.Register(Action{ID: ActionFindInDocument, Key: tcell.KeyRune, Rune: '/', Label: "Find", ShowInHeader: true})
+	r.Register(Action{ID: ActionFindNext, Key: tcell.KeyRune, Rune: 'n', Label: "Next match"})
+	r.Register(Action{ID: ActionFindPrevious, Key: tcell.KeyRune, Rune: 'N', Label: "Previous match"})
+	r.Register(Action{ID: ActionToggleOutline, Key: tcell.KeyRune, Rune: 'o', Label: "Outline", ShowInHeader: true})
+
 	// plugin activation keys are merged dynamically after plugins load
 	r.MergePluginActions()
 
 	return r
 }
+
+// ActivityViewActions returns the wiki actions plus the filters of an
+// activity feed; each asks for its new value in the input box.
+func ActivityViewActions() *ActionRegistry {
+	r := WikiViewActions()
+	r.Register(Action{ID: ActionActivityUser, Key: tcell.KeyRune, Rune: 'u', Label: "User", ShowInHeader: true})
+	r.Register(Action{ID: ActionActivityField, Key: tcell.KeyRune, Rune: 'f', Label: "Field", ShowInHeader: true})
+	r.Register(Action{ID: ActionActivitySince, Key: tcell.KeyRune, Rune: 't', Label: "Since", ShowInHeader: true})
+	r.Register(Action{ID: ActionActivityUntil, Key: tcell.KeyRune, Rune: 'T', Label: "Until", ShowInHeader: true})
+	return r
+}
@@ -330,11 +330,11 @@ func TestDefaultGlobalActions(t *testing.T) {
 	registry := DefaultGlobalActions()
 	actions := registry.GetActions()
 
-	if len(actions) != 7 {
-		t.Errorf("expected 7 global actions, got %d", len(actions))
+	if len(actions) != 8 {
+		t.Errorf("expected 8 global actions, got %d", len(actions))
 	}
 
-	expectedActions := []ActionID{ActionBack, ActionQuit, ActionRefresh, ActionToggleHeader, ActionOpenPalette, ActionOpenMarkdownTree, ActionEditWorkflow}
+	expectedActions := []ActionID{ActionBack, ActionQuit, ActionRefresh, ActionToggleHeader, ActionOpenPalette, ActionOpenMarkdownTree, ActionEditWorkflow, ActionShowOverdue}
 	for i, expected := range expectedActions {
 		if i >= len(actions) {
 			t.Errorf("missing action at index %d: want %v", i, expected)
@@ -365,12 +365,12 @@ func TestDefaultGlobalActions(t *testing.T) {
 			}
 			continue
 		}
-		// ActionEditWorkflow is palette-only (no key, no header)
-		if a.ID == ActionEditWorkflow {
-			if a.ShowInHeader {
-				t.Error("ActionEditWorkflow should have ShowInHeader=false")
+		// ActionEditWorkflow and ActionShowOverdue are palette-only (no key, no header)
+		if a.ID == ActionEditWorkflow || a.ID == ActionShowOverdue {
+			if a.ShowInHeader || a.Key != 0 {
+				t.Errorf("%v should be palette-only", a.ID)
 			}
-			if a.Label != "Edit Workflow" {
+			if a.ID == ActionEditWorkflow && a.Label != "Edit Workflow" {
 				t.Errorf("ActionEditWorkflow label = %q, want %q", a.Label, "Edit Workflow")
 			}
 			continue
@@ -907,6 +907,63 @@ func TestBuildAppContext_SelectableView(t *testing.T) {
 	}
 }
 
+// TestBuildAppContext_DeniedPermissions checks that the permission rules the
+// user fails on the selected tiki grey out actions gated on them.
+func TestBuildAppContext_DeniedPermissions(t *testing.T) {
+	SetDeniedPredicate(func(id string) []string {
+		if id == "ABC123" {
+			return []string{"delete", "set:priority=high"}
+		}
+		return nil
+	})
+	defer SetDeniedPredicate(nil)
+
+	entry := &ViewEntry{ViewID: model.MakePluginViewID("Kanban")}
+	deleteAction := Action{Require: []Requirement{RequireID, "!denied:delete"}}
+
+	ctx := BuildAppContext(entry, &mockSelectableView{selectedID: "ABC123"})
+	if !ctx.Has("denied:set:priority=high") {
+		t.Error("context should name the denied rules of the selected tiki")
+	}
+	if ActionEnabled(deleteAction, ctx) {
+		t.Error("delete should be disabled on a tiki the user may not delete")
+	}
+
+	ctx = BuildAppContext(entry, &mockSelectableView{selectedID: "DEF456"})
+	if !ActionEnabled(deleteAction, ctx) {
+		t.Error("delete should be enabled on a tiki the user may delete")
+	}
+}
+
+type moveGuardView struct {
+	mockSelectableView
+	blocked []ActionID
+}
+
+func (m *moveGuardView) BlockedMoves() []ActionID { return m.blocked }
+
+// TestBuildAppContext_BlockedMoves checks that moves the active view reports
+// as blocked are greyed out and the others are not.
+func TestBuildAppContext_BlockedMoves(t *testing.T) {
+	entry := &ViewEntry{ViewID: model.MakePluginViewID("Kanban")}
+	view := &moveGuardView{mockSelectableView: mockSelectableView{selectedID: "ABC123"}, blocked: []ActionID{ActionMoveTikiRight}}
+	ctx := BuildAppContext(entry, view)
+
+	registry := PluginViewActions()
+	for _, a := range registry.GetActions() {
+		switch a.ID {
+		case ActionMoveTikiLeft:
+			if !ActionEnabled(a, ctx) {
+				t.Error("move left should stay enabled")
+			}
+		case ActionMoveTikiRight:
+			if ActionEnabled(a, ctx) {
+				t.Error("move right should be disabled when blocked")
+			}
+		}
+	}
+}
+
 // TestBuildAppContext_EmptyBoardStaleParams reproduces H/H2: a plugin board
 // view with no live selection (empty/filtered board, GetSelectedID == "") must
 // NOT report selection:one just because stale nav params still carry a TikiID.
@@ -1,31 +1,90 @@
 package controller
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/store"
 )
 
-// tikiContextPrompt builds the shared prompt instructing the AI agent about the current tiki.
-func tikiContextPrompt(tikiFilePath string) string {
-	return fmt.Sprintf(
-		"Read the tiki at %s first. "+
-			"This is the tiki the user is currently viewing. "+
-			"When the user asks to modify something without specifying a file, "+
-			"they mean this tiki. "+
-			"After reading, chat with the user about it.",
-		tikiFilePath,
-	)
+// agentContextFor describes the tiki an agent is started on.
+func agentContextFor(tikiStore store.ReadStore, tikiID string) config.AgentContext {
+	ctx := config.AgentContext{ID: tikiID, Workflow: config.FindWorkflowFile()}
+	if tikiStore != nil {
+		ctx.Path = tikiStore.PathForID(tikiID)
+		if tk := tikiStore.GetTiki(tikiID); tk != nil {
+			ctx.Title = tk.Title()
+		}
+	}
+	if ctx.Path == "" {
+		ctx.Path = filepath.Join(config.GetDocDir(), tikiID+".md")
+	}
+	return ctx
 }
 
-// resolveAgentCommand maps a logical agent name to the actual command and arguments.
-// tikiFilePath is the path to the current tiki file being viewed.
-func resolveAgentCommand(agent string, tikiFilePath string) (name string, args []string) {
+// resolveAgentCommand maps a logical agent name to the command line that
+// starts it on the tiki described by ctx.
+func resolveAgentCommand(agent string, ctx config.AgentContext) config.AgentInvocation {
 	tool, ok := config.LookupAITool(agent)
 	if !ok {
 		// unknown agent: treat name as the command, no context injection
-		return agent, nil
+		return config.AgentInvocation{Command: agent}
+	}
+	return tool.Invocation(ctx, tool.ContextPrompt(ctx))
+}
+
+// runAgentChat hands the terminal to agent — the configured ai.agent when
+// empty — on the given tiki, then reloads the tiki to surface any
+// agent-applied edits. Returns false when no agent is configured.
+func runAgentChat(navController *NavigationController, tikiStore store.Store, statusline *model.StatuslineConfig,
+	agent, tikiID string) bool {
+	if agent == "" {
+		agent = config.GetAIAgent()
+	}
+	if agent == "" || tikiID == "" || navController == nil || tikiStore == nil {
+		return false
+	}
+	navController.SuspendAndRunAgent(resolveAgentCommand(agent, agentContextFor(tikiStore, tikiID)))
+	if err := tikiStore.ReloadTiki(tikiID); err != nil && statusline != nil {
+		statusline.SetMessage("reload failed: "+err.Error(), model.MessageLevelError, true)
+	}
+	return true
+}
+
+// proposalTimeout bounds a headless agent run of a `kind: propose` action.
+const proposalTimeout = 5 * time.Minute
+
+// runHeadlessAgent runs an agent without a terminal and returns what it
+// printed. A failing agent's error carries the last line it wrote to stderr.
+func runHeadlessAgent(inv config.AgentInvocation, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, inv.Command, inv.Args...) //nolint:gosec // G204: the agent command line comes from the user's own config.yaml
+	cmd.Env = append(os.Environ(), inv.Env...)
+	if inv.Stdin != "" {
+		cmd.Stdin = strings.NewReader(inv.Stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%s did not answer within %s", inv.Command, timeout)
+		}
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+			return nil, fmt.Errorf("%s: %w: %s", inv.Command, err, last)
+		}
+		return nil, fmt.Errorf("%s: %w", inv.Command, err)
 	}
-	prompt := tikiContextPrompt(tikiFilePath)
-	return tool.Command, tool.PromptArgs(prompt)
+	return stdout.Bytes(), nil
 }
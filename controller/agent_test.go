@@ -3,12 +3,15 @@ package controller
 import (
 	"strings"
 	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
 )
 
 const testTikiPath = "/tmp/tiki-abc123.md"
 
 func TestResolveAgentCommand_Claude(t *testing.T) {
-	name, args := resolveAgentCommand("claude", testTikiPath)
+	inv := resolveAgentCommand("claude", config.AgentContext{Path: testTikiPath})
+	name, args := inv.Command, inv.Args
 	if name != "claude" {
 		t.Errorf("expected name 'claude', got %q", name)
 	}
@@ -24,7 +27,8 @@ func TestResolveAgentCommand_Claude(t *testing.T) {
 }
 
 func TestResolveAgentCommand_Gemini(t *testing.T) {
-	name, args := resolveAgentCommand("gemini", testTikiPath)
+	inv := resolveAgentCommand("gemini", config.AgentContext{Path: testTikiPath})
+	name, args := inv.Command, inv.Args
 	if name != "gemini" {
 		t.Errorf("expected name 'gemini', got %q", name)
 	}
@@ -40,7 +44,8 @@ func TestResolveAgentCommand_Gemini(t *testing.T) {
 }
 
 func TestResolveAgentCommand_Codex(t *testing.T) {
-	name, args := resolveAgentCommand("codex", testTikiPath)
+	inv := resolveAgentCommand("codex", config.AgentContext{Path: testTikiPath})
+	name, args := inv.Command, inv.Args
 	if name != "codex" {
 		t.Errorf("expected name 'codex', got %q", name)
 	}
@@ -53,7 +58,8 @@ func TestResolveAgentCommand_Codex(t *testing.T) {
 }
 
 func TestResolveAgentCommand_OpenCode(t *testing.T) {
-	name, args := resolveAgentCommand("opencode", testTikiPath)
+	inv := resolveAgentCommand("opencode", config.AgentContext{Path: testTikiPath})
+	name, args := inv.Command, inv.Args
 	if name != "opencode" {
 		t.Errorf("expected name 'opencode', got %q", name)
 	}
@@ -69,7 +75,8 @@ func TestResolveAgentCommand_OpenCode(t *testing.T) {
 }
 
 func TestResolveAgentCommand_Unknown(t *testing.T) {
-	name, args := resolveAgentCommand("myagent", testTikiPath)
+	inv := resolveAgentCommand("myagent", config.AgentContext{Path: testTikiPath})
+	name, args := inv.Command, inv.Args
 	if name != "myagent" {
 		t.Errorf("expected name 'myagent', got %q", name)
 	}
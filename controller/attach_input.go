@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/service"
+
+	"github.com/rivo/tview"
+)
+
+// attachPrompt is the InputBar prompt shown while picking a file to attach.
+const attachPrompt = "attach file> "
+
+// startAttachInput opens the InputBar for a path to attach to the given
+// tiki. The file is copied into the tiki's asset folder and recorded in its
+// attachments field by service.AttachFile.
+func (ir *InputRouter) startAttachInput(tikiID string) bool {
+	activeView := ir.navController.GetActiveView()
+	inputableView, ok := activeView.(InputableView)
+	if !ok {
+		return false
+	}
+
+	app := ir.navController.GetApp()
+	inputableView.SetFocusSetter(func(p tview.Primitive) {
+		app.SetFocus(p)
+	})
+
+	inputableView.SetInputSubmitHandler(func(text string) InputSubmitResult {
+		return ir.handleAttachInput(tikiID, text)
+	})
+
+	inputableView.SetInputCancelHandler(func() {
+		inputableView.CancelInputBox()
+	})
+
+	inputBox := inputableView.ShowInputBox(attachPrompt, "")
+	if inputBox != nil {
+		app.SetFocus(inputBox)
+	}
+
+	return true
+}
+
+// handleAttachInput attaches the submitted path. Errors (missing file,
+// rejected update) are shown in the statusline and keep the InputBar open so
+// the path can be corrected.
+func (ir *InputRouter) handleAttachInput(tikiID, text string) InputSubmitResult {
+	path := strings.TrimSpace(text)
+	if path == "" {
+		return InputKeepEditing
+	}
+	rel, err := service.AttachFile(context.Background(), ir.mutationGate, tikiID, path)
+	if err != nil {
+		if ir.statusline != nil {
+			ir.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
+		}
+		return InputKeepEditing
+	}
+	if ir.statusline != nil {
+		ir.statusline.SetMessage("attached "+rel, model.MessageLevelInfo, true)
+	}
+	return InputClose
+}
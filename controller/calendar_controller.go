@@ -0,0 +1,387 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/ruki/recurrence"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// CalendarEntry is one tiki shown on one day of a calendar. Occurrence marks
+// a future occurrence of a recurring tiki rather than its stored date.
+type CalendarEntry struct {
+	Tiki       *tikipkg.Tiki
+	Occurrence bool
+}
+
+// CalendarController backs `kind: calendar` views: it places the filtered
+// tikis on their dates, moves the focused day and reschedules the selected
+// tiki through the mutation gate. Top-level actions run against the selected
+// tiki.
+type CalendarController struct {
+	pluginDef     *plugin.CalendarPlugin
+	tikiStore     store.Store
+	mutationGate  *service.TikiMutationGate
+	navController *NavigationController
+	statusline    *model.StatuslineConfig
+	registry      *ActionRegistry
+	state         *model.CalendarState
+	schema        ruki.Schema
+	globalActions []plugin.PluginAction
+	executor      *PluginExecutor
+	now           func() time.Time
+}
+
+// NewCalendarController creates the controller of a calendar view.
+func NewCalendarController(
+	pluginDef *plugin.CalendarPlugin,
+	navController *NavigationController,
+	statusline *model.StatuslineConfig,
+	progressHub *model.ProgressHub,
+	globalActions []plugin.PluginAction,
+	tikiStore store.Store,
+	mutationGate *service.TikiMutationGate,
+	schema ruki.Schema,
+) *CalendarController {
+	cc := &CalendarController{
+		pluginDef:     pluginDef,
+		tikiStore:     tikiStore,
+		mutationGate:  mutationGate,
+		navController: navController,
+		statusline:    statusline,
+		registry:      CalendarViewActions(),
+		state:         model.NewCalendarState(pluginDef.Mode == plugin.CalendarWeek),
+		schema:        schema,
+		globalActions: globalActions,
+		now:           time.Now,
+	}
+	cc.executor = NewPluginExecutor(tikiStore, mutationGate, statusline, progressHub, schema,
+		pluginDef.GetName(), nil)
+	cc.mergeGlobalActions()
+	return cc
+}
+
+// mergeGlobalActions registers the top-level actions that can run here.
+// Interactive ruki actions are left out: like the wiki, the calendar does not
+// implement the input/choose prompts.
+func (cc *CalendarController) mergeGlobalActions() {
+	for _, ga := range cc.globalActions {
+		switch ga.Kind {
+		case plugin.ActionKindView:
+			if ga.TargetView == cc.pluginDef.GetName() {
+				continue
+			}
+		case plugin.ActionKindRuki:
+			if ga.HasInput || ga.HasChoose {
+				continue
+			}
+		case plugin.ActionKindChat, plugin.ActionKindPropose:
+		default:
+			continue
+		}
+		cc.registry.Register(Action{
+			ID:           ActionID("plugin_action:" + ga.KeyStr),
+			Key:          ga.Key,
+			Rune:         ga.Rune,
+			Modifier:     ga.Modifier,
+			Label:        ga.Label,
+			ShowInHeader: ga.ShowInHeader,
+			Require:      toRequirements(ga.Require),
+		})
+	}
+}
+
+// GetState returns the view's selection state.
+func (cc *CalendarController) GetState() *model.CalendarState { return cc.state }
+
+// GetPluginDef returns the calendar definition.
+func (cc *CalendarController) GetPluginDef() *plugin.CalendarPlugin { return cc.pluginDef }
+
+// Today returns the current day.
+func (cc *CalendarController) Today() time.Time { return model.CalendarDay(cc.now()) }
+
+// Entries returns the entries of every day in [from, to), keyed by day.
+// Within a day, stored dates come before occurrences, then by title.
+func (cc *CalendarController) Entries(from, to time.Time) map[time.Time][]CalendarEntry {
+	from, to = model.CalendarDay(from), model.CalendarDay(to)
+	today := cc.Today()
+	days := make(map[time.Time][]CalendarEntry)
+	for _, tk := range cc.calendarTikis() {
+		date, hasDate, _ := tk.TimeField(cc.pluginDef.DateField)
+		if hasDate && !date.IsZero() {
+			date = model.CalendarDay(date)
+			if !date.Before(from) && date.Before(to) {
+				days[date] = append(days[date], CalendarEntry{Tiki: tk})
+			}
+		} else {
+			hasDate = false
+		}
+
+		rec, ok := cc.recurrenceOf(tk)
+		if !ok {
+			continue
+		}
+		// occurrences follow the stored date and never lie in the past
+		cursor := today.AddDate(0, 0, -1)
+		if hasDate && date.After(cursor) {
+			cursor = date
+		}
+		if start := from.AddDate(0, 0, -1); start.After(cursor) {
+			cursor = start
+		}
+		for {
+			next := recurrence.NextOccurrenceFrom(rec, cursor)
+			if next.IsZero() || !next.Before(to) {
+				break
+			}
+			if !hasDate || !next.Equal(date) {
+				days[next] = append(days[next], CalendarEntry{Tiki: tk, Occurrence: true})
+			}
+			cursor = next
+		}
+	}
+	for day, entries := range days {
+		slices.SortStableFunc(entries, compareCalendarEntries)
+		days[day] = entries
+	}
+	return days
+}
+
+func compareCalendarEntries(a, b CalendarEntry) int {
+	if a.Occurrence != b.Occurrence {
+		if a.Occurrence {
+			return 1
+		}
+		return -1
+	}
+	if c := strings.Compare(strings.ToLower(a.Tiki.Title()), strings.ToLower(b.Tiki.Title())); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Tiki.ID(), b.Tiki.ID())
+}
+
+// DayEntries returns the entries of a single day.
+func (cc *CalendarController) DayEntries(day time.Time) []CalendarEntry {
+	day = model.CalendarDay(day)
+	return cc.Entries(day, day.AddDate(0, 0, 1))[day]
+}
+
+// calendarTikis runs the view filter; without one every tiki is a candidate.
+func (cc *CalendarController) calendarTikis() []*tikipkg.Tiki {
+	allTikis := cc.tikiStore.GetAllTikis()
+	if cc.pluginDef.Filter == nil {
+		return allTikis
+	}
+	var userFunc func() string
+	if userName := getCurrentUserName(cc.tikiStore); userName != "" {
+		userFunc = func() string { return userName }
+	}
+	executor := ruki.NewExecutor(cc.schema, ruki.DocumentFactory(tikipkg.NewDoc), userFunc,
+		ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimePlugin})
+	result, err := executor.Execute(cc.pluginDef.Filter, tikipkg.WrapDocs(allTikis))
+	if err != nil {
+		slog.Error("failed to execute calendar filter", "view", cc.pluginDef.GetName(), "error", err)
+		return nil
+	}
+	return tikipkg.UnwrapDocs(result.Select.Tikis)
+}
+
+// recurrenceOf returns the tiki's recurrence when the view expands them.
+func (cc *CalendarController) recurrenceOf(tk *tikipkg.Tiki) (recurrence.Recurrence, bool) {
+	if cc.pluginDef.RecurrenceField == "" {
+		return "", false
+	}
+	s, present, ok := tk.StringField(cc.pluginDef.RecurrenceField)
+	if !present || !ok {
+		return "", false
+	}
+	rec := recurrence.Recurrence(s)
+	if rec == recurrence.RecurrenceNone || !recurrence.IsValidRecurrence(rec) {
+		return "", false
+	}
+	return rec, true
+}
+
+// SelectedEntry returns the entry selected on the focused day.
+func (cc *CalendarController) SelectedEntry() (CalendarEntry, bool) {
+	entries := cc.DayEntries(cc.state.GetDay())
+	if len(entries) == 0 {
+		return CalendarEntry{}, false
+	}
+	return entries[min(cc.state.GetIndex(), len(entries)-1)], true
+}
+
+// GetSelectedTikiID returns the ID of the selected tiki, or "".
+func (cc *CalendarController) GetSelectedTikiID() string {
+	if e, ok := cc.SelectedEntry(); ok {
+		return e.Tiki.ID()
+	}
+	return ""
+}
+
+// SelectTikiByID focuses the tiki's date and selects it there. Tikis without
+// a date in this view leave the selection unchanged.
+func (cc *CalendarController) SelectTikiByID(id string) bool {
+	tk := cc.tikiStore.GetTiki(id)
+	if tk == nil {
+		return false
+	}
+	date, ok, _ := tk.TimeField(cc.pluginDef.DateField)
+	if !ok || date.IsZero() {
+		return false
+	}
+	return cc.selectOnDay(date, id)
+}
+
+func (cc *CalendarController) selectOnDay(day time.Time, id string) bool {
+	for i, e := range cc.DayEntries(day) {
+		if e.Tiki.ID() == id {
+			cc.state.SetDay(day, i)
+			return true
+		}
+	}
+	return false
+}
+
+// GetActionRegistry returns the actions for the view.
+func (cc *CalendarController) GetActionRegistry() *ActionRegistry { return cc.registry }
+
+// GetPluginName returns the view name.
+func (cc *CalendarController) GetPluginName() string { return cc.pluginDef.GetName() }
+
+// ShowNavigation returns true — calendar views show plugin navigation keys.
+func (cc *CalendarController) ShowNavigation() bool { return true }
+
+// HandleAction moves the focused day, cycles the entries of a day,
+// reschedules the selected tiki and runs top-level actions.
+func (cc *CalendarController) HandleAction(actionID ActionID) bool {
+	switch actionID {
+	case ActionNavLeft:
+		return cc.moveDay(0, -1)
+	case ActionNavRight:
+		return cc.moveDay(0, 1)
+	case ActionNavUp:
+		return cc.moveDay(0, -7)
+	case ActionNavDown:
+		return cc.moveDay(0, 7)
+	case ActionCalendarPrevPeriod:
+		return cc.movePeriod(-1)
+	case ActionCalendarNextPeriod:
+		return cc.movePeriod(1)
+	case ActionCalendarToday:
+		cc.state.SetDay(cc.Today(), 0)
+		return true
+	case ActionCalendarToggleMode:
+		cc.state.ToggleWeekMode()
+		return true
+	case ActionCalendarNextEntry:
+		return cc.cycleEntry(1)
+	case ActionCalendarPrevEntry:
+		return cc.cycleEntry(-1)
+	case ActionMoveTikiLeft:
+		return cc.Reschedule(-1)
+	case ActionMoveTikiRight:
+		return cc.Reschedule(1)
+	case ActionMoveTikiUp:
+		return cc.Reschedule(-7)
+	case ActionMoveTikiDown:
+		return cc.Reschedule(7)
+	}
+	if keyStr := getPluginActionKeyStr(actionID); keyStr != "" {
+		for i := range cc.globalActions {
+			if ga := &cc.globalActions[i]; ga.KeyStr == keyStr {
+				return dispatchGlobalAction(ga, cc.pluginDef.GetName(), cc.GetSelectedTikiID(), cc.navController, cc.executor)
+			}
+		}
+	}
+	return false
+}
+
+func (cc *CalendarController) moveDay(months, days int) bool {
+	cc.state.SetDay(cc.state.GetDay().AddDate(0, months, days), 0)
+	return true
+}
+
+// movePeriod pages by a month on the grid (keeping the day of month where it
+// exists) and by a week in the agenda.
+func (cc *CalendarController) movePeriod(dir int) bool {
+	if cc.state.IsWeekMode() {
+		return cc.moveDay(0, 7*dir)
+	}
+	day := cc.state.GetDay()
+	first := time.Date(day.Year(), day.Month()+time.Month(dir), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	cc.state.SetDay(first.AddDate(0, 0, min(day.Day(), last)-1), 0)
+	return true
+}
+
+func (cc *CalendarController) cycleEntry(dir int) bool {
+	n := len(cc.DayEntries(cc.state.GetDay()))
+	if n < 2 {
+		return false
+	}
+	cc.state.SetIndex((cc.state.GetIndex() + dir + n) % n)
+	return true
+}
+
+// Reschedule moves the selected tiki days away from the focused day: its
+// date field is set to the new day through the mutation gate, and the
+// selection follows it. Moving an occurrence of a recurring tiki reschedules
+// the tiki itself.
+func (cc *CalendarController) Reschedule(days int) bool {
+	entry, ok := cc.SelectedEntry()
+	if !ok {
+		return false
+	}
+	return cc.RescheduleTo(entry.Tiki.ID(), cc.state.GetDay().AddDate(0, 0, days))
+}
+
+// RescheduleTo sets the tiki's date field to day and selects it there.
+func (cc *CalendarController) RescheduleTo(id string, day time.Time) bool {
+	tk := cc.tikiStore.GetTiki(id)
+	if tk == nil {
+		return false
+	}
+	day = model.CalendarDay(day)
+	updated := tk.Clone()
+	updated.Set(cc.pluginDef.DateField, day)
+	if err := cc.mutationGate.UpdateTiki(context.Background(), updated); err != nil {
+		slog.Error("failed to reschedule tiki", "tiki_id", id, "error", err)
+		if cc.statusline != nil {
+			cc.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
+		}
+		return false
+	}
+	if !cc.selectOnDay(day, id) {
+		// the new date fell outside the filter; keep the focus on that day
+		cc.state.SetDay(day, 0)
+	}
+	return true
+}
+
+// HandleSearch is not applicable to calendar views.
+func (cc *CalendarController) HandleSearch(string) {}
+
+func (cc *CalendarController) GetActionInputSpec(ActionID) (string, ruki.ValueType, bool) {
+	return "", 0, false
+}
+func (cc *CalendarController) CanStartActionInput(ActionID) (string, ruki.ValueType, bool) {
+	return "", 0, false
+}
+func (cc *CalendarController) HandleActionInput(ActionID, string) InputSubmitResult {
+	return InputKeepEditing
+}
+func (cc *CalendarController) GetActionChooseSpec(ActionID) (string, bool) { return "", false }
+func (cc *CalendarController) CanStartActionChoose(ActionID) (string, []*tikipkg.Tiki, bool) {
+	return "", nil, false
+}
+func (cc *CalendarController) HandleActionChoose(ActionID, string) bool { return false }
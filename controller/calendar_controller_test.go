@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+func calendarDate(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newCalendarHarness(t *testing.T) (*CalendarController, store.Store) {
+	t.Helper()
+	tikiStore := store.NewInMemoryStore()
+	seedTiki(t, tikiStore, "0000T1", "Alpha", "ready", 0)
+	seedTiki(t, tikiStore, "0000T2", "Bravo", "ready", 0)
+	seedTiki(t, tikiStore, "0000T3", "Weekly sync", "ready", 0)
+	seedTiki(t, tikiStore, "0000T4", "Shipped", "done", 0)
+	seedTiki(t, tikiStore, "0000T5", "Someday", "ready", 0)
+	for id, due := range map[string]time.Time{
+		"0000T1": calendarDate(time.October, 21),
+		"0000T2": calendarDate(time.October, 21),
+		"0000T3": calendarDate(time.October, 19),
+		"0000T4": calendarDate(time.October, 22),
+	} {
+		tk := tikiStore.GetTiki(id).Clone()
+		tk.Set("due", due)
+		if id == "0000T3" {
+			tk.Set("recurrence", "0 0 * * MON")
+		}
+		if err := tikiStore.UpdateTiki(tk); err != nil {
+			t.Fatalf("set due: %v", err)
+		}
+	}
+
+	def := &plugin.CalendarPlugin{
+		BasePlugin:      plugin.BasePlugin{Name: "Calendar", Kind: plugin.KindCalendar},
+		Filter:          mustParseStmt(t, `select where status != "done"`),
+		DateField:       "due",
+		RecurrenceField: "recurrence",
+		Mode:            plugin.CalendarMonth,
+	}
+	gate := service.NewTikiMutationGate()
+	gate.SetStore(tikiStore)
+	cc := NewCalendarController(def, newMockNavigationController(), nil, nil, nil, tikiStore, gate, rukiRuntime.NewSchema())
+	cc.now = func() time.Time { return time.Date(2026, time.October, 19, 15, 4, 0, 0, time.Local) }
+	cc.state.SetDay(cc.Today(), 0)
+	return cc, tikiStore
+}
+
+func entryTitles(entries []CalendarEntry) []string {
+	titles := make([]string, len(entries))
+	for i, e := range entries {
+		titles[i] = e.Tiki.Title()
+		if e.Occurrence {
+			titles[i] += "*"
+		}
+	}
+	return titles
+}
+
+func TestCalendarEntries(t *testing.T) {
+	cc, _ := newCalendarHarness(t)
+	days := cc.Entries(calendarDate(time.October, 1), calendarDate(time.November, 1))
+
+	want := map[time.Time][]string{
+		calendarDate(time.October, 19): {"Weekly sync"},
+		calendarDate(time.October, 21): {"Alpha", "Bravo"},
+		calendarDate(time.October, 26): {"Weekly sync*"},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("days = %v, want %d days", days, len(want))
+	}
+	for day, titles := range want {
+		got := entryTitles(days[day])
+		if len(got) != len(titles) {
+			t.Fatalf("%s = %v, want %v", day.Format(time.DateOnly), got, titles)
+		}
+		for i := range titles {
+			if got[i] != titles[i] {
+				t.Errorf("%s = %v, want %v", day.Format(time.DateOnly), got, titles)
+			}
+		}
+	}
+
+	// occurrences are not projected into the past
+	if past := cc.Entries(calendarDate(time.September, 1), calendarDate(time.October, 1)); len(past) != 0 {
+		t.Errorf("September = %v, want empty", past)
+	}
+}
+
+func TestCalendarNavigation(t *testing.T) {
+	cc, _ := newCalendarHarness(t)
+	state := cc.GetState()
+
+	steps := []struct {
+		action ActionID
+		want   time.Time
+	}{
+		{ActionNavRight, calendarDate(time.October, 20)},
+		{ActionNavDown, calendarDate(time.October, 27)},
+		{ActionNavLeft, calendarDate(time.October, 26)},
+		{ActionNavUp, calendarDate(time.October, 19)},
+		{ActionCalendarNextPeriod, calendarDate(time.November, 19)},
+		{ActionCalendarToday, calendarDate(time.October, 19)},
+		{ActionCalendarPrevPeriod, calendarDate(time.September, 19)},
+	}
+	for _, s := range steps {
+		if !cc.HandleAction(s.action) {
+			t.Fatalf("%s not handled", s.action)
+		}
+		if got := state.GetDay(); !got.Equal(s.want) {
+			t.Fatalf("after %s day = %s, want %s", s.action, got.Format(time.DateOnly), s.want.Format(time.DateOnly))
+		}
+	}
+
+	// paging clamps to the end of shorter months
+	state.SetDay(calendarDate(time.October, 31), 0)
+	cc.HandleAction(ActionCalendarNextPeriod)
+	if got := state.GetDay(); !got.Equal(calendarDate(time.November, 30)) {
+		t.Errorf("Oct 31 + 1 month = %s, want 2026-11-30", got.Format(time.DateOnly))
+	}
+
+	// the agenda pages by a week
+	cc.HandleAction(ActionCalendarToggleMode)
+	cc.HandleAction(ActionCalendarNextPeriod)
+	if !state.IsWeekMode() || !state.GetDay().Equal(calendarDate(time.December, 7)) {
+		t.Errorf("week mode %v, day %s, want week paging to 2026-12-07", state.IsWeekMode(), state.GetDay().Format(time.DateOnly))
+	}
+}
+
+func TestCalendarSelectionAndReschedule(t *testing.T) {
+	cc, tikiStore := newCalendarHarness(t)
+
+	if !cc.SelectTikiByID("0000T2") || cc.GetSelectedTikiID() != "0000T2" {
+		t.Fatalf("selected = %q, want 0000T2", cc.GetSelectedTikiID())
+	}
+	if cc.SelectTikiByID("0000T5") {
+		t.Error("a tiki without a date cannot be selected")
+	}
+	cc.HandleAction(ActionCalendarNextEntry)
+	if cc.GetSelectedTikiID() != "0000T1" {
+		t.Errorf("next entry wraps to %q, want 0000T1", cc.GetSelectedTikiID())
+	}
+
+	cc.HandleAction(ActionMoveTikiRight)
+	due, _, _ := tikiStore.GetTiki("0000T1").TimeField("due")
+	if !due.Equal(calendarDate(time.October, 22)) {
+		t.Fatalf("due = %s, want 2026-10-22", due.Format(time.DateOnly))
+	}
+	if cc.GetSelectedTikiID() != "0000T1" || !cc.GetState().GetDay().Equal(calendarDate(time.October, 22)) {
+		t.Errorf("selection did not follow the tiki: %q on %s", cc.GetSelectedTikiID(), cc.GetState().GetDay().Format(time.DateOnly))
+	}
+
+	cc.HandleAction(ActionMoveTikiDown)
+	due, _, _ = tikiStore.GetTiki("0000T1").TimeField("due")
+	if !due.Equal(calendarDate(time.October, 29)) {
+		t.Errorf("due = %s, want 2026-10-29", due.Format(time.DateOnly))
+	}
+
+	// empty days have nothing to reschedule
+	cc.GetState().SetDay(calendarDate(time.October, 1), 0)
+	if cc.HandleAction(ActionMoveTikiLeft) {
+		t.Error("reschedule on an empty day should not be handled")
+	}
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/document"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/service"
+
+	"github.com/rivo/tview"
+)
+
+// startChecklistInput opens the InputBar for the number of the checklist
+// item to toggle, prefilled with the first unchecked item so Enter checks
+// off the next step. The body is rewritten by service.ToggleChecklistItem.
+func (ir *InputRouter) startChecklistInput(tikiID string) bool {
+	tk := ir.tikiStore.GetTiki(tikiID)
+	if tk == nil {
+		return false
+	}
+	items := document.ParseChecklist(tk.Body())
+	if len(items) == 0 {
+		if ir.statusline != nil {
+			ir.statusline.SetMessage("no checklist in this tiki", model.MessageLevelInfo, true)
+		}
+		return true
+	}
+	activeView := ir.navController.GetActiveView()
+	inputableView, ok := activeView.(InputableView)
+	if !ok {
+		return false
+	}
+
+	app := ir.navController.GetApp()
+	inputableView.SetFocusSetter(func(p tview.Primitive) {
+		app.SetFocus(p)
+	})
+
+	inputableView.SetInputSubmitHandler(func(text string) InputSubmitResult {
+		return ir.handleChecklistInput(tikiID, text)
+	})
+
+	inputableView.SetInputCancelHandler(func() {
+		inputableView.CancelInputBox()
+	})
+
+	next := ""
+	for i, item := range items {
+		if !item.Done {
+			next = strconv.Itoa(i + 1)
+			break
+		}
+	}
+	inputBox := inputableView.ShowInputBox(fmt.Sprintf("toggle item (1-%d)> ", len(items)), next)
+	if inputBox != nil {
+		app.SetFocus(inputBox)
+	}
+
+	return true
+}
+
+// handleChecklistInput toggles the item with the submitted 1-based number.
+// A bad number or a rejected update is shown in the statusline and keeps the
+// InputBar open.
+func (ir *InputRouter) handleChecklistInput(tikiID, text string) InputSubmitResult {
+	n, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || n < 1 {
+		if ir.statusline != nil {
+			ir.statusline.SetMessage("enter the number of a checklist item", model.MessageLevelError, true)
+		}
+		return InputKeepEditing
+	}
+	item, err := service.ToggleChecklistItem(context.Background(), ir.mutationGate, tikiID, n-1)
+	if err != nil {
+		if ir.statusline != nil {
+			ir.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
+		}
+		return InputKeepEditing
+	}
+	if ir.statusline != nil {
+		verb := "unchecked"
+		if item.Done {
+			verb = "checked"
+		}
+		ir.statusline.SetMessage(verb+" "+item.Text, model.MessageLevelInfo, true)
+	}
+	return InputClose
+}
@@ -107,6 +107,11 @@ func (dc *DetailController) registerPluginActions() {
 		switch a.Kind {
 		case plugin.ActionKindView:
 			// surface unconditionally; navigation has no executor deps
+		case plugin.ActionKindChat, plugin.ActionKindPropose:
+			if dc.executor == nil {
+				// agents need the store to reload or change tikis afterwards
+				continue
+			}
 		case plugin.ActionKindRuki:
 			if dc.executor == nil {
 				continue
@@ -275,6 +280,12 @@ func (dc *DetailController) updateFieldHint(focused model.EditField) {
 		case workflow.TypeEnum, workflow.TypeUser, workflow.TypeDate:
 			dc.statusline.SetMessage("↑↓ change value", model.MessageLevelInfo, false)
 			return
+		case workflow.TypeRef:
+			dc.statusline.SetMessage("↑↓ pick tiki  or type an id", model.MessageLevelInfo, false)
+			return
+		case workflow.TypeEnumList:
+			dc.statusline.SetMessage("space-separated: "+strings.Join(wfd.AllowedValues(), " "), model.MessageLevelInfo, false)
+			return
 		}
 	}
 	dc.statusline.ClearMessage()
@@ -405,6 +416,12 @@ func (dc *DetailController) enterEditModeWithFocus(focusField model.EditField) b
 	return true
 }
 
+// EditBody enters edit mode focused on the description, the built-in
+// stand-in for editing the source file when no external editor can run.
+func (dc *DetailController) EditBody() bool {
+	return dc.enterEditModeWithFocus(model.EditFieldDescription)
+}
+
 // ApplyDetailMode runs the per-mode setup carried in PluginViewParams.
 // Called by the view factory after BindEditView on a freshly built
 // DetailController that has just been pushed onto the nav stack. For plain
@@ -562,6 +579,16 @@ func (dc *DetailController) handlePluginAction(keyStr string) bool {
 			return dc.dispatchViewAction(a)
 		case plugin.ActionKindRuki:
 			return dc.dispatchRukiAction(a)
+		case plugin.ActionKindChat:
+			if dc.executor == nil {
+				return false
+			}
+			return runAgentChat(dc.navController, dc.executor.tikiStore, dc.statusline, a.Agent, dc.selectedTikiID)
+		case plugin.ActionKindPropose:
+			if dc.executor == nil || dc.selectedTikiID == "" {
+				return false
+			}
+			return dc.executor.Propose(a, []string{dc.selectedTikiID}, dc.navController)
 		}
 	}
 	return false
@@ -771,6 +798,9 @@ func DetailViewActions() *ActionRegistry {
 	r.Register(Action{ID: ActionDetailEdit, Key: tcell.KeyRune, Rune: 'e', Label: "Edit", ShowInHeader: true, Require: idReq})
 	r.Register(Action{ID: ActionEditSource, Key: tcell.KeyRune, Rune: 's', Label: "Edit source", ShowInHeader: true, Require: idReq})
 	r.Register(Action{ID: ActionChat, Key: tcell.KeyRune, Rune: 'c', Label: "Chat", ShowInHeader: true, Require: []Requirement{RequireAI, RequireID}})
+	r.Register(Action{ID: ActionAttach, Key: tcell.KeyRune, Rune: 'i', Label: "Attach file", ShowInHeader: true, Require: []Requirement{RequireAttachments, RequireID}})
+	r.Register(Action{ID: ActionOpenLink, Key: tcell.KeyRune, Rune: 'o', Label: "Open link", ShowInHeader: true, Require: []Requirement{RequireLinks, RequireID}})
+	r.Register(Action{ID: ActionChecklist, Key: tcell.KeyRune, Rune: 'x', Label: "Check item", ShowInHeader: true, Require: idReq})
 	return r
 }
 
@@ -2,9 +2,10 @@ package controller
 
 import (
 	"bytes"
+	"fmt"
 	"log/slog"
-	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/boolean-maybe/ruki"
@@ -14,6 +15,8 @@ import (
 	"github.com/boolean-maybe/tiki/service"
 	"github.com/boolean-maybe/tiki/store"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/util"
+	"github.com/boolean-maybe/tiki/workflow"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -57,6 +60,29 @@ type TikiViewProvider interface {
 	EnsureFirstNonEmptyLaneSelection() bool
 	GetActionRegistry() *ActionRegistry
 	ShowNavigation() bool
+	MoveSelectedTikiToLane(lane int) bool
+}
+
+// SwimlaneProvider is implemented by board controllers whose lanes may be
+// split into swimlane bands.
+type SwimlaneProvider interface {
+	HasSwimlanes() bool
+	GetSwimlaneBands() []SwimlaneBand
+	MoveSelectedTikiToBand(index int) bool
+	ToggleBand(name string)
+}
+
+// MoveGuard is implemented by board views and controllers whose Shift-arrow
+// moves run a lane or band `action:`. BlockedMoves names the moves the
+// workflow's permissions keep the selected tiki from making.
+type MoveGuard interface {
+	BlockedMoves() []ActionID
+}
+
+// LaneHeaderProvider is implemented by controllers that compute lane header
+// captions (WIP limits and summaries).
+type LaneHeaderProvider interface {
+	GetLaneHeaders() []LaneHeader
 }
 
 // InputRouter dispatches input events to appropriate controllers
@@ -84,6 +110,7 @@ type InputRouter struct {
 	markdownTreeView   MarkdownTreeView
 	workflowPath       string
 	clipboardWriter    func([][]string) error
+	pendingKeys        []model.KeyStroke // typed prefix of a multi-key binding
 }
 
 // NewInputRouter creates an input router
@@ -151,7 +178,7 @@ func (ir *InputRouter) SetClipboardWriter(fn func([][]string) error) {
 
 // HandleInput processes a key event for the current view and routes it to the appropriate handler.
 // It processes events through multiple handlers in order:
-// 1. Search input (if search is active)
+// 1. Search input (if search is active), or a focused widget claiming the key
 // 2. Fullscreen escape (Esc key in fullscreen views)
 // 3. Configurable detail view in edit mode (Esc/Ctrl+S/Tab/Shift-Tab/Left/Right)
 // 4. Global actions (Esc, Refresh)
@@ -178,6 +205,12 @@ func (ir *InputRouter) HandleInput(event *tcell.EventKey, currentView *ViewEntry
 		}
 	}
 
+	// a focused widget that claims the key (a document's search prompt or
+	// outline) gets it before any binding
+	if focusCapturesKey(ir.navController.GetApp(), event) {
+		return false
+	}
+
 	// pre-gate: global actions that must fire before tiki-edit Prepare() and before
 	// search/fullscreen/editor gates
 	if action := ir.globalActions.Match(event); action != nil {
@@ -205,6 +238,9 @@ func (ir *InputRouter) HandleInput(event *tcell.EventKey, currentView *ViewEntry
 	if stop, handled := ir.maybeHandleDetailEditMode(activeView, currentView, event); stop {
 		return handled
 	}
+	if stop, handled := ir.maybeHandleKeySequence(event, currentView, activeView); stop {
+		return handled
+	}
 
 	// check global actions first
 	if action := ir.globalActions.Match(event); action != nil {
@@ -222,6 +258,43 @@ func (ir *InputRouter) HandleInput(event *tcell.EventKey, currentView *ViewEntry
 	return false
 }
 
+// maybeHandleKeySequence matches multi-key bindings (e.g. "g g") from the
+// keymap. A key that continues a pending sequence is consumed; one that
+// completes it dispatches the action like a single-key match would. A key
+// that breaks the sequence drops the pending prefix and is routed normally.
+func (ir *InputRouter) maybeHandleKeySequence(event *tcell.EventKey, currentView *ViewEntry, activeView View) (stop bool, handled bool) {
+	var ctrl PluginControllerInterface
+	if model.IsPluginViewID(currentView.ViewID) {
+		ctrl = ir.pluginControllers[model.GetPluginName(currentView.ViewID)]
+	}
+	if len(ir.globalActions.sequences) == 0 && (ctrl == nil || len(ctrl.GetActionRegistry().sequences) == 0) {
+		return false, false
+	}
+
+	keys := append(slices.Clone(ir.pendingKeys), model.KeyStroke{Key: event.Key(), Rune: event.Rune(), Modifier: event.Modifiers()})
+	ir.pendingKeys = nil
+
+	action, prefix := ir.globalActions.MatchSequence(keys)
+	if action != nil {
+		if !ActionEnabled(*action, BuildAppContext(currentView, activeView)) {
+			return true, false
+		}
+		return true, ir.handleGlobalAction(action.ID)
+	}
+	if ctrl != nil {
+		viewAction, viewPrefix := ctrl.GetActionRegistry().MatchSequence(keys)
+		if viewAction != nil {
+			return true, ir.dispatchMatchedPluginAction(ctrl, viewAction, currentView.ViewID)
+		}
+		prefix = prefix || viewPrefix
+	}
+	if prefix {
+		ir.pendingKeys = keys
+		return true, true
+	}
+	return false, false
+}
+
 // maybeHandleInputBox handles input box focus/visibility semantics.
 // stop=true means input routing should stop and return handled.
 func (ir *InputRouter) maybeHandleInputBox(activeView View, event *tcell.EventKey) (stop bool, handled bool) {
@@ -273,6 +346,8 @@ type detailEditModeView interface {
 //   - Esc cancels the edit session and exits edit mode without popping
 //     the view from the nav stack.
 //
+// A focused editor that implements keyCapturer is asked before any of these.
+//
 // Up/Down/typing are handed back to the focused EditSelectList by
 // returning stop=false; the registry-based dispatcher then matches them
 // to ActionNextValue/ActionPrevValue or the widget's own InputHandler.
@@ -291,6 +366,12 @@ func (ir *InputRouter) maybeHandleDetailEditMode(activeView View, currentView *V
 		return false, false
 	}
 
+	// an editor that owns keys edit mode also binds (the markdown body
+	// editor's Enter, arrows, Ctrl motions, vim Esc) gets them first
+	if ir.navController != nil && focusCapturesKey(ir.navController.GetApp(), event) {
+		return true, false
+	}
+
 	switch event.Key() {
 	case tcell.KeyEscape:
 		return true, ctrl.HandleAction(ActionDetailCancel)
@@ -366,6 +447,24 @@ type textInputCapable interface {
 	AcceptsTextInput() bool
 }
 
+// keyCapturer is implemented by widgets that handle some of the keys the
+// views bind — component.MarkdownEditor keeps Enter, the arrows and its own
+// motions, and decides per key and mode (completions open, vim insert mode)
+// whether Esc and Tab are its own; a document's search prompt keeps every
+// key and its outline keeps Esc and Enter.
+type keyCapturer interface {
+	CapturesKey(event *tcell.EventKey) bool
+}
+
+// focusCapturesKey reports whether the focused primitive claims event.
+func focusCapturesKey(app *tview.Application, event *tcell.EventKey) bool {
+	if app == nil {
+		return false
+	}
+	kc, ok := app.GetFocus().(keyCapturer)
+	return ok && kc.CapturesKey(event)
+}
+
 func isInputFieldOrTextArea(p tview.Primitive) bool {
 	// a widget that embeds an *tview.InputField but only conditionally accepts
 	// typing (EditSelectList) must be asked directly — a non-typing picker
@@ -457,54 +556,62 @@ func (ir *InputRouter) handlePluginInput(event *tcell.EventKey, viewID model.Vie
 		return false
 	}
 
-	registry := ctrl.GetActionRegistry()
-	if action := registry.Match(event); action != nil {
-		currentView := ir.navController.CurrentView()
-		activeView := ir.navController.GetActiveView()
-		ctx := BuildAppContext(currentView, activeView)
-		if !ActionEnabled(*action, ctx) {
-			return false
-		}
-		if action.ID == ActionSearch {
-			return ir.handleSearchInput(ctrl)
-		}
-		if action.ID == ActionExecute {
-			return ir.startExecuteInput()
-		}
-		if handled, ok := ir.dispatchDetailViewSharedAction(action.ID, currentView); ok {
-			return handled
-		}
-		if targetPluginName := GetPluginNameFromAction(action.ID); targetPluginName != "" {
-			// 6B.18 + 6B.24: selection passthrough + target-scoped
-			// require evaluation in a single shared helper so direct
-			// activation matches `kind: view` semantics.
-			return ir.activateTargetView(viewID, model.MakePluginViewID(targetPluginName), targetPluginName)
-		}
-		if _, hasChoose := ctrl.GetActionChooseSpec(action.ID); hasChoose {
-			return ir.startActionChoose(ctrl, action.ID)
-		}
-		if _, _, hasInput := ctrl.GetActionInputSpec(action.ID); hasInput {
-			return ir.startActionInput(ctrl, action.ID)
-		}
-		return ctrl.HandleAction(action.ID)
+	if action := ctrl.GetActionRegistry().Match(event); action != nil {
+		return ir.dispatchMatchedPluginAction(ctrl, action, viewID)
 	}
 	return false
 }
 
+// dispatchMatchedPluginAction runs a key-matched view action: built-in
+// prompts and shared detail actions first, then view activation, then the
+// controller.
+func (ir *InputRouter) dispatchMatchedPluginAction(ctrl PluginControllerInterface, action *Action, viewID model.ViewID) bool {
+	currentView := ir.navController.CurrentView()
+	activeView := ir.navController.GetActiveView()
+	ctx := BuildAppContext(currentView, activeView)
+	if !ActionEnabled(*action, ctx) {
+		return false
+	}
+	if action.ID == ActionSearch {
+		return ir.handleSearchInput(ctrl)
+	}
+	if action.ID == ActionExecute {
+		return ir.startExecuteInput()
+	}
+	if handled, ok := ir.dispatchDetailViewSharedAction(action.ID, currentView); ok {
+		return handled
+	}
+	if targetPluginName := GetPluginNameFromAction(action.ID); targetPluginName != "" {
+		// 6B.18 + 6B.24: selection passthrough + target-scoped
+		// require evaluation in a single shared helper so direct
+		// activation matches `kind: view` semantics.
+		return ir.activateTargetView(viewID, model.MakePluginViewID(targetPluginName), targetPluginName)
+	}
+	if _, hasChoose := ctrl.GetActionChooseSpec(action.ID); hasChoose {
+		return ir.startActionChoose(ctrl, action.ID)
+	}
+	if _, _, hasInput := ctrl.GetActionInputSpec(action.ID); hasInput {
+		return ir.startActionInput(ctrl, action.ID)
+	}
+	return ctrl.HandleAction(action.ID)
+}
+
 // dispatchDetailViewSharedAction handles actions that the configurable
 // detail view inherits from the legacy tiki-detail view: invoking the
-// AI chat agent and opening the underlying markdown file in $EDITOR.
+// AI chat agent, opening the underlying markdown file in $EDITOR,
+// attaching a file and checking off checklist items.
 // The configurable detail view's controller is too narrow to own these
 // paths (chat needs the suspend/resume runner, edit-source needs the
 // TikiEditSession's reload semantics), so the router dispatches them
-// directly when the carried selection is present.
+// directly when the carried selection is present. Open link lives here too
+// so it shares the selection decoding.
 //
 // Returns (handled, true) when the action was recognized; (_, false)
 // when the action is not a shared detail-view action and the caller
 // should fall through to the controller dispatch path.
 func (ir *InputRouter) dispatchDetailViewSharedAction(id ActionID, currentView *ViewEntry) (bool, bool) {
 	switch id {
-	case ActionChat, ActionEditSource:
+	case ActionChat, ActionEditSource, ActionAttach, ActionOpenLink, ActionChecklist:
 	default:
 		return false, false
 	}
@@ -519,33 +626,65 @@ func (ir *InputRouter) dispatchDetailViewSharedAction(id ActionID, currentView *
 	case ActionChat:
 		return ir.runChatForTiki(tikiID), true
 	case ActionEditSource:
+		if editor, ok := util.EditorAvailable(); !ok && ir.editBodyInPlace(currentView, editor) {
+			return true, true
+		}
 		ir.tikiEditSession.SetCurrentTiki(tikiID)
 		return ir.tikiEditSession.HandleAction(ActionEditSource), true
+	case ActionAttach:
+		return ir.startAttachInput(tikiID), true
+	case ActionOpenLink:
+		return ir.openTikiLink(tikiID), true
+	case ActionChecklist:
+		return ir.startChecklistInput(tikiID), true
 	}
 	return false, true
 }
 
-// runChatForTiki invokes the configured AI agent against the given tiki
-// file path, then reloads the tiki to surface any agent-applied edits.
-// Mirrors the legacy tiki-detail chat path so the configurable detail
-// view's `c` keybinding behaves identically.
-func (ir *InputRouter) runChatForTiki(tikiID string) bool {
-	agent := config.GetAIAgent()
-	if agent == "" {
+// editBodyInPlace falls back to the built-in body editor of the detail view
+// when editor, the external editor for edit-source, cannot be found.
+func (ir *InputRouter) editBodyInPlace(currentView *ViewEntry, editor string) bool {
+	ed, ok := ir.pluginControllers[model.GetPluginName(currentView.ViewID)].(interface{ EditBody() bool })
+	if !ok || !ed.EditBody() {
+		return false
+	}
+	if ir.statusline != nil {
+		ir.statusline.SetMessage(fmt.Sprintf("editor %q not found, editing the body in place", editor), model.MessageLevelInfo, true)
+	}
+	return true
+}
+
+// openTikiLink opens the tiki's first set url field, in workflow declaration
+// order, with the platform's default handler (usually the browser).
+func (ir *InputRouter) openTikiLink(tikiID string) bool {
+	tk := ir.tikiStore.GetTiki(tikiID)
+	if tk == nil {
 		return false
 	}
-	tikiFilePath := ir.tikiStore.PathForID(tikiID)
-	if tikiFilePath == "" {
-		tikiFilePath = filepath.Join(config.GetDocDir(), tikiID+".md")
+	for _, fd := range workflow.FieldsOfType(workflow.TypeURL) {
+		u, _, _ := tk.StringField(fd.Name)
+		if u == "" {
+			continue
+		}
+		if err := service.OpenExternal(u); err != nil && ir.statusline != nil {
+			ir.statusline.SetMessage("open failed: "+err.Error(), model.MessageLevelError, true)
+		}
+		return true
 	}
-	name, args := resolveAgentCommand(agent, tikiFilePath)
-	ir.navController.SuspendAndRun(name, args...)
-	if err := ir.tikiStore.ReloadTiki(tikiID); err != nil && ir.statusline != nil {
-		ir.statusline.SetMessage("reload failed: "+err.Error(), model.MessageLevelError, true)
+	if ir.statusline != nil {
+		ir.statusline.SetMessage("no link set on this tiki", model.MessageLevelInfo, true)
 	}
 	return true
 }
 
+// runChatForTiki invokes the configured AI agent against the given tiki,
+// then reloads the tiki to surface any agent-applied edits. Mirrors the
+// legacy tiki-detail chat path so the configurable detail view's `c`
+// keybinding behaves identically.
+func (ir *InputRouter) runChatForTiki(tikiID string) bool {
+	return runAgentChat(ir.navController, ir.tikiStore, ir.statusline, "", tikiID)
+}
+
 // handleGlobalAction processes actions available in all views
 func (ir *InputRouter) handleGlobalAction(actionID ActionID) bool {
 	switch actionID {
@@ -578,6 +717,8 @@ func (ir *InputRouter) handleGlobalAction(actionID ActionID) bool {
 			ir.statusline.SetMessage("restart tiki to apply workflow changes", model.MessageLevelInfo, true)
 		}
 		return true
+	case ActionShowOverdue:
+		return ir.showOverdue()
 	default:
 		return false
 	}
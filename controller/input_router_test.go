@@ -307,6 +307,30 @@ func TestMaybeHandleDetailEditMode_EnterInterceptedOnSingleLineField(t *testing.
 	}
 }
 
+// TestMaybeHandleDetailEditMode_MarkdownEditorCapturesKeys pins that the
+// focused markdown body editor gets Enter and the arrows (newline and cursor
+// movement rather than save & close or value cycling), while Ctrl-S still
+// reaches the edit mode.
+func TestMaybeHandleDetailEditMode_MarkdownEditorCapturesKeys(t *testing.T) {
+	const pluginName = "Detail"
+	ir := newRouterWithApp(pluginName, component.NewMarkdownEditor())
+	view := &detailEditFakeView{routerFakeView: &routerFakeView{}}
+	entry := &ViewEntry{ViewID: model.MakePluginViewID(pluginName)}
+
+	for _, key := range []tcell.Key{tcell.KeyEnter, tcell.KeyUp, tcell.KeyDown, tcell.KeyCtrlA} {
+		stop, handled := ir.maybeHandleDetailEditMode(view, entry, tcell.NewEventKey(key, 0, tcell.ModNone))
+		if !stop || handled {
+			t.Errorf("%v on the markdown editor: stop=%v handled=%v, want true/false", tcell.KeyNames[key], stop, handled)
+		}
+	}
+	if !focusCapturesKey(ir.navController.GetApp(), tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)) {
+		t.Error("focusCapturesKey(Enter) = false on the markdown editor")
+	}
+	if focusCapturesKey(ir.navController.GetApp(), tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModNone)) {
+		t.Error("the markdown editor must leave Ctrl-S to the edit mode")
+	}
+}
+
 // TestIsTextAreaFocused pins the discriminator: only *tview.TextArea (direct or
 // embedded) reports true; a bare *tview.InputField does not.
 func TestIsTextAreaFocused(t *testing.T) {
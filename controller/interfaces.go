@@ -243,6 +243,37 @@ type PaletteActionHandler interface {
 	HandlePaletteAction(id ActionID) bool
 }
 
+// ActivityView is the feed of a kind: activity view, whose filters the
+// controller sets from the input box. Empty text resets a filter to the
+// view's configured value; invalid text is reported as an error and leaves
+// the filter unchanged.
+type ActivityView interface {
+	View
+
+	SetActivityUser(text string)
+	SetActivityField(text string) error
+	SetActivitySince(text string) error
+	SetActivityUntil(text string) error
+}
+
+// DocumentView is a view showing a markdown document that can be searched
+// and outlined in place (wiki views). The view takes focus for its search
+// prompt and outline through the FocusSettable setter.
+type DocumentView interface {
+	View
+	FocusSettable
+
+	// StartDocumentSearch opens the search prompt under the document
+	StartDocumentSearch()
+
+	// FindNext and FindPrevious move between matches of the last search
+	FindNext() bool
+	FindPrevious() bool
+
+	// ToggleOutline opens, focuses or closes the heading outline
+	ToggleOutline()
+}
+
 // FocusRestorer is implemented by views that can recover focus after the palette closes
 // when the originally saved focused primitive is no longer valid (e.g., TikiDetailView
 // rebuilds its description primitive during store-driven refresh).
@@ -0,0 +1,356 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/boolean-maybe/tiki/model"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Keymap remaps built-in actions to user-configured keys (config.yaml
+// `keys:`). Each action maps to its full list of bindings, each binding a
+// sequence of one or more keys; an empty list unbinds the action, leaving it
+// reachable from the palette only. Actions absent from the map keep their
+// default keys.
+type Keymap map[ActionID][][]model.KeyStroke
+
+// activeKeymap is applied by every registry created after SetKeymap.
+var activeKeymap Keymap
+
+// SetKeymap installs the keymap used by action registries. Bootstrap calls it
+// once, before any view or controller builds its registry. nil restores the
+// default bindings.
+func SetKeymap(km Keymap) {
+	activeKeymap = km
+}
+
+// keyScope groups the built-in actions that are live at the same time.
+// Global actions are live in every view, so they conflict with everything.
+type keyScope string
+
+const (
+	keyScopeGlobal keyScope = "global"
+	keyScopeBoard  keyScope = "board"
+	keyScopeDetail keyScope = "detail"
+	keyScopeWiki   keyScope = "wiki"
+
+	keyScopeCalendar keyScope = "calendar"
+	keyScopeActivity keyScope = "activity"
+)
+
+// remappableActions lists the built-in actions `keys:` may rebind. Detail
+// edit-mode keys (Tab, Ctrl-S, Enter, Esc inside the editor) are not
+// included: they are part of the editor's text-input handling.
+var remappableActions = map[ActionID]keyScope{
+	ActionBack:             keyScopeGlobal,
+	ActionQuit:             keyScopeGlobal,
+	ActionRefresh:          keyScopeGlobal,
+	ActionToggleHeader:     keyScopeGlobal,
+	ActionOpenPalette:      keyScopeGlobal,
+	ActionOpenMarkdownTree: keyScopeGlobal,
+	ActionEditWorkflow:     keyScopeGlobal,
+	ActionShowOverdue:      keyScopeGlobal,
+
+	ActionNavUp:         keyScopeBoard,
+	ActionNavDown:       keyScopeBoard,
+	ActionNavLeft:       keyScopeBoard,
+	ActionNavRight:      keyScopeBoard,
+	ActionMoveTikiLeft:  keyScopeBoard,
+	ActionMoveTikiRight: keyScopeBoard,
+	ActionSearch:        keyScopeBoard,
+	ActionExecute:       keyScopeBoard,
+
+	ActionMoveTikiUp:      keyScopeBoard,
+	ActionMoveTikiDown:    keyScopeBoard,
+	ActionToggleSwimlane:  keyScopeBoard,
+	ActionExpandSwimlanes: keyScopeBoard,
+
+	ActionFullscreen: keyScopeDetail,
+	ActionDetailEdit: keyScopeDetail,
+	ActionEditSource: keyScopeDetail,
+	ActionChat:       keyScopeDetail,
+	ActionAttach:     keyScopeDetail,
+	ActionOpenLink:   keyScopeDetail,
+	ActionChecklist:  keyScopeDetail,
+
+	ActionNavigateBack:    keyScopeWiki,
+	ActionNavigateForward: keyScopeWiki,
+	ActionFindInDocument:  keyScopeWiki,
+	ActionFindNext:        keyScopeWiki,
+	ActionFindPrevious:    keyScopeWiki,
+	ActionToggleOutline:   keyScopeWiki,
+
+	ActionCalendarToday:      keyScopeCalendar,
+	ActionCalendarToggleMode: keyScopeCalendar,
+	ActionCalendarPrevPeriod: keyScopeCalendar,
+	ActionCalendarNextPeriod: keyScopeCalendar,
+	ActionCalendarNextEntry:  keyScopeCalendar,
+	ActionCalendarPrevEntry:  keyScopeCalendar,
+
+	ActionActivityUser:  keyScopeActivity,
+	ActionActivityField: keyScopeActivity,
+	ActionActivitySince: keyScopeActivity,
+	ActionActivityUntil: keyScopeActivity,
+}
+
+// keyProfiles are the preset keymaps selectable with `keys.profile`. Each
+// keeps the default keys and adds alternatives, so switching profiles never
+// takes a familiar key away. vim adds `<`/`>` for the lane moves because
+// Shift-arrows are swallowed by several terminal multiplexers.
+var keyProfiles = map[string]map[ActionID][]string{
+	"default": {},
+	"vim": {
+		ActionMoveTikiLeft:     {"Shift-Left", "<"},
+		ActionMoveTikiRight:    {"Shift-Right", ">"},
+		ActionOpenPalette:      {"Ctrl-A", ":"},
+		ActionOpenMarkdownTree: {"Ctrl-O", "Space f"},
+	},
+	"emacs": {
+		ActionNavUp:         {"Up", "Ctrl-P"},
+		ActionNavDown:       {"Down", "Ctrl-N"},
+		ActionNavLeft:       {"Left", "Ctrl-B"},
+		ActionNavRight:      {"Right", "Ctrl-F"},
+		ActionMoveTikiLeft:  {"Shift-Left", "Alt-b"},
+		ActionMoveTikiRight: {"Shift-Right", "Alt-f"},
+		ActionSearch:        {"/", "Ctrl-S"},
+		ActionBack:          {"Esc", "Ctrl-G"},
+		ActionQuit:          {"q", "Ctrl-X Ctrl-C"},
+		ActionOpenPalette:   {"Ctrl-A", "Alt-x"},
+	},
+}
+
+// KeyProfiles returns the names of the preset key profiles.
+func KeyProfiles() []string {
+	names := make([]string, 0, len(keyProfiles))
+	for name := range keyProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadKeymap builds the keymap from a preset profile and per-action
+// overrides (action id → key sequences; "none" or an empty list unbinds).
+// Overrides replace the profile's keys for that action. The result is
+// validated against the default bindings and the workflow's view activation
+// keys (InitPluginActions must run first): two actions that can be live at
+// the same time may not share a key, and no key may both complete one
+// binding and start a longer sequence of another.
+func LoadKeymap(profile string, bindings map[string][]string) (Keymap, error) {
+	if profile == "" {
+		profile = "default"
+	}
+	preset, ok := keyProfiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown key profile %q (available: %s)", profile, strings.Join(KeyProfiles(), ", "))
+	}
+
+	raw := make(map[ActionID][]string, len(preset)+len(bindings))
+	for id, keys := range preset {
+		raw[id] = keys
+	}
+	for name, keys := range bindings {
+		id := ActionID(name)
+		if _, ok := remappableActions[id]; !ok {
+			return nil, fmt.Errorf("unknown action %q in keys.bindings (available: %s)", name, strings.Join(remappableActionNames(), ", "))
+		}
+		raw[id] = keys
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	km := make(Keymap, len(raw))
+	for id, keys := range raw {
+		seqs := [][]model.KeyStroke{}
+		for _, k := range keys {
+			if strings.EqualFold(strings.TrimSpace(k), "none") {
+				continue
+			}
+			seq, err := ParseKeySequence(k)
+			if err != nil {
+				return nil, fmt.Errorf("keys.bindings.%s: %w", id, err)
+			}
+			seqs = append(seqs, seq)
+		}
+		km[id] = seqs
+	}
+
+	if err := validateKeymap(km); err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+func remappableActionNames() []string {
+	names := make([]string, 0, len(remappableActions))
+	for id := range remappableActions {
+		names = append(names, string(id))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateKeymap applies km to the default registry of each scope (plus the
+// global actions, live everywhere) and reports the first binding clash that
+// involves a remapped action. Clashes among untouched defaults are the
+// workflow's business and are reported where the workflow is loaded.
+func validateKeymap(km Keymap) error {
+	for _, scope := range []keyScope{keyScopeBoard, keyScopeDetail, keyScopeWiki, keyScopeCalendar, keyScopeActivity} {
+		r := newActionRegistry(km)
+		for _, a := range defaultScopeActions(scope) {
+			r.Register(a)
+		}
+
+		bound := make(map[string]ActionID)
+		var keys []string
+		for _, a := range r.actions {
+			if a.Key == 0 && a.Rune == 0 {
+				continue
+			}
+			key := a.KeyString()
+			if other, ok := bound[key]; ok && other != a.ID && (km.has(a.ID) || km.has(other)) {
+				return fmt.Errorf("key %q is bound to both %s and %s", key, other, a.ID)
+			}
+			if _, ok := bound[key]; !ok {
+				bound[key] = a.ID
+				keys = append(keys, key)
+			}
+		}
+		for _, short := range keys {
+			for _, long := range keys {
+				if short == long || !strings.HasPrefix(long, short+" ") {
+					continue
+				}
+				a, b := bound[short], bound[long]
+				if a != b && (km.has(a) || km.has(b)) {
+					return fmt.Errorf("key %q of %s is the start of %q of %s", short, a, long, b)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (km Keymap) has(id ActionID) bool {
+	_, ok := km[id]
+	return ok
+}
+
+// defaultScopeActions returns the default (un-remapped) actions live in a
+// view scope, global actions first as InputRouter matches them first.
+func defaultScopeActions(scope keyScope) []Action {
+	saved := activeKeymap
+	activeKeymap = nil
+	defer func() { activeKeymap = saved }()
+
+	actions := slices.Clone(DefaultGlobalActions().GetActions())
+	switch scope {
+	case keyScopeBoard:
+		board := PluginViewActions()
+		registerSwimlaneActions(board)
+		actions = append(actions, board.GetActions()...)
+	case keyScopeDetail:
+		actions = append(actions, DetailViewActions().GetActions()...)
+		actions = append(actions, GetPluginActions().GetActions()...)
+	case keyScopeWiki:
+		actions = append(actions, WikiViewActions().GetActions()...)
+	case keyScopeCalendar:
+		actions = append(actions, CalendarViewActions().GetActions()...)
+	case keyScopeActivity:
+		actions = append(actions, ActivityViewActions().GetActions()...)
+	}
+	return actions
+}
+
+// ParseKeySequence parses a space-separated key sequence such as "g g",
+// "Space f" or "Ctrl-X Ctrl-C". Each key is a single character or a key
+// name (Esc, Enter, Tab, Backtab, Space, Up, Down, Left, Right, Home, End,
+// PgUp, PgDn, Insert, Delete, Backspace, F1..F12), optionally prefixed by
+// Ctrl-, Alt- and Shift-. Unlike workflow.yaml keys, Alt-x keeps the
+// letter's case, matching what terminals report.
+func ParseKeySequence(s string) ([]model.KeyStroke, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty key")
+	}
+	seq := make([]model.KeyStroke, 0, len(fields))
+	for _, f := range fields {
+		k, err := parseKeyStroke(f)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, normalizeStroke(k))
+	}
+	return seq, nil
+}
+
+var namedKeys = map[string]tcell.Key{
+	"ESC": tcell.KeyEscape, "ESCAPE": tcell.KeyEscape,
+	"ENTER": tcell.KeyEnter, "TAB": tcell.KeyTab, "BACKTAB": tcell.KeyBacktab,
+	"UP": tcell.KeyUp, "DOWN": tcell.KeyDown, "LEFT": tcell.KeyLeft, "RIGHT": tcell.KeyRight,
+	"HOME": tcell.KeyHome, "END": tcell.KeyEnd,
+	"PGUP": tcell.KeyPgUp, "PAGEUP": tcell.KeyPgUp, "PGDN": tcell.KeyPgDn, "PAGEDOWN": tcell.KeyPgDn,
+	"INSERT": tcell.KeyInsert, "DELETE": tcell.KeyDelete, "DEL": tcell.KeyDelete,
+	"BACKSPACE": tcell.KeyBackspace2,
+}
+
+func parseKeyStroke(s string) (model.KeyStroke, error) {
+	var mod tcell.ModMask
+	rest := s
+	for {
+		upper := strings.ToUpper(rest)
+		switch {
+		case strings.HasPrefix(upper, "CTRL-") && len(rest) > 5:
+			mod |= tcell.ModCtrl
+			rest = rest[5:]
+			continue
+		case strings.HasPrefix(upper, "ALT-") && len(rest) > 4:
+			mod |= tcell.ModAlt
+			rest = rest[4:]
+			continue
+		case strings.HasPrefix(upper, "SHIFT-") && len(rest) > 6:
+			mod |= tcell.ModShift
+			rest = rest[6:]
+			continue
+		}
+		break
+	}
+
+	upper := strings.ToUpper(rest)
+	if upper == "SPACE" {
+		return model.KeyStroke{Key: tcell.KeyRune, Rune: ' ', Modifier: mod}, nil
+	}
+	if key, ok := namedKeys[upper]; ok {
+		return model.KeyStroke{Key: key, Modifier: mod}, nil
+	}
+	if len(upper) >= 2 && upper[0] == 'F' {
+		var n int
+		if _, err := fmt.Sscanf(upper[1:], "%d", &n); err == nil && n >= 1 && n <= 12 && fmt.Sprint(n) == upper[1:] {
+			return model.KeyStroke{Key: tcell.KeyF1 + tcell.Key(n-1), Modifier: mod}, nil //nolint:gosec // G115: n bounded 1-12
+		}
+	}
+
+	runes := []rune(rest)
+	if len(runes) != 1 || !unicode.IsPrint(runes[0]) {
+		return model.KeyStroke{}, fmt.Errorf("invalid key %q", s)
+	}
+	r := runes[0]
+	switch {
+	case mod&tcell.ModCtrl != 0:
+		u := unicode.ToUpper(r)
+		if u < 'A' || u > 'Z' {
+			return model.KeyStroke{}, fmt.Errorf("invalid key %q (Ctrl- takes a letter or a key name)", s)
+		}
+		return model.KeyStroke{Key: tcell.KeyCtrlA + tcell.Key(u-'A'), Modifier: mod}, nil
+	case mod == tcell.ModShift:
+		// Shift-x is just the uppercase rune
+		return model.KeyStroke{Key: tcell.KeyRune, Rune: unicode.ToUpper(r)}, nil
+	}
+	return model.KeyStroke{Key: tcell.KeyRune, Rune: r, Modifier: mod}, nil
+}
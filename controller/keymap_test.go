@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/store"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestParseKeySequence(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []model.KeyStroke
+	}{
+		{"q", []model.KeyStroke{{Key: tcell.KeyRune, Rune: 'q'}}},
+		{"g g", []model.KeyStroke{{Key: tcell.KeyRune, Rune: 'g'}, {Key: tcell.KeyRune, Rune: 'g'}}},
+		{"Space f", []model.KeyStroke{{Key: tcell.KeyRune, Rune: ' '}, {Key: tcell.KeyRune, Rune: 'f'}}},
+		{"Ctrl-X Ctrl-C", []model.KeyStroke{{Key: tcell.KeyCtrlX, Modifier: tcell.ModCtrl}, {Key: tcell.KeyCtrlC, Modifier: tcell.ModCtrl}}},
+		{"shift-left", []model.KeyStroke{{Key: tcell.KeyLeft, Modifier: tcell.ModShift}}},
+		{"Alt-b", []model.KeyStroke{{Key: tcell.KeyRune, Rune: 'b', Modifier: tcell.ModAlt}}},
+		{"Shift-h", []model.KeyStroke{{Key: tcell.KeyRune, Rune: 'H'}}},
+		{"F5", []model.KeyStroke{{Key: tcell.KeyF5}}},
+		{"Esc", []model.KeyStroke{{Key: tcell.KeyEscape}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseKeySequence(tt.in)
+		if err != nil {
+			t.Errorf("ParseKeySequence(%q): %v", tt.in, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseKeySequence(%q) = %v, want %v", tt.in, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseKeySequence(%q)[%d] = %+v, want %+v", tt.in, i, got[i], tt.want[i])
+			}
+		}
+	}
+
+	for _, bad := range []string{"", "Ctrl-1", "F13", "Hyper-x", "ab"} {
+		if _, err := ParseKeySequence(bad); err == nil {
+			t.Errorf("ParseKeySequence(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestLoadKeymap_Profiles(t *testing.T) {
+	for _, profile := range KeyProfiles() {
+		if _, err := LoadKeymap(profile, nil); err != nil {
+			t.Errorf("profile %s: %v", profile, err)
+		}
+	}
+	if _, err := LoadKeymap("nano", nil); err == nil || !strings.Contains(err.Error(), "available: default, emacs, vim") {
+		t.Errorf("unknown profile err = %v", err)
+	}
+	km, err := LoadKeymap("", nil)
+	if err != nil || km != nil {
+		t.Errorf("default profile = %v, %v; want nil keymap", km, err)
+	}
+}
+
+func TestLoadKeymap_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindings map[string][]string
+		want     string
+	}{
+		{"unknown action", map[string][]string{"fly": {"x"}}, `unknown action "fly"`},
+		{"bad key", map[string][]string{"quit": {"Ctrl-?"}}, "keys.bindings.quit"},
+		{"clash with global", map[string][]string{"search": {"q"}}, "bound to both quit and search"},
+		{"clash between remaps", map[string][]string{"quit": {"x"}, "refresh": {"x"}}, "bound to both"},
+		{"prefix clash", map[string][]string{"quit": {"g"}, "refresh": {"g g"}}, "is the start of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadKeymap("", tt.bindings)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadKeymap_OverrideReplacesProfile(t *testing.T) {
+	km, err := LoadKeymap("vim", map[string][]string{"move_tiki_left": {"none"}})
+	if err != nil {
+		t.Fatalf("LoadKeymap: %v", err)
+	}
+	if seqs, ok := km[ActionMoveTikiLeft]; !ok || len(seqs) != 0 {
+		t.Fatalf("move_tiki_left = %v, want unbound", seqs)
+	}
+	if len(km[ActionMoveTikiRight]) != 2 {
+		t.Fatalf("move_tiki_right = %v, want the vim profile's two keys", km[ActionMoveTikiRight])
+	}
+}
+
+func TestRegistry_KeymapReplacesDefaults(t *testing.T) {
+	km, err := LoadKeymap("", map[string][]string{"nav_up": {"Ctrl-P"}, "quit": {}})
+	if err != nil {
+		t.Fatalf("LoadKeymap: %v", err)
+	}
+	SetKeymap(km)
+	t.Cleanup(func() { SetKeymap(nil) })
+
+	board := PluginViewActions()
+	if a := board.MatchBinding(tcell.KeyRune, 'k', 0); a != nil {
+		t.Errorf("k still bound to %s", a.ID)
+	}
+	if a := board.MatchBinding(tcell.KeyUp, 0, 0); a != nil {
+		t.Errorf("Up still bound to %s", a.ID)
+	}
+	if a := board.MatchBinding(tcell.KeyCtrlP, 0, tcell.ModCtrl); a == nil || a.ID != ActionNavUp {
+		t.Errorf("Ctrl-P = %v, want nav_up", a)
+	}
+
+	global := DefaultGlobalActions()
+	if a := global.MatchBinding(tcell.KeyRune, 'q', 0); a != nil {
+		t.Errorf("q still bound to %s", a.ID)
+	}
+	if !global.ContainsID(ActionQuit) {
+		t.Error("unbound quit must stay in the registry for the palette")
+	}
+}
+
+func TestRegistry_MatchSequence(t *testing.T) {
+	seq, _ := ParseKeySequence("g g")
+	r := newActionRegistry(Keymap{ActionRefresh: {seq}})
+	r.Register(Action{ID: ActionRefresh, Key: tcell.KeyRune, Rune: 'r', Label: "Refresh", ShowInHeader: true})
+
+	g := model.KeyStroke{Key: tcell.KeyRune, Rune: 'g'}
+	if a, prefix := r.MatchSequence([]model.KeyStroke{g}); a != nil || !prefix {
+		t.Fatalf("g: action=%v prefix=%v, want prefix", a, prefix)
+	}
+	if a, _ := r.MatchSequence([]model.KeyStroke{g, g}); a == nil || a.ID != ActionRefresh {
+		t.Fatalf("g g: action=%v, want refresh", a)
+	}
+	if a := r.GetByID(ActionRefresh); a.KeyString() != "g g" {
+		t.Errorf("KeyString = %q, want %q", a.KeyString(), "g g")
+	}
+}
+
+func TestInputRouter_KeySequenceDispatchesGlobalAction(t *testing.T) {
+	km, err := LoadKeymap("vim", nil)
+	if err != nil {
+		t.Fatalf("LoadKeymap: %v", err)
+	}
+	SetKeymap(km)
+	t.Cleanup(func() { SetKeymap(nil) })
+
+	cfg := model.NewMarkdownTreeConfig()
+	ir := &InputRouter{
+		navController:     NewNavigationController(tview.NewApplication()),
+		statusline:        model.NewStatuslineConfig(),
+		globalActions:     DefaultGlobalActions(),
+		tikiStore:         store.NewInMemoryStore(),
+		pluginControllers: map[string]PluginControllerInterface{},
+	}
+	ir.SetMarkdownTreeConfig(cfg)
+	ir.SetMarkdownTreeView(&fakeMarkdownTreeView{})
+	view := &ViewEntry{ViewID: model.MakePluginViewID("Board")}
+
+	if !ir.HandleInput(tcell.NewEventKey(tcell.KeyRune, ' ', 0), view) {
+		t.Fatal("Space should be consumed as a sequence prefix")
+	}
+	if cfg.IsVisible() {
+		t.Fatal("tree opened before the sequence completed")
+	}
+	if !ir.HandleInput(tcell.NewEventKey(tcell.KeyRune, 'f', 0), view) || !cfg.IsVisible() {
+		t.Fatal("Space f should open the markdown tree")
+	}
+
+	// a broken sequence drops the prefix and routes the key normally
+	cfg.SetVisible(false)
+	ir.HandleInput(tcell.NewEventKey(tcell.KeyRune, ' ', 0), view)
+	ir.HandleInput(tcell.NewEventKey(tcell.KeyRune, 'x', 0), view)
+	if len(ir.pendingKeys) != 0 || cfg.IsVisible() {
+		t.Fatalf("pending=%v visible=%v after broken sequence", ir.pendingKeys, cfg.IsVisible())
+	}
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/workflow/value"
+)
+
+// LaneHeader is what a board shows above a lane: its name, how many tikis it
+// holds against its WIP limit, and its summary aggregate. Counts and
+// summaries cover every tiki the lane filter selects, regardless of search.
+type LaneHeader struct {
+	Name    string
+	Count   int
+	Limit   int    // 0 when the lane has no limit
+	Summary string // formatted aggregate, empty when the lane has none
+}
+
+// OverLimit reports whether the lane holds more tikis than its limit allows.
+func (h LaneHeader) OverLimit() bool {
+	return h.Limit > 0 && h.Count > h.Limit
+}
+
+// Caption renders the header as one line, e.g. "In Progress 4/3 · Σ13".
+func (h LaneHeader) Caption() string {
+	parts := []string{h.Name}
+	if h.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d", h.Count, h.Limit))
+	}
+	caption := strings.Join(parts, " ")
+	if h.Summary != "" {
+		caption += " · " + h.Summary
+	}
+	return caption
+}
+
+// GetLaneHeaders returns the header of every lane, in lane order.
+func (pc *PluginController) GetLaneHeaders() []LaneHeader {
+	if pc.pluginDef == nil {
+		return nil
+	}
+	headers := make([]LaneHeader, len(pc.pluginDef.Lanes))
+	for i, lane := range pc.pluginDef.Lanes {
+		headers[i] = LaneHeader{Name: lane.Name, Limit: lane.Limit}
+		if lane.Limit == 0 && lane.Summary == nil {
+			continue
+		}
+		tikis, _ := pc.laneMembers(i)
+		headers[i].Count = len(tikis)
+		if lane.Summary == nil {
+			continue
+		}
+		values := make([]float64, len(tikis))
+		set := make([]bool, len(tikis))
+		for j, tk := range tikis {
+			if raw, ok := tk.Get(lane.Summary.Field); ok {
+				values[j], set[j] = value.NumberOf(raw)
+			}
+		}
+		headers[i].Summary = lane.Summary.Format(lane.Summary.Aggregate(values, set))
+	}
+	return headers
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"testing"
+
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func TestGetLaneHeaders(t *testing.T) {
+	tikiStore := store.NewInMemoryStore()
+	seedTiki(t, tikiStore, "0000T1", "Alpha", "ready", 0)
+	seedTiki(t, tikiStore, "0000T2", "Bravo", "ready", 0)
+	seedTiki(t, tikiStore, "0000T3", "Charlie", "ready", 0)
+	seedTiki(t, tikiStore, "0000T4", "Delta", "done", 0)
+	for id, points := range map[string]int{"0000T1": 3, "0000T2": 7} {
+		tk := tikiStore.GetTiki(id).Clone()
+		tk.Set("points", points)
+		if err := tikiStore.UpdateTiki(tk); err != nil {
+			t.Fatalf("set points: %v", err)
+		}
+	}
+
+	board := &plugin.WorkflowPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Kanban", Kind: plugin.KindBoard},
+		Lanes: []plugin.TikiLane{
+			{Name: "Ready", Columns: 1, Limit: 2, Summary: &plugin.LaneSummary{Func: plugin.SummarySum, Field: "points"},
+				Filter: mustParseStmt(t, `select where status = "ready"`)},
+			{Name: "Done", Columns: 1, Limit: 5, Filter: mustParseStmt(t, `select where status = "done"`)},
+			{Name: "All", Columns: 1, Filter: mustParseStmt(t, `select`)},
+		},
+	}
+	cfg := model.NewPluginConfig("Kanban")
+	cfg.SetLaneLayout([]int{1, 1, 1}, nil)
+	gate := service.NewTikiMutationGate()
+	gate.SetStore(tikiStore)
+	pc := NewPluginController(tikiStore, gate, cfg, board, newMockNavigationController(), nil, nil, rukiRuntime.NewSchema())
+
+	// counts ignore the active search
+	cfg.SetSearchResults([]*tikipkg.Tiki{tikiStore.GetTiki("0000T1")}, "alpha")
+	if n := len(pc.GetFilteredTikisForLane(0)); n != 1 {
+		t.Fatalf("search should narrow the Ready lane to 1 tiki, got %d", n)
+	}
+	headers := pc.GetLaneHeaders()
+	want := []string{"Ready 3/2 · Σ10", "Done 1/5", "All"}
+	for i, h := range headers {
+		if got := h.Caption(); got != want[i] {
+			t.Errorf("lane %d caption = %q, want %q", i, got, want[i])
+		}
+	}
+	if !headers[0].OverLimit() || headers[1].OverLimit() || headers[2].OverLimit() {
+		t.Errorf("over limit = %v %v %v, want only Ready", headers[0].OverLimit(), headers[1].OverLimit(), headers[2].OverLimit())
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"log/slog"
 	"os"
 	"os/exec"
+	"strings"
 
+	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/util"
 
@@ -23,6 +25,8 @@ type NavigationController struct {
 	onViewChanged    func(viewID model.ViewID, params map[string]interface{}) // callback when view changes (for layoutModel sync)
 	editorOpener     func(string) error
 	commandRunner    func(name string, args ...string) error
+	agentRunner      func(inv config.AgentInvocation) error
+	proposalReview   *model.ProposalReviewConfig
 }
 
 // NewNavigationController creates a navigation controller
@@ -116,6 +120,13 @@ func (nc *NavigationController) CurrentViewID() model.ViewID {
 	return nc.navState.currentViewID()
 }
 
+// Stack returns a copy of the navigation stack, bottom first.
+func (nc *NavigationController) Stack() []ViewEntry {
+	out := make([]ViewEntry, len(nc.navState.stack))
+	copy(out, nc.navState.stack)
+	return out
+}
+
 // Depth returns the current stack depth (for testing)
 func (nc *NavigationController) Depth() int {
 	return nc.navState.depth()
@@ -177,6 +188,74 @@ func (nc *NavigationController) SuspendAndRun(name string, args ...string) {
 	})
 }
 
+// SetAgentRunner overrides how SuspendAndRunAgent starts agents (useful for tests).
+func (nc *NavigationController) SetAgentRunner(runner func(inv config.AgentInvocation) error) {
+	nc.agentRunner = runner
+}
+
+// SuspendAndRunAgent is SuspendAndRun for an AI agent, whose invocation may
+// also carry environment variables and stdin. A runner set via
+// SetCommandRunner receives just the command and arguments.
+func (nc *NavigationController) SuspendAndRunAgent(inv config.AgentInvocation) {
+	nc.app.Suspend(func() {
+		var err error
+		switch {
+		case nc.agentRunner != nil:
+			err = nc.agentRunner(inv)
+		case nc.commandRunner != nil:
+			err = nc.commandRunner(inv.Command, inv.Args...)
+		default:
+			err = defaultRunAgent(inv)
+		}
+		if err != nil {
+			slog.Error("agent failed", "command", inv.Command, "error", err)
+		}
+	})
+}
+
+// SetProposalReview wires the overlay that shows changes proposed by
+// `kind: propose` actions.
+func (nc *NavigationController) SetProposalReview(rc *model.ProposalReviewConfig) {
+	nc.proposalReview = rc
+}
+
+// ProposalReview returns the proposal review overlay config, or nil when
+// none is wired.
+func (nc *NavigationController) ProposalReview() *model.ProposalReviewConfig {
+	return nc.proposalReview
+}
+
+// RunInBackground runs work off the UI goroutine and then the func it
+// returns, if any, on the UI goroutine. Without an application (unit tests)
+// both run synchronously.
+func (nc *NavigationController) RunInBackground(work func() func()) {
+	if nc.app == nil {
+		if done := work(); done != nil {
+			done()
+		}
+		return
+	}
+	go func() {
+		if done := work(); done != nil {
+			nc.app.QueueUpdateDraw(done)
+		}
+	}()
+}
+
+// defaultRunAgent runs an agent connected to the terminal. With a stdin
+// prompt the agent reads the prompt instead of the keyboard.
+func defaultRunAgent(inv config.AgentInvocation) error {
+	cmd := exec.Command(inv.Command, inv.Args...) //nolint:gosec // G204: the agent command line comes from the user's own config.yaml
+	cmd.Env = append(os.Environ(), inv.Env...)
+	cmd.Stdin = os.Stdin
+	if inv.Stdin != "" {
+		cmd.Stdin = strings.NewReader(inv.Stdin)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 // defaultRunCommand runs a command with stdin/stdout/stderr connected to the terminal.
 func defaultRunCommand(name string, args ...string) error {
 	cmd := exec.Command(name, args...) //nolint:gosec // G204: args are constructed internally by resolveAgentCommand, not from user input
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// OverdueStatName is the statusline stat counting the tikis assigned to
+// user() that are overdue. Clicking it runs ActionShowOverdue.
+const OverdueStatName = "Overdue"
+
+// overdueStatOrder places the stat after the user and branch stats.
+const overdueStatOrder = 5
+
+// overdueRecountInterval is how often the stat is recounted without store
+// changes, so deadlines that pass at midnight show up.
+const overdueRecountInterval = time.Hour
+
+// currentUserOverdue returns the overdue tikis assigned to the current user.
+// ok is false when the workflow has no due or assignee field, or no user
+// resolves.
+func currentUserOverdue(tikiStore store.ReadStore, now time.Time) ([]*tikipkg.Tiki, bool) {
+	cfg, ok := config.WorkflowAgenda()
+	if !ok || cfg.AssigneeField == "" {
+		return nil, false
+	}
+	user, err := store.CurrentUserDisplay(tikiStore)
+	if err != nil || user == "" {
+		return nil, false
+	}
+	return store.OverdueTikis(tikiStore.GetAllTikis(), cfg, user, now), true
+}
+
+// UpdateOverdueStat shows the number of the current user's overdue tikis
+// in the statusline, and removes the stat when there are none.
+func UpdateOverdueStat(statusline *model.StatuslineConfig, tikiStore store.ReadStore, now time.Time) {
+	tikis, ok := currentUserOverdue(tikiStore, now)
+	if !ok || len(tikis) == 0 {
+		statusline.RemoveLeftStat(OverdueStatName)
+		return
+	}
+	statusline.SetLeftStat(OverdueStatName, fmt.Sprintf("⚑ %d overdue", len(tikis)), overdueStatOrder)
+}
+
+// WatchOverdueStat keeps the overdue stat current: it recounts on every
+// store change and every overdueRecountInterval until ctx is done. Recounts
+// run through redraw so the statusline only changes on the UI goroutine.
+func WatchOverdueStat(ctx context.Context, tikiStore store.ReadStore, statusline *model.StatuslineConfig, redraw func(func())) {
+	recount := func() {
+		redraw(func() { UpdateOverdueStat(statusline, tikiStore, time.Now()) })
+	}
+	UpdateOverdueStat(statusline, tikiStore, time.Now())
+	id := tikiStore.AddListener(recount)
+	go func() {
+		ticker := time.NewTicker(overdueRecountInterval)
+		defer ticker.Stop()
+		defer tikiStore.RemoveListener(id)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				recount()
+			}
+		}
+	}()
+}
+
+// showOverdue opens the QuickSelect picker on the current user's overdue
+// tikis; picking one opens it in the detail view.
+func (ir *InputRouter) showOverdue() bool {
+	tikis, ok := currentUserOverdue(ir.tikiStore, time.Now())
+	if !ok {
+		if ir.statusline != nil {
+			ir.statusline.SetMessage("overdue tikis need a due field, an assignee field and a current user",
+				model.MessageLevelError, true)
+		}
+		return true
+	}
+	if len(tikis) == 0 {
+		if ir.statusline != nil {
+			ir.statusline.SetMessage("nothing overdue", model.MessageLevelInfo, true)
+		}
+		return true
+	}
+	if ir.quickSelectConfig == nil || ir.quickSelectView == nil {
+		return false
+	}
+	ir.quickSelectConfig.SetOnSelect(ir.openInDetailView)
+	ir.quickSelectConfig.SetOnCancel(func() {})
+	ir.quickSelectView.OnShow(tikis)
+	ir.quickSelectConfig.SetVisible(true)
+	return true
+}
+
+// openInDetailView pushes the detail view on tikiID: the conventional
+// Detail view, else the first detail view by name.
+func (ir *InputRouter) openInDetailView(tikiID string) {
+	name := ""
+	if _, ok := ir.pluginControllers[model.DetailPluginName].(*DetailController); ok {
+		name = model.DetailPluginName
+	} else {
+		names := make([]string, 0, len(ir.pluginControllers))
+		for n, pc := range ir.pluginControllers {
+			if _, ok := pc.(*DetailController); ok {
+				names = append(names, n)
+			}
+		}
+		sort.Strings(names)
+		if len(names) > 0 {
+			name = names[0]
+		}
+	}
+	if name == "" || !TargetViewEnabled(name, 1) {
+		return
+	}
+	ir.navController.PushView(model.MakePluginViewID(name),
+		model.EncodePluginViewParams(model.PluginViewParams{TikiID: tikiID}))
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// fakeQuickSelectView records the tikis the router hands to the picker.
+type fakeQuickSelectView struct{ shown []*tikipkg.Tiki }
+
+func (f *fakeQuickSelectView) OnShow(tikis []*tikipkg.Tiki)    { f.shown = tikis }
+func (f *fakeQuickSelectView) GetFilterInput() tview.Primitive { return nil }
+
+// overdueStore holds one overdue tiki for the in-memory store's user, one
+// for someone else and one due in the future.
+func overdueStore(t *testing.T) store.Store {
+	t.Helper()
+	config.ResetWorkflowAgendaForTest(&config.AgendaConfig{
+		DueFields:     []string{"due"},
+		AssigneeField: "assignee",
+		DoneStatus:    config.DoneStatus{StatusField: "status", Done: []string{"done"}},
+	})
+	t.Cleanup(func() { config.ResetWorkflowAgendaForTest(nil) })
+
+	s := store.NewInMemoryStore()
+	add := func(id, assignee string, due time.Time) {
+		tk := tikipkg.New()
+		tk.SetID(id)
+		tk.SetTitle(id)
+		tk.Set("status", "ready")
+		tk.Set("assignee", assignee)
+		tk.Set("due", due)
+		if err := s.CreateTiki(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().AddDate(0, 0, -3)
+	add("MINE01", "memory-user", past)
+	add("THEIRS", "someone-else", past)
+	add("LATER1", "memory-user", time.Now().AddDate(0, 0, 3))
+	return s
+}
+
+func TestUpdateOverdueStat(t *testing.T) {
+	s := overdueStore(t)
+	statusline := model.NewStatuslineConfig()
+
+	UpdateOverdueStat(statusline, s, time.Now())
+	if got := statusline.GetLeftStats()[OverdueStatName].Value; got != "⚑ 1 overdue" {
+		t.Errorf("overdue stat = %q, want one tiki for the current user", got)
+	}
+
+	s.DeleteTiki("MINE01")
+	UpdateOverdueStat(statusline, s, time.Now())
+	if _, ok := statusline.GetLeftStats()[OverdueStatName]; ok {
+		t.Error("the stat should disappear when nothing is overdue")
+	}
+}
+
+func TestInputRouter_ShowOverdueOpensQuickSelect(t *testing.T) {
+	qc := model.NewQuickSelectConfig()
+	fake := &fakeQuickSelectView{}
+	ir := &InputRouter{
+		navController: NewNavigationController(tview.NewApplication()),
+		statusline:    model.NewStatuslineConfig(),
+		globalActions: DefaultGlobalActions(),
+		tikiStore:     overdueStore(t),
+	}
+	ir.SetQuickSelectConfig(qc)
+	ir.SetQuickSelectView(fake)
+
+	if !ir.handleGlobalAction(ActionShowOverdue) {
+		t.Fatal("ActionShowOverdue not handled")
+	}
+	if !qc.IsVisible() {
+		t.Fatal("expected the QuickSelect picker to be visible")
+	}
+	if len(fake.shown) != 1 || fake.shown[0].ID() != "MINE01" {
+		t.Errorf("picker got %v, want only MINE01", fake.shown)
+	}
+}
@@ -42,6 +42,9 @@ func NewPluginController(
 			schema:        schema,
 		},
 	}
+	if pluginDef.Swimlanes != nil {
+		registerSwimlaneActions(pc.registry)
+	}
 
 	// register plugin-specific shortcut actions, warn about conflicts
 	globalActions := DefaultGlobalActions()
@@ -116,6 +119,14 @@ func (pc *PluginController) HandleAction(actionID ActionID) bool {
 		return pc.handleMoveTiki(-1)
 	case ActionMoveTikiRight:
 		return pc.handleMoveTiki(1)
+	case ActionMoveTikiUp:
+		return pc.handleMoveBand(-1)
+	case ActionMoveTikiDown:
+		return pc.handleMoveBand(1)
+	case ActionToggleSwimlane:
+		return pc.ToggleSelectedBand()
+	case ActionExpandSwimlanes:
+		return pc.pluginConfig.ExpandAllBands()
 	default:
 		if keyStr := getPluginActionKeyStr(actionID); keyStr != "" {
 			return pc.handlePluginAction(actionID)
@@ -159,7 +170,7 @@ func (pc *PluginController) buildExecutionInput(pa *plugin.PluginAction) (ruki.E
 	}
 
 	if pa.Action != nil && pa.Action.IsCreate() {
-		template, err := pc.tikiStore.NewTikiTemplate()
+		template, err := service.NewTikiFromTemplate(pc.tikiStore, pa.CreateTemplate)
 		if err != nil {
 			slog.Error("failed to create tiki template for plugin action", "error", err)
 			return input, false
@@ -253,7 +264,9 @@ func (pc *PluginController) executeAndApply(pa *plugin.PluginAction, input ruki.
 			pc.ensureSearchResultIncludesTiki(tk)
 		}
 	case result.Create != nil:
-		if err := pc.mutationGate.CreateTiki(ctx, tikipkg.UnwrapDoc(result.Create.Tiki)); err != nil {
+		created := tikipkg.UnwrapDoc(result.Create.Tiki)
+		service.FinishTemplateCreate(pc.tikiStore, created, pa.CreateTemplate)
+		if err := pc.mutationGate.CreateTiki(ctx, created); err != nil {
 			slog.Error("failed to create tiki from plugin action", "key", pa.KeyStr, "error", err)
 			if pc.statusline != nil {
 				pc.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
@@ -299,14 +312,24 @@ func (pc *PluginController) executeAndApply(pa *plugin.PluginAction, input ruki.
 }
 
 // handlePluginAction applies a plugin shortcut action. Ruki-kind actions run
-// through the executor pipeline; view-kind actions navigate to another view.
+// through the executor pipeline; view-kind actions navigate to another view;
+// chat-kind actions start an AI agent on the selected tiki; propose-kind
+// actions ask an agent for changes to review.
 func (pc *PluginController) handlePluginAction(actionID ActionID) bool {
 	pa, ok := pc.getPluginAction(actionID)
 	if !ok {
 		return false
 	}
-	if pa.Kind == plugin.ActionKindView {
+	switch pa.Kind {
+	case plugin.ActionKindView:
 		return pc.handleViewAction(pa)
+	case plugin.ActionKindChat:
+		return runAgentChat(pc.navController, pc.tikiStore, pc.statusline, pa.Agent,
+			pc.getSelectedTikiID(pc.GetFilteredTikisForLane))
+	case plugin.ActionKindPropose:
+		executor := NewPluginExecutor(pc.tikiStore, pc.mutationGate, pc.statusline, pc.progressHub, pc.schema,
+			pc.pluginDef.Name, pc.ensureSearchResultIncludesTiki)
+		return executor.Propose(pa, pc.getSelectedTikiIDs(pc.GetFilteredTikisForLane), pc.navController)
 	}
 	input, ok := pc.buildExecutionInput(pa)
 	if !ok {
@@ -399,7 +422,7 @@ func buildDetailViewParams(pa *plugin.PluginAction, ids []string, s store.Store,
 // back to the bare template, keeping pure-navigation callers working.
 func newModeDraft(pa *plugin.PluginAction, s store.Store, exec *PluginExecutor) (*tikipkg.Tiki, error) {
 	if pa.CreateSeed != nil && exec != nil {
-		return exec.BuildCreateDraft(pa.CreateSeed)
+		return exec.BuildCreateDraft(pa.CreateSeed, pa.CreateTemplate)
 	}
 	return createDraftTiki(s)
 }
@@ -453,9 +476,11 @@ func (pc *PluginController) HandleActionInput(actionID ActionID, text string) In
 }
 
 // GetActionChooseSpec returns the label and whether the action uses choose().
+// A bare `mode: new` action also reports a choose spec when the workflow
+// declares templates, so the router opens the template picker first.
 func (pc *PluginController) GetActionChooseSpec(actionID ActionID) (string, bool) {
 	pa, ok := pc.getPluginAction(actionID)
-	if !ok || !pa.HasChoose {
+	if !ok || (!pa.HasChoose && !offersTemplatePicker(pa)) {
 		return "", false
 	}
 	return pa.Label, true
@@ -468,7 +493,17 @@ func (pc *PluginController) GetActionChooseSpec(actionID ActionID) (string, bool
 // statusline message.
 func (pc *PluginController) CanStartActionChoose(actionID ActionID) (string, []*tikipkg.Tiki, bool) {
 	pa, ok := pc.getPluginAction(actionID)
-	if !ok || !pa.HasChoose {
+	if !ok {
+		return "", nil, false
+	}
+	if offersTemplatePicker(pa) {
+		ids := pc.getSelectedTikiIDs(pc.GetFilteredTikisForLane)
+		if !selectionSatisfies(pa.Require, len(ids)) {
+			return "", nil, false
+		}
+		return pa.Label, templateChoices(), true
+	}
+	if !pa.HasChoose {
 		return "", nil, false
 	}
 	input, ok := pc.buildExecutionInput(pa)
@@ -492,13 +527,20 @@ func (pc *PluginController) CanStartActionChoose(actionID ActionID) (string, []*
 }
 
 // HandleActionChoose handles the selected tiki ID from the QuickSelect picker.
+// For the template picker the "id" is the chosen template name.
 // For ruki-kind actions the chosen id is fed back into the validated statement
 // via ExecutionInput.ChooseValue. For view-kind actions the chosen id replaces
 // the source view's cursor selection in the navigation params, so the target
 // detail view opens on the picked tiki rather than the cursor row.
 func (pc *PluginController) HandleActionChoose(actionID ActionID, tikiID string) bool {
 	pa, ok := pc.getPluginAction(actionID)
-	if !ok || !pa.HasChoose {
+	if !ok {
+		return false
+	}
+	if offersTemplatePicker(pa) {
+		return pc.handleViewActionWithTemplate(pa, tikiID)
+	}
+	if !pa.HasChoose {
 		return false
 	}
 	if pa.Kind == plugin.ActionKindView {
@@ -534,6 +576,13 @@ func (pc *PluginController) handleViewActionWithChosenID(pa *plugin.PluginAction
 }
 
 func (pc *PluginController) handleMoveTiki(offset int) bool {
+	return pc.MoveSelectedTikiToLane(pc.pluginConfig.GetSelectedLane() + offset)
+}
+
+// MoveSelectedTikiToLane runs targetLane's `action:` on the selected tiki
+// through the mutation gate and follows the tiki into that lane. Keyboard
+// moves (Shift-←/→) and mouse drags both land here.
+func (pc *PluginController) MoveSelectedTikiToLane(targetLane int) bool {
 	tikiID := pc.getSelectedTikiID(pc.GetFilteredTikisForLane)
 	if tikiID == "" {
 		return false
@@ -543,9 +592,7 @@ func (pc *PluginController) handleMoveTiki(offset int) bool {
 		return false
 	}
 
-	currentLane := pc.pluginConfig.GetSelectedLane()
-	targetLane := currentLane + offset
-	if targetLane < 0 || targetLane >= len(pc.pluginDef.Lanes) {
+	if targetLane == pc.pluginConfig.GetSelectedLane() || targetLane < 0 || targetLane >= len(pc.pluginDef.Lanes) {
 		return false
 	}
 
@@ -554,62 +601,89 @@ func (pc *PluginController) handleMoveTiki(offset int) bool {
 		return false
 	}
 
-	allTikis := pc.tikiStore.GetAllTikis()
-	executor := pc.newExecutor()
-	result, err := executor.Execute(actionStmt, tikipkg.WrapDocs(allTikis), ruki.NewSingleSelectionInput(tikiID))
-	if err != nil {
-		slog.Error("failed to execute lane action", "tiki_id", tikiID, "error", err)
+	movedTiki, ok := pc.runMoveAction(actionStmt, tikiID)
+	if !ok || !pc.commitMove(movedTiki) {
 		return false
 	}
 
-	if result.Update == nil || len(result.Update.Updated) == 0 {
-		return false
-	}
-
-	movedTiki := tikipkg.UnwrapDoc(result.Update.Updated[0])
-	if err := pc.mutationGate.UpdateTiki(context.Background(), movedTiki); err != nil {
-		slog.Error("failed to update tiki after lane move", "tiki_id", tikiID, "error", err)
-		if pc.statusline != nil {
-			pc.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
-		}
-		return false
-	}
-
-	pc.ensureSearchResultIncludesTiki(movedTiki)
 	pc.selectTikiInLane(targetLane, tikiID, pc.GetFilteredTikisForLane)
 	return true
 }
 
-// GetFilteredTikisForLane returns tikis filtered and sorted for a specific lane.
-func (pc *PluginController) GetFilteredTikisForLane(lane int) []*tikipkg.Tiki {
-	if pc.pluginDef == nil {
+// BlockedMoves returns the Shift-arrow moves of the selected tiki into a
+// lane or band whose action the workflow's permissions deny the user.
+func (pc *PluginController) BlockedMoves() []ActionID {
+	tikiID := pc.GetSelectedTikiID()
+	if tikiID == "" || pc.pluginDef == nil {
 		return nil
 	}
-	if lane < 0 || lane >= len(pc.pluginDef.Lanes) {
-		return nil
+	var blocked []ActionID
+	lane := pc.pluginConfig.GetSelectedLane()
+	for _, move := range []struct {
+		id     ActionID
+		offset int
+	}{{ActionMoveTikiLeft, -1}, {ActionMoveTikiRight, 1}} {
+		target := lane + move.offset
+		if target >= 0 && target < len(pc.pluginDef.Lanes) && !deniedRequirementsMet(pc.pluginDef.Lanes[target].MoveRequire, tikiID) {
+			blocked = append(blocked, move.id)
+		}
+	}
+	if !pc.HasSwimlanes() {
+		return blocked
 	}
+	for _, move := range []struct {
+		id     ActionID
+		offset int
+	}{{ActionMoveTikiUp, -1}, {ActionMoveTikiDown, 1}} {
+		if target := pc.bandTarget(move.offset); target >= 0 && !deniedRequirementsMet(pc.bandMoveRequire(target), tikiID) {
+			blocked = append(blocked, move.id)
+		}
+	}
+	return blocked
+}
 
-	filterStmt := pc.pluginDef.Lanes[lane].Filter
-	allTikis := pc.tikiStore.GetAllTikis()
+// GetSelectedTikiID returns the id of the selected tiki, or "" when the
+// selected lane is empty.
+func (pc *PluginController) GetSelectedTikiID() string {
+	return pc.getSelectedTikiID(pc.GetFilteredTikisForLane)
+}
 
-	var filtered []*tikipkg.Tiki
-	needsSort := false
-	if filterStmt == nil {
-		// no filter: use all tikis — order is nondeterministic from the map
-		filtered = make([]*tikipkg.Tiki, 0, len(allTikis))
-		filtered = append(filtered, allTikis...)
-		needsSort = true
-	} else {
-		executor := pc.newExecutor()
-		result, err := executor.Execute(filterStmt, tikipkg.WrapDocs(allTikis))
-		if err != nil {
-			slog.Error("failed to execute lane filter", "lane", lane, "error", err)
-			return nil
+// SelectTikiByID selects tikiID, preferring the currently selected lane and
+// otherwise the first lane that shows it. Returns false when no lane does.
+func (pc *PluginController) SelectTikiByID(tikiID string) bool {
+	if pc.pluginDef == nil {
+		return false
+	}
+	lanes := make([]int, 0, len(pc.pluginDef.Lanes))
+	lanes = append(lanes, pc.pluginConfig.GetSelectedLane())
+	for lane := range pc.pluginDef.Lanes {
+		lanes = append(lanes, lane)
+	}
+	for _, lane := range lanes {
+		for _, tk := range pc.GetFilteredTikisForLane(lane) {
+			if tk.ID() == tikiID {
+				pc.selectTikiInLane(lane, tikiID, pc.GetFilteredTikisForLane)
+				return true
+			}
 		}
-		filtered = tikipkg.UnwrapDocs(result.Select.Tikis)
-		// only skip secondary sort when the filter statement carries its own order by
-		needsSort = !filterStmt.HasOrderBy()
 	}
+	return false
+}
+
+// GetFilteredTikisForLane returns tikis filtered and sorted for a specific
+// lane. On a swimlane board they are grouped band by band, without the
+// tikis of collapsed bands.
+func (pc *PluginController) GetFilteredTikisForLane(lane int) []*tikipkg.Tiki {
+	tikis := pc.laneTikis(lane)
+	if pc.HasSwimlanes() {
+		return pc.swimlaneTikis(tikis)
+	}
+	return tikis
+}
+
+// laneTikis runs the lane filter, narrowed by the active search.
+func (pc *PluginController) laneTikis(lane int) []*tikipkg.Tiki {
+	filtered, ordered := pc.laneMembers(lane)
 
 	// narrow by search results if active
 	if searchResults := pc.pluginConfig.GetSearchResults(); searchResults != nil {
@@ -620,12 +694,40 @@ func (pc *PluginController) GetFilteredTikisForLane(lane int) []*tikipkg.Tiki {
 		filtered = filterTikisBySearch(filtered, searchTikiMap)
 	}
 
-	if needsSort {
+	if !ordered {
 		sortTikisByTitle(filtered)
 	}
 	return filtered
 }
 
+// laneMembers returns every tiki the lane filter selects, ignoring search.
+// ordered reports whether the filter carries its own order by.
+func (pc *PluginController) laneMembers(lane int) (tikis []*tikipkg.Tiki, ordered bool) {
+	if pc.pluginDef == nil {
+		return nil, false
+	}
+	if lane < 0 || lane >= len(pc.pluginDef.Lanes) {
+		return nil, false
+	}
+
+	filterStmt := pc.pluginDef.Lanes[lane].Filter
+	allTikis := pc.tikiStore.GetAllTikis()
+
+	if filterStmt == nil {
+		// no filter: use all tikis — order is nondeterministic from the map
+		filtered := make([]*tikipkg.Tiki, 0, len(allTikis))
+		return append(filtered, allTikis...), false
+	}
+	executor := pc.newExecutor()
+	result, err := executor.Execute(filterStmt, tikipkg.WrapDocs(allTikis))
+	if err != nil {
+		slog.Error("failed to execute lane filter", "lane", lane, "error", err)
+		return nil, false
+	}
+	// only skip secondary sort when the filter statement carries its own order by
+	return tikipkg.UnwrapDocs(result.Select.Tikis), filterStmt.HasOrderBy()
+}
+
 func (pc *PluginController) ensureSearchResultIncludesTiki(updated *tikipkg.Tiki) {
 	if updated == nil {
 		return
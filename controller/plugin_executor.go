@@ -77,7 +77,7 @@ func (pe *PluginExecutor) BuildExecutionInput(pa *plugin.PluginAction, selectedI
 	}
 
 	if pa.Action != nil && pa.Action.IsCreate() {
-		template, err := pe.tikiStore.NewTikiTemplate()
+		template, err := service.NewTikiFromTemplate(pe.tikiStore, pa.CreateTemplate)
 		if err != nil {
 			slog.Error("failed to create tiki template for plugin action", "error", err)
 			return input, false
@@ -92,12 +92,13 @@ func (pe *PluginExecutor) BuildExecutionInput(pa *plugin.PluginAction, selectedI
 // view actions: catalog defaults from NewTikiTemplate() are layered with the
 // statement's assignments, and the tiki is handed to the detail view as an
 // editable draft (persisted only on form commit). It never calls the mutation
-// gate, so a cancelled form leaves nothing on disk.
-func (pe *PluginExecutor) BuildCreateDraft(stmt *ruki.ValidatedStatement) (*tikipkg.Tiki, error) {
+// gate, so a cancelled form leaves nothing on disk. templateName is the
+// workflow template from a `create from "<name>"` seed, or "" for none.
+func (pe *PluginExecutor) BuildCreateDraft(stmt *ruki.ValidatedStatement, templateName string) (*tikipkg.Tiki, error) {
 	if stmt == nil || !stmt.IsCreate() {
 		return nil, fmt.Errorf("BuildCreateDraft requires a create statement")
 	}
-	template, err := pe.tikiStore.NewTikiTemplate()
+	template, err := service.NewTikiFromTemplate(pe.tikiStore, templateName)
 	if err != nil {
 		return nil, fmt.Errorf("build create template: %w", err)
 	}
@@ -111,7 +112,9 @@ func (pe *PluginExecutor) BuildCreateDraft(stmt *ruki.ValidatedStatement) (*tiki
 	if result.Create == nil {
 		return nil, fmt.Errorf("create seed produced no tiki")
 	}
-	return tikipkg.UnwrapDoc(result.Create.Tiki), nil
+	draft := tikipkg.UnwrapDoc(result.Create.Tiki)
+	service.FinishTemplateCreate(pe.tikiStore, draft, templateName)
+	return draft, nil
 }
 
 // EvalChooseFilter evaluates an action's choose subquery against the current
@@ -198,7 +201,9 @@ func (pe *PluginExecutor) applyResult(pa *plugin.PluginAction, input ruki.Execut
 			}
 		}
 	case result.Create != nil:
-		if err := pe.mutationGate.CreateTiki(ctx, tikipkg.UnwrapDoc(result.Create.Tiki)); err != nil {
+		created := tikipkg.UnwrapDoc(result.Create.Tiki)
+		service.FinishTemplateCreate(pe.tikiStore, created, pa.CreateTemplate)
+		if err := pe.mutationGate.CreateTiki(ctx, created); err != nil {
 			slog.Error("failed to create tiki from plugin action", "key", pa.KeyStr, "error", err)
 			pe.setError(err)
 			return false
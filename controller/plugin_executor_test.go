@@ -92,7 +92,7 @@ func TestPluginExecutor_BuildCreateDraft_SeedsTypeWithoutPersisting(t *testing.T
 	}
 
 	before := len(tikiStore.GetAllTikis())
-	draft, err := pe.BuildCreateDraft(stmt)
+	draft, err := pe.BuildCreateDraft(stmt, "")
 	if err != nil {
 		t.Fatalf("BuildCreateDraft: %v", err)
 	}
@@ -115,7 +115,7 @@ func TestPluginExecutor_BuildCreateDraft_RejectsNonCreate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("parse update: %v", err)
 	}
-	if _, err := pe.BuildCreateDraft(stmt); err == nil {
+	if _, err := pe.BuildCreateDraft(stmt, ""); err == nil {
 		t.Fatal("expected error for non-create statement")
 	}
 }
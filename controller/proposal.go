@@ -0,0 +1,550 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/go-udiff"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// proposalFormat is appended to every `kind: propose` prompt. It tells the
+// agent to answer with the JSON document parseProposal reads.
+const proposalFormat = `Do not edit any files. Reply with one JSON object and nothing else:
+
+{
+  "summary": "one line describing the proposal",
+  "updates": [
+    {"id": "<tiki id>", "fields": {"<field>": <value>}, "body": "<new markdown body>", "dependsOn": ["<tiki id or ref>"]}
+  ],
+  "create": [
+    {"ref": "<short name>", "title": "<title>", "fields": {"<field>": <value>}, "body": "<markdown body>", "dependsOn": ["<tiki id or ref>"]}
+  ]
+}
+
+Only list the tikis you change and only the keys you change; omit "body" to keep a body as it is. "title" may be set in "fields", and null clears a field. Dates are YYYY-MM-DD. "dependsOn" adds dependencies: a tiki id, or the "ref" of a tiki in "create".`
+
+// proposal is the JSON document a `kind: propose` agent answers with.
+type proposal struct {
+	Summary string           `json:"summary"`
+	Updates []proposalUpdate `json:"updates"`
+	Create  []proposalCreate `json:"create"`
+}
+
+type proposalUpdate struct {
+	ID        string                 `json:"id"`
+	Fields    map[string]interface{} `json:"fields"`
+	Body      *string                `json:"body"`
+	DependsOn []string               `json:"dependsOn"`
+}
+
+type proposalCreate struct {
+	Ref       string                 `json:"ref"`
+	Title     string                 `json:"title"`
+	Fields    map[string]interface{} `json:"fields"`
+	Body      string                 `json:"body"`
+	DependsOn []string               `json:"dependsOn"`
+}
+
+// parseProposal reads the proposal out of an agent's answer. Agents tend to
+// wrap JSON in prose or code fences, so the outermost {...} is decoded.
+func parseProposal(out []byte) (*proposal, error) {
+	text := string(out)
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("agent answer contains no JSON object")
+	}
+	var p proposal
+	if err := json.Unmarshal([]byte(text[start:end+1]), &p); err != nil {
+		return nil, fmt.Errorf("agent answer is not a valid proposal: %w", err)
+	}
+	if len(p.Updates) == 0 && len(p.Create) == 0 {
+		return nil, fmt.Errorf("agent proposed no changes")
+	}
+	return &p, nil
+}
+
+// proposalPrompt builds the prompt of a `kind: propose` run: the action's
+// request, the workflow fields, the context tikis in full, and the answer
+// format.
+func proposalPrompt(request string, tikis []*tikipkg.Tiki, tikiStore store.ReadStore) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(request))
+	b.WriteString("\n\nYou are working on tikis: markdown documents with a title, typed fields and a body.\n\nFields:\n")
+	for _, fd := range workflow.WorkflowFields() {
+		if fd.IsComputed() || workflow.IsDerivedFieldName(fd.Name) {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s", fd.Name)
+		if values := fd.AllowedValues(); len(values) > 0 {
+			fmt.Fprintf(&b, " (one of: %s)", strings.Join(values, ", "))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\nTikis:\n")
+	for _, tk := range tikis {
+		fmt.Fprintf(&b, "\n## %s: %s\n", tk.ID(), tk.Title())
+		if path := tikiStore.PathForID(tk.ID()); path != "" {
+			fmt.Fprintf(&b, "path: %s\n", path)
+		}
+		for _, fd := range workflow.WorkflowFields() {
+			if v, ok := tk.Get(fd.Name); ok {
+				fmt.Fprintf(&b, "%s: %s\n", fd.Name, proposalValue(fd.Name, v))
+			}
+		}
+		if body := strings.TrimSpace(tk.Body()); body != "" {
+			b.WriteString("\n" + body + "\n")
+		}
+	}
+	b.WriteString("\n" + proposalFormat + "\n")
+	return b.String()
+}
+
+// proposalChange is one reviewable change of a planned proposal.
+type proposalChange struct {
+	item   model.ReviewItem
+	old    *tikipkg.Tiki   // persisted tiki; nil for a create
+	tk     *tikipkg.Tiki   // the tiki after the change
+	update *proposalUpdate // the proposed edit, re-applied at apply time; nil for a create
+	links  []string        // dependencies to add: tiki ids or refs
+}
+
+// proposalPlan is a proposal checked against the store and turned into
+// concrete tikis, ready for review.
+type proposalPlan struct {
+	changes  []proposalChange
+	depField string
+	refs     map[string]int // create ref → index in changes
+}
+
+// planProposal checks p against the store and the workflow fields and
+// builds the changes to review. Any invalid entry fails the whole plan, so
+// the user never reviews half of what the agent meant.
+func planProposal(p *proposal, tikiStore store.ReadStore) (*proposalPlan, error) {
+	plan := &proposalPlan{depField: "dependsOn", refs: map[string]int{}}
+	if deps, ok := config.WorkflowDependencies(); ok {
+		plan.depField = deps.Field
+	}
+	titles := map[string]string{}
+	for _, c := range p.Create {
+		if c.Ref != "" {
+			titles[c.Ref] = c.Title
+		}
+	}
+
+	updated := map[string]bool{}
+	for _, u := range p.Updates {
+		id := strings.ToUpper(strings.TrimSpace(u.ID))
+		old := tikiStore.GetTiki(id)
+		if old == nil {
+			return nil, fmt.Errorf("update: unknown tiki %q", u.ID)
+		}
+		if updated[id] {
+			return nil, fmt.Errorf("update: tiki %s is listed more than once", id)
+		}
+		updated[id] = true
+		tk := old.Clone()
+		if err := applyProposalUpdate(tk, u); err != nil {
+			return nil, fmt.Errorf("update %s: %w", id, err)
+		}
+		diff := proposalDiff(old, tk)
+		diff = append(diff, plan.linkDiff(u.DependsOn, titles)...)
+		if len(diff) == 0 {
+			continue
+		}
+		plan.changes = append(plan.changes, proposalChange{
+			item:   model.ReviewItem{Summary: fmt.Sprintf("Update %s %s", id, tk.Title()), Diff: diff},
+			old:    old,
+			tk:     tk,
+			update: &u,
+			links:  u.DependsOn,
+		})
+	}
+
+	ids := map[string]bool{}
+	for _, c := range p.Create {
+		if strings.TrimSpace(c.Title) == "" {
+			return nil, fmt.Errorf("create: title is required")
+		}
+		if c.Ref != "" {
+			if _, dup := plan.refs[c.Ref]; dup {
+				return nil, fmt.Errorf("create: duplicate ref %q", c.Ref)
+			}
+		}
+		tk, err := newProposedTiki(tikiStore, ids)
+		if err != nil {
+			return nil, fmt.Errorf("create %q: %w", c.Title, err)
+		}
+		tk.SetTitle(strings.TrimSpace(c.Title))
+		if err := setProposalFields(tk, c.Fields); err != nil {
+			return nil, fmt.Errorf("create %q: %w", c.Title, err)
+		}
+		tk.SetBody(c.Body)
+		if c.Ref != "" {
+			plan.refs[c.Ref] = len(plan.changes)
+		}
+		diff := append(proposalDiff(nil, tk), plan.linkDiff(c.DependsOn, titles)...)
+		plan.changes = append(plan.changes, proposalChange{
+			item:  model.ReviewItem{Summary: "Create " + tk.Title(), Diff: diff},
+			tk:    tk,
+			links: c.DependsOn,
+		})
+	}
+	if len(plan.changes) == 0 {
+		return nil, fmt.Errorf("agent proposed no changes")
+	}
+	return plan, nil
+}
+
+// newProposedTiki mints a creation template whose id is unique in the store
+// and among the tikis already planned.
+func newProposedTiki(tikiStore store.ReadStore, planned map[string]bool) (*tikipkg.Tiki, error) {
+	for range 10 {
+		tk, err := service.NewTikiFromTemplate(tikiStore, "")
+		if err != nil {
+			return nil, err
+		}
+		if !planned[tk.ID()] {
+			planned[tk.ID()] = true
+			return tk, nil
+		}
+	}
+	return nil, fmt.Errorf("could not generate a unique id")
+}
+
+// applyProposalUpdate applies a proposed edit's fields and body to tk.
+func applyProposalUpdate(tk *tikipkg.Tiki, u proposalUpdate) error {
+	if err := setProposalFields(tk, u.Fields); err != nil {
+		return err
+	}
+	if u.Body != nil {
+		tk.SetBody(*u.Body)
+	}
+	return nil
+}
+
+// setProposalFields applies proposed field values to tk. Values are coerced
+// through the workflow field catalog; null clears a field.
+func setProposalFields(tk *tikipkg.Tiki, fields map[string]interface{}) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		raw := fields[name]
+		if name == "title" {
+			title, ok := raw.(string)
+			if !ok || strings.TrimSpace(title) == "" {
+				return fmt.Errorf("title must be a non-empty string")
+			}
+			tk.SetTitle(strings.TrimSpace(title))
+			continue
+		}
+		fd, ok := workflow.Field(name)
+		if !ok || workflow.IsSystemField(name) || fd.Derived {
+			return fmt.Errorf("field %q cannot be set", name)
+		}
+		if raw == nil {
+			tk.Delete(name)
+			continue
+		}
+		v, err := config.CoerceFieldValue(fd, raw)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+		tk.Set(name, v)
+	}
+	return nil
+}
+
+// proposalDiff lists what differs between old and new (old is nil for a
+// create) the way a ruki dry run does: title, workflow fields in declaration
+// order, then a unified diff of the body.
+func proposalDiff(old, new *tikipkg.Tiki) []string {
+	var diff []string
+	if old == nil || old.Title() != new.Title() {
+		if old != nil {
+			diff = append(diff, "- title: "+old.Title())
+		}
+		diff = append(diff, "+ title: "+new.Title())
+	}
+	for _, fd := range workflow.WorkflowFields() {
+		ov, hadOld := proposalField(old, fd.Name)
+		nv, hasNew := proposalField(new, fd.Name)
+		if (old == nil && !hasNew) || (hadOld == hasNew && reflect.DeepEqual(ov, nv)) {
+			continue
+		}
+		if hadOld {
+			diff = append(diff, fmt.Sprintf("- %s: %s", fd.Name, proposalValue(fd.Name, ov)))
+		}
+		if hasNew {
+			diff = append(diff, fmt.Sprintf("+ %s: %s", fd.Name, proposalValue(fd.Name, nv)))
+		}
+	}
+
+	oldBody := ""
+	if old != nil {
+		oldBody = old.Body()
+	}
+	if oldBody == new.Body() {
+		return diff
+	}
+	diff = append(diff, "  body:")
+	unified := udiff.Unified("old", "new", withNewline(oldBody), withNewline(new.Body()))
+	for _, line := range strings.Split(strings.TrimSuffix(unified, "\n"), "\n") {
+		if strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "@@") {
+			continue
+		}
+		if line == "" {
+			line = " "
+		}
+		diff = append(diff, line[:1]+" "+line[1:])
+	}
+	return diff
+}
+
+// linkDiff renders the dependencies a change adds. Refs to created tikis
+// show the new tiki's title.
+func (plan *proposalPlan) linkDiff(links []string, titles map[string]string) []string {
+	diff := make([]string, 0, len(links))
+	for _, link := range links {
+		if title, ok := titles[link]; ok {
+			diff = append(diff, fmt.Sprintf("+ %s: %s (new)", plan.depField, title))
+			continue
+		}
+		diff = append(diff, fmt.Sprintf("+ %s: %s", plan.depField, strings.ToUpper(link)))
+	}
+	return diff
+}
+
+func proposalField(tk *tikipkg.Tiki, name string) (interface{}, bool) {
+	if tk == nil {
+		return nil, false
+	}
+	return tk.Get(name)
+}
+
+// proposalValue renders a field value for a prompt or a diff line.
+func proposalValue(name string, v interface{}) string {
+	switch val := v.(type) {
+	case []string:
+		return strings.Join(val, ", ")
+	case time.Time:
+		if fd, ok := workflow.Field(name); ok && fd.Type == workflow.TypeDate {
+			return val.Format(time.DateOnly)
+		}
+		return val.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+func withNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
+
+// batch builds the mutations for the accepted changes, index-aligned with
+// plan.changes. Updates are re-applied to the tiki as it is in the store
+// now, so edits made while the review was open are kept rather than
+// overwritten. Links to created tikis resolve to their new ids; links to
+// creates that were not accepted are dropped.
+func (plan *proposalPlan) batch(accepted []bool, tikiStore store.ReadStore) (*service.MutationBatch, error) {
+	b := &service.MutationBatch{}
+	for i, ch := range plan.changes {
+		if i >= len(accepted) || !accepted[i] {
+			continue
+		}
+		tk := ch.tk.Clone()
+		if ch.update != nil {
+			current := tikiStore.GetTiki(ch.old.ID())
+			if current == nil {
+				return nil, fmt.Errorf("tiki %s was deleted during the review", ch.old.ID())
+			}
+			tk = current.Clone()
+			if err := applyProposalUpdate(tk, *ch.update); err != nil {
+				return nil, fmt.Errorf("update %s: %w", tk.ID(), err)
+			}
+		}
+		if len(ch.links) > 0 {
+			deps, _, _ := tk.StringSliceField(plan.depField)
+			deps = append([]string(nil), deps...)
+			for _, link := range ch.links {
+				id := strings.ToUpper(strings.TrimSpace(link))
+				if idx, ok := plan.refs[link]; ok {
+					if idx >= len(accepted) || !accepted[idx] {
+						continue
+					}
+					id = plan.changes[idx].tk.ID()
+				}
+				if id != "" && id != tk.ID() && !containsString(deps, id) {
+					deps = append(deps, id)
+				}
+			}
+			tk.Set(plan.depField, deps)
+		}
+		if ch.old == nil {
+			b.Creates = append(b.Creates, tk)
+		} else {
+			b.Updates = append(b.Updates, tk)
+		}
+	}
+	return b, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Propose runs a `kind: propose` action: the agent gets the action's prompt
+// and the context tikis (the selection, or the matches of the action's
+// select statement), runs in print mode off the UI goroutine, and its
+// answer opens in the proposal review overlay. Nothing is written until the
+// user applies the reviewed changes. Returns false when the run could not
+// start.
+func (pe *PluginExecutor) Propose(pa *plugin.PluginAction, selectedIDs []string, navController *NavigationController) bool {
+	if navController == nil || navController.ProposalReview() == nil {
+		return false
+	}
+	if !selectionSatisfies(pa.Require, len(selectedIDs)) {
+		return false
+	}
+	tikis, err := pe.proposalContext(pa, selectedIDs)
+	if err != nil {
+		pe.setError(err)
+		return false
+	}
+	if len(tikis) == 0 {
+		if pe.statusline != nil {
+			pe.statusline.SetMessage(pa.Label+": no tikis to send to the agent", model.MessageLevelInfo, true)
+		}
+		return false
+	}
+
+	agent := pa.Agent
+	if agent == "" {
+		agent = config.GetAIAgent()
+	}
+	tool, ok := config.LookupAITool(agent)
+	if !ok {
+		pe.setError(fmt.Errorf("unknown agent %q", agent))
+		return false
+	}
+	inv := tool.PrintInvocation(agentContextFor(pe.tikiStore, tikis[0].ID()), proposalPrompt(pa.Prompt, tikis, pe.tikiStore))
+
+	slog.Info("running propose action", "key", pa.KeyStr, "label", pa.Label, "agent", tool.Key, "tikis", len(tikis))
+	reporter := pe.startProgress("asking " + tool.Key)
+	navController.RunInBackground(func() func() {
+		defer reporter.Done()
+		out, runErr := runHeadlessAgent(inv, proposalTimeout)
+		return func() { pe.reviewProposal(pa, out, runErr, navController.ProposalReview()) }
+	})
+	return true
+}
+
+// proposalContext returns the tikis a propose action sends to the agent.
+func (pe *PluginExecutor) proposalContext(pa *plugin.PluginAction, selectedIDs []string) ([]*tikipkg.Tiki, error) {
+	if pa.Action == nil {
+		tikis := make([]*tikipkg.Tiki, 0, len(selectedIDs))
+		for _, id := range selectedIDs {
+			if tk := pe.tikiStore.GetTiki(id); tk != nil {
+				tikis = append(tikis, tk)
+			}
+		}
+		return tikis, nil
+	}
+	executor := ruki.NewExecutor(pe.schema, pe.factory(), pe.userFunc(),
+		ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimePlugin})
+	input := ruki.ExecutionInput{}
+	if len(selectedIDs) > 0 {
+		input.SelectedTikiIDs = selectedIDs
+	}
+	result, err := executor.Execute(pa.Action, tikipkg.WrapDocs(pe.tikiStore.GetAllTikis()), input)
+	if err != nil {
+		return nil, err
+	}
+	if result.Select == nil {
+		return nil, nil
+	}
+	return tikipkg.UnwrapDocs(result.Select.Tikis), nil
+}
+
+// reviewProposal runs on the UI goroutine once the agent has answered: it
+// plans the proposal and opens it for review, or reports why it cannot.
+func (pe *PluginExecutor) reviewProposal(pa *plugin.PluginAction, out []byte, runErr error, review *model.ProposalReviewConfig) {
+	if runErr != nil {
+		slog.Error("propose agent failed", "key", pa.KeyStr, "error", runErr)
+		pe.setError(fmt.Errorf("%s: %w", pa.Label, runErr))
+		return
+	}
+	p, err := parseProposal(out)
+	if err == nil {
+		var plan *proposalPlan
+		if plan, err = planProposal(p, pe.tikiStore); err == nil {
+			items := make([]model.ReviewItem, len(plan.changes))
+			for i, ch := range plan.changes {
+				items[i] = ch.item
+			}
+			title := pa.Label
+			if p.Summary != "" {
+				title += ": " + p.Summary
+			}
+			review.Show(title, items, func(accepted []bool) { pe.applyProposal(pa, plan, accepted) }, nil)
+			return
+		}
+	}
+	slog.Error("invalid proposal", "key", pa.KeyStr, "error", err)
+	pe.setError(fmt.Errorf("%s: %w", pa.Label, err))
+}
+
+// applyProposal writes the accepted changes through the mutation gate as one
+// all-or-nothing batch.
+func (pe *PluginExecutor) applyProposal(pa *plugin.PluginAction, plan *proposalPlan, accepted []bool) {
+	b, err := plan.batch(accepted, pe.tikiStore)
+	if err != nil {
+		slog.Error("failed to apply proposal", "key", pa.KeyStr, "error", err)
+		pe.setError(fmt.Errorf("%s: %w", pa.Label, err))
+		return
+	}
+	if b.Len() == 0 {
+		return
+	}
+	if err := pe.mutationGate.ApplyBatch(context.Background(), b); err != nil {
+		slog.Error("failed to apply proposal", "key", pa.KeyStr, "error", err)
+		if pe.statusline != nil {
+			pe.statusline.SetMessage(rejectionMessage(err), model.MessageLevelError, true)
+		}
+		return
+	}
+	if pe.onTikiUpdated != nil {
+		for _, tk := range b.Updates {
+			pe.onTikiUpdated(tk)
+		}
+	}
+	slog.Info("proposal applied", "key", pa.KeyStr, "created", len(b.Creates), "updated", len(b.Updates))
+	if pe.statusline != nil {
+		pe.statusline.SetMessage(fmt.Sprintf("%s: applied %d of %d changes", pa.Label, b.Len(), len(plan.changes)),
+			model.MessageLevelInfo, true)
+	}
+}
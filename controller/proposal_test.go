@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+// fakeAgent writes a shell script that saves the prompt it reads on stdin
+// next to itself and prints answer, and registers it as ai.agents.fake.
+// Returns the path of the saved prompt.
+func fakeAgent(t *testing.T, answer string, exitCode int) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake agent is a shell script, skipping on Windows")
+	}
+	dir := t.TempDir()
+	promptPath := filepath.Join(dir, "prompt.txt")
+	answerPath := filepath.Join(dir, "answer.txt")
+	if err := os.WriteFile(answerPath, []byte(answer), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\ncat > '" + promptPath + "'\n"
+	if exitCode != 0 {
+		script += "echo 'model overloaded' >&2\nexit " + strconv.Itoa(exitCode) + "\n"
+	}
+	script += "cat '" + answerPath + "'\n"
+	scriptPath := filepath.Join(dir, "fake-agent")
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil { //nolint:gosec // test script must be executable
+		t.Fatal(err)
+	}
+	viper.Set("ai.agents", map[string]interface{}{
+		"fake": map[string]interface{}{"command": scriptPath, "prompt": "stdin"},
+	})
+	t.Cleanup(func() { viper.Set("ai.agents", nil) })
+	return promptPath
+}
+
+func newProposeHarness(t *testing.T) (*PluginExecutor, *NavigationController, store.Store, *model.StatuslineConfig) {
+	t.Helper()
+	tikiStore := store.NewInMemoryStore()
+	seedTiki(t, tikiStore, "0000T1", "Checkout flow", "ready", 0)
+	seedTiki(t, tikiStore, "0000T2", "Unrelated", "ready", 0)
+	gate := service.NewTikiMutationGate()
+	gate.SetStore(tikiStore)
+	statusline := model.NewStatuslineConfig()
+	nav := newMockNavigationController()
+	nav.SetProposalReview(model.NewProposalReviewConfig())
+	executor := NewPluginExecutor(tikiStore, gate, statusline, nil, rukiRuntime.NewSchema(), "Board", nil)
+	return executor, nav, tikiStore, statusline
+}
+
+var splitAction = &plugin.PluginAction{
+	Kind:    plugin.ActionKindPropose,
+	KeyStr:  "S",
+	Label:   "Split",
+	Agent:   "fake",
+	Prompt:  "Split this story into subtasks.",
+	Require: []string{"selection:any"},
+}
+
+const splitAnswer = "Here is the plan:\n```json\n" + `{
+  "summary": "two subtasks",
+  "updates": [
+    {"id": "0000t1", "fields": {"status": "inProgress"}, "body": "Tracked by subtasks.", "dependsOn": ["cart", "pay"]}
+  ],
+  "create": [
+    {"ref": "cart", "title": "Cart page", "fields": {"priority": "high"}},
+    {"ref": "pay", "title": "Payment form", "body": "Card and invoice.", "dependsOn": ["cart"]}
+  ]
+}` + "\n```\n"
+
+func TestPropose_FakeAgentReviewAndApply(t *testing.T) {
+	promptPath := fakeAgent(t, splitAnswer, 0)
+	executor, nav, tikiStore, _ := newProposeHarness(t)
+
+	if !executor.Propose(splitAction, []string{"0000T1"}, nav) {
+		t.Fatal("propose did not start")
+	}
+	prompt, err := os.ReadFile(promptPath)
+	if err != nil {
+		t.Fatalf("agent did not receive a prompt: %v", err)
+	}
+	for _, want := range []string{"Split this story into subtasks.", "## 0000T1: Checkout flow", `"dependsOn"`} {
+		if !strings.Contains(string(prompt), want) {
+			t.Errorf("prompt does not contain %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(string(prompt), "Unrelated") {
+		t.Error("prompt should only carry the selected tikis")
+	}
+
+	review := nav.ProposalReview()
+	items := review.Items()
+	if !review.IsVisible() || len(items) != 3 {
+		t.Fatalf("review visible = %v with %d items, want 3", review.IsVisible(), len(items))
+	}
+	if review.Title() != "Split: two subtasks" || items[0].Summary != "Update 0000T1 Checkout flow" || items[2].Summary != "Create Payment form" {
+		t.Errorf("title %q, items %+v", review.Title(), items)
+	}
+	diff := strings.Join(items[0].Diff, "\n")
+	for _, want := range []string{"- status: ready", "+ status: inProgress", "+ Tracked by subtasks.", "+ dependsOn: Cart page (new)"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("update diff does not contain %q:\n%s", want, diff)
+		}
+	}
+	if tikiStore.GetTiki("0000T1").Body() != "" || len(tikiStore.GetAllTikis()) != 2 {
+		t.Fatal("nothing may be written before the review is applied")
+	}
+
+	review.Apply()
+	story := tikiStore.GetTiki("0000T1")
+	if status, _, _ := story.StringField("status"); status != "inProgress" || story.Body() != "Tracked by subtasks." {
+		t.Errorf("story status %q, body %q", status, story.Body())
+	}
+	deps, _, _ := story.StringSliceField("dependsOn")
+	if len(deps) != 2 {
+		t.Fatalf("story dependsOn = %v, want the two new subtasks", deps)
+	}
+	cart, pay := tikiStore.GetTiki(deps[0]), tikiStore.GetTiki(deps[1])
+	if cart == nil || cart.Title() != "Cart page" || pay == nil || pay.Title() != "Payment form" {
+		t.Fatalf("subtasks = %v, %v", cart, pay)
+	}
+	if payDeps, _, _ := pay.StringSliceField("dependsOn"); len(payDeps) != 1 || payDeps[0] != cart.ID() {
+		t.Errorf("payment dependsOn = %v, want [%s]", payDeps, cart.ID())
+	}
+}
+
+func TestPropose_RejectedCreateDropsItsLinks(t *testing.T) {
+	fakeAgent(t, splitAnswer, 0)
+	executor, nav, tikiStore, _ := newProposeHarness(t)
+	executor.Propose(splitAction, []string{"0000T1"}, nav)
+
+	review := nav.ProposalReview()
+	review.MoveCursor(1) // Create Cart page
+	review.ToggleCurrent()
+	review.Apply()
+
+	if n := len(tikiStore.GetAllTikis()); n != 3 {
+		t.Fatalf("%d tikis, want the story, the unrelated tiki and one subtask", n)
+	}
+	deps, _, _ := tikiStore.GetTiki("0000T1").StringSliceField("dependsOn")
+	if len(deps) != 1 || tikiStore.GetTiki(deps[0]).Title() != "Payment form" {
+		t.Errorf("story dependsOn = %v, want only the payment subtask", deps)
+	}
+}
+
+func TestPropose_ApplyKeepsEditsMadeDuringReview(t *testing.T) {
+	fakeAgent(t, `{"updates": [{"id": "0000T1", "fields": {"status": "done"}}]}`, 0)
+	executor, nav, tikiStore, _ := newProposeHarness(t)
+	executor.Propose(splitAction, []string{"0000T1"}, nav)
+
+	// the user edits the story while the review is open
+	edited := tikiStore.GetTiki("0000T1").Clone()
+	edited.SetBody("Notes added during review.")
+	edited.Set("priority", "high")
+	if err := tikiStore.UpdateTiki(edited); err != nil {
+		t.Fatal(err)
+	}
+
+	nav.ProposalReview().Apply()
+	story := tikiStore.GetTiki("0000T1")
+	if status, _, _ := story.StringField("status"); status != "done" {
+		t.Errorf("status = %q, want the proposed done", status)
+	}
+	if priority, _, _ := story.StringField("priority"); priority != "high" || story.Body() != "Notes added during review." {
+		t.Errorf("edit made during review was lost: priority %q, body %q", priority, story.Body())
+	}
+}
+
+func TestPropose_Failures(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		exitCode int
+		want     string
+	}{
+		{"agent fails", "", 3, "model overloaded"},
+		{"no json", "I cannot help with that.", 0, "no JSON object"},
+		{"no changes", `{"updates": []}`, 0, "no changes"},
+		{"unknown tiki", `{"updates": [{"id": "ZZZZZZ", "fields": {"status": "done"}}]}`, 0, `unknown tiki "ZZZZZZ"`},
+		{"unknown field", `{"updates": [{"id": "0000T1", "fields": {"mood": "happy"}}]}`, 0, `field "mood" cannot be set`},
+		{"duplicate update", `{"updates": [{"id": "0000T1", "fields": {"status": "done"}}, {"id": "0000t1", "body": "x"}]}`, 0, "listed more than once"},
+		{"bad enum", `{"create": [{"title": "x", "fields": {"status": "someday"}}]}`, 0, "someday"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeAgent(t, tt.answer, tt.exitCode)
+			executor, nav, _, statusline := newProposeHarness(t)
+			executor.Propose(splitAction, []string{"0000T1"}, nav)
+
+			if nav.ProposalReview().IsVisible() {
+				t.Fatal("review should not open")
+			}
+			msg, level, _ := statusline.GetMessage()
+			if level != model.MessageLevelError || !strings.Contains(msg, tt.want) {
+				t.Errorf("statusline = %q (level %v), want error containing %q", msg, level, tt.want)
+			}
+		})
+	}
+}
+
+func TestPropose_SelectContext(t *testing.T) {
+	promptPath := fakeAgent(t, `{"updates": [{"id": "0000T2", "fields": {"status": "done"}}]}`, 0)
+	executor, nav, tikiStore, _ := newProposeHarness(t)
+	seedTiki(t, tikiStore, "0000T3", "Inbox item", "inbox", 0)
+
+	triage := *splitAction
+	triage.Require = nil
+	triage.Action = mustParseStmt(t, `select where status = "inbox"`)
+	if !executor.Propose(&triage, nil, nav) {
+		t.Fatal("propose with a select context should not need a selection")
+	}
+	prompt, _ := os.ReadFile(promptPath)
+	if !strings.Contains(string(prompt), "Inbox item") || strings.Contains(string(prompt), "Checkout flow") {
+		t.Errorf("prompt should carry only the selected inbox tiki:\n%s", prompt)
+	}
+
+	triage.Action = mustParseStmt(t, `select where status = "done"`)
+	if executor.Propose(&triage, nil, nav) {
+		t.Error("an empty context should not run the agent")
+	}
+}
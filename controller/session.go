@@ -0,0 +1,344 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+// sessionSelectable is implemented by plugin controllers whose selection can
+// be saved and restored by tiki ID (board and list views).
+type sessionSelectable interface {
+	GetSelectedTikiID() string
+	SelectTikiByID(tikiID string) bool
+}
+
+// documentPathView is implemented by views that can navigate away from the
+// document they were opened with (wiki views following links).
+type documentPathView interface {
+	CurrentDocumentPath() string
+}
+
+// SessionManager captures the UI state that outlives a launch and reapplies
+// it on the next one. It also applies the --view / --open launch flags, so
+// bootstrap has a single place that decides what the first screen shows.
+type SessionManager struct {
+	nav           *NavigationController
+	views         []plugin.Plugin
+	plugins       map[string]PluginControllerInterface
+	pluginConfigs map[string]*model.PluginConfig
+	markdownTree  *model.MarkdownTreeConfig
+	tikiStore     store.ReadStore
+}
+
+// NewSessionManager creates a session manager over the workflow views and
+// their controllers. markdownTree may be nil.
+func NewSessionManager(
+	nav *NavigationController,
+	views []plugin.Plugin,
+	plugins map[string]PluginControllerInterface,
+	pluginConfigs map[string]*model.PluginConfig,
+	markdownTree *model.MarkdownTreeConfig,
+	tikiStore store.ReadStore,
+) *SessionManager {
+	return &SessionManager{
+		nav:           nav,
+		views:         views,
+		plugins:       plugins,
+		pluginConfigs: pluginConfigs,
+		markdownTree:  markdownTree,
+		tikiStore:     tikiStore,
+	}
+}
+
+// Capture snapshots the current session. Unsaved drafts are not part of it:
+// a detail view opened in mode: new has nothing to come back to.
+func (sm *SessionManager) Capture() model.SessionState {
+	var st model.SessionState
+
+	stack := sm.nav.Stack()
+	for i, entry := range stack {
+		if !model.IsPluginViewID(entry.ViewID) {
+			continue
+		}
+		params := model.DecodePluginViewParams(entry.Params)
+		if params.Draft != nil || params.Mode == plugin.DetailModeNew {
+			continue
+		}
+		sv := model.SessionView{
+			Name:         model.GetPluginName(entry.ViewID),
+			TikiID:       params.TikiID,
+			DocumentPath: params.DocumentPath,
+		}
+		if i == len(stack)-1 {
+			if dv, ok := sm.nav.GetActiveView().(documentPathView); ok {
+				if path := dv.CurrentDocumentPath(); path != "" {
+					sv.DocumentPath = path
+				}
+			}
+		}
+		st.Views = append(st.Views, sv)
+	}
+
+	for name, pc := range sm.plugins {
+		cfg := sm.pluginConfigs[name]
+		sel, ok := pc.(sessionSelectable)
+		if cfg == nil || !ok {
+			continue
+		}
+		ps := model.PluginSession{
+			SelectedTikiID: sel.GetSelectedTikiID(),
+			Lane:           cfg.GetSelectedLane(),
+			ScrollOffsets:  trimTrailingZeros(cfg.GetScrollOffsets()),
+			CollapsedBands: cfg.GetCollapsedBands(),
+		}
+		if cfg.IsSearchActive() {
+			ps.Search = cfg.GetSearchQuery()
+		}
+		if ps.SelectedTikiID == "" && ps.Lane == 0 && len(ps.ScrollOffsets) == 0 && ps.Search == "" && ps.CollapsedBands == nil {
+			continue
+		}
+		if st.Plugins == nil {
+			st.Plugins = make(map[string]model.PluginSession)
+		}
+		st.Plugins[name] = ps
+	}
+
+	if sm.markdownTree != nil {
+		if dirs := sm.markdownTree.GetExpandedDirs(); len(dirs) > 0 {
+			st.TreeDirs = dirs
+		}
+	}
+	return st
+}
+
+// Start shows the first screen. Without launch flags the saved stack is
+// restored, falling back to the default view when nothing in it still
+// applies. viewName opens that view instead of the saved stack; tikiID opens
+// the tiki in the detail view, or selects it in viewName when both are set.
+// Per-view selection and the markdown tree state are restored either way.
+func (sm *SessionManager) Start(st model.SessionState, viewName, tikiID string) error {
+	if viewName != "" && !sm.hasView(viewName) {
+		return fmt.Errorf("unknown view %q (available: %s)", viewName, strings.Join(sm.viewNames(), ", "))
+	}
+	if tikiID != "" {
+		tk := sm.tikiStore.GetTiki(tikiID)
+		if tk == nil {
+			return fmt.Errorf("tiki %s not found", tikiID)
+		}
+		tikiID = tk.ID()
+	}
+
+	sm.restoreSelection(st)
+
+	if viewName != "" {
+		if tikiID != "" {
+			if sel, ok := sm.plugins[viewName].(sessionSelectable); ok {
+				sel.SelectTikiByID(tikiID)
+			}
+		}
+		sm.nav.PushView(model.MakePluginViewID(viewName),
+			model.EncodePluginViewParams(model.PluginViewParams{TikiID: tikiID}))
+		return nil
+	}
+
+	if !sm.restoreStack(st) {
+		sm.pushDefault()
+	}
+	if tikiID != "" {
+		sm.openTiki(tikiID)
+	}
+	return nil
+}
+
+// restoreSelection reapplies tree expansion and per-view selection state.
+func (sm *SessionManager) restoreSelection(st model.SessionState) {
+	if sm.markdownTree != nil && len(st.TreeDirs) > 0 {
+		sm.markdownTree.SetExpandedDirs(st.TreeDirs)
+	}
+	for name, ps := range st.Plugins {
+		cfg := sm.pluginConfigs[name]
+		pc, ok := sm.plugins[name]
+		if cfg == nil || !ok {
+			continue
+		}
+		cfg.SetCollapsedBands(ps.CollapsedBands)
+		if ps.Search != "" {
+			pc.HandleSearch(ps.Search)
+		}
+		cfg.SetSelectedLane(ps.Lane)
+		if ps.SelectedTikiID != "" {
+			if sel, ok := pc.(sessionSelectable); ok {
+				sel.SelectTikiByID(ps.SelectedTikiID)
+			}
+		}
+		cfg.SetScrollOffsets(ps.ScrollOffsets)
+	}
+}
+
+// restoreStack pushes the saved stack entries that still apply and reports
+// whether any were pushed.
+func (sm *SessionManager) restoreStack(st model.SessionState) bool {
+	pushed := false
+	for _, sv := range st.Views {
+		params, ok := sm.restorableParams(sv)
+		if !ok {
+			continue
+		}
+		sm.nav.PushView(model.MakePluginViewID(sv.Name), params)
+		pushed = true
+	}
+	return pushed
+}
+
+// restorableParams validates a saved stack entry against the workspace. A
+// detail view whose tiki is gone, or the markdown viewer whose file is gone,
+// is dropped; other views just lose the stale parameter.
+func (sm *SessionManager) restorableParams(sv model.SessionView) (map[string]interface{}, bool) {
+	isFileViewer := sv.Name == MarkdownFileViewerPlugin
+	def := sm.findView(sv.Name)
+	if def == nil && !isFileViewer {
+		return nil, false
+	}
+	params := model.PluginViewParams{TikiID: sv.TikiID, DocumentPath: sv.DocumentPath}
+	if params.TikiID != "" && sm.tikiStore.GetTiki(params.TikiID) == nil {
+		if def != nil && def.GetKind() == plugin.KindDetail {
+			return nil, false
+		}
+		params.TikiID = ""
+	}
+	if params.DocumentPath != "" && !documentExists(params.DocumentPath) {
+		if isFileViewer {
+			return nil, false
+		}
+		params.DocumentPath = ""
+	}
+	if isFileViewer && params.DocumentPath == "" {
+		return nil, false
+	}
+	return model.EncodePluginViewParams(params), true
+}
+
+// openTiki shows a tiki on top of the current stack: in the detail view when
+// the workflow has one, otherwise by selecting it in the active view.
+func (sm *SessionManager) openTiki(tikiID string) {
+	if detail := sm.detailViewName(); detail != "" {
+		sm.nav.PushView(model.MakePluginViewID(detail),
+			model.EncodePluginViewParams(model.PluginViewParams{TikiID: tikiID}))
+		return
+	}
+	name := model.GetPluginName(sm.nav.CurrentViewID())
+	if sel, ok := sm.plugins[name].(sessionSelectable); ok {
+		sel.SelectTikiByID(tikiID)
+	}
+}
+
+func (sm *SessionManager) pushDefault() {
+	if len(sm.views) == 0 {
+		return
+	}
+	sm.nav.PushView(model.MakePluginViewID(plugin.DefaultPlugin(sm.views).GetName()), nil)
+}
+
+// detailViewName prefers the conventional "Detail" view, then the first
+// detail-kind view in workflow order.
+func (sm *SessionManager) detailViewName() string {
+	if def := sm.findView(model.DetailPluginName); def != nil && def.GetKind() == plugin.KindDetail {
+		return def.GetName()
+	}
+	for _, v := range sm.views {
+		if v.GetKind() == plugin.KindDetail {
+			return v.GetName()
+		}
+	}
+	return ""
+}
+
+func (sm *SessionManager) findView(name string) plugin.Plugin {
+	for _, v := range sm.views {
+		if v.GetName() == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func (sm *SessionManager) hasView(name string) bool {
+	return sm.findView(name) != nil
+}
+
+func (sm *SessionManager) viewNames() []string {
+	names := make([]string, 0, len(sm.views))
+	for _, v := range sm.views {
+		names = append(names, v.GetName())
+	}
+	return names
+}
+
+// documentExists resolves a document path the way wiki views do: relative
+// paths are under the document root.
+func documentExists(path string) bool {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(config.GetDocDir(), path)
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func trimTrailingZeros(offsets []int) []int {
+	n := len(offsets)
+	for n > 0 && offsets[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return nil
+	}
+	return offsets[:n]
+}
+
+// LoadSessionState reads the saved session. A missing file yields an empty
+// session; an unreadable one is logged and ignored so a corrupt cache never
+// blocks startup.
+func LoadSessionState(path string) model.SessionState {
+	var st model.SessionState
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("failed to read session state", "path", path, "error", err)
+		}
+		return st
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		slog.Warn("ignoring corrupt session state", "path", path, "error", err)
+		return model.SessionState{}
+	}
+	return st
+}
+
+// SaveSessionState writes the session atomically (temp file + rename).
+func SaveSessionState(path string, st model.SessionState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create session dir: %w", err)
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode session state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write session state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write session state: %w", err)
+	}
+	return nil
+}
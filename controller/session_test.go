@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+type sessionHarness struct {
+	session *SessionManager
+	nav     *NavigationController
+	board   *PluginController
+	config  *model.PluginConfig
+	tree    *model.MarkdownTreeConfig
+}
+
+func newSessionHarness(t *testing.T) *sessionHarness {
+	t.Helper()
+	tikiStore := store.NewInMemoryStore()
+	seedTiki(t, tikiStore, "0000T1", "Tiki 1", "ready", 0)
+	seedTiki(t, tikiStore, "0000T2", "Tiki 2", "ready", 0)
+	seedTiki(t, tikiStore, "0000T3", "Tiki 3", "done", 0)
+
+	board := &plugin.WorkflowPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Board", Kind: plugin.KindBoard},
+		Lanes: []plugin.TikiLane{
+			{Name: "Ready", Columns: 1, Filter: mustParseStmt(t, `select where status = "ready"`)},
+			{Name: "Done", Columns: 1, Filter: mustParseStmt(t, `select where status = "done"`)},
+		},
+	}
+	detail := newTestDetailPlugin(nil, nil)
+
+	cfg := model.NewPluginConfig("Board")
+	cfg.SetLaneLayout([]int{1, 1}, nil)
+	gate := service.NewTikiMutationGate()
+	gate.SetStore(tikiStore)
+	nav := newMockNavigationController()
+	pc := NewPluginController(tikiStore, gate, cfg, board, nav, nil, nil, rukiRuntime.NewSchema())
+	tree := model.NewMarkdownTreeConfig()
+
+	sm := NewSessionManager(nav,
+		[]plugin.Plugin{board, detail},
+		map[string]PluginControllerInterface{"Board": pc},
+		map[string]*model.PluginConfig{"Board": cfg},
+		tree, tikiStore)
+	return &sessionHarness{session: sm, nav: nav, board: pc, config: cfg, tree: tree}
+}
+
+func stackNames(nav *NavigationController) []string {
+	var names []string
+	for _, e := range nav.Stack() {
+		names = append(names, model.GetPluginName(e.ViewID))
+	}
+	return names
+}
+
+func TestSession_StartWithoutStatePushesDefault(t *testing.T) {
+	h := newSessionHarness(t)
+	if err := h.session.Start(model.SessionState{}, "", ""); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if got := stackNames(h.nav); len(got) != 1 || got[0] != "Board" {
+		t.Fatalf("stack = %v, want [Board]", got)
+	}
+}
+
+func TestSession_CaptureRestoreRoundTrip(t *testing.T) {
+	h := newSessionHarness(t)
+	h.nav.PushView(model.MakePluginViewID("Board"), nil)
+	h.nav.PushView(model.MakePluginViewID("Detail"),
+		model.EncodePluginViewParams(model.PluginViewParams{TikiID: "0000T2"}))
+	if !h.board.SelectTikiByID("0000T3") {
+		t.Fatal("SelectTikiByID(0000T3) = false")
+	}
+	h.config.SetScrollOffsets([]int{0, 4})
+	h.config.ToggleBandCollapsed("alice")
+	h.tree.SetExpandedDirs(map[string]bool{"notes": true})
+
+	st := h.session.Capture()
+
+	h2 := newSessionHarness(t)
+	if err := h2.session.Start(st, "", ""); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if got := stackNames(h2.nav); strings.Join(got, ",") != "Board,Detail" {
+		t.Fatalf("stack = %v, want [Board Detail]", got)
+	}
+	if p := model.DecodePluginViewParams(h2.nav.CurrentView().Params); p.TikiID != "0000T2" {
+		t.Errorf("detail tiki = %q, want 0000T2", p.TikiID)
+	}
+	if got := h2.board.GetSelectedTikiID(); got != "0000T3" {
+		t.Errorf("selected = %q, want 0000T3", got)
+	}
+	if got := h2.config.GetSelectedLane(); got != 1 {
+		t.Errorf("lane = %d, want 1", got)
+	}
+	if got := h2.config.GetScrollOffsetForLane(1); got != 4 {
+		t.Errorf("scroll offset = %d, want 4", got)
+	}
+	if !h2.config.IsBandCollapsed("alice") {
+		t.Errorf("collapsed bands = %v, want [alice]", h2.config.GetCollapsedBands())
+	}
+	if !h2.tree.GetExpandedDirs()["notes"] {
+		t.Errorf("tree dirs = %v, want notes expanded", h2.tree.GetExpandedDirs())
+	}
+}
+
+func TestSession_CaptureSkipsDrafts(t *testing.T) {
+	h := newSessionHarness(t)
+	h.nav.PushView(model.MakePluginViewID("Board"), nil)
+	h.nav.PushView(model.MakePluginViewID("Detail"),
+		model.EncodePluginViewParams(model.PluginViewParams{Draft: newTestDraftTiki("NEW001"), Mode: plugin.DetailModeNew}))
+
+	st := h.session.Capture()
+	if len(st.Views) != 1 || st.Views[0].Name != "Board" {
+		t.Fatalf("views = %+v, want only Board", st.Views)
+	}
+}
+
+func TestSession_RestoreDropsStaleEntries(t *testing.T) {
+	h := newSessionHarness(t)
+	st := model.SessionState{
+		Views: []model.SessionView{
+			{Name: "Gone"},
+			{Name: "Detail", TikiID: "ZZZZZZ"},
+		},
+		Plugins: map[string]model.PluginSession{"Board": {SelectedTikiID: "ZZZZZZ"}},
+	}
+	if err := h.session.Start(st, "", ""); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if got := stackNames(h.nav); len(got) != 1 || got[0] != "Board" {
+		t.Fatalf("stack = %v, want fallback to [Board]", got)
+	}
+}
+
+func TestSession_LaunchFlags(t *testing.T) {
+	saved := model.SessionState{Views: []model.SessionView{{Name: "Board"}, {Name: "Detail", TikiID: "0000T1"}}}
+
+	t.Run("view replaces saved stack", func(t *testing.T) {
+		h := newSessionHarness(t)
+		if err := h.session.Start(saved, "Board", "0000t3"); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		if got := stackNames(h.nav); len(got) != 1 || got[0] != "Board" {
+			t.Fatalf("stack = %v, want [Board]", got)
+		}
+		if got := h.board.GetSelectedTikiID(); got != "0000T3" {
+			t.Errorf("selected = %q, want 0000T3", got)
+		}
+	})
+
+	t.Run("open pushes detail on restored stack", func(t *testing.T) {
+		h := newSessionHarness(t)
+		if err := h.session.Start(saved, "", "0000T2"); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		if got := stackNames(h.nav); strings.Join(got, ",") != "Board,Detail,Detail" {
+			t.Fatalf("stack = %v, want [Board Detail Detail]", got)
+		}
+		if p := model.DecodePluginViewParams(h.nav.CurrentView().Params); p.TikiID != "0000T2" {
+			t.Errorf("detail tiki = %q, want 0000T2", p.TikiID)
+		}
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		h := newSessionHarness(t)
+		err := h.session.Start(saved, "Nope", "")
+		if err == nil || !strings.Contains(err.Error(), "available: Board, Detail") {
+			t.Fatalf("err = %v, want unknown view listing available views", err)
+		}
+	})
+
+	t.Run("unknown tiki", func(t *testing.T) {
+		h := newSessionHarness(t)
+		if err := h.session.Start(saved, "", "ZZZZZZ"); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("err = %v, want not found", err)
+		}
+	})
+}
+
+func TestSessionState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "session.json")
+	if st := LoadSessionState(path); !st.IsEmpty() {
+		t.Fatalf("missing file: got %+v, want empty", st)
+	}
+
+	want := model.SessionState{
+		Views:   []model.SessionView{{Name: "Board"}},
+		Plugins: map[string]model.PluginSession{"Board": {SelectedTikiID: "0000T1", Search: "bug"}},
+	}
+	if err := SaveSessionState(path, want); err != nil {
+		t.Fatalf("SaveSessionState: %v", err)
+	}
+	got := LoadSessionState(path)
+	if len(got.Views) != 1 || got.Plugins["Board"].Search != "bug" {
+		t.Fatalf("loaded %+v, want %+v", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if st := LoadSessionState(path); !st.IsEmpty() {
+		t.Fatalf("corrupt file: got %+v, want empty", st)
+	}
+}
@@ -0,0 +1,392 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// Band names for tikis no swimlane claims: groupBy boards put tikis without
+// a value in (none); explicit band lists put tikis no filter matches in
+// (other).
+const (
+	NoneBandName  = "(none)"
+	OtherBandName = "(other)"
+)
+
+// SwimlaneBand is one horizontal band of a swimlane board. Lanes holds the
+// band's tikis per lane, in lane order, even when the band is collapsed so
+// its header can still show counts.
+type SwimlaneBand struct {
+	Name      string
+	Collapsed bool
+	Lanes     [][]*tikipkg.Tiki
+}
+
+// Count returns the number of tikis in the band across all lanes.
+func (b SwimlaneBand) Count() int {
+	n := 0
+	for _, tikis := range b.Lanes {
+		n += len(tikis)
+	}
+	return n
+}
+
+// bandKey places a tiki in a band. groupBy boards key on the field value
+// (nil for the none band); explicit bands key on their position, with
+// len(Bands) for the other band.
+type bandKey struct {
+	name  string
+	value interface{}
+	index int
+}
+
+// HasSwimlanes reports whether the board splits its lanes into bands.
+func (pc *PluginController) HasSwimlanes() bool {
+	return pc.pluginDef != nil && pc.pluginDef.Swimlanes != nil
+}
+
+// GetSwimlaneBands returns the board's bands in display order, or nil when
+// the board has no swimlanes. Explicit bands and enum values are always
+// listed so a tiki can be moved into an empty band; the none/other band
+// only appears while it holds tikis.
+func (pc *PluginController) GetSwimlaneBands() []SwimlaneBand {
+	if !pc.HasSwimlanes() {
+		return nil
+	}
+	laneCount := len(pc.pluginDef.Lanes)
+
+	keys := pc.seedBandKeys()
+	perLane := make([][]bandKey, laneCount)
+	laneTikis := make([][]*tikipkg.Tiki, laneCount)
+	for lane := range laneCount {
+		laneTikis[lane] = pc.laneTikis(lane)
+		perLane[lane] = pc.bandKeysFor(laneTikis[lane])
+		for _, k := range perLane[lane] {
+			if !slices.ContainsFunc(keys, func(o bandKey) bool { return o.name == k.name }) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	slices.SortStableFunc(keys, pc.compareBandKeys)
+
+	bands := make([]SwimlaneBand, len(keys))
+	index := make(map[string]int, len(keys))
+	for i, k := range keys {
+		index[k.name] = i
+		bands[i] = SwimlaneBand{
+			Name:      k.name,
+			Collapsed: pc.pluginConfig.IsBandCollapsed(k.name),
+			Lanes:     make([][]*tikipkg.Tiki, laneCount),
+		}
+	}
+	for lane := range laneCount {
+		for i, tk := range laneTikis[lane] {
+			b := index[perLane[lane][i].name]
+			bands[b].Lanes[lane] = append(bands[b].Lanes[lane], tk)
+		}
+	}
+	return bands
+}
+
+// swimlaneTikis orders a lane's tikis band by band and drops the tikis of
+// collapsed bands, so the (lane, index) selection walks the board top to
+// bottom and never lands on a hidden card.
+func (pc *PluginController) swimlaneTikis(tikis []*tikipkg.Tiki) []*tikipkg.Tiki {
+	keys := pc.bandKeysFor(tikis)
+	order := make([]int, 0, len(tikis))
+	for i, k := range keys {
+		if !pc.pluginConfig.IsBandCollapsed(k.name) {
+			order = append(order, i)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return pc.compareBandKeys(keys[a], keys[b]) })
+	out := make([]*tikipkg.Tiki, len(order))
+	for i, idx := range order {
+		out[i] = tikis[idx]
+	}
+	return out
+}
+
+// seedBandKeys returns the bands listed even when empty.
+func (pc *PluginController) seedBandKeys() []bandKey {
+	sw := pc.pluginDef.Swimlanes
+	var keys []bandKey
+	if sw.GroupBy == "" {
+		for i, band := range sw.Bands {
+			keys = append(keys, bandKey{name: band.Name, index: i})
+		}
+		return keys
+	}
+	for _, v := range sw.Enum {
+		keys = append(keys, bandKey{name: v, value: v})
+	}
+	return keys
+}
+
+// bandKeysFor returns the band of each tiki, in the order given.
+func (pc *PluginController) bandKeysFor(tikis []*tikipkg.Tiki) []bandKey {
+	sw := pc.pluginDef.Swimlanes
+	keys := make([]bandKey, len(tikis))
+	if sw.GroupBy != "" {
+		for i, tk := range tikis {
+			keys[i] = pc.groupByKey(tk)
+		}
+		return keys
+	}
+
+	for i := range keys {
+		keys[i] = bandKey{name: OtherBandName, index: len(sw.Bands)}
+	}
+	if len(tikis) == 0 {
+		return keys
+	}
+	executor := pc.newExecutor()
+	docs := tikipkg.WrapDocs(tikis)
+	for b := len(sw.Bands) - 1; b >= 0; b-- {
+		result, err := executor.Execute(sw.Bands[b].Filter, docs)
+		if err != nil {
+			slog.Error("failed to execute swimlane filter", "swimlane", sw.Bands[b].Name, "error", err)
+			continue
+		}
+		matched := make(map[string]bool, len(result.Select.Tikis))
+		for _, tk := range tikipkg.UnwrapDocs(result.Select.Tikis) {
+			matched[tk.ID()] = true
+		}
+		// walking the bands backwards leaves each tiki in the first match
+		for i, tk := range tikis {
+			if matched[tk.ID()] {
+				keys[i] = bandKey{name: sw.Bands[b].Name, index: b}
+			}
+		}
+	}
+	return keys
+}
+
+func (pc *PluginController) groupByKey(tk *tikipkg.Tiki) bandKey {
+	sw := pc.pluginDef.Swimlanes
+	none := bandKey{name: NoneBandName}
+	if sw.GroupByType == ruki.ValueInt {
+		n, present, ok := tk.IntField(sw.GroupBy)
+		if !present || !ok {
+			return none
+		}
+		return bandKey{name: strconv.Itoa(n), value: n}
+	}
+	s, present, ok := tk.StringField(sw.GroupBy)
+	if !present || !ok || s == "" {
+		return none
+	}
+	return bandKey{name: s, value: s}
+}
+
+// compareBandKeys orders explicit bands as declared and groupBy bands by
+// enum order, number or case-insensitive name, with the none band last.
+func (pc *PluginController) compareBandKeys(a, b bandKey) int {
+	sw := pc.pluginDef.Swimlanes
+	if sw.GroupBy == "" {
+		return a.index - b.index
+	}
+	if (a.value == nil) != (b.value == nil) {
+		if a.value == nil {
+			return 1
+		}
+		return -1
+	}
+	if a.value == nil {
+		return 0
+	}
+	if sw.GroupByType == ruki.ValueInt {
+		return a.value.(int) - b.value.(int)
+	}
+	if len(sw.Enum) > 0 {
+		ra, rb := enumRank(sw.Enum, a.name), enumRank(sw.Enum, b.name)
+		if ra != rb {
+			return ra - rb
+		}
+	}
+	if c := strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name)); c != 0 {
+		return c
+	}
+	return strings.Compare(a.name, b.name)
+}
+
+// enumRank places unknown values after every allowed one.
+func enumRank(values []string, v string) int {
+	if i := slices.Index(values, v); i >= 0 {
+		return i
+	}
+	return len(values)
+}
+
+// selectedBand returns the bands and the index of the one holding the
+// selected tiki, or -1 when nothing is selected.
+func (pc *PluginController) selectedBand() ([]SwimlaneBand, int, string) {
+	tikiID := pc.GetSelectedTikiID()
+	if tikiID == "" || !pc.HasSwimlanes() {
+		return nil, -1, ""
+	}
+	lane := pc.pluginConfig.GetSelectedLane()
+	bands := pc.GetSwimlaneBands()
+	for i, band := range bands {
+		for _, tk := range band.Lanes[lane] {
+			if tk.ID() == tikiID {
+				return bands, i, tikiID
+			}
+		}
+	}
+	return bands, -1, tikiID
+}
+
+// handleMoveBand moves the selected tiki to the nearest expanded band above
+// (offset -1) or below (offset 1).
+func (pc *PluginController) handleMoveBand(offset int) bool {
+	target := pc.bandTarget(offset)
+	if target < 0 {
+		return false
+	}
+	return pc.MoveSelectedTikiToBand(target)
+}
+
+// bandTarget returns the index of the nearest expanded band above (offset
+// -1) or below (offset 1) the selected tiki's band, or -1 when there is none.
+func (pc *PluginController) bandTarget(offset int) int {
+	bands, current, _ := pc.selectedBand()
+	if current < 0 {
+		return -1
+	}
+	for target := current + offset; target >= 0 && target < len(bands); target += offset {
+		if !bands[target].Collapsed {
+			return target
+		}
+	}
+	return -1
+}
+
+// bandMoveRequire returns the requirements a tiki must meet to be moved into
+// the band at index: those inferred from an explicit band's action, or the
+// set of the groupBy field to the band's value.
+func (pc *PluginController) bandMoveRequire(index int) []string {
+	sw := pc.pluginDef.Swimlanes
+	if sw.GroupBy == "" {
+		if index >= len(sw.Bands) {
+			return nil
+		}
+		return sw.Bands[index].MoveRequire
+	}
+	bands := pc.GetSwimlaneBands()
+	if key := pc.bandKeyByName(bands[index].Name); key.value != nil {
+		return plugin.DeniedSetRequirements(sw.GroupBy, fmt.Sprint(key.value))
+	}
+	return plugin.DeniedSetRequirements(sw.GroupBy)
+}
+
+// MoveSelectedTikiToBand moves the selected tiki into the band at index
+// (as returned by GetSwimlaneBands), staying in its lane. Explicit bands run
+// their `action:`; groupBy boards set the field to the band's value, or
+// clear it for the none band. Keyboard moves and mouse drags both land here.
+func (pc *PluginController) MoveSelectedTikiToBand(index int) bool {
+	bands, current, tikiID := pc.selectedBand()
+	if current < 0 || index == current || index < 0 || index >= len(bands) {
+		return false
+	}
+	sw := pc.pluginDef.Swimlanes
+
+	var moved *tikipkg.Tiki
+	if sw.GroupBy == "" {
+		if index >= len(sw.Bands) || sw.Bands[index].Action == nil {
+			return false
+		}
+		var ok bool
+		if moved, ok = pc.runMoveAction(sw.Bands[index].Action, tikiID); !ok {
+			return false
+		}
+	} else {
+		tk := pc.tikiStore.GetTiki(tikiID)
+		if tk == nil {
+			return false
+		}
+		moved = tk.Clone()
+		if key := pc.bandKeyByName(bands[index].Name); key.value == nil {
+			moved.Delete(sw.GroupBy)
+		} else {
+			moved.Set(sw.GroupBy, key.value)
+		}
+	}
+
+	if !pc.commitMove(moved) {
+		return false
+	}
+	pc.selectTikiInLane(pc.pluginConfig.GetSelectedLane(), tikiID, pc.GetFilteredTikisForLane)
+	return true
+}
+
+// bandKeyByName recovers a groupBy band's value from its header label.
+func (pc *PluginController) bandKeyByName(name string) bandKey {
+	sw := pc.pluginDef.Swimlanes
+	if name == NoneBandName {
+		return bandKey{name: name}
+	}
+	if sw.GroupByType == ruki.ValueInt {
+		if n, err := strconv.Atoi(name); err == nil {
+			return bandKey{name: name, value: n}
+		}
+	}
+	return bandKey{name: name, value: name}
+}
+
+// ToggleSelectedBand folds or unfolds the band holding the selected tiki.
+func (pc *PluginController) ToggleSelectedBand() bool {
+	bands, current, _ := pc.selectedBand()
+	if current < 0 {
+		return false
+	}
+	pc.ToggleBand(bands[current].Name)
+	return true
+}
+
+// ToggleBand folds or unfolds a band by name, keeping a visible tiki
+// selected.
+func (pc *PluginController) ToggleBand(name string) {
+	pc.pluginConfig.ToggleBandCollapsed(name)
+	if len(pc.GetFilteredTikisForLane(pc.pluginConfig.GetSelectedLane())) == 0 {
+		pc.selectFirstNonEmptyLane(pc.GetFilteredTikisForLane)
+	}
+}
+
+// runMoveAction executes a lane or swimlane `action:` against tikiID and
+// returns the updated tiki.
+func (pc *PluginController) runMoveAction(stmt *ruki.ValidatedStatement, tikiID string) (*tikipkg.Tiki, bool) {
+	allTikis := pc.tikiStore.GetAllTikis()
+	result, err := pc.newExecutor().Execute(stmt, tikipkg.WrapDocs(allTikis), ruki.NewSingleSelectionInput(tikiID))
+	if err != nil {
+		slog.Error("failed to execute move action", "tiki_id", tikiID, "error", err)
+		return nil, false
+	}
+	if result.Update == nil || len(result.Update.Updated) == 0 {
+		return nil, false
+	}
+	return tikipkg.UnwrapDoc(result.Update.Updated[0]), true
+}
+
+// commitMove saves a moved tiki through the mutation gate and keeps it in
+// the active search results.
+func (pc *PluginController) commitMove(moved *tikipkg.Tiki) bool {
+	if err := pc.mutationGate.UpdateTiki(context.Background(), moved); err != nil {
+		slog.Error("failed to update tiki after move", "tiki_id", moved.ID(), "error", err)
+		if pc.statusline != nil {
+			pc.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
+		}
+		return false
+	}
+	pc.ensureSearchResultIncludesTiki(moved)
+	return true
+}
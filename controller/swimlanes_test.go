@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/ruki"
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+type swimlaneHarness struct {
+	store  store.Store
+	config *model.PluginConfig
+	pc     *PluginController
+}
+
+// newSwimlaneHarness builds a Ready/Done board over four tikis assigned to
+// alice, bob or nobody.
+func newSwimlaneHarness(t *testing.T, sw *plugin.Swimlanes) *swimlaneHarness {
+	t.Helper()
+	tikiStore := store.NewInMemoryStore()
+	seedTiki(t, tikiStore, "0000T1", "Alpha", "ready", 0)
+	seedTiki(t, tikiStore, "0000T2", "Bravo", "ready", 0)
+	seedTiki(t, tikiStore, "0000T3", "Charlie", "done", 0)
+	seedTiki(t, tikiStore, "0000T4", "Delta", "ready", 0)
+	assign(t, tikiStore, "0000T1", "bob")
+	assign(t, tikiStore, "0000T2", "alice")
+	assign(t, tikiStore, "0000T3", "bob")
+
+	board := &plugin.WorkflowPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Standup", Kind: plugin.KindBoard},
+		Lanes: []plugin.TikiLane{
+			{Name: "Ready", Columns: 1, Filter: mustParseStmt(t, `select where status = "ready"`), Action: mustParseStmt(t, `update where id = id() set status = "ready"`)},
+			{Name: "Done", Columns: 1, Filter: mustParseStmt(t, `select where status = "done"`), Action: mustParseStmt(t, `update where id = id() set status = "done"`)},
+		},
+		Swimlanes: sw,
+	}
+	cfg := model.NewPluginConfig("Standup")
+	cfg.SetLaneLayout([]int{1, 1}, nil)
+	gate := service.NewTikiMutationGate()
+	gate.SetStore(tikiStore)
+	pc := NewPluginController(tikiStore, gate, cfg, board, newMockNavigationController(), nil, nil, rukiRuntime.NewSchema())
+	return &swimlaneHarness{store: tikiStore, config: cfg, pc: pc}
+}
+
+func assign(t *testing.T, s store.Store, id, who string) {
+	t.Helper()
+	tk := s.GetTiki(id).Clone()
+	tk.Set("assignee", who)
+	if err := s.UpdateTiki(tk); err != nil {
+		t.Fatalf("assign %s: %v", id, err)
+	}
+}
+
+func bandSummary(bands []SwimlaneBand) string {
+	var parts []string
+	for _, b := range bands {
+		var lanes []string
+		for _, tikis := range b.Lanes {
+			var ids []string
+			for _, tk := range tikis {
+				ids = append(ids, tk.ID())
+			}
+			lanes = append(lanes, strings.Join(ids, ","))
+		}
+		parts = append(parts, b.Name+"="+strings.Join(lanes, "|"))
+	}
+	return strings.Join(parts, " ")
+}
+
+func laneIDs(tikis []*tikipkg.Tiki) string {
+	ids := make([]string, len(tikis))
+	for i, tk := range tikis {
+		ids[i] = tk.ID()
+	}
+	return strings.Join(ids, ",")
+}
+
+func groupByAssignee() *plugin.Swimlanes {
+	return &plugin.Swimlanes{GroupBy: "assignee", GroupByType: ruki.ValueString}
+}
+
+func TestSwimlanes_GroupByBands(t *testing.T) {
+	h := newSwimlaneHarness(t, groupByAssignee())
+
+	want := "alice=0000T2| bob=0000T1|0000T3 (none)=0000T4|"
+	if got := bandSummary(h.pc.GetSwimlaneBands()); got != want {
+		t.Fatalf("bands = %q, want %q", got, want)
+	}
+	if got := laneIDs(h.pc.GetFilteredTikisForLane(0)); got != "0000T2,0000T1,0000T4" {
+		t.Errorf("ready lane = %s, want band order", got)
+	}
+	if !h.pc.GetActionRegistry().ContainsID(ActionMoveTikiUp) {
+		t.Error("swimlane board should register move_tiki_up")
+	}
+}
+
+func TestSwimlanes_ExplicitBands(t *testing.T) {
+	h := newSwimlaneHarness(t, &plugin.Swimlanes{Bands: []plugin.Swimlane{
+		{Name: "Bob", Filter: mustParseStmt(t, `select where assignee = "bob"`), Action: mustParseStmt(t, `update where id = id() set assignee = "bob"`)},
+		{Name: "Team", Filter: mustParseStmt(t, `select where assignee != "nobody"`)},
+	}})
+
+	// Bob's cards match both bands and land in the first
+	want := "Bob=0000T1|0000T3 Team=0000T2,0000T4|"
+	if got := bandSummary(h.pc.GetSwimlaneBands()); got != want {
+		t.Fatalf("bands = %q, want %q", got, want)
+	}
+
+	// Team has no action, so nothing can move into it
+	h.config.SetSelectedLaneAndIndex(0, 0)
+	if h.pc.HandleAction(ActionMoveTikiDown) {
+		t.Error("move into a band without an action should fail")
+	}
+
+	h.config.SetSelectedLaneAndIndex(0, 1)
+	if h.pc.GetSelectedTikiID() != "0000T2" || !h.pc.HandleAction(ActionMoveTikiUp) {
+		t.Fatal("move 0000T2 up into Bob failed")
+	}
+	if got, _, _ := h.store.GetTiki("0000T2").StringField("assignee"); got != "bob" {
+		t.Errorf("assignee = %q, want bob", got)
+	}
+	if h.pc.GetSelectedTikiID() != "0000T2" {
+		t.Errorf("selection = %s, want it to follow 0000T2", h.pc.GetSelectedTikiID())
+	}
+}
+
+func TestSwimlanes_MoveVerticallySetsGroupField(t *testing.T) {
+	h := newSwimlaneHarness(t, groupByAssignee())
+
+	// 0000T1 (bob) up into alice
+	h.config.SetSelectedLaneAndIndex(0, 1)
+	if !h.pc.HandleAction(ActionMoveTikiUp) {
+		t.Fatal("move up failed")
+	}
+	if got, _, _ := h.store.GetTiki("0000T1").StringField("assignee"); got != "alice" {
+		t.Errorf("assignee = %q, want alice", got)
+	}
+	if h.pc.GetSelectedTikiID() != "0000T1" {
+		t.Errorf("selection = %s, want 0000T1", h.pc.GetSelectedTikiID())
+	}
+
+	// 0000T2 straight into (none)
+	if !h.pc.SelectTikiByID("0000T2") || !h.pc.MoveSelectedTikiToBand(2) {
+		t.Fatal("move to (none) failed")
+	}
+	if h.store.GetTiki("0000T2").Has("assignee") {
+		t.Error("moving into (none) should clear the assignee")
+	}
+
+	// horizontal moves still run the lane action
+	if !h.pc.HandleAction(ActionMoveTikiRight) {
+		t.Fatal("move right failed")
+	}
+	if got, _, _ := h.store.GetTiki("0000T2").StringField("status"); got != "done" {
+		t.Errorf("status = %q, want done", got)
+	}
+}
+
+func TestSwimlanes_BlockedMoves(t *testing.T) {
+	h := newSwimlaneHarness(t, groupByAssignee())
+	h.pc.pluginDef.Lanes[1].MoveRequire = plugin.DeniedSetRequirements("status", "done")
+	SetDeniedPredicate(func(id string) []string {
+		if id == "0000T1" {
+			return []string{"set:status=done", "set:assignee=alice"}
+		}
+		return nil
+	})
+	defer SetDeniedPredicate(nil)
+
+	// 0000T1 (bob) may move into (none) below, but neither up into alice
+	// nor right into Done
+	h.config.SetSelectedLaneAndIndex(0, 1)
+	blocked := h.pc.BlockedMoves()
+	if !slices.Equal(blocked, []ActionID{ActionMoveTikiRight, ActionMoveTikiUp}) {
+		t.Errorf("blocked = %v, want right and up", blocked)
+	}
+
+	h.config.SetSelectedLaneAndIndex(0, 0) // 0000T2
+	if blocked := h.pc.BlockedMoves(); len(blocked) != 0 {
+		t.Errorf("blocked = %v, want none", blocked)
+	}
+}
+
+func TestSwimlanes_Collapse(t *testing.T) {
+	h := newSwimlaneHarness(t, groupByAssignee())
+
+	h.config.SetSelectedLaneAndIndex(0, 0) // 0000T2, alice
+	if !h.pc.HandleAction(ActionToggleSwimlane) {
+		t.Fatal("fold failed")
+	}
+	if !h.config.IsBandCollapsed("alice") {
+		t.Fatal("alice should be folded")
+	}
+	if got := laneIDs(h.pc.GetFilteredTikisForLane(0)); got != "0000T1,0000T4" {
+		t.Errorf("ready lane = %s, want alice's cards hidden", got)
+	}
+	bands := h.pc.GetSwimlaneBands()
+	if !bands[0].Collapsed || bands[0].Count() != 1 {
+		t.Errorf("alice band = %+v, want collapsed with count 1", bands[0])
+	}
+
+	// moving up skips the folded band
+	h.config.SetSelectedLaneAndIndex(0, 0) // 0000T1, bob
+	if h.pc.HandleAction(ActionMoveTikiUp) {
+		t.Error("move up should have no expanded band to land in")
+	}
+
+	if !h.pc.HandleAction(ActionExpandSwimlanes) || h.config.IsBandCollapsed("alice") {
+		t.Fatal("unfold all failed")
+	}
+	if h.pc.HandleAction(ActionExpandSwimlanes) {
+		t.Error("unfold all with nothing folded should report no change")
+	}
+}
+
+func TestSwimlanes_EnumBandsListEveryValue(t *testing.T) {
+	h := newSwimlaneHarness(t, &plugin.Swimlanes{
+		GroupBy:     "type",
+		GroupByType: ruki.ValueEnum,
+		Enum:        []string{"story", "bug", "spike", "project"},
+	})
+	bands := h.pc.GetSwimlaneBands()
+	if len(bands) != 4 || bands[0].Name != "story" || bands[0].Count() != 4 || bands[1].Count() != 0 {
+		t.Fatalf("bands = %s", bandSummary(bands))
+	}
+
+	h.config.SetSelectedLaneAndIndex(1, 0) // 0000T3
+	if !h.pc.HandleAction(ActionMoveTikiDown) {
+		t.Fatal("move into the empty bug band failed")
+	}
+	if got, _, _ := h.store.GetTiki("0000T3").StringField("type"); got != "bug" {
+		t.Errorf("type = %q, want bug", got)
+	}
+}
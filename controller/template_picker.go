@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"log/slog"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// blankTemplateChoice is the picker row id for "no template": the draft is
+// built from the field-catalog defaults alone. Template names cannot start
+// with '-', so it never collides with a declared template.
+const blankTemplateChoice = "-"
+
+// offersTemplatePicker reports whether dispatching pa should first ask which
+// workflow template to start from. Only a bare `mode: new` action qualifies:
+// a create seed already decides its own template via `create from`, and a
+// `choose:` action owns the QuickSelect for its tiki candidates.
+func offersTemplatePicker(pa *plugin.PluginAction) bool {
+	if pa == nil || pa.Kind != plugin.ActionKindView || pa.Mode != plugin.DetailModeNew {
+		return false
+	}
+	if pa.CreateSeed != nil || pa.HasChoose {
+		return false
+	}
+	return len(config.WorkflowTemplates()) > 0
+}
+
+// templateChoices renders the loaded workflow templates as QuickSelect rows:
+// the row id is the template name and the title its label. A leading blank
+// row keeps plain creation one keystroke away.
+func templateChoices() []*tikipkg.Tiki {
+	templates := config.WorkflowTemplates()
+	choices := make([]*tikipkg.Tiki, 0, len(templates)+1)
+	blank := tikipkg.New()
+	blank.SetID(blankTemplateChoice)
+	blank.SetTitle("blank")
+	choices = append(choices, blank)
+	for _, t := range templates {
+		row := tikipkg.New()
+		row.SetID(t.Name)
+		row.SetTitle(t.Label())
+		choices = append(choices, row)
+	}
+	return choices
+}
+
+// handleViewActionWithTemplate is handleViewAction's template-picker sibling:
+// the draft for the `mode: new` target is built from the chosen workflow
+// template instead of the catalog defaults alone.
+func (pc *PluginController) handleViewActionWithTemplate(pa *plugin.PluginAction, choice string) bool {
+	if pa.TargetView == "" || choice == "" {
+		return false
+	}
+	name := choice
+	if name == blankTemplateChoice {
+		name = ""
+	}
+	draft, err := service.NewTikiFromTemplate(pc.tikiStore, name)
+	if err != nil {
+		slog.Error("mode: new failed to create draft from template", "template", name, "error", err)
+		if pc.statusline != nil {
+			pc.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
+		}
+		return false
+	}
+	pvp := model.PluginViewParams{
+		Mode:  pa.Mode,
+		Focus: model.EditFieldTitle,
+		Draft: draft,
+	}
+	pc.navController.PushView(model.MakePluginViewID(pa.TargetView), model.EncodePluginViewParams(pvp))
+	return true
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+func newTemplatePickerController(t *testing.T, action plugin.PluginAction) (*PluginController, *NavigationController, store.Store) {
+	t.Helper()
+	tikiStore := store.NewInMemoryStore()
+	pluginDef := &plugin.WorkflowPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Bugs"},
+		Lanes:      []plugin.TikiLane{{Name: "All", Columns: 1, Filter: mustParseStmt(t, `select`)}},
+		Actions:    []plugin.PluginAction{action},
+	}
+	pluginConfig := model.NewPluginConfig("Bugs")
+	pluginConfig.SetLaneLayout([]int{1}, nil)
+	gate := service.NewTikiMutationGate()
+	gate.SetStore(tikiStore)
+	nav := newMockNavigationController()
+	pc := NewPluginController(tikiStore, gate, pluginConfig, pluginDef, nav, nil, nil, rukiRuntime.NewSchema())
+	return pc, nav, tikiStore
+}
+
+func bareNewAction() plugin.PluginAction {
+	return plugin.PluginAction{
+		Rune: 'n', KeyStr: "n", Label: "New",
+		Kind: plugin.ActionKindView, Mode: plugin.DetailModeNew, TargetView: "Detail",
+	}
+}
+
+func TestTemplatePicker_OfferedOnlyWithTemplates(t *testing.T) {
+	pc, _, _ := newTemplatePickerController(t, bareNewAction())
+
+	if _, ok := pc.GetActionChooseSpec(pluginActionID("n")); ok {
+		t.Fatal("no templates loaded: New must not open a picker")
+	}
+
+	config.ResetWorkflowTemplatesForTest([]config.TikiTemplate{{Name: "bug", Description: "Bug report"}})
+	t.Cleanup(func() { config.ResetWorkflowTemplatesForTest(nil) })
+
+	if _, ok := pc.GetActionChooseSpec(pluginActionID("n")); !ok {
+		t.Fatal("templates loaded: New should open the template picker")
+	}
+	_, choices, ok := pc.CanStartActionChoose(pluginActionID("n"))
+	if !ok {
+		t.Fatal("expected picker preflight to pass")
+	}
+	if len(choices) != 2 || choices[0].ID() != blankTemplateChoice || choices[1].ID() != "bug" || choices[1].Title() != "Bug report" {
+		t.Fatalf("unexpected picker rows: %v", choices)
+	}
+}
+
+func TestTemplatePicker_SeededActionSkipsPicker(t *testing.T) {
+	config.ResetWorkflowTemplatesForTest([]config.TikiTemplate{{Name: "bug"}})
+	t.Cleanup(func() { config.ResetWorkflowTemplatesForTest(nil) })
+
+	action := bareNewAction()
+	action.CreateSeed = mustParseStmt(t, `create type="bug"`)
+	pc, _, _ := newTemplatePickerController(t, action)
+
+	if _, ok := pc.GetActionChooseSpec(pluginActionID("n")); ok {
+		t.Fatal("a create-seeded New action must not open the template picker")
+	}
+}
+
+func TestTemplatePicker_ChoiceSeedsDraft(t *testing.T) {
+	config.ResetWorkflowTemplatesForTest([]config.TikiTemplate{{
+		Name:   "bug",
+		Title:  "Bug: {title}",
+		Folder: "bugs",
+		Fields: map[string]interface{}{"type": "bug"},
+		Body:   "## Steps to reproduce",
+	}})
+	t.Cleanup(func() { config.ResetWorkflowTemplatesForTest(nil) })
+
+	pc, nav, tikiStore := newTemplatePickerController(t, bareNewAction())
+	if !pc.HandleActionChoose(pluginActionID("n"), "bug") {
+		t.Fatal("expected template choice to open the new-tiki form")
+	}
+	if n := len(tikiStore.GetAllTikis()); n != 0 {
+		t.Fatalf("picking a template must not persist, store has %d tikis", n)
+	}
+	entry := nav.CurrentView()
+	if entry == nil {
+		t.Fatal("expected a pushed view on the nav stack")
+	}
+	pvp := model.DecodePluginViewParams(entry.Params)
+	if pvp.Draft == nil {
+		t.Fatalf("expected pushed draft, got params %+v", entry.Params)
+	}
+	if got, _, _ := pvp.Draft.StringField("type"); got != "bug" {
+		t.Errorf("draft type = %q, want bug", got)
+	}
+	if pvp.Draft.Body() != "## Steps to reproduce" || pvp.Draft.Folder() != "bugs" || pvp.Draft.Title() != "Bug: " {
+		t.Errorf("template not applied to draft: title=%q body=%q folder=%q", pvp.Draft.Title(), pvp.Draft.Body(), pvp.Draft.Folder())
+	}
+	if pvp.Mode != plugin.DetailModeNew || pvp.Focus != model.EditFieldTitle {
+		t.Errorf("unexpected params: mode=%q focus=%q", pvp.Mode, pvp.Focus)
+	}
+}
+
+func TestTemplatePicker_BlankChoiceUsesDefaults(t *testing.T) {
+	config.ResetWorkflowTemplatesForTest([]config.TikiTemplate{{Name: "bug", Body: "## Steps"}})
+	t.Cleanup(func() { config.ResetWorkflowTemplatesForTest(nil) })
+
+	pc, nav, _ := newTemplatePickerController(t, bareNewAction())
+	if !pc.HandleActionChoose(pluginActionID("n"), blankTemplateChoice) {
+		t.Fatal("expected blank choice to open the new-tiki form")
+	}
+	pvp := model.DecodePluginViewParams(nav.CurrentView().Params)
+	if pvp.Draft == nil || pvp.Draft.Body() != "" {
+		t.Fatalf("blank choice should produce a default draft, got %+v", pvp.Draft)
+	}
+}
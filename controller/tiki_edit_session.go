@@ -9,8 +9,10 @@ import (
 	"strings"
 
 	collectionutil "github.com/boolean-maybe/ruki/collections"
+	"github.com/boolean-maybe/ruki/idfmt"
 	"github.com/boolean-maybe/ruki/recurrence"
 	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/document"
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/service"
 	"github.com/boolean-maybe/tiki/store"
@@ -314,6 +316,14 @@ func (tc *TikiEditSession) SaveWorkflowField(name, raw string) bool {
 		return tc.saveWorkflowRecurrence(name, raw)
 	case workflow.TypeListString:
 		return tc.saveWorkflowStringList(name, raw)
+	case workflow.TypeEnumList:
+		return tc.saveWorkflowEnumList(wfd, raw)
+	case workflow.TypeRef:
+		return tc.saveWorkflowRef(name, raw)
+	case workflow.TypeURL:
+		return tc.saveWorkflowURL(name, raw)
+	case workflow.TypeNumber:
+		return tc.saveWorkflowNumber(name, raw)
 	case workflow.TypeString, workflow.TypeUser:
 		return tc.setOrDelete(name, raw, raw == "")
 	}
@@ -380,6 +390,64 @@ func (tc *TikiEditSession) saveWorkflowStringList(name, raw string) bool {
 	return tc.setOrDelete(name, values, len(values) == 0)
 }
 
+// saveWorkflowEnumList accepts space-separated value keys, matched
+// case-insensitively to their declared spelling. An unknown key rejects the
+// whole edit.
+func (tc *TikiEditSession) saveWorkflowEnumList(wfd workflow.FieldDef, raw string) bool {
+	var keys []string
+	for _, word := range strings.Fields(raw) {
+		key := ""
+		for _, allowed := range wfd.AllowedValues() {
+			if strings.EqualFold(word, allowed) {
+				key = allowed
+				break
+			}
+		}
+		if key == "" {
+			slog.Warn("saveWorkflowEnumList: invalid enum key", "field", wfd.Name, "value", word)
+			return false
+		}
+		keys = append(keys, key)
+	}
+	keys = collectionutil.NormalizeStringSet(keys)
+	return tc.setOrDelete(wfd.Name, keys, len(keys) == 0)
+}
+
+// saveWorkflowRef stores a tikiId field. A workspace-qualified id
+// (`BE:ABC123`), as the picker shows it, is stored bare.
+func (tc *TikiEditSession) saveWorkflowRef(name, raw string) bool {
+	id := strings.ToUpper(strings.TrimSpace(raw))
+	if _, bare, ok := document.SplitQualifiedID(id); ok {
+		id = bare
+	}
+	if id != "" && !idfmt.IsValidID(id) {
+		slog.Warn("saveWorkflowRef: not a tiki id", "field", name, "value", raw)
+		return false
+	}
+	return tc.setOrDelete(name, id, id == "")
+}
+
+func (tc *TikiEditSession) saveWorkflowURL(name, raw string) bool {
+	u := strings.TrimSpace(raw)
+	if err := value.ValidateURL(u); err != nil {
+		slog.Warn("saveWorkflowURL: invalid URL", "field", name, "value", raw, "error", err)
+		return false
+	}
+	return tc.setOrDelete(name, u, u == "")
+}
+
+func (tc *TikiEditSession) saveWorkflowNumber(name, raw string) bool {
+	if strings.TrimSpace(raw) == "" {
+		return tc.setOrDelete(name, 0.0, true)
+	}
+	f, ok := value.ParseNumber(raw)
+	if !ok {
+		slog.Warn("saveWorkflowNumber: not a number", "field", name, "value", raw)
+		return false
+	}
+	return tc.setOrDelete(name, f, false)
+}
+
 // setOrDelete deletes the field when clear is true, else sets it to fieldValue,
 // on whichever tiki the session is currently editing (draft or existing copy).
 func (tc *TikiEditSession) setOrDelete(name string, fieldValue interface{}, clear bool) bool {
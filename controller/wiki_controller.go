@@ -2,6 +2,7 @@ package controller
 
 import (
 	"log/slog"
+	"strings"
 
 	"github.com/boolean-maybe/ruki"
 	"github.com/boolean-maybe/tiki/model"
@@ -10,6 +11,7 @@ import (
 	"github.com/boolean-maybe/tiki/store"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 )
 
 // WikiController handles non-board view actions (wiki, detail, search).
@@ -55,6 +57,9 @@ func NewWikiController(
 		registry:      WikiViewActions(),
 		globalActions: globalActions,
 	}
+	if pluginDef.GetKind() == plugin.KindActivity {
+		dc.registry = ActivityViewActions()
+	}
 	if tikiStore != nil && mutationGate != nil && schema != nil {
 		dc.executor = NewPluginExecutor(tikiStore, mutationGate, statusline, progressHub, schema,
 			pluginDef.GetName(), nil)
@@ -71,8 +76,8 @@ func NewWikiController(
 //   - view-kind actions surface unconditionally.
 //   - ruki-kind actions surface only when the executor is wired AND the
 //     action is non-interactive. The wiki controller does not implement
-//     the input/choose pipeline (GetActionInputSpec / GetActionChooseSpec
-//     return empty), so dispatching an `input:` or `choose()` action here
+//     the input/choose pipeline for actions (GetActionInputSpec only
+//     covers the activity filters), so dispatching an `input:` or `choose()` action here
 //     would fire it with an uninitialized prompt. Filter them out at
 //     registration time so the UI reflects what can actually run.
 //     Implementing the interactive pipeline on non-board views is a
@@ -220,6 +225,8 @@ func (dc *WikiController) HandleAction(actionID ActionID) bool {
 	case ActionNavigateBack, ActionNavigateForward:
 		// handled by the view's NavigableMarkdown component
 		return false
+	case ActionFindInDocument, ActionFindNext, ActionFindPrevious, ActionToggleOutline:
+		return dc.handleDocumentAction(actionID)
 	}
 	if keyStr := getPluginActionKeyStr(actionID); keyStr != "" {
 		return dc.handleGlobalAction(keyStr)
@@ -227,72 +234,122 @@ func (dc *WikiController) HandleAction(actionID ActionID) bool {
 	return false
 }
 
+// handleDocumentAction runs search and outline actions on the active view.
+func (dc *WikiController) handleDocumentAction(actionID ActionID) bool {
+	if dc.navController == nil {
+		return false
+	}
+	dv, ok := dc.navController.GetActiveView().(DocumentView)
+	if !ok {
+		return false
+	}
+	app := dc.navController.GetApp()
+	dv.SetFocusSetter(func(p tview.Primitive) {
+		if app != nil {
+			app.SetFocus(p)
+		}
+	})
+	switch actionID {
+	case ActionFindInDocument:
+		dv.StartDocumentSearch()
+		return true
+	case ActionFindNext:
+		return dv.FindNext()
+	case ActionFindPrevious:
+		return dv.FindPrevious()
+	case ActionToggleOutline:
+		dv.ToggleOutline()
+		return true
+	}
+	return false
+}
+
 // handleGlobalAction dispatches a global action by its canonical key string.
 // View-kind actions switch the current view. Ruki-kind actions run through
-// the shared PluginExecutor against an empty selection (wiki views have no
-// intrinsic selection; selection passthrough from a source view lands in
-// 6B.3).
+// the shared PluginExecutor against the selection carried in from the source
+// view, if any.
 func (dc *WikiController) handleGlobalAction(keyStr string) bool {
 	for i := range dc.globalActions {
 		ga := &dc.globalActions[i]
 		if ga.KeyStr != keyStr {
 			continue
 		}
-		switch ga.Kind {
-		case plugin.ActionKindView:
-			if ga.TargetView == "" {
-				return false
-			}
-			// Defense-in-depth: refuse to navigate from a view to itself.
-			// surfacedGlobalActions and mergeGlobalActions both filter
-			// these out, but if anything slips through it would push an
-			// identical view onto the stack on every keypress.
-			if ga.TargetView == dc.pluginDef.GetName() {
-				return false
-			}
-			// 6B.15/6B.20/6B.22: honor the target view's own require:
-			// in full. The wiki view's carried selection is 0 or 1
-			// depending on whether a tiki id was threaded in via
-			// PluginViewParams; the target context is built fresh
-			// from the target's own identity so view:* requirements
-			// resolve against the target, not this view.
-			selected := 0
-			if dc.selectedTikiID != "" {
-				selected = 1
-			}
-			if !TargetViewEnabled(ga.TargetView, selected) {
-				return false
-			}
-			var params map[string]interface{}
-			if dc.selectedTikiID != "" {
-				params = model.EncodePluginViewParams(model.PluginViewParams{TikiID: dc.selectedTikiID})
-			}
-			dc.navController.PushView(model.MakePluginViewID(ga.TargetView), params)
-			return true
-		case plugin.ActionKindRuki:
-			if dc.executor == nil {
-				return false
-			}
-			// 6B.13 belt-and-suspenders: mergeGlobalActions filters
-			// HasInput/HasChoose actions out of the registry so they
-			// shouldn't reach here, but an action registered via a
-			// different path (future code) could. Refuse rather than
-			// fire with an empty input/choose payload.
-			if ga.HasInput || ga.HasChoose {
-				slog.Debug("interactive ruki global refused on non-board view",
-					"view", dc.pluginDef.GetName(), "key", keyStr)
-				return false
-			}
-			var selection []string
-			if dc.selectedTikiID != "" {
-				selection = []string{dc.selectedTikiID}
-			}
-			input, ok := dc.executor.BuildExecutionInput(ga, selection)
-			if !ok {
-				return false
-			}
-			return dc.executor.Execute(ga, input)
+		return dispatchGlobalAction(ga, dc.pluginDef.GetName(), dc.selectedTikiID, dc.navController, dc.executor)
+	}
+	return false
+}
+
+// dispatchGlobalAction runs a top-level action from a view that does not
+// merge globals into its own actions (wiki, calendar). selectedID is the
+// view's selected tiki, or empty when it has none.
+func dispatchGlobalAction(ga *plugin.PluginAction, viewName, selectedID string, navController *NavigationController, executor *PluginExecutor) bool {
+	switch ga.Kind {
+	case plugin.ActionKindChat:
+		if executor == nil {
+			return false
+		}
+		return runAgentChat(navController, executor.tikiStore, executor.statusline, ga.Agent, selectedID)
+	case plugin.ActionKindPropose:
+		if executor == nil {
+			return false
+		}
+		var selectedIDs []string
+		if selectedID != "" {
+			selectedIDs = []string{selectedID}
+		}
+		return executor.Propose(ga, selectedIDs, navController)
+	case plugin.ActionKindView:
+		if ga.TargetView == "" {
+			return false
+		}
+		// Defense-in-depth: refuse to navigate from a view to itself.
+		// surfacedGlobalActions and mergeGlobalActions both filter
+		// these out, but if anything slips through it would push an
+		// identical view onto the stack on every keypress.
+		if ga.TargetView == viewName {
+			return false
+		}
+		// 6B.15/6B.20/6B.22: honor the target view's own require:
+		// in full. The carried selection is 0 or 1 depending on whether
+		// the view has a selected tiki; the target context is built fresh
+		// from the target's own identity so view:* requirements
+		// resolve against the target, not this view.
+		selected := 0
+		if selectedID != "" {
+			selected = 1
+		}
+		if !TargetViewEnabled(ga.TargetView, selected) {
+			return false
+		}
+		var params map[string]interface{}
+		if selectedID != "" {
+			params = model.EncodePluginViewParams(model.PluginViewParams{TikiID: selectedID})
+		}
+		navController.PushView(model.MakePluginViewID(ga.TargetView), params)
+		return true
+	case plugin.ActionKindRuki:
+		if executor == nil {
+			return false
+		}
+		// 6B.13 belt-and-suspenders: mergeGlobalActions filters
+		// HasInput/HasChoose actions out of the registry so they
+		// shouldn't reach here, but an action registered via a
+		// different path (future code) could. Refuse rather than
+		// fire with an empty input/choose payload.
+		if ga.HasInput || ga.HasChoose {
+			slog.Debug("interactive ruki global refused on non-board view",
+				"view", viewName, "key", ga.KeyStr)
+			return false
+		}
+		var selection []string
+		if selectedID != "" {
+			selection = []string{selectedID}
+		}
+		input, ok := executor.BuildExecutionInput(ga, selection)
+		if !ok {
+			return false
 		}
+		return executor.Execute(ga, input)
 	}
 	return false
 }
@@ -300,14 +357,70 @@ func (dc *WikiController) handleGlobalAction(keyStr string) bool {
 // HandleSearch is not applicable for WikiPlugins (documentation views don't have search)
 func (dc *WikiController) HandleSearch(query string) {}
 
-func (dc *WikiController) GetActionInputSpec(ActionID) (string, ruki.ValueType, bool) {
-	return "", 0, false
+// activityFilterPrompts are the input prompts of the activity feed filters.
+var activityFilterPrompts = map[ActionID]string{
+	ActionActivityUser:  "User: ",
+	ActionActivityField: "Field: ",
+	ActionActivitySince: "Since: ",
+	ActionActivityUntil: "Until: ",
 }
-func (dc *WikiController) CanStartActionInput(ActionID) (string, ruki.ValueType, bool) {
-	return "", 0, false
+
+// GetActionInputSpec reports the activity feed filters as input actions;
+// no other action on these views takes input.
+func (dc *WikiController) GetActionInputSpec(actionID ActionID) (string, ruki.ValueType, bool) {
+	prompt, ok := activityFilterPrompts[actionID]
+	if !ok || dc.pluginDef.GetKind() != plugin.KindActivity {
+		return "", 0, false
+	}
+	return prompt, ruki.ValueString, true
+}
+
+// CanStartActionInput checks that an activity filter has a feed to apply to.
+func (dc *WikiController) CanStartActionInput(actionID ActionID) (string, ruki.ValueType, bool) {
+	if _, ok := dc.activeActivityView(); !ok {
+		return "", 0, false
+	}
+	return dc.GetActionInputSpec(actionID)
+}
+
+// HandleActionInput applies a submitted activity filter. A value the filter
+// rejects is reported in the statusline and the box stays open for
+// correction.
+func (dc *WikiController) HandleActionInput(actionID ActionID, text string) InputSubmitResult {
+	av, ok := dc.activeActivityView()
+	if !ok {
+		return InputClose
+	}
+	text = strings.TrimSpace(text)
+	var err error
+	switch actionID {
+	case ActionActivityUser:
+		av.SetActivityUser(text)
+	case ActionActivityField:
+		err = av.SetActivityField(text)
+	case ActionActivitySince:
+		err = av.SetActivitySince(text)
+	case ActionActivityUntil:
+		err = av.SetActivityUntil(text)
+	default:
+		return InputKeepEditing
+	}
+	if err != nil {
+		if dc.statusline != nil {
+			dc.statusline.SetMessage(err.Error(), model.MessageLevelError, true)
+		}
+		return InputKeepEditing
+	}
+	return InputClose
 }
-func (dc *WikiController) HandleActionInput(ActionID, string) InputSubmitResult {
-	return InputKeepEditing
+
+// activeActivityView returns the active view when it is an activity feed.
+func (dc *WikiController) activeActivityView() (ActivityView, bool) {
+	if dc.navController == nil || dc.pluginDef.GetKind() != plugin.KindActivity {
+		return nil, false
+	}
+	av, ok := dc.navController.GetActiveView().(ActivityView)
+	return av, ok
 }
 func (dc *WikiController) GetActionChooseSpec(ActionID) (string, bool) { return "", false }
 func (dc *WikiController) CanStartActionChoose(ActionID) (string, []*tikipkg.Tiki, bool) {
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 
 	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
 	"github.com/boolean-maybe/tiki/model"
@@ -69,3 +72,113 @@ func TestWikiKeepsNonSelectionGlobal(t *testing.T) {
 		t.Error("a ruki global with no selection builtin should surface on the wiki, but it did not")
 	}
 }
+
+// fakeDocumentView records the search and outline calls routed to it.
+type fakeDocumentView struct {
+	mockSelectableView
+	calls []string
+}
+
+func (f *fakeDocumentView) SetFocusSetter(func(p tview.Primitive)) {}
+func (f *fakeDocumentView) StartDocumentSearch()                   { f.calls = append(f.calls, "search") }
+func (f *fakeDocumentView) FindNext() bool {
+	f.calls = append(f.calls, "next")
+	return true
+}
+func (f *fakeDocumentView) FindPrevious() bool {
+	f.calls = append(f.calls, "prev")
+	return true
+}
+func (f *fakeDocumentView) ToggleOutline() { f.calls = append(f.calls, "outline") }
+
+// The find and outline keys reach the active document view through the
+// wiki controller.
+func TestWikiRoutesDocumentActionsToView(t *testing.T) {
+	nav := newMockNavigationController()
+	view := &fakeDocumentView{}
+	nav.SetActiveViewGetter(func() View { return view })
+	dc := NewWikiController(&plugin.WikiPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Docs", Kind: plugin.KindWiki},
+	}, nav, &model.StatuslineConfig{}, nil, nil, nil, nil, nil)
+
+	for _, r := range "/nNo" {
+		action := dc.GetActionRegistry().Match(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		if action == nil {
+			t.Fatalf("no wiki action bound to %q", r)
+		}
+		if !dc.HandleAction(action.ID) {
+			t.Errorf("action %s not handled", action.ID)
+		}
+	}
+	want := []string{"search", "next", "prev", "outline"}
+	if len(view.calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", view.calls, want)
+	}
+	for i := range want {
+		if view.calls[i] != want[i] {
+			t.Errorf("calls = %v, want %v", view.calls, want)
+			break
+		}
+	}
+}
+
+// fakeActivityView records the filters set on it; a field named "bad" is
+// rejected.
+type fakeActivityView struct {
+	mockSelectableView
+	set []string
+}
+
+func (f *fakeActivityView) SetActivityUser(text string) { f.set = append(f.set, "user="+text) }
+func (f *fakeActivityView) SetActivityField(text string) error {
+	if text == "bad" {
+		return errors.New(`unknown field "bad"`)
+	}
+	f.set = append(f.set, "field="+text)
+	return nil
+}
+func (f *fakeActivityView) SetActivitySince(text string) error {
+	f.set = append(f.set, "since="+text)
+	return nil
+}
+func (f *fakeActivityView) SetActivityUntil(text string) error {
+	f.set = append(f.set, "until="+text)
+	return nil
+}
+
+// The filter keys of an activity view take their value from the input box
+// and apply it to the active feed.
+func TestWikiActivityFilters(t *testing.T) {
+	nav := newMockNavigationController()
+	view := &fakeActivityView{}
+	nav.SetActiveViewGetter(func() View { return view })
+	dc := NewWikiController(&plugin.ActivityPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Activity", Kind: plugin.KindActivity},
+	}, nav, &model.StatuslineConfig{}, nil, nil, nil, nil, nil)
+
+	for _, r := range "uftT" {
+		action := dc.GetActionRegistry().Match(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		if action == nil {
+			t.Fatalf("no activity action bound to %q", r)
+		}
+		if _, _, ok := dc.CanStartActionInput(action.ID); !ok {
+			t.Fatalf("action %s does not take input", action.ID)
+		}
+		if got := dc.HandleActionInput(action.ID, " me "); got != InputClose {
+			t.Errorf("submit %s = %v, want close", action.ID, got)
+		}
+	}
+	if want := []string{"user=me", "field=me", "since=me", "until=me"}; !slices.Equal(view.set, want) {
+		t.Errorf("filters = %v, want %v", view.set, want)
+	}
+	if got := dc.HandleActionInput(ActionActivityField, "bad"); got != InputKeepEditing {
+		t.Errorf("rejected field = %v, want the box kept open", got)
+	}
+
+	wiki := NewWikiController(&plugin.WikiPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Docs", Kind: plugin.KindWiki},
+	}, nav, &model.StatuslineConfig{}, nil, nil, nil, nil, nil)
+	if _, _, ok := wiki.GetActionInputSpec(ActionActivityUser); ok {
+		t.Error("a wiki view offers the activity filters")
+	}
+}
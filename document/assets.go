@@ -0,0 +1,43 @@
+package document
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// AssetDirName is the hidden directory, next to a document's markdown file,
+// that holds per-document attachment folders. Being dot-prefixed, it is
+// pruned by WalkDocuments, so markdown files copied in as attachments are
+// never mistaken for managed documents.
+const AssetDirName = ".assets"
+
+// AssetDir returns the attachment folder for the document with the given id
+// stored at docPath: `<dir of docPath>/.assets/<id>`.
+func AssetDir(docPath, id string) string {
+	return filepath.Join(filepath.Dir(docPath), AssetDirName, id)
+}
+
+// AssetRelPath returns the slash-separated path of an attachment relative to
+// the document's markdown file. This is the form recorded in frontmatter and
+// linked from markdown, so it stays valid when the document and its asset
+// folder move together.
+func AssetRelPath(id, name string) string {
+	return path.Join(AssetDirName, id, name)
+}
+
+// imageExts lists the attachment extensions rendered inline as images.
+var imageExts = map[string]bool{
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".gif":  true,
+	".webp": true,
+	".bmp":  true,
+	".svg":  true,
+}
+
+// IsImageAsset reports whether name has an image extension.
+func IsImageAsset(name string) bool {
+	return imageExts[strings.ToLower(filepath.Ext(name))]
+}
@@ -0,0 +1,43 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAssetPaths(t *testing.T) {
+	docPath := filepath.Join("root", "bugs", "crash.md")
+	if got, want := AssetDir(docPath, "ABC123"), filepath.Join("root", "bugs", ".assets", "ABC123"); got != want {
+		t.Errorf("AssetDir = %q, want %q", got, want)
+	}
+	if got := AssetRelPath("ABC123", "shot.png"); got != ".assets/ABC123/shot.png" {
+		t.Errorf("AssetRelPath = %q", got)
+	}
+}
+
+func TestIsImageAsset(t *testing.T) {
+	for name, want := range map[string]bool{"a.PNG": true, "b.jpeg": true, "c.svg": true, "d.pdf": false, "e.log": false, "f": false} {
+		if got := IsImageAsset(name); got != want {
+			t.Errorf("IsImageAsset(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestWalkDocuments_SkipsAssetFolders(t *testing.T) {
+	root := t.TempDir()
+	dir := AssetDir(filepath.Join(root, "a.md"), "ABC123")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.md"), []byte("---\nid: ZZZ999\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	paths, err := WalkDocuments(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("attachment markdown must not be discovered: %v", paths)
+	}
+}
@@ -0,0 +1,79 @@
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ChecklistItem is one GitHub-style task list entry (`- [ ] text`) in a
+// markdown body. Line is the zero-based line it sits on.
+type ChecklistItem struct {
+	Line int
+	Text string
+	Done bool
+}
+
+// checklistItemPattern matches a bullet or ordered list item whose text
+// starts with a `[ ]` / `[x]` box. Group 1 is everything up to the box's
+// inner character, group 2 that character, group 3 the item text.
+var checklistItemPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d{1,9}[.)])\s+\[)([ xX])\](?:\s+(.*))?$`)
+
+// ParseChecklist returns the task list items of body in document order.
+// Items inside fenced code blocks are ignored, as GitHub does.
+func ParseChecklist(body string) []ChecklistItem {
+	var items []ChecklistItem
+	walkChecklist(body, func(i int, line string, m []int) {
+		text := ""
+		if m[6] >= 0 {
+			text = strings.TrimSpace(line[m[6]:m[7]])
+		}
+		items = append(items, ChecklistItem{Line: i, Text: text, Done: line[m[4]] != ' '})
+	})
+	return items
+}
+
+// ToggleChecklistItem flips the box of the n-th (zero-based) task list item
+// in body and returns the new body; every other byte is kept.
+func ToggleChecklistItem(body string, n int) (string, error) {
+	lines := strings.Split(body, "\n")
+	found := 0
+	toggled := false
+	walkChecklist(body, func(i int, line string, m []int) {
+		if found == n {
+			box := "x"
+			if line[m[4]] != ' ' {
+				box = " "
+			}
+			lines[i] = line[:m[4]] + box + line[m[5]:]
+			toggled = true
+		}
+		found++
+	})
+	if !toggled {
+		return body, fmt.Errorf("no checklist item %d (the body has %d)", n+1, found)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// walkChecklist calls fn with the index, text and submatch offsets of every
+// task list line outside fenced code blocks.
+func walkChecklist(body string, fn func(i int, line string, m []int)) {
+	fence := ""
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		if m := checklistItemPattern.FindStringSubmatchIndex(strings.TrimRight(line, "\r")); m != nil {
+			fn(i, line, m)
+		}
+	}
+}
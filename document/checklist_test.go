@@ -0,0 +1,43 @@
+package document
+
+import (
+	"reflect"
+	"testing"
+)
+
+const checklistBody = "Intro\n\n- [ ] write spec\n  * [x] nested done\n1. [X] ordered\n- [] not a box\n- [ ]\n```\n- [ ] in a fence\n```\n+ [ ] after fence"
+
+func TestParseChecklist(t *testing.T) {
+	got := ParseChecklist(checklistBody)
+	want := []ChecklistItem{
+		{Line: 2, Text: "write spec"},
+		{Line: 3, Text: "nested done", Done: true},
+		{Line: 4, Text: "ordered", Done: true},
+		{Line: 6, Text: ""},
+		{Line: 10, Text: "after fence"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseChecklist = %+v, want %+v", got, want)
+	}
+	if items := ParseChecklist("no boxes here\n- plain item"); items != nil {
+		t.Errorf("ParseChecklist without boxes = %+v, want nil", items)
+	}
+}
+
+func TestToggleChecklistItem(t *testing.T) {
+	body, err := ToggleChecklistItem(checklistBody, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err = ToggleChecklistItem(body, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Intro\n\n- [x] write spec\n  * [x] nested done\n1. [ ] ordered\n- [] not a box\n- [ ]\n```\n- [ ] in a fence\n```\n+ [ ] after fence"
+	if body != want {
+		t.Errorf("toggled body =\n%s\nwant\n%s", body, want)
+	}
+	if _, err := ToggleChecklistItem(checklistBody, 5); err == nil {
+		t.Error("toggling a missing item should fail")
+	}
+}
@@ -44,6 +44,27 @@ func ValidateID(id string) error {
 	return nil
 }
 
+// QualifyID returns id qualified with a workspace prefix, `PREFIX:ID`. An
+// empty prefix returns the bare id.
+func QualifyID(prefix, id string) string {
+	if prefix == "" {
+		return id
+	}
+	return prefix + ":" + id
+}
+
+// SplitQualifiedID splits a `PREFIX:ID` reference into its upper-cased
+// prefix and bare id. A reference without a colon returns an empty prefix and
+// the normalized id; ok is false only when a prefix is present but empty.
+func SplitQualifiedID(ref string) (prefix, id string, ok bool) {
+	p, rest, found := strings.Cut(ref, ":")
+	if !found {
+		return "", NormalizeID(ref), true
+	}
+	prefix = strings.ToUpper(strings.TrimSpace(p))
+	return prefix, NormalizeID(rest), prefix != ""
+}
+
 // Index tracks the set of document IDs currently loaded so duplicate detection
 // and unique-ID generation can share a single source of truth. The zero value
 // is not usable — use NewIndex.
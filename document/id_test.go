@@ -28,6 +28,29 @@ func TestNormalizeID(t *testing.T) {
 	}
 }
 
+func TestQualifiedID(t *testing.T) {
+	if got := QualifyID("BE", "ABC123"); got != "BE:ABC123" {
+		t.Errorf("QualifyID = %q", got)
+	}
+	if got := QualifyID("", "ABC123"); got != "ABC123" {
+		t.Errorf("QualifyID without prefix = %q", got)
+	}
+	tests := []struct {
+		ref, prefix, id string
+		ok              bool
+	}{
+		{"be:abc123", "BE", "ABC123", true},
+		{" ABC123 ", "", "ABC123", true},
+		{":ABC123", "", "ABC123", false},
+	}
+	for _, tt := range tests {
+		prefix, id, ok := SplitQualifiedID(tt.ref)
+		if prefix != tt.prefix || id != tt.id || ok != tt.ok {
+			t.Errorf("SplitQualifiedID(%q) = %q, %q, %v", tt.ref, prefix, id, ok)
+		}
+	}
+}
+
 func TestIndex(t *testing.T) {
 	ix := NewIndex()
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/testutil"
 
@@ -130,3 +131,54 @@ func TestTikiDetailView_ChatNotAvailableWithoutConfig(t *testing.T) {
 		t.Errorf("title = %q, want %q", unchanged.Title(), "Unchanged Title")
 	}
 }
+
+// TestTikiDetailView_ChatWithConfiguredAgent verifies an agent declared under
+// ai.agents receives its templated args, env and stdin prompt.
+func TestTikiDetailView_ChatWithConfiguredAgent(t *testing.T) {
+	ta := testutil.NewTestApp(t)
+	defer ta.Cleanup()
+
+	viper.Set("ai.agent", "local")
+	viper.Set("ai.agents", map[string]interface{}{
+		"local": map[string]interface{}{
+			"command": "local-llm",
+			"args":    []interface{}{"--id", "{id}"},
+			"prompt":  "stdin",
+			"env":     []interface{}{"LOCAL_TITLE={title}"},
+			"context": "Review {path}",
+		},
+	})
+	defer viper.Set("ai.agent", "")
+	defer viper.Set("ai.agents", nil)
+
+	tikiID := "AGENT1"
+	if err := testutil.CreateTestTiki(ta.TikiDir, tikiID, "Needs review", "ready", "story"); err != nil {
+		t.Fatalf("failed to create test tiki: %v", err)
+	}
+	if err := ta.TikiStore.Reload(); err != nil {
+		t.Fatalf("failed to reload tikis: %v", err)
+	}
+
+	var got config.AgentInvocation
+	ta.NavController.SetAgentRunner(func(inv config.AgentInvocation) error {
+		got = inv
+		return nil
+	})
+
+	ta.NavController.PushView(
+		model.DetailPluginViewID(),
+		model.EncodePluginViewParams(model.PluginViewParams{TikiID: tikiID}),
+	)
+	ta.Draw()
+	ta.SendKey(tcell.KeyRune, 'c', tcell.ModNone)
+
+	if got.Command != "local-llm" || strings.Join(got.Args, " ") != "--id "+tikiID {
+		t.Errorf("invocation = %q %q, want local-llm --id %s", got.Command, got.Args, tikiID)
+	}
+	if len(got.Env) != 1 || got.Env[0] != "LOCAL_TITLE=Needs review" {
+		t.Errorf("env = %q", got.Env)
+	}
+	if !strings.HasPrefix(got.Stdin, "Review ") || !strings.HasSuffix(got.Stdin, tikiID+".md") {
+		t.Errorf("stdin = %q, want the rendered context prompt", got.Stdin)
+	}
+}
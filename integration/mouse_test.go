@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/testutil"
+
+	"github.com/rivo/tview"
+)
+
+func TestPluginView_MouseDragAndHeaderClickRunLaneActions(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowContent := testWorkflowPreamble + `views:
+  - name: MouseTest
+    kind: board
+    key: "F4"
+    layout: |
+      id
+    lanes:
+      - name: Backlog
+        columns: 1
+        filter: select where status = "backlog"
+        action: update where id = id() set status="backlog" tags=tags-["moved"]
+      - name: Done
+        columns: 1
+        filter: select where status = "done"
+        action: update where id = id() set status="done" tags=tags+["moved"]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "workflow.yaml"), []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("failed to write workflow.yaml: %v", err)
+	}
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get cwd: %v", err)
+	}
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+	})
+
+	ta := testutil.NewTestApp(t)
+	if err := ta.LoadPlugins(); err != nil {
+		t.Fatalf("failed to load plugins: %v", err)
+	}
+	defer ta.Cleanup()
+
+	if err := testutil.CreateTestTiki(ta.TikiDir, "000001", "Backlog Tiki", "backlog", "story"); err != nil {
+		t.Fatalf("failed to create tiki: %v", err)
+	}
+	if err := testutil.CreateTestTiki(ta.TikiDir, "000002", "Done Tiki", "done", "story"); err != nil {
+		t.Fatalf("failed to create tiki: %v", err)
+	}
+	if err := ta.TikiStore.Reload(); err != nil {
+		t.Fatalf("failed to reload tikis: %v", err)
+	}
+
+	// wide enough for the header to show the board's move actions
+	ta.Screen.SetSize(200, 30)
+	ta.NavController.PushView(model.MakePluginViewID("MouseTest"), nil)
+	ta.Draw()
+
+	// each lane's first card (one row tall with this layout) sits just
+	// below its caption row
+	found, fromX, captionY := ta.FindText("Backlog")
+	if !found {
+		ta.DumpScreen()
+		t.Fatal("Backlog lane not on screen")
+	}
+	found, toX, _ := ta.FindText("Done")
+	if !found {
+		ta.DumpScreen()
+		t.Fatal("Done lane not on screen")
+	}
+	cardY := captionY + 1
+
+	// drag the backlog card onto the Done lane
+	ta.SendMouse(tview.MouseLeftDown, fromX, cardY)
+	ta.SendMouse(tview.MouseLeftUp, toX, cardY)
+
+	status := func() (string, []string) {
+		t.Helper()
+		if err := ta.TikiStore.Reload(); err != nil {
+			t.Fatalf("failed to reload tikis: %v", err)
+		}
+		tk := ta.TikiStore.GetTiki("000001")
+		if tk == nil {
+			t.Fatal("tiki 000001 missing")
+		}
+		s, _, _ := tk.StringField("status")
+		tags, _, _ := tk.StringSliceField("tags")
+		return s, tags
+	}
+	if s, tags := status(); s != "done" || !containsTag(tags, "moved") {
+		t.Fatalf("after drag: status %q tags %v, want done with moved tag", s, tags)
+	}
+
+	// the moved card stays selected; clicking the header's move-left action
+	// runs it exactly like Shift-←
+	found, x, y := ta.FindText("Move ←")
+	if !found {
+		ta.DumpScreen()
+		t.Fatal("move-left action not in header")
+	}
+	ta.SendMouse(tview.MouseLeftClick, x, y)
+	if s, tags := status(); s != "backlog" || containsTag(tags, "moved") {
+		t.Fatalf("after header click: status %q tags %v, want backlog without moved tag", s, tags)
+	}
+}
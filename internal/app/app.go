@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+
+	"github.com/boolean-maybe/tiki/config"
 )
 
 // NewApp creates a tview application.
@@ -18,8 +20,9 @@ func NewApp() *tview.Application {
 }
 
 // Run runs the tview application with the given root primitive (typically a tview.Pages).
+// Mouse input is enabled only when `mouse.enabled` is set in config.yaml.
 func Run(app *tview.Application, root tview.Primitive) error {
-	app.SetRoot(root, true).EnableMouse(false)
+	app.SetRoot(root, true).EnableMouse(config.GetMouseEnabled())
 	if err := app.Run(); err != nil {
 		return fmt.Errorf("run application: %w", err)
 	}
@@ -17,6 +17,7 @@ func InstallGlobalInputCapture(
 	paletteConfig *model.ActionPaletteConfig,
 	quickSelectConfig *model.QuickSelectConfig,
 	markdownTreeConfig *model.MarkdownTreeConfig,
+	proposalReviewConfig *model.ProposalReviewConfig,
 	statuslineConfig *model.StatuslineConfig,
 	inputRouter *controller.InputRouter,
 	navController *controller.NavigationController,
@@ -37,6 +38,10 @@ func InstallGlobalInputCapture(
 			return event
 		}
 
+		if proposalReviewConfig != nil && proposalReviewConfig.IsVisible() {
+			return event
+		}
+
 		// dismiss auto-hide statusline messages on any keypress
 		statuslineConfig.DismissAutoHide()
 
@@ -46,3 +51,29 @@ func InstallGlobalInputCapture(
 		return event
 	})
 }
+
+// InstallGlobalMouseCapture swallows mouse events while a keyboard-driven
+// overlay (palette, QuickSelect, markdown tree, proposal review) is open, so clicks behind it
+// neither run actions nor steal focus from its input field. A click also
+// dismisses auto-hide statusline messages, like a keypress does.
+func InstallGlobalMouseCapture(
+	app *tview.Application,
+	paletteConfig *model.ActionPaletteConfig,
+	quickSelectConfig *model.QuickSelectConfig,
+	markdownTreeConfig *model.MarkdownTreeConfig,
+	proposalReviewConfig *model.ProposalReviewConfig,
+	statuslineConfig *model.StatuslineConfig,
+) {
+	app.SetMouseCapture(func(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+		if (paletteConfig != nil && paletteConfig.IsVisible()) ||
+			(quickSelectConfig != nil && quickSelectConfig.IsVisible()) ||
+			(markdownTreeConfig != nil && markdownTreeConfig.IsVisible()) ||
+			(proposalReviewConfig != nil && proposalReviewConfig.IsVisible()) {
+			return nil, action
+		}
+		if action == tview.MouseLeftClick {
+			statuslineConfig.DismissAutoHide()
+		}
+		return event, action
+	})
+}
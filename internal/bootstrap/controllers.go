@@ -54,7 +54,7 @@ func BuildControllers(
 				progressHub,
 				schema,
 			)
-		case plugin.KindWiki:
+		case plugin.KindWiki, plugin.KindDependencies, plugin.KindSprints, plugin.KindActivity:
 			pluginControllers[p.GetName()] = controller.NewWikiController(
 				p, navController, statuslineConfig, progressHub, globalActions,
 				tikiStore, mutationGate, schema,
@@ -68,6 +68,15 @@ func BuildControllers(
 				dp, navController, statuslineConfig, progressHub,
 				tikiStore, mutationGate, schema, editSession,
 			)
+		case plugin.KindCalendar:
+			cp, ok := p.(*plugin.CalendarPlugin)
+			if !ok {
+				continue
+			}
+			pluginControllers[p.GetName()] = controller.NewCalendarController(
+				cp, navController, statuslineConfig, progressHub, globalActions,
+				tikiStore, mutationGate, schema,
+			)
 		}
 	}
 
@@ -58,11 +58,20 @@ type Result struct {
 	CancelFunc        context.CancelFunc
 	WorkflowPath      string
 	WorkflowScope     config.Scope
+	Session           *controller.SessionManager
+}
+
+// LaunchOptions carries the command-line flags that choose the first screen.
+type LaunchOptions struct {
+	// View is the workflow view to open instead of the saved session (--view).
+	View string
+	// Open is a tiki ID to show on launch (--open).
+	Open string
 }
 
 // Bootstrap orchestrates the complete application initialization sequence and
 // returns all initialized components.
-func Bootstrap() (*Result, error) {
+func Bootstrap(opts LaunchOptions) (*Result, error) {
 	// Phase 0: Configuration and logging — loaded first so store.name is
 	// available before any side effects.
 	cfg, err := LoadConfig()
@@ -76,6 +85,12 @@ func Bootstrap() (*Result, error) {
 		return nil, fmt.Errorf("unknown store backend: %q (supported: tiki)", name)
 	}
 
+	// Phase 0.6: Validate user-defined AI agents before workflow actions
+	// refer to them
+	if err := config.ValidateAIAgents(); err != nil {
+		return nil, fmt.Errorf("config %w", err)
+	}
+
 	// Phase 2.5: Install default workflow to user config dir (first-run or upgrade)
 	// Runs on every launch so upgrades from older versions get workflow.yaml installed.
 	if err := config.InstallDefaultWorkflow(); err != nil {
@@ -117,6 +132,23 @@ func Bootstrap() (*Result, error) {
 		return nil, err
 	}
 	InitPluginActionRegistry(plugins)
+
+	// Phase 6.05: Permissions grey out the actions the current user may not
+	// take on the selected tiki; the gate rejects them either way
+	controller.SetDeniedPredicate(func(id string) []string {
+		p, ok := service.CurrentPermissions(tikiStore)
+		tk := tikiStore.GetTiki(id)
+		if !ok || tk == nil {
+			return nil
+		}
+		return p.Denied(tk)
+	})
+
+	// Phase 6.1: Key bindings — validated against the view activation keys
+	// registered above and installed before any action registry is built
+	if err := InitKeymap(); err != nil {
+		return nil, err
+	}
 	viewContext := model.NewViewContext()
 	pluginConfigs, pluginDefs := BuildPluginConfigsAndDefs(plugins)
 
@@ -147,6 +179,20 @@ func Bootstrap() (*Result, error) {
 		slog.Info("triggers loaded", "count", triggerCount)
 	}
 
+	// Phase 6.55: Enforced lane WIP limits — validators generated from views
+	service.RegisterLaneLimitValidators(gate, LaneLimits(plugins), schema, userFunc)
+
+	// Phase 6.6: Outbound webhooks — after-hooks that queue JSON deliveries
+	webhooks, err := service.LoadAndRegisterHooks(gate, schema, config.GetWebhookQueueDir(), service.StoreWebhookActor(tikiStore))
+	if err != nil {
+		return nil, fmt.Errorf("load hooks: %w", err)
+	}
+
+	// Phase 6.7: Activity log — records this session's changes for
+	// kind: activity views, which merge them with committed history
+	activityLog := service.NewActivityLog(service.StoreActivityActor(tikiStore))
+	activityLog.RegisterWithGate(gate)
+
 	// Phase 7: Application and controllers
 	application := app.NewApp()
 	app.SetupSignalHandler(application)
@@ -186,6 +232,7 @@ func Bootstrap() (*Result, error) {
 	viewFactory := view.NewViewFactory(tikiStore)
 	viewFactory.SetPlugins(pluginConfigs, pluginDefs, controllers.Plugins, globalActions)
 	viewFactory.SetProgressHub(progressHub, redraw)
+	viewFactory.SetActivitySource(service.ActivitySource{Store: tikiStore, Log: activityLog})
 
 	// Wire fresh-per-navigation WikiController creation so each view instance
 	// on the nav stack holds its own selectedTikiID (prevents a second wiki
@@ -224,6 +271,8 @@ func Bootstrap() (*Result, error) {
 	// Phase 11: Background tasks
 	ctx, cancel := context.WithCancel(context.Background()) //nolint:gosec // G118: cancel stored in Result.CancelFunc, called by app shutdown
 	triggerEngine.StartScheduler(ctx)
+	webhooks.Start(ctx)
+	controller.WatchOverdueStat(ctx, tikiStore, statuslineConfig, redraw)
 
 	// Phase 11.5: Action palette
 	paletteConfig := model.NewActionPaletteConfig()
@@ -242,6 +291,13 @@ func Bootstrap() (*Result, error) {
 	quickSelect.SetChangedFunc()
 	inputRouter.SetQuickSelectView(quickSelect)
 
+	// clicking the overdue stat opens the overdue tikis in QuickSelect
+	statuslineWidget.SetStatClickHandler(func(key string) {
+		if key == controller.OverdueStatName {
+			inputRouter.HandleAction(controller.ActionShowOverdue, controllers.Nav.CurrentView())
+		}
+	})
+
 	// Phase 11.7: markdown-file tree overlay (Ctrl-O)
 	markdownTreeConfig := model.NewMarkdownTreeConfig()
 	inputRouter.SetMarkdownTreeConfig(markdownTreeConfig)
@@ -258,6 +314,11 @@ func Bootstrap() (*Result, error) {
 	})
 	inputRouter.SetMarkdownTreeView(markdownTree)
 
+	// Phase 11.8: review overlay for changes proposed by kind: propose actions
+	proposalReviewConfig := model.NewProposalReviewConfig()
+	controllers.Nav.SetProposalReview(proposalReviewConfig)
+	proposalReview := palette.NewProposalReview(proposalReviewConfig)
+
 	// Build Pages root: base = rootLayout, overlay = palette + quickselect
 	pages := tview.NewPages()
 	pages.AddPage("base", rootLayout.GetPrimitive(), true, true)
@@ -267,6 +328,7 @@ func Bootstrap() (*Result, error) {
 	pages.AddPage("quickselect", quickSelectOverlay, true, false)
 	markdownTreeOverlay := buildMarkdownTreeOverlay(markdownTree)
 	pages.AddPage("markdowntree", markdownTreeOverlay, true, false)
+	pages.AddPage("proposalreview", buildProposalReviewOverlay(proposalReview), true, false)
 
 	// Wire palette visibility to Pages show/hide and focus management
 	var previousFocus tview.Primitive
@@ -310,6 +372,7 @@ func Bootstrap() (*Result, error) {
 			application.SetFocus(markdownTree.GetFilterInput())
 		} else {
 			pages.HidePage("markdowntree")
+			markdownTree.RememberExpansion()
 			if mtPreviousFocus != nil {
 				application.SetFocus(mtPreviousFocus)
 			} else if cv := rootLayout.GetContentView(); cv != nil {
@@ -319,13 +382,51 @@ func Bootstrap() (*Result, error) {
 		}
 	})
 
+	// Wire proposal review visibility
+	// the config also notifies on cursor moves, so only open/close transitions act
+	var prPreviousFocus tview.Primitive
+	prShown := false
+	proposalReviewConfig.AddListener(func() {
+		visible := proposalReviewConfig.IsVisible()
+		if visible && !prShown {
+			prShown = true
+			prPreviousFocus = application.GetFocus()
+			proposalReview.OnShow()
+			pages.ShowPage("proposalreview")
+			application.SetFocus(proposalReview.GetFocusTarget())
+		} else if !visible && prShown {
+			prShown = false
+			pages.HidePage("proposalreview")
+			if prPreviousFocus != nil {
+				application.SetFocus(prPreviousFocus)
+			} else if cv := rootLayout.GetContentView(); cv != nil {
+				application.SetFocus(cv.GetPrimitive())
+			}
+			prPreviousFocus = nil
+		}
+	})
+
 	// Phase 12: Navigation and input wiring
 	wireNavigation(controllers.Nav, layoutModel, rootLayout)
-	app.InstallGlobalInputCapture(application, paletteConfig, quickSelectConfig, markdownTreeConfig, statuslineConfig, inputRouter, controllers.Nav)
+	app.InstallGlobalInputCapture(application, paletteConfig, quickSelectConfig, markdownTreeConfig, proposalReviewConfig, statuslineConfig, inputRouter, controllers.Nav)
+	app.InstallGlobalMouseCapture(application, paletteConfig, quickSelectConfig, markdownTreeConfig, proposalReviewConfig, statuslineConfig)
 
-	// Phase 13: Initial view — use the first plugin marked default: true,
-	// or fall back to the first plugin in the list.
-	controllers.Nav.PushView(model.MakePluginViewID(plugin.DefaultPlugin(plugins).GetName()), nil)
+	// mouse gestures (double-click on a card, clicks on header actions)
+	// replay keys through the router so they run the same actions
+	dispatchKey := func(event *tcell.EventKey) {
+		inputRouter.HandleInput(event, controllers.Nav.CurrentView())
+	}
+	viewFactory.SetKeyDispatcher(dispatchKey)
+	headerWidget.SetKeyDispatcher(dispatchKey)
+
+	// Phase 13: Initial view — the launch flags when given, else the saved
+	// session, else the first plugin marked default: true (or the first
+	// plugin in the list).
+	session := controller.NewSessionManager(controllers.Nav, plugins, controllers.Plugins, pluginConfigs, markdownTreeConfig, tikiStore)
+	if err := session.Start(controller.LoadSessionState(config.GetSessionStateFile()), opts.View, opts.Open); err != nil {
+		cancel()
+		return nil, err
+	}
 
 	return &Result{
 		Cfg:               cfg,
@@ -356,6 +457,7 @@ func Bootstrap() (*Result, error) {
 		CancelFunc:        cancel,
 		WorkflowPath:      workflowPath,
 		WorkflowScope:     workflowScope,
+		Session:           session,
 	}, nil
 }
 
@@ -486,6 +588,19 @@ func (o *markdownTreeOverlayFlex) Draw(screen tcell.Screen) {
 	o.Flex.Draw(screen)
 }
 
+// buildProposalReviewOverlay centers the review dialog at two thirds of the
+// screen in each direction; diffs need more room than the side pickers.
+func buildProposalReviewOverlay(pr *palette.ProposalReview) *tview.Flex {
+	row := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(pr.GetPrimitive(), 0, 4, true).
+		AddItem(nil, 0, 1, false)
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(row, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+}
+
 // restoreFocusAfterPalette restores focus to the previously focused primitive,
 // falling back to FocusRestorer on the active view, then to the content view root.
 func restoreFocusAfterPalette(application *tview.Application, previousFocus tview.Primitive, rootLayout *view.RootLayout) {
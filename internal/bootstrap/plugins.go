@@ -1,12 +1,15 @@
 package bootstrap
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/controller"
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
 )
 
 // LoadPlugins loads plugins and the workflow's top-level global actions from
@@ -25,6 +28,17 @@ func LoadPlugins(schema ruki.Schema) ([]plugin.Plugin, []plugin.PluginAction, er
 	return plugins, globals, nil
 }
 
+// InitKeymap loads the `keys:` section of config.yaml and installs it for
+// every action registry built afterwards.
+func InitKeymap() error {
+	km, err := controller.LoadKeymap(config.GetKeyProfile(), config.GetKeyBindings())
+	if err != nil {
+		return fmt.Errorf("config keys: %w", err)
+	}
+	controller.SetKeymap(km)
+	return nil
+}
+
 // InitPluginActionRegistry initializes the controller plugin action registry
 // from loaded plugin activation keys.
 func InitPluginActionRegistry(plugins []plugin.Plugin) {
@@ -119,3 +133,27 @@ func BuildPluginConfigsAndDefs(plugins []plugin.Plugin) (map[string]*model.Plugi
 	}
 	return pluginConfigs, pluginDefs
 }
+
+// LaneLimits collects the enforced WIP limits of every board and list view
+// for the mutation gate.
+func LaneLimits(plugins []plugin.Plugin) []service.LaneLimit {
+	var limits []service.LaneLimit
+	for _, p := range plugins {
+		tp, ok := p.(*plugin.WorkflowPlugin)
+		if !ok {
+			continue
+		}
+		for _, lane := range tp.Lanes {
+			if !lane.EnforceLimit {
+				continue
+			}
+			limits = append(limits, service.LaneLimit{
+				View:   tp.GetName(),
+				Lane:   lane.Name,
+				Limit:  lane.Limit,
+				Filter: lane.Filter,
+			})
+		}
+	}
+	return limits
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 
 	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/internal/ruki/runtime"
 	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/store/tikistore"
+	"github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
 )
 
 // InitStores initializes the tiki stores.
@@ -20,9 +23,49 @@ func InitStores() (*tikistore.TikiStore, store.Store, error) {
 	// pruned except `.doc`, which the walker traverses by exception — so legacy
 	// projects whose tikis still live under `.doc/` (e.g. `.doc/tiki/*.md`) load,
 	// while new documents are written as `<slug>.md` directly under the root.
-	tikiStore, err := tikistore.NewTikiStore(config.GetDocDir())
+	// `workspaces:` in config.yaml mounts several roots into the one store
+	// instead; the derived workspace field is enabled before the store loads
+	// so frontmatter and computed fields see it like any other field.
+	workspaces, err := config.GetWorkspaces()
+	if err != nil {
+		return nil, nil, err
+	}
+	var tikiStore *tikistore.TikiStore
+	if len(workspaces) > 0 {
+		if err := workflow.EnableWorkspaceField(); err != nil {
+			return nil, nil, err
+		}
+		tikiStore, err = tikistore.NewWorkspaceTikiStore(workspaces)
+		if err == nil {
+			tiki.SetDerivedResolver([]string{workflow.FieldWorkspace}, tikiStore.ResolveWorkspace)
+		}
+	} else {
+		workflow.DisableWorkspaceField()
+		tiki.SetDerivedResolver([]string{workflow.FieldWorkspace}, nil)
+		tikiStore, err = tikistore.NewTikiStore(config.GetDocDir())
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("initialize tiki store: %w", err)
 	}
+	// the checklist fields only read a tiki's body, so they need no index.
+	store.InstallChecklistResolver()
+	// the derived blocked/blockers/depth fields are answered from a graph
+	// over this store; without a dependencies: section they don't exist.
+	if deps, ok := config.WorkflowDependencies(); ok {
+		store.InstallDependencyIndex(tikiStore, deps)
+	}
+	// currentSprint reads the sprint list, which sprint tikis can extend.
+	if sprints, ok := config.WorkflowSprints(); ok {
+		store.InstallSprintIndex(tikiStore, sprints)
+	} else {
+		tiki.SetDerivedResolver([]string{workflow.FieldCurrentSprint}, nil)
+	}
+	// computed fields are evaluated on read from their ruki expressions;
+	// an expression that doesn't compile fails startup like a bad trigger.
+	// An unresolvable identity only leaves user() unavailable to them.
+	userFunc, _ := store.CurrentUserDisplayFunc(tikiStore)
+	if _, err := store.InstallComputedIndex(tikiStore, runtime.NewSchema(), userFunc); err != nil {
+		return nil, nil, fmt.Errorf("initialize computed fields: %w", err)
+	}
 	return tikiStore, tikiStore, nil
 }
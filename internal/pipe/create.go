@@ -43,7 +43,7 @@ func HasPositionalArgs(args []string) bool {
 			return true
 		}
 		if strings.HasPrefix(arg, "-") {
-			if arg == "--log-level" {
+			if arg == "--log-level" || arg == "--template" {
 				skipNext = true
 			}
 			continue
@@ -53,12 +53,38 @@ func HasPositionalArgs(args []string) bool {
 	return false
 }
 
+// TemplateFlag returns the value of a `--template <name>` or
+// `--template=<name>` argument, or "" when the flag is absent. A flag with
+// no value is an error.
+func TemplateFlag(args []string) (string, error) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--template="); ok {
+			if value == "" {
+				return "", fmt.Errorf("--template requires a template name")
+			}
+			return value, nil
+		}
+		if arg == "--template" {
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return "", fmt.Errorf("--template requires a template name")
+			}
+			return args[i+1], nil
+		}
+	}
+	return "", nil
+}
+
 // CreateTikiFromReader reads piped input, parses it into title/description,
 // and creates a new document. When the active workflow has a default status,
 // the result is a workflow tiki; otherwise the result is a plain doc with
-// only id and title in the frontmatter. Returns the generated bare document
-// id (e.g. "ABC123").
-func CreateTikiFromReader(r io.Reader) (string, error) {
+// only id and title in the frontmatter. A non-empty templateName starts the
+// tiki from that workflow template: its preset fields and folder apply, the
+// piped title runs through its title pattern, and its body skeleton follows
+// the piped description. Returns the generated bare document id (e.g. "ABC123").
+func CreateTikiFromReader(r io.Reader, templateName string) (string, error) {
 	// Suppress info/debug logs for the non-interactive pipe path.
 	// The pipe path bypasses bootstrap (which normally configures logging),
 	// so the default slog handler would write INFO+ messages to stderr.
@@ -104,18 +130,26 @@ func CreateTikiFromReader(r io.Reader) (string, error) {
 	if _, _, loadErr := service.LoadAndRegisterTriggers(gate, schema, userFunc); loadErr != nil {
 		return "", fmt.Errorf("load triggers: %w", loadErr)
 	}
+	webhooks, hookErr := service.LoadAndRegisterHooks(gate, schema, config.GetWebhookQueueDir(), service.StoreWebhookActor(tikiStore))
+	if hookErr != nil {
+		return "", fmt.Errorf("load hooks: %w", hookErr)
+	}
 
-	tmpl, err := tikiStore.NewTikiTemplate()
+	tmpl, err := service.NewTikiFromTemplate(tikiStore, templateName)
 	if err != nil {
 		return "", fmt.Errorf("create tiki template: %w", err)
 	}
 
 	tmpl.SetTitle(title)
-	tmpl.SetBody(description)
+	service.FinishTemplateCreate(tikiStore, tmpl, templateName)
+	tmpl.SetBody(joinBody(description, tmpl.Body()))
 
 	if err := gate.CreateTiki(context.Background(), tmpl); err != nil {
 		return "", fmt.Errorf("create tiki: %w", err)
 	}
+	if err := webhooks.FlushPending(service.CLIWebhookFlushTimeout); err != nil {
+		slog.Warn("webhook delivery deferred", "error", err)
+	}
 
 	return tmpl.ID(), nil
 }
@@ -147,3 +181,16 @@ func stripMarkdownHeading(line string) (string, bool) {
 	}
 	return strings.TrimSpace(trimmed), true
 }
+
+// joinBody places the piped description above a template body skeleton,
+// separated by a blank line. Either part may be empty.
+func joinBody(description, skeleton string) string {
+	switch {
+	case skeleton == "":
+		return description
+	case description == "":
+		return skeleton
+	default:
+		return description + "\n\n" + skeleton
+	}
+}
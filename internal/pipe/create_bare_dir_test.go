@@ -26,7 +26,7 @@ func TestCreateInBareDirSucceeds(t *testing.T) {
 		t.Fatalf("install default workflow: %v", err)
 	}
 
-	id, err := CreateTikiFromReader(strings.NewReader("My task\nsome body\n"))
+	id, err := CreateTikiFromReader(strings.NewReader("My task\nsome body\n"), "")
 	if err != nil {
 		t.Fatalf("create failed in bare dir: %v", err)
 	}
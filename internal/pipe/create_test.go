@@ -113,6 +113,8 @@ func TestHasPositionalArgs(t *testing.T) {
 		{name: "stdin dash", args: []string{"-"}, want: true},
 		{name: "flag then positional", args: []string{"--log-level", "debug", "file.md"}, want: true},
 		{name: "double dash", args: []string{"--", "file.md"}, want: true},
+		{name: "template flag with value", args: []string{"--template", "bug"}, want: false},
+		{name: "template=value", args: []string{"--template=bug"}, want: false},
 	}
 
 	for _, tt := range tests {
@@ -123,3 +125,45 @@ func TestHasPositionalArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "absent", args: []string{"--log-level", "debug"}, want: ""},
+		{name: "separate value", args: []string{"--template", "bug"}, want: "bug"},
+		{name: "equals value", args: []string{"--template=spike"}, want: "spike"},
+		{name: "after other flag", args: []string{"--log-level", "debug", "--template", "bug"}, want: "bug"},
+		{name: "after double dash", args: []string{"--", "--template", "bug"}, want: ""},
+		{name: "missing value", args: []string{"--template"}, wantErr: true},
+		{name: "flag as value", args: []string{"--template", "--log-level"}, wantErr: true},
+		{name: "empty equals", args: []string{"--template="}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateFlag(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TemplateFlag(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TemplateFlag(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinBody(t *testing.T) {
+	if got := joinBody("details", ""); got != "details" {
+		t.Errorf("no skeleton: got %q", got)
+	}
+	if got := joinBody("", "## Expected"); got != "## Expected" {
+		t.Errorf("no description: got %q", got)
+	}
+	if got := joinBody("details", "## Expected"); got != "details\n\n## Expected" {
+		t.Errorf("both: got %q", got)
+	}
+}
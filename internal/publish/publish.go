@@ -0,0 +1,575 @@
+// Package publish renders a workspace to a static HTML site: every markdown
+// document, an index page per board/list view and a JSON search index. It
+// backs `tiki publish`.
+package publish
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/ruki"
+
+	"github.com/boolean-maybe/tiki/document"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/theme"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/view/markdown"
+	"github.com/boolean-maybe/tiki/workflow"
+	"github.com/boolean-maybe/tiki/workflow/value"
+)
+
+// siteMarker is written into every published site. Its presence is what
+// allows a later run to clear the directory; a non-empty directory without
+// it is never touched.
+const siteMarker = ".tiki-site"
+
+// site paths of the generated files
+const (
+	indexPage   = "index.html"
+	styleSheet  = "style.css"
+	searchIndex = "search-index.json"
+	docsDir     = "docs"
+	viewsDir    = "views"
+)
+
+// Options configures a publish run.
+type Options struct {
+	Root      string          // workspace root scanned for markdown documents
+	OutDir    string          // output directory; created if missing
+	Title     string          // site title; defaults to the root directory name
+	Store     store.ReadStore // loaded tikis, used for titles, fields and lane filters
+	Views     []plugin.Plugin // workflow views; board and list views get an index page
+	Schema    ruki.Schema     // schema the lane filters were parsed against
+	User      func() string   // current user for user() in lane filters; may be nil
+	Theme     *theme.Theme    // active theme
+	Light     bool            // whether the active theme has a light background
+	CodeTheme string          // chroma style for code blocks
+}
+
+// Result summarizes a publish run.
+type Result struct {
+	Documents int // markdown documents rendered
+	Views     int // view index pages generated
+	Files     int // linked files (attachments, images) copied
+}
+
+// page is one document page of the site.
+type page struct {
+	Source string        // absolute path of the markdown file
+	Path   string        // slash-separated site path of the generated page
+	Title  string        // page title
+	Tiki   *tikipkg.Tiki // the managed document, nil for plain markdown
+}
+
+// searchEntry is one record of search-index.json.
+type searchEntry struct {
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	Text  string `json:"text"`
+}
+
+// publisher holds the state of one run.
+type publisher struct {
+	opts   Options
+	root   string
+	out    string
+	style  *siteStyle
+	md     *markdownRenderer
+	pages  []*page
+	bySrc  map[string]*page
+	byID   map[string]*page
+	copies map[string]string // absolute source file → site path
+	nav    []navLink
+	index  []searchEntry
+}
+
+// Publish renders the workspace described by opts into opts.OutDir.
+func Publish(opts Options) (Result, error) {
+	if opts.Store == nil {
+		return Result{}, errors.New("publish: no store")
+	}
+	if opts.Theme == nil {
+		return Result{}, errors.New("publish: no theme")
+	}
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return Result{}, fmt.Errorf("resolving workspace root: %w", err)
+	}
+	out, err := filepath.Abs(opts.OutDir)
+	if err != nil {
+		return Result{}, fmt.Errorf("resolving output directory: %w", err)
+	}
+	if opts.Title == "" {
+		opts.Title = filepath.Base(root)
+	}
+	if err := prepareOutDir(out, root); err != nil {
+		return Result{}, err
+	}
+
+	p := &publisher{
+		opts:   opts,
+		root:   root,
+		out:    out,
+		style:  newSiteStyle(opts.Theme, opts.Light, opts.CodeTheme),
+		bySrc:  map[string]*page{},
+		byID:   map[string]*page{},
+		copies: map[string]string{},
+	}
+	p.md = newMarkdownRenderer(p.style.code, p.linkTarget)
+
+	if err := p.collectPages(); err != nil {
+		return Result{}, err
+	}
+	views := p.collectViews()
+
+	for _, pg := range p.pages {
+		if err := p.writeDocument(pg); err != nil {
+			return Result{}, err
+		}
+	}
+	for _, v := range views {
+		if err := p.writeView(v); err != nil {
+			return Result{}, err
+		}
+	}
+	if err := p.writeIndex(views); err != nil {
+		return Result{}, err
+	}
+	if err := p.writeAssets(); err != nil {
+		return Result{}, err
+	}
+	return Result{Documents: len(p.pages), Views: len(views), Files: len(p.copies)}, nil
+}
+
+// prepareOutDir creates out, or empties it when it holds a previously
+// published site. Anything else already in the directory is left alone and
+// reported as an error so a typo in --out cannot wipe unrelated files.
+func prepareOutDir(out, root string) error {
+	if out == root || isWithin(root, out) {
+		return fmt.Errorf("output directory %s must not contain the workspace", out)
+	}
+	entries, err := os.ReadDir(out)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("reading output directory: %w", err)
+	case len(entries) > 0:
+		if _, err := os.Stat(filepath.Join(out, siteMarker)); err != nil {
+			return fmt.Errorf("output directory %s is not empty and was not created by tiki publish", out)
+		}
+		for _, e := range entries {
+			if err := os.RemoveAll(filepath.Join(out, e.Name())); err != nil {
+				return fmt.Errorf("clearing output directory: %w", err)
+			}
+		}
+	}
+	//nolint:gosec // G301: published sites are meant to be world-readable
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	return nil
+}
+
+// isWithin reports whether path lies strictly inside dir.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// collectPages maps every markdown document under the root to its site
+// path. Managed documents keep their store identity; the rest are plain
+// wiki pages titled like the wiki view titles them.
+func (p *publisher) collectPages() error {
+	paths, err := document.WalkDocuments(p.root)
+	if err != nil {
+		return fmt.Errorf("scanning documents: %w", err)
+	}
+	tikisByPath := map[string]*tikipkg.Tiki{}
+	for _, tk := range p.opts.Store.GetAllTikis() {
+		src := tk.Path()
+		if src == "" {
+			src = p.opts.Store.PathForID(tk.ID())
+		}
+		if abs, err := filepath.Abs(src); err == nil && src != "" {
+			tikisByPath[abs] = tk
+		}
+	}
+	for _, src := range paths {
+		abs, err := filepath.Abs(src)
+		if err != nil {
+			return err
+		}
+		if isWithin(abs, p.out) {
+			continue
+		}
+		rel, err := filepath.Rel(p.root, abs)
+		if err != nil {
+			return err
+		}
+		pg := &page{
+			Source: abs,
+			Path:   path.Join(docsDir, strings.TrimSuffix(filepath.ToSlash(rel), filepath.Ext(rel))+".html"),
+			Tiki:   tikisByPath[abs],
+		}
+		if pg.Tiki != nil {
+			pg.Title = pg.Tiki.Title()
+			if pg.Title == "" {
+				pg.Title = pg.Tiki.ID()
+			}
+			p.byID[pg.Tiki.ID()] = pg
+		}
+		p.pages = append(p.pages, pg)
+		p.bySrc[abs] = pg
+	}
+	return nil
+}
+
+// linkTarget rewrites a link found on pg: bare document ids (including the
+// `[title](ID)` links produced from `[[ID]]`) and relative `.md` links point
+// at the generated pages, and other relative files are copied into the site
+// so attachments and images keep working. Anything else is left as written.
+func (p *publisher) linkTarget(pg *page, dest string) (string, bool) {
+	if isExternal(dest) {
+		return "", false
+	}
+	target, suffix := splitDest(dest)
+	if id, ok := bareID(target); ok {
+		if to, ok := p.byID[id]; ok {
+			return relHref(pg.Path, to.Path) + suffix, true
+		}
+	}
+	if pg.Source == "" {
+		return "", false
+	}
+	unescaped, err := url.PathUnescape(target)
+	if err != nil {
+		return "", false
+	}
+	abs := filepath.Join(filepath.Dir(pg.Source), filepath.FromSlash(unescaped))
+	if !isWithin(abs, p.root) {
+		return "", false
+	}
+	if strings.EqualFold(filepath.Ext(abs), ".md") {
+		to, ok := p.bySrc[abs]
+		if !ok {
+			return "", false
+		}
+		return relHref(pg.Path, to.Path) + suffix, true
+	}
+	info, err := os.Stat(abs)
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+	rel, err := filepath.Rel(p.root, abs)
+	if err != nil {
+		return "", false
+	}
+	sitePath := path.Join(docsDir, filepath.ToSlash(rel))
+	p.copies[abs] = sitePath
+	return relHref(pg.Path, sitePath) + suffix, true
+}
+
+// fieldRow is one row of a document's field table. Reference fields list
+// their targets as Refs so they link to the referenced pages.
+type fieldRow struct {
+	Caption string
+	Value   string
+	Refs    []card
+}
+
+// writeDocument renders one markdown document. Wikilinks are resolved
+// through the same store resolver the wiki view uses; managed documents get
+// a field table and their attachments section like the detail view.
+func (p *publisher) writeDocument(pg *page) error {
+	//nolint:gosec // G304: pg.Source comes from the workspace walk
+	raw, err := os.ReadFile(pg.Source)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", pg.Source, err)
+	}
+	var body string
+	var fields []fieldRow
+	var id string
+	if tk := pg.Tiki; tk != nil {
+		id = tk.ID()
+		body = tk.Body()
+		fields = p.documentFields(pg, tk)
+		if atts, _, _ := tk.StringSliceField(service.AttachmentsField); len(atts) > 0 {
+			body = strings.TrimRight(body, "\n") + "\n\n" + service.AttachmentsMarkdown(atts) + "\n"
+		}
+	} else {
+		parsed, _ := document.ParseFrontmatter(string(raw))
+		body = parsed.Body
+		pg.Title = plainTitle(parsed, body, pg.Source)
+	}
+
+	resolver := &markdown.StoreResolver{Store: p.opts.Store}
+	html, mermaid, err := p.md.render(pg, markdown.RewriteWikilinks(body, resolver))
+	if err != nil {
+		return fmt.Errorf("rendering %s: %w", pg.Source, err)
+	}
+
+	var content bytes.Buffer
+	err = documentTemplate.Execute(&content, struct {
+		ID     string
+		Title  string
+		Fields []fieldRow
+		Body   template.HTML
+	}{id, pg.Title, fields, template.HTML(html)}) //nolint:gosec // G203: goldmark escapes raw HTML in documents
+	if err != nil {
+		return err
+	}
+	p.index = append(p.index, searchEntry{ID: id, Title: pg.Title, URL: pg.Path, Text: plainText(html)})
+
+	data := p.layout(pg.Path, pg.Title, content.String())
+	if mermaid {
+		data.Mermaid = p.style.mermaidTheme()
+	}
+	return p.writePage(pg.Path, data)
+}
+
+// documentFields lists the workflow and derived fields set on tk, in
+// registry order, formatted for display.
+func (p *publisher) documentFields(pg *page, tk *tikipkg.Tiki) []fieldRow {
+	doc := tikipkg.Doc{T: tk}
+	var rows []fieldRow
+	for _, fd := range workflow.Fields() {
+		if !fd.Custom && !fd.Derived {
+			continue
+		}
+		val, ok := doc.Get(fd.Name)
+		if !ok {
+			continue
+		}
+		if fd.Type == workflow.TypeRef || fd.Type == workflow.TypeListRef {
+			if refs := p.refCards(pg, val); len(refs) > 0 {
+				rows = append(rows, fieldRow{Caption: fd.DisplayCaption(), Refs: refs})
+			}
+			continue
+		}
+		if u, isURL := val.(string); isURL && fd.Type == workflow.TypeURL && u != "" {
+			rows = append(rows, fieldRow{Caption: fd.DisplayCaption(), Refs: []card{{Title: u, Href: u}}})
+			continue
+		}
+		if s := formatValue(fd, val); s != "" {
+			rows = append(rows, fieldRow{Caption: fd.DisplayCaption(), Value: s})
+		}
+	}
+	return rows
+}
+
+// refCards turns a reference field value into links to the referenced
+// documents; unknown ids are listed without a link.
+func (p *publisher) refCards(pg *page, val interface{}) []card {
+	var ids []string
+	switch v := val.(type) {
+	case string:
+		if v != "" {
+			ids = []string{v}
+		}
+	case []string:
+		ids = v
+	}
+	refs := make([]card, 0, len(ids))
+	for _, id := range ids {
+		c := card{ID: id, Title: id}
+		if to, ok := p.byID[strings.ToUpper(id)]; ok {
+			c.Title = to.Title
+			c.Href = relHref(pg.Path, to.Path)
+		}
+		refs = append(refs, c)
+	}
+	return refs
+}
+
+// formatValue renders a field value as plain text.
+func formatValue(fd workflow.FieldDef, val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		switch fd.Type {
+		case workflow.TypeEnum:
+			return fd.EnumLabel(v)
+		case workflow.TypeNumber:
+			if f, ok := value.NumberOf(v); ok {
+				return value.FormatNumberUnit(f, fd.Unit)
+			}
+		}
+		return v
+	case float64:
+		return value.FormatNumberUnit(v, fd.Unit)
+	case []string:
+		if fd.Type == workflow.TypeEnumList {
+			labels := make([]string, len(v))
+			for i, k := range v {
+				labels[i] = fd.EnumLabel(k)
+			}
+			return strings.Join(labels, ", ")
+		}
+		return strings.Join(v, ", ")
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		if fd.Type == workflow.TypeDate {
+			return v.Format("2006-01-02")
+		}
+		return v.Format("2006-01-02 15:04")
+	case time.Duration:
+		return value.FormatDuration(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// plainTitle titles an id-less page the way the wiki view does: frontmatter
+// title, then the first H1, then the file name.
+func plainTitle(parsed document.ParsedFrontmatter, body, src string) string {
+	if t, ok := parsed.Map["title"].(string); ok && strings.TrimSpace(t) != "" {
+		return strings.TrimSpace(t)
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
+}
+
+// layout returns the page chrome for the page at sitePath.
+func (p *publisher) layout(sitePath, title, content string) layoutData {
+	return layoutData{
+		SiteTitle: p.opts.Title,
+		Title:     title,
+		Root:      relHref(sitePath, ""),
+		Nav:       p.nav,
+		Content:   template.HTML(content), //nolint:gosec // G203: built from escaped templates
+	}
+}
+
+// writePage renders data through the layout template to sitePath.
+func (p *publisher) writePage(sitePath string, data layoutData) error {
+	var buf bytes.Buffer
+	if err := layoutTemplate.Execute(&buf, data); err != nil {
+		return err
+	}
+	return p.writeFile(sitePath, buf.Bytes())
+}
+
+// writeFile writes content to sitePath below the output directory.
+func (p *publisher) writeFile(sitePath string, content []byte) error {
+	dst := filepath.Join(p.out, filepath.FromSlash(sitePath))
+	//nolint:gosec // G301: published sites are meant to be world-readable
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	//nolint:gosec // G306: published sites are meant to be world-readable
+	return os.WriteFile(dst, content, 0644)
+}
+
+// writeIndex writes the home page, the search index, the stylesheet and the
+// marker files.
+func (p *publisher) writeIndex(views []*viewPage) error {
+	type docLink struct{ ID, Title, Href string }
+	docs := make([]docLink, 0, len(p.pages))
+	for _, pg := range p.pages {
+		var id string
+		if pg.Tiki != nil {
+			id = pg.Tiki.ID()
+		}
+		docs = append(docs, docLink{ID: id, Title: pg.Title, Href: pg.Path})
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		return strings.ToLower(docs[i].Title) < strings.ToLower(docs[j].Title)
+	})
+	type viewLink struct{ Label, Description, Href string }
+	links := make([]viewLink, 0, len(views))
+	for _, v := range views {
+		links = append(links, viewLink{Label: v.Title, Description: v.Description, Href: v.Path})
+	}
+
+	var content bytes.Buffer
+	err := indexTemplate.Execute(&content, struct {
+		Title string
+		Views []viewLink
+		Docs  []docLink
+	}{p.opts.Title, links, docs})
+	if err != nil {
+		return err
+	}
+	data := p.layout(indexPage, "", content.String())
+	data.Search = true
+	if err := p.writePage(indexPage, data); err != nil {
+		return err
+	}
+
+	sort.SliceStable(p.index, func(i, j int) bool { return p.index[i].URL < p.index[j].URL })
+	idx, err := json.MarshalIndent(p.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := p.writeFile(searchIndex, idx); err != nil {
+		return err
+	}
+	css, err := p.style.css()
+	if err != nil {
+		return fmt.Errorf("rendering stylesheet: %w", err)
+	}
+	if err := p.writeFile(styleSheet, []byte(css)); err != nil {
+		return err
+	}
+	// GitHub Pages runs Jekyll unless told otherwise, which would hide the
+	// dot-prefixed attachment folders.
+	if err := p.writeFile(".nojekyll", nil); err != nil {
+		return err
+	}
+	return p.writeFile(siteMarker, nil)
+}
+
+// writeAssets copies every local file linked from a document.
+func (p *publisher) writeAssets() error {
+	for src, sitePath := range p.copies {
+		if err := p.copyFile(src, sitePath); err != nil {
+			return fmt.Errorf("copying %s: %w", src, err)
+		}
+	}
+	return nil
+}
+
+func (p *publisher) copyFile(src, sitePath string) error {
+	dst := filepath.Join(p.out, filepath.FromSlash(sitePath))
+	//nolint:gosec // G301: published sites are meant to be world-readable
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	//nolint:gosec // G304: src was linked from a workspace document
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	//nolint:gosec // G304: dst is below the output directory
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
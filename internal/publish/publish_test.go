@@ -0,0 +1,226 @@
+package publish
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/ruki"
+
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/theme"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// writeDoc writes a markdown file below root and returns its path.
+func writeDoc(t *testing.T, root, rel, content string) string {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// publishFixture builds a small workspace: two tikis (one in a subfolder,
+// with an attachment-style image), a plain wiki page linking to them, and a
+// board view with a filtered and an unfiltered lane.
+func publishFixture(t *testing.T) (Options, string) {
+	t.Helper()
+	root := t.TempDir()
+	s := store.NewInMemoryStore()
+
+	add := func(id, title, status, rel, body string) {
+		tk := tikipkg.New()
+		tk.SetID(id)
+		tk.SetTitle(title)
+		tk.SetBody(body)
+		tk.Set("status", status)
+		tk.SetPath(writeDoc(t, root, rel, "---\nid: "+id+"\n---\n"+body))
+		if err := s.CreateTiki(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("AAA001", "Login page", "ready", "AAA001.md", "Needs ![shot](.assets/AAA001/shot.png)\n")
+	add("BBB001", "Schema", "done", "backend/BBB001.md", "Blocks [[AAA001]].\n")
+	writeDoc(t, root, ".assets/AAA001/shot.png", "png")
+	writeDoc(t, root, "guide.md", "# Guide\n\nStart at [[BBB001]], see [login](AAA001.md#top) and [[ZZZ999]].\n\n"+
+		"```mermaid\ngraph TD; A-->B\n```\n\n```go\nfunc main() {}\n```\n")
+
+	schema := rukiRuntime.NewSchema()
+	filter, err := ruki.NewParser(schema).ParseAndValidateStatement(`select where status = "ready"`, ruki.ExecutorRuntimePlugin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := &plugin.WorkflowPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Team Board", Kind: plugin.KindBoard},
+		Lanes: []plugin.TikiLane{
+			{Name: "Ready", Filter: filter},
+			{Name: "All"},
+		},
+	}
+	wiki := &plugin.WikiPlugin{BasePlugin: plugin.BasePlugin{Name: "Docs", Kind: plugin.KindWiki}, DocumentPath: "guide.md"}
+
+	return Options{
+		Root:      root,
+		OutDir:    filepath.Join(root, "site"),
+		Title:     "Team",
+		Store:     s,
+		Views:     []plugin.Plugin{board, wiki},
+		Schema:    schema,
+		Theme:     theme.LoadByName("dark"),
+		CodeTheme: "nord",
+	}, root
+}
+
+func readSite(t *testing.T, opts Options, rel string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(opts.OutDir, filepath.FromSlash(rel)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestPublish_RendersDocumentsViewsAndIndex(t *testing.T) {
+	opts, _ := publishFixture(t)
+	res, err := Publish(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Documents != 3 || res.Views != 1 || res.Files != 1 {
+		t.Fatalf("result = %+v, want 3 documents, 1 view, 1 file", res)
+	}
+
+	guide := readSite(t, opts, "docs/guide.html")
+	for _, want := range []string{
+		`<a href="backend/BBB001.html">Schema</a>`,
+		`<a href="AAA001.html#top">login</a>`,
+		`[[ZZZ999]] <em>(not found)</em>`,
+		`<pre class="mermaid">graph TD; A--&gt;B`,
+		`mermaid.initialize({ startOnLoad: true, theme: "dark" })`,
+		`<pre class="chroma">`,
+		`href="../style.css"`,
+	} {
+		if !strings.Contains(guide, want) {
+			t.Errorf("guide page missing %q:\n%s", want, guide)
+		}
+	}
+
+	schema := readSite(t, opts, "docs/backend/BBB001.html")
+	for _, want := range []string{
+		`<h1>Schema</h1>`,
+		`<p class="id">BBB001</p>`,
+		`<tr><th>status</th><td>Done</td></tr>`,
+		`<a href="../AAA001.html">Login page</a>`,
+		`href="../../style.css"`,
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("tiki page missing %q:\n%s", want, schema)
+		}
+	}
+	if strings.Contains(schema, "mermaid.initialize") {
+		t.Error("mermaid script loaded on a page without diagrams")
+	}
+
+	login := readSite(t, opts, "docs/AAA001.html")
+	if !strings.Contains(login, `<img src=".assets/AAA001/shot.png" alt="shot">`) {
+		t.Errorf("image link not preserved:\n%s", login)
+	}
+	if got := readSite(t, opts, "docs/.assets/AAA001/shot.png"); got != "png" {
+		t.Errorf("copied attachment = %q", got)
+	}
+
+	board := readSite(t, opts, "views/team-board.html")
+	ready := board[strings.Index(board, "<h2>Ready"):strings.Index(board, "<h2>All")]
+	if !strings.Contains(ready, "AAA001") || strings.Contains(ready, "BBB001") {
+		t.Errorf("Ready lane not filtered:\n%s", ready)
+	}
+	all := board[strings.Index(board, "<h2>All"):]
+	if strings.Index(all, "AAA001") > strings.Index(all, "BBB001") {
+		t.Errorf("unfiltered lane not sorted by title:\n%s", all)
+	}
+	if !strings.Contains(board, `<a href="../docs/backend/BBB001.html">Schema</a>`) {
+		t.Errorf("card link missing:\n%s", board)
+	}
+
+	index := readSite(t, opts, "index.html")
+	for _, want := range []string{`<a href="views/team-board.html">Team Board</a>`, `<a href="docs/guide.html">Docs</a>`, `id="search"`} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index missing %q:\n%s", want, index)
+		}
+	}
+
+	var entries []searchEntry
+	if err := json.Unmarshal([]byte(readSite(t, opts, "search-index.json")), &entries); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, e := range entries {
+		if e.URL == "docs/guide.html" {
+			found = strings.Contains(e.Text, "Start at Schema") && e.Title == "Guide"
+		}
+	}
+	if !found {
+		t.Errorf("search index lacks the guide text: %+v", entries)
+	}
+
+	css := readSite(t, opts, "style.css")
+	if !strings.Contains(css, "--id: "+theme.LoadByName("dark").TikiID().Hex()) || !strings.Contains(css, ".chroma") {
+		t.Errorf("stylesheet lacks theme or code colors:\n%s", css)
+	}
+}
+
+func TestPublish_ReplacesPreviousSiteOnly(t *testing.T) {
+	opts, root := publishFixture(t)
+	if _, err := Publish(opts); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(opts.OutDir, "docs", "stale.html")
+	if err := os.WriteFile(stale, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Publish(opts); err != nil {
+		t.Fatalf("republish: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale page survived republishing")
+	}
+
+	foreign := filepath.Join(t.TempDir(), "out")
+	writeDoc(t, foreign, "keep.txt", "mine")
+	opts.OutDir = foreign
+	if _, err := Publish(opts); err == nil {
+		t.Error("expected error for a non-empty directory without a site marker")
+	}
+	if _, err := os.Stat(filepath.Join(foreign, "keep.txt")); err != nil {
+		t.Error("foreign file was removed")
+	}
+
+	opts.OutDir = root
+	if _, err := Publish(opts); err == nil {
+		t.Error("expected error when the output directory is the workspace")
+	}
+}
+
+func TestRelHref(t *testing.T) {
+	tests := []struct{ from, to, want string }{
+		{"index.html", "docs/a.html", "docs/a.html"},
+		{"docs/a.html", "docs/b.html", "b.html"},
+		{"docs/x/a.html", "docs/b.html", "../b.html"},
+		{"views/v.html", "docs/x/a.html", "../docs/x/a.html"},
+		{"docs/x/a.html", "", "../../"},
+	}
+	for _, tt := range tests {
+		if got := relHref(tt.from, tt.to); got != tt.want {
+			t.Errorf("relHref(%q, %q) = %q, want %q", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package publish
+
+import (
+	"bytes"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/boolean-maybe/ruki/idfmt"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// pageKey carries the page being rendered through goldmark's parser context
+// so the link transformer can compute relative hrefs.
+var pageKey = parser.NewContextKey()
+
+// linkTarget maps a markdown link destination, as written in the source
+// document, to the href it should have on the published site. ok=false
+// leaves the destination untouched.
+type linkTarget func(page *page, dest string) (href string, ok bool)
+
+// renderPage is the per-call state of one markdown conversion.
+type renderPage struct {
+	page    *page
+	resolve linkTarget
+}
+
+// markdownRenderer converts document bodies to HTML. Links are rewritten
+// through resolve, fenced code is highlighted with chroma and `mermaid`
+// fences are emitted as `<pre class="mermaid">` for mermaid.js.
+type markdownRenderer struct {
+	md      goldmark.Markdown
+	resolve linkTarget
+}
+
+func newMarkdownRenderer(style *chroma.Style, resolve linkTarget) *markdownRenderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+			parser.WithASTTransformers(util.Prioritized(&linkTransformer{}, 100)),
+		),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&codeRenderer{style: style}, 100)),
+		),
+	)
+	return &markdownRenderer{md: md, resolve: resolve}
+}
+
+// render converts source to HTML for p and reports whether it contains
+// mermaid diagrams.
+func (r *markdownRenderer) render(p *page, source string) (string, bool, error) {
+	state := &renderPage{page: p, resolve: r.resolve}
+	ctx := parser.NewContext()
+	ctx.Set(pageKey, state)
+	var buf bytes.Buffer
+	src := []byte(source)
+	doc := r.md.Parser().Parse(text.NewReader(src), parser.WithContext(ctx))
+	if err := r.md.Renderer().Render(&buf, src, doc); err != nil {
+		return "", false, err
+	}
+	return buf.String(), hasMermaid(doc, src), nil
+}
+
+// hasMermaid reports whether the document contains a mermaid fence.
+func hasMermaid(doc ast.Node, source []byte) bool {
+	found := false
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if fcb, ok := n.(*ast.FencedCodeBlock); ok && entering && string(fcb.Language(source)) == "mermaid" {
+			found = true
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return found
+}
+
+// linkTransformer rewrites link and image destinations through the page's
+// linkTarget after parsing.
+type linkTransformer struct{}
+
+func (t *linkTransformer) Transform(doc *ast.Document, _ text.Reader, pc parser.Context) {
+	state, ok := pc.Get(pageKey).(*renderPage)
+	if !ok || state.resolve == nil {
+		return
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Link:
+			if href, ok := state.resolve(state.page, string(node.Destination)); ok {
+				node.Destination = []byte(href)
+			}
+		case *ast.Image:
+			if href, ok := state.resolve(state.page, string(node.Destination)); ok {
+				node.Destination = []byte(href)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// codeRenderer renders fenced and indented code blocks. Mermaid fences are
+// passed through for client-side rendering; everything else is highlighted
+// with chroma using CSS classes (see siteCSS).
+type codeRenderer struct {
+	style *chroma.Style
+}
+
+func (r *codeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFenced)
+	reg.Register(ast.KindCodeBlock, r.renderIndented)
+}
+
+func (r *codeRenderer) renderFenced(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	fcb := n.(*ast.FencedCodeBlock)
+	lang := string(fcb.Language(source))
+	code := blockText(source, n)
+	if lang == "mermaid" {
+		_, _ = w.WriteString(`<pre class="mermaid">`)
+		_, _ = w.Write(util.EscapeHTML([]byte(code)))
+		_, _ = w.WriteString("</pre>\n")
+		return ast.WalkSkipChildren, nil
+	}
+	r.writeCode(w, lang, code)
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *codeRenderer) renderIndented(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	r.writeCode(w, "", blockText(source, n))
+	return ast.WalkSkipChildren, nil
+}
+
+// writeCode highlights code when a lexer for lang exists and falls back to
+// an escaped plain block otherwise.
+func (r *codeRenderer) writeCode(w util.BufWriter, lang, code string) {
+	if lexer := lexers.Get(lang); lang != "" && lexer != nil {
+		iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+		if err == nil {
+			if err := codeFormatter().Format(w, r.style, iterator); err == nil {
+				return
+			}
+		}
+	}
+	_, _ = w.WriteString("<pre><code>")
+	_, _ = w.Write(util.EscapeHTML([]byte(code)))
+	_, _ = w.WriteString("</code></pre>\n")
+}
+
+// codeFormatter is the chroma formatter shared by code blocks and the
+// stylesheet, so class names always match.
+func codeFormatter() *chromahtml.Formatter {
+	return chromahtml.New(chromahtml.WithClasses(true))
+}
+
+// blockText returns the raw text of a code block node.
+func blockText(source []byte, n ast.Node) string {
+	var b strings.Builder
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		b.Write(seg.Value(source))
+	}
+	return b.String()
+}
+
+// splitDest separates a link destination into its path and the "#fragment"
+// or "?query" suffix, which is carried over unchanged.
+func splitDest(dest string) (string, string) {
+	if i := strings.IndexAny(dest, "#?"); i >= 0 {
+		return dest[:i], dest[i:]
+	}
+	return dest, ""
+}
+
+// isExternal reports whether dest points outside the site: absolute URLs,
+// mailto links, protocol-relative and root-relative paths, and bare anchors.
+func isExternal(dest string) bool {
+	if dest == "" || strings.HasPrefix(dest, "#") || strings.HasPrefix(dest, "/") {
+		return true
+	}
+	u, err := url.Parse(dest)
+	return err != nil || u.Scheme != ""
+}
+
+// bareID returns the document id a link destination names, when it is a
+// bare id such as the `[title](ID)` links produced from `[[ID]]`.
+func bareID(dest string) (string, bool) {
+	id := strings.ToUpper(dest)
+	if !idfmt.IsValidID(id) {
+		return "", false
+	}
+	return id, true
+}
+
+// relHref returns the href that leads from the page at from to the site
+// path to; both are slash-separated and relative to the site root.
+func relHref(from, to string) string {
+	fromParts := strings.Split(path.Dir(from), "/")
+	if fromParts[0] == "." {
+		fromParts = nil
+	}
+	toParts := strings.Split(to, "/")
+	common := 0
+	for common < len(fromParts) && common < len(toParts)-1 && fromParts[common] == toParts[common] {
+		common++
+	}
+	up := strings.Repeat("../", len(fromParts)-common)
+	return up + strings.Join(toParts[common:], "/")
+}
+
+var (
+	tagPattern   = regexp.MustCompile(`<[^>]*>`)
+	spacePattern = regexp.MustCompile(`\s+`)
+)
+
+// plainText reduces rendered HTML to the whitespace-normalized text the
+// search index matches against.
+func plainText(rendered string) string {
+	text := html.UnescapeString(tagPattern.ReplaceAllString(rendered, " "))
+	return strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+}
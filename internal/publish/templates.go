@@ -0,0 +1,111 @@
+package publish
+
+import "html/template"
+
+// layoutData is what every published page is rendered with.
+type layoutData struct {
+	SiteTitle string
+	Title     string
+	Root      string // relative path from the page back to the site root ("" or "../…")
+	Nav       []navLink
+	Wide      bool
+	Content   template.HTML
+	Mermaid   string // mermaid.js theme; empty when the page has no diagrams
+	Search    bool
+}
+
+// navLink is one entry of the header navigation.
+type navLink struct {
+	Label string
+	Href  string // relative to the site root
+}
+
+// mermaidScript is loaded only on pages that contain diagrams.
+const mermaidScript = "https://cdn.jsdelivr.net/npm/mermaid@11/dist/mermaid.esm.min.mjs"
+
+var layoutTemplate = template.Must(template.New("layout").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{if .Title}}{{.Title}} · {{end}}{{.SiteTitle}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<header class="site">
+<a class="home" href="{{.Root}}index.html">{{.SiteTitle}}</a>
+{{range .Nav}}<a href="{{$.Root}}{{.Href}}">{{.Label}}</a>
+{{end}}</header>
+<main{{if .Wide}} class="wide"{{end}}>
+{{.Content}}
+</main>
+{{if .Mermaid}}<script type="module">
+import mermaid from "` + mermaidScript + `";
+mermaid.initialize({ startOnLoad: true, theme: "{{.Mermaid}}" });
+</script>
+{{end}}{{if .Search}}<script>
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  var index = [];
+  fetch("search-index.json").then(function (r) { return r.json(); }).then(function (data) { index = data; });
+  input.addEventListener("input", function () {
+    var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+    results.innerHTML = "";
+    if (!terms.length) { return; }
+    index.filter(function (e) {
+      var hay = (e.id + " " + e.title + " " + e.text).toLowerCase();
+      return terms.every(function (t) { return hay.indexOf(t) >= 0; });
+    }).slice(0, 50).forEach(function (e) {
+      var li = document.createElement("li");
+      var a = document.createElement("a");
+      a.href = e.url;
+      a.textContent = e.title;
+      if (e.id) {
+        var id = document.createElement("span");
+        id.className = "id";
+        id.textContent = e.id + " ";
+        li.appendChild(id);
+      }
+      li.appendChild(a);
+      results.appendChild(li);
+    });
+  });
+})();
+</script>
+{{end}}</body>
+</html>
+`))
+
+var indexTemplate = template.Must(template.New("index").Parse(`<h1>{{.Title}}</h1>
+<input id="search" type="search" placeholder="Search documents" autocomplete="off">
+<ul id="results"></ul>
+{{if .Views}}<h2>Views</h2>
+<ul class="docs">
+{{range .Views}}<li><a href="{{.Href}}">{{.Label}}</a>{{if .Description}} <span class="muted">— {{.Description}}</span>{{end}}</li>
+{{end}}</ul>
+{{end}}<h2>Documents</h2>
+<ul class="docs">
+{{range .Docs}}<li>{{if .ID}}<span class="id">{{.ID}}</span> {{end}}<a href="{{.Href}}">{{.Title}}</a></li>
+{{end}}</ul>
+`))
+
+var documentTemplate = template.Must(template.New("document").Parse(`{{if .ID}}<h1>{{.Title}}</h1>
+<p class="id">{{.ID}}</p>
+{{end}}{{if .Fields}}<table class="meta">
+{{range .Fields}}<tr><th>{{.Caption}}</th><td>{{if .Refs}}{{range $i, $r := .Refs}}{{if $i}}, {{end}}{{if $r.Href}}<a href="{{$r.Href}}">{{$r.Title}}</a>{{else}}<span class="id">{{$r.ID}}</span>{{end}}{{end}}{{else}}{{.Value}}{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{.Body}}
+`))
+
+var viewTemplate = template.Must(template.New("view").Parse(`<h1>{{.Title}}</h1>
+{{if .Description}}<p class="muted">{{.Description}}</p>
+{{end}}<div class="{{.Layout}}">
+{{range .Lanes}}<section class="{{.Class}}">
+<h2>{{.Name}} <span class="muted">{{len .Cards}}</span></h2>
+<ul>
+{{range .Cards}}<li class="card"><span class="id">{{.ID}}</span> {{if .Href}}<a href="{{.Href}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</li>
+{{end}}</ul>
+</section>
+{{end}}</div>
+`))
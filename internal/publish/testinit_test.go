@@ -0,0 +1,7 @@
+package publish
+
+import "github.com/boolean-maybe/tiki/internal/teststatuses"
+
+func init() {
+	teststatuses.Init()
+}
@@ -0,0 +1,155 @@
+package publish
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+
+	"github.com/boolean-maybe/tiki/theme"
+)
+
+// fallback page backgrounds for themes whose canvas is the terminal default
+// and whose syntax style declares no background either.
+const (
+	darkCanvas  = "#1e1e1e"
+	lightCanvas = "#ffffff"
+)
+
+// siteStyle is the resolved color set of the published site: the active
+// tiki theme's roles plus the chroma style used for code blocks.
+type siteStyle struct {
+	th    *theme.Theme
+	light bool
+	code  *chroma.Style
+}
+
+func newSiteStyle(th *theme.Theme, light bool, codeTheme string) *siteStyle {
+	return &siteStyle{th: th, light: light, code: styles.Get(codeTheme)}
+}
+
+// canvas returns the page background. Terminal themes leave the canvas to
+// the terminal, so the code style's background stands in for it.
+func (s *siteStyle) canvas() string {
+	if c := s.th.SurfaceCanvas(); !c.IsDefault() {
+		return c.Hex()
+	}
+	if bg := s.code.Get(chroma.Background).Background; bg.IsSet() {
+		return bg.String()
+	}
+	if s.light {
+		return lightCanvas
+	}
+	return darkCanvas
+}
+
+// hex returns the role's color, or fallback when the role defers to the
+// terminal default.
+func hex(r theme.Role, fallback string) string {
+	if r == nil || r.IsDefault() {
+		return fallback
+	}
+	return r.Hex()
+}
+
+// css renders the site stylesheet: theme colors as CSS variables, page
+// layout, board lanes and the chroma classes for highlighted code.
+func (s *siteStyle) css() (string, error) {
+	th := s.th
+	bg := s.canvas()
+	fg := hex(th.TextPrimary(), "inherit")
+
+	var b strings.Builder
+	b.WriteString(":root {\n")
+	vars := [][2]string{
+		{"bg", bg},
+		{"fg", fg},
+		{"soft", hex(th.TextSecondary(), fg)},
+		{"muted", hex(th.TextMuted(), fg)},
+		{"label", hex(th.TextLabel(), fg)},
+		{"value", hex(th.TextValue(), fg)},
+		{"link", hex(th.AccentAction(), fg)},
+		{"tag", hex(th.AccentTag(), fg)},
+		{"highlight", hex(th.Highlight(), fg)},
+		{"border", hex(th.BorderIdle(), fg)},
+		{"focus", hex(th.BorderFocus(), fg)},
+		{"selection", hex(th.SurfaceSelection(), bg)},
+		{"id", hex(th.TikiID(), fg)},
+		{"ok", hex(th.StatusOk(), fg)},
+		{"warn", hex(th.StatusWarn(), fg)},
+		{"danger", hex(th.StatusDanger(), fg)},
+		{"bar-fg", hex(th.StatuslineMain().Fg(), fg)},
+		{"bar-bg", hex(th.StatuslineMain().Bg(), bg)},
+	}
+	for _, v := range vars {
+		fmt.Fprintf(&b, "  --%s: %s;\n", v[0], v[1])
+	}
+	captions := th.PluginCaptions()
+	for i := 0; i < captions.Len(); i++ {
+		pair := captions.At(i)
+		fmt.Fprintf(&b, "  --lane%d-fg: %s;\n  --lane%d-bg: %s;\n", i, hex(pair.Fg(), fg), i, hex(pair.Bg(), bg))
+	}
+	b.WriteString("}\n")
+	b.WriteString(baseCSS)
+	for i := 0; i < captions.Len(); i++ {
+		fmt.Fprintf(&b, ".lane-%d > h2 { color: var(--lane%d-fg); background: var(--lane%d-bg); }\n", i, i, i)
+	}
+
+	var code strings.Builder
+	if err := codeFormatter().WriteCSS(&code, s.code); err != nil {
+		return "", err
+	}
+	b.WriteString(code.String())
+	return b.String(), nil
+}
+
+// laneClass returns the caption class of the i-th lane, cycling through the
+// theme's caption pairs like the board header does.
+func (s *siteStyle) laneClass(i int) string {
+	n := s.th.PluginCaptions().Len()
+	if n == 0 {
+		return "lane"
+	}
+	return fmt.Sprintf("lane lane-%d", i%n)
+}
+
+// mermaidTheme returns the mermaid.js theme matching the site background.
+func (s *siteStyle) mermaidTheme() string {
+	if s.light {
+		return "default"
+	}
+	return "dark"
+}
+
+const baseCSS = `* { box-sizing: border-box; }
+body { margin: 0; background: var(--bg); color: var(--fg); font: 16px/1.55 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; }
+a { color: var(--link); text-decoration: none; }
+a:hover { text-decoration: underline; }
+header.site { display: flex; flex-wrap: wrap; gap: 0.25rem 1rem; align-items: baseline; padding: 0.6rem 1.5rem; background: var(--bar-bg); color: var(--bar-fg); }
+header.site a { color: var(--bar-fg); }
+header.site .home { font-weight: 700; margin-right: 1rem; }
+main { max-width: 60rem; margin: 0 auto; padding: 1rem 1.5rem 3rem; }
+main.wide { max-width: none; }
+h1, h2, h3, h4 { color: var(--label); line-height: 1.25; }
+hr { border: 0; border-top: 1px solid var(--border); }
+blockquote { margin: 0; padding: 0 1rem; border-left: 3px solid var(--border); color: var(--soft); }
+table { border-collapse: collapse; }
+th, td { border: 1px solid var(--border); padding: 0.3rem 0.6rem; text-align: left; }
+code { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.9em; }
+pre { padding: 0.8rem 1rem; overflow-x: auto; border: 1px solid var(--border); border-radius: 4px; }
+img { max-width: 100%; }
+pre.mermaid { background: transparent; border: 0; text-align: center; }
+.id { color: var(--id); font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+.muted { color: var(--muted); }
+table.meta th { color: var(--label); font-weight: 600; }
+table.meta td { color: var(--value); }
+.board { display: grid; grid-auto-flow: column; grid-auto-columns: minmax(14rem, 1fr); gap: 1rem; overflow-x: auto; }
+.list .lane { margin-bottom: 1.5rem; }
+.lane > h2 { margin: 0 0 0.5rem; padding: 0.3rem 0.6rem; border-radius: 4px; font-size: 1rem; }
+.lane ul { list-style: none; margin: 0; padding: 0; }
+.card { border: 1px solid var(--border); border-radius: 4px; padding: 0.5rem 0.6rem; margin-bottom: 0.5rem; }
+#search { width: 100%; padding: 0.5rem 0.7rem; font-size: 1rem; color: var(--fg); background: var(--bg); border: 1px solid var(--border); border-radius: 4px; }
+#search:focus { outline: none; border-color: var(--focus); }
+#results li, .docs li { margin: 0.2rem 0; }
+`
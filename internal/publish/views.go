@@ -0,0 +1,154 @@
+package publish
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/boolean-maybe/ruki"
+
+	"github.com/boolean-maybe/tiki/document"
+	"github.com/boolean-maybe/tiki/plugin"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// viewPage is the generated index page of one board or list view.
+type viewPage struct {
+	Title       string
+	Description string
+	Path        string
+	Layout      string // "board" lays lanes out side by side, "list" stacks them
+	Lanes       []laneSection
+}
+
+// laneSection is one lane of a view page.
+type laneSection struct {
+	Name  string
+	Class string
+	Cards []card
+}
+
+// card is one document listed in a lane.
+type card struct {
+	ID    string
+	Title string
+	Href  string
+}
+
+// collectViews evaluates the lane filters of every board and list view
+// against the store, the same way the board controller fills its lanes, and
+// builds the header navigation. Wiki views bound to a document link to that
+// document's page.
+func (p *publisher) collectViews() []*viewPage {
+	var views []*viewPage
+	used := map[string]bool{}
+	for _, pl := range p.opts.Views {
+		switch def := pl.(type) {
+		case *plugin.WorkflowPlugin:
+			if def.GetKind() != plugin.KindBoard && def.GetKind() != plugin.KindList {
+				continue
+			}
+			v := &viewPage{
+				Title:       def.GetLabel(),
+				Description: def.GetDescription(),
+				Path:        path.Join(viewsDir, uniqueSlug(def.GetName(), used)+".html"),
+				Layout:      string(def.GetKind()),
+			}
+			v.Lanes = p.laneSections(v.Path, def)
+			views = append(views, v)
+			p.nav = append(p.nav, navLink{Label: v.Title, Href: v.Path})
+		case *plugin.WikiPlugin:
+			if def.DocumentPath == "" {
+				continue
+			}
+			target := path.Join(docsDir, strings.TrimSuffix(path.Clean(def.DocumentPath), path.Ext(def.DocumentPath))+".html")
+			if !p.hasPage(target) {
+				continue
+			}
+			p.nav = append(p.nav, navLink{Label: def.GetLabel(), Href: target})
+		}
+	}
+	return views
+}
+
+// hasPage reports whether a document page is published at sitePath.
+func (p *publisher) hasPage(sitePath string) bool {
+	for _, pg := range p.pages {
+		if pg.Path == sitePath {
+			return true
+		}
+	}
+	return false
+}
+
+// laneSections fills the lanes of def. A lane whose filter fails is logged
+// and published empty rather than failing the whole site.
+func (p *publisher) laneSections(viewPath string, def *plugin.WorkflowPlugin) []laneSection {
+	all := p.opts.Store.GetAllTikis()
+	executor := ruki.NewExecutor(p.opts.Schema, ruki.DocumentFactory(tikipkg.NewDoc), p.opts.User,
+		ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimePlugin})
+	sections := make([]laneSection, 0, len(def.Lanes))
+	for i, lane := range def.Lanes {
+		var tikis []*tikipkg.Tiki
+		needsSort := true
+		if lane.Filter == nil {
+			tikis = append(tikis, all...)
+		} else {
+			result, err := executor.Execute(lane.Filter, tikipkg.WrapDocs(all))
+			if err != nil {
+				slog.Error("failed to execute lane filter", "view", def.GetName(), "lane", lane.Name, "error", err)
+			} else if result.Select != nil {
+				tikis = tikipkg.UnwrapDocs(result.Select.Tikis)
+				needsSort = !lane.Filter.HasOrderBy()
+			}
+		}
+		if needsSort {
+			sort.SliceStable(tikis, func(a, b int) bool {
+				ta, tb := strings.ToLower(tikis[a].Title()), strings.ToLower(tikis[b].Title())
+				if ta != tb {
+					return ta < tb
+				}
+				return tikis[a].ID() < tikis[b].ID()
+			})
+		}
+		section := laneSection{Name: lane.Name, Class: p.style.laneClass(i)}
+		for _, tk := range tikis {
+			c := card{ID: tk.ID(), Title: tk.Title()}
+			if pg, ok := p.byID[tk.ID()]; ok {
+				c.Href = relHref(viewPath, pg.Path)
+			}
+			section.Cards = append(section.Cards, c)
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// writeView renders a view index page.
+func (p *publisher) writeView(v *viewPage) error {
+	var content bytes.Buffer
+	if err := viewTemplate.Execute(&content, v); err != nil {
+		return fmt.Errorf("rendering view %s: %w", v.Title, err)
+	}
+	data := p.layout(v.Path, v.Title, content.String())
+	data.Wide = v.Layout == string(plugin.KindBoard)
+	p.index = append(p.index, searchEntry{Title: v.Title, URL: v.Path, Text: v.Description})
+	return p.writePage(v.Path, data)
+}
+
+// uniqueSlug slugs a view name into a file stem not yet in used.
+func uniqueSlug(name string, used map[string]bool) string {
+	base := document.Slugify(name)
+	if base == "" {
+		base = "view"
+	}
+	slug := base
+	for i := 2; used[slug]; i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+	used[slug] = true
+	return slug
+}
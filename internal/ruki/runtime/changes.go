@@ -0,0 +1,170 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aymanbagabas/go-udiff"
+
+	"github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// writeChangeDiff prints the staged changes of a dry run: one header line
+// per tiki, followed by its changed fields as `-` old / `+` new lines and a
+// unified diff of the body.
+func writeChangeDiff(w io.Writer, tx *scriptTx) error {
+	changes := tx.changes()
+	if _, err := fmt.Fprintf(w, "dry run: %d %s, nothing written\n", len(changes), plural(len(changes), "change", "changes")); err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, ch := range changes {
+		switch ch.kind {
+		case "create":
+			fmt.Fprintf(&b, "\n+ create %s %s\n", ch.tk.ID(), ch.tk.Title())
+			writeFieldDiff(&b, nil, ch.tk)
+		case "update":
+			fmt.Fprintf(&b, "\n~ update %s %s%s\n", ch.tk.ID(), ch.tk.Title(), changePath(ch.old))
+			n := b.Len()
+			writeFieldDiff(&b, ch.old, ch.tk)
+			if b.Len() == n {
+				b.WriteString("    (no field changes)\n")
+			}
+		case "delete":
+			fmt.Fprintf(&b, "\n- delete %s %s%s\n", ch.tk.ID(), ch.tk.Title(), changePath(ch.old))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeFieldDiff lists the fields that differ between old and new (old is
+// nil for a create): title, workflow fields in declaration order, other
+// fields alphabetically, then the body.
+func writeFieldDiff(b *strings.Builder, old, new *tiki.Tiki) {
+	if old == nil || old.Title() != new.Title() {
+		writeValueChange(b, "title", old != nil, oldTitle(old), new.Title())
+	}
+	for _, name := range diffFieldNames(old, new) {
+		ov, hadOld := fieldOf(old, name)
+		nv, hasNew := fieldOf(new, name)
+		if hadOld && hasNew && reflect.DeepEqual(ov, nv) {
+			continue
+		}
+		if hadOld {
+			fmt.Fprintf(b, "    - %s: %s\n", name, displayValue(name, ov))
+		}
+		if hasNew {
+			fmt.Fprintf(b, "    + %s: %s\n", name, displayValue(name, nv))
+		}
+	}
+
+	oldBody := ""
+	if old != nil {
+		oldBody = old.Body()
+	}
+	if oldBody == new.Body() {
+		return
+	}
+	b.WriteString("    body:\n")
+	diff := udiff.Unified("old", "new", withTrailingNewline(oldBody), withTrailingNewline(new.Body()))
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		if strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ") {
+			continue
+		}
+		b.WriteString("      " + line + "\n")
+	}
+}
+
+func writeValueChange(b *strings.Builder, name string, hadOld bool, oldVal, newVal string) {
+	if hadOld {
+		fmt.Fprintf(b, "    - %s: %s\n", name, oldVal)
+	}
+	fmt.Fprintf(b, "    + %s: %s\n", name, newVal)
+}
+
+func oldTitle(old *tiki.Tiki) string {
+	if old == nil {
+		return ""
+	}
+	return old.Title()
+}
+
+// diffFieldNames returns the union of both tikis' field names in display
+// order. createdBy is runtime audit metadata that never reaches disk.
+func diffFieldNames(old, new *tiki.Tiki) []string {
+	present := map[string]bool{}
+	for _, t := range []*tiki.Tiki{old, new} {
+		if t == nil {
+			continue
+		}
+		for k := range t.Fields {
+			present[k] = true
+		}
+	}
+	delete(present, "createdBy")
+
+	var names []string
+	for _, fd := range workflow.WorkflowFields() {
+		if present[fd.Name] {
+			names = append(names, fd.Name)
+			delete(present, fd.Name)
+		}
+	}
+	rest := make([]string, 0, len(present))
+	for k := range present {
+		rest = append(rest, k)
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+func fieldOf(t *tiki.Tiki, name string) (interface{}, bool) {
+	if t == nil {
+		return nil, false
+	}
+	return t.Get(name)
+}
+
+// displayValue renders a field value the way the table formatter does.
+func displayValue(name string, v interface{}) string {
+	if fd, ok := workflow.Field(name); ok {
+		return renderValue(v, fd.Type)
+	}
+	return escapeScalar(fmt.Sprint(v))
+}
+
+// changePath returns " (path)" for a persisted tiki, relative to the
+// working directory (the workspace root) when possible.
+func changePath(t *tiki.Tiki) string {
+	if t == nil || t.Path() == "" {
+		return ""
+	}
+	p := t.Path()
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, p); err == nil && !strings.HasPrefix(rel, "..") {
+			p = rel
+		}
+	}
+	return " (" + filepath.ToSlash(p) + ")"
+}
+
+func withTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/boolean-maybe/ruki"
 	"github.com/boolean-maybe/tiki/workflow"
+	"github.com/boolean-maybe/tiki/workflow/value"
 )
 
 // Formatter renders a TikiProjection to an io.Writer.
@@ -194,8 +195,20 @@ func renderScalarList(val interface{}) string {
 	}
 }
 
-// renderValue formats a value according to its workflow type.
+// renderValue formats a value according to its workflow type for a
+// single-line table cell: free text has newlines and tabs escaped.
 func renderValue(val interface{}, vt workflow.ValueType) string {
+	s := renderRawValue(val, vt)
+	if isTextType(vt) {
+		return escapeScalar(s)
+	}
+	return s
+}
+
+// renderRawValue formats a value according to its workflow type without
+// escaping free text. Used by formats that quote multi-line cells themselves
+// (CSV) or escape them their own way (markdown).
+func renderRawValue(val interface{}, vt workflow.ValueType) string {
 	if val == nil {
 		return ""
 	}
@@ -205,19 +218,35 @@ func renderValue(val interface{}, vt workflow.ValueType) string {
 		return renderDate(val)
 	case workflow.TypeTimestamp:
 		return renderTimestamp(val)
-	case workflow.TypeListString, workflow.TypeListRef:
+	case workflow.TypeListString, workflow.TypeListRef, workflow.TypeEnumList:
 		return renderList(val)
 	case workflow.TypeInt:
 		return renderInt(val)
-	case workflow.TypeEnum:
-		return escapeScalar(fmt.Sprint(val))
-	case workflow.TypeBool:
+	case workflow.TypeNumber:
+		if f, ok := value.NumberOf(val); ok {
+			return value.FormatNumber(f)
+		}
+		return fmt.Sprint(val)
+	case workflow.TypeDuration:
+		if d, ok := val.(time.Duration); ok {
+			return value.FormatDuration(d)
+		}
 		return fmt.Sprint(val)
 	default:
-		return escapeScalar(fmt.Sprint(val))
+		return fmt.Sprint(val)
 	}
 }
 
+// isTextType reports whether values of vt are rendered as free text.
+func isTextType(vt workflow.ValueType) bool {
+	switch vt {
+	case workflow.TypeDate, workflow.TypeTimestamp, workflow.TypeListString, workflow.TypeListRef,
+		workflow.TypeEnumList, workflow.TypeInt, workflow.TypeNumber, workflow.TypeBool, workflow.TypeDuration:
+		return false
+	}
+	return true
+}
+
 func renderDate(val interface{}) string {
 	t, ok := val.(time.Time)
 	if !ok || t.IsZero() {
@@ -323,7 +352,7 @@ func jsonCellValue(t ruki.Document, fd workflow.FieldDef) interface{} {
 func toJSONValue(val interface{}, vt workflow.ValueType) interface{} {
 	// list types always render as an array — including nil/unset, which emit
 	// [] instead of null so scripts can always iterate without a null check
-	if vt == workflow.TypeListString || vt == workflow.TypeListRef {
+	if vt.IsList() {
 		return jsonList(val)
 	}
 	if val == nil {
@@ -336,11 +365,22 @@ func toJSONValue(val interface{}, vt workflow.ValueType) interface{} {
 		return jsonTimestamp(val)
 	case workflow.TypeInt:
 		return jsonInt(val)
+	case workflow.TypeNumber:
+		if f, ok := value.NumberOf(val); ok {
+			return f
+		}
+		return val
 	case workflow.TypeBool:
 		if b, ok := val.(bool); ok {
 			return b
 		}
 		return val
+	case workflow.TypeDuration:
+		// whole seconds, so scripts can compare and sort without parsing
+		if d, ok := val.(time.Duration); ok {
+			return int64(d / time.Second)
+		}
+		return val
 	default:
 		if s, ok := val.(string); ok {
 			return s
@@ -0,0 +1,256 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/theme"
+	"github.com/boolean-maybe/tiki/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// CSVFormatter renders select results as RFC 4180 CSV with a header row of
+// field names. Cells use the same typed rendering as the table, minus the
+// newline/tab escaping — CSV quoting keeps multi-line text intact.
+type CSVFormatter struct{}
+
+// NewCSVFormatter returns a Formatter that emits CSV.
+func NewCSVFormatter() Formatter {
+	return &CSVFormatter{}
+}
+
+func (f *CSVFormatter) Format(w io.Writer, proj *ruki.TikiProjection) error {
+	fields := resolveFields(proj.Fields)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(fieldNames(fields)); err != nil {
+		return err
+	}
+	for _, t := range proj.Tikis {
+		row := make([]string, len(fields))
+		for c, fd := range fields {
+			row[c] = renderRawValue(extractFieldValue(t, fd.Name), fd.Type)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// NDJSONFormatter renders select results as newline-delimited JSON: one row
+// object per line, with the same values as JSONFormatter.
+type NDJSONFormatter struct{}
+
+// NewNDJSONFormatter returns a Formatter that emits one JSON object per row.
+func NewNDJSONFormatter() Formatter {
+	return &NDJSONFormatter{}
+}
+
+func (f *NDJSONFormatter) Format(w io.Writer, proj *ruki.TikiProjection) error {
+	fields := resolveFields(proj.Fields)
+	for _, t := range proj.Tikis {
+		row := make(map[string]interface{}, len(fields))
+		for _, fd := range fields {
+			row[fd.Name] = jsonCellValue(t, fd)
+		}
+		b, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// YAMLFormatter renders select results as a YAML sequence of mappings.
+// Keys keep the projection order; values are the JSON formatter's values.
+type YAMLFormatter struct{}
+
+// NewYAMLFormatter returns a Formatter that emits a YAML document.
+func NewYAMLFormatter() Formatter {
+	return &YAMLFormatter{}
+}
+
+func (f *YAMLFormatter) Format(w io.Writer, proj *ruki.TikiProjection) error {
+	fields := resolveFields(proj.Fields)
+	if len(proj.Tikis) == 0 {
+		_, err := fmt.Fprintln(w, "[]")
+		return err
+	}
+
+	doc := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, t := range proj.Tikis {
+		row := &yaml.Node{Kind: yaml.MappingNode}
+		for _, fd := range fields {
+			var val yaml.Node
+			if err := val.Encode(jsonCellValue(t, fd)); err != nil {
+				return fmt.Errorf("encode %s: %w", fd.Name, err)
+			}
+			row.Content = append(row.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: fd.Name}, &val)
+		}
+		doc.Content = append(doc.Content, row)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// MarkdownFormatter renders select results as a GitHub-flavored markdown
+// table. Pipes are escaped and newlines become <br> so multi-line text stays
+// in its cell.
+type MarkdownFormatter struct{}
+
+// NewMarkdownFormatter returns a Formatter that emits a markdown table.
+func NewMarkdownFormatter() Formatter {
+	return &MarkdownFormatter{}
+}
+
+func (f *MarkdownFormatter) Format(w io.Writer, proj *ruki.TikiProjection) error {
+	fields := resolveFields(proj.Fields)
+	names := fieldNames(fields)
+	rule := make([]string, len(fields))
+	for i := range rule {
+		rule[i] = "---"
+	}
+
+	lines := []string{markdownRow(names), markdownRow(rule)}
+	for _, t := range proj.Tikis {
+		row := make([]string, len(fields))
+		for c, fd := range fields {
+			row[c] = escapeMarkdownCell(renderRawValue(extractFieldValue(t, fd.Name), fd.Type))
+		}
+		lines = append(lines, markdownRow(row))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func markdownRow(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// TemplateFormatter renders each selected row through a Go text/template.
+// The row is a map of projected field name to value (the JSON formatter's
+// values, with unset scalars as ""), so `{{.id}}` and `{{.title}}` work
+// directly. A newline is appended to every row that does not end in one.
+type TemplateFormatter struct {
+	tmpl *template.Template
+}
+
+// NewTemplateFormatter parses text as a row template. Besides the standard
+// text/template functions it provides:
+//
+//	label "field" value   enum label for value (e.g. "In Progress")
+//	visual "field" value  enum visual with color markup stripped (e.g. "🚧")
+//	join list sep         strings.Join for list fields
+func NewTemplateFormatter(text string) (Formatter, error) {
+	tmpl, err := template.New("row").
+		Option("missingkey=error").
+		Funcs(templateFuncs()).
+		Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateFormatter{tmpl: tmpl}, nil
+}
+
+func (f *TemplateFormatter) Format(w io.Writer, proj *ruki.TikiProjection) error {
+	fields := resolveFields(proj.Fields)
+	var buf bytes.Buffer
+	for _, t := range proj.Tikis {
+		row := make(map[string]interface{}, len(fields))
+		for _, fd := range fields {
+			v := jsonCellValue(t, fd)
+			if v == nil {
+				v = ""
+			}
+			row[fd.Name] = v
+		}
+		buf.Reset()
+		if err := f.tmpl.Execute(&buf, row); err != nil {
+			return fmt.Errorf("template: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"label": func(field string, value interface{}) (string, error) {
+			fd, err := templateField(field)
+			if err != nil {
+				return "", err
+			}
+			return fd.EnumLabel(fmt.Sprint(value)), nil
+		},
+		"visual": func(field string, value interface{}) (string, error) {
+			fd, err := templateField(field)
+			if err != nil {
+				return "", err
+			}
+			return workflow.ExpandVisual(fd.EnumDisplay(fmt.Sprint(value)), plainPaintResolver)
+		},
+		"join": func(list interface{}, sep string) string {
+			switch v := list.(type) {
+			case []string:
+				return strings.Join(v, sep)
+			case []interface{}:
+				ss := make([]string, len(v))
+				for i, e := range v {
+					ss[i] = fmt.Sprint(e)
+				}
+				return strings.Join(ss, sep)
+			default:
+				return fmt.Sprint(list)
+			}
+		},
+	}
+}
+
+func templateField(name string) (workflow.FieldDef, error) {
+	fd, ok := workflow.Field(name)
+	if !ok {
+		return workflow.FieldDef{}, fmt.Errorf("unknown field %q", name)
+	}
+	return fd, nil
+}
+
+// plainPaint writes text without color, so visual markup expands to the
+// bare glyphs and words a script can post elsewhere.
+type plainPaint struct{}
+
+func (plainPaint) PaintString(s string) string { return s }
+
+func plainPaintResolver(string, string) (theme.Paint, bool) {
+	return plainPaint{}, true
+}
@@ -0,0 +1,155 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/ruki"
+	"gopkg.in/yaml.v3"
+)
+
+func exportFixture() *ruki.TikiProjection {
+	return &ruki.TikiProjection{
+		Fields: []string{"id", "title", "status", "tags", "due"},
+		Tikis: []ruki.Document{
+			tikiFromLegacy(legacyFields{
+				ID: "TIKI-AAA001", Title: "Build | API\nv2", Status: "inProgress",
+				Tags: []string{"api", "backend"}, Due: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			}),
+			tikiFromLegacy(legacyFields{ID: "TIKI-BBB002", Title: "Docs", Status: "done"}),
+		},
+	}
+}
+
+func formatWith(t *testing.T, f Formatter, proj *ruki.TikiProjection) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := f.Format(&buf, proj); err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCSVFormatter(t *testing.T) {
+	initTestRegistries()
+
+	out := formatWith(t, NewCSVFormatter(), exportFixture())
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v\n%s", err, out)
+	}
+	want := [][]string{
+		{"id", "title", "status", "tags", "due"},
+		{"TIKI-AAA001", "Build | API\nv2", "inProgress", `["api","backend"]`, "2026-03-01"},
+		{"TIKI-BBB002", "Docs", "done", "[]", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d:\n%s", len(records), len(want), out)
+	}
+	for i := range want {
+		if strings.Join(records[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("record %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
+
+func TestNDJSONFormatter(t *testing.T) {
+	initTestRegistries()
+
+	out := formatWith(t, NewNDJSONFormatter(), exportFixture())
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), out)
+	}
+	var row map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &row); err != nil {
+		t.Fatalf("line 2 is not JSON: %v", err)
+	}
+	if row["id"] != "TIKI-BBB002" || row["due"] != nil {
+		t.Errorf("row = %#v", row)
+	}
+	if tags, ok := row["tags"].([]interface{}); !ok || len(tags) != 0 {
+		t.Errorf("unset list should be [], got %#v", row["tags"])
+	}
+
+	if got := formatWith(t, NewNDJSONFormatter(), &ruki.TikiProjection{Fields: []string{"id"}}); got != "" {
+		t.Errorf("empty result should print nothing, got %q", got)
+	}
+}
+
+func TestYAMLFormatter(t *testing.T) {
+	initTestRegistries()
+
+	out := formatWith(t, NewYAMLFormatter(), exportFixture())
+	if !strings.HasPrefix(out, "- id: TIKI-AAA001\n  title: ") {
+		t.Errorf("fields not in projection order:\n%s", out)
+	}
+	var rows []map[string]interface{}
+	if err := yaml.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("invalid YAML: %v\n%s", err, out)
+	}
+	if len(rows) != 2 || rows[0]["title"] != "Build | API\nv2" || rows[0]["due"] != "2026-03-01" {
+		t.Errorf("rows = %#v", rows)
+	}
+
+	if got := formatWith(t, NewYAMLFormatter(), &ruki.TikiProjection{Fields: []string{"id"}}); got != "[]\n" {
+		t.Errorf("empty result = %q, want []", got)
+	}
+}
+
+func TestMarkdownFormatter(t *testing.T) {
+	initTestRegistries()
+
+	out := formatWith(t, NewMarkdownFormatter(), exportFixture())
+	want := "| id | title | status | tags | due |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| TIKI-AAA001 | Build \\| API<br>v2 | inProgress | [\"api\",\"backend\"] | 2026-03-01 |\n" +
+		"| TIKI-BBB002 | Docs | done | [] |  |\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	initTestRegistries()
+
+	f, err := NewTemplateFormatter(`{{visual "status" .status}} {{.id}} [{{label "status" .status}}] {{join .tags ", "}}{{if .due}} due {{.due}}{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := formatWith(t, f, exportFixture())
+	want := "⚙️ TIKI-AAA001 [In Progress] api, backend due 2026-03-01\n" +
+		"✅ TIKI-BBB002 [Done] \n"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestTemplateFormatterErrors(t *testing.T) {
+	initTestRegistries()
+
+	if _, err := NewTemplateFormatter("{{.id"); err == nil {
+		t.Error("expected parse error")
+	}
+
+	f, err := NewTemplateFormatter("{{.assignee}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := f.Format(&buf, exportFixture()); err == nil {
+		t.Error("expected error for a field that is not projected")
+	}
+
+	f, err = NewTemplateFormatter(`{{label "nope" .status}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Format(&buf, exportFixture()); err == nil || !strings.Contains(err.Error(), `unknown field "nope"`) {
+		t.Errorf("err = %v, want unknown field", err)
+	}
+}
@@ -377,3 +377,37 @@ func TestFormatClipboardSummary(t *testing.T) {
 		t.Errorf("json: got %q", got)
 	}
 }
+
+func TestJSONFormatterNumberAndEnumList(t *testing.T) {
+	initTestRegistries()
+	if err := teststatuses.InitWith([]workflow.FieldDef{
+		{Name: "cost", Type: workflow.TypeNumber, Unit: "h"},
+		{Name: "components", Type: workflow.TypeEnumList, EnumValues: []workflow.EnumValue{{Value: "api"}, {Value: "ui"}}},
+	}); err != nil {
+		t.Fatalf("register custom fields: %v", err)
+	}
+	t.Cleanup(initTestRegistries)
+
+	proj := &ruki.TikiProjection{
+		Fields: []string{"cost", "components"},
+		Tikis: []ruki.Document{tikiFromLegacy(legacyFields{CustomFields: map[string]interface{}{
+			"cost":       2.5,
+			"components": []string{"api", "ui"},
+		}})},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONFormatter().Format(&buf, proj); err != nil {
+		t.Fatal(err)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if n, ok := rows[0]["cost"].(float64); !ok || n != 2.5 {
+		t.Errorf("cost = %#v, want JSON number 2.5", rows[0]["cost"])
+	}
+	if list, _ := rows[0]["components"].([]interface{}); len(list) != 2 || list[0] != "api" {
+		t.Errorf("components = %#v, want [api ui]", rows[0]["components"])
+	}
+}
@@ -341,6 +341,16 @@ func TestRenderValueNil(t *testing.T) {
 	}
 }
 
+func TestRenderDurationValue(t *testing.T) {
+	d := 50 * time.Hour
+	if got := renderValue(d, workflow.TypeDuration); got != "2d 2h" {
+		t.Errorf("renderValue(duration) = %q, want %q", got, "2d 2h")
+	}
+	if got := toJSONValue(d, workflow.TypeDuration); got != int64(180000) {
+		t.Errorf("toJSONValue(duration) = %#v, want seconds", got)
+	}
+}
+
 func TestRenderDateEdgeCases(t *testing.T) {
 	// renderDate with non-time value
 	if got := renderDate("not-a-time"); got != "" {
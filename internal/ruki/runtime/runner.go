@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/service"
 	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/tiki"
@@ -30,12 +31,38 @@ const (
 	// OutputJSON renders compact JSON: array of row objects for selects, bare
 	// JSON values for scalars, and small summary objects for mutations/pipes.
 	OutputJSON
+	// OutputCSV renders selects as CSV with a header row.
+	OutputCSV
+	// OutputNDJSON renders selects as one JSON object per line; scalars and
+	// summaries match OutputJSON.
+	OutputNDJSON
+	// OutputYAML renders selects as a YAML sequence of row mappings.
+	OutputYAML
+	// OutputMarkdown renders selects as a GitHub-flavored markdown table.
+	OutputMarkdown
+	// OutputTemplate renders each selected row through RunQueryOptions.Template.
+	OutputTemplate
 )
 
+// jsonSummaries reports whether scalars and mutation summaries are written
+// as JSON. Every other format falls back to the plain-text form.
+func (f OutputFormat) jsonSummaries() bool {
+	return f == OutputJSON || f == OutputNDJSON
+}
+
 // RunQueryOptions tunes CLI query execution. Zero value means table output
 // and the real system clipboard, matching the default RunQuery behavior.
 type RunQueryOptions struct {
 	OutputFormat OutputFormat
+	// Template is the text/template source used when OutputFormat is
+	// OutputTemplate.
+	Template string
+	// Atomic runs the statement through RunScript: every change it makes is
+	// validated first and written as one batch, rolled back if a write fails.
+	Atomic bool
+	// DryRun validates and prints the would-be changes without writing.
+	// Implies Atomic.
+	DryRun bool
 	// ClipboardWriter is injected so tests can substitute a fake that doesn't
 	// require a GUI clipboard binary (xclip/xsel/pbcopy). nil → system clipboard.
 	ClipboardWriter func([][]string) error
@@ -54,6 +81,9 @@ func RunQueryWithOptions(gate *service.TikiMutationGate, query string, out io.Wr
 	if query == "" {
 		return fmt.Errorf("empty query")
 	}
+	if opts.Atomic || opts.DryRun {
+		return RunScript(gate, []ScriptStatement{{Text: query, Line: 1}}, out, opts)
+	}
 
 	readStore := gate.ReadStore()
 
@@ -67,6 +97,9 @@ func RunQueryWithOptions(gate *service.TikiMutationGate, query string, out io.Wr
 	factory := ruki.DocumentFactory(tiki.NewDoc)
 	executor := ruki.NewExecutor(schema, factory, userFunc, ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimeCLI})
 
+	// `create from "<template>"` is rewritten host-side to a plain create
+	query, templateName := config.SplitCreateFrom(query)
+
 	stmt, err := parser.ParseAndValidateStatement(query, ruki.ExecutorRuntimeCLI)
 	if err != nil {
 		return fmt.Errorf("parse: %w", err)
@@ -76,7 +109,7 @@ func RunQueryWithOptions(gate *service.TikiMutationGate, query string, out io.Wr
 	// (e.g. labels=labels+["new"]) resolve from template defaults
 	var input ruki.ExecutionInput
 	if stmt.RequiresCreateTemplate() {
-		tmpl, tmplErr := readStore.NewTikiTemplate()
+		tmpl, tmplErr := service.NewTikiFromTemplate(readStore, templateName)
 		if tmplErr != nil {
 			return fmt.Errorf("create template: %w", tmplErr)
 		}
@@ -93,16 +126,21 @@ func RunQueryWithOptions(gate *service.TikiMutationGate, query string, out io.Wr
 	}
 
 	ctx := context.Background()
-	json := opts.OutputFormat == OutputJSON
+	json := opts.OutputFormat.jsonSummaries()
 
 	switch {
 	case result.Select != nil:
-		return selectFormatter(json).Format(out, result.Select)
+		formatter, err := selectFormatter(opts)
+		if err != nil {
+			return err
+		}
+		return formatter.Format(out, result.Select)
 
 	case result.Update != nil:
 		return persistAndSummarize(ctx, gate, result.Update, out, json)
 
 	case result.Create != nil:
+		service.FinishTemplateCreate(readStore, tiki.UnwrapDoc(result.Create.Tiki), templateName)
 		return persistCreate(ctx, gate, result.Create, out, json)
 
 	case result.Delete != nil:
@@ -132,12 +170,28 @@ func RunQueryWithOptions(gate *service.TikiMutationGate, query string, out io.Wr
 	}
 }
 
-// selectFormatter returns the JSON or table formatter for select results.
-func selectFormatter(json bool) Formatter {
-	if json {
-		return NewJSONFormatter()
+// selectFormatter returns the formatter for select results.
+func selectFormatter(opts RunQueryOptions) (Formatter, error) {
+	switch opts.OutputFormat {
+	case OutputJSON:
+		return NewJSONFormatter(), nil
+	case OutputCSV:
+		return NewCSVFormatter(), nil
+	case OutputNDJSON:
+		return NewNDJSONFormatter(), nil
+	case OutputYAML:
+		return NewYAMLFormatter(), nil
+	case OutputMarkdown:
+		return NewMarkdownFormatter(), nil
+	case OutputTemplate:
+		f, err := NewTemplateFormatter(opts.Template)
+		if err != nil {
+			return nil, fmt.Errorf("template: %w", err)
+		}
+		return f, nil
+	default:
+		return NewTableFormatter(), nil
 	}
-	return NewTableFormatter()
 }
 
 // executePipe runs the pipe command for each row. If any row fails, the first
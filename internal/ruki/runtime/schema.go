@@ -11,6 +11,7 @@ import (
 // observes newly loaded workflow fields through live global lookups.
 type workflowSchema struct {
 	fieldsByName map[string]ruki.FieldSpec
+	numbers      map[string]bool
 }
 
 // NewSchema constructs a ruki.Schema backed by the loaded workflow fields.
@@ -27,18 +28,27 @@ func NewSchema() ruki.Schema {
 // fields they want included.
 func NewSchemaFromFields(fields []workflow.FieldDef) ruki.Schema {
 	byName := make(map[string]ruki.FieldSpec, len(fields))
+	numbers := make(map[string]bool)
 	for _, fd := range fields {
+		// ruki has no decimal type, so number fields stay out of ruki
+		// entirely rather than being compared and sorted as text
+		if fd.Type == workflow.TypeNumber {
+			numbers[fd.Name] = true
+			continue
+		}
 		spec := ruki.FieldSpec{
 			Name:   fd.Name,
 			Type:   mapValueType(fd.Type),
 			Custom: fd.Custom,
 		}
-		if vals := fd.AllowedValues(); vals != nil {
-			spec.AllowedValues = vals
+		// ruki reserves AllowedValues for ValueEnum; an enumList is a plain
+		// list of strings there and its keys are checked by the validators.
+		if fd.Type == workflow.TypeEnum {
+			spec.AllowedValues = fd.AllowedValues()
 		}
 		byName[fd.Name] = spec
 	}
-	return &workflowSchema{fieldsByName: byName}
+	return &workflowSchema{fieldsByName: byName, numbers: numbers}
 }
 
 func (s *workflowSchema) Field(name string) (ruki.FieldSpec, bool) {
@@ -54,10 +64,16 @@ func (s *workflowSchema) Field(name string) (ruki.FieldSpec, bool) {
 	return out, true
 }
 
+// NumberField reports whether name is a number field; see
+// workflow.NumberSchema.
+func (s *workflowSchema) NumberField(name string) bool {
+	return s.numbers[name]
+}
+
 // mapValueType converts workflow.ValueType to ruki.ValueType.
 func mapValueType(wt workflow.ValueType) ruki.ValueType {
 	switch wt {
-	case workflow.TypeString, workflow.TypeUser:
+	case workflow.TypeString, workflow.TypeUser, workflow.TypeURL:
 		return ruki.ValueString
 	case workflow.TypeInt:
 		return ruki.ValueInt
@@ -75,7 +91,7 @@ func mapValueType(wt workflow.ValueType) ruki.ValueType {
 		return ruki.ValueRef
 	case workflow.TypeRecurrence:
 		return ruki.ValueRecurrence
-	case workflow.TypeListString:
+	case workflow.TypeListString, workflow.TypeEnumList:
 		return ruki.ValueListString
 	case workflow.TypeListRef:
 		return ruki.ValueListRef
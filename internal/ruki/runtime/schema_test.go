@@ -91,6 +91,27 @@ func TestSchemaUnknownField(t *testing.T) {
 	}
 }
 
+func TestSchemaKeepsNumberFieldsOutOfRuki(t *testing.T) {
+	fields := append(workflow.SystemFields(), workflow.FieldDef{Name: "estimate", Type: workflow.TypeNumber, Custom: true})
+	s := NewSchemaFromFields(fields)
+
+	if _, ok := s.Field("estimate"); ok {
+		t.Error("number field should not be visible to ruki")
+	}
+	if !workflow.IsNumberField(s, "estimate") || workflow.IsNumberField(s, "title") {
+		t.Error("IsNumberField should report only the number field")
+	}
+	for _, src := range []string{
+		`select where estimate = "2"`,
+		`select order by estimate`,
+		`update where id = "X" set estimate = "2.5"`,
+	} {
+		if _, err := ruki.NewParser(s).ParseAndValidateStatement(src, ruki.ExecutorRuntimeCLI); err == nil {
+			t.Errorf("%s: expected an unknown-field error", src)
+		}
+	}
+}
+
 func TestSchemaEnumAllowedValues(t *testing.T) {
 	initTestRegistries()
 	s := NewSchema()
@@ -0,0 +1,335 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/tiki"
+)
+
+// ScriptStatement is one statement of a ruki script and the 1-based line it
+// starts on, for error messages.
+type ScriptStatement struct {
+	Text string
+	Line int
+}
+
+// SplitScript splits a ruki script into statements at top-level semicolons.
+// Semicolons inside string literals and `--` line comments do not split.
+// Statements that hold only whitespace and comments are dropped, so a
+// trailing `;` or a commented-out statement is harmless. Comment and blank
+// lines before a statement are stripped from its text, so host-side prefixes
+// such as `create from "<template>"` are still recognised.
+func SplitScript(src string) []ScriptStatement {
+	var stmts []ScriptStatement
+	start, line, startLine := 0, 1, 1
+	inString, inComment := false, false
+
+	flush := func(end int) {
+		text := src[start:end]
+		lead := leadingComments(text)
+		if lead < 0 {
+			return
+		}
+		// report the line the statement text starts on
+		stmts = append(stmts, ScriptStatement{
+			Text: strings.TrimSpace(text[lead:]),
+			Line: startLine + strings.Count(text[:lead], "\n"),
+		})
+	}
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			inComment = false
+		case inComment:
+		case inString:
+			if c == '\\' {
+				i++
+				if i < len(src) && src[i] == '\n' {
+					line++
+				}
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '-' && i+1 < len(src) && src[i+1] == '-':
+			inComment = true
+		case c == ';':
+			flush(i)
+			start, startLine = i+1, line
+		}
+	}
+	flush(len(src))
+	return stmts
+}
+
+// leadingComments returns the length of the whitespace and `--` comment
+// lines that open text, or -1 when text holds nothing else.
+func leadingComments(text string) int {
+	offset := 0
+	for _, l := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(l)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+			return offset + len(l) - len(strings.TrimLeft(l, " \t\r"))
+		}
+		offset += len(l)
+	}
+	return -1
+}
+
+// RunScript executes statements in order as a single transaction. Each
+// statement sees the effects of the ones before it, but nothing is written
+// until every statement has run; the combined changes are then validated
+// and persisted together through TikiMutationGate.ApplyBatch, so a
+// rejection or a failed write leaves the workspace untouched. Output is
+// buffered and written only when the script succeeds.
+//
+// With opts.DryRun the batch is validated but not written, and the would-be
+// changes are printed after the statement output.
+//
+// Pipe (`| run(...)`) and clipboard statements have side effects outside the
+// workspace that cannot be rolled back, so they are rejected here.
+func RunScript(gate *service.TikiMutationGate, statements []ScriptStatement, out io.Writer, opts RunQueryOptions) error {
+	if len(statements) == 0 {
+		return fmt.Errorf("empty script")
+	}
+	readStore := gate.ReadStore()
+	userFunc, err := resolveUserFunc(readStore)
+	if err != nil {
+		return fmt.Errorf("resolve current user: %w", err)
+	}
+
+	schema := NewSchema()
+	tx := newScriptTx(readStore)
+	run := &scriptRun{
+		parser: ruki.NewParser(schema),
+		executor: ruki.NewExecutor(schema, ruki.DocumentFactory(tiki.NewDoc), userFunc,
+			ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimeCLI}),
+		tx:   tx,
+		opts: opts,
+	}
+
+	var buf bytes.Buffer
+	for _, st := range statements {
+		if err := run.statement(st.Text, &buf); err != nil {
+			if len(statements) > 1 {
+				return fmt.Errorf("line %d: %w", st.Line, err)
+			}
+			return err
+		}
+	}
+
+	batch := tx.batch()
+	if opts.DryRun {
+		if err := gate.ValidateBatch(batch); err != nil {
+			return err
+		}
+		if _, err := out.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		return writeChangeDiff(out, tx)
+	}
+	if err := gate.ApplyBatch(context.Background(), batch); err != nil {
+		return fmt.Errorf("nothing was written: %w", err)
+	}
+	_, err = out.Write(buf.Bytes())
+	return err
+}
+
+// scriptRun carries the parser/executor state shared by a script's statements.
+type scriptRun struct {
+	parser   *ruki.Parser
+	executor *ruki.Executor
+	tx       *scriptTx
+	opts     RunQueryOptions
+}
+
+func (r *scriptRun) statement(query string, out io.Writer) error {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if query == "" {
+		return fmt.Errorf("empty query")
+	}
+	query, templateName := config.SplitCreateFrom(query)
+
+	stmt, err := r.parser.ParseAndValidateStatement(query, ruki.ExecutorRuntimeCLI)
+	if err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+
+	var input ruki.ExecutionInput
+	if stmt.RequiresCreateTemplate() {
+		tmpl, tmplErr := service.NewTikiFromTemplate(r.tx.store, templateName)
+		if tmplErr != nil {
+			return fmt.Errorf("create template: %w", tmplErr)
+		}
+		if tmpl == nil {
+			return fmt.Errorf("create template: store returned nil template")
+		}
+		input.CreateTemplate = tiki.WrapDoc(tmpl)
+	}
+
+	result, err := r.executor.Execute(stmt, tiki.WrapDocs(r.tx.working), input)
+	if err != nil {
+		return fmt.Errorf("execute: %w", err)
+	}
+
+	json := r.opts.OutputFormat.jsonSummaries()
+	switch {
+	case result.Select != nil:
+		formatter, err := selectFormatter(r.opts)
+		if err != nil {
+			return err
+		}
+		return formatter.Format(out, result.Select)
+
+	case result.Update != nil:
+		for _, doc := range result.Update.Updated {
+			r.tx.update(tiki.UnwrapDoc(doc))
+		}
+		return formatUpdateSummary(out, len(result.Update.Updated), 0, json)
+
+	case result.Create != nil:
+		created := tiki.UnwrapDoc(result.Create.Tiki)
+		r.tx.create(created)
+		service.FinishTemplateCreate(r.tx.store, created, templateName)
+		return formatCreateSummary(out, created.ID(), json)
+
+	case result.Delete != nil:
+		for _, doc := range result.Delete.Deleted {
+			r.tx.delete(tiki.UnwrapDoc(doc))
+		}
+		return formatDeleteSummary(out, len(result.Delete.Deleted), 0, json)
+
+	case result.Pipe != nil, result.Clipboard != nil:
+		return fmt.Errorf("pipe and clipboard statements cannot run in a transaction or dry run")
+
+	case result.Scalar != nil:
+		if json {
+			return FormatScalarJSON(out, result.Scalar)
+		}
+		return FormatScalar(out, result.Scalar)
+
+	default:
+		return fmt.Errorf("unsupported statement type")
+	}
+}
+
+// scriptTx stages a script's mutations against a working copy of the
+// workspace. Later statements query the working copy, and repeated
+// mutations of one tiki collapse into a single batch entry: an update of a
+// staged create stays a create, a delete of a staged create drops it.
+type scriptTx struct {
+	store   store.ReadStore
+	working []*tiki.Tiki
+	order   []string
+	staged  map[string]*stagedChange
+}
+
+type stagedChange struct {
+	kind string // "create", "update", "delete"
+	old  *tiki.Tiki
+	tk   *tiki.Tiki
+}
+
+func newScriptTx(rs store.ReadStore) *scriptTx {
+	return &scriptTx{
+		store:   rs,
+		working: append([]*tiki.Tiki(nil), allDocsAsTikis(rs)...),
+		staged:  map[string]*stagedChange{},
+	}
+}
+
+// create assigns the new tiki an id up front, so later statements and the
+// create summary can refer to it.
+func (tx *scriptTx) create(tk *tiki.Tiki) {
+	if tk.ID() == "" {
+		for {
+			id := strings.ToUpper(config.GenerateRandomID())
+			if tx.store.GetTiki(id) == nil && tx.find(id) < 0 {
+				tk.SetID(id)
+				break
+			}
+		}
+	}
+	tx.working = append(tx.working, tk)
+	tx.stage(tk.ID(), &stagedChange{kind: "create", tk: tk})
+}
+
+func (tx *scriptTx) update(tk *tiki.Tiki) {
+	if i := tx.find(tk.ID()); i >= 0 {
+		tx.working[i] = tk
+	}
+	if ch, ok := tx.staged[tk.ID()]; ok {
+		ch.tk = tk
+		return
+	}
+	tx.stage(tk.ID(), &stagedChange{kind: "update", old: tx.store.GetTiki(tk.ID()), tk: tk})
+}
+
+func (tx *scriptTx) delete(tk *tiki.Tiki) {
+	if i := tx.find(tk.ID()); i >= 0 {
+		tx.working = append(tx.working[:i], tx.working[i+1:]...)
+	}
+	if ch, ok := tx.staged[tk.ID()]; ok {
+		if ch.kind == "create" {
+			delete(tx.staged, tk.ID())
+			return
+		}
+		ch.kind, ch.tk = "delete", tk
+		return
+	}
+	tx.stage(tk.ID(), &stagedChange{kind: "delete", old: tx.store.GetTiki(tk.ID()), tk: tk})
+}
+
+func (tx *scriptTx) stage(id string, ch *stagedChange) {
+	if _, ok := tx.staged[id]; !ok {
+		tx.order = append(tx.order, id)
+	}
+	tx.staged[id] = ch
+}
+
+func (tx *scriptTx) find(id string) int {
+	for i, t := range tx.working {
+		if t.ID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// changes returns the staged changes in the order they were first made.
+func (tx *scriptTx) changes() []*stagedChange {
+	out := make([]*stagedChange, 0, len(tx.staged))
+	for _, id := range tx.order {
+		if ch, ok := tx.staged[id]; ok {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+func (tx *scriptTx) batch() *service.MutationBatch {
+	b := &service.MutationBatch{}
+	for _, ch := range tx.changes() {
+		switch ch.kind {
+		case "create":
+			b.Creates = append(b.Creates, ch.tk)
+		case "update":
+			b.Updates = append(b.Updates, ch.tk)
+		case "delete":
+			b.Deletes = append(b.Deletes, ch.tk)
+		}
+	}
+	return b
+}
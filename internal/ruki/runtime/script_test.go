@@ -0,0 +1,192 @@
+package runtime
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/service"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func TestSplitScript(t *testing.T) {
+	src := `-- nightly cleanup; not a separator
+update where status = "done" set tags = tags + ["a;b"];
+
+create title="Semi; colon" status="ready"  -- trailing; comment
+;
+-- commented out: delete where status = "done";
+select id`
+
+	got := SplitScript(src)
+	want := []ScriptStatement{
+		{Text: "update where status = \"done\" set tags = tags + [\"a;b\"]", Line: 2},
+		{Text: "create title=\"Semi; colon\" status=\"ready\"  -- trailing; comment", Line: 4},
+		{Text: "select id", Line: 7},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d statements: %#v", len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("statement %d = %#v, want %#v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRunScript_StatementsSeeEarlierChanges(t *testing.T) {
+	s := setupRunnerTest(t)
+
+	script := SplitScript(`
+update where id = "TIKI-AAA001" set status = "done";
+select id where status = "done" order by id;
+delete where id = "TIKI-BBB002";
+count(select)`)
+
+	var buf bytes.Buffer
+	if err := RunScript(gateFor(s), script, &buf, RunQueryOptions{OutputFormat: OutputCSV}); err != nil {
+		t.Fatal(err)
+	}
+	want := "updated 1 tikis\nid\nTIKI-AAA001\nTIKI-BBB002\ndeleted 1 tikis\n1\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if v, _ := s.GetTiki("TIKI-AAA001").Get("status"); v != "done" {
+		t.Errorf("status = %v, want done", v)
+	}
+	if s.GetTiki("TIKI-BBB002") != nil {
+		t.Error("TIKI-BBB002 not deleted")
+	}
+}
+
+func TestRunScript_RejectionLeavesWorkspaceUntouched(t *testing.T) {
+	s := setupRunnerTest(t)
+	g := gateFor(s)
+	g.OnDelete(func(old, _ *tikipkg.Tiki, _ []*tikipkg.Tiki) *service.Rejection {
+		return &service.Rejection{Reason: "deletes forbidden"}
+	})
+
+	script := SplitScript(`update where id = "TIKI-AAA001" set status = "done"; delete where id = "TIKI-BBB002"`)
+	var buf bytes.Buffer
+	err := RunScript(g, script, &buf, RunQueryOptions{})
+	if err == nil || !strings.Contains(err.Error(), "nothing was written") || !strings.Contains(err.Error(), "deletes forbidden") {
+		t.Fatalf("err = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("output written for a failed script: %q", buf.String())
+	}
+	if v, _ := s.GetTiki("TIKI-AAA001").Get("status"); v != "ready" {
+		t.Errorf("status = %v, want unchanged", v)
+	}
+}
+
+func TestRunScript_ErrorNamesLine(t *testing.T) {
+	s := setupRunnerTest(t)
+	script := SplitScript("select id;\n\nselect nosuchfield")
+	err := RunScript(gateFor(s), script, &bytes.Buffer{}, RunQueryOptions{})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3: parse:") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRunScript_CreateThenUpdate(t *testing.T) {
+	s := setupRunnerTest(t)
+	script := SplitScript(`create title="Fresh" status="ready"; update where title = "Fresh" set priority = "low"`)
+
+	var buf bytes.Buffer
+	if err := RunScript(gateFor(s), script, &buf, RunQueryOptions{OutputFormat: OutputJSON}); err != nil {
+		t.Fatal(err)
+	}
+	var created *tikipkg.Tiki
+	for _, tk := range s.GetAllTikis() {
+		if tk.Title() == "Fresh" {
+			created = tk
+		}
+	}
+	if created == nil {
+		t.Fatal("tiki not created")
+	}
+	if v, _ := created.Get("priority"); v != "low" {
+		t.Errorf("priority = %v, want low", v)
+	}
+	if !strings.Contains(buf.String(), `{"created":"`+created.ID()+`"}`) {
+		t.Errorf("create summary lacks the assigned id: %s", buf.String())
+	}
+}
+
+func TestRunScript_CommentedCreateFrom(t *testing.T) {
+	config.ResetWorkflowTemplatesForTest([]config.TikiTemplate{{
+		Name:   "bug",
+		Fields: map[string]interface{}{"priority": "high"},
+	}})
+	t.Cleanup(func() { config.ResetWorkflowTemplatesForTest(nil) })
+	s := setupRunnerTest(t)
+
+	script := SplitScript("-- file the crash\n\ncreate from \"bug\" title=\"Crash\" status=\"ready\"")
+	if len(script) != 1 || script[0].Line != 3 {
+		t.Fatalf("script = %#v", script)
+	}
+	if err := RunScript(gateFor(s), script, &bytes.Buffer{}, RunQueryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range s.GetAllTikis() {
+		if tk.Title() == "Crash" {
+			if v, _ := tk.Get("priority"); v != "high" {
+				t.Errorf("priority = %v, want template preset high", v)
+			}
+			return
+		}
+	}
+	t.Fatal("tiki not created")
+}
+
+func TestRunQueryWithOptions_DryRun(t *testing.T) {
+	s := setupRunnerTest(t)
+	s.GetTiki("TIKI-AAA001").SetBody("line one\nline two\n")
+
+	var buf bytes.Buffer
+	err := RunQueryWithOptions(gateFor(s), `update where id = "TIKI-AAA001" set status="done" description="line one"`,
+		&buf, RunQueryOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"updated 1 tikis",
+		"dry run: 1 change, nothing written",
+		"~ update TIKI-AAA001 Build API",
+		"    - status: ready\n    + status: done",
+		"       line one\n      -line two",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry run output missing %q:\n%s", want, out)
+		}
+	}
+	if v, _ := s.GetTiki("TIKI-AAA001").Get("status"); v != "ready" {
+		t.Errorf("dry run wrote status %v", v)
+	}
+}
+
+func TestRunQueryWithOptions_AtomicRollsBack(t *testing.T) {
+	s := setupRunnerTest(t)
+	_ = s.CreateTiki(newRunnerTiki("TIKI-CCC003", "Third", "ready", "medium", ""))
+	fs := &failingUpdateStore{Store: s, failID: "TIKI-CCC003"}
+
+	var buf bytes.Buffer
+	err := RunQueryWithOptions(gateFor(fs), `update where status = "ready" set priority = "low"`, &buf, RunQueryOptions{Atomic: true})
+	if err == nil || !strings.Contains(err.Error(), "rolled back 1") {
+		t.Fatalf("err = %v", err)
+	}
+	if v, _ := s.GetTiki("TIKI-AAA001").Get("priority"); v != "high" {
+		t.Errorf("TIKI-AAA001 priority = %v, want rollback to high", v)
+	}
+}
+
+func TestRunScript_RejectsPipe(t *testing.T) {
+	s := setupRunnerTest(t)
+	err := RunQueryWithOptions(gateFor(s), `select id | run("echo $1")`, &bytes.Buffer{}, RunQueryOptions{DryRun: true})
+	if err == nil || !strings.Contains(err.Error(), "cannot run in a transaction") {
+		t.Fatalf("err = %v", err)
+	}
+}
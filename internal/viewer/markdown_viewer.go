@@ -86,13 +86,14 @@ func Run(input InputSpec) error {
 	})
 	defer md.Close()
 	md.SetStateChangedHandler(func() {
-		updateStatusBar(statusBar, md.Viewer())
+		updateStatusBar(statusBar, md)
 	})
+	md.SetFocusFunc(func(p tview.Primitive) { app.SetFocus(p) })
 
 	// progress hub: renders the block-shade bar into the status bar while images
 	// resolve off the UI goroutine. redraw runs a func on the UI goroutine.
 	redraw := func(fn func()) { app.QueueUpdateDraw(fn) }
-	sink := &progressStatusBar{bar: statusBar, viewer: md.Viewer}
+	sink := &progressStatusBar{bar: statusBar, md: md}
 	progressHub := model.NewProgressHub(sink, redraw)
 	defer progressHub.Stop()
 
@@ -108,17 +109,36 @@ func Run(input InputSpec) error {
 	}
 
 	// initial status bar update
-	updateStatusBar(statusBar, md.Viewer())
+	updateStatusBar(statusBar, md)
 
 	// create flex layout with status bar
 	flex := tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(md.Viewer(), 0, 1, true).
+		AddItem(md.View(), 0, 1, true).
 		AddItem(statusBar, 1, 0, false)
 
 	// key handlers
 	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// the search prompt and the outline keep the keys they claim
+		if kc, ok := app.GetFocus().(interface{ CapturesKey(*tcell.EventKey) bool }); ok && kc.CapturesKey(event) {
+			return event
+		}
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
 		switch event.Rune() {
+		case '/':
+			md.StartSearch()
+			return nil
+		case 'n':
+			md.NextMatch()
+			return nil
+		case 'N':
+			md.PrevMatch()
+			return nil
+		case 'o':
+			md.ToggleOutline()
+			return nil
 		case 'q':
 			app.Stop()
 			return nil
@@ -150,7 +170,7 @@ func Run(input InputSpec) error {
 		return event
 	})
 
-	app.SetRoot(flex, true).EnableMouse(false)
+	app.SetRoot(flex, true).EnableMouse(config.GetMouseEnabled())
 	if err := app.Run(); err != nil {
 		return fmt.Errorf("viewer error: %w", err)
 	}
@@ -240,17 +260,17 @@ func resolveInitialSource(candidate string, searchRoots []string) string {
 	return resolved
 }
 
-// updateStatusBar refreshes the status bar with current viewer state.
-func updateStatusBar(statusBar *tview.TextView, v *navtview.TextViewViewer) {
-	core := v.Core()
-	srcPath := core.SourceFilePath()
+// updateStatusBar refreshes the status bar with current viewer state: the
+// file, the section being read and the search position, then the key hints.
+func updateStatusBar(statusBar *tview.TextView, md *markdown.NavigableMarkdown) {
+	srcPath := md.SourceFilePath()
 	fileName := filepath.Base(srcPath)
 	if fileName == "" || fileName == "." {
 		fileName = "tiki"
 	}
 
-	canBack := core.CanGoBack()
-	canForward := core.CanGoForward()
+	canBack := md.CanGoBack()
+	canForward := md.CanGoForward()
 
 	roles := theme.Roles()
 	labelColor := roles.TextSecondary().Hex()
@@ -258,7 +278,18 @@ func updateStatusBar(statusBar *tview.TextView, v *navtview.TextViewViewer) {
 	activeColor := roles.TextPrimary().Hex()
 	mutedColor := roles.TextHint().Hex()
 	accentColor := roles.StatusWarn().BoldTag()
-	status := fmt.Sprintf(" %s%s[-] | [%s]Link:[-][%s]Tab/Shift-Tab[-] | [%s]Back:[-]", accentColor, fileName, labelColor, keyColor, labelColor)
+	status := fmt.Sprintf(" %s%s[-]", accentColor, fileName)
+	if crumb := md.BreadcrumbText(); crumb != "" {
+		status += fmt.Sprintf(" [%s]%s[-]", activeColor, tview.Escape(crumb))
+	}
+	if query, current, total := md.SearchStatus(); query != "" {
+		if total == 0 {
+			status += fmt.Sprintf(" | [%s]/%s:[-] [%s]no match[-]", labelColor, tview.Escape(query), mutedColor)
+		} else {
+			status += fmt.Sprintf(" | [%s]/%s:[-] [%s]%d/%d[-]", labelColor, tview.Escape(query), activeColor, current, total)
+		}
+	}
+	status += fmt.Sprintf(" | [%s]Link:[-][%s]Tab/Shift-Tab[-] | [%s]Back:[-]", labelColor, keyColor, labelColor)
 	if canBack {
 		status += fmt.Sprintf("[%s]◀[-]", activeColor)
 	} else {
@@ -270,7 +301,8 @@ func updateStatusBar(statusBar *tview.TextView, v *navtview.TextViewViewer) {
 	} else {
 		status += fmt.Sprintf("[%s]▶[-]", mutedColor)
 	}
-	status += fmt.Sprintf(" | [%s]Scroll:[-][%s]j/k[-] [%s]Top/End:[-][%s]g/G[-] [%s]Refresh:[-][%s]r[-] [%s]Edit:[-][%s]e[-] [%s]Quit:[-][%s]q[-]", labelColor, keyColor, labelColor, keyColor, labelColor, keyColor, labelColor, keyColor, labelColor, keyColor)
+	status += fmt.Sprintf(" | [%s]Scroll:[-][%s]j/k[-] [%s]Top/End:[-][%s]g/G[-] [%s]Find:[-][%s]/ n N[-] [%s]Outline:[-][%s]o[-] [%s]Refresh:[-][%s]r[-] [%s]Edit:[-][%s]e[-] [%s]Quit:[-][%s]q[-]",
+		labelColor, keyColor, labelColor, keyColor, labelColor, keyColor, labelColor, keyColor, labelColor, keyColor, labelColor, keyColor, labelColor, keyColor)
 
 	statusBar.SetText(status)
 }
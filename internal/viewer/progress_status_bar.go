@@ -3,8 +3,8 @@ package viewer
 import (
 	"fmt"
 
-	navtview "github.com/boolean-maybe/navidown/navidown/tview"
 	"github.com/boolean-maybe/tiki/theme"
+	"github.com/boolean-maybe/tiki/view/markdown"
 	"github.com/boolean-maybe/tiki/view/statusline"
 	"github.com/rivo/tview"
 )
@@ -20,7 +20,7 @@ const progressBarCols = 12
 // redraw), so the TextView mutations are safe.
 type progressStatusBar struct {
 	bar       *tview.TextView
-	viewer    func() *navtview.TextViewViewer
+	md        *markdown.NavigableMarkdown
 	animFrame int
 	active    bool
 }
@@ -41,7 +41,7 @@ func (p *progressStatusBar) SetProgress(done, total int) {
 // ClearProgress restores the normal status text.
 func (p *progressStatusBar) ClearProgress() {
 	p.active = false
-	if v := p.viewer(); v != nil {
-		updateStatusBar(p.bar, v)
+	if p.md != nil {
+		updateStatusBar(p.bar, p.md)
 	}
 }
@@ -3,11 +3,13 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
 
 	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/controller"
 	"github.com/boolean-maybe/tiki/internal/app"
 	"github.com/boolean-maybe/tiki/internal/bootstrap"
 	"github.com/boolean-maybe/tiki/internal/pipe"
@@ -67,9 +69,42 @@ func main() {
 		os.Exit(runExec(os.Args[2:]))
 	}
 
+	// Handle publish command: render the workspace to a static site and exit
+	if len(os.Args) > 1 && os.Args[1] == "publish" {
+		os.Exit(runPublish(os.Args[2:]))
+	}
+
+	// Handle sprint command: show or close sprints and exit
+	if len(os.Args) > 1 && os.Args[1] == "sprint" {
+		os.Exit(runSprint(os.Args[2:]))
+	}
+
+	// Handle agenda command: list approaching deadlines and exit
+	if len(os.Args) > 1 && os.Args[1] == "agenda" {
+		os.Exit(runAgenda(os.Args[2:]))
+	}
+
+	// Handle log command: list recent changes from git history and exit
+	if len(os.Args) > 1 && os.Args[1] == "log" {
+		os.Exit(runLog(os.Args[2:]))
+	}
+
+	// Launch flags pick the first screen; strip them so the pipe and viewer
+	// parsers below only see their own arguments
+	launch, args, err := parseLaunchArgs(os.Args[1:])
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(2)
+	}
+
 	// Handle piped stdin: create a tiki and exit without launching TUI
-	if pipe.IsPipedInput() && !pipe.HasPositionalArgs(os.Args[1:]) {
-		tikiID, err := pipe.CreateTikiFromReader(os.Stdin)
+	templateName, err := pipe.TemplateFlag(args)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(2)
+	}
+	if pipe.IsPipedInput() && !pipe.HasPositionalArgs(args) {
+		tikiID, err := pipe.CreateTikiFromReader(os.Stdin, templateName)
 		if err != nil {
 			_, _ = fmt.Fprintln(os.Stderr, "error:", err)
 			os.Exit(1)
@@ -78,8 +113,13 @@ func main() {
 		return
 	}
 
+	if templateName != "" {
+		_, _ = fmt.Fprintln(os.Stderr, "error: --template only applies to piped quick capture")
+		os.Exit(2)
+	}
+
 	// Handle viewer mode (standalone markdown viewer)
-	viewerInput, runViewer, err := viewer.ParseViewerInput(os.Args[1:], map[string]struct{}{"demo": {}, "exec": {}, "workflow": {}})
+	viewerInput, runViewer, err := viewer.ParseViewerInput(args, map[string]struct{}{"agenda": {}, "demo": {}, "exec": {}, "log": {}, "publish": {}, "sprint": {}, "workflow": {}})
 	if err != nil {
 		if errors.Is(err, viewer.ErrMultipleInputs) {
 			_, _ = fmt.Fprintln(os.Stderr, "error:", err)
@@ -89,6 +129,10 @@ func main() {
 		os.Exit(1)
 	}
 	if runViewer {
+		if launch != (bootstrap.LaunchOptions{}) {
+			_, _ = fmt.Fprintln(os.Stderr, "error: --view and --open do not apply to the markdown viewer")
+			os.Exit(2)
+		}
 		if err := viewer.Run(viewerInput); err != nil {
 			_, _ = fmt.Fprintln(os.Stderr, "error:", err)
 			os.Exit(1)
@@ -97,7 +141,7 @@ func main() {
 	}
 
 	// Bootstrap application
-	result, err := bootstrap.Bootstrap()
+	result, err := bootstrap.Bootstrap(launch)
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
 		os.Exit(1)
@@ -131,10 +175,55 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Remember where the user was for the next launch
+	if err := controller.SaveSessionState(config.GetSessionStateFile(), result.Session.Capture()); err != nil {
+		slog.Warn("failed to save session state", "error", err)
+	}
+
 	// Keep logLevel variable referenced so it isn't optimized away in some builds
 	_ = result.LogLevel
 }
 
+// parseLaunchArgs extracts the --view and --open flags (space or = form) and
+// returns the remaining arguments in order. Parsing stops at `--`.
+func parseLaunchArgs(args []string) (bootstrap.LaunchOptions, []string, error) {
+	var opts bootstrap.LaunchOptions
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		var target *string
+		var name, value string
+		switch {
+		case arg == "--view" || arg == "--open":
+			name = arg
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return bootstrap.LaunchOptions{}, nil, fmt.Errorf("%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(arg, "--view=") || strings.HasPrefix(arg, "--open="):
+			name, value, _ = strings.Cut(arg, "=")
+			if value == "" {
+				return bootstrap.LaunchOptions{}, nil, fmt.Errorf("%s requires a value", name)
+			}
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+		if name == "--view" {
+			target = &opts.View
+		} else {
+			target = &opts.Open
+		}
+		*target = value
+	}
+	return opts, rest, nil
+}
+
 // runSysInfo handles the sysinfo command, displaying system and terminal environment information.
 func runSysInfo() error {
 	// Initialize paths first (needed for ConfigDir, CacheDir)
@@ -167,10 +256,17 @@ const (
 type ExecOpts struct {
 	Statement string
 	Format    rukiRuntime.OutputFormat
+	// Template is the row template for `--format template=...`.
+	Template string
+	// File is a ruki script to run instead of Statement ("-" for stdin).
+	File   string
+	Atomic bool
+	DryRun bool
 }
 
 // parseExecArgs parses `tiki exec` arguments, accepting a single ruki
-// statement plus an optional `--format table|json` flag.
+// statement or `--file <script>`, plus the optional `--format` (see
+// parseExecFormat), `--atomic` and `--dry-run` flags.
 //
 // Supports the standard `--` end-of-options marker: every arg after `--` is
 // treated as positional, so ruki statements that legitimately start with `-`
@@ -182,11 +278,12 @@ func parseExecArgs(args []string) (ExecOpts, error) {
 	endOfOptions := false
 
 	setFormat := func(value, origin string) error {
-		fmtVal, err := parseExecFormat(value)
+		fmtVal, tmpl, err := parseExecFormat(value)
 		if err != nil {
 			return fmt.Errorf("%s %s", origin, err.Error())
 		}
 		opts.Format = fmtVal
+		opts.Template = tmpl
 		return nil
 	}
 
@@ -229,6 +326,22 @@ func parseExecArgs(args []string) (ExecOpts, error) {
 				return ExecOpts{}, err
 			}
 
+		case arg == "--file":
+			i++
+			if i >= len(args) {
+				return ExecOpts{}, fmt.Errorf("--file requires a value")
+			}
+			opts.File = args[i] //nolint:gosec // G602: bounds checked above
+
+		case strings.HasPrefix(arg, "--file="):
+			opts.File = strings.TrimPrefix(arg, "--file=")
+
+		case arg == "--atomic":
+			opts.Atomic = true
+
+		case arg == "--dry-run":
+			opts.DryRun = true
+
 		case strings.HasPrefix(arg, "-"):
 			return ExecOpts{}, fmt.Errorf("unknown flag: %s (use -- to pass a statement that starts with '-')", arg)
 
@@ -239,6 +352,12 @@ func parseExecArgs(args []string) (ExecOpts, error) {
 		}
 	}
 
+	if opts.File != "" {
+		if statement != "" {
+			return ExecOpts{}, fmt.Errorf("--file and a statement argument are mutually exclusive")
+		}
+		return opts, nil
+	}
 	if statement == "" {
 		return ExecOpts{}, fmt.Errorf("missing ruki statement")
 	}
@@ -247,20 +366,38 @@ func parseExecArgs(args []string) (ExecOpts, error) {
 	return opts, nil
 }
 
-// parseExecFormat maps the --format value to an OutputFormat. Only `table`
-// and `json` are accepted.
-func parseExecFormat(value string) (rukiRuntime.OutputFormat, error) {
+// parseExecFormat maps the --format value to an OutputFormat. `template=`
+// values also return the template text, parsed up front so syntax errors
+// are usage errors rather than query failures.
+func parseExecFormat(value string) (rukiRuntime.OutputFormat, string, error) {
+	if tmpl, ok := strings.CutPrefix(value, "template="); ok {
+		if tmpl == "" {
+			return 0, "", fmt.Errorf("template must not be empty")
+		}
+		if _, err := rukiRuntime.NewTemplateFormatter(tmpl); err != nil {
+			return 0, "", fmt.Errorf("invalid template: %w", err)
+		}
+		return rukiRuntime.OutputTemplate, tmpl, nil
+	}
 	switch value {
 	case "table":
-		return rukiRuntime.OutputTable, nil
+		return rukiRuntime.OutputTable, "", nil
 	case "json":
-		return rukiRuntime.OutputJSON, nil
+		return rukiRuntime.OutputJSON, "", nil
+	case "csv":
+		return rukiRuntime.OutputCSV, "", nil
+	case "ndjson":
+		return rukiRuntime.OutputNDJSON, "", nil
+	case "yaml":
+		return rukiRuntime.OutputYAML, "", nil
+	case "markdown":
+		return rukiRuntime.OutputMarkdown, "", nil
 	default:
-		return 0, fmt.Errorf("unsupported format %q (supported: table, json)", value)
+		return 0, "", fmt.Errorf("unsupported format %q (supported: table, json, csv, ndjson, yaml, markdown, template=<text>)", value)
 	}
 }
 
-// runExec implements `tiki exec [--format table|json] '<statement>'`. Returns an exit code.
+// runExec implements `tiki exec [options] '<statement>'` and `tiki exec --file <script>`. Returns an exit code.
 func runExec(args []string) int {
 	opts, err := parseExecArgs(args)
 	if err != nil {
@@ -316,24 +453,72 @@ func runExec(args []string) int {
 		_, _ = fmt.Fprintf(os.Stderr, "error: load triggers: %v\n", err)
 		return exitStartupFailure
 	}
+	webhooks, err := service.LoadAndRegisterHooks(gate, schema, config.GetWebhookQueueDir(), service.StoreWebhookActor(tikiStore))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: load hooks: %v\n", err)
+		return exitStartupFailure
+	}
 
-	runOpts := rukiRuntime.RunQueryOptions{OutputFormat: opts.Format}
-	if err := rukiRuntime.RunQueryWithOptions(gate, opts.Statement, os.Stdout, runOpts); err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+	runOpts := rukiRuntime.RunQueryOptions{
+		OutputFormat: opts.Format,
+		Template:     opts.Template,
+		Atomic:       opts.Atomic,
+		DryRun:       opts.DryRun,
+	}
+	var queryErr error
+	if opts.File != "" {
+		queryErr = runExecScript(gate, opts.File, runOpts)
+	} else {
+		queryErr = rukiRuntime.RunQueryWithOptions(gate, opts.Statement, os.Stdout, runOpts)
+	}
+
+	// deliver webhooks queued by this statement before the process exits;
+	// failures stay queued for the next session
+	if err := webhooks.FlushPending(service.CLIWebhookFlushTimeout); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: webhook delivery deferred: %v\n", err)
+	}
+
+	if queryErr != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "error:", queryErr)
 		return exitQueryError
 	}
 
 	return exitOK
 }
 
+// runExecScript reads a ruki script from path ("-" for stdin) and runs it
+// as one transaction.
+func runExecScript(gate *service.TikiMutationGate, path string, opts rukiRuntime.RunQueryOptions) error {
+	var src []byte
+	var err error
+	if path == "-" {
+		src, err = io.ReadAll(os.Stdin)
+	} else {
+		src, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("read script: %w", err)
+	}
+	return rukiRuntime.RunScript(gate, rukiRuntime.SplitScript(string(src)), os.Stdout, opts)
+}
+
 // printExecUsage prints usage for the exec subcommand.
 func printExecUsage() {
 	fmt.Print(`Usage: tiki exec [options] [--] '<ruki-statement>'
+       tiki exec [options] --file <script.ruki>
 
-Execute a ruki statement and exit. Requires an initialized project.
+Execute a ruki statement, or a script of ';'-separated statements, and exit.
+Requires an initialized project.
 
 Options:
-  --format <table|json>    Output format (default: table)
+  --format <format>        Output format (default: table):
+                             table, json, csv, ndjson, yaml, markdown,
+                             or template=<go text/template> (one per row)
+  --file <path>            Run a script ('-' reads stdin). Scripts are atomic:
+                           statements see each other's changes, and all of
+                           them are validated and written together or not at all
+  --atomic                 Apply a single statement's changes all-or-nothing
+  --dry-run                Validate and print the would-be changes; write nothing
   --                       End of options; everything after is the statement
   -h, --help               Show this help message
 
@@ -341,6 +526,10 @@ Examples:
   tiki exec 'select where status = "ready"'
   tiki exec --format json 'select id, title where status = "ready"'
   tiki exec --format=json 'count(select)'
+  tiki exec --format markdown 'select id, title, status where status = "done"'
+  tiki exec --format 'template={{.id}} {{label "status" .status}} {{.title}}' 'select'
+  tiki exec --dry-run 'update where status = "review" set status = "done"'
+  tiki exec --file migrate.ruki
 
   # statements that start with '-' need the -- marker
   # (e.g. a leading '--' ruki line comment)
@@ -355,18 +544,26 @@ func printUsage() {
 
 Usage:
   tiki                       Launch TUI over Markdown in the current directory
-  tiki exec [--format table|json] '<statement>'    Execute a ruki query and exit
+  tiki exec [options] '<statement>'|--file <script>    Execute ruki and exit
+  tiki publish [--out dir]   Render documents and views to a static HTML site
+  tiki sprint [close [NAME]] Show sprint capacity, or close a sprint
+  tiki agenda [--days N] [--user me]  List overdue and upcoming deadlines
+  tiki log [--since 1week] [--user me]  List recent changes from git history
   tiki workflow reset [target]  Reset config files (--global, --current)
   tiki workflow install <source> Install a workflow (--global, --current)
   tiki demo                  Launch demo project (extracts embedded files on first run)
   tiki file.md/URL           View markdown file or image
   echo "Title" | tiki        Create a document from piped input
                                (workflow document or plain document, depending on workflow)
+  echo "Title" | tiki --template bug  Create from a named workflow template
   tiki sysinfo               Display system information
   tiki --help                Show this help message
   tiki --version             Show version
 
 Options:
   --log-level <level>   Set log level (debug, info, warn, error)
+  --template <name>     Start piped quick capture from a workflow template
+  --view <name>         Open the named view instead of the saved session
+  --open <ID>           Open a tiki on launch
 `)
 }
@@ -62,6 +62,41 @@ func TestParseExecArgs_FormatJSONSpaceAndEqual(t *testing.T) {
 	}
 }
 
+func TestParseExecArgs_ExportFormats(t *testing.T) {
+	for value, want := range map[string]rukiRuntime.OutputFormat{
+		"csv":      rukiRuntime.OutputCSV,
+		"ndjson":   rukiRuntime.OutputNDJSON,
+		"yaml":     rukiRuntime.OutputYAML,
+		"markdown": rukiRuntime.OutputMarkdown,
+	} {
+		t.Run(value, func(t *testing.T) {
+			opts, err := parseExecArgs([]string{"--format=" + value, "select"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if opts.Format != want || opts.Template != "" {
+				t.Errorf("got format %v template %q, want %v", opts.Format, opts.Template, want)
+			}
+		})
+	}
+}
+
+func TestParseExecArgs_TemplateFormat(t *testing.T) {
+	opts, err := parseExecArgs([]string{"--format", "template={{.id}} {{.title}}", "select id, title"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Format != rukiRuntime.OutputTemplate || opts.Template != "{{.id}} {{.title}}" {
+		t.Errorf("got format %v template %q", opts.Format, opts.Template)
+	}
+
+	for _, value := range []string{"template=", "template={{.id"} {
+		if _, err := parseExecArgs([]string{"--format=" + value, "select"}); err == nil {
+			t.Errorf("expected error for --format=%s", value)
+		}
+	}
+}
+
 func TestParseExecArgs_MissingFormatValue(t *testing.T) {
 	_, err := parseExecArgs([]string{"--format"})
 	if err == nil {
@@ -73,7 +108,7 @@ func TestParseExecArgs_MissingFormatValue(t *testing.T) {
 }
 
 func TestParseExecArgs_UnsupportedFormat(t *testing.T) {
-	_, err := parseExecArgs([]string{"--format", "yaml", "select"})
+	_, err := parseExecArgs([]string{"--format", "xml", "select"})
 	if err == nil {
 		t.Fatal("expected error for unsupported format")
 	}
@@ -82,7 +117,7 @@ func TestParseExecArgs_UnsupportedFormat(t *testing.T) {
 	}
 
 	// also via --format=
-	_, err = parseExecArgs([]string{"--format=yaml", "select"})
+	_, err = parseExecArgs([]string{"--format=xml", "select"})
 	if err == nil {
 		t.Fatal("expected error for unsupported format (equal form)")
 	}
@@ -201,3 +236,52 @@ func TestParseExecArgs_UnknownFlagHintsAtDoubleDash(t *testing.T) {
 		t.Errorf("error should mention -- as escape, got: %v", err)
 	}
 }
+
+func TestParseExecArgs_FileAtomicDryRun(t *testing.T) {
+	opts, err := parseExecArgs([]string{"--file", "migrate.ruki", "--dry-run"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.File != "migrate.ruki" || !opts.DryRun || opts.Statement != "" {
+		t.Errorf("got %+v", opts)
+	}
+
+	opts, err = parseExecArgs([]string{"--atomic", "--file=-"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.File != "-" || !opts.Atomic {
+		t.Errorf("got %+v", opts)
+	}
+
+	if _, err := parseExecArgs([]string{"--file", "a.ruki", "select"}); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected mutually exclusive error, got %v", err)
+	}
+	if _, err := parseExecArgs([]string{"--file"}); err == nil || !strings.Contains(err.Error(), "requires a value") {
+		t.Errorf("expected missing value error, got %v", err)
+	}
+}
+
+func TestParseLaunchArgs(t *testing.T) {
+	opts, rest, err := parseLaunchArgs([]string{"--view", "Backlog", "--log-level", "debug", "--open=ABC123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.View != "Backlog" || opts.Open != "ABC123" {
+		t.Errorf("opts = %+v, want View=Backlog Open=ABC123", opts)
+	}
+	if strings.Join(rest, " ") != "--log-level debug" {
+		t.Errorf("rest = %v, want [--log-level debug]", rest)
+	}
+
+	_, rest, err = parseLaunchArgs([]string{"--", "--view", "x"})
+	if err != nil || len(rest) != 3 {
+		t.Errorf("args after -- must pass through: rest=%v err=%v", rest, err)
+	}
+
+	for _, args := range [][]string{{"--view"}, {"--open", "--log-level"}, {"--view="}} {
+		if _, _, err := parseLaunchArgs(args); err == nil || !strings.Contains(err.Error(), "requires a value") {
+			t.Errorf("parseLaunchArgs(%v) err = %v, want requires a value", args, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"sync"
+	"time"
+)
+
+// CalendarState holds the selection of a calendar view: the focused day,
+// the entry selected within it and whether the view shows a month grid or a
+// week agenda. Days are midnight UTC, matching stored date fields.
+type CalendarState struct {
+	mu             sync.RWMutex
+	day            time.Time
+	index          int
+	week           bool
+	listeners      map[int]PluginSelectionListener
+	nextListenerID int
+}
+
+// NewCalendarState creates calendar state focused on today.
+func NewCalendarState(week bool) *CalendarState {
+	return &CalendarState{
+		day:            CalendarDay(time.Now()),
+		week:           week,
+		listeners:      make(map[int]PluginSelectionListener),
+		nextListenerID: 1,
+	}
+}
+
+// CalendarDay truncates t to midnight UTC of its calendar date.
+func CalendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// GetDay returns the focused day.
+func (cs *CalendarState) GetDay() time.Time {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.day
+}
+
+// GetIndex returns the index of the selected entry within the focused day.
+func (cs *CalendarState) GetIndex() int {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.index
+}
+
+// IsWeekMode reports whether the view shows the week agenda.
+func (cs *CalendarState) IsWeekMode() bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.week
+}
+
+// SetDay focuses a day and selects the entry at index within it.
+func (cs *CalendarState) SetDay(day time.Time, index int) {
+	cs.mu.Lock()
+	cs.day = CalendarDay(day)
+	cs.index = max(index, 0)
+	cs.mu.Unlock()
+	cs.notifyListeners()
+}
+
+// SetIndex selects an entry within the focused day.
+func (cs *CalendarState) SetIndex(index int) {
+	cs.mu.Lock()
+	cs.index = max(index, 0)
+	cs.mu.Unlock()
+	cs.notifyListeners()
+}
+
+// ToggleWeekMode switches between the month grid and the week agenda.
+func (cs *CalendarState) ToggleWeekMode() {
+	cs.mu.Lock()
+	cs.week = !cs.week
+	cs.mu.Unlock()
+	cs.notifyListeners()
+}
+
+// AddListener registers a callback for selection and mode changes.
+func (cs *CalendarState) AddListener(listener PluginSelectionListener) int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	id := cs.nextListenerID
+	cs.nextListenerID++
+	cs.listeners[id] = listener
+	return id
+}
+
+// RemoveListener removes a listener by ID.
+func (cs *CalendarState) RemoveListener(id int) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	delete(cs.listeners, id)
+}
+
+func (cs *CalendarState) notifyListeners() {
+	cs.mu.RLock()
+	listeners := make([]PluginSelectionListener, 0, len(cs.listeners))
+	for _, l := range cs.listeners {
+		listeners = append(listeners, l)
+	}
+	cs.mu.RUnlock()
+
+	for _, l := range listeners {
+		l()
+	}
+}
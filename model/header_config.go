@@ -16,6 +16,15 @@ type HeaderAction struct {
 	Modifier     tcell.ModMask
 	ShowInHeader bool
 	Enabled      bool
+	// Then holds the remaining keys of a multi-key binding (e.g. "g g").
+	Then []KeyStroke
+}
+
+// KeyStroke is a single key of a multi-key binding.
+type KeyStroke struct {
+	Key      tcell.Key
+	Rune     rune
+	Modifier tcell.ModMask
 }
 
 // StatValue represents a single stat entry for the statusline
@@ -10,6 +10,7 @@ type MarkdownTreeConfig struct {
 	visible  bool
 	onSelect func(relPath string)
 	onCancel func()
+	dirs     map[string]bool // folder expand state, kept across openings
 
 	listeners    map[int]func()
 	nextListener int
@@ -76,6 +77,29 @@ func (mc *MarkdownTreeConfig) Cancel() {
 	mc.SetVisible(false)
 }
 
+// GetExpandedDirs returns a copy of the remembered folder expand state,
+// keyed by folder path relative to the document root. Folders absent from
+// the map use the tree's default (top level expanded).
+func (mc *MarkdownTreeConfig) GetExpandedDirs() map[string]bool {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	out := make(map[string]bool, len(mc.dirs))
+	for k, v := range mc.dirs {
+		out[k] = v
+	}
+	return out
+}
+
+// SetExpandedDirs replaces the remembered folder expand state.
+func (mc *MarkdownTreeConfig) SetExpandedDirs(dirs map[string]bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.dirs = make(map[string]bool, len(dirs))
+	for k, v := range dirs {
+		mc.dirs[k] = v
+	}
+}
+
 func (mc *MarkdownTreeConfig) AddListener(listener func()) int {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
@@ -2,6 +2,7 @@ package model
 
 import (
 	"log/slog"
+	"sort"
 	"sync"
 
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
@@ -17,8 +18,9 @@ type PluginConfig struct {
 	selectedLane     int
 	selectedIndices  []int
 	laneColumns      []int
-	laneWidths       []int // per-lane width proportion (0 = equal share)
-	scrollOffsets    []int // per-lane viewport position (top visible row)
+	laneWidths       []int           // per-lane width proportion (0 = equal share)
+	scrollOffsets    []int           // per-lane viewport position (top visible row)
+	collapsedBands   map[string]bool // swimlane bands folded to their header, by name
 	preSearchLane    int
 	preSearchIndices []int
 	listeners        map[int]PluginSelectionListener
@@ -131,6 +133,27 @@ func (pc *PluginConfig) SetScrollOffsetForLane(lane int, offset int) {
 	pc.scrollOffsets[lane] = offset
 }
 
+// GetScrollOffsets returns a copy of every lane's scroll offset.
+func (pc *PluginConfig) GetScrollOffsets() []int {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	out := make([]int, len(pc.scrollOffsets))
+	copy(out, pc.scrollOffsets)
+	return out
+}
+
+// SetScrollOffsets restores lane scroll offsets; entries beyond the lane
+// count are ignored and negative offsets are treated as zero.
+func (pc *PluginConfig) SetScrollOffsets(offsets []int) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	for lane := range pc.scrollOffsets {
+		if lane < len(offsets) && offsets[lane] > 0 {
+			pc.scrollOffsets[lane] = offsets[lane]
+		}
+	}
+}
+
 func (pc *PluginConfig) SetSelectedLaneAndIndex(lane int, idx int) {
 	pc.mu.Lock()
 	if lane < 0 || lane >= len(pc.selectedIndices) {
@@ -151,6 +174,68 @@ func (pc *PluginConfig) SetSelectedLaneAndIndex(lane int, idx int) {
 	}
 }
 
+// IsBandCollapsed reports whether the named swimlane band is folded.
+func (pc *PluginConfig) IsBandCollapsed(name string) bool {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	return pc.collapsedBands[name]
+}
+
+// ToggleBandCollapsed folds or unfolds the named swimlane band.
+func (pc *PluginConfig) ToggleBandCollapsed(name string) {
+	pc.mu.Lock()
+	if pc.collapsedBands == nil {
+		pc.collapsedBands = make(map[string]bool)
+	}
+	if pc.collapsedBands[name] {
+		delete(pc.collapsedBands, name)
+	} else {
+		pc.collapsedBands[name] = true
+	}
+	pc.mu.Unlock()
+	pc.notifyListeners()
+}
+
+// ExpandAllBands unfolds every swimlane band. Returns false when none was folded.
+func (pc *PluginConfig) ExpandAllBands() bool {
+	pc.mu.Lock()
+	had := len(pc.collapsedBands) > 0
+	pc.collapsedBands = nil
+	pc.mu.Unlock()
+	if had {
+		pc.notifyListeners()
+	}
+	return had
+}
+
+// GetCollapsedBands returns the folded band names, sorted; nil when none is.
+func (pc *PluginConfig) GetCollapsedBands() []string {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	if len(pc.collapsedBands) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(pc.collapsedBands))
+	for name := range pc.collapsedBands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetCollapsedBands replaces the folded band set (restored sessions).
+func (pc *PluginConfig) SetCollapsedBands(names []string) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.collapsedBands = nil
+	for _, name := range names {
+		if pc.collapsedBands == nil {
+			pc.collapsedBands = make(map[string]bool, len(names))
+		}
+		pc.collapsedBands[name] = true
+	}
+}
+
 // GetColumnsForLane returns the number of grid columns for a lane.
 func (pc *PluginConfig) GetColumnsForLane(lane int) int {
 	pc.mu.RLock()
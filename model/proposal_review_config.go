@@ -0,0 +1,179 @@
+package model
+
+import "sync"
+
+// ReviewItem is one proposed change shown in the review overlay: a one-line
+// summary and its diff. Diff lines start with "+ " (added), "- " (removed)
+// or "  " (unchanged context).
+type ReviewItem struct {
+	Summary string
+	Diff    []string
+}
+
+// ProposalReviewConfig manages the review overlay for changes proposed by an
+// AI agent: the items on show, which of them are accepted, and the callbacks
+// run when the user applies or discards the proposal.
+type ProposalReviewConfig struct {
+	mu sync.RWMutex
+
+	visible  bool
+	title    string
+	items    []ReviewItem
+	accepted []bool
+	cursor   int
+	onApply  func(accepted []bool)
+	onCancel func()
+
+	listeners    map[int]func()
+	nextListener int
+}
+
+// NewProposalReviewConfig creates a new config (hidden by default).
+func NewProposalReviewConfig() *ProposalReviewConfig {
+	return &ProposalReviewConfig{
+		listeners:    make(map[int]func()),
+		nextListener: 1,
+	}
+}
+
+// Show opens the overlay on items, all accepted. onApply receives the
+// accepted flags, index-aligned with items; onCancel may be nil.
+func (rc *ProposalReviewConfig) Show(title string, items []ReviewItem, onApply func(accepted []bool), onCancel func()) {
+	rc.mu.Lock()
+	rc.visible = true
+	rc.title = title
+	rc.items = items
+	rc.accepted = make([]bool, len(items))
+	for i := range rc.accepted {
+		rc.accepted[i] = true
+	}
+	rc.cursor = 0
+	rc.onApply = onApply
+	rc.onCancel = onCancel
+	rc.mu.Unlock()
+	rc.notifyListeners()
+}
+
+func (rc *ProposalReviewConfig) IsVisible() bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.visible
+}
+
+// Title returns the overlay title (usually the action label).
+func (rc *ProposalReviewConfig) Title() string {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.title
+}
+
+// Items returns the proposed changes on show.
+func (rc *ProposalReviewConfig) Items() []ReviewItem {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.items
+}
+
+// IsAccepted reports whether item i will be applied.
+func (rc *ProposalReviewConfig) IsAccepted(i int) bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return i >= 0 && i < len(rc.accepted) && rc.accepted[i]
+}
+
+// Cursor returns the index of the focused item.
+func (rc *ProposalReviewConfig) Cursor() int {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.cursor
+}
+
+// MoveCursor moves the focus by delta items, clamped to the list.
+func (rc *ProposalReviewConfig) MoveCursor(delta int) {
+	rc.mu.Lock()
+	next := max(0, min(rc.cursor+delta, len(rc.items)-1))
+	changed := next != rc.cursor
+	rc.cursor = next
+	rc.mu.Unlock()
+	if changed {
+		rc.notifyListeners()
+	}
+}
+
+// ToggleCurrent flips whether the focused item is accepted.
+func (rc *ProposalReviewConfig) ToggleCurrent() {
+	rc.mu.Lock()
+	if rc.cursor >= len(rc.accepted) {
+		rc.mu.Unlock()
+		return
+	}
+	rc.accepted[rc.cursor] = !rc.accepted[rc.cursor]
+	rc.mu.Unlock()
+	rc.notifyListeners()
+}
+
+// Apply hides the overlay, then invokes the apply callback with the
+// accepted flags.
+func (rc *ProposalReviewConfig) Apply() {
+	rc.mu.RLock()
+	fn := rc.onApply
+	accepted := append([]bool(nil), rc.accepted...)
+	rc.mu.RUnlock()
+	rc.hide()
+	if fn != nil {
+		fn(accepted)
+	}
+}
+
+// Cancel hides the overlay, then invokes the cancel callback.
+func (rc *ProposalReviewConfig) Cancel() {
+	rc.mu.RLock()
+	fn := rc.onCancel
+	rc.mu.RUnlock()
+	rc.hide()
+	if fn != nil {
+		fn()
+	}
+}
+
+func (rc *ProposalReviewConfig) hide() {
+	rc.mu.Lock()
+	changed := rc.visible
+	rc.visible = false
+	rc.items = nil
+	rc.accepted = nil
+	rc.onApply = nil
+	rc.onCancel = nil
+	rc.mu.Unlock()
+	if changed {
+		rc.notifyListeners()
+	}
+}
+
+func (rc *ProposalReviewConfig) AddListener(listener func()) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	id := rc.nextListener
+	rc.nextListener++
+	rc.listeners[id] = listener
+	return id
+}
+
+func (rc *ProposalReviewConfig) RemoveListener(id int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.listeners, id)
+}
+
+func (rc *ProposalReviewConfig) notifyListeners() {
+	rc.mu.RLock()
+	listeners := make([]func(), 0, len(rc.listeners))
+	for _, l := range rc.listeners {
+		listeners = append(listeners, l)
+	}
+	rc.mu.RUnlock()
+
+	for _, l := range listeners {
+		l()
+	}
+}
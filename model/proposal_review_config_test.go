@@ -0,0 +1,48 @@
+package model
+
+import "testing"
+
+func TestProposalReviewConfig_ToggleAndApply(t *testing.T) {
+	rc := NewProposalReviewConfig()
+	notified := 0
+	rc.AddListener(func() { notified++ })
+
+	var got []bool
+	rc.Show("Split", []ReviewItem{{Summary: "a"}, {Summary: "b"}, {Summary: "c"}},
+		func(accepted []bool) { got = accepted }, nil)
+	if !rc.IsVisible() || notified != 1 {
+		t.Fatalf("visible = %v, notified = %d after Show", rc.IsVisible(), notified)
+	}
+	if !rc.IsAccepted(0) || !rc.IsAccepted(2) {
+		t.Fatal("items should start accepted")
+	}
+
+	rc.MoveCursor(1)
+	rc.ToggleCurrent()
+	rc.MoveCursor(5) // clamps to the last item
+	if rc.Cursor() != 2 {
+		t.Fatalf("cursor = %d, want 2", rc.Cursor())
+	}
+
+	rc.Apply()
+	if rc.IsVisible() {
+		t.Fatal("should be hidden after Apply")
+	}
+	if len(got) != 3 || !got[0] || got[1] || !got[2] {
+		t.Fatalf("accepted = %v, want [true false true]", got)
+	}
+}
+
+func TestProposalReviewConfig_CancelInvokesCallbackAndHides(t *testing.T) {
+	rc := NewProposalReviewConfig()
+	cancelled, applied := false, false
+	rc.Show("Triage", []ReviewItem{{Summary: "a"}}, func([]bool) { applied = true }, func() { cancelled = true })
+
+	rc.Cancel()
+	if !cancelled || applied {
+		t.Fatalf("cancelled = %v, applied = %v", cancelled, applied)
+	}
+	if rc.IsVisible() || len(rc.Items()) != 0 {
+		t.Fatal("should be hidden and cleared after Cancel")
+	}
+}
@@ -0,0 +1,41 @@
+package model
+
+// SessionState is the UI state tiki saves per workspace on exit and restores
+// on the next launch: the navigation stack, each view's selection, scroll
+// position and search text, and the markdown tree's expanded folders. It is
+// plain data — the controller layer captures and reapplies it, so every field
+// is a hint that is dropped when it no longer matches the workspace (a
+// deleted tiki, a renamed view).
+type SessionState struct {
+	// Views is the navigation stack, bottom first; the last entry is the
+	// view that was active on exit.
+	Views []SessionView `json:"views,omitempty"`
+	// Plugins holds per-view selection state, keyed by view name.
+	Plugins map[string]PluginSession `json:"plugins,omitempty"`
+	// TreeDirs records the expand/collapse state of markdown-tree folders
+	// the user toggled, keyed by folder path relative to the document root.
+	TreeDirs map[string]bool `json:"treeDirs,omitempty"`
+}
+
+// SessionView is one navigation stack entry. TikiID and DocumentPath mirror
+// the PluginViewParams the view was opened with; for a wiki view
+// DocumentPath is the document last shown, so link navigation survives.
+type SessionView struct {
+	Name         string `json:"name"`
+	TikiID       string `json:"tikiId,omitempty"`
+	DocumentPath string `json:"documentPath,omitempty"`
+}
+
+// PluginSession is the selection state of a board or list view.
+type PluginSession struct {
+	SelectedTikiID string   `json:"selectedTikiId,omitempty"`
+	Lane           int      `json:"lane,omitempty"`
+	ScrollOffsets  []int    `json:"scrollOffsets,omitempty"`
+	Search         string   `json:"search,omitempty"`
+	CollapsedBands []string `json:"collapsedBands,omitempty"`
+}
+
+// IsEmpty reports whether the session carries nothing to restore.
+func (s SessionState) IsEmpty() bool {
+	return len(s.Views) == 0 && len(s.Plugins) == 0 && len(s.TreeDirs) == 0
+}
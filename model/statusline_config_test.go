@@ -54,6 +54,23 @@ func TestStatuslineConfig_LeftStats(t *testing.T) {
 	}
 }
 
+func TestStatuslineConfig_RemoveLeftStat(t *testing.T) {
+	sc := NewStatuslineConfig()
+	sc.SetLeftStat("Overdue", "2 overdue", 5)
+
+	callCount := 0
+	sc.AddListener(func() { callCount++ })
+
+	sc.RemoveLeftStat("Overdue")
+	if _, ok := sc.GetLeftStats()["Overdue"]; ok {
+		t.Error("Overdue stat still present after RemoveLeftStat")
+	}
+	sc.RemoveLeftStat("Overdue")
+	if callCount != 1 {
+		t.Errorf("callCount = %d, want 1 (removing an absent stat is a no-op)", callCount)
+	}
+}
+
 func TestStatuslineConfig_ViewStats(t *testing.T) {
 	sc := NewStatuslineConfig()
 
@@ -18,6 +18,22 @@ const (
 	KindDetail ViewKind = "detail"
 	KindSearch ViewKind = "search"
 
+	// KindDependencies renders the dependency tree and critical path of the
+	// selected tiki. Only valid when the workflow declares dependencies.
+	KindDependencies ViewKind = "dependencies"
+
+	// KindCalendar places tikis on a month grid or week agenda by a date
+	// field.
+	KindCalendar ViewKind = "calendar"
+
+	// KindSprints renders sprint planning: each sprint's committed points
+	// against its capacity. Only useful when the workflow declares sprints.
+	KindSprints ViewKind = "sprints"
+
+	// KindActivity renders a feed of recent changes across the workspace:
+	// this session's edits merged with committed git history.
+	KindActivity ViewKind = "activity"
+
 	// KindTimeline is reserved for a later phase; parser rejects it with a
 	// dedicated "not yet implemented" error so users don't confuse the
 	// rejection with the generic unknown-kind diagnostic.
@@ -29,7 +45,7 @@ const (
 // and are handled by a dedicated rejection message.
 func IsValidKind(s string) bool {
 	switch ViewKind(s) {
-	case KindBoard, KindList, KindWiki, KindDetail:
+	case KindBoard, KindList, KindWiki, KindDetail, KindDependencies, KindCalendar, KindSprints, KindActivity:
 		return true
 	}
 	return false
@@ -113,9 +129,10 @@ func (p *BasePlugin) GetRequire() []string {
 // GetKind() distinguishes board vs list.
 type WorkflowPlugin struct {
 	BasePlugin
-	Lanes   []TikiLane          // lane definitions for this plugin
-	Layout  gridlayout.GridSpec // parsed layout grid for tiki-box rendering (see workflow-format.md)
-	Actions []PluginAction      // shortcut actions applied to the selected tiki
+	Lanes     []TikiLane          // lane definitions for this plugin
+	Swimlanes *Swimlanes          // optional second axis (boards only); nil when absent
+	Layout    gridlayout.GridSpec // parsed layout grid for tiki-box rendering (see workflow-format.md)
+	Actions   []PluginAction      // shortcut actions applied to the selected tiki
 }
 
 // WikiPlugin backs the wiki view kind (markdown document rendering bound to a
@@ -140,6 +157,63 @@ type DetailPlugin struct {
 	Actions []PluginAction      // per-view shortcut actions (merged with globals at runtime)
 }
 
+// DependencyPlugin backs the dependencies view kind: the dependency tree of
+// the selected tiki with its blockers and critical path. It has no
+// configuration beyond the common view fields.
+type DependencyPlugin struct {
+	BasePlugin
+}
+
+// SprintPlugin backs the sprints view kind: committed points against
+// capacity for every sprint of the workflow's sprints: section. It has no
+// configuration beyond the common view fields.
+type SprintPlugin struct {
+	BasePlugin
+}
+
+// ActivityPlugin backs the activity view kind: recent changes to tikis,
+// newest first. Since is kept as written ("today", "2day", a date) and
+// resolved each time the feed renders. User "me" stands for the current
+// user; an empty User or Field shows every actor or field.
+type ActivityPlugin struct {
+	BasePlugin
+	Since string
+	User  string
+	Field string
+}
+
+// Calendar display modes.
+const (
+	CalendarMonth = "month"
+	CalendarWeek  = "week"
+)
+
+// CalendarPlugin backs the calendar view kind: the tikis selected by Filter,
+// placed on the day held in DateField. Tikis with a RecurrenceField value
+// also appear on each of their future occurrences.
+type CalendarPlugin struct {
+	BasePlugin
+	Filter          *ruki.ValidatedStatement // nil selects every tiki with a date
+	DateField       string
+	RecurrenceField string // empty when recurring tikis are not expanded
+	Mode            string // initial mode: CalendarMonth or CalendarWeek
+}
+
+// PluginCalendarConfig represents the `calendar:` block of a calendar view.
+type PluginCalendarConfig struct {
+	Filter     string `yaml:"filter" mapstructure:"filter"`
+	Field      string `yaml:"field" mapstructure:"field"`
+	Recurrence string `yaml:"recurrence" mapstructure:"recurrence"`
+	Mode       string `yaml:"mode" mapstructure:"mode"`
+}
+
+// PluginActivityConfig represents the `activity:` block of an activity view.
+type PluginActivityConfig struct {
+	Since string `yaml:"since" mapstructure:"since"`
+	User  string `yaml:"user" mapstructure:"user"`
+	Field string `yaml:"field" mapstructure:"field"`
+}
+
 // PluginActionConfig represents a shortcut action in YAML or config definitions.
 // A PluginActionConfig models either a ruki-executing action (Action is set)
 // or a view-switching action (View is set). Exactly one must be set.
@@ -160,15 +234,21 @@ type PluginActionConfig struct {
 	Choose  string   `yaml:"choose,omitempty" mapstructure:"choose"`
 	Hot     *bool    `yaml:"hot,omitempty" mapstructure:"hot"`
 	Input   string   `yaml:"input,omitempty" mapstructure:"input"`
+	Agent   string   `yaml:"agent,omitempty" mapstructure:"agent"`
+	Prompt  string   `yaml:"prompt,omitempty" mapstructure:"prompt"`
 	Require []string `yaml:"require,omitempty" mapstructure:"require"`
 }
 
-// ActionKind distinguishes ruki-executing actions from view-switching actions.
+// ActionKind distinguishes ruki-executing actions from view-switching
+// actions, from actions that hand the selected tiki to an AI agent, and from
+// actions that ask an agent for changes to review.
 type ActionKind string
 
 const (
-	ActionKindRuki ActionKind = "ruki"
-	ActionKindView ActionKind = "view"
+	ActionKindRuki    ActionKind = "ruki"
+	ActionKindView    ActionKind = "view"
+	ActionKindChat    ActionKind = "chat"
+	ActionKindPropose ActionKind = "propose"
 )
 
 // DetailMode is the closed vocabulary for the optional `mode:` field on
@@ -208,14 +288,20 @@ type PluginAction struct {
 	// CreateSeed is the optional ruki create statement declared via `action:`
 	// on a `mode: new` view action. When set, the dispatcher runs it (without
 	// persisting) to produce the form's seeded draft. nil for a bare mode: new.
-	CreateSeed   *ruki.ValidatedStatement
-	TargetView   string // for Kind == ActionKindView: name of the view to open
-	ShowInHeader bool
-	InputType    ruki.ValueType
-	HasInput     bool
-	HasChoose    bool
-	ChooseFilter *ruki.SubQuery
-	Require      []string // effective requirements after auto-inference from id() usage
+	CreateSeed *ruki.ValidatedStatement
+	// CreateTemplate names the workflow template from a `create from "<name>"`
+	// action or seed. The create statement itself has already been rewritten
+	// to a plain create; "" means the catalog defaults alone.
+	CreateTemplate string
+	TargetView     string // for Kind == ActionKindView: name of the view to open
+	Agent          string // for Kind == ActionKindChat or ActionKindPropose: AI tool key; "" means the configured ai.agent
+	Prompt         string // for Kind == ActionKindPropose: the request sent to the agent
+	ShowInHeader   bool
+	InputType      ruki.ValueType
+	HasInput       bool
+	HasChoose      bool
+	ChooseFilter   *ruki.SubQuery
+	Require        []string // effective requirements after auto-inference from id() usage
 }
 
 // PluginLaneConfig represents a lane in YAML or config definitions.
@@ -225,6 +311,37 @@ type PluginLaneConfig struct {
 	Width   int    `yaml:"width" mapstructure:"width"`
 	Filter  string `yaml:"filter" mapstructure:"filter"`
 	Action  string `yaml:"action" mapstructure:"action"`
+
+	Limit        int    `yaml:"limit" mapstructure:"limit"`
+	EnforceLimit bool   `yaml:"enforceLimit" mapstructure:"enforceLimit"`
+	Summary      string `yaml:"summary" mapstructure:"summary"`
+}
+
+// PluginSwimlanesConfig represents the `swimlanes:` block of a board. Exactly
+// one of GroupBy and Bands is set.
+type PluginSwimlanesConfig struct {
+	GroupBy string             `yaml:"groupBy" mapstructure:"groupBy"`
+	Bands   []PluginLaneConfig `yaml:"bands" mapstructure:"bands"`
+}
+
+// Swimlanes splits every lane of a board into horizontal bands. With GroupBy
+// there is one band per distinct value of that field; otherwise Bands lists
+// the bands explicitly, and a tiki lands in the first band whose filter
+// matches it.
+type Swimlanes struct {
+	GroupBy     string
+	GroupByType ruki.ValueType
+	Enum        []string // allowed values, in band order, for an enum GroupBy
+	Bands       []Swimlane
+}
+
+// Swimlane is an explicit band: a filter selecting its tikis and an optional
+// action run when a tiki is moved into it.
+type Swimlane struct {
+	Name        string
+	Filter      *ruki.ValidatedStatement
+	Action      *ruki.ValidatedStatement
+	MoveRequire []string // requirements the moved tiki must meet, inferred from Action
 }
 
 // TikiLane represents a parsed lane definition.
@@ -234,4 +351,11 @@ type TikiLane struct {
 	Width   int // lane width as a percentage (0 = equal share of remaining space)
 	Filter  *ruki.ValidatedStatement
 	Action  *ruki.ValidatedStatement
+	// MoveRequire holds the requirements a tiki must meet to be moved into
+	// the lane, inferred from Action
+	MoveRequire []string
+
+	Limit        int          // WIP limit shown as count/limit in the header (0 = none)
+	EnforceLimit bool         // reject mutations that would push the lane past Limit
+	Summary      *LaneSummary // aggregate shown beside the lane name, nil for none
 }
@@ -0,0 +1,141 @@
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/workflow"
+	"github.com/boolean-maybe/tiki/workflow/value"
+)
+
+// Lane summary aggregates. count() takes no field; the others fold a numeric
+// field over the lane's tikis.
+const (
+	SummaryCount = "count"
+	SummarySum   = "sum"
+	SummaryAvg   = "avg"
+	SummaryMin   = "min"
+	SummaryMax   = "max"
+)
+
+// LaneSummary is a parsed `summary:` aggregate such as sum(points).
+type LaneSummary struct {
+	Func  string
+	Field string // empty for count()
+}
+
+var laneSummaryPattern = regexp.MustCompile(`^(\w+)\(\s*(\w*)\s*\)$`)
+
+// parseLaneSummary validates a lane `summary:` expression. Aggregated fields
+// must hold numbers: integer and number fields, or enums whose values are
+// all numeric (story points declared as an enum of 1, 2, 3, 5, ...).
+func parseLaneSummary(expr string, schema ruki.Schema) (*LaneSummary, error) {
+	m := laneSummaryPattern.FindStringSubmatch(strings.TrimSpace(expr))
+	if m == nil {
+		return nil, fmt.Errorf("summary %q: want count() or sum|avg|min|max(field)", expr)
+	}
+	fn, field := m[1], m[2]
+	switch fn {
+	case SummaryCount:
+		if field != "" {
+			return nil, fmt.Errorf("summary %q: count() takes no field", expr)
+		}
+		return &LaneSummary{Func: fn}, nil
+	case SummarySum, SummaryAvg, SummaryMin, SummaryMax:
+	default:
+		return nil, fmt.Errorf("summary %q: unknown aggregate %q (want count, sum, avg, min or max)", expr, fn)
+	}
+	if field == "" {
+		return nil, fmt.Errorf("summary %q: %s() needs a field", expr, fn)
+	}
+	if workflow.IsNumberField(schema, field) {
+		return &LaneSummary{Func: fn, Field: field}, nil
+	}
+	spec, ok := schema.Field(field)
+	if !ok {
+		return nil, fmt.Errorf("summary %q: unknown field %q", expr, field)
+	}
+	if !isNumericField(spec) {
+		return nil, fmt.Errorf("summary %q: field %q is not numeric", expr, field)
+	}
+	return &LaneSummary{Func: fn, Field: spec.Name}, nil
+}
+
+func isNumericField(spec ruki.FieldSpec) bool {
+	switch spec.Type {
+	case ruki.ValueInt:
+		return true
+	case ruki.ValueEnum:
+		if len(spec.AllowedValues) == 0 {
+			return false
+		}
+		for _, v := range spec.AllowedValues {
+			if _, ok := value.ParseNumber(v); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Aggregate folds values (one per tiki, ok=false where the field is unset)
+// into the summary's result. count() counts every tiki; the field aggregates
+// skip unset values, and avg/min/max report ok=false when none are set.
+func (s *LaneSummary) Aggregate(values []float64, set []bool) (float64, bool) {
+	if s.Func == SummaryCount {
+		return float64(len(values)), true
+	}
+	total, n := 0.0, 0
+	var lo, hi float64
+	for i, v := range values {
+		if !set[i] {
+			continue
+		}
+		if n == 0 || v < lo {
+			lo = v
+		}
+		if n == 0 || v > hi {
+			hi = v
+		}
+		total += v
+		n++
+	}
+	switch s.Func {
+	case SummarySum:
+		return total, true
+	case SummaryAvg:
+		if n == 0 {
+			return 0, false
+		}
+		return total / float64(n), true
+	case SummaryMin:
+		return lo, n > 0
+	default:
+		return hi, n > 0
+	}
+}
+
+// Format renders an aggregate result for a lane header: "Σ12", "⌀4.5",
+// "min 1", "max 8", or the bare count.
+func (s *LaneSummary) Format(result float64, ok bool) string {
+	text := "–"
+	if ok {
+		if s.Func == SummaryAvg {
+			result = float64(int64(result*10+0.5)) / 10
+		}
+		text = value.FormatNumber(result)
+	}
+	switch s.Func {
+	case SummarySum:
+		return "Σ" + text
+	case SummaryAvg:
+		return "⌀" + text
+	case SummaryMin, SummaryMax:
+		return s.Func + " " + text
+	default:
+		return text
+	}
+}
@@ -0,0 +1,119 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func TestParseLaneSummary(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    LaneSummary
+		wantErr string
+	}{
+		{expr: "count()", want: LaneSummary{Func: SummaryCount}},
+		{expr: " sum( points ) ", want: LaneSummary{Func: SummarySum, Field: "points"}},
+		{expr: "avg(points)", want: LaneSummary{Func: SummaryAvg, Field: "points"}},
+		{expr: "count(points)", wantErr: "takes no field"},
+		{expr: "sum()", wantErr: "needs a field"},
+		{expr: "median(points)", wantErr: `unknown aggregate "median"`},
+		{expr: "sum(effort)", wantErr: `unknown field "effort"`},
+		{expr: "sum(assignee)", wantErr: "not numeric"},
+		{expr: "sum(type)", wantErr: "not numeric"},
+		{expr: "points", wantErr: "want count()"},
+		{expr: "sum(estimate)", want: LaneSummary{Func: SummarySum, Field: "estimate"}},
+	}
+	// number fields are kept out of ruki but can still be aggregated
+	schema := rukiRuntime.NewSchemaFromFields(append(workflow.Fields(),
+		workflow.FieldDef{Name: "estimate", Type: workflow.TypeNumber, Custom: true}))
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := parseLaneSummary(tt.expr, schema)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("summary = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLaneSummaryAggregate(t *testing.T) {
+	values := []float64{3, 0, 8, 1}
+	set := []bool{true, false, true, true}
+	tests := []struct {
+		fn   string
+		want string
+	}{
+		{SummaryCount, "4"},
+		{SummarySum, "Σ12"},
+		{SummaryAvg, "⌀4"},
+		{SummaryMin, "min 1"},
+		{SummaryMax, "max 8"},
+	}
+	for _, tt := range tests {
+		s := &LaneSummary{Func: tt.fn, Field: "points"}
+		if got := s.Format(s.Aggregate(values, set)); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.fn, got, tt.want)
+		}
+	}
+
+	avg := &LaneSummary{Func: SummaryAvg, Field: "points"}
+	if got := avg.Format(avg.Aggregate([]float64{1, 2, 2}, []bool{true, true, true})); got != "⌀1.7" {
+		t.Errorf("avg = %q, want one decimal", got)
+	}
+	if got := avg.Format(avg.Aggregate(nil, nil)); got != "⌀–" {
+		t.Errorf("empty avg = %q", got)
+	}
+}
+
+func TestParseLanes_LimitAndSummary(t *testing.T) {
+	cfg := swimlaneBoard(nil)
+	cfg.Lanes[0].Limit = 3
+	cfg.Lanes[0].EnforceLimit = true
+	cfg.Lanes[0].Summary = "sum(points)"
+	p, err := parsePluginConfig(cfg, "test.yaml", testSchema(), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	lane := p.(*WorkflowPlugin).Lanes[0]
+	if lane.Limit != 3 || !lane.EnforceLimit || lane.Summary == nil || lane.Summary.Field != "points" {
+		t.Fatalf("lane = %+v", lane)
+	}
+
+	errs := []struct {
+		name string
+		edit func(*PluginLaneConfig)
+		want string
+	}{
+		{"negative", func(l *PluginLaneConfig) { l.Limit = -1 }, "invalid limit -1"},
+		{"enforce without limit", func(l *PluginLaneConfig) { l.EnforceLimit = true }, "enforceLimit without a limit"},
+		{"enforce without filter", func(l *PluginLaneConfig) { l.Limit, l.EnforceLimit, l.Filter = 2, true, "" }, "has no filter"},
+		{"bad summary", func(l *PluginLaneConfig) { l.Summary = "sum(title)" }, `lane "Ready": summary`},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := swimlaneBoard(nil)
+			tt.edit(&cfg.Lanes[0])
+			_, err := parsePluginConfig(cfg, "test.yaml", testSchema(), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	sw := swimlaneBoard(&PluginSwimlanesConfig{Bands: []PluginLaneConfig{{Name: "A", Filter: "select", Limit: 2}}})
+	if _, err := parsePluginConfig(sw, "test.yaml", testSchema(), nil); err == nil || !strings.Contains(err.Error(), "set per lane") {
+		t.Fatalf("swimlane limit err = %v", err)
+	}
+}
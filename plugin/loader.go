@@ -15,20 +15,23 @@ import (
 // Field-level legacy detection happens in rejectLegacyTopLevel so user-visible
 // errors point at the specific field that changed.
 type pluginFileConfig struct {
-	Name        string               `yaml:"name"`
-	Label       string               `yaml:"label"`
-	Description string               `yaml:"description"`
-	Foreground  string               `yaml:"foreground"`
-	Background  string               `yaml:"background"`
-	Key         string               `yaml:"key"`
-	Kind        string               `yaml:"kind"`
-	Document    string               `yaml:"document"`
-	Path        string               `yaml:"path"`
-	Lanes       []PluginLaneConfig   `yaml:"lanes"`
-	Actions     []PluginActionConfig `yaml:"actions"`
-	Layout      string               `yaml:"layout"`
-	Require     []string             `yaml:"require"`
-	Default     bool                 `yaml:"default"`
+	Name        string                 `yaml:"name"`
+	Label       string                 `yaml:"label"`
+	Description string                 `yaml:"description"`
+	Foreground  string                 `yaml:"foreground"`
+	Background  string                 `yaml:"background"`
+	Key         string                 `yaml:"key"`
+	Kind        string                 `yaml:"kind"`
+	Document    string                 `yaml:"document"`
+	Path        string                 `yaml:"path"`
+	Lanes       []PluginLaneConfig     `yaml:"lanes"`
+	Swimlanes   *PluginSwimlanesConfig `yaml:"swimlanes"`
+	Calendar    *PluginCalendarConfig  `yaml:"calendar"`
+	Activity    *PluginActivityConfig  `yaml:"activity"`
+	Actions     []PluginActionConfig   `yaml:"actions"`
+	Layout      string                 `yaml:"layout"`
+	Require     []string               `yaml:"require"`
+	Default     bool                   `yaml:"default"`
 
 	// Legacy fields retained only for rejection diagnostics.
 	Type     string     `yaml:"type"`
@@ -3,13 +3,17 @@ package plugin
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"gopkg.in/yaml.v3"
 
 	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/gridlayout"
+	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/theme"
 	"github.com/boolean-maybe/tiki/workflow"
 )
@@ -75,7 +79,7 @@ func parsePluginConfig(cfg pluginFileConfig, source string, schema ruki.Schema,
 	}
 
 	if cfg.Kind == "" {
-		return nil, fmt.Errorf("plugin %q (%s): missing `kind:` — expected board, list, wiki, detail, or search",
+		return nil, fmt.Errorf("plugin %q (%s): missing `kind:` — expected board, list, wiki, detail, calendar, sprints, activity, or search",
 			cfg.Name, source)
 	}
 	if strings.ToLower(cfg.Kind) == string(KindTimeline) {
@@ -88,11 +92,17 @@ func parsePluginConfig(cfg pluginFileConfig, source string, schema ruki.Schema,
 			cfg.Name, source)
 	}
 	if !IsValidKind(cfg.Kind) {
-		return nil, fmt.Errorf("plugin %q (%s): unknown view kind %q — expected board, list, wiki, or detail",
+		return nil, fmt.Errorf("plugin %q (%s): unknown view kind %q — expected board, list, wiki, detail, calendar, sprints, or activity",
 			cfg.Name, source, cfg.Kind)
 	}
 
 	kind := ViewKind(cfg.Kind)
+	if cfg.Calendar != nil && kind != KindCalendar {
+		return nil, fmt.Errorf("plugin %q (%s): `calendar:` only valid on kind: calendar", cfg.Name, source)
+	}
+	if cfg.Activity != nil && kind != KindActivity {
+		return nil, fmt.Errorf("plugin %q (%s): `activity:` only valid on kind: activity", cfg.Name, source)
+	}
 
 	key, r, mod, _, err := parseCanonicalKey(cfg.Key)
 	if err != nil {
@@ -125,6 +135,14 @@ func parsePluginConfig(cfg pluginFileConfig, source string, schema ruki.Schema,
 		return parseWikiPlugin(cfg, base)
 	case KindDetail:
 		return parseDetailPlugin(cfg, base, schema, viewNames)
+	case KindDependencies:
+		return parseDependencyPlugin(cfg, base)
+	case KindCalendar:
+		return parseCalendarPlugin(cfg, base, schema)
+	case KindSprints:
+		return parseSprintPlugin(cfg, base)
+	case KindActivity:
+		return parseActivityPlugin(cfg, base, schema)
 	default:
 		// unreachable: IsValidKind already gated this
 		return nil, fmt.Errorf("plugin %q (%s): unhandled kind %q", cfg.Name, source, kind)
@@ -148,7 +166,15 @@ func parseBoardOrListPlugin(cfg pluginFileConfig, base BasePlugin, schema ruki.S
 	}
 
 	parser := ruki.NewParser(schema)
-	lanes, err := parseLanes(cfg.Name, cfg.Lanes, parser)
+	lanes, err := parseLanes(cfg.Name, cfg.Lanes, schema, parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Swimlanes != nil && base.Kind != KindBoard {
+		return nil, fmt.Errorf("plugin %q: `swimlanes:` only valid on board views (got kind: %s)", cfg.Name, cfg.Kind)
+	}
+	swimlanes, err := parseSwimlanes(cfg.Name, cfg.Swimlanes, schema, parser)
 	if err != nil {
 		return nil, err
 	}
@@ -175,13 +201,14 @@ func parseBoardOrListPlugin(cfg pluginFileConfig, base BasePlugin, schema ruki.S
 	return &WorkflowPlugin{
 		BasePlugin: base,
 		Lanes:      lanes,
+		Swimlanes:  swimlanes,
 		Layout:     layout,
 		Actions:    actions,
 	}, nil
 }
 
 // parseLanes validates and parses the lanes section of a board/list view.
-func parseLanes(pluginName string, configs []PluginLaneConfig, parser *ruki.Parser) ([]TikiLane, error) {
+func parseLanes(pluginName string, configs []PluginLaneConfig, schema ruki.Schema, parser *ruki.Parser) ([]TikiLane, error) {
 	lanes := make([]TikiLane, 0, len(configs))
 	for i, lane := range configs {
 		if lane.Name == "" {
@@ -197,65 +224,175 @@ func parseLanes(pluginName string, configs []PluginLaneConfig, parser *ruki.Pars
 		if lane.Width < 0 || lane.Width > 100 {
 			return nil, fmt.Errorf("plugin %q: lane %q has invalid width %d (must be 0-100)", pluginName, lane.Name, lane.Width)
 		}
+		if lane.Limit < 0 {
+			return nil, fmt.Errorf("plugin %q: lane %q has invalid limit %d", pluginName, lane.Name, lane.Limit)
+		}
+		if lane.EnforceLimit && lane.Limit == 0 {
+			return nil, fmt.Errorf("plugin %q: lane %q sets enforceLimit without a limit", pluginName, lane.Name)
+		}
+		var summary *LaneSummary
+		if lane.Summary != "" {
+			var err error
+			if summary, err = parseLaneSummary(lane.Summary, schema); err != nil {
+				return nil, fmt.Errorf("plugin %q: lane %q: %w", pluginName, lane.Name, err)
+			}
+		}
 
 		filterStmt, err := parseLaneFilter(pluginName, lane, parser)
 		if err != nil {
 			return nil, err
 		}
-		actionStmt, err := parseLaneAction(pluginName, lane, parser)
+		actionStmt, moveRequire, err := parseLaneAction(pluginName, lane, parser)
 		if err != nil {
 			return nil, err
 		}
 
+		if lane.EnforceLimit && filterStmt == nil {
+			return nil, fmt.Errorf("plugin %q: lane %q enforces its limit but has no filter", pluginName, lane.Name)
+		}
+
 		lanes = append(lanes, TikiLane{
-			Name:    lane.Name,
-			Columns: columns,
-			Width:   lane.Width,
-			Filter:  filterStmt,
-			Action:  actionStmt,
+			Name:         lane.Name,
+			Columns:      columns,
+			Width:        lane.Width,
+			Filter:       filterStmt,
+			Action:       actionStmt,
+			MoveRequire:  moveRequire,
+			Limit:        lane.Limit,
+			EnforceLimit: lane.EnforceLimit,
+			Summary:      summary,
 		})
 	}
 	return lanes, nil
 }
 
 func parseLaneFilter(pluginName string, lane PluginLaneConfig, parser *ruki.Parser) (*ruki.ValidatedStatement, error) {
+	return parseFilterFor(pluginName, "lane", lane, parser)
+}
+
+func parseLaneAction(pluginName string, lane PluginLaneConfig, parser *ruki.Parser) (*ruki.ValidatedStatement, []string, error) {
+	return parseActionFor(pluginName, "lane", lane, parser)
+}
+
+// parseFilterFor validates the filter of a lane or swimlane band; noun names
+// which one in error messages.
+func parseFilterFor(pluginName, noun string, lane PluginLaneConfig, parser *ruki.Parser) (*ruki.ValidatedStatement, error) {
 	if lane.Filter == "" {
 		return nil, nil
 	}
 	stmt, err := parser.ParseAndValidateStatement(lane.Filter, ruki.ExecutorRuntimePlugin)
 	if err != nil {
-		return nil, fmt.Errorf("plugin %q: parsing filter for lane %q: %w", pluginName, lane.Name, err)
+		return nil, fmt.Errorf("plugin %q: parsing filter for %s %q: %w", pluginName, noun, lane.Name, err)
 	}
 	if !stmt.IsSelect() {
-		return nil, fmt.Errorf("plugin %q: lane %q filter must be a SELECT statement", pluginName, lane.Name)
+		return nil, fmt.Errorf("plugin %q: %s %q filter must be a SELECT statement", pluginName, noun, lane.Name)
 	}
 	if stmt.HasAnyInteractive() {
-		return nil, fmt.Errorf("plugin %q: lane %q filter cannot use interactive builtins (input/choose)", pluginName, lane.Name)
+		return nil, fmt.Errorf("plugin %q: %s %q filter cannot use interactive builtins (input/choose)", pluginName, noun, lane.Name)
 	}
 	if stmt.UsesTargetQualifier() {
-		return nil, fmt.Errorf("plugin %q: lane %q filter cannot use target. — no selection context at render time", pluginName, lane.Name)
+		return nil, fmt.Errorf("plugin %q: %s %q filter cannot use target. — no selection context at render time", pluginName, noun, lane.Name)
 	}
 	if stmt.UsesTargetsQualifier() {
-		return nil, fmt.Errorf("plugin %q: lane %q filter cannot use targets. — no selection context at render time", pluginName, lane.Name)
+		return nil, fmt.Errorf("plugin %q: %s %q filter cannot use targets. — no selection context at render time", pluginName, noun, lane.Name)
 	}
 	return stmt, nil
 }
 
-func parseLaneAction(pluginName string, lane PluginLaneConfig, parser *ruki.Parser) (*ruki.ValidatedStatement, error) {
+// parseActionFor validates the move action of a lane or swimlane band and
+// returns the requirements a tiki must meet to be moved there (see
+// setRequirements).
+func parseActionFor(pluginName, noun string, lane PluginLaneConfig, parser *ruki.Parser) (*ruki.ValidatedStatement, []string, error) {
 	if lane.Action == "" {
-		return nil, nil
+		return nil, nil, nil
 	}
 	stmt, err := parser.ParseAndValidateStatement(lane.Action, ruki.ExecutorRuntimePlugin)
 	if err != nil {
-		return nil, fmt.Errorf("plugin %q: parsing action for lane %q: %w", pluginName, lane.Name, err)
+		return nil, nil, fmt.Errorf("plugin %q: parsing action for %s %q: %w", pluginName, noun, lane.Name, err)
 	}
 	if !stmt.IsUpdate() {
-		return nil, fmt.Errorf("plugin %q: lane %q action must be an UPDATE statement", pluginName, lane.Name)
+		return nil, nil, fmt.Errorf("plugin %q: %s %q action must be an UPDATE statement", pluginName, noun, lane.Name)
 	}
 	if stmt.HasAnyInteractive() {
-		return nil, fmt.Errorf("plugin %q: lane %q action cannot use interactive builtins (input/choose)", pluginName, lane.Name)
+		return nil, nil, fmt.Errorf("plugin %q: %s %q action cannot use interactive builtins (input/choose)", pluginName, noun, lane.Name)
 	}
-	return stmt, nil
+	require, err := setRequirements(parser, lane.Action, stmt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("plugin %q: %s %q action: %w", pluginName, noun, lane.Name, err)
+	}
+	return stmt, dedup(require), nil
+}
+
+// maxSwimlaneBands bounds an explicit band list, like the lane limit.
+const maxSwimlaneBands = 20
+
+// parseSwimlanes validates the optional `swimlanes:` block of a board.
+// groupBy must name a scalar field whose value can be both read and set by a
+// vertical move; list fields are rejected because a tiki would belong to
+// several bands at once.
+func parseSwimlanes(pluginName string, cfg *PluginSwimlanesConfig, schema ruki.Schema, parser *ruki.Parser) (*Swimlanes, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	if cfg.GroupBy != "" && len(cfg.Bands) > 0 {
+		return nil, fmt.Errorf("plugin %q: swimlanes take either `groupBy:` or `bands:`, not both", pluginName)
+	}
+
+	if cfg.GroupBy != "" {
+		spec, ok := schema.Field(cfg.GroupBy)
+		if !ok && !workflow.IsNumberField(schema, cfg.GroupBy) {
+			return nil, fmt.Errorf("plugin %q: swimlanes groupBy: unknown field %q", pluginName, cfg.GroupBy)
+		}
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("plugin %q: swimlanes groupBy: field %q cannot group bands (want a string, enum, integer or reference field)", pluginName, cfg.GroupBy)
+		case spec.Type == ruki.ValueString, spec.Type == ruki.ValueEnum, spec.Type == ruki.ValueInt, spec.Type == ruki.ValueRef:
+		default:
+			return nil, fmt.Errorf("plugin %q: swimlanes groupBy: field %q cannot group bands (want a string, enum, integer or reference field)", pluginName, cfg.GroupBy)
+		}
+		return &Swimlanes{
+			GroupBy:     spec.Name,
+			GroupByType: spec.Type,
+			Enum:        spec.AllowedValues,
+		}, nil
+	}
+
+	if len(cfg.Bands) == 0 {
+		return nil, fmt.Errorf("plugin %q: swimlanes require `groupBy:` or `bands:`", pluginName)
+	}
+	if len(cfg.Bands) > maxSwimlaneBands {
+		return nil, fmt.Errorf("plugin %q: too many swimlanes (%d), max is %d", pluginName, len(cfg.Bands), maxSwimlaneBands)
+	}
+	bands := make([]Swimlane, 0, len(cfg.Bands))
+	seen := make(map[string]bool, len(cfg.Bands))
+	for i, band := range cfg.Bands {
+		if band.Name == "" {
+			return nil, fmt.Errorf("plugin %q: swimlane %d missing name", pluginName, i)
+		}
+		if seen[band.Name] {
+			return nil, fmt.Errorf("plugin %q: duplicate swimlane %q", pluginName, band.Name)
+		}
+		seen[band.Name] = true
+		if band.Columns != 0 || band.Width != 0 {
+			return nil, fmt.Errorf("plugin %q: swimlane %q: columns and width are set per lane, not per swimlane", pluginName, band.Name)
+		}
+		if band.Limit != 0 || band.EnforceLimit || band.Summary != "" {
+			return nil, fmt.Errorf("plugin %q: swimlane %q: limit and summary are set per lane, not per swimlane", pluginName, band.Name)
+		}
+		if band.Filter == "" {
+			return nil, fmt.Errorf("plugin %q: swimlane %q missing filter", pluginName, band.Name)
+		}
+		filterStmt, err := parseFilterFor(pluginName, "swimlane", band, parser)
+		if err != nil {
+			return nil, err
+		}
+		actionStmt, moveRequire, err := parseActionFor(pluginName, "swimlane", band, parser)
+		if err != nil {
+			return nil, err
+		}
+		bands = append(bands, Swimlane{Name: band.Name, Filter: filterStmt, Action: actionStmt, MoveRequire: moveRequire})
+	}
+	return &Swimlanes{Bands: bands}, nil
 }
 
 // parseWikiPlugin handles kind: wiki — a markdown view bound to a specific document.
@@ -288,6 +425,160 @@ func parseWikiPlugin(cfg pluginFileConfig, base BasePlugin) (Plugin, error) {
 	}, nil
 }
 
+// parseDependencyPlugin handles kind: dependencies — the dependency tree of
+// the selected tiki. The view is driven entirely by the workflow's
+// dependencies: section (without one it renders a placeholder). The tree is
+// rooted at the selection, so selection:one is always required.
+func parseDependencyPlugin(cfg pluginFileConfig, base BasePlugin) (Plugin, error) {
+	if err := rejectBoardOnlyFields(cfg, "dependencies"); err != nil {
+		return nil, err
+	}
+	if cfg.Document != "" || cfg.Path != "" {
+		return nil, fmt.Errorf("plugin %q: `document:` and `path:` only valid on kind: wiki", cfg.Name)
+	}
+	if strings.TrimSpace(cfg.Layout) != "" {
+		return nil, fmt.Errorf("plugin %q: `layout:` only valid on kind: board, list, or detail", cfg.Name)
+	}
+	if len(cfg.Actions) > 0 {
+		return nil, fmt.Errorf("plugin %q: kind: dependencies cannot have per-view `actions:` — use top-level actions", cfg.Name)
+	}
+	if !slices.Contains(base.Require, "selection:one") {
+		base.Require = append(base.Require, "selection:one")
+	}
+	return &DependencyPlugin{BasePlugin: base}, nil
+}
+
+// parseSprintPlugin handles kind: sprints — the sprint planning report. Like
+// kind: dependencies it is driven entirely by a workflow section (sprints:),
+// but it covers every sprint rather than a selection.
+func parseSprintPlugin(cfg pluginFileConfig, base BasePlugin) (Plugin, error) {
+	if err := rejectBoardOnlyFields(cfg, "sprints"); err != nil {
+		return nil, err
+	}
+	if cfg.Document != "" || cfg.Path != "" {
+		return nil, fmt.Errorf("plugin %q: `document:` and `path:` only valid on kind: wiki", cfg.Name)
+	}
+	if strings.TrimSpace(cfg.Layout) != "" {
+		return nil, fmt.Errorf("plugin %q: `layout:` only valid on kind: board, list, or detail", cfg.Name)
+	}
+	if len(cfg.Actions) > 0 {
+		return nil, fmt.Errorf("plugin %q: kind: sprints cannot have per-view `actions:` — use top-level actions", cfg.Name)
+	}
+	return &SprintPlugin{BasePlugin: base}, nil
+}
+
+// parseActivityPlugin handles kind: activity — the feed of recent changes.
+// The time range defaults to today; since and the field filter are checked
+// here so a typo fails at load rather than rendering an empty feed.
+func parseActivityPlugin(cfg pluginFileConfig, base BasePlugin, schema ruki.Schema) (Plugin, error) {
+	if err := rejectBoardOnlyFields(cfg, "activity"); err != nil {
+		return nil, err
+	}
+	if cfg.Document != "" || cfg.Path != "" {
+		return nil, fmt.Errorf("plugin %q: `document:` and `path:` only valid on kind: wiki", cfg.Name)
+	}
+	if strings.TrimSpace(cfg.Layout) != "" {
+		return nil, fmt.Errorf("plugin %q: `layout:` only valid on kind: board, list, or detail", cfg.Name)
+	}
+	if len(cfg.Actions) > 0 {
+		return nil, fmt.Errorf("plugin %q: kind: activity cannot have per-view `actions:` — use top-level actions", cfg.Name)
+	}
+	act := PluginActivityConfig{}
+	if cfg.Activity != nil {
+		act = *cfg.Activity
+	}
+
+	since := strings.TrimSpace(act.Since)
+	if since == "" {
+		since = "today"
+	}
+	if _, err := store.ParseActivityTime(since, time.Now()); err != nil {
+		return nil, fmt.Errorf("plugin %q: activity since: %w", cfg.Name, err)
+	}
+
+	field := strings.TrimSpace(act.Field)
+	if field != "" {
+		if _, ok := schema.Field(field); !ok && !workflow.IsNumberField(schema, field) {
+			return nil, fmt.Errorf("plugin %q: activity field: unknown field %q", cfg.Name, field)
+		}
+	}
+
+	return &ActivityPlugin{
+		BasePlugin: base,
+		Since:      since,
+		User:       strings.TrimSpace(act.User),
+		Field:      field,
+	}, nil
+}
+
+// parseCalendarPlugin handles kind: calendar. The date field defaults to
+// `due` and must be a date; the optional recurrence field expands recurring
+// tikis into their future occurrences.
+func parseCalendarPlugin(cfg pluginFileConfig, base BasePlugin, schema ruki.Schema) (Plugin, error) {
+	if err := rejectBoardOnlyFields(cfg, "calendar"); err != nil {
+		return nil, err
+	}
+	if cfg.Document != "" || cfg.Path != "" {
+		return nil, fmt.Errorf("plugin %q: `document:` and `path:` only valid on kind: wiki", cfg.Name)
+	}
+	if strings.TrimSpace(cfg.Layout) != "" {
+		return nil, fmt.Errorf("plugin %q: `layout:` only valid on kind: board, list, or detail", cfg.Name)
+	}
+	if len(cfg.Actions) > 0 {
+		return nil, fmt.Errorf("plugin %q: kind: calendar cannot have per-view `actions:` — use top-level actions", cfg.Name)
+	}
+	cal := PluginCalendarConfig{}
+	if cfg.Calendar != nil {
+		cal = *cfg.Calendar
+	}
+
+	field := cal.Field
+	if field == "" {
+		field = "due"
+	}
+	spec, ok := schema.Field(field)
+	if !ok {
+		return nil, fmt.Errorf("plugin %q: calendar field: unknown field %q", cfg.Name, field)
+	}
+	if spec.Type != ruki.ValueDate {
+		return nil, fmt.Errorf("plugin %q: calendar field %q is not a date field", cfg.Name, field)
+	}
+
+	recurrence := ""
+	if cal.Recurrence != "" {
+		rspec, ok := schema.Field(cal.Recurrence)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q: calendar recurrence: unknown field %q", cfg.Name, cal.Recurrence)
+		}
+		if rspec.Type != ruki.ValueRecurrence {
+			return nil, fmt.Errorf("plugin %q: calendar recurrence field %q is not a recurrence field", cfg.Name, cal.Recurrence)
+		}
+		recurrence = rspec.Name
+	}
+
+	mode := strings.ToLower(cal.Mode)
+	switch mode {
+	case "":
+		mode = CalendarMonth
+	case CalendarMonth, CalendarWeek:
+	default:
+		return nil, fmt.Errorf("plugin %q: calendar mode %q — expected month or week", cfg.Name, cal.Mode)
+	}
+
+	filter, err := parseFilterFor(cfg.Name, "calendar", PluginLaneConfig{Name: cfg.Name, Filter: cal.Filter}, ruki.NewParser(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CalendarPlugin{
+		BasePlugin:      base,
+		Filter:          filter,
+		DateField:       spec.Name,
+		RecurrenceField: recurrence,
+		Mode:            mode,
+	}, nil
+}
+
 // parseDetailPlugin handles kind: detail — a configurable view of a single
 // selected tiki. Renders title, the configured layout grid, and description.
 // Per-view actions are allowed and will be surfaced alongside the built-in
@@ -443,11 +734,11 @@ func validateCountDisplay(pluginName, name string, display gridlayout.DisplayMod
 		return nil
 	}
 	spec, ok := schema.Field(name)
-	if !ok {
+	if !ok && !workflow.IsNumberField(schema, name) {
 		// field existence is already enforced by validateLayoutFieldName.
 		return nil
 	}
-	if spec.Type == ruki.ValueListString || spec.Type == ruki.ValueListRef {
+	if ok && (spec.Type == ruki.ValueListString || spec.Type == ruki.ValueListRef) {
 		return nil
 	}
 	return fmt.Errorf(
@@ -510,7 +801,7 @@ func validateLayoutFieldName(pluginName, viewKind, name string, schema ruki.Sche
 		}
 	}
 	if schema != nil {
-		if _, ok := schema.Field(name); !ok {
+		if _, ok := schema.Field(name); !ok && !workflow.IsNumberField(schema, name) {
 			return fmt.Errorf(
 				"plugin %q: layout field %q is not a workflow-declared field",
 				pluginName, name)
@@ -540,11 +831,14 @@ func isDetailNonRenderableSystemField(name string) bool {
 	return name == "filepath" || name == "path"
 }
 
-// rejectBoardOnlyFields catches lanes set on a non-board/list view.
+// rejectBoardOnlyFields catches lanes or swimlanes set on a non-board/list view.
 func rejectBoardOnlyFields(cfg pluginFileConfig, kind string) error {
 	if len(cfg.Lanes) > 0 {
 		return fmt.Errorf("plugin %q: `lanes:` only valid on board or list views (got kind: %s)", cfg.Name, kind)
 	}
+	if cfg.Swimlanes != nil {
+		return fmt.Errorf("plugin %q: `swimlanes:` only valid on board views (got kind: %s)", cfg.Name, kind)
+	}
 	return nil
 }
 
@@ -587,31 +881,46 @@ func parsePluginActions(configs []PluginActionConfig, parser *ruki.Parser, viewN
 			parsed, err = parseRukiAction(cfg, i, parser, key, r, mod, keyStr)
 		case ActionKindView:
 			parsed, err = parseViewAction(cfg, i, parser, viewNames, sourceIsDetailView, key, r, mod, keyStr)
+		case ActionKindChat:
+			parsed, err = parseChatAction(cfg, i, key, r, mod, keyStr)
+		case ActionKindPropose:
+			parsed, err = parseProposeAction(cfg, i, parser, key, r, mod, keyStr)
 		default:
 			err = fmt.Errorf("action %d (key %q): unknown action kind %q", i, cfg.Key, cfg.Kind)
 		}
 		if err != nil {
 			return nil, err
 		}
+		if cfg.Agent != "" && actionKind != ActionKindChat && actionKind != ActionKindPropose {
+			return nil, fmt.Errorf("action %d (key %q): agent: only valid on kind: chat and kind: propose actions", i, cfg.Key)
+		}
+		if cfg.Prompt != "" && actionKind != ActionKindPropose {
+			return nil, fmt.Errorf("action %d (key %q): prompt: only valid on kind: propose actions", i, cfg.Key)
+		}
 		actions = append(actions, parsed)
 	}
 
 	return actions, nil
 }
 
-// resolveActionKind determines whether an action is a ruki or view action.
-// Explicit `kind:` wins. Otherwise: `action:` set → ruki; `view:` set → view.
-// Both set or neither set is an error.
+// resolveActionKind determines whether an action is a ruki, view, chat or
+// propose action. Explicit `kind:` wins. Otherwise: `action:` set → ruki;
+// `view:` set → view. Both set or neither set is an error. Chat and propose
+// are never inferred.
 func resolveActionKind(cfg PluginActionConfig, idx int) (ActionKind, error) {
 	switch strings.ToLower(cfg.Kind) {
 	case string(ActionKindRuki):
 		return ActionKindRuki, nil
 	case string(ActionKindView):
 		return ActionKindView, nil
+	case string(ActionKindChat):
+		return ActionKindChat, nil
+	case string(ActionKindPropose):
+		return ActionKindPropose, nil
 	case "":
 		// inference path
 	default:
-		return "", fmt.Errorf("action %d (key %q): unknown kind %q — expected `ruki` or `view`", idx, cfg.Key, cfg.Kind)
+		return "", fmt.Errorf("action %d (key %q): unknown kind %q — expected `ruki`, `view`, `chat` or `propose`", idx, cfg.Key, cfg.Kind)
 	}
 
 	hasAction := cfg.Action != ""
@@ -649,15 +958,23 @@ func parseRukiAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, key t
 		hasInput     bool
 		hasChoose    bool
 		chooseFilter *ruki.SubQuery
+		sets         []string
 		err          error
 	)
 
+	// `create from "<template>"` is resolved host-side: the statement is
+	// rewritten to a plain create and the template name rides on the action.
+	src, createTemplate := config.SplitCreateFrom(cfg.Action)
+	if err := validateCreateTemplate(createTemplate); err != nil {
+		return PluginAction{}, fmt.Errorf("action %d (key %q): %w", idx, cfg.Key, err)
+	}
+
 	if cfg.Input != "" {
 		typ, parseErr := ruki.ParseScalarTypeName(cfg.Input)
 		if parseErr != nil {
 			return PluginAction{}, fmt.Errorf("action %d (key %q) input: %w", idx, cfg.Key, parseErr)
 		}
-		stmt, err = parser.ParseAndValidateStatementWithInput(cfg.Action, ruki.ExecutorRuntimePlugin, typ)
+		stmt, err = parser.ParseAndValidateStatementWithInput(src, ruki.ExecutorRuntimePlugin, typ)
 		if err != nil {
 			return PluginAction{}, fmt.Errorf("parsing action %d (key %q): %w", idx, cfg.Key, err)
 		}
@@ -667,10 +984,15 @@ func parseRukiAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, key t
 		inputType = typ
 		hasInput = true
 	} else {
-		stmt, err = parser.ParseAndValidateStatement(cfg.Action, ruki.ExecutorRuntimePlugin)
+		stmt, err = parser.ParseAndValidateStatement(src, ruki.ExecutorRuntimePlugin)
 		if err != nil {
 			return PluginAction{}, fmt.Errorf("parsing action %d (key %q): %w", idx, cfg.Key, err)
 		}
+		// only here: ruki offers no way to re-parse an input: action with
+		// its declared input type, so the gate alone checks its permissions
+		if sets, err = setRequirements(parser, src, stmt); err != nil {
+			return PluginAction{}, fmt.Errorf("action %d (key %q): %w", idx, cfg.Key, err)
+		}
 	}
 
 	if stmt.IsExpr() {
@@ -685,7 +1007,7 @@ func parseRukiAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, key t
 		chooseFilter = stmt.ChooseFilter()
 	}
 
-	require, err := inferRequirements(cfg.Require, stmt, idx, cfg.Key)
+	require, err := inferRequirements(cfg.Require, stmt, sets, idx, cfg.Key)
 	if err != nil {
 		return PluginAction{}, err
 	}
@@ -695,19 +1017,20 @@ func parseRukiAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, key t
 		showInHeader = *cfg.Hot
 	}
 	return PluginAction{
-		Key:          key,
-		Rune:         r,
-		Modifier:     mod,
-		KeyStr:       keyStr,
-		Label:        cfg.Label,
-		Kind:         ActionKindRuki,
-		Action:       stmt,
-		ShowInHeader: showInHeader,
-		InputType:    inputType,
-		HasInput:     hasInput,
-		HasChoose:    hasChoose,
-		ChooseFilter: chooseFilter,
-		Require:      require,
+		Key:            key,
+		Rune:           r,
+		Modifier:       mod,
+		KeyStr:         keyStr,
+		Label:          cfg.Label,
+		Kind:           ActionKindRuki,
+		Action:         stmt,
+		CreateTemplate: createTemplate,
+		ShowInHeader:   showInHeader,
+		InputType:      inputType,
+		HasInput:       hasInput,
+		HasChoose:      hasChoose,
+		ChooseFilter:   chooseFilter,
+		Require:        require,
 	}, nil
 }
 
@@ -790,13 +1113,20 @@ func parseViewAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, viewN
 	// a single ruki create statement that pre-fills the synthesized draft. it
 	// is parsed only after mode is resolved so the guard can reject it on any
 	// non-new mode.
-	var createSeed *ruki.ValidatedStatement
+	var (
+		createSeed     *ruki.ValidatedStatement
+		createTemplate string
+	)
 	if strings.TrimSpace(cfg.Action) != "" {
 		if mode != DetailModeNew {
 			return PluginAction{}, fmt.Errorf(
 				"action %d (key %q): kind: view must not set 'action:' unless mode: new", idx, cfg.Key)
 		}
-		stmt, err := parser.ParseAndValidateStatement(cfg.Action, ruki.ExecutorRuntimePlugin)
+		src, tmplName := config.SplitCreateFrom(cfg.Action)
+		if err := validateCreateTemplate(tmplName); err != nil {
+			return PluginAction{}, fmt.Errorf("action %d (key %q): %w", idx, cfg.Key, err)
+		}
+		stmt, err := parser.ParseAndValidateStatement(src, ruki.ExecutorRuntimePlugin)
 		if err != nil {
 			return PluginAction{}, fmt.Errorf("parsing action %d (key %q) seed: %w", idx, cfg.Key, err)
 		}
@@ -809,6 +1139,7 @@ func parseViewAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, viewN
 				"action %d (key %q): cannot combine `action:` create seed with `choose:`", idx, cfg.Key)
 		}
 		createSeed = stmt
+		createTemplate = tmplName
 	}
 
 	require := make([]string, 0, len(cfg.Require))
@@ -839,6 +1170,60 @@ func parseViewAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, viewN
 		chooseFilter = filter
 	}
 
+	showInHeader := true
+	if cfg.Hot != nil {
+		showInHeader = *cfg.Hot
+	}
+	return PluginAction{
+		Key:            key,
+		Rune:           r,
+		Modifier:       mod,
+		KeyStr:         keyStr,
+		Label:          cfg.Label,
+		Kind:           ActionKindView,
+		TargetView:     cfg.View,
+		Mode:           mode,
+		Focus:          focus,
+		CreateSeed:     createSeed,
+		CreateTemplate: createTemplate,
+		ShowInHeader:   showInHeader,
+		HasChoose:      hasChoose,
+		ChooseFilter:   chooseFilter,
+		Require:        dedup(require),
+	}, nil
+}
+
+// parseChatAction builds a `kind: chat` action: it starts an AI agent on the
+// selected tiki, like the detail view's built-in Chat. The optional `agent:`
+// names a built-in tool or one declared under `ai.agents:` in config.yaml;
+// without it the action uses `ai.agent` and is only enabled when one is set.
+func parseChatAction(cfg PluginActionConfig, idx int, key tcell.Key, r rune, mod tcell.ModMask, keyStr string) (PluginAction, error) {
+	switch {
+	case cfg.Action != "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): kind: chat must not set `action:`", idx, cfg.Key)
+	case cfg.View != "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): kind: chat must not set `view:`", idx, cfg.Key)
+	case cfg.Input != "" || cfg.Choose != "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): kind: chat does not support `input:` or `choose:`", idx, cfg.Key)
+	case cfg.Mode != "" || cfg.Focus != "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): mode: and focus: only valid on kind: view actions", idx, cfg.Key)
+	}
+
+	agent := strings.TrimSpace(cfg.Agent)
+	require := make([]string, 0, len(cfg.Require)+2)
+	for _, req := range cfg.Require {
+		if err := validateRequirement(req); err != nil {
+			return PluginAction{}, fmt.Errorf("action %d (key %q) require: %w", idx, cfg.Key, err)
+		}
+		require = append(require, req)
+	}
+	require = append(require, "selection:one")
+	if agent == "" {
+		require = append(require, "ai")
+	} else if _, ok := config.LookupAITool(agent); !ok {
+		return PluginAction{}, fmt.Errorf("action %d (key %q): unknown agent %q — declare it under ai.agents in config.yaml", idx, cfg.Key, agent)
+	}
+
 	showInHeader := true
 	if cfg.Hot != nil {
 		showInHeader = *cfg.Hot
@@ -849,14 +1234,81 @@ func parseViewAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, viewN
 		Modifier:     mod,
 		KeyStr:       keyStr,
 		Label:        cfg.Label,
-		Kind:         ActionKindView,
-		TargetView:   cfg.View,
-		Mode:         mode,
-		Focus:        focus,
-		CreateSeed:   createSeed,
+		Kind:         ActionKindChat,
+		Agent:        agent,
+		ShowInHeader: showInHeader,
+		Require:      dedup(require),
+	}, nil
+}
+
+// parseProposeAction builds a `kind: propose` action: it runs an AI agent
+// non-interactively with `prompt:` and tikis as context, and opens the
+// changes the agent proposes for review. The context is the selection, or
+// the tikis matched by an optional `action:` select statement (e.g. all of
+// the inbox). `agent:` works as on kind: chat.
+func parseProposeAction(cfg PluginActionConfig, idx int, parser *ruki.Parser, key tcell.Key, r rune, mod tcell.ModMask, keyStr string) (PluginAction, error) {
+	switch {
+	case strings.TrimSpace(cfg.Prompt) == "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): kind: propose requires `prompt:`", idx, cfg.Key)
+	case cfg.View != "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): kind: propose must not set `view:`", idx, cfg.Key)
+	case cfg.Input != "" || cfg.Choose != "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): kind: propose does not support `input:` or `choose:`", idx, cfg.Key)
+	case cfg.Mode != "" || cfg.Focus != "":
+		return PluginAction{}, fmt.Errorf("action %d (key %q): mode: and focus: only valid on kind: view actions", idx, cfg.Key)
+	}
+
+	var (
+		stmt    *ruki.ValidatedStatement
+		require []string
+		err     error
+	)
+	if cfg.Action != "" {
+		stmt, err = parser.ParseAndValidateStatement(cfg.Action, ruki.ExecutorRuntimePlugin)
+		if err != nil {
+			return PluginAction{}, fmt.Errorf("parsing action %d (key %q): %w", idx, cfg.Key, err)
+		}
+		if !stmt.IsSelect() || stmt.IsPipe() || stmt.IsClipboardPipe() || stmt.UsesChooseBuiltin() {
+			return PluginAction{}, fmt.Errorf("action %d (key %q): kind: propose `action:` must be a plain select statement", idx, cfg.Key)
+		}
+		require, err = inferRequirements(cfg.Require, stmt, nil, idx, cfg.Key)
+		if err != nil {
+			return PluginAction{}, err
+		}
+	} else {
+		for _, req := range cfg.Require {
+			if err := validateRequirement(req); err != nil {
+				return PluginAction{}, fmt.Errorf("action %d (key %q) require: %w", idx, cfg.Key, err)
+			}
+			require = append(require, req)
+		}
+		if !hasAnySelectionRequirement(require) {
+			require = append(require, "selection:any")
+		}
+	}
+
+	agent := strings.TrimSpace(cfg.Agent)
+	if agent == "" {
+		require = append(require, "ai")
+	} else if _, ok := config.LookupAITool(agent); !ok {
+		return PluginAction{}, fmt.Errorf("action %d (key %q): unknown agent %q — declare it under ai.agents in config.yaml", idx, cfg.Key, agent)
+	}
+
+	showInHeader := true
+	if cfg.Hot != nil {
+		showInHeader = *cfg.Hot
+	}
+	return PluginAction{
+		Key:          key,
+		Rune:         r,
+		Modifier:     mod,
+		KeyStr:       keyStr,
+		Label:        cfg.Label,
+		Kind:         ActionKindPropose,
+		Action:       stmt,
+		Agent:        agent,
+		Prompt:       strings.TrimSpace(cfg.Prompt),
 		ShowInHeader: showInHeader,
-		HasChoose:    hasChoose,
-		ChooseFilter: chooseFilter,
 		Require:      dedup(require),
 	}, nil
 }
@@ -916,13 +1368,17 @@ func validateRequireList(reqs []string) ([]string, error) {
 //   - id() / filepath() / target.<field> → "id" (legacy alias for selection:one)
 //   - ids() / filepaths() / targets.<field> → "selection:any" (at least one selection)
 //
+// A delete of the selection also gets "!denied:delete", and an update of it
+// gets sets (see setRequirements), so workflows whose permissions keep the
+// user from making the change to the selected tiki grey it out.
+//
 // selected_count() deliberately does NOT auto-infer anything: its whole
 // purpose is to let ruki branch on cardinality, including the zero case
 // (e.g. `where selected_count() = 0`). Gating the action on a non-zero
 // selection would make that branch unreachable. Authors who want tighter
 // gating can add `require: ["selection:any"]` (or `selection:many`)
 // explicitly.
-func inferRequirements(explicit []string, stmt *ruki.ValidatedStatement, idx int, key string) ([]string, error) {
+func inferRequirements(explicit []string, stmt *ruki.ValidatedStatement, sets []string, idx int, key string) ([]string, error) {
 	for _, r := range explicit {
 		if err := validateRequirement(r); err != nil {
 			return nil, fmt.Errorf("action %d (key %q) require: %w", idx, key, err)
@@ -941,6 +1397,12 @@ func inferRequirements(explicit []string, stmt *ruki.ValidatedStatement, idx int
 	if needsAny && !hasAnySelectionRequirement(reqs) {
 		reqs = append(reqs, "selection:any")
 	}
+	if stmt.IsDelete() && (needsSingle || needsAny) {
+		reqs = append(reqs, "!denied:delete")
+	}
+	if needsSingle || needsAny {
+		reqs = append(reqs, sets...)
+	}
 
 	if len(reqs) == 0 {
 		return nil, nil
@@ -1019,3 +1481,22 @@ func parsePluginYAML(data []byte, source string, schema ruki.Schema) (Plugin, er
 
 	return parsePluginConfig(cfg, source, schema, nil)
 }
+
+// validateCreateTemplate checks that a `create from "<template>"` name refers
+// to a template of the loaded workflow. An empty name is a plain create.
+func validateCreateTemplate(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := config.FindTemplate(name); ok {
+		return nil
+	}
+	var names []string
+	for _, t := range config.WorkflowTemplates() {
+		names = append(names, t.Name)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("unknown template %q: the workflow defines no templates", name)
+	}
+	return fmt.Errorf("unknown template %q (valid templates: %s)", name, strings.Join(names, ", "))
+}
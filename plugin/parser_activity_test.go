@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+)
+
+func activityConfig(act *PluginActivityConfig) pluginFileConfig {
+	return pluginFileConfig{Name: "Activity", Kind: "activity", Activity: act}
+}
+
+func TestParseActivityPlugin(t *testing.T) {
+	p, err := parsePluginConfig(activityConfig(nil), "test.yaml", testSchema(), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	act, ok := p.(*ActivityPlugin)
+	if !ok {
+		t.Fatalf("plugin = %T, want *ActivityPlugin", p)
+	}
+	if act.Since != "today" || act.User != "" || act.Field != "" {
+		t.Errorf("defaults = %+v", act)
+	}
+
+	p, err = parsePluginConfig(activityConfig(&PluginActivityConfig{
+		Since: "2week",
+		User:  "me",
+		Field: "status",
+	}), "test.yaml", testSchema(), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	act = p.(*ActivityPlugin)
+	if act.Since != "2week" || act.User != "me" || act.Field != "status" {
+		t.Errorf("activity = %+v", act)
+	}
+}
+
+func TestParseActivityPlugin_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  pluginFileConfig
+		want string
+	}{
+		{"bad since", activityConfig(&PluginActivityConfig{Since: "lately"}), "activity since"},
+		{"unknown field", activityConfig(&PluginActivityConfig{Field: "severity"}), `unknown field "severity"`},
+		{"path", func() pluginFileConfig {
+			cfg := activityConfig(nil)
+			cfg.Path = "notes.md"
+			return cfg
+		}(), "only valid on kind: wiki"},
+		{"activity on a board", func() pluginFileConfig {
+			cfg := swimlaneBoard(nil)
+			cfg.Activity = &PluginActivityConfig{}
+			return cfg
+		}(), "`activity:` only valid on kind: activity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePluginConfig(tt.cfg, "test.yaml", testSchema(), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+)
+
+func calendarConfig(cal *PluginCalendarConfig) pluginFileConfig {
+	return pluginFileConfig{Name: "Calendar", Kind: "calendar", Calendar: cal}
+}
+
+func TestParseCalendarPlugin(t *testing.T) {
+	p, err := parsePluginConfig(calendarConfig(nil), "test.yaml", testSchema(), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	cal, ok := p.(*CalendarPlugin)
+	if !ok {
+		t.Fatalf("plugin = %T, want *CalendarPlugin", p)
+	}
+	if cal.DateField != "due" || cal.Mode != CalendarMonth || cal.RecurrenceField != "" || cal.Filter != nil {
+		t.Errorf("defaults = %+v", cal)
+	}
+
+	p, err = parsePluginConfig(calendarConfig(&PluginCalendarConfig{
+		Filter:     `select where status != "done"`,
+		Recurrence: "recurrence",
+		Mode:       "Week",
+	}), "test.yaml", testSchema(), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	cal = p.(*CalendarPlugin)
+	if cal.Filter == nil || cal.RecurrenceField != "recurrence" || cal.Mode != CalendarWeek {
+		t.Errorf("calendar = %+v", cal)
+	}
+}
+
+func TestParseCalendarPlugin_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  pluginFileConfig
+		want string
+	}{
+		{"unknown field", calendarConfig(&PluginCalendarConfig{Field: "deadline"}), `unknown field "deadline"`},
+		{"not a date", calendarConfig(&PluginCalendarConfig{Field: "title"}), "is not a date field"},
+		{"bad recurrence", calendarConfig(&PluginCalendarConfig{Recurrence: "due"}), "is not a recurrence field"},
+		{"bad mode", calendarConfig(&PluginCalendarConfig{Mode: "year"}), "expected month or week"},
+		{"bad filter", calendarConfig(&PluginCalendarConfig{Filter: `update set status = "done"`}), "calendar"},
+		{"lanes", func() pluginFileConfig {
+			cfg := calendarConfig(nil)
+			cfg.Lanes = []PluginLaneConfig{{Name: "A", Filter: "select"}}
+			return cfg
+		}(), "calendar"},
+		{"calendar on a board", func() pluginFileConfig {
+			cfg := swimlaneBoard(nil)
+			cfg.Calendar = &PluginCalendarConfig{}
+			return cfg
+		}(), "calendar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePluginConfig(tt.cfg, "test.yaml", testSchema(), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
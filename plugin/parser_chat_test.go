@@ -0,0 +1,45 @@
+package plugin
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParsePluginActions_ChatKind(t *testing.T) {
+	actions, err := parsePluginActions([]PluginActionConfig{
+		{Key: "a", Kind: "chat", Label: "Ask AI"},
+		{Key: "g", Kind: "chat", Label: "Ask Gemini", Agent: "gemini"},
+	}, testParser(), nil, false)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	def, gemini := actions[0], actions[1]
+	if def.Kind != ActionKindChat || def.Agent != "" || !slices.Contains(def.Require, "ai") || !slices.Contains(def.Require, "selection:one") {
+		t.Errorf("default-agent chat = %+v, want ai and selection:one required", def)
+	}
+	if gemini.Agent != "gemini" || slices.Contains(gemini.Require, "ai") {
+		t.Errorf("gemini chat = %+v, want agent override without the ai requirement", gemini)
+	}
+}
+
+func TestParsePluginActions_ChatKindErrors(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       PluginActionConfig
+		wantError string
+	}{
+		{"unknown agent", PluginActionConfig{Key: "a", Kind: "chat", Label: "Ask", Agent: "nope"}, `unknown agent "nope"`},
+		{"action set", PluginActionConfig{Key: "a", Kind: "chat", Label: "Ask", Action: `select`}, "kind: chat must not set `action:`"},
+		{"view set", PluginActionConfig{Key: "a", Kind: "chat", Label: "Ask", View: "Kanban"}, "kind: chat must not set `view:`"},
+		{"agent on ruki", PluginActionConfig{Key: "a", Label: "Run", Action: `select`, Agent: "claude"}, "agent: only valid on kind: chat"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parsePluginActions([]PluginActionConfig{tc.cfg}, testParser(), nil, false)
+			if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+				t.Fatalf("err = %v, want containing %q", err, tc.wantError)
+			}
+		})
+	}
+}
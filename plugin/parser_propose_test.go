@@ -0,0 +1,51 @@
+package plugin
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParsePluginActions_ProposeKind(t *testing.T) {
+	actions, err := parsePluginActions([]PluginActionConfig{
+		{Key: "s", Kind: "propose", Label: "Split", Prompt: "Split this story into subtasks."},
+		{Key: "t", Kind: "propose", Label: "Triage", Agent: "codex", Prompt: "Triage the inbox.",
+			Action: `select where status = "inbox"`},
+	}, testParser(), nil, false)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	split, triage := actions[0], actions[1]
+	if split.Kind != ActionKindPropose || split.Prompt != "Split this story into subtasks." || split.Action != nil {
+		t.Errorf("split = %+v", split)
+	}
+	if !slices.Contains(split.Require, "selection:any") || !slices.Contains(split.Require, "ai") {
+		t.Errorf("split require = %v, want selection:any and ai", split.Require)
+	}
+	if triage.Action == nil || triage.Agent != "codex" || len(triage.Require) != 0 {
+		t.Errorf("triage = %+v, want a select context, the codex agent and no requirements", triage)
+	}
+}
+
+func TestParsePluginActions_ProposeKindErrors(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       PluginActionConfig
+		wantError string
+	}{
+		{"no prompt", PluginActionConfig{Key: "a", Kind: "propose", Label: "Ask"}, "requires `prompt:`"},
+		{"update action", PluginActionConfig{Key: "a", Kind: "propose", Label: "Ask", Prompt: "x",
+			Action: `update where id = id() set title = "x"`}, "must be a plain select"},
+		{"view set", PluginActionConfig{Key: "a", Kind: "propose", Label: "Ask", Prompt: "x", View: "Kanban"}, "must not set `view:`"},
+		{"prompt on chat", PluginActionConfig{Key: "a", Kind: "chat", Label: "Ask", Prompt: "x"}, "prompt: only valid on kind: propose"},
+		{"unknown agent", PluginActionConfig{Key: "a", Kind: "propose", Label: "Ask", Prompt: "x", Agent: "nope"}, `unknown agent "nope"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parsePluginActions([]PluginActionConfig{tc.cfg}, testParser(), nil, false)
+			if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+				t.Fatalf("err = %v, want containing %q", err, tc.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/ruki"
+)
+
+func swimlaneBoard(sw *PluginSwimlanesConfig) pluginFileConfig {
+	return pluginFileConfig{
+		Name: "Standup",
+		Kind: "board",
+		Lanes: []PluginLaneConfig{
+			{Name: "Ready", Filter: `select where status = "ready"`, Action: `update where id = id() set status = "ready"`},
+			{Name: "Done", Filter: `select where status = "done"`, Action: `update where id = id() set status = "done"`},
+		},
+		Layout:    minimalBoardLayout(),
+		Swimlanes: sw,
+	}
+}
+
+func TestParseSwimlanes_GroupBy(t *testing.T) {
+	p, err := parsePluginConfig(swimlaneBoard(&PluginSwimlanesConfig{GroupBy: "status"}), "test.yaml", testSchema(), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	sw := p.(*WorkflowPlugin).Swimlanes
+	if sw == nil || sw.GroupBy != "status" || sw.GroupByType != ruki.ValueEnum || len(sw.Enum) == 0 {
+		t.Fatalf("swimlanes = %+v, want status enum grouping", sw)
+	}
+}
+
+func TestParseSwimlanes_Bands(t *testing.T) {
+	p, err := parsePluginConfig(swimlaneBoard(&PluginSwimlanesConfig{Bands: []PluginLaneConfig{
+		{Name: "Bugs", Filter: `select where type = "bug"`, Action: `update where id = id() set type = "bug"`},
+		{Name: "Other", Filter: `select where type != "bug"`},
+	}}), "test.yaml", testSchema(), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	sw := p.(*WorkflowPlugin).Swimlanes
+	if len(sw.Bands) != 2 || sw.Bands[0].Action == nil || sw.Bands[1].Action != nil {
+		t.Fatalf("bands = %+v", sw.Bands)
+	}
+	if got := sw.Bands[0].MoveRequire; !slices.Equal(got, []string{"!denied:set:type", "!denied:set:type=bug"}) {
+		t.Errorf("MoveRequire = %v", got)
+	}
+}
+
+func TestParseSwimlanes_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		sw   *PluginSwimlanesConfig
+		want string
+	}{
+		{"empty", &PluginSwimlanesConfig{}, "require `groupBy:` or `bands:`"},
+		{"both", &PluginSwimlanesConfig{GroupBy: "status", Bands: []PluginLaneConfig{{Name: "A", Filter: "select"}}}, "not both"},
+		{"unknown field", &PluginSwimlanesConfig{GroupBy: "owner"}, `unknown field "owner"`},
+		{"list field", &PluginSwimlanesConfig{GroupBy: "tags"}, "cannot group bands"},
+		{"missing name", &PluginSwimlanesConfig{Bands: []PluginLaneConfig{{Filter: "select"}}}, "swimlane 0 missing name"},
+		{"missing filter", &PluginSwimlanesConfig{Bands: []PluginLaneConfig{{Name: "A"}}}, `swimlane "A" missing filter`},
+		{"duplicate", &PluginSwimlanesConfig{Bands: []PluginLaneConfig{{Name: "A", Filter: "select"}, {Name: "A", Filter: "select"}}}, "duplicate swimlane"},
+		{"filter not select", &PluginSwimlanesConfig{Bands: []PluginLaneConfig{{Name: "A", Filter: `update where id = id() set type = "bug"`}}}, `swimlane "A" filter must be a SELECT`},
+		{"action not update", &PluginSwimlanesConfig{Bands: []PluginLaneConfig{{Name: "A", Filter: "select", Action: "select"}}}, `swimlane "A" action must be an UPDATE`},
+		{"columns", &PluginSwimlanesConfig{Bands: []PluginLaneConfig{{Name: "A", Filter: "select", Columns: 2}}}, "set per lane"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePluginConfig(swimlaneBoard(tt.sw), "test.yaml", testSchema(), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSwimlanes_BoardOnly(t *testing.T) {
+	cfg := swimlaneBoard(&PluginSwimlanesConfig{GroupBy: "status"})
+	cfg.Kind = "list"
+	_, err := parsePluginConfig(cfg, "test.yaml", testSchema(), nil)
+	if err == nil || !strings.Contains(err.Error(), "only valid on board views") {
+		t.Fatalf("list err = %v", err)
+	}
+
+	wiki := pluginFileConfig{Name: "Docs", Kind: "wiki", Path: "index.md", Swimlanes: &PluginSwimlanesConfig{GroupBy: "status"}}
+	_, err = parsePluginConfig(wiki, "test.yaml", testSchema(), nil)
+	if err == nil || !strings.Contains(err.Error(), "only valid on board views") {
+		t.Fatalf("wiki err = %v", err)
+	}
+}
+
+func TestParseSwimlanes_YAML(t *testing.T) {
+	data := []byte(`
+name: Standup
+kind: board
+layout: id
+lanes:
+  - name: Ready
+    filter: select where status = "ready"
+swimlanes:
+  groupBy: assignee
+`)
+	p, err := parsePluginYAML(data, "test.yaml", testSchema())
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if sw := p.(*WorkflowPlugin).Swimlanes; sw == nil || sw.GroupBy != "assignee" || sw.GroupByType != ruki.ValueString {
+		t.Fatalf("swimlanes = %+v", sw)
+	}
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -8,7 +9,10 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/gridlayout"
 	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/workflow"
 )
 
 func testSchema() ruki.Schema {
@@ -521,9 +525,14 @@ func TestParsePluginConfig_LaneActionAllowsTargetQualifier(t *testing.T) {
 			},
 		},
 	}
-	if _, err := parsePluginConfig(cfg, "test.yaml", schema, nil); err != nil {
+	p, err := parsePluginConfig(cfg, "test.yaml", schema, nil)
+	if err != nil {
 		t.Fatalf("expected success, got: %v", err)
 	}
+	want := []string{"!denied:set:status", "!denied:set:status=ready"}
+	if got := p.(*WorkflowPlugin).Lanes[0].MoveRequire; !slices.Equal(got, want) {
+		t.Errorf("MoveRequire = %v, want %v", got, want)
+	}
 }
 
 func TestParsePluginConfig_LaneActionMustBeUpdate(t *testing.T) {
@@ -909,6 +918,66 @@ foreground: "#00ff00"
 	}
 }
 
+func TestParsePluginYAML_Dependencies(t *testing.T) {
+	p, err := parsePluginYAML([]byte(`
+name: Dependencies
+kind: dependencies
+description: what blocks the selected tiki
+`), "test.yaml", testSchema())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	dp, ok := p.(*DependencyPlugin)
+	if !ok {
+		t.Fatalf("Expected DependencyPlugin, got %T", p)
+	}
+	if dp.GetKind() != KindDependencies {
+		t.Errorf("Expected kind dependencies, got %q", dp.GetKind())
+	}
+	if !slices.Contains(dp.GetRequire(), "selection:one") {
+		t.Errorf("Expected selection:one to be required, got %v", dp.GetRequire())
+	}
+
+	_, err = parsePluginYAML([]byte(`
+name: Dependencies
+kind: dependencies
+path: index.md
+`), "test.yaml", testSchema())
+	if err == nil || !strings.Contains(err.Error(), "only valid on kind: wiki") {
+		t.Errorf("Expected path rejection, got: %v", err)
+	}
+}
+
+func TestParsePluginYAML_Sprints(t *testing.T) {
+	p, err := parsePluginYAML([]byte(`
+name: Sprints
+kind: sprints
+description: committed points against capacity
+`), "test.yaml", testSchema())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	sp, ok := p.(*SprintPlugin)
+	if !ok {
+		t.Fatalf("Expected SprintPlugin, got %T", p)
+	}
+	if sp.GetKind() != KindSprints {
+		t.Errorf("Expected kind sprints, got %q", sp.GetKind())
+	}
+	if len(sp.GetRequire()) != 0 {
+		t.Errorf("Expected no requirements, got %v", sp.GetRequire())
+	}
+
+	_, err = parsePluginYAML([]byte(`
+name: Sprints
+kind: sprints
+layout: "title"
+`), "test.yaml", testSchema())
+	if err == nil || !strings.Contains(err.Error(), "only valid on kind: board, list, or detail") {
+		t.Errorf("Expected layout rejection, got: %v", err)
+	}
+}
+
 func TestParsePluginActions_HotDefault(t *testing.T) {
 	parser := testParser()
 	configs := []PluginActionConfig{
@@ -1086,8 +1155,9 @@ func TestParsePluginActions_RequirePreserved(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(actions[0].Require) != 1 || actions[0].Require[0] != "id" {
-		t.Errorf("expected require=[id], got %v", actions[0].Require)
+	// inferred requirements follow the explicit ones, without repeating id
+	if actions[0].Require[0] != "id" || slices.Contains(actions[0].Require[1:], "id") {
+		t.Errorf("expected require to start with a single id, got %v", actions[0].Require)
 	}
 }
 
@@ -1125,6 +1195,48 @@ func TestParsePluginActions_RequireNoAutoInferWithoutID(t *testing.T) {
 	}
 }
 
+func TestParsePluginActions_RequireAutoInferDeniedDelete(t *testing.T) {
+	parser := testParser()
+	configs := []PluginActionConfig{
+		{Key: "d", Label: "Delete", Action: `delete where id = id()`},
+	}
+	actions, err := parsePluginActions(configs, parser, nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsRequirement(actions[0].Require, "!denied:delete") {
+		t.Errorf("expected auto-inferred '!denied:delete' requirement, got %v", actions[0].Require)
+	}
+}
+
+func TestParsePluginActions_RequireAutoInferDeniedSet(t *testing.T) {
+	parser := testParser()
+	configs := []PluginActionConfig{
+		{Key: "r", Label: "Ready", Action: `update where id = id() set status = "READY" tags = tags + ["urgent"] title = "x" + title`},
+		{Key: "a", Label: "Bulk", Action: `update where status = "done" set status = "ready"`},
+		{Key: "i", Label: "Assign", Action: `update where id = id() set assignee = input()`, Input: "string"},
+	}
+	actions, err := parsePluginActions(configs, parser, nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"!denied:set:status", "!denied:set:status=ready", "!denied:set:tags", "!denied:set:tags=urgent", "!denied:set:title"} {
+		if !containsRequirement(actions[0].Require, want) {
+			t.Errorf("expected auto-inferred %q requirement, got %v", want, actions[0].Require)
+		}
+	}
+	if containsRequirement(actions[0].Require, "!denied:set:title=x") {
+		t.Errorf("computed value inferred as a literal: %v", actions[0].Require)
+	}
+	if len(actions[1].Require) > 0 {
+		t.Errorf("expected no requirements for an update not of the selection, got %v", actions[1].Require)
+	}
+	// input: actions cannot be re-parsed; the mutation gate still checks them
+	if containsRequirement(actions[2].Require, "!denied:set:assignee") {
+		t.Errorf("unexpected set requirement on an input: action: %v", actions[2].Require)
+	}
+}
+
 // target.<field> must auto-infer the same "id" requirement as id(), so plugin
 // actions using it stay disabled until exactly one tiki is selected.
 func TestParsePluginActions_RequireAutoInferIDFromTargetQualifier(t *testing.T) {
@@ -1949,6 +2061,69 @@ func TestParseViewAction_BareModeNewStillValid(t *testing.T) {
 	}
 }
 
+// withTemplates installs a workflow template set for the test.
+func withTemplates(t *testing.T, names ...string) {
+	t.Helper()
+	templates := make([]config.TikiTemplate, 0, len(names))
+	for _, name := range names {
+		templates = append(templates, config.TikiTemplate{Name: name})
+	}
+	config.ResetWorkflowTemplatesForTest(templates)
+	t.Cleanup(func() { config.ResetWorkflowTemplatesForTest(nil) })
+}
+
+func TestParseViewAction_CreateFromTemplateSeed(t *testing.T) {
+	withTemplates(t, "bug")
+	pa, err := parseBoardActionWithSeed(t, PluginActionConfig{
+		Key: "n", Label: "New bug", Kind: "view", View: "Project",
+		Mode: "new", Action: `create from "bug" type="project"`,
+	})
+	if err != nil {
+		t.Fatalf("parse create-from seed: %v", err)
+	}
+	if pa.CreateSeed == nil || !pa.CreateSeed.IsCreate() {
+		t.Fatal("expected create-from seed to parse as a create statement")
+	}
+	if pa.CreateTemplate != "bug" {
+		t.Fatalf("CreateTemplate = %q, want %q", pa.CreateTemplate, "bug")
+	}
+}
+
+func TestParseRukiAction_CreateFromTemplate(t *testing.T) {
+	withTemplates(t, "bug")
+	pa, err := parseBoardActionWithSeed(t, PluginActionConfig{
+		Key: "B", Label: "New bug", Action: `create from "bug"`,
+	})
+	if err != nil {
+		t.Fatalf("parse create-from action: %v", err)
+	}
+	if pa.Action == nil || !pa.Action.IsCreate() {
+		t.Fatal("expected create-from action to parse as a create statement")
+	}
+	if pa.CreateTemplate != "bug" {
+		t.Fatalf("CreateTemplate = %q, want %q", pa.CreateTemplate, "bug")
+	}
+}
+
+func TestParseActions_CreateFromUnknownTemplate(t *testing.T) {
+	withTemplates(t, "bug", "feature")
+	for _, cfg := range []PluginActionConfig{
+		{Key: "B", Label: "New bug", Action: `create from "bgu"`},
+		{Key: "n", Label: "New bug", Kind: "view", View: "Project", Mode: "new", Action: `create from "bgu"`},
+	} {
+		_, err := parseBoardActionWithSeed(t, cfg)
+		if err == nil || !strings.Contains(err.Error(), `unknown template "bgu" (valid templates: bug, feature)`) {
+			t.Errorf("key %q: err = %v, want unknown template listing the valid names", cfg.Key, err)
+		}
+	}
+
+	withTemplates(t)
+	_, err := parseBoardActionWithSeed(t, PluginActionConfig{Key: "B", Label: "New bug", Action: `create from "bug"`})
+	if err == nil || !strings.Contains(err.Error(), "the workflow defines no templates") {
+		t.Errorf("err = %v, want no-templates error", err)
+	}
+}
+
 // loadPluginsFromYAML is a test helper that parses a complete workflow YAML
 // snippet and returns plugins, global actions, and any errors.
 func loadPluginsFromYAML(yamlSrc string, schema ruki.Schema) ([]Plugin, []PluginAction, []string) {
@@ -1989,3 +2164,16 @@ func loadPluginsFromYAML(yamlSrc string, schema ruki.Schema) ([]Plugin, []Plugin
 
 	return plugins, actions, errs
 }
+
+// TestLayoutAcceptsNumberField asserts that a number field, which ruki never
+// sees, can still be shown in a layout but not counted like a list.
+func TestLayoutAcceptsNumberField(t *testing.T) {
+	schema := rukiRuntime.NewSchemaFromFields(append(workflow.Fields(),
+		workflow.FieldDef{Name: "estimate", Type: workflow.TypeNumber, Custom: true}))
+	if err := validateLayoutFieldName("p", "detail", "estimate", schema); err != nil {
+		t.Errorf("number field rejected in a layout: %v", err)
+	}
+	if err := validateCountDisplay("p", "estimate", gridlayout.DisplayCount, schema); err == nil {
+		t.Error("expected .count on a number field to be rejected")
+	}
+}
@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// DeniedSetRequirements returns the negated `denied:` requirements that
+// grey out a change of field, or a change setting it to one of values, for
+// tikis whose permission rules the user fails (see
+// config.PermissionRule.Token).
+func DeniedSetRequirements(field string, values ...string) []string {
+	reqs := []string{"!denied:" + config.PermissionSet + ":" + field}
+	for _, v := range values {
+		reqs = append(reqs, "!denied:"+config.PermissionSet+":"+field+"="+v)
+	}
+	return reqs
+}
+
+// setRequirements returns the DeniedSetRequirements of every field an update
+// assigns, with the literal values it assigns them. src must already have
+// passed validation with parser. Values only known when the statement runs
+// are left to the mutation gate.
+func setRequirements(parser *ruki.Parser, src string, stmt *ruki.ValidatedStatement) ([]string, error) {
+	if !stmt.IsUpdate() {
+		return nil, nil
+	}
+	parsed, err := parser.ParseStatement(src)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Update == nil {
+		return nil, nil
+	}
+	var reqs []string
+	for _, a := range parsed.Update.Set {
+		reqs = append(reqs, DeniedSetRequirements(a.Field, assignedValues(a.Field, a.Value)...)...)
+	}
+	return reqs, nil
+}
+
+// assignedValues returns the literal values value gives field: the value
+// itself, the elements of a list, or what `field + [...]` adds to one. Enum
+// values are spelled the way the workflow declares them, as rules are.
+func assignedValues(field string, value ruki.Expr) []string {
+	fd, _ := workflow.Field(field)
+	switch v := value.(type) {
+	case *ruki.StringLiteral:
+		return []string{canonicalEnumValue(fd, v.Value)}
+	case *ruki.IntLiteral:
+		return []string{strconv.Itoa(v.Value)}
+	case *ruki.ListLiteral:
+		var values []string
+		for _, el := range v.Elements {
+			values = append(values, assignedValues(field, el)...)
+		}
+		return values
+	case *ruki.BinaryExpr:
+		if v.Op == "+" && fd.Type.IsList() {
+			return assignedValues(field, v.Right)
+		}
+	}
+	return nil
+}
+
+// canonicalEnumValue returns the declared spelling of an enum value, which
+// ruki matches ignoring case; other values are returned unchanged.
+func canonicalEnumValue(fd workflow.FieldDef, value string) string {
+	if !fd.Type.IsEnum() {
+		return value
+	}
+	for _, allowed := range fd.AllowedValues() {
+		if strings.EqualFold(allowed, value) {
+			return allowed
+		}
+	}
+	return value
+}
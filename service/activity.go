@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// maxSessionActivity caps how many session events the activity log keeps;
+// older events fall off the front.
+const maxSessionActivity = 1000
+
+// ActivityLog records the changes made through the mutation gate in this
+// session. It is safe for concurrent use.
+type ActivityLog struct {
+	mu             sync.Mutex
+	events         []store.ActivityEvent
+	actor          func() string
+	now            func() time.Time
+	listeners      map[int]store.ChangeListener
+	nextListenerID int
+}
+
+// NewActivityLog returns an empty log attributing changes to actor.
+func NewActivityLog(actor func() string) *ActivityLog {
+	return &ActivityLog{actor: actor, now: time.Now, listeners: make(map[int]store.ChangeListener)}
+}
+
+// AddListener registers a callback run after each recorded event and
+// returns an id for RemoveListener.
+func (l *ActivityLog) AddListener(listener store.ChangeListener) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := l.nextListenerID
+	l.nextListenerID++
+	l.listeners[id] = listener
+	return id
+}
+
+// RemoveListener removes a listener registered with AddListener.
+func (l *ActivityLog) RemoveListener(id int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.listeners, id)
+}
+
+// RegisterWithGate records every successful create, update and delete.
+// Updates that change nothing but the gate-maintained timestamp are not
+// recorded.
+func (l *ActivityLog) RegisterWithGate(gate *TikiMutationGate) {
+	gate.OnAfterCreate(l.makeAfterHook(store.ActivityCreated))
+	gate.OnAfterUpdate(l.makeAfterHook(store.ActivityUpdated))
+	gate.OnAfterDelete(l.makeAfterHook(store.ActivityDeleted))
+}
+
+func (l *ActivityLog) makeAfterHook(kind store.ActivityKind) AfterHook {
+	return func(_ context.Context, old, new *tikipkg.Tiki) error {
+		l.record(kind, old, new)
+		return nil
+	}
+}
+
+func (l *ActivityLog) record(kind store.ActivityKind, old, new *tikipkg.Tiki) {
+	subject := new
+	if subject == nil {
+		subject = old
+	}
+	if subject == nil {
+		return
+	}
+	e := store.ActivityEvent{
+		When:   l.now(),
+		TikiID: subject.ID(),
+		Title:  subject.Title(),
+		Kind:   kind,
+	}
+	if kind == store.ActivityUpdated {
+		e.Fields = ChangedFields(old, new)
+		if len(e.Fields) == 0 {
+			return
+		}
+	}
+	if l.actor != nil {
+		e.Actor = l.actor()
+	}
+
+	l.mu.Lock()
+	l.events = append(l.events, e)
+	if over := len(l.events) - maxSessionActivity; over > 0 {
+		l.events = slices.Delete(l.events, 0, over)
+	}
+	listeners := make([]store.ChangeListener, 0, len(l.listeners))
+	for _, listener := range l.listeners {
+		listeners = append(listeners, listener)
+	}
+	l.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+// Events returns a copy of the recorded events, oldest first.
+func (l *ActivityLog) Events() []store.ActivityEvent {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.events)
+}
+
+// StoreActivityActor returns an actor resolver backed by the store's current
+// identity, shown the way ruki's user() shows it. Resolution errors yield an
+// empty actor rather than dropping the event.
+func StoreActivityActor(rs store.ReadStore) func() string {
+	return func() string {
+		display, _ := store.CurrentUserDisplay(rs)
+		return display
+	}
+}
+
+// Activity returns the feed since the given time, newest first: the
+// session's events from log (which may be nil) merged with the committed
+// history of rs when rs can read it.
+func Activity(rs store.ReadStore, log *ActivityLog, since time.Time) []store.ActivityEvent {
+	feed := ActivitySource{Store: rs, Log: log}
+	return MergeActivity(feed.Session(since), feed.Committed(since))
+}
+
+// ActivitySource is the feed of a running session, read in its two parts so
+// a view can re-read the session's changes without reading git again.
+type ActivitySource struct {
+	Store store.ReadStore
+	Log   *ActivityLog // may be nil
+}
+
+// Committed returns the committed history of the store since the given
+// time, when the store can read it. History that cannot be read is logged
+// and left out rather than failing the feed.
+func (s ActivitySource) Committed(since time.Time) []store.ActivityEvent {
+	hr, ok := s.Store.(store.HistoryReader)
+	if !ok {
+		return nil
+	}
+	versions, err := hr.TikiVersionsSince(since)
+	if err != nil {
+		slog.Warn("failed to read committed activity", "error", err)
+		return nil
+	}
+	return CommittedActivity(versions, since)
+}
+
+// Session returns the changes made in this session since the given time.
+func (s ActivitySource) Session(since time.Time) []store.ActivityEvent {
+	return store.FilterActivity(s.Log.Events(), store.ActivityFilter{Since: since})
+}
+
+// AddListener registers a callback run after each change recorded in this
+// session; it is a no-op without a log.
+func (s ActivitySource) AddListener(listener store.ChangeListener) int {
+	if s.Log == nil {
+		return -1
+	}
+	return s.Log.AddListener(listener)
+}
+
+// RemoveListener removes a listener registered with AddListener.
+func (s ActivitySource) RemoveListener(id int) {
+	if s.Log != nil {
+		s.Log.RemoveListener(id)
+	}
+}
+
+// CommittedActivity turns committed versions into events. A deleted
+// version is the tiki's deletion; the first version of a tiki with no
+// earlier one, or following a deletion, is its creation; every other
+// version is an update of the fields that differ from the version before
+// it. Versions before since only serve as that baseline.
+func CommittedActivity(versions []store.TikiVersion, since time.Time) []store.ActivityEvent {
+	byID := make(map[string][]store.TikiVersion)
+	for _, v := range versions {
+		if v.Tiki == nil {
+			continue
+		}
+		byID[v.Tiki.ID()] = append(byID[v.Tiki.ID()], v)
+	}
+
+	var events []store.ActivityEvent
+	for id, history := range byID {
+		slices.SortStableFunc(history, func(a, b store.TikiVersion) int { return a.When.Compare(b.When) })
+		for i, v := range history {
+			if v.When.Before(since) {
+				continue
+			}
+			e := store.ActivityEvent{
+				When:      v.When,
+				Actor:     v.Author,
+				TikiID:    id,
+				Title:     v.Tiki.Title(),
+				Kind:      store.ActivityCreated,
+				Committed: true,
+			}
+			switch {
+			case v.Deleted:
+				e.Kind = store.ActivityDeleted
+			case i > 0 && !history[i-1].Deleted:
+				e.Kind = store.ActivityUpdated
+				e.Fields = ChangedFields(history[i-1].Tiki, v.Tiki)
+				if len(e.Fields) == 0 {
+					continue
+				}
+			}
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// MergeActivity combines session and committed events, newest first. A
+// committed event that repeats a session change — the same actor making the
+// same change to the same tiki, committed after it was made — is dropped.
+func MergeActivity(session, committed []store.ActivityEvent) []store.ActivityEvent {
+	merged := slices.Clone(session)
+	for _, c := range committed {
+		if !slices.ContainsFunc(session, func(s store.ActivityEvent) bool { return sameActivity(s, c) }) {
+			merged = append(merged, c)
+		}
+	}
+	// sort oldest first and reverse, so changes within the same second keep
+	// the order they were made in
+	slices.SortStableFunc(merged, func(a, b store.ActivityEvent) int {
+		if c := a.When.Compare(b.When); c != 0 {
+			return c
+		}
+		return strings.Compare(b.TikiID, a.TikiID)
+	})
+	slices.Reverse(merged)
+	return merged
+}
+
+// sameActivity reports whether the committed event records session change s.
+func sameActivity(s, committed store.ActivityEvent) bool {
+	return s.TikiID == committed.TikiID && s.Kind == committed.Kind &&
+		strings.EqualFold(s.Actor, committed.Actor) && !committed.When.Before(s.When) &&
+		slices.Equal(s.Fields, committed.Fields)
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func TestActivityLog_RecordsGateMutations(t *testing.T) {
+	gate, s := newGateWithStore()
+	log := NewActivityLog(StoreActivityActor(s))
+	log.RegisterWithGate(gate)
+	notified := 0
+	log.AddListener(func() {
+		// listeners run once the event is in the log
+		notified = len(log.Events())
+	})
+	ctx := context.Background()
+
+	if err := gate.CreateTiki(ctx, newWorkflowTiki("AAA001", "A")); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	moved := s.GetTiki("AAA001").Clone()
+	moved.Set("status", "ready")
+	moved.Set("priority", "high")
+	if err := gate.UpdateTiki(ctx, moved); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	// saving an unchanged tiki is not activity
+	if err := gate.UpdateTiki(ctx, s.GetTiki("AAA001").Clone()); err != nil {
+		t.Fatalf("no-op update: %v", err)
+	}
+	if err := gate.DeleteTiki(ctx, s.GetTiki("AAA001")); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	events := log.Events()
+	if len(events) != 3 {
+		t.Fatalf("events = %+v, want create, update and delete", events)
+	}
+	if notified != 3 {
+		t.Errorf("listener saw %d events after the last change, want 3", notified)
+	}
+	kinds := []store.ActivityKind{events[0].Kind, events[1].Kind, events[2].Kind}
+	if !slices.Equal(kinds, []store.ActivityKind{store.ActivityCreated, store.ActivityUpdated, store.ActivityDeleted}) {
+		t.Errorf("kinds = %v", kinds)
+	}
+	if !slices.Equal(events[1].Fields, []string{"priority", "status"}) {
+		t.Errorf("updated fields = %v", events[1].Fields)
+	}
+	for _, e := range events {
+		if e.Actor != "memory-user" || e.TikiID != "AAA001" || e.Committed {
+			t.Errorf("event = %+v", e)
+		}
+	}
+}
+
+func TestCommittedActivity(t *testing.T) {
+	at := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	version := func(tk *tikipkg.Tiki, author string, d int) store.TikiVersion {
+		return store.TikiVersion{Tiki: tk, Author: author, When: at(d)}
+	}
+	oldA := newWorkflowTiki("AAA001", "A")
+	doneA := oldA.Clone()
+	doneA.Set("status", "done")
+	doneA.Set("priority", "high")
+	newB := newWorkflowTiki("BBB001", "B")
+	readyB := newB.Clone()
+	readyB.Set("status", "ready")
+
+	since := at(5)
+	// versions arrive unordered; AAA001's first version is only a baseline
+	events := CommittedActivity([]store.TikiVersion{
+		version(readyB, "bob", 7),
+		version(doneA, "alice", 6),
+		version(newB, "bob", 5),
+		version(oldA, "alice", 1),
+	}, since)
+	events = MergeActivity(nil, events)
+
+	if len(events) != 3 {
+		t.Fatalf("events = %+v, want 3", events)
+	}
+	if e := events[0]; e.TikiID != "BBB001" || e.Kind != store.ActivityUpdated || !slices.Equal(e.Fields, []string{"status"}) {
+		t.Errorf("newest = %+v", e)
+	}
+	if e := events[1]; e.TikiID != "AAA001" || e.Actor != "alice" || !slices.Equal(e.Fields, []string{"priority", "status"}) || !e.Committed {
+		t.Errorf("second = %+v", e)
+	}
+	if e := events[2]; e.TikiID != "BBB001" || e.Kind != store.ActivityCreated {
+		t.Errorf("oldest = %+v", e)
+	}
+}
+
+func TestMergeActivity_DropsCommittedSessionChanges(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 3, 2, h, 0, 0, 0, time.UTC) }
+	session := []store.ActivityEvent{
+		{When: at(10), Actor: "alice", TikiID: "AAA001", Kind: store.ActivityUpdated, Fields: []string{"status"}},
+	}
+	committed := []store.ActivityEvent{
+		{When: at(11), Actor: "Alice", TikiID: "AAA001", Kind: store.ActivityUpdated, Fields: []string{"status"}, Committed: true},
+		{When: at(9), Actor: "alice", TikiID: "AAA001", Kind: store.ActivityUpdated, Fields: []string{"status"}, Committed: true},
+		{When: at(12), Actor: "bob", TikiID: "AAA001", Kind: store.ActivityUpdated, Fields: []string{"status"}, Committed: true},
+	}
+	got := MergeActivity(session, committed)
+	if len(got) != 3 {
+		t.Fatalf("merged = %+v, want the commit of the session change dropped", got)
+	}
+	if got[0].Actor != "bob" || got[1].Committed || !got[2].When.Equal(at(9)) {
+		t.Errorf("merged = %+v", got)
+	}
+}
+
+func TestCommittedActivity_SameSecondKeepsCommitOrder(t *testing.T) {
+	when := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	created := newWorkflowTiki("AAA001", "A")
+	ready := created.Clone()
+	ready.Set("status", "ready")
+	// versions come oldest first from the history reader
+	events := MergeActivity(nil, CommittedActivity([]store.TikiVersion{
+		{Tiki: created, Author: "alice", When: when},
+		{Tiki: ready, Author: "bob", When: when},
+	}, when))
+	if len(events) != 2 || events[0].Kind != store.ActivityUpdated || events[1].Kind != store.ActivityCreated {
+		t.Errorf("events = %+v, want the update listed above the create", events)
+	}
+}
+
+func TestCommittedActivity_Deletions(t *testing.T) {
+	at := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	a := newWorkflowTiki("AAA001", "A")
+	restored := a.Clone()
+	restored.Set("status", "ready")
+	events := MergeActivity(nil, CommittedActivity([]store.TikiVersion{
+		{Tiki: a, Author: "alice", When: at(1)},
+		{Tiki: a, Author: "bob", When: at(6), Deleted: true},
+		{Tiki: restored, Author: "carol", When: at(7)},
+	}, at(5)))
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want the deletion and the re-creation", events)
+	}
+	if e := events[1]; e.Kind != store.ActivityDeleted || e.Actor != "bob" || e.Title != "A" || len(e.Fields) != 0 {
+		t.Errorf("deletion = %+v", e)
+	}
+	if e := events[0]; e.Kind != store.ActivityCreated || e.Actor != "carol" {
+		t.Errorf("after a deletion = %+v, want a creation", e)
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/document"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// AttachmentsField is the workflow field that records a tiki's attachments.
+// Workflows opt in by declaring it as a stringList.
+const AttachmentsField = "attachments"
+
+// AttachFile copies the file at src into the tiki's asset folder
+// (`.assets/<id>/` next to its markdown file) and appends the copy's
+// relative path to the attachments field through the mutation gate, so
+// triggers and hooks see an ordinary update. A name already taken in the
+// folder gets a numeric suffix. Returns the recorded relative path. When the
+// update is rejected the copied file is removed again.
+func AttachFile(ctx context.Context, gate *TikiMutationGate, tikiID, src string) (string, error) {
+	fd, ok := workflow.Field(AttachmentsField)
+	if !ok || fd.Type != workflow.TypeListString {
+		return "", fmt.Errorf("workflow does not declare an %q stringList field", AttachmentsField)
+	}
+	rs := gate.ReadStore()
+	tk := rs.GetTiki(tikiID)
+	if tk == nil {
+		return "", fmt.Errorf("tiki not found: %s", tikiID)
+	}
+	docPath := tk.Path()
+	if docPath == "" {
+		docPath = rs.PathForID(tk.ID())
+	}
+	if docPath == "" {
+		return "", fmt.Errorf("tiki %s has no file on disk", tk.ID())
+	}
+
+	src, err := expandHome(strings.TrimSpace(src))
+	if err != nil {
+		return "", err
+	}
+	if src == "" {
+		return "", errors.New("no file given")
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", fmt.Errorf("attach: %w", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("attach: %s is a directory", src)
+	}
+
+	dir := document.AssetDir(docPath, tk.ID())
+	//nolint:gosec // G301: 0755 matches the document directory permissions
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating attachment folder: %w", err)
+	}
+	name := uniqueAttachmentName(dir, attachmentName(filepath.Base(src)))
+	dst := filepath.Join(dir, name)
+	if err := copyFile(src, dst); err != nil {
+		return "", fmt.Errorf("copying attachment: %w", err)
+	}
+
+	rel := document.AssetRelPath(tk.ID(), name)
+	updated := tk.Clone()
+	existing, _, _ := updated.StringSliceField(AttachmentsField)
+	list := make([]string, 0, len(existing)+1)
+	list = append(list, existing...)
+	list = append(list, rel)
+	updated.Set(AttachmentsField, list)
+	if err := gate.UpdateTiki(ctx, updated); err != nil {
+		_ = os.Remove(dst)
+		return "", err
+	}
+	return rel, nil
+}
+
+// AttachmentsMarkdown renders a tiki's attachments as a markdown section
+// appended below the description: images as inline image links, so the
+// detail view's image pipeline renders them, and everything else as plain
+// links. Returns "" when there are no attachments.
+func AttachmentsMarkdown(attachments []string) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("## Attachments\n\n")
+	for _, a := range attachments {
+		name := path.Base(a)
+		if document.IsImageAsset(name) {
+			fmt.Fprintf(&b, "![%s](%s)\n\n", name, a)
+			continue
+		}
+		fmt.Fprintf(&b, "- [%s](%s)\n", name, a)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// attachmentName makes a source file name safe to use as a markdown link
+// target by replacing whitespace with hyphens.
+func attachmentName(name string) string {
+	return strings.Join(strings.Fields(name), "-")
+}
+
+// uniqueAttachmentName returns name, or name with a "-N" suffix before the
+// extension when a file of that name already exists in dir.
+func uniqueAttachmentName(dir, name string) string {
+	if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+		return name
+	}
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		candidate := stem + "-" + strconv.Itoa(i) + ext
+		if _, err := os.Stat(filepath.Join(dir, candidate)); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
+
+// expandHome resolves a leading "~/" against the user's home directory.
+func expandHome(p string) (string, error) {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(p, "~")), nil
+}
+
+// copyFile copies src to a new file at dst.
+func copyFile(src, dst string) error {
+	//nolint:gosec // G304: the user chose src explicitly
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	//nolint:gosec // G302: attachments share the document files' permissions
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
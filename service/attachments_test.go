@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/internal/teststatuses"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func withAttachmentsField(t *testing.T) {
+	t.Helper()
+	t.Cleanup(teststatuses.Init)
+	if err := teststatuses.InitWith([]workflow.FieldDef{{Name: AttachmentsField, Type: workflow.TypeListString}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeAttachmentSource(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestAttachFile_CopiesAndRecords(t *testing.T) {
+	withAttachmentsField(t)
+	gate, s := newGateWithStore()
+	docDir := t.TempDir()
+	tk := newWorkflowTiki("ABC123", "crash on save")
+	tk.SetPath(filepath.Join(docDir, "crash-on-save.md"))
+	if err := s.CreateTiki(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	src := writeAttachmentSource(t, "crash log.txt", "boom")
+	rel, err := AttachFile(context.Background(), gate, "ABC123", src)
+	if err != nil {
+		t.Fatalf("AttachFile: %v", err)
+	}
+	if rel != ".assets/ABC123/crash-log.txt" {
+		t.Errorf("rel = %q", rel)
+	}
+	data, err := os.ReadFile(filepath.Join(docDir, ".assets", "ABC123", "crash-log.txt"))
+	if err != nil || string(data) != "boom" {
+		t.Fatalf("copied file = %q, %v", data, err)
+	}
+
+	// a second file with the same name gets a numeric suffix
+	rel2, err := AttachFile(context.Background(), gate, "ABC123", src)
+	if err != nil {
+		t.Fatalf("AttachFile: %v", err)
+	}
+	if rel2 != ".assets/ABC123/crash-log-2.txt" {
+		t.Errorf("rel2 = %q", rel2)
+	}
+	got, _, _ := s.GetTiki("ABC123").StringSliceField(AttachmentsField)
+	if len(got) != 2 || got[0] != rel || got[1] != rel2 {
+		t.Errorf("attachments = %v", got)
+	}
+}
+
+func TestAttachFile_Errors(t *testing.T) {
+	gate, s := newGateWithStore()
+	tk := newWorkflowTiki("ABC123", "x")
+	tk.SetPath(filepath.Join(t.TempDir(), "x.md"))
+	if err := s.CreateTiki(tk); err != nil {
+		t.Fatal(err)
+	}
+	src := writeAttachmentSource(t, "a.txt", "a")
+
+	if _, err := AttachFile(context.Background(), gate, "ABC123", src); err == nil || !strings.Contains(err.Error(), "does not declare") {
+		t.Errorf("undeclared field: err = %v", err)
+	}
+
+	withAttachmentsField(t)
+	if _, err := AttachFile(context.Background(), gate, "NOPE00", src); err == nil {
+		t.Error("expected error for unknown tiki")
+	}
+	if _, err := AttachFile(context.Background(), gate, "ABC123", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if _, err := AttachFile(context.Background(), gate, "ABC123", t.TempDir()); err == nil || !strings.Contains(err.Error(), "directory") {
+		t.Errorf("directory: err = %v", err)
+	}
+}
+
+func TestAttachFile_RejectedUpdateRemovesCopy(t *testing.T) {
+	withAttachmentsField(t)
+	gate, s := newGateWithStore()
+	docDir := t.TempDir()
+	tk := newWorkflowTiki("ABC123", "x")
+	tk.SetPath(filepath.Join(docDir, "x.md"))
+	if err := s.CreateTiki(tk); err != nil {
+		t.Fatal(err)
+	}
+	gate.OnUpdate(func(_, _ *tikipkg.Tiki, _ []*tikipkg.Tiki) *Rejection {
+		return &Rejection{Reason: "frozen"}
+	})
+	if _, err := AttachFile(context.Background(), gate, "ABC123", writeAttachmentSource(t, "a.txt", "a")); err == nil {
+		t.Fatal("expected rejection")
+	}
+	if _, err := os.Stat(filepath.Join(docDir, ".assets", "ABC123", "a.txt")); !os.IsNotExist(err) {
+		t.Errorf("copied file should be removed, stat err = %v", err)
+	}
+}
+
+func TestAttachmentsMarkdown(t *testing.T) {
+	if got := AttachmentsMarkdown(nil); got != "" {
+		t.Errorf("empty = %q", got)
+	}
+	got := AttachmentsMarkdown([]string{".assets/ABC123/shot.png", ".assets/ABC123/log.txt"})
+	for _, want := range []string{"## Attachments", "![shot.png](.assets/ABC123/shot.png)", "- [log.txt](.assets/ABC123/log.txt)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown %q missing %q", got, want)
+		}
+	}
+}
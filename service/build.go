@@ -1,9 +1,12 @@
 package service
 
-// BuildGate creates a TikiMutationGate with standard field validators registered.
-// Call SetStore() on the returned gate after store initialization.
+// BuildGate creates a TikiMutationGate with standard field validators and,
+// when the workflow declares dependencies or permissions, the dependency-graph
+// and permission validators registered. Call SetStore() on the returned gate after store initialization.
 func BuildGate() *TikiMutationGate {
 	gate := NewTikiMutationGate()
 	RegisterFieldValidators(gate)
+	RegisterDependencyValidators(gate)
+	RegisterPermissionValidators(gate)
 	return gate
 }
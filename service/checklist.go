@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boolean-maybe/tiki/document"
+)
+
+// ToggleChecklistItem checks or unchecks the n-th (zero-based) task list item
+// in the tiki's body and saves the body through the mutation gate, so
+// triggers — such as one that closes a tiki once every item is checked — see
+// an ordinary update. Returns the item as it is after the toggle.
+func ToggleChecklistItem(ctx context.Context, gate *TikiMutationGate, tikiID string, n int) (document.ChecklistItem, error) {
+	tk := gate.ReadStore().GetTiki(tikiID)
+	if tk == nil {
+		return document.ChecklistItem{}, fmt.Errorf("tiki not found: %s", tikiID)
+	}
+	body, err := document.ToggleChecklistItem(tk.Body(), n)
+	if err != nil {
+		return document.ChecklistItem{}, err
+	}
+	updated := tk.Clone()
+	updated.SetBody(body)
+	if err := gate.UpdateTiki(ctx, updated); err != nil {
+		return document.ChecklistItem{}, err
+	}
+	return document.ParseChecklist(body)[n], nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// checklistTriggerSchema adds the checklist fields to testTriggerSchema.
+type checklistTriggerSchema struct{ testTriggerSchema }
+
+func (s checklistTriggerSchema) Field(name string) (ruki.FieldSpec, bool) {
+	if workflow.IsChecklistFieldName(name) {
+		return ruki.FieldSpec{Name: name, Type: ruki.ValueInt}, true
+	}
+	return s.testTriggerSchema.Field(name)
+}
+
+func TestToggleChecklistItem_ClosesTikiThroughTrigger(t *testing.T) {
+	store.InstallChecklistResolver()
+	p := ruki.NewParser(checklistTriggerSchema{})
+	trig, err := p.ParseTrigger(`after update where new.checklistTotal > 0 and new.checklistDone = new.checklistTotal and new.status != "done" update where id = new.id set status = "done"`)
+	if err != nil {
+		t.Fatalf("parse trigger: %v", err)
+	}
+	tk := newTiki("LIST01", "release", "inProgress", "story", 3)
+	tk.SetBody("- [x] tag\n- [ ] announce\n")
+	gate, s := newGateWithStoreAndTikis(tk)
+	engine := NewTriggerEngine([]triggerEntry{{description: "close finished checklists", trigger: trig}}, nil,
+		ruki.NewTriggerExecutor(checklistTriggerSchema{}, testTriggerDocFactory(), nil))
+	engine.RegisterWithGate(gate)
+
+	item, err := ToggleChecklistItem(context.Background(), gate, "LIST01", 1)
+	if err != nil {
+		t.Fatalf("ToggleChecklistItem: %v", err)
+	}
+	if item.Text != "announce" || !item.Done {
+		t.Errorf("toggled item = %+v, want announce checked", item)
+	}
+	got := s.GetTiki("LIST01")
+	if got.Body() != "- [x] tag\n- [x] announce\n" {
+		t.Errorf("body = %q", got.Body())
+	}
+	if status, _, _ := got.StringField("status"); status != "done" {
+		t.Errorf("status = %q, want done once every item is checked", status)
+	}
+
+	if _, err := ToggleChecklistItem(context.Background(), gate, "LIST01", 2); err == nil || !strings.Contains(err.Error(), "no checklist item 3") {
+		t.Errorf("toggling a missing item: err = %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// RegisterDependencyValidators registers the dependency-graph checks on
+// create and update when the workflow declares a dependencies: section.
+func RegisterDependencyValidators(g *TikiMutationGate) {
+	cfg, ok := config.WorkflowDependencies()
+	if !ok {
+		return
+	}
+	v := dependencyValidator(cfg)
+	g.OnCreate(v)
+	g.OnUpdate(v)
+}
+
+// dependencyValidator rejects writes to the derived dependency fields and
+// mutations that would close a dependency cycle. The cycle check runs over
+// the candidate world state, so it sees the proposed dependency list.
+func dependencyValidator(cfg config.DependencyConfig) MutationValidator {
+	return func(old, new *tikipkg.Tiki, allTikis []*tikipkg.Tiki) *Rejection {
+		if new == nil {
+			return nil
+		}
+		for _, name := range workflow.DerivedFieldNames() {
+			if _, present := new.Get(name); present {
+				return &Rejection{Reason: fmt.Sprintf("%s is computed from dependencies and cannot be set", name)}
+			}
+		}
+		if old != nil && !dependenciesChanged(cfg.Field, old, new) {
+			return nil
+		}
+		cycle := store.NewDependencyGraph(allTikis, cfg).FindCycle(new.ID())
+		if cycle != nil {
+			return &Rejection{Reason: "dependency cycle: " + strings.Join(cycle, " → ")}
+		}
+		return nil
+	}
+}
+
+// dependenciesChanged reports whether the dependency list differs between
+// old and new; unchanged lists cannot introduce a cycle.
+func dependenciesChanged(field string, old, new *tikipkg.Tiki) bool {
+	before, _, _ := old.StringSliceField(field)
+	after, _, _ := new.StringSliceField(field)
+	if len(before) != len(after) {
+		return true
+	}
+	for i := range before {
+		if before[i] != after[i] {
+			return true
+		}
+	}
+	return false
+}
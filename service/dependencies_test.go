@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/internal/teststatuses"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func withDependencies(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { config.ResetWorkflowDependenciesForTest(nil) })
+	teststatuses.Init()
+	config.ResetWorkflowDependenciesForTest(&config.DependencyConfig{
+		Field: "dependsOn", DoneStatus: config.DoneStatus{StatusField: "status", Done: []string{"done"}},
+	})
+}
+
+func newDependentTiki(id, title string, deps ...string) *tikipkg.Tiki {
+	tk := newWorkflowTiki(id, title)
+	if len(deps) > 0 {
+		tk.Set("dependsOn", deps)
+	}
+	return tk
+}
+
+func TestDependencyValidator_RejectsCycle(t *testing.T) {
+	withDependencies(t)
+	gate, s := newGateWithStore()
+	RegisterDependencyValidators(gate)
+	ctx := context.Background()
+	for _, tk := range []*tikipkg.Tiki{
+		newDependentTiki("AAA001", "A"),
+		newDependentTiki("BBB001", "B", "AAA001"),
+		newDependentTiki("CCC001", "C", "BBB001"),
+	} {
+		if err := gate.CreateTiki(ctx, tk); err != nil {
+			t.Fatalf("create %s: %v", tk.ID(), err)
+		}
+	}
+
+	a := s.GetTiki("AAA001").Clone()
+	a.Set("dependsOn", []string{"CCC001"})
+	err := gate.UpdateTiki(ctx, a)
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle: AAA001 → CCC001 → BBB001 → AAA001") {
+		t.Fatalf("err = %v, want dependency cycle rejection", err)
+	}
+
+	self := s.GetTiki("AAA001").Clone()
+	self.Set("dependsOn", []string{"AAA001"})
+	if err := gate.UpdateTiki(ctx, self); err == nil {
+		t.Error("self-dependency accepted")
+	}
+
+	// unrelated edits to a tiki inside an existing graph still pass
+	c := s.GetTiki("CCC001").Clone()
+	c.SetTitle("C renamed")
+	if err := gate.UpdateTiki(ctx, c); err != nil {
+		t.Errorf("unrelated update rejected: %v", err)
+	}
+}
+
+func TestDependencyValidator_RejectsDerivedFieldWrites(t *testing.T) {
+	withDependencies(t)
+	gate, _ := newGateWithStore()
+	RegisterDependencyValidators(gate)
+
+	tk := newDependentTiki("AAA001", "A")
+	tk.Set("blocked", true)
+	err := gate.CreateTiki(context.Background(), tk)
+	if err == nil || !strings.Contains(err.Error(), "blocked is computed") {
+		t.Fatalf("err = %v, want derived-field rejection", err)
+	}
+}
+
+func TestRegisterDependencyValidators_NoopWithoutConfig(t *testing.T) {
+	teststatuses.Init()
+	config.ResetWorkflowDependenciesForTest(nil)
+	gate, s := newGateWithStore()
+	RegisterDependencyValidators(gate)
+	ctx := context.Background()
+	if err := gate.CreateTiki(ctx, newDependentTiki("AAA001", "A", "AAA001")); err != nil {
+		t.Fatalf("create without dependencies config: %v", err)
+	}
+	if s.GetTiki("AAA001") == nil {
+		t.Fatal("tiki not stored")
+	}
+}
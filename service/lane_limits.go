@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/boolean-maybe/ruki"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// LaneLimit is an enforced WIP limit: at most Limit tikis may match the
+// lane's Filter.
+type LaneLimit struct {
+	View   string
+	Lane   string
+	Limit  int
+	Filter *ruki.ValidatedStatement
+}
+
+// RegisterLaneLimitValidators rejects creates and updates that would bring
+// a tiki into a lane already at its limit. Tikis already in the lane can
+// still be edited, so a lane that is over its limit (because the limit was
+// lowered, or the tikis were edited outside tiki) only drains.
+func RegisterLaneLimitValidators(g *TikiMutationGate, limits []LaneLimit, schema ruki.Schema, userFunc func() string) {
+	if len(limits) == 0 {
+		return
+	}
+	factory := ruki.DocumentFactory(tikipkg.NewDoc)
+	executor := ruki.NewExecutor(schema, factory, userFunc,
+		ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimePlugin})
+	for _, limit := range limits {
+		v := laneLimitValidator(g, executor, limit)
+		g.OnCreate(v)
+		g.OnUpdate(v)
+	}
+}
+
+func laneLimitValidator(g *TikiMutationGate, executor *ruki.Executor, limit LaneLimit) MutationValidator {
+	return func(old, new *tikipkg.Tiki, allTikis []*tikipkg.Tiki) *Rejection {
+		if new == nil {
+			return nil
+		}
+		members, ok := laneMemberIDs(executor, limit, allTikis)
+		if !ok || !members[new.ID()] {
+			return nil
+		}
+		if old != nil {
+			before, ok := laneMemberIDs(executor, limit, g.ReadStore().GetAllTikis())
+			if !ok || before[old.ID()] {
+				return nil
+			}
+		}
+		if len(members) <= limit.Limit {
+			return nil
+		}
+		return &Rejection{Reason: fmt.Sprintf("lane %q in %s is at its WIP limit (%d)", limit.Lane, limit.View, limit.Limit)}
+	}
+}
+
+// laneMemberIDs runs the lane filter over tikis. A failing filter never
+// blocks a mutation.
+func laneMemberIDs(executor *ruki.Executor, limit LaneLimit, tikis []*tikipkg.Tiki) (map[string]bool, bool) {
+	result, err := executor.Execute(limit.Filter, tikipkg.WrapDocs(tikis))
+	if err != nil {
+		slog.Error("failed to execute lane filter for WIP limit", "view", limit.View, "lane", limit.Lane, "error", err)
+		return nil, false
+	}
+	ids := make(map[string]bool, len(result.Select.Tikis))
+	for _, tk := range tikipkg.UnwrapDocs(result.Select.Tikis) {
+		ids[tk.ID()] = true
+	}
+	return ids, true
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/ruki"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func inProgressLimit(t *testing.T, limit int) LaneLimit {
+	t.Helper()
+	stmt, err := ruki.NewParser(testTriggerSchema{}).ParseAndValidateStatement(`select where status = "inProgress"`, ruki.ExecutorRuntimePlugin)
+	if err != nil {
+		t.Fatalf("parse filter: %v", err)
+	}
+	return LaneLimit{View: "Kanban", Lane: "Doing", Limit: limit, Filter: stmt}
+}
+
+// limitTiki is a workflow tiki with a status the trigger test schema knows.
+func limitTiki(id, status string) *tikipkg.Tiki {
+	tk := newWorkflowTiki(id, id)
+	tk.Set("status", status)
+	return tk
+}
+
+func TestLaneLimitValidator(t *testing.T) {
+	gate, s := newGateWithStore()
+	RegisterLaneLimitValidators(gate, []LaneLimit{inProgressLimit(t, 1)}, testTriggerSchema{}, nil)
+	ctx := context.Background()
+
+	for _, tk := range []*tikipkg.Tiki{limitTiki("AAA001", "inProgress"), limitTiki("BBB001", "ready")} {
+		if err := gate.CreateTiki(ctx, tk); err != nil {
+			t.Fatalf("create %s: %v", tk.ID(), err)
+		}
+	}
+
+	// moving a second tiki into the full lane is rejected
+	b := s.GetTiki("BBB001").Clone()
+	b.Set("status", "inProgress")
+	err := gate.UpdateTiki(ctx, b)
+	if err == nil || !strings.Contains(err.Error(), `lane "Doing" in Kanban is at its WIP limit (1)`) {
+		t.Fatalf("err = %v, want WIP limit rejection", err)
+	}
+
+	// so is creating one straight into it
+	if err := gate.CreateTiki(ctx, limitTiki("CCC001", "inProgress")); err == nil {
+		t.Error("create into a full lane accepted")
+	}
+
+	// tikis already in the lane can still be edited
+	a := s.GetTiki("AAA001").Clone()
+	a.SetTitle("A renamed")
+	if err := gate.UpdateTiki(ctx, a); err != nil {
+		t.Errorf("edit inside the lane rejected: %v", err)
+	}
+
+	// once the lane drains there is room again
+	a = s.GetTiki("AAA001").Clone()
+	a.Set("status", "done")
+	if err := gate.UpdateTiki(ctx, a); err != nil {
+		t.Fatalf("move out: %v", err)
+	}
+	if err := gate.UpdateTiki(ctx, b); err != nil {
+		t.Errorf("move into a lane with room rejected: %v", err)
+	}
+}
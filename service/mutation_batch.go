@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// MutationBatch is a set of mutations applied all-or-nothing by
+// ApplyBatch. Creates carry the new tikis, Updates the full post-mutation
+// tikis (exact-presence, as for UpdateTiki), and Deletes the tikis to remove.
+// A tiki id should appear in at most one list.
+type MutationBatch struct {
+	Creates []*tikipkg.Tiki
+	Updates []*tikipkg.Tiki
+	Deletes []*tikipkg.Tiki
+}
+
+// Len returns the number of mutations in the batch.
+func (b *MutationBatch) Len() int {
+	return len(b.Creates) + len(b.Updates) + len(b.Deletes)
+}
+
+// batchOp is one staged write with the snapshot needed to undo it.
+type batchOp struct {
+	kind string // "create", "update", "delete"
+	tk   *tikipkg.Tiki
+	old  *tikipkg.Tiki // persisted version before the batch; nil for create
+}
+
+// ValidateBatch runs every create, update and delete validator against the
+// batch without writing anything. Validators see the candidate world with
+// the whole batch applied, so aggregate rules (WIP limits, dependency
+// cycles) judge the end state rather than a half-applied one. All
+// rejections are collected into one *RejectionError, each prefixed with the
+// tiki id it concerns.
+func (g *TikiMutationGate) ValidateBatch(b *MutationBatch) error {
+	_, err := g.prepareBatch(b)
+	return err
+}
+
+// ApplyBatch validates the whole batch, then persists it: creates first (so
+// updates may reference new tikis), then updates, then deletes. If any write
+// fails, the writes already made are undone in reverse order and the store
+// is left as it was; deleted tikis are restored from their snapshots, but
+// attachments removed with them are not. After-hooks run only once every
+// write has succeeded.
+func (g *TikiMutationGate) ApplyBatch(ctx context.Context, b *MutationBatch) error {
+	if err := checkTriggerDepth(ctx); err != nil {
+		return err
+	}
+	ops, err := g.prepareBatch(b)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i, op := range ops {
+		if err := g.writeBatchOp(op, now); err != nil {
+			err = fmt.Errorf("%s %s: %w", op.kind, batchLabel(op), err)
+			if rbErr := g.rollbackBatch(ops[:i]); rbErr != nil {
+				return fmt.Errorf("%w; rollback incomplete: %v", err, rbErr)
+			}
+			return fmt.Errorf("%w (rolled back %d earlier writes)", err, i)
+		}
+	}
+
+	for _, op := range ops {
+		switch op.kind {
+		case "create":
+			g.runAfterHooks(ctx, g.afterCreateHooks, nil, op.tk.Clone())
+		case "update":
+			g.runAfterHooks(ctx, g.afterUpdateHooks, op.old, op.tk.Clone())
+		case "delete":
+			g.runAfterHooks(ctx, g.afterDeleteHooks, op.old, nil)
+		}
+	}
+	return nil
+}
+
+// prepareBatch snapshots the persisted versions, builds the candidate world
+// and runs the validators. Returns the ops in write order.
+func (g *TikiMutationGate) prepareBatch(b *MutationBatch) ([]batchOp, error) {
+	g.ensureStore()
+
+	ops := make([]batchOp, 0, b.Len())
+	for _, tk := range b.Creates {
+		ops = append(ops, batchOp{kind: "create", tk: tk})
+	}
+	for _, tk := range b.Updates {
+		raw := g.store.GetTiki(tk.ID())
+		if raw == nil {
+			return nil, fmt.Errorf("tiki not found: %s", tk.ID())
+		}
+		ops = append(ops, batchOp{kind: "update", tk: tk, old: raw.Clone()})
+	}
+	for _, tk := range b.Deletes {
+		raw := g.store.GetTiki(tk.ID())
+		if raw == nil {
+			// already gone — nothing to write or undo
+			continue
+		}
+		ops = append(ops, batchOp{kind: "delete", tk: tk, old: raw.Clone()})
+	}
+
+	world := g.batchWorld(ops)
+	var rejections []Rejection
+	for _, op := range ops {
+		var err error
+		switch op.kind {
+		case "create":
+			err = g.runValidators(g.createValidators, nil, op.tk, world)
+		case "update":
+			err = g.runValidators(g.updateValidators, op.old, op.tk, world)
+		case "delete":
+			err = g.runValidators(g.deleteValidators, op.old, nil, world)
+		}
+		var rejErr *RejectionError
+		if errors.As(err, &rejErr) {
+			for _, r := range rejErr.Rejections {
+				rejections = append(rejections, Rejection{Reason: batchLabel(op) + ": " + r.Reason})
+			}
+		}
+	}
+	if len(rejections) > 0 {
+		return nil, &RejectionError{Rejections: rejections}
+	}
+	return ops, nil
+}
+
+// batchWorld returns all stored tikis with the batch applied.
+func (g *TikiMutationGate) batchWorld(ops []batchOp) []*tikipkg.Tiki {
+	replaced := map[string]*tikipkg.Tiki{}
+	deleted := map[string]bool{}
+	var created []*tikipkg.Tiki
+	for _, op := range ops {
+		switch op.kind {
+		case "create":
+			created = append(created, op.tk)
+		case "update":
+			replaced[op.tk.ID()] = op.tk
+		case "delete":
+			deleted[op.tk.ID()] = true
+		}
+	}
+	stored := g.store.GetAllTikis()
+	world := make([]*tikipkg.Tiki, 0, len(stored)+len(created))
+	for _, t := range stored {
+		switch {
+		case deleted[t.ID()]:
+		case replaced[t.ID()] != nil:
+			world = append(world, replaced[t.ID()])
+		default:
+			world = append(world, t)
+		}
+	}
+	return append(world, created...)
+}
+
+func (g *TikiMutationGate) writeBatchOp(op batchOp, now time.Time) error {
+	switch op.kind {
+	case "create":
+		if op.tk.CreatedAt().IsZero() {
+			op.tk.SetCreatedAt(now)
+		}
+		op.tk.SetUpdatedAt(now)
+		return g.store.CreateTiki(op.tk)
+	case "update":
+		op.tk.SetUpdatedAt(now)
+		return g.store.UpdateTiki(op.tk)
+	default:
+		g.store.DeleteTiki(op.tk.ID())
+		if g.store.GetTiki(op.tk.ID()) != nil {
+			return fmt.Errorf("store did not delete the tiki")
+		}
+		return nil
+	}
+}
+
+// rollbackBatch undoes written ops in reverse order, returning the first
+// failure after attempting them all.
+func (g *TikiMutationGate) rollbackBatch(written []batchOp) error {
+	var firstErr error
+	for i := len(written) - 1; i >= 0; i-- {
+		op := written[i]
+		var err error
+		switch op.kind {
+		case "create":
+			g.store.DeleteTiki(op.tk.ID())
+		case "update":
+			err = g.store.UpdateTiki(g.rollbackVersion(op))
+		case "delete":
+			err = g.store.CreateTiki(op.old.Clone())
+		}
+		if err != nil {
+			slog.Error("batch rollback failed", "op", op.kind, "tiki_id", op.tk.ID(), "error", err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("restore %s: %w", op.tk.ID(), err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// rollbackVersion returns the snapshot to restore for a written update,
+// carrying the path and modification time of the version the batch just
+// wrote: the snapshot's own are stale, and a store with optimistic locking
+// would reject the restore as a conflict.
+func (g *TikiMutationGate) rollbackVersion(op batchOp) *tikipkg.Tiki {
+	restore := op.old.Clone()
+	if current := g.store.GetTiki(op.tk.ID()); current != nil {
+		restore.SetPath(current.Path())
+		restore.LoadedMtime = current.LoadedMtime
+	}
+	return restore
+}
+
+// batchLabel names a tiki in batch errors: its id, or its title while a new
+// tiki has no id yet.
+func batchLabel(op batchOp) string {
+	if op.tk.ID() != "" {
+		return op.tk.ID()
+	}
+	return fmt.Sprintf("%q", op.tk.Title())
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/store/tikistore"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func TestApplyBatch_WritesAllAndRunsHooks(t *testing.T) {
+	gate, s := newGateWithStore()
+	_ = s.CreateTiki(newWorkflowTiki("UPD001", "one"))
+	_ = s.CreateTiki(newWorkflowTiki("DEL001", "gone"))
+
+	var hooks []string
+	gate.OnAfterCreate(func(_ context.Context, _, new *tikipkg.Tiki) error {
+		hooks = append(hooks, "create "+new.ID())
+		return nil
+	})
+	gate.OnAfterUpdate(func(_ context.Context, old, new *tikipkg.Tiki) error {
+		hooks = append(hooks, "update "+old.Title()+"->"+new.Title())
+		return nil
+	})
+	gate.OnAfterDelete(func(_ context.Context, old, _ *tikipkg.Tiki) error {
+		hooks = append(hooks, "delete "+old.ID())
+		return nil
+	})
+
+	updated := s.GetTiki("UPD001").Clone()
+	updated.SetTitle("one v2")
+	batch := &MutationBatch{
+		Creates: []*tikipkg.Tiki{newWorkflowTiki("NEW001", "new")},
+		Updates: []*tikipkg.Tiki{updated},
+		Deletes: []*tikipkg.Tiki{s.GetTiki("DEL001")},
+	}
+	if err := gate.ApplyBatch(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.GetTiki("NEW001") == nil || s.GetTiki("DEL001") != nil || s.GetTiki("UPD001").Title() != "one v2" {
+		t.Error("batch not fully applied")
+	}
+	want := "create NEW001|update one->one v2|delete DEL001"
+	if got := strings.Join(hooks, "|"); got != want {
+		t.Errorf("hooks = %q, want %q", got, want)
+	}
+}
+
+func TestApplyBatch_RejectionWritesNothing(t *testing.T) {
+	gate, s := newGateWithStore()
+	_ = s.CreateTiki(newWorkflowTiki("AAA001", "a"))
+	_ = s.CreateTiki(newWorkflowTiki("BBB001", "b"))
+
+	// aggregate rule: at most one tiki may be "ready"; judged on the end state
+	gate.OnUpdate(func(_, _ *tikipkg.Tiki, all []*tikipkg.Tiki) *Rejection {
+		ready := 0
+		for _, tk := range all {
+			if v, _ := tk.Get("status"); v == "ready" {
+				ready++
+			}
+		}
+		if ready > 1 {
+			return &Rejection{Reason: "too many ready"}
+		}
+		return nil
+	})
+
+	var batch MutationBatch
+	for _, id := range []string{"AAA001", "BBB001"} {
+		tk := s.GetTiki(id).Clone()
+		tk.Set("status", "ready")
+		batch.Updates = append(batch.Updates, tk)
+	}
+
+	err := gate.ApplyBatch(context.Background(), &batch)
+	var rejErr *RejectionError
+	if !errors.As(err, &rejErr) || len(rejErr.Rejections) != 2 {
+		t.Fatalf("err = %v, want a rejection per tiki", err)
+	}
+	if !strings.Contains(err.Error(), "AAA001: too many ready") {
+		t.Errorf("rejection not labeled with the tiki id: %v", err)
+	}
+	for _, id := range []string{"AAA001", "BBB001"} {
+		if v, _ := s.GetTiki(id).Get("status"); v != "inbox" {
+			t.Errorf("%s status = %v, want unchanged", id, v)
+		}
+	}
+	if err := gate.ValidateBatch(&batch); err == nil {
+		t.Error("ValidateBatch should report the same rejection")
+	}
+}
+
+func TestApplyBatch_RollsBackOnWriteFailure(t *testing.T) {
+	gate := NewTikiMutationGate()
+	fs := &failingUpdateStore{Store: store.NewInMemoryStore(), failID: "BBB001"}
+	gate.SetStore(fs)
+	_ = fs.CreateTiki(newWorkflowTiki("AAA001", "a"))
+	_ = fs.CreateTiki(newWorkflowTiki("BBB001", "b"))
+	_ = fs.CreateTiki(newWorkflowTiki("DEL001", "gone"))
+
+	hooked := false
+	gate.OnAfterUpdate(func(context.Context, *tikipkg.Tiki, *tikipkg.Tiki) error {
+		hooked = true
+		return nil
+	})
+
+	a := fs.GetTiki("AAA001").Clone()
+	a.SetTitle("a v2")
+	b := fs.GetTiki("BBB001").Clone()
+	b.SetTitle("b v2")
+	batch := &MutationBatch{
+		Creates: []*tikipkg.Tiki{newWorkflowTiki("NEW001", "new")},
+		Updates: []*tikipkg.Tiki{a, b},
+		Deletes: []*tikipkg.Tiki{fs.GetTiki("DEL001")},
+	}
+	err := gate.ApplyBatch(context.Background(), batch)
+	if err == nil || !strings.Contains(err.Error(), "update BBB001") || !strings.Contains(err.Error(), "rolled back 2") {
+		t.Fatalf("err = %v", err)
+	}
+
+	if fs.GetTiki("NEW001") != nil {
+		t.Error("created tiki not rolled back")
+	}
+	if got := fs.GetTiki("AAA001").Title(); got != "a" {
+		t.Errorf("AAA001 title = %q, want rollback to %q", got, "a")
+	}
+	if fs.GetTiki("DEL001") == nil {
+		t.Error("delete ran despite the earlier failure")
+	}
+	if hooked {
+		t.Error("after-hooks ran for a failed batch")
+	}
+}
+
+func TestApplyBatch_RollsBackOnDiskStore(t *testing.T) {
+	ts, err := tikistore.NewTikiStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gate := NewTikiMutationGate()
+	fs := &failingUpdateStore{Store: ts, failID: "BBB001"}
+	gate.SetStore(fs)
+	for _, tk := range []*tikipkg.Tiki{newWorkflowTiki("AAA001", "a"), newWorkflowTiki("BBB001", "b")} {
+		if err := fs.CreateTiki(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := fs.GetTiki("AAA001").Clone()
+	a.SetTitle("a v2")
+	b := fs.GetTiki("BBB001").Clone()
+	b.SetTitle("b v2")
+	err = gate.ApplyBatch(context.Background(), &MutationBatch{Updates: []*tikipkg.Tiki{a, b}})
+	if err == nil || !strings.Contains(err.Error(), "rolled back 1") {
+		t.Fatalf("err = %v, want a complete rollback", err)
+	}
+	if got := fs.GetTiki("AAA001").Title(); got != "a" {
+		t.Errorf("AAA001 title = %q, want rollback to %q", got, "a")
+	}
+	if err := ts.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if got := ts.GetTiki("AAA001").Title(); got != "a" {
+		t.Errorf("AAA001 on disk = %q, want rollback to %q", got, "a")
+	}
+}
+
+func TestApplyBatch_DepthExceeded(t *testing.T) {
+	gate, _ := newGateWithStore()
+	ctx := withTriggerDepth(context.Background(), maxTriggerDepth+1)
+	if err := gate.ApplyBatch(ctx, &MutationBatch{}); err == nil {
+		t.Fatal("expected cascade depth error")
+	}
+}
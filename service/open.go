@@ -0,0 +1,37 @@
+package service
+
+import (
+	"log/slog"
+	"os/exec"
+	"runtime"
+)
+
+// openCommand returns the command that opens path with the platform's
+// default application.
+func openCommand(path string) (string, []string) {
+	switch runtime.GOOS {
+	case "darwin":
+		return "open", []string{path}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", path}
+	default:
+		return "xdg-open", []string{path}
+	}
+}
+
+// OpenExternal opens path with the platform's default application without
+// blocking the caller. Used for attachments the terminal cannot display.
+func OpenExternal(path string) error {
+	name, args := openCommand(path)
+	//nolint:gosec // G204: the opener is fixed per platform; path is a single argument
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			slog.Warn("external open failed", "path", path, "error", err)
+		}
+	}()
+	return nil
+}
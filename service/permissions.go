@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// Permissions evaluates the workflow's permission rules for one user, known
+// by a name and an email.
+type Permissions struct {
+	cfg        config.PermissionConfig
+	identities []string
+	roles      map[string]bool
+}
+
+// NewPermissions returns the permissions of the user called name with the
+// given email; either may be empty.
+func NewPermissions(cfg config.PermissionConfig, name, email string) *Permissions {
+	p := &Permissions{cfg: cfg, identities: []string{name, email}, roles: make(map[string]bool)}
+	for _, role := range cfg.RolesOf(name, email) {
+		p.roles[role] = true
+	}
+	return p
+}
+
+// CurrentPermissions returns the permissions of the store's current user.
+// ok is false when the workflow declares no permissions: section.
+func CurrentPermissions(s store.ReadStore) (*Permissions, bool) {
+	cfg, ok := config.WorkflowPermissions()
+	if !ok {
+		return nil, false
+	}
+	// without an identity the user holds no role and matches no user field
+	name, email, _ := s.GetCurrentUser()
+	return NewPermissions(cfg, name, email), true
+}
+
+// RegisterPermissionValidators registers the permission rules on create,
+// update and delete when the workflow declares a permissions: section. The
+// current user is looked up on every mutation, so the validators can be
+// registered before the gate has a store.
+func RegisterPermissionValidators(g *TikiMutationGate) {
+	if _, ok := config.WorkflowPermissions(); !ok {
+		return
+	}
+	v := func(old, new *tikipkg.Tiki, _ []*tikipkg.Tiki) *Rejection {
+		p, ok := CurrentPermissions(g.ReadStore())
+		if !ok {
+			return nil
+		}
+		return p.Check(old, new)
+	}
+	g.OnCreate(v)
+	g.OnUpdate(v)
+	g.OnDelete(v)
+}
+
+// Check rejects a mutation that breaks one of the rules: old is nil for a
+// create and new is nil for a delete. Every broken rule is named in the
+// reason.
+func (p *Permissions) Check(old, new *tikipkg.Tiki) *Rejection {
+	var reasons []string
+	for _, rule := range p.cfg.Rules {
+		if !ruleApplies(rule, old, new) {
+			continue
+		}
+		// allow fields are read before the change, so nobody can assign a
+		// tiki to themselves and finish it in one edit. A create has no
+		// before, so only the rule's roles allow it.
+		if !p.allowed(rule, old) {
+			reasons = append(reasons, permissionReason(rule))
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	return &Rejection{Reason: strings.Join(reasons, "; ")}
+}
+
+// permissionReason explains a broken rule: "only lead may set priority to
+// high", or "nobody may delete tikis" when no one is allowed.
+func permissionReason(rule config.PermissionRule) string {
+	if len(rule.AllowRoles) == 0 && len(rule.AllowFields) == 0 {
+		return "nobody may " + rule.Describe()
+	}
+	return fmt.Sprintf("only %s may %s", rule.Allowed(), rule.Describe())
+}
+
+// Denied returns the tokens of the rules that keep the user from changing
+// tk (see config.PermissionRule.Token), once each. A delete rule counts only
+// when it covers tk.
+func (p *Permissions) Denied(tk *tikipkg.Tiki) []string {
+	var tokens []string
+	seen := make(map[string]bool)
+	for _, rule := range p.cfg.Rules {
+		if rule.Action == config.PermissionDelete && !ruleApplies(rule, tk, nil) {
+			continue
+		}
+		if token := rule.Token(); !seen[token] && !p.allowed(rule, tk) {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// allowed reports whether the user holds one of the rule's roles or is
+// named in one of its user fields on tk.
+func (p *Permissions) allowed(rule config.PermissionRule, tk *tikipkg.Tiki) bool {
+	for _, role := range rule.AllowRoles {
+		if p.roles[role] {
+			return true
+		}
+	}
+	if tk == nil {
+		return false
+	}
+	for _, field := range rule.AllowFields {
+		who, _, _ := tk.StringField(field)
+		who = strings.TrimSpace(who)
+		if who == "" {
+			continue
+		}
+		for _, id := range p.identities {
+			if strings.EqualFold(who, strings.TrimSpace(id)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ruleApplies reports whether the mutation from old to new is one the rule
+// restricts.
+func ruleApplies(rule config.PermissionRule, old, new *tikipkg.Tiki) bool {
+	if rule.Action == config.PermissionDelete {
+		if new != nil || old == nil {
+			return false
+		}
+		return rule.Field == "" || fieldHolds(old, rule.Field, rule.Value)
+	}
+	if new == nil {
+		return false
+	}
+	if rule.Value != "" {
+		return fieldHolds(new, rule.Field, rule.Value) && (old == nil || !fieldHolds(old, rule.Field, rule.Value))
+	}
+	after := permissionValue(new, rule.Field)
+	if old == nil {
+		return after != ""
+	}
+	return after != permissionValue(old, rule.Field)
+}
+
+// fieldHolds reports whether tk's field is value, or contains it for list
+// fields. Enum values compare case-insensitively.
+func fieldHolds(tk *tikipkg.Tiki, field, value string) bool {
+	raw, present := tk.Get(field)
+	if !present {
+		return false
+	}
+	if items, ok := coerceStringListValue(raw); ok {
+		for _, item := range items {
+			if strings.EqualFold(item, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.EqualFold(formatPermissionValue(raw), value)
+}
+
+// permissionValue formats tk's field for change detection; absent is "".
+func permissionValue(tk *tikipkg.Tiki, field string) string {
+	raw, present := tk.Get(field)
+	if !present || raw == nil {
+		return ""
+	}
+	return formatPermissionValue(raw)
+}
+
+// formatPermissionValue renders a field value the way rules spell it:
+// dates as 2006-01-02, lists comma-separated.
+func formatPermissionValue(raw interface{}) string {
+	if items, ok := coerceStringListValue(raw); ok {
+		return strings.Join(items, ",")
+	}
+	if t, ok := raw.(time.Time); ok {
+		if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 {
+			return t.Format("2006-01-02")
+		}
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(raw)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/internal/teststatuses"
+)
+
+// testPermissions makes alice a lead; the in-memory store's user holds no
+// role.
+func testPermissions() config.PermissionConfig {
+	return config.PermissionConfig{
+		Roles: map[string][]string{"lead": {"alice"}},
+		Rules: []config.PermissionRule{
+			{Action: config.PermissionSet, Field: "priority", Value: "high", AllowRoles: []string{"lead"}},
+			{Action: config.PermissionSet, Field: "status", Value: "done", AllowRoles: []string{"lead"}, AllowFields: []string{"assignee"}},
+			{Action: config.PermissionDelete, Field: "type", Value: "project"},
+		},
+	}
+}
+
+func withPermissions(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { config.ResetWorkflowPermissionsForTest(nil) })
+	teststatuses.Init()
+	cfg := testPermissions()
+	config.ResetWorkflowPermissionsForTest(&cfg)
+}
+
+func TestPermissions_Check(t *testing.T) {
+	lead := NewPermissions(testPermissions(), "Alice", "")
+	dev := NewPermissions(testPermissions(), "bob", "bob@example.com")
+
+	base := newWorkflowTiki("AAA001", "A")
+	high := base.Clone()
+	high.Set("priority", "high")
+	if r := dev.Check(base, high); r == nil || r.Reason != "only lead may set priority to high" {
+		t.Errorf("dev raising priority: %v", r)
+	}
+	if r := lead.Check(base, high); r != nil {
+		t.Errorf("lead raising priority rejected: %v", r.Reason)
+	}
+	if r := dev.Check(nil, high); r == nil {
+		t.Error("dev creating a high-priority tiki should be rejected")
+	}
+	// a tiki that is already high priority can be edited by anyone
+	retitled := high.Clone()
+	retitled.SetTitle("A renamed")
+	if r := dev.Check(high, retitled); r != nil {
+		t.Errorf("unrelated edit rejected: %v", r.Reason)
+	}
+
+	mine := base.Clone()
+	mine.Set("assignee", "BOB@example.com")
+	done := mine.Clone()
+	done.Set("status", "done")
+	if r := dev.Check(mine, done); r != nil {
+		t.Errorf("assignee finishing their tiki rejected: %v", r.Reason)
+	}
+	// the assignee is read before the change
+	stolen := done.Clone()
+	stolen.Set("assignee", "carol")
+	if r := NewPermissions(testPermissions(), "carol", "").Check(mine, stolen); r == nil ||
+		r.Reason != "only lead or assignee may set status to done" {
+		t.Errorf("reassign-and-finish: %v", r)
+	}
+	// a create has no assignee before the change, so only roles allow it
+	if r := dev.Check(nil, done); r == nil || r.Reason != "only lead or assignee may set status to done" {
+		t.Errorf("creating a finished tiki assigned to yourself: %v", r)
+	}
+	if r := lead.Check(nil, done); r != nil {
+		t.Errorf("lead creating a finished tiki rejected: %v", r.Reason)
+	}
+
+	project := base.Clone()
+	project.Set("type", "project")
+	if r := lead.Check(project, nil); r == nil || r.Reason != "nobody may delete tikis with type project" {
+		t.Errorf("deleting a project: %v", r)
+	}
+	if r := dev.Check(base, nil); r != nil {
+		t.Errorf("deleting a story rejected: %v", r.Reason)
+	}
+}
+
+func TestPermissions_Denied(t *testing.T) {
+	dev := NewPermissions(testPermissions(), "bob", "")
+	tk := newWorkflowTiki("AAA001", "A")
+	if got := dev.Denied(tk); !slices.Equal(got, []string{"set:priority=high", "set:status=done"}) {
+		t.Errorf("denied on a story = %v", got)
+	}
+	tk.Set("assignee", "bob")
+	tk.Set("type", "project")
+	if got := dev.Denied(tk); !slices.Equal(got, []string{"set:priority=high", "delete"}) {
+		t.Errorf("denied on bob's project = %v", got)
+	}
+	if got := NewPermissions(testPermissions(), "alice", "").Denied(newWorkflowTiki("AAA002", "B")); len(got) != 0 {
+		t.Errorf("lead denied %v", got)
+	}
+}
+
+func TestRegisterPermissionValidators(t *testing.T) {
+	withPermissions(t)
+	gate, s := newGateWithStore()
+	RegisterPermissionValidators(gate)
+	ctx := context.Background()
+
+	project := newWorkflowTiki("AAA001", "Roadmap")
+	project.Set("type", "project")
+	if err := gate.CreateTiki(ctx, project); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	high := s.GetTiki("AAA001").Clone()
+	high.Set("priority", "high")
+	if err := gate.UpdateTiki(ctx, high); err == nil || !strings.Contains(err.Error(), "only lead may set priority to high") {
+		t.Errorf("update err = %v, want permission rejection", err)
+	}
+	if err := gate.DeleteTiki(ctx, project); err == nil || !strings.Contains(err.Error(), "nobody may delete") {
+		t.Errorf("delete err = %v, want permission rejection", err)
+	}
+	if s.GetTiki("AAA001") == nil {
+		t.Error("rejected delete removed the tiki")
+	}
+}
+
+func TestRegisterPermissionValidators_NoopWithoutConfig(t *testing.T) {
+	teststatuses.Init()
+	config.ResetWorkflowPermissionsForTest(nil)
+	gate, _ := newGateWithStore()
+	RegisterPermissionValidators(gate)
+	tk := newWorkflowTiki("AAA001", "A")
+	tk.Set("priority", "high")
+	if err := gate.CreateTiki(context.Background(), tk); err != nil {
+		t.Fatalf("create without permissions config: %v", err)
+	}
+}
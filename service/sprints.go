@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// SprintClose is the outcome of CloseSprint. Summary is the document that
+// was written; Carried are the unfinished tikis as moved to Next.
+type SprintClose struct {
+	Plan    store.SprintPlan
+	Next    config.Sprint
+	Carried []*tikipkg.Tiki
+	Summary *tikipkg.Tiki
+}
+
+// CloseSprint closes the sprint called name — or, when name is empty, the
+// current sprint or else the one that ended last. Every unfinished tiki
+// committed to it moves to the following sprint and a summary document with
+// the completed points is created, all in one batch so either everything is
+// written or nothing is. Triggers see ordinary updates and a create.
+func CloseSprint(ctx context.Context, gate *TikiMutationGate, cfg config.SprintConfig, name string, now time.Time) (*SprintClose, error) {
+	rs := gate.ReadStore()
+	all := rs.GetAllTikis()
+	sprints := store.Sprints(all, cfg)
+
+	var sprint config.Sprint
+	var ok bool
+	if name == "" {
+		if sprint, ok = sprints.Latest(now); !ok {
+			return nil, fmt.Errorf("no current or past sprint to close")
+		}
+	} else if sprint, ok = sprints.Find(name); !ok {
+		return nil, fmt.Errorf("unknown sprint %q", name)
+	}
+	next, ok := sprints.Next(sprint.Name)
+	if !ok {
+		return nil, fmt.Errorf("sprint %q is the last one; declare the next sprint before closing it", sprint.Name)
+	}
+
+	plan := store.PlanSprints(all, cfg, config.SprintList{sprint})[0]
+	result := &SprintClose{Plan: plan, Next: next}
+	batch := &MutationBatch{}
+	for _, tk := range plan.Open {
+		moved := tk.Clone()
+		moved.Set(cfg.Field, next.Name)
+		batch.Updates = append(batch.Updates, moved)
+		result.Carried = append(result.Carried, moved)
+	}
+
+	summary, err := rs.NewTikiTemplate()
+	if err != nil {
+		return nil, fmt.Errorf("create sprint summary: %w", err)
+	}
+	// the summary is a plain document, not work to be planned, so it keeps
+	// none of the workflow's creation defaults.
+	for _, fd := range workflow.Fields() {
+		if fd.Custom {
+			summary.Delete(fd.Name)
+		}
+	}
+	summary.SetTitle(fmt.Sprintf("Sprint %s summary", sprint.Name))
+	summary.SetBody(SprintSummaryBody(result, now))
+	batch.Creates = append(batch.Creates, summary)
+	result.Summary = summary
+
+	if err := gate.ApplyBatch(ctx, batch); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SprintSummaryBody renders the markdown body of a sprint summary document.
+func SprintSummaryBody(c *SprintClose, now time.Time) string {
+	s := c.Plan.Sprint
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sprint **%s** ran from %s to %s and was closed on %s.\n\n",
+		s.Name, s.Start.Format("2006-01-02"), s.End.Format("2006-01-02"), now.Format("2006-01-02"))
+	fmt.Fprintf(&b, "| | Points |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Capacity | %s |\n", store.FormatPoints(s.Capacity))
+	fmt.Fprintf(&b, "| Committed | %s |\n", store.FormatPoints(c.Plan.Committed))
+	fmt.Fprintf(&b, "| Completed | %s |\n", store.FormatPoints(c.Plan.Completed))
+	fmt.Fprintf(&b, "| Carried over | %s |\n", store.FormatPoints(c.Plan.Committed-c.Plan.Completed))
+
+	fmt.Fprintf(&b, "\n## Completed\n\n")
+	writeSprintTikis(&b, c.Plan.Done, "Nothing was completed.")
+	fmt.Fprintf(&b, "\n## Carried over to %s\n\n", c.Next.Name)
+	writeSprintTikis(&b, c.Carried, "Nothing was left open.")
+	return b.String()
+}
+
+func writeSprintTikis(b *strings.Builder, tikis []*tikipkg.Tiki, empty string) {
+	if len(tikis) == 0 {
+		fmt.Fprintf(b, "%s\n", empty)
+		return
+	}
+	for _, tk := range tikis {
+		fmt.Fprintf(b, "- [[%s]] %s\n", tk.ID(), tk.Title())
+	}
+}
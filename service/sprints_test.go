@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+var testSprints = config.SprintConfig{
+	Field:       "sprint",
+	PointsField: "points",
+	DoneStatus:  config.DoneStatus{StatusField: "status", Done: []string{"done"}},
+	List: []config.Sprint{
+		{Name: "S1", Start: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC), Capacity: 12},
+		{Name: "S2", Start: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC), Capacity: 12},
+	},
+}
+
+func newSprintServiceTiki(id, status, sprint, points string) *tikipkg.Tiki {
+	tk := newTiki(id, "Work "+id, status, "story", 3)
+	tk.Set("sprint", sprint)
+	tk.Set("points", points)
+	return tk
+}
+
+func TestCloseSprint_CarriesOverAndWritesSummary(t *testing.T) {
+	gate, s := newGateWithStoreAndTikis(
+		newSprintServiceTiki("AAA001", "done", "S1", "3"),
+		newSprintServiceTiki("BBB001", "inProgress", "S1", "7"),
+		newSprintServiceTiki("CCC001", "ready", "S2", "1"),
+	)
+	now := time.Date(2026, 1, 16, 17, 0, 0, 0, time.UTC)
+
+	closed, err := CloseSprint(context.Background(), gate, testSprints, "", now)
+	if err != nil {
+		t.Fatalf("CloseSprint: %v", err)
+	}
+	if closed.Plan.Sprint.Name != "S1" || closed.Next.Name != "S2" {
+		t.Fatalf("closed %q into %q, want S1 into S2", closed.Plan.Sprint.Name, closed.Next.Name)
+	}
+	if closed.Plan.Completed != 3 || closed.Plan.Committed != 10 {
+		t.Errorf("completed/committed = %v/%v, want 3/10", closed.Plan.Completed, closed.Plan.Committed)
+	}
+	if sprint, _, _ := s.GetTiki("BBB001").StringField("sprint"); sprint != "S2" {
+		t.Errorf("unfinished tiki sprint = %q, want S2", sprint)
+	}
+	if sprint, _, _ := s.GetTiki("AAA001").StringField("sprint"); sprint != "S1" {
+		t.Errorf("finished tiki sprint = %q, want it left in S1", sprint)
+	}
+
+	summary := s.GetTiki(closed.Summary.ID())
+	if summary == nil {
+		t.Fatal("summary document was not created")
+	}
+	if summary.Title() != "Sprint S1 summary" {
+		t.Errorf("summary title = %q", summary.Title())
+	}
+	if summary.Has("status") {
+		t.Error("summary should be a plain document without workflow defaults")
+	}
+	for _, want := range []string{
+		"| Completed | 3 |",
+		"| Carried over | 7 |",
+		"## Completed\n\n- [[AAA001]] Work AAA001",
+		"## Carried over to S2\n\n- [[BBB001]] Work BBB001",
+	} {
+		if !strings.Contains(summary.Body(), want) {
+			t.Errorf("summary missing %q:\n%s", want, summary.Body())
+		}
+	}
+}
+
+func TestCloseSprint_Rejections(t *testing.T) {
+	gate, _ := newGateWithStoreAndTikis(newSprintServiceTiki("AAA001", "ready", "S2", "3"))
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+	if _, err := CloseSprint(context.Background(), gate, testSprints, "S9", now); err == nil || !strings.Contains(err.Error(), "unknown sprint") {
+		t.Errorf("unknown sprint: err = %v", err)
+	}
+	if _, err := CloseSprint(context.Background(), gate, testSprints, "", now); err == nil || !strings.Contains(err.Error(), "is the last one") {
+		t.Errorf("closing the last sprint: err = %v", err)
+	}
+	if _, err := CloseSprint(context.Background(), gate, testSprints, "", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)); err == nil || !strings.Contains(err.Error(), "no current or past sprint") {
+		t.Errorf("before the first sprint: err = %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// NewTikiFromTemplate returns a creation template from rs with the named
+// workflow template applied on top of the field-catalog defaults. An empty
+// name returns the plain defaults template unchanged.
+func NewTikiFromTemplate(rs store.ReadStore, name string) (*tikipkg.Tiki, error) {
+	tk, err := rs.NewTikiTemplate()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" || tk == nil {
+		return tk, nil
+	}
+	tmpl, ok := config.FindTemplate(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q", name)
+	}
+	ApplyTemplate(tk, tmpl, templateUser(rs), time.Now())
+	return tk, nil
+}
+
+// ApplyTemplate overlays tmpl onto tk: preset fields replace catalog
+// defaults, the body skeleton becomes the body, the folder becomes the
+// creation folder, and the title pattern is expanded with an empty {title}.
+func ApplyTemplate(tk *tikipkg.Tiki, tmpl config.TikiTemplate, user string, now time.Time) {
+	for k, v := range tmpl.Fields {
+		if ss, ok := v.([]string); ok {
+			cp := make([]string, len(ss))
+			copy(cp, ss)
+			v = cp
+		}
+		tk.Set(k, v)
+	}
+	if tmpl.Body != "" {
+		tk.SetBody(tmpl.Body)
+	}
+	if tmpl.Folder != "" {
+		tk.SetFolder(tmpl.Folder)
+	}
+	if tmpl.Title != "" {
+		tk.SetTitle(ExpandTemplateTitle(tmpl.Title, "", tk.ID(), user, now))
+	}
+}
+
+// ApplyTemplateTitle runs a caller-supplied title through tmpl's title
+// pattern once the final title is known (after a ruki create or from piped
+// input). Patterns without {title} leave the title alone, as does a title
+// that is still the untouched pre-filled pattern.
+func ApplyTemplateTitle(tk *tikipkg.Tiki, tmpl config.TikiTemplate, user string, now time.Time) {
+	if !strings.Contains(tmpl.Title, "{title}") {
+		return
+	}
+	title := tk.Title()
+	if title == ExpandTemplateTitle(tmpl.Title, "", tk.ID(), user, now) {
+		return
+	}
+	tk.SetTitle(ExpandTemplateTitle(tmpl.Title, title, tk.ID(), user, now))
+}
+
+// ExpandTemplateTitle substitutes the {title}, {date}, {user} and {id}
+// placeholders in a template title pattern.
+func ExpandTemplateTitle(pattern, title, id, user string, now time.Time) string {
+	return strings.NewReplacer(
+		"{title}", title,
+		"{date}", now.Format("2006-01-02"),
+		"{user}", user,
+		"{id}", id,
+	).Replace(pattern)
+}
+
+// FinishTemplateCreate applies the named template's title pattern to a tiki
+// produced by a `create from "<template>"` statement. No-op for "".
+func FinishTemplateCreate(rs store.ReadStore, tk *tikipkg.Tiki, name string) {
+	if name == "" || tk == nil {
+		return
+	}
+	if tmpl, ok := config.FindTemplate(name); ok {
+		ApplyTemplateTitle(tk, tmpl, templateUser(rs), time.Now())
+	}
+}
+
+// templateUser resolves the {user} placeholder value; "" when no identity resolves.
+func templateUser(rs store.ReadStore) string {
+	user, err := store.CurrentUserDisplay(rs)
+	if err != nil {
+		return ""
+	}
+	return user
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func withTestTemplates(t *testing.T, templates ...config.TikiTemplate) {
+	t.Helper()
+	config.ResetWorkflowTemplatesForTest(templates)
+	t.Cleanup(func() { config.ResetWorkflowTemplatesForTest(nil) })
+}
+
+func TestExpandTemplateTitle(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	got := ExpandTemplateTitle("[{date}] {user}: {title} ({id})", "Crash", "ABC123", "alice", now)
+	want := "[2026-03-14] alice: Crash (ABC123)"
+	if got != want {
+		t.Errorf("ExpandTemplateTitle = %q, want %q", got, want)
+	}
+}
+
+func TestApplyTemplate(t *testing.T) {
+	tk := tikipkg.New()
+	tk.SetID("ABC123")
+	tk.Set("type", "story")
+	tk.Set("priority", "medium")
+	tags := []string{"triage"}
+	tmpl := config.TikiTemplate{
+		Name:   "bug",
+		Title:  "Bug: {title}",
+		Folder: "bugs",
+		Fields: map[string]interface{}{"type": "bug", "tags": tags},
+		Body:   "## Expected\n\n## Actual",
+	}
+
+	ApplyTemplate(tk, tmpl, "alice", time.Now())
+
+	if v, _ := tk.Get("type"); v != "bug" {
+		t.Errorf("type = %v, want preset bug", v)
+	}
+	if v, _ := tk.Get("priority"); v != "medium" {
+		t.Errorf("priority = %v, want untouched default", v)
+	}
+	if tk.Body() != tmpl.Body || tk.Folder() != "bugs" {
+		t.Errorf("body/folder not applied: %q / %q", tk.Body(), tk.Folder())
+	}
+	if tk.Title() != "Bug: " {
+		t.Errorf("title = %q, want pattern with empty {title}", tk.Title())
+	}
+	tags[0] = "mutated"
+	if v, _ := tk.Get("tags"); v.([]string)[0] != "triage" {
+		t.Error("list preset must be copied, not aliased")
+	}
+}
+
+func TestApplyTemplateTitle(t *testing.T) {
+	now := time.Now()
+	tmpl := config.TikiTemplate{Name: "bug", Title: "Bug: {title}"}
+
+	tk := tikipkg.New()
+	tk.SetID("ABC123")
+	tk.SetTitle("Crash on login")
+	ApplyTemplateTitle(tk, tmpl, "", now)
+	if tk.Title() != "Bug: Crash on login" {
+		t.Errorf("title = %q, want wrapped", tk.Title())
+	}
+
+	// untouched pre-filled title is not wrapped a second time
+	prefilled := tikipkg.New()
+	prefilled.SetID("ABC124")
+	ApplyTemplate(prefilled, tmpl, "", now)
+	ApplyTemplateTitle(prefilled, tmpl, "", now)
+	if prefilled.Title() != "Bug: " {
+		t.Errorf("title = %q, want untouched pre-fill", prefilled.Title())
+	}
+
+	// fixed patterns leave an explicit title alone
+	fixed := tikipkg.New()
+	fixed.SetTitle("Explicit")
+	ApplyTemplateTitle(fixed, config.TikiTemplate{Title: "Weekly sync"}, "", now)
+	if fixed.Title() != "Explicit" {
+		t.Errorf("title = %q, want explicit title kept", fixed.Title())
+	}
+}
+
+func TestNewTikiFromTemplate(t *testing.T) {
+	withTestTemplates(t, config.TikiTemplate{
+		Name:   "bug",
+		Title:  "Bug: {title}",
+		Fields: map[string]interface{}{"type": "bug"},
+		Body:   "## Steps to reproduce",
+	})
+	s := store.NewInMemoryStore()
+
+	plain, err := NewTikiFromTemplate(s, "")
+	if err != nil {
+		t.Fatalf("plain template: %v", err)
+	}
+	if plain.Body() != "" {
+		t.Errorf("plain template should carry no body, got %q", plain.Body())
+	}
+
+	tk, err := NewTikiFromTemplate(s, "BUG")
+	if err != nil {
+		t.Fatalf("named template: %v", err)
+	}
+	if v, _ := tk.Get("type"); v != "bug" {
+		t.Errorf("type = %v, want bug", v)
+	}
+	if tk.ID() == "" || tk.Body() != "## Steps to reproduce" {
+		t.Errorf("unexpected tiki: id=%q body=%q", tk.ID(), tk.Body())
+	}
+
+	if _, err := NewTikiFromTemplate(s, "missing"); err == nil || !strings.Contains(err.Error(), "unknown template") {
+		t.Fatalf("expected unknown template error, got %v", err)
+	}
+}
+
+func TestFinishTemplateCreate(t *testing.T) {
+	withTestTemplates(t, config.TikiTemplate{Name: "bug", Title: "Bug: {title}"})
+	s := store.NewInMemoryStore()
+
+	tk := tikipkg.New()
+	tk.SetTitle("Crash")
+	FinishTemplateCreate(s, tk, "bug")
+	if tk.Title() != "Bug: Crash" {
+		t.Errorf("title = %q, want %q", tk.Title(), "Bug: Crash")
+	}
+
+	other := tikipkg.New()
+	other.SetTitle("Crash")
+	FinishTemplateCreate(s, other, "")
+	if other.Title() != "Crash" {
+		t.Errorf("title = %q, want untouched without template", other.Title())
+	}
+}
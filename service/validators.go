@@ -8,6 +8,7 @@ import (
 	"github.com/boolean-maybe/ruki/idfmt"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
 	"github.com/boolean-maybe/tiki/workflow"
+	"github.com/boolean-maybe/tiki/workflow/value"
 )
 
 // RegisterFieldValidators registers standard field validators with the gate.
@@ -61,16 +62,35 @@ func validateTikiTitle(tk *tikipkg.Tiki) string {
 // validateTikiWorkflowFields walks every workflow-declared field and rejects
 // values that don't match the declared type. Absent fields pass (presence-
 // aware contract); fields not declared in workflow.yaml are not checked here
-// — they round-trip as unknown.
+// — they round-trip as unknown. Computed fields and the derived workspace,
+// currentSprint and checklist fields are never stored, so any value for one
+// is rejected.
 func validateTikiWorkflowFields(tk *tikipkg.Tiki) string {
 	if tk == nil {
 		return ""
 	}
+	// with workspaces mounted, the workspace follows from the file location
+	if fd, ok := workflow.Field(workflow.FieldWorkspace); ok && fd.Derived && tk.Has(fd.Name) {
+		return fmt.Sprintf("%s is derived from where the tiki lives and cannot be set", fd.Name)
+	}
+	if tk.Has(workflow.FieldCurrentSprint) {
+		if _, ok := workflow.Field(workflow.FieldCurrentSprint); ok {
+			return fmt.Sprintf("%s follows from the sprint dates and cannot be set", workflow.FieldCurrentSprint)
+		}
+	}
+	for _, name := range workflow.ChecklistFieldNames() {
+		if tk.Has(name) {
+			return fmt.Sprintf("%s is counted from the checklist in the body and cannot be set", name)
+		}
+	}
 	for _, fd := range workflow.WorkflowFields() {
 		raw, present := tk.Get(fd.Name)
 		if !present {
 			continue
 		}
+		if fd.IsComputed() {
+			return fmt.Sprintf("%s is computed and cannot be set", fd.Name)
+		}
 		if msg := validateWorkflowFieldValue(fd, raw); msg != "" {
 			return msg
 		}
@@ -126,6 +146,31 @@ func validateWorkflowFieldValue(fd workflow.FieldDef, raw interface{}) string {
 			}
 		}
 
+	case workflow.TypeEnumList:
+		ss, ok := coerceStringListValue(raw)
+		if !ok {
+			return fmt.Sprintf("%s field has wrong type (expected list of strings)", fd.Name)
+		}
+		for _, s := range ss {
+			if !fd.IsValidEnum(s) {
+				return fmt.Sprintf("invalid %s value: %s (allowed: %s)", fd.Name, s, strings.Join(fd.AllowedValues(), ", "))
+			}
+		}
+
+	case workflow.TypeURL:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Sprintf("%s field has wrong type (expected URL string)", fd.Name)
+		}
+		if err := value.ValidateURL(s); err != nil {
+			return fmt.Sprintf("invalid %s: %v", fd.Name, err)
+		}
+
+	case workflow.TypeNumber:
+		if _, ok := value.NumberOf(raw); !ok {
+			return fmt.Sprintf("%s field has wrong type (expected number, got %v)", fd.Name, raw)
+		}
+
 	case workflow.TypeDate, workflow.TypeTimestamp:
 		if _, ok := raw.(time.Time); !ok {
 			return fmt.Sprintf("%s field has wrong type (expected date)", fd.Name)
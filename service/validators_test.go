@@ -3,7 +3,9 @@ package service
 import (
 	"testing"
 
+	"github.com/boolean-maybe/tiki/internal/teststatuses"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
 )
 
 func TestWrapTikiFieldValidator_DeleteCase(t *testing.T) {
@@ -33,3 +35,55 @@ func TestWrapTikiFieldValidator_DeleteCase(t *testing.T) {
 		t.Errorf("expected 'title required', got %q", rejection.Reason)
 	}
 }
+
+func TestValidateTikiWorkflowFields_RejectsComputed(t *testing.T) {
+	t.Cleanup(teststatuses.Init)
+	if err := teststatuses.InitWith([]workflow.FieldDef{
+		{Name: "effort", Type: workflow.TypeInt},
+		{Name: "score", Type: workflow.TypeInt, Computed: "effort + 1"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tk := tikipkg.New()
+	tk.SetID("CMP001")
+	tk.Set("effort", 3)
+	if msg := validateTikiWorkflowFields(tk); msg != "" {
+		t.Fatalf("unexpected rejection: %s", msg)
+	}
+	tk.Set("score", 4)
+	if msg := validateTikiWorkflowFields(tk); msg != "score is computed and cannot be set" {
+		t.Errorf("msg = %q", msg)
+	}
+}
+
+func TestValidateTikiWorkflowFields_RejectsChecklistFields(t *testing.T) {
+	teststatuses.Init()
+	tk := tikipkg.New()
+	tk.SetID("ABC123")
+	tk.SetBody("- [x] done\n")
+	if msg := validateTikiWorkflowFields(tk); msg != "" {
+		t.Fatalf("unexpected rejection: %s", msg)
+	}
+	tk.Set("checklistDone", 3)
+	if msg := validateTikiWorkflowFields(tk); msg != "checklistDone is counted from the checklist in the body and cannot be set" {
+		t.Errorf("msg = %q", msg)
+	}
+}
+
+func TestValidateTikiWorkflowFields_RejectsCurrentSprint(t *testing.T) {
+	teststatuses.Init()
+	tk := tikipkg.New()
+	tk.SetID("ABC123")
+	tk.Set("currentSprint", "S1")
+	if msg := validateTikiWorkflowFields(tk); msg != "" {
+		t.Fatalf("without sprints currentSprint is an unknown field, got rejection: %s", msg)
+	}
+	if err := workflow.EnableSprintField(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(workflow.DisableSprintField)
+	if msg := validateTikiWorkflowFields(tk); msg != "currentSprint follows from the sprint dates and cannot be set" {
+		t.Errorf("msg = %q", msg)
+	}
+}
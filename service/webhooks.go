@@ -0,0 +1,665 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+// webhook delivery headers
+const (
+	HeaderWebhookEvent     = "X-Tiki-Event"
+	HeaderWebhookDelivery  = "X-Tiki-Delivery"
+	HeaderWebhookSignature = "X-Tiki-Signature"
+)
+
+// webhookFlushInterval is how often the background worker retries queued
+// deliveries that failed on a previous attempt (e.g. while offline).
+const webhookFlushInterval = 30 * time.Second
+
+// CLIWebhookFlushTimeout bounds how long one-shot commands (exec, piped
+// create) wait for webhook delivery before exiting.
+const CLIWebhookFlushTimeout = 30 * time.Second
+
+// defaultWebhookBackoff is the delay before the first retry; each further
+// retry doubles it.
+const defaultWebhookBackoff = 500 * time.Millisecond
+
+// Queued deliveries are dropped once they are older than webhookExpiry or
+// have failed webhookMaxAttempts times, so an endpoint that is gone for good
+// does not keep the queue growing and retrying forever.
+const (
+	webhookExpiry      = 7 * 24 * time.Hour
+	webhookMaxAttempts = 100
+)
+
+// WebhookActor identifies who made the change, as resolved by GetCurrentUser.
+type WebhookActor struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// WebhookPayload is the JSON body POSTed to a hook URL. Old is nil for
+// create events and New is nil for delete events.
+type WebhookPayload struct {
+	Event     string                 `json:"event"`
+	Hook      string                 `json:"hook,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Actor     WebhookActor           `json:"actor"`
+	ID        string                 `json:"id"`
+	Old       map[string]interface{} `json:"old"`
+	New       map[string]interface{} `json:"new"`
+	Changed   []string               `json:"changed"`
+}
+
+// queuedDelivery is the on-disk form of a pending webhook request. URL and
+// Headers keep their ${VAR} references unexpanded and are expanded when the
+// request is sent, and the signature is computed at enqueue time, so neither
+// the secret nor tokens from the environment touch the queue directory.
+type queuedDelivery struct {
+	ID       string            `json:"id"`
+	Hook     string            `json:"hook"`
+	URL      string            `json:"url"`
+	Headers  map[string]string `json:"headers"`
+	Body     json.RawMessage   `json:"body"`
+	Retries  int               `json:"retries"`
+	Timeout  time.Duration     `json:"timeout"`
+	Attempts int               `json:"attempts"`
+	Created  time.Time         `json:"created"`
+}
+
+// webhookEntry pairs a hook definition with its parsed filter.
+type webhookEntry struct {
+	label  string
+	def    config.HookDef
+	filter *ruki.ValidatedStatement
+}
+
+// WebhookDispatcher turns mutation-gate after-hooks into outbound HTTP
+// notifications. Every matching event is first written to an on-disk queue
+// and then delivered by Flush, so deliveries that fail (network down,
+// endpoint unavailable) survive restarts and are retried later.
+type WebhookDispatcher struct {
+	hooks    []webhookEntry
+	executor *ruki.Executor
+	gate     *TikiMutationGate
+	queueDir string
+	client   *http.Client
+	backoff  time.Duration
+	actor    func() WebhookActor
+	now      func() time.Time
+
+	flushMu sync.Mutex
+	wake    chan struct{}
+
+	// queued holds the queue files written by this process, so FlushPending
+	// can deliver them without also retrying other sessions' backlog.
+	queuedMu sync.Mutex
+	queued   []string
+}
+
+// NewWebhookDispatcher creates a dispatcher for the given hooks. Filters are
+// parsed against schema and must be SELECT statements without interactive
+// builtins or selection qualifiers — the same rules as lane filters.
+func NewWebhookDispatcher(defs []config.HookDef, schema ruki.Schema, queueDir string) (*WebhookDispatcher, error) {
+	parser := ruki.NewParser(schema)
+	entries := make([]webhookEntry, 0, len(defs))
+	for i, def := range defs {
+		label := config.HookLabel(def, i)
+		if err := def.Validate(); err != nil {
+			return nil, fmt.Errorf("hook %q: %w", label, err)
+		}
+		entry := webhookEntry{label: label, def: def}
+		if strings.TrimSpace(def.Filter) != "" {
+			stmt, err := ParseHookFilter(parser, def.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("hook %q: %w", label, err)
+			}
+			entry.filter = stmt
+		}
+		entries = append(entries, entry)
+	}
+	factory := ruki.DocumentFactory(tikipkg.NewDoc)
+	return &WebhookDispatcher{
+		hooks:    entries,
+		executor: ruki.NewExecutor(schema, factory, nil, ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimePlugin}),
+		queueDir: queueDir,
+		client:   &http.Client{},
+		backoff:  defaultWebhookBackoff,
+		actor:    func() WebhookActor { return WebhookActor{} },
+		now:      time.Now,
+		wake:     make(chan struct{}, 1),
+	}, nil
+}
+
+// ParseHookFilter parses and validates a hook filter statement.
+func ParseHookFilter(parser *ruki.Parser, filter string) (*ruki.ValidatedStatement, error) {
+	stmt, err := parser.ParseAndValidateStatement(filter, ruki.ExecutorRuntimePlugin)
+	if err != nil {
+		return nil, fmt.Errorf("parsing filter: %w", err)
+	}
+	if !stmt.IsSelect() {
+		return nil, fmt.Errorf("filter must be a SELECT statement")
+	}
+	if stmt.HasAnyInteractive() {
+		return nil, fmt.Errorf("filter cannot use interactive builtins (input/choose)")
+	}
+	if stmt.UsesTargetQualifier() || stmt.UsesTargetsQualifier() {
+		return nil, fmt.Errorf("filter cannot use target./targets. — hooks have no selection context")
+	}
+	return stmt, nil
+}
+
+// SetActorFunc sets the resolver used to attribute events to a user.
+func (d *WebhookDispatcher) SetActorFunc(fn func() WebhookActor) {
+	d.actor = fn
+}
+
+// SetHTTPClient replaces the HTTP client used for delivery.
+func (d *WebhookDispatcher) SetHTTPClient(c *http.Client) {
+	d.client = c
+}
+
+// SetRetryBackoff sets the delay before the first retry of a delivery.
+func (d *WebhookDispatcher) SetRetryBackoff(b time.Duration) {
+	d.backoff = b
+}
+
+// HookCount returns the number of registered hooks.
+func (d *WebhookDispatcher) HookCount() int {
+	return len(d.hooks)
+}
+
+// RegisterWithGate wires the dispatcher into the gate's after-hooks.
+func (d *WebhookDispatcher) RegisterWithGate(gate *TikiMutationGate) {
+	d.gate = gate
+	if len(d.hooks) == 0 {
+		return
+	}
+	gate.OnAfterCreate(d.makeAfterHook("create"))
+	gate.OnAfterUpdate(d.makeAfterHook("update"))
+	gate.OnAfterDelete(d.makeAfterHook("delete"))
+}
+
+func (d *WebhookDispatcher) makeAfterHook(event string) AfterHook {
+	return func(_ context.Context, old, new *tikipkg.Tiki) error {
+		return d.dispatch(event, old, new)
+	}
+}
+
+// dispatch queues a delivery for every hook subscribed to the event whose
+// filter matches the mutated tiki, then wakes the background worker.
+func (d *WebhookDispatcher) dispatch(event string, old, new *tikipkg.Tiki) error {
+	subject := new
+	if subject == nil {
+		subject = old
+	}
+	if subject == nil {
+		return nil
+	}
+
+	var errs []error
+	queued := 0
+	for _, h := range d.hooks {
+		if !h.def.Subscribes(event) {
+			continue
+		}
+		match, err := d.matches(h, subject, new == nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("hook %q filter: %w", h.label, err))
+			continue
+		}
+		if !match {
+			continue
+		}
+		payload := WebhookPayload{
+			Event:     event,
+			Hook:      h.def.Description,
+			Timestamp: d.now().UTC(),
+			Actor:     d.actor(),
+			ID:        subject.ID(),
+			Old:       TikiSnapshot(old),
+			New:       TikiSnapshot(new),
+			Changed:   ChangedFields(old, new),
+		}
+		if err := d.enqueue(h, payload); err != nil {
+			errs = append(errs, fmt.Errorf("hook %q: %w", h.label, err))
+			continue
+		}
+		queued++
+	}
+	if queued > 0 {
+		d.signal()
+	}
+	return errors.Join(errs...)
+}
+
+// matches evaluates the hook filter against the full tiki universe so
+// aggregate subqueries behave as they do in lane filters. Deleted tikis are
+// no longer in the store, so they are appended for evaluation.
+func (d *WebhookDispatcher) matches(h webhookEntry, subject *tikipkg.Tiki, deleted bool) (bool, error) {
+	if h.filter == nil {
+		return true, nil
+	}
+	var universe []*tikipkg.Tiki
+	if d.gate != nil && d.gate.store != nil {
+		universe = d.gate.ReadStore().GetAllTikis()
+	}
+	if deleted {
+		universe = append(universe, subject)
+	}
+	result, err := d.executor.Execute(h.filter, tikipkg.WrapDocs(universe))
+	if err != nil {
+		return false, err
+	}
+	if result.Select == nil {
+		return false, nil
+	}
+	for _, doc := range result.Select.Tikis {
+		if doc.ID() == subject.ID() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (d *WebhookDispatcher) enqueue(h webhookEntry, payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+	id, err := gonanoid.New()
+	if err != nil {
+		return fmt.Errorf("generate delivery id: %w", err)
+	}
+	headers := map[string]string{
+		"Content-Type":        "application/json",
+		"User-Agent":          "tiki/" + config.Version,
+		HeaderWebhookEvent:    payload.Event,
+		HeaderWebhookDelivery: id,
+	}
+	for k, v := range h.def.Headers {
+		headers[k] = v
+	}
+	if secret := h.def.ResolvedSecret(); secret != "" {
+		headers[HeaderWebhookSignature] = SignWebhookPayload(secret, body)
+	}
+	qd := queuedDelivery{
+		ID:      id,
+		Hook:    h.label,
+		URL:     h.def.URL,
+		Headers: headers,
+		Body:    body,
+		Retries: h.def.RetryCount(),
+		Timeout: h.def.TimeoutDuration(),
+		Created: d.now().UTC(),
+	}
+	if err := d.writeQueued(qd); err != nil {
+		return err
+	}
+	d.queuedMu.Lock()
+	d.queued = append(d.queued, d.queuePath(qd))
+	d.queuedMu.Unlock()
+	return nil
+}
+
+// SignWebhookPayload returns the X-Tiki-Signature value for body:
+// "sha256=" followed by the hex HMAC-SHA256 of body keyed by secret.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeQueued persists a delivery atomically (temp file + rename). File
+// names sort by creation time so Flush delivers in event order.
+func (d *WebhookDispatcher) writeQueued(qd queuedDelivery) error {
+	if err := os.MkdirAll(d.queueDir, 0o750); err != nil {
+		return fmt.Errorf("create queue dir: %w", err)
+	}
+	data, err := json.Marshal(qd)
+	if err != nil {
+		return fmt.Errorf("encode queued delivery: %w", err)
+	}
+	final := d.queuePath(qd)
+	tmp := final + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write queued delivery: %w", err)
+	}
+	if err := os.Rename(tmp, final); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("commit queued delivery: %w", err)
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) queuePath(qd queuedDelivery) string {
+	name := fmt.Sprintf("%020d-%s.json", qd.Created.UnixNano(), qd.ID)
+	return filepath.Join(d.queueDir, name)
+}
+
+// Pending returns the number of deliveries waiting in the queue.
+func (d *WebhookDispatcher) Pending() int {
+	files, _ := d.queuedFiles()
+	return len(files)
+}
+
+func (d *WebhookDispatcher) queuedFiles() ([]string, error) {
+	entries, err := os.ReadDir(d.queueDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		files = append(files, filepath.Join(d.queueDir, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Flush attempts every queued delivery in order, retrying each up to its
+// configured retry count with exponential backoff. Delivered entries are
+// removed; failed ones stay queued for the next Flush.
+func (d *WebhookDispatcher) Flush(ctx context.Context) error {
+	d.flushMu.Lock()
+	defer d.flushMu.Unlock()
+
+	files, err := d.queuedFiles()
+	if err != nil {
+		return fmt.Errorf("read webhook queue: %w", err)
+	}
+	var errs []error
+	for _, path := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := d.deliverFile(ctx, path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FlushPending is the short-lived-process variant of Flush used by CLI
+// commands before exit: it delivers only the entries this dispatcher queued,
+// does nothing when the command queued none (reads, dry runs, writes no hook
+// matched), and bounds the total delivery time. Backlog left by earlier
+// sessions is not retried here — the TUI worker retries it — so a down
+// endpoint does not stall every CLI call. Anything still failing stays
+// queued for the next tiki session.
+func (d *WebhookDispatcher) FlushPending(timeout time.Duration) error {
+	d.queuedMu.Lock()
+	files := d.queued
+	d.queued = nil
+	d.queuedMu.Unlock()
+	if len(files) == 0 {
+		return nil
+	}
+
+	d.flushMu.Lock()
+	defer d.flushMu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var errs []error
+	for _, path := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			// already delivered by a concurrent Flush
+			continue
+		}
+		if err := d.deliverFile(ctx, path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *WebhookDispatcher) deliverFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read queued delivery: %w", err)
+	}
+	var qd queuedDelivery
+	if err := json.Unmarshal(data, &qd); err != nil {
+		// a corrupt entry can never succeed — drop it rather than block the queue
+		slog.Error("dropping unreadable webhook delivery", "file", path, "error", err)
+		_ = os.Remove(path)
+		return nil
+	}
+	if age := d.now().Sub(qd.Created); age > webhookExpiry || qd.Attempts >= webhookMaxAttempts {
+		slog.Warn("dropping expired webhook delivery", "hook", qd.Hook, "delivery", qd.ID,
+			"age", age.Round(time.Minute), "attempts", qd.Attempts)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove expired webhook: %w", err)
+		}
+		return nil
+	}
+
+	var lastErr error
+	delay := d.backoff
+	for attempt := 0; attempt <= qd.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		qd.Attempts++
+		lastErr = d.send(ctx, qd)
+		if lastErr == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("remove delivered webhook: %w", err)
+			}
+			slog.Debug("webhook delivered", "hook", qd.Hook, "delivery", qd.ID, "attempts", qd.Attempts)
+			return nil
+		}
+	}
+
+	if err := d.writeQueued(qd); err != nil {
+		slog.Error("failed to update webhook attempt count", "delivery", qd.ID, "error", err)
+	}
+	return fmt.Errorf("hook %q delivery %s failed after %d attempts: %w", qd.Hook, qd.ID, qd.Attempts, lastErr)
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, qd queuedDelivery) error {
+	timeout := qd.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultHookTimeout
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, os.ExpandEnv(qd.URL), bytes.NewReader(qd.Body))
+	if err != nil {
+		return err
+	}
+	for k, v := range qd.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+	resp, err := d.client.Do(req) //nolint:gosec // G107: URL comes from the user's own workflow.yaml
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start launches the background delivery worker. It flushes immediately
+// (delivering anything queued by a previous session), whenever a new event
+// is queued, and periodically to retry failures. Returns immediately when
+// no hooks are configured.
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	if len(d.hooks) == 0 && d.Pending() == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(webhookFlushInterval)
+		defer ticker.Stop()
+		d.flushLogged(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-d.wake:
+			case <-ticker.C:
+			}
+			d.flushLogged(ctx)
+		}
+	}()
+}
+
+func (d *WebhookDispatcher) flushLogged(ctx context.Context) {
+	if err := d.Flush(ctx); err != nil && ctx.Err() == nil {
+		slog.Warn("webhook delivery failed; will retry", "error", err)
+	}
+}
+
+// TikiSnapshot returns the JSON-friendly representation of a tiki used in
+// webhook payloads: identity fields plus every frontmatter field. Returns
+// nil for a nil tiki.
+func TikiSnapshot(tk *tikipkg.Tiki) map[string]interface{} {
+	if tk == nil {
+		return nil
+	}
+	snap := make(map[string]interface{}, len(tk.Fields)+6)
+	for k, v := range tk.Fields {
+		snap[k] = snapshotValue(v)
+	}
+	snap["id"] = tk.ID()
+	snap["title"] = tk.Title()
+	snap["description"] = tk.Body()
+	if p := tk.Path(); p != "" {
+		snap["filepath"] = p
+	}
+	if !tk.CreatedAt().IsZero() {
+		snap["createdAt"] = tk.CreatedAt().UTC().Format(time.RFC3339)
+	}
+	if !tk.UpdatedAt().IsZero() {
+		snap["updatedAt"] = tk.UpdatedAt().UTC().Format(time.RFC3339)
+	}
+	return snap
+}
+
+func snapshotValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	case fmt.Stringer:
+		return val.String()
+	default:
+		return v
+	}
+}
+
+// ChangedFields lists the field names whose values differ between old and
+// new, sorted. Timestamps maintained by the gate (updatedAt) are ignored.
+// For create and delete every present field counts as changed.
+func ChangedFields(old, new *tikipkg.Tiki) []string {
+	a, b := TikiSnapshot(old), TikiSnapshot(new)
+	seen := make(map[string]struct{}, len(a)+len(b))
+	var changed []string
+	check := func(k string) {
+		if _, ok := seen[k]; ok || k == "updatedAt" {
+			return
+		}
+		seen[k] = struct{}{}
+		av, aok := a[k]
+		bv, bok := b[k]
+		if aok != bok || !reflect.DeepEqual(av, bv) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range a {
+		check(k)
+	}
+	for k := range b {
+		check(k)
+	}
+	sort.Strings(changed)
+	if changed == nil {
+		changed = []string{}
+	}
+	return changed
+}
+
+// StoreWebhookActor returns an actor resolver backed by the store's current
+// identity. Resolution errors yield an empty actor rather than failing the
+// delivery.
+func StoreWebhookActor(rs store.ReadStore) func() WebhookActor {
+	return func() WebhookActor {
+		name, email, err := rs.GetCurrentUser()
+		if err != nil {
+			return WebhookActor{}
+		}
+		return WebhookActor{Name: name, Email: email}
+	}
+}
+
+// LoadAndRegisterHooks loads hook definitions from workflow.yaml, builds a
+// dispatcher queueing into queueDir, and registers it with the gate. The
+// dispatcher is always non-nil so callers can Start or Flush it without a
+// nil check. Fails fast on invalid hooks — a bad hook blocks startup.
+func LoadAndRegisterHooks(gate *TikiMutationGate, schema ruki.Schema, queueDir string, actor func() WebhookActor) (*WebhookDispatcher, error) {
+	defs, err := config.LoadHookDefs()
+	if err != nil {
+		return emptyDispatcher(schema, queueDir), fmt.Errorf("loading hook definitions: %w", err)
+	}
+	d, err := NewWebhookDispatcher(defs, schema, queueDir)
+	if err != nil {
+		return emptyDispatcher(schema, queueDir), err
+	}
+	if actor != nil {
+		d.SetActorFunc(actor)
+	}
+	d.RegisterWithGate(gate)
+	if len(defs) > 0 {
+		slog.Info("webhooks loaded", "count", len(defs))
+	}
+	return d, nil
+}
+
+func emptyDispatcher(schema ruki.Schema, queueDir string) *WebhookDispatcher {
+	d, _ := NewWebhookDispatcher(nil, schema, queueDir)
+	return d
+}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+)
+
+// webhookRecorder is a local HTTP stand-in that records every request body
+// and replies with the configured status.
+type webhookRecorder struct {
+	mu       sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	status   atomic.Int32
+	requests atomic.Int32
+}
+
+func newWebhookRecorder(t *testing.T) (*webhookRecorder, *httptest.Server) {
+	t.Helper()
+	rec := &webhookRecorder{}
+	rec.status.Store(http.StatusOK)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.requests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.bodies = append(rec.bodies, body)
+		rec.headers = append(rec.headers, r.Header.Clone())
+		rec.mu.Unlock()
+		w.WriteHeader(int(rec.status.Load()))
+	}))
+	t.Cleanup(srv.Close)
+	return rec, srv
+}
+
+func (r *webhookRecorder) payloads(t *testing.T) []WebhookPayload {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]WebhookPayload, 0, len(r.bodies))
+	for _, b := range r.bodies {
+		var p WebhookPayload
+		if err := json.Unmarshal(b, &p); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func newTestDispatcher(t *testing.T, defs []config.HookDef) *WebhookDispatcher {
+	t.Helper()
+	d, err := NewWebhookDispatcher(defs, testTriggerSchema{}, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWebhookDispatcher: %v", err)
+	}
+	d.SetRetryBackoff(time.Millisecond)
+	d.SetActorFunc(func() WebhookActor { return WebhookActor{Name: "Ada", Email: "ada@example.com"} })
+	return d
+}
+
+func TestWebhook_UpdateDeliversPayload(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	tk := newTiki("HOOK01", "Fix login", "ready", "story", 3)
+	gate, _ := newGateWithStoreAndTikis(tk)
+	d := newTestDispatcher(t, []config.HookDef{{Description: "team", URL: srv.URL, Events: []string{"update"}}})
+	d.RegisterWithGate(gate)
+
+	updated := gate.ReadStore().GetTiki("HOOK01").Clone()
+	updated.Set("status", "done")
+	if err := gate.UpdateTiki(context.Background(), updated); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if d.Pending() != 1 {
+		t.Fatalf("expected 1 queued delivery, got %d", d.Pending())
+	}
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if d.Pending() != 0 {
+		t.Fatalf("expected empty queue after delivery, got %d", d.Pending())
+	}
+
+	got := rec.payloads(t)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(got))
+	}
+	p := got[0]
+	if p.Event != "update" || p.ID != "HOOK01" || p.Hook != "team" {
+		t.Errorf("unexpected payload header fields: %+v", p)
+	}
+	if p.Old["status"] != "ready" || p.New["status"] != "done" {
+		t.Errorf("unexpected snapshots: old=%v new=%v", p.Old["status"], p.New["status"])
+	}
+	if len(p.Changed) != 1 || p.Changed[0] != "status" {
+		t.Errorf("Changed = %v, want [status]", p.Changed)
+	}
+	if p.Actor.Name != "Ada" || p.Actor.Email != "ada@example.com" {
+		t.Errorf("Actor = %+v", p.Actor)
+	}
+	if h := rec.headers[0]; h.Get(HeaderWebhookEvent) != "update" || h.Get(HeaderWebhookDelivery) == "" {
+		t.Errorf("missing event/delivery headers: %v", h)
+	}
+}
+
+func TestWebhook_EventSubscriptionAndFilter(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	gate, _ := newGateWithStoreAndTikis()
+	d := newTestDispatcher(t, []config.HookDef{{
+		URL:    srv.URL,
+		Events: []string{"create"},
+		Filter: `select where type = "bug"`,
+	}})
+	d.RegisterWithGate(gate)
+
+	ctx := context.Background()
+	if err := gate.CreateTiki(ctx, newTiki("HOOK02", "a story", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gate.CreateTiki(ctx, newTiki("HOOK03", "a bug", "ready", "bug", 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gate.DeleteTiki(ctx, gate.ReadStore().GetTiki("HOOK03")); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	got := rec.payloads(t)
+	if len(got) != 1 {
+		t.Fatalf("expected only the bug create to be delivered, got %d", len(got))
+	}
+	if got[0].ID != "HOOK03" || got[0].Event != "create" || got[0].Old != nil {
+		t.Errorf("unexpected payload: %+v", got[0])
+	}
+}
+
+func TestWebhook_DeleteMatchesFilterOnOldTiki(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	gate, _ := newGateWithStoreAndTikis(newTiki("HOOK04", "gone", "done", "bug", 3))
+	d := newTestDispatcher(t, []config.HookDef{{URL: srv.URL, Filter: `select where status = "done"`}})
+	d.RegisterWithGate(gate)
+
+	if err := gate.DeleteTiki(context.Background(), gate.ReadStore().GetTiki("HOOK04")); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := rec.payloads(t)
+	if len(got) != 1 || got[0].Event != "delete" || got[0].New != nil {
+		t.Fatalf("unexpected delete payloads: %+v", got)
+	}
+}
+
+func TestWebhook_SignsBody(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	gate, _ := newGateWithStoreAndTikis()
+	d := newTestDispatcher(t, []config.HookDef{{URL: srv.URL, Secret: "topsecret"}})
+	d.RegisterWithGate(gate)
+
+	if err := gate.CreateTiki(context.Background(), newTiki("HOOK05", "signed", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := SignWebhookPayload("topsecret", rec.bodies[0])
+	if got := rec.headers[0].Get(HeaderWebhookSignature); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if !strings.HasPrefix(want, "sha256=") {
+		t.Errorf("signature %q lacks sha256= prefix", want)
+	}
+}
+
+func TestWebhook_RetriesThenSucceeds(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	retries := 2
+	gate, _ := newGateWithStoreAndTikis()
+	d := newTestDispatcher(t, []config.HookDef{{URL: srv.URL, Retries: &retries}})
+	d.RegisterWithGate(gate)
+	if err := gate.CreateTiki(context.Background(), newTiki("HOOK06", "retry", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatalf("expected success on third attempt, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+	if d.Pending() != 0 {
+		t.Errorf("expected empty queue, got %d", d.Pending())
+	}
+}
+
+func TestWebhook_FailedDeliveryStaysQueued(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	rec.status.Store(http.StatusInternalServerError)
+
+	retries := 1
+	gate, _ := newGateWithStoreAndTikis()
+	d := newTestDispatcher(t, []config.HookDef{{URL: srv.URL, Retries: &retries}})
+	d.RegisterWithGate(gate)
+	if err := gate.CreateTiki(context.Background(), newTiki("HOOK07", "offline", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Flush(context.Background()); err == nil {
+		t.Fatal("expected delivery error")
+	}
+	if rec.requests.Load() != 2 {
+		t.Errorf("expected 2 attempts (1 + 1 retry), got %d", rec.requests.Load())
+	}
+	if d.Pending() != 1 {
+		t.Fatalf("failed delivery should stay queued, pending=%d", d.Pending())
+	}
+
+	// endpoint recovers: a later flush (e.g. next session) delivers it
+	rec.status.Store(http.StatusOK)
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatalf("second flush: %v", err)
+	}
+	if d.Pending() != 0 {
+		t.Errorf("expected queue drained, pending=%d", d.Pending())
+	}
+	if rec.requests.Load() != 3 {
+		t.Errorf("expected 3 total requests, got %d", rec.requests.Load())
+	}
+}
+
+func TestWebhook_QueueSurvivesNewDispatcher(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	rec.status.Store(http.StatusBadGateway)
+	queueDir := t.TempDir()
+
+	zero := 0
+	defs := []config.HookDef{{URL: srv.URL, Retries: &zero}}
+	gate, _ := newGateWithStoreAndTikis()
+	first, err := NewWebhookDispatcher(defs, testTriggerSchema{}, queueDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.RegisterWithGate(gate)
+	if err := gate.CreateTiki(context.Background(), newTiki("HOOK08", "queued", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	_ = first.Flush(context.Background())
+
+	rec.status.Store(http.StatusOK)
+	second, err := NewWebhookDispatcher(defs, testTriggerSchema{}, queueDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Pending() != 1 {
+		t.Fatalf("expected restarted dispatcher to see 1 pending delivery, got %d", second.Pending())
+	}
+	if err := second.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := rec.payloads(t)
+	if len(got) != 2 || got[1].ID != "HOOK08" {
+		t.Fatalf("expected redelivery of HOOK08, got %+v", got)
+	}
+}
+
+func TestWebhook_FlushPendingDeliversOnlyOwnQueue(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	rec.status.Store(http.StatusBadGateway)
+	queueDir := t.TempDir()
+
+	zero := 0
+	defs := []config.HookDef{{URL: srv.URL, Retries: &zero}}
+	gate, _ := newGateWithStoreAndTikis()
+	first, err := NewWebhookDispatcher(defs, testTriggerSchema{}, queueDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.RegisterWithGate(gate)
+	if err := gate.CreateTiki(context.Background(), newTiki("HOOK10", "stale", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	_ = first.Flush(context.Background())
+	attempts := len(rec.payloads(t))
+
+	// a later command that queued nothing must not retry the backlog
+	gate2, _ := newGateWithStoreAndTikis()
+	second, err := NewWebhookDispatcher(defs, testTriggerSchema{}, queueDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second.RegisterWithGate(gate2)
+	if err := second.FlushPending(time.Second); err != nil {
+		t.Fatalf("flush with nothing queued: %v", err)
+	}
+	if got := len(rec.payloads(t)); got != attempts {
+		t.Fatalf("expected no requests, got %d new", got-attempts)
+	}
+
+	// once it queues its own event, only that one is sent
+	rec.status.Store(http.StatusOK)
+	if err := gate2.CreateTiki(context.Background(), newTiki("HOOK11", "fresh", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.FlushPending(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	got := rec.payloads(t)[attempts:]
+	if len(got) != 1 || got[0].ID != "HOOK11" {
+		t.Fatalf("expected only HOOK11 delivered, got %+v", got)
+	}
+	if second.Pending() != 1 {
+		t.Errorf("earlier session's delivery should stay queued, pending=%d", second.Pending())
+	}
+}
+
+func TestWebhook_QueueKeepsEnvReferencesUnexpanded(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	rec.status.Store(http.StatusServiceUnavailable)
+	t.Setenv("TIKI_HOOK_TOKEN", "s3cr3t")
+	t.Setenv("TIKI_HOOK_URL", srv.URL)
+
+	zero := 0
+	queueDir := t.TempDir()
+	gate, _ := newGateWithStoreAndTikis()
+	d, err := NewWebhookDispatcher([]config.HookDef{{
+		URL:     "${TIKI_HOOK_URL}/hook",
+		Headers: map[string]string{"Authorization": "Bearer ${TIKI_HOOK_TOKEN}"},
+		Retries: &zero,
+	}}, testTriggerSchema{}, queueDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.RegisterWithGate(gate)
+	if err := gate.CreateTiki(context.Background(), newTiki("HOOK09", "token", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	_ = d.Flush(context.Background())
+
+	files, err := d.queuedFiles()
+	if err != nil || len(files) != 1 {
+		t.Fatalf("queued files = %v, %v", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "s3cr3t") || strings.Contains(string(data), srv.URL) {
+		t.Errorf("queue entry contains expanded environment values:\n%s", data)
+	}
+	if got := rec.headers[0].Get("Authorization"); got != "Bearer s3cr3t" {
+		t.Errorf("sent Authorization = %q, want the expanded token", got)
+	}
+}
+
+func TestWebhook_ExpiredDeliveryIsDropped(t *testing.T) {
+	rec, srv := newWebhookRecorder(t)
+	rec.status.Store(http.StatusBadGateway)
+
+	zero := 0
+	gate, _ := newGateWithStoreAndTikis()
+	d := newTestDispatcher(t, []config.HookDef{{URL: srv.URL, Retries: &zero}})
+	d.RegisterWithGate(gate)
+	if err := gate.CreateTiki(context.Background(), newTiki("HOOK10", "stale", "ready", "story", 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Flush(context.Background()); err == nil || d.Pending() != 1 {
+		t.Fatalf("expected a queued failure, err=%v pending=%d", err, d.Pending())
+	}
+
+	// a week later the endpoint is still down: the delivery is given up
+	later := time.Now().Add(webhookExpiry + time.Hour)
+	d.now = func() time.Time { return later }
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatalf("flush of an expired delivery: %v", err)
+	}
+	if d.Pending() != 0 {
+		t.Errorf("expired delivery still queued, pending=%d", d.Pending())
+	}
+	if rec.requests.Load() != 1 {
+		t.Errorf("expired delivery was retried: %d requests", rec.requests.Load())
+	}
+}
+
+func TestNewWebhookDispatcher_RejectsBadFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		wantErr string
+	}{
+		{"not select", `update where status = "done" set status = "ready"`, "must be a SELECT"},
+		{"unknown field", `select where nope = 1`, "parsing filter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebhookDispatcher([]config.HookDef{{URL: "https://example.com", Filter: tt.filter}}, testTriggerSchema{}, t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	old := newTiki("CHG001", "t", "ready", "story", 3)
+	new := old.Clone()
+	new.Set("status", "done")
+	new.Set("assignee", "bob")
+	new.SetUpdatedAt(time.Now())
+
+	got := ChangedFields(old, new)
+	if strings.Join(got, ",") != "assignee,status" {
+		t.Errorf("ChangedFields = %v, want [assignee status]", got)
+	}
+	if got := ChangedFields(old, old.Clone()); len(got) != 0 {
+		t.Errorf("identical snapshots should have no changes, got %v", got)
+	}
+}
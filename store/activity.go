@@ -0,0 +1,115 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/ruki"
+	"github.com/boolean-maybe/ruki/duration"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// ActivityKind says what a change did to a tiki.
+type ActivityKind string
+
+const (
+	ActivityCreated ActivityKind = "created"
+	ActivityUpdated ActivityKind = "updated"
+	ActivityDeleted ActivityKind = "deleted"
+)
+
+// ActivityEvent is one change to a tiki: who made it, when, and which fields
+// it touched. Committed marks an event read from git history rather than
+// recorded in this session.
+type ActivityEvent struct {
+	When      time.Time
+	Actor     string
+	TikiID    string
+	Title     string
+	Kind      ActivityKind
+	Fields    []string
+	Committed bool
+}
+
+// TikiVersion is one committed version of a tiki and the commit it is from.
+// A commit that deleted the tiki is a version with Deleted set, carrying the
+// tiki as it was last committed.
+type TikiVersion struct {
+	Tiki    *tikipkg.Tiki
+	Author  string
+	When    time.Time
+	Hash    string
+	Deleted bool
+}
+
+// HistoryReader is implemented by stores that can read committed history.
+// TikiVersionsSince returns the versions of the tikis committed since
+// since, each tiki's oldest first and preceded by its last version before
+// since when there is one.
+type HistoryReader interface {
+	TikiVersionsSince(since time.Time) ([]TikiVersion, error)
+}
+
+// ActivityFilter narrows a feed. Empty fields match everything: User
+// matches the actor case-insensitively, Field keeps events that touched it,
+// and Since/Until bound the time range (Until exclusive).
+type ActivityFilter struct {
+	User  string
+	Field string
+	Since time.Time
+	Until time.Time
+}
+
+// Matches reports whether e passes the filter.
+func (f ActivityFilter) Matches(e ActivityEvent) bool {
+	if f.User != "" && !strings.EqualFold(strings.TrimSpace(e.Actor), f.User) {
+		return false
+	}
+	if f.Field != "" && !slices.ContainsFunc(e.Fields, func(name string) bool { return strings.EqualFold(name, f.Field) }) {
+		return false
+	}
+	if !f.Since.IsZero() && e.When.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.When.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// FilterActivity returns the events that pass f, in order.
+func FilterActivity(events []ActivityEvent, f ActivityFilter) []ActivityEvent {
+	var result []ActivityEvent
+	for _, e := range events {
+		if f.Matches(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// ParseActivityTime reads the bound of an activity time range: "today" is
+// the start of the local day, a ruki duration such as "2day" or "12hour" is
+// that long before now, and a YYYY-MM-DD date is the start of that local
+// day.
+func ParseActivityTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "today") {
+		y, m, d := now.Local().Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local), nil
+	}
+	if date, err := ruki.ParseDateString(s); err == nil {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	value, unit, err := duration.Parse(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not today, a date (2006-01-02) or a duration (2day, 12hour)", s)
+	}
+	d, err := duration.ToDuration(value, unit)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(-d), nil
+}
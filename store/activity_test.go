@@ -0,0 +1,61 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityFilter(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 3, 2, h, 0, 0, 0, time.UTC) }
+	events := []ActivityEvent{
+		{When: at(15), Actor: "Alice", TikiID: "AAA001", Kind: ActivityUpdated, Fields: []string{"priority", "status"}},
+		{When: at(12), Actor: "bob", TikiID: "BBB001", Kind: ActivityCreated},
+		{When: at(9), Actor: "alice", TikiID: "CCC001", Kind: ActivityUpdated, Fields: []string{"title"}},
+	}
+	ids := func(es []ActivityEvent) string {
+		s := ""
+		for _, e := range es {
+			s += e.TikiID + " "
+		}
+		return s
+	}
+	tests := []struct {
+		name   string
+		filter ActivityFilter
+		want   string
+	}{
+		{"everything", ActivityFilter{}, "AAA001 BBB001 CCC001 "},
+		{"user ignores case", ActivityFilter{User: "ALICE"}, "AAA001 CCC001 "},
+		{"field", ActivityFilter{Field: "Status"}, "AAA001 "},
+		{"since inclusive, until exclusive", ActivityFilter{Since: at(12), Until: at(15)}, "BBB001 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(FilterActivity(events, tt.filter)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseActivityTime(t *testing.T) {
+	now := time.Date(2026, 3, 2, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"today", time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)},
+		{"12hour", now.Add(-12 * time.Hour)},
+		{"2days", now.Add(-48 * time.Hour)},
+		{"2026-02-20", time.Date(2026, 2, 20, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := ParseActivityTime(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseActivityTime(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseActivityTime("lately", now); err == nil {
+		t.Error("expected an error for an unknown bound")
+	}
+}
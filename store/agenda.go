@@ -0,0 +1,181 @@
+package store
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/boolean-maybe/ruki/recurrence"
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// AgendaEntry is one deadline of a tiki: the day it falls on and the due
+// field it was read from. Occurrence marks an upcoming occurrence of a
+// recurring tiki rather than its stored date.
+type AgendaEntry struct {
+	Tiki       *tikipkg.Tiki
+	Field      string
+	Day        time.Time
+	Occurrence bool
+}
+
+// AgendaDay is the entries falling on one day.
+type AgendaDay struct {
+	Day     time.Time
+	Entries []AgendaEntry
+}
+
+// Agenda is the open deadlines around Today: Overdue holds those before it,
+// oldest first, and Days one group per day from Today on that has entries.
+type Agenda struct {
+	Today   time.Time
+	Overdue []AgendaEntry
+	Days    []AgendaDay
+}
+
+// BuildAgenda collects the deadlines of the tikis that are not done: every
+// stored due date before today is overdue, and stored dates and recurrence
+// occurrences in the days days from today on are grouped by day. A non-empty
+// assignee keeps only the tikis assigned to that user.
+func BuildAgenda(tikis []*tikipkg.Tiki, cfg config.AgendaConfig, assignee string, today time.Time, days int) Agenda {
+	today = agendaDay(today)
+	end := today.AddDate(0, 0, days)
+	agenda := Agenda{Today: today}
+	byDay := make(map[time.Time][]AgendaEntry)
+	for _, tk := range agendaTikis(tikis, cfg, assignee) {
+		var stored time.Time
+		for _, field := range cfg.DueFields {
+			due, ok, _ := tk.TimeField(field)
+			if !ok || due.IsZero() {
+				continue
+			}
+			day := agendaDay(due)
+			if stored.IsZero() {
+				stored = day
+			}
+			entry := AgendaEntry{Tiki: tk, Field: field, Day: day}
+			switch {
+			case day.Before(today):
+				agenda.Overdue = append(agenda.Overdue, entry)
+			case day.Before(end):
+				byDay[day] = append(byDay[day], entry)
+			}
+		}
+
+		rec, ok := agendaRecurrence(tk, cfg)
+		if !ok {
+			continue
+		}
+		// occurrences follow the first stored date and never lie in the past
+		cursor := today.AddDate(0, 0, -1)
+		if stored.After(cursor) {
+			cursor = stored
+		}
+		for {
+			next := recurrence.NextOccurrenceFrom(rec, cursor)
+			if next.IsZero() || !next.Before(end) {
+				break
+			}
+			if !next.Equal(stored) {
+				byDay[next] = append(byDay[next], AgendaEntry{Tiki: tk, Field: cfg.DueFields[0], Day: next, Occurrence: true})
+			}
+			cursor = next
+		}
+	}
+
+	slices.SortStableFunc(agenda.Overdue, func(a, b AgendaEntry) int {
+		if c := a.Day.Compare(b.Day); c != 0 {
+			return c
+		}
+		return compareAgendaEntries(a, b)
+	})
+	for day, entries := range byDay {
+		slices.SortStableFunc(entries, compareAgendaEntries)
+		agenda.Days = append(agenda.Days, AgendaDay{Day: day, Entries: entries})
+	}
+	slices.SortFunc(agenda.Days, func(a, b AgendaDay) int { return a.Day.Compare(b.Day) })
+	return agenda
+}
+
+// OverdueTikis returns the tikis with at least one due date before today
+// that are not done, most overdue first. A non-empty assignee keeps only the
+// tikis assigned to that user.
+func OverdueTikis(tikis []*tikipkg.Tiki, cfg config.AgendaConfig, assignee string, today time.Time) []*tikipkg.Tiki {
+	overdue := BuildAgenda(tikis, cfg, assignee, today, 0).Overdue
+	seen := make(map[string]bool, len(overdue))
+	result := make([]*tikipkg.Tiki, 0, len(overdue))
+	for _, e := range overdue {
+		if seen[e.Tiki.ID()] {
+			continue
+		}
+		seen[e.Tiki.ID()] = true
+		result = append(result, e.Tiki)
+	}
+	return result
+}
+
+// agendaTikis drops done tikis and, for a non-empty assignee, the tikis
+// assigned to someone else.
+func agendaTikis(tikis []*tikipkg.Tiki, cfg config.AgendaConfig, assignee string) []*tikipkg.Tiki {
+	result := make([]*tikipkg.Tiki, 0, len(tikis))
+	for _, tk := range tikis {
+		if cfg.StatusField != "" {
+			if status, _, _ := tk.StringField(cfg.StatusField); cfg.IsDone(status) {
+				continue
+			}
+		}
+		if assignee != "" {
+			if cfg.AssigneeField == "" {
+				continue
+			}
+			if who, _, _ := tk.StringField(cfg.AssigneeField); !strings.EqualFold(strings.TrimSpace(who), assignee) {
+				continue
+			}
+		}
+		result = append(result, tk)
+	}
+	return result
+}
+
+// agendaRecurrence returns the tiki's recurrence when the agenda expands
+// them.
+func agendaRecurrence(tk *tikipkg.Tiki, cfg config.AgendaConfig) (recurrence.Recurrence, bool) {
+	if cfg.RecurrenceField == "" || len(cfg.DueFields) == 0 {
+		return "", false
+	}
+	s, present, ok := tk.StringField(cfg.RecurrenceField)
+	if !present || !ok {
+		return "", false
+	}
+	rec := recurrence.Recurrence(s)
+	if rec == recurrence.RecurrenceNone || !recurrence.IsValidRecurrence(rec) {
+		return "", false
+	}
+	return rec, true
+}
+
+// compareAgendaEntries orders stored dates before occurrences, then by
+// title and ID.
+func compareAgendaEntries(a, b AgendaEntry) int {
+	if a.Occurrence != b.Occurrence {
+		if a.Occurrence {
+			return 1
+		}
+		return -1
+	}
+	if c := strings.Compare(strings.ToLower(a.Tiki.Title()), strings.ToLower(b.Tiki.Title())); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Tiki.ID(), b.Tiki.ID())
+}
+
+// agendaDay truncates t to its calendar day, in UTC like date fields. A
+// time with a clock part (a datetime field, or now) counts on its local day.
+func agendaDay(t time.Time) time.Time {
+	if h, m, s := t.Clock(); h != 0 || m != 0 || s != 0 || t.Nanosecond() != 0 {
+		t = t.Local()
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
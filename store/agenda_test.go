@@ -0,0 +1,106 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func testAgendaConfig() config.AgendaConfig {
+	return config.AgendaConfig{
+		DueFields:       []string{"due"},
+		RecurrenceField: "recurrence",
+		AssigneeField:   "assignee",
+		DoneStatus:      config.DoneStatus{StatusField: "status", Done: []string{"done"}},
+	}
+}
+
+func newAgendaTiki(id, status, due, assignee, rec string) *tikipkg.Tiki {
+	tk := newWorkflowTiki(id, id, status, nil, nil)
+	if due != "" {
+		d, _ := time.Parse(time.DateOnly, due)
+		tk.Set("due", d)
+	}
+	if assignee != "" {
+		tk.Set("assignee", assignee)
+	}
+	if rec != "" {
+		tk.Set("recurrence", rec)
+	}
+	return tk
+}
+
+// agendaFixture is seen from Wednesday 2026-03-04.
+func agendaFixture() []*tikipkg.Tiki {
+	return []*tikipkg.Tiki{
+		newAgendaTiki("LATE01", "ready", "2026-03-01", "alice", ""),
+		newAgendaTiki("LATE02", "ready", "2026-02-20", "bob", ""),
+		newAgendaTiki("TODAY1", "ready", "2026-03-04", "alice", ""),
+		newAgendaTiki("DONE01", "done", "2026-02-01", "alice", ""),
+		newAgendaTiki("WEEK01", "ready", "2026-03-05", "alice", "0 0 * * THU"),
+		newAgendaTiki("LATER1", "ready", "2026-04-30", "alice", ""),
+		newAgendaTiki("NODUE1", "ready", "", "alice", ""),
+	}
+}
+
+func agendaIDs(entries []AgendaEntry) []string {
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.Tiki.ID()
+		if e.Occurrence {
+			ids[i] += "*"
+		}
+	}
+	return ids
+}
+
+func TestBuildAgenda_GroupsByDayAndExpandsRecurrence(t *testing.T) {
+	today := time.Date(2026, 3, 4, 15, 30, 0, 0, time.Local)
+	agenda := BuildAgenda(agendaFixture(), testAgendaConfig(), "", today, 14)
+
+	if got := agendaIDs(agenda.Overdue); len(got) != 2 || got[0] != "LATE02" || got[1] != "LATE01" {
+		t.Errorf("overdue = %v, want LATE02 then LATE01 and no done tikis", got)
+	}
+	want := map[string][]string{
+		"2026-03-04": {"TODAY1"},
+		"2026-03-05": {"WEEK01"},
+		"2026-03-12": {"WEEK01*"},
+	}
+	if len(agenda.Days) != len(want) {
+		t.Fatalf("days = %+v, want %d", agenda.Days, len(want))
+	}
+	for i, day := range agenda.Days {
+		key := day.Day.Format("2006-01-02")
+		got := agendaIDs(day.Entries)
+		if len(got) != len(want[key]) || got[0] != want[key][0] {
+			t.Errorf("day %d %s = %v, want %v", i, key, got, want[key])
+		}
+		if i > 0 && !day.Day.After(agenda.Days[i-1].Day) {
+			t.Errorf("days out of order at %s", key)
+		}
+	}
+}
+
+func TestBuildAgenda_Assignee(t *testing.T) {
+	today := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	agenda := BuildAgenda(agendaFixture(), testAgendaConfig(), "Bob", today, 7)
+	if got := agendaIDs(agenda.Overdue); len(got) != 1 || got[0] != "LATE02" {
+		t.Errorf("overdue for bob = %v, want LATE02", got)
+	}
+	if len(agenda.Days) != 0 {
+		t.Errorf("bob has nothing upcoming, got %+v", agenda.Days)
+	}
+}
+
+func TestOverdueTikis_OncePerTiki(t *testing.T) {
+	cfg := testAgendaConfig()
+	cfg.DueFields = []string{"due", "start"}
+	late := newAgendaTiki("LATE01", "ready", "2026-03-01", "alice", "")
+	late.Set("start", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	got := OverdueTikis([]*tikipkg.Tiki{late}, cfg, "alice", time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC))
+	if len(got) != 1 || got[0].ID() != "LATE01" {
+		t.Errorf("overdue = %v, want LATE01 once", got)
+	}
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/document"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// checklistBarCells is the width of the checklistProgress bar, excluding the
+// "done/total" count behind it.
+const checklistBarCells = 5
+
+// ResolveChecklist answers the derived checklist fields from the task list
+// in tk's body. A body without task list items has a zero total and done
+// count and no progress bar, so `checklistProgress?` hides in layouts.
+func ResolveChecklist(tk *tikipkg.Tiki, name string) (interface{}, bool) {
+	items := document.ParseChecklist(tk.Body())
+	done := 0
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	switch name {
+	case workflow.FieldChecklistTotal:
+		return len(items), true
+	case workflow.FieldChecklistDone:
+		return done, true
+	case workflow.FieldChecklistProgress:
+		if len(items) == 0 {
+			return nil, false
+		}
+		return ChecklistProgress(done, len(items)), true
+	}
+	return nil, false
+}
+
+// ChecklistProgress renders done of total as a short bar followed by the
+// count, e.g. "▰▰▰▱▱ 3/5".
+func ChecklistProgress(done, total int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(done*checklistBarCells/total, checklistBarCells)
+	}
+	return fmt.Sprintf("%s%s %d/%d",
+		strings.Repeat("▰", filled), strings.Repeat("▱", checklistBarCells-filled), done, total)
+}
+
+// InstallChecklistResolver installs ResolveChecklist as the resolver for the
+// checklist fields, so ruki queries and layouts see them on every tiki.
+func InstallChecklistResolver() {
+	tikipkg.SetDerivedResolver(workflow.ChecklistFieldNames(), ResolveChecklist)
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"testing"
+
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func TestResolveChecklist(t *testing.T) {
+	tk := tikipkg.New()
+	tk.SetBody("Steps:\n- [x] design\n- [ ] build\n- [x] review\n")
+
+	for name, want := range map[string]interface{}{
+		workflow.FieldChecklistTotal:    3,
+		workflow.FieldChecklistDone:     2,
+		workflow.FieldChecklistProgress: "▰▰▰▱▱ 2/3",
+	} {
+		if got, ok := ResolveChecklist(tk, name); !ok || got != want {
+			t.Errorf("%s = %v (ok %v), want %v", name, got, ok, want)
+		}
+	}
+
+	tk.SetBody("no task list")
+	if got, ok := ResolveChecklist(tk, workflow.FieldChecklistTotal); !ok || got != 0 {
+		t.Errorf("checklistTotal without items = %v (ok %v), want 0", got, ok)
+	}
+	if _, ok := ResolveChecklist(tk, workflow.FieldChecklistProgress); ok {
+		t.Error("checklistProgress should be absent without items")
+	}
+}
+
+func TestChecklistProgress(t *testing.T) {
+	for _, tt := range []struct {
+		done, total int
+		want        string
+	}{
+		{0, 4, "▱▱▱▱▱ 0/4"},
+		{1, 4, "▰▱▱▱▱ 1/4"},
+		{4, 4, "▰▰▰▰▰ 4/4"},
+		{9, 10, "▰▰▰▰▱ 9/10"},
+	} {
+		if got := ChecklistProgress(tt.done, tt.total); got != tt.want {
+			t.Errorf("ChecklistProgress(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package store
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/boolean-maybe/ruki"
+
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// computedCacheTTL bounds how long a cached value is served without a store
+// change, so expressions using now() (e.g. an age) stay roughly current.
+const computedCacheTTL = 30 * time.Second
+
+// durationEpoch anchors duration-typed expressions. ruki cannot assign a
+// duration to a field, so `expr` is evaluated as a date `epoch + (expr)` and
+// the duration recovered by subtracting the epoch again.
+const durationEpoch = "2000-01-01"
+
+var durationEpochTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ComputedField is a computed field compiled against a ruki schema.
+type ComputedField struct {
+	Def    workflow.FieldDef
+	stmt   *ruki.ValidatedStatement // evaluates the selected tiki
+	all    *ruki.ValidatedStatement // evaluates every tiki at once
+	schema ruki.Schema
+}
+
+// CompileComputedFields parses and type-checks the computed expressions in
+// defs against schema. Each expression is compiled as the assignment
+// `update where id = id() set <field> = <expr>`, so it is checked against
+// the field's declared type and, at evaluation time, reads the tiki's own
+// fields bare while subqueries see the whole workspace. A second form
+// without the id filter evaluates every tiki in one pass. Non-computed defs
+// are skipped.
+func CompileComputedFields(schema ruki.Schema, defs []workflow.FieldDef) ([]ComputedField, error) {
+	var out []ComputedField
+	for _, fd := range defs {
+		if !fd.IsComputed() {
+			continue
+		}
+		cf, err := compileComputedField(schema, fd)
+		if err != nil {
+			return nil, fmt.Errorf("computed field %q: %w", fd.Name, err)
+		}
+		out = append(out, cf)
+	}
+	return out, nil
+}
+
+func compileComputedField(schema ruki.Schema, fd workflow.FieldDef) (ComputedField, error) {
+	evalSchema := schema
+	rhs := fd.Computed
+	if fd.Type == workflow.TypeDuration {
+		evalSchema = overrideSchema{Schema: schema, name: fd.Name, typ: ruki.ValueDate}
+		// newline before the paren so a trailing `--` comment stays a comment
+		rhs = durationEpoch + " + (" + fd.Computed + "\n)"
+	}
+	parser := ruki.NewParser(evalSchema)
+	stmt, err := parser.ParseAndValidateStatement("update where id = id() set "+fd.Name+" = "+rhs, ruki.ExecutorRuntimePlugin)
+	if err != nil {
+		return ComputedField{}, err
+	}
+	all, err := parser.ParseAndValidateStatement(`update where id != "" set `+fd.Name+" = "+rhs, ruki.ExecutorRuntimePlugin)
+	if err != nil {
+		return ComputedField{}, err
+	}
+	if stmt.UsesInputBuiltin() || stmt.UsesChooseBuiltin() || stmt.UsesIDsBuiltin() ||
+		stmt.UsesSelectedCountBuiltin() || stmt.UsesFilepathsBuiltin() ||
+		stmt.UsesTargetQualifier() || stmt.UsesTargetsQualifier() {
+		return ComputedField{}, fmt.Errorf("input(), choose(), ids(), selected_count(), filepaths() and target qualifiers are not available in computed fields")
+	}
+	return ComputedField{Def: fd, stmt: stmt, all: all, schema: evalSchema}, nil
+}
+
+// overrideSchema reports one field under a different type.
+type overrideSchema struct {
+	ruki.Schema
+	name string
+	typ  ruki.ValueType
+}
+
+func (s overrideSchema) Field(name string) (ruki.FieldSpec, bool) {
+	spec, ok := s.Schema.Field(name)
+	if ok && name == s.name {
+		spec.Type = s.typ
+	}
+	return spec, ok
+}
+
+// ComputedIndex evaluates computed fields for tikis of a store. Every value
+// of the stored tikis is evaluated once per store change (or once per
+// computedCacheTTL) over one shared snapshot of the workspace; tikis that are
+// not the stored version — candidates inside mutations and triggers — are
+// evaluated fresh against that snapshot, so they see values matching their
+// pending state.
+type ComputedIndex struct {
+	store      ReadStore
+	userFunc   func() string
+	fields     map[string]ComputedField
+	mu         sync.Mutex
+	snap       *computedSnapshot
+	listenerID int
+}
+
+type computedValue struct {
+	value interface{}
+	ok    bool
+}
+
+// NewComputedIndex creates an index for the compiled fields over s and
+// subscribes it to changes. userFunc backs the user() builtin and may be nil.
+func NewComputedIndex(s ReadStore, fields []ComputedField, userFunc func() string) *ComputedIndex {
+	x := &ComputedIndex{
+		store:    s,
+		userFunc: userFunc,
+		fields:   make(map[string]ComputedField, len(fields)),
+	}
+	for _, cf := range fields {
+		x.fields[cf.Def.Name] = cf
+	}
+	x.listenerID = s.AddListener(x.invalidate)
+	return x
+}
+
+// InstallComputedIndex compiles the registered computed fields against
+// schema, creates an index over s and installs it as their resolver, so ruki
+// queries (lane filters, order by) and the views see the values on every
+// tiki. Returns nil when the workflow declares no computed fields.
+func InstallComputedIndex(s ReadStore, schema ruki.Schema, userFunc func() string) (*ComputedIndex, error) {
+	fields, err := CompileComputedFields(schema, workflow.ComputedFields())
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	x := NewComputedIndex(s, fields, userFunc)
+	tikipkg.SetDerivedResolver(x.Names(), x.Resolve)
+	return x, nil
+}
+
+// Names returns the names of the indexed fields.
+func (x *ComputedIndex) Names() []string {
+	names := make([]string, 0, len(x.fields))
+	for name := range x.fields {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Resolve returns the value of computed field name for tk. ok is false when
+// the expression fails for tk (e.g. it reads a field tk doesn't have).
+func (x *ComputedIndex) Resolve(tk *tikipkg.Tiki, name string) (interface{}, bool) {
+	cf, known := x.fields[name]
+	if !known || tk == nil || tk.ID() == "" {
+		return nil, false
+	}
+	snap := x.snapshot()
+	if snap.tikis[tk.ID()] == tk {
+		v := snap.values[tk.ID()][name]
+		return v.value, v.ok
+	}
+	return snap.evaluateCandidate(cf, tk)
+}
+
+// snapshot returns the current snapshot, building it when the store changed
+// or the TTL passed since it was built.
+func (x *ComputedIndex) snapshot() *computedSnapshot {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.snap == nil || time.Since(x.snap.builtAt) > computedCacheTTL {
+		x.snap = x.buildSnapshot()
+	}
+	return x.snap
+}
+
+// Close unsubscribes the index from the store and removes its resolver.
+func (x *ComputedIndex) Close() {
+	x.store.RemoveListener(x.listenerID)
+	tikipkg.SetDerivedResolver(x.Names(), nil)
+}
+
+func (x *ComputedIndex) invalidate() {
+	x.mu.Lock()
+	x.snap = nil
+	x.mu.Unlock()
+}
+
+// computedSnapshot holds the stored workspace as of one store state and the
+// computed values of every stored tiki. Its documents answer computed fields
+// from the snapshot itself, so an expression reading another computed field
+// (on its own tiki or inside a subquery) reuses the values already evaluated
+// instead of evaluating them again.
+type computedSnapshot struct {
+	x       *ComputedIndex
+	builtAt time.Time
+	tikis   map[string]*tikipkg.Tiki
+	docs    []ruki.Document
+	values  map[string]map[string]computedValue // tiki id → field → value
+	done    map[string]bool                     // fields evaluated for every tiki
+}
+
+func (x *ComputedIndex) buildSnapshot() *computedSnapshot {
+	all := x.store.GetAllTikis()
+	snap := &computedSnapshot{
+		x:       x,
+		builtAt: time.Now(),
+		tikis:   make(map[string]*tikipkg.Tiki, len(all)),
+		docs:    make([]ruki.Document, 0, len(all)),
+		values:  make(map[string]map[string]computedValue, len(all)),
+		done:    make(map[string]bool, len(x.fields)),
+	}
+	for _, tk := range all {
+		snap.tikis[tk.ID()] = tk
+		snap.docs = append(snap.docs, snapshotDoc{Doc: tikipkg.Doc{T: tk}, snap: snap})
+		snap.values[tk.ID()] = make(map[string]computedValue, len(x.fields))
+	}
+	for name := range x.fields {
+		if !snap.done[name] {
+			snap.evaluateField(name)
+		}
+	}
+	return snap
+}
+
+// value returns the snapshot value of field name for the stored tiki id,
+// evaluating the field first when an expression reads it before its turn.
+// Cycles are rejected when the workflow loads, so the recursion ends.
+func (snap *computedSnapshot) value(id, name string) computedValue {
+	if !snap.done[name] {
+		snap.evaluateField(name)
+	}
+	return snap.values[id][name]
+}
+
+// evaluateField evaluates field name for every stored tiki. The expression
+// normally runs once over the whole snapshot; if it fails for some tiki, each
+// tiki is evaluated on its own so only the failing ones go without a value.
+func (snap *computedSnapshot) evaluateField(name string) {
+	snap.done[name] = true
+	cf := snap.x.fields[name]
+	executor := snap.x.newExecutor(cf)
+	if result, err := executor.Execute(cf.all, snap.docs); err == nil && result.Update != nil {
+		for _, doc := range result.Update.Updated {
+			value, ok := computedResult(cf, doc)
+			snap.values[doc.ID()][name] = computedValue{value: value, ok: ok}
+		}
+		return
+	}
+	for id := range snap.tikis {
+		value, ok := snap.x.evaluate(executor, cf, snap.docs, id)
+		snap.values[id][name] = computedValue{value: value, ok: ok}
+	}
+}
+
+// evaluateCandidate evaluates cf for a tiki that is not the stored version,
+// against the snapshot with tk in place of its stored version. Other tikis'
+// computed values come from the snapshot; tk's own are evaluated fresh.
+func (snap *computedSnapshot) evaluateCandidate(cf ComputedField, tk *tikipkg.Tiki) (interface{}, bool) {
+	docs := make([]ruki.Document, 0, len(snap.docs)+1)
+	replaced := false
+	for _, d := range snap.docs {
+		if d.ID() == tk.ID() {
+			d, replaced = tikipkg.WrapDoc(tk), true
+		}
+		docs = append(docs, d)
+	}
+	if !replaced {
+		docs = append(docs, tikipkg.WrapDoc(tk))
+	}
+	return snap.x.evaluate(snap.x.newExecutor(cf), cf, docs, tk.ID())
+}
+
+// snapshotDoc is a stored tiki inside a snapshot. Computed fields are read
+// from the snapshot; clones made by the executor keep reading them by id,
+// which is sound because an expression never changes the tiki it reads.
+type snapshotDoc struct {
+	tikipkg.Doc
+	snap *computedSnapshot
+}
+
+func (d snapshotDoc) Get(n string) (interface{}, bool) {
+	if _, ok := d.snap.x.fields[n]; ok {
+		v := d.snap.value(d.ID(), n)
+		return v.value, v.ok
+	}
+	return d.Doc.Get(n)
+}
+
+func (d snapshotDoc) Has(n string) bool {
+	if _, ok := d.snap.x.fields[n]; ok {
+		return d.snap.value(d.ID(), n).ok
+	}
+	return d.Doc.Has(n)
+}
+
+func (d snapshotDoc) Clone() ruki.Document {
+	return snapshotDoc{Doc: tikipkg.Doc{T: d.T.Clone()}, snap: d.snap}
+}
+
+func (x *ComputedIndex) newExecutor(cf ComputedField) *ruki.Executor {
+	return ruki.NewExecutor(cf.schema, ruki.DocumentFactory(tikipkg.NewDoc), x.userFunc,
+		ruki.ExecutorRuntime{Mode: ruki.ExecutorRuntimePlugin})
+}
+
+// evaluate runs the field's statement over docs with tiki id selected.
+func (x *ComputedIndex) evaluate(executor *ruki.Executor, cf ComputedField, docs []ruki.Document, id string) (interface{}, bool) {
+	result, err := executor.Execute(cf.stmt, docs, ruki.NewSingleSelectionInput(id))
+	if err != nil {
+		slog.Debug("computed field evaluation failed", "field", cf.Def.Name, "tiki_id", id, "error", err)
+		return nil, false
+	}
+	if result.Update == nil || len(result.Update.Updated) != 1 {
+		return nil, false
+	}
+	return computedResult(cf, result.Update.Updated[0])
+}
+
+// computedResult reads the assigned value from an updated clone's own
+// fields: Doc.Get would route the name back to the resolver.
+func computedResult(cf ComputedField, doc ruki.Document) (interface{}, bool) {
+	var tk *tikipkg.Tiki
+	switch d := doc.(type) {
+	case snapshotDoc:
+		tk = d.T
+	default:
+		tk = tikipkg.UnwrapDoc(doc)
+	}
+	value, ok := tk.Get(cf.Def.Name)
+	if !ok {
+		return nil, false
+	}
+	if cf.Def.Type == workflow.TypeDuration {
+		t, isTime := value.(time.Time)
+		if !isTime {
+			return nil, false
+		}
+		return t.Sub(durationEpochTime), true
+	}
+	return value, true
+}
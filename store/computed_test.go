@@ -0,0 +1,185 @@
+package store_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/ruki"
+
+	"github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/internal/teststatuses"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func initComputedFields(t *testing.T, defs ...workflow.FieldDef) {
+	t.Helper()
+	t.Cleanup(teststatuses.Init)
+	if err := teststatuses.InitWith(defs); err != nil {
+		t.Fatalf("register fields: %v", err)
+	}
+}
+
+func computedTiki(id, status string, escalations int, deps ...string) *tikipkg.Tiki {
+	tk := tikipkg.New()
+	tk.SetID(id)
+	tk.SetTitle(id)
+	tk.Set("status", status)
+	tk.Set("escalations", escalations)
+	if len(deps) > 0 {
+		tk.Set("dependsOn", deps)
+	}
+	return tk
+}
+
+func TestComputedIndex_ValuesInQueries(t *testing.T) {
+	initComputedFields(t,
+		workflow.FieldDef{Name: "openDeps", Type: workflow.TypeInt,
+			Computed: `count(select where id in outer.dependsOn and status != "done")`},
+		workflow.FieldDef{Name: "score", Type: workflow.TypeInt, Computed: "escalations + openDeps"},
+		workflow.FieldDef{Name: "age", Type: workflow.TypeDuration, Computed: "now() - createdAt -- elapsed"},
+	)
+	s := store.NewInMemoryStore()
+	created := time.Now().Add(-48 * time.Hour)
+	for _, tk := range []*tikipkg.Tiki{
+		computedTiki("AAA001", "ready", 1, "BBB001", "CCC001"),
+		computedTiki("BBB001", "ready", 5),
+		computedTiki("CCC001", "done", 0),
+	} {
+		if err := s.CreateTiki(tk); err != nil {
+			t.Fatal(err)
+		}
+		s.GetTiki(tk.ID()).SetCreatedAt(created) // the store stamps creation time
+	}
+
+	x, err := store.InstallComputedIndex(s, runtime.NewSchema(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(x.Close)
+
+	doc := tikipkg.WrapDoc(s.GetTiki("AAA001"))
+	if v, _ := doc.Get("openDeps"); v != 1 {
+		t.Errorf("openDeps = %v, want 1", v)
+	}
+	if v, _ := doc.Get("score"); v != 2 {
+		t.Errorf("score = %v, want 2", v)
+	}
+	if v, _ := doc.Get("age"); v.(time.Duration) < 47*time.Hour {
+		t.Errorf("age = %v, want about 48h", v)
+	}
+	if _, ok := s.GetTiki("AAA001").Get("score"); ok {
+		t.Error("computed value leaked into the tiki's fields")
+	}
+
+	// lane filters and order by see the values
+	schema := runtime.NewSchema()
+	stmt, err := ruki.NewParser(schema).ParseAndValidateStatement(
+		`select where score > 0 and age > 1day order by score desc`, ruki.ExecutorRuntimeCLI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := ruki.NewExecutor(schema, ruki.DocumentFactory(tikipkg.NewDoc), nil, ruki.ExecutorRuntime{})
+	result, err := executor.Execute(stmt, tikipkg.WrapDocs(s.GetAllTikis()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, d := range result.Select.Tikis {
+		ids = append(ids, d.ID())
+	}
+	if got := strings.Join(ids, ","); got != "BBB001,AAA001" {
+		t.Errorf("select = %s, want BBB001,AAA001", got)
+	}
+
+	// a pending edit is evaluated against its own state, not the cache
+	pending := s.GetTiki("AAA001").Clone()
+	pending.Set("escalations", 10)
+	if v, _ := tikipkg.WrapDoc(pending).Get("score"); v != 11 {
+		t.Errorf("pending score = %v, want 11", v)
+	}
+
+	// a store change drops cached values
+	done := s.GetTiki("BBB001").Clone()
+	done.Set("status", "done")
+	if err := s.UpdateTiki(done); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := tikipkg.WrapDoc(s.GetTiki("AAA001")).Get("openDeps"); v != 0 {
+		t.Errorf("openDeps after update = %v, want 0", v)
+	}
+}
+
+func TestComputedIndex_EvaluatesOncePerChange(t *testing.T) {
+	initComputedFields(t,
+		workflow.FieldDef{Name: "viewer", Type: workflow.TypeString, Computed: "user()"},
+		workflow.FieldDef{Name: "badge", Type: workflow.TypeString, Computed: `viewer + "!"`},
+	)
+	s := store.NewInMemoryStore()
+	for _, id := range []string{"AAA001", "BBB001", "CCC001"} {
+		if err := s.CreateTiki(computedTiki(id, "ready", 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	calls := 0
+	x, err := store.InstallComputedIndex(s, runtime.NewSchema(), func() string { calls++; return "ada" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(x.Close)
+
+	read := func() {
+		t.Helper()
+		for _, tk := range s.GetAllTikis() {
+			if v, _ := tikipkg.WrapDoc(tk).Get("badge"); v != "ada!" {
+				t.Fatalf("%s badge = %v, want ada!", tk.ID(), v)
+			}
+		}
+	}
+	read()
+	read()
+	if calls != 3 {
+		t.Errorf("user() evaluated %d times for 3 tikis, want each value evaluated once", calls)
+	}
+
+	if err := s.UpdateTiki(s.GetTiki("AAA001").Clone()); err != nil {
+		t.Fatal(err)
+	}
+	read()
+	if calls != 6 {
+		t.Errorf("user() evaluated %d times after one change, want 6", calls)
+	}
+}
+
+func TestCompileComputedFields_Errors(t *testing.T) {
+	initComputedFields(t)
+	schema := runtime.NewSchemaFromFields(append(workflow.Fields(),
+		workflow.FieldDef{Name: "label", Type: workflow.TypeInt, Computed: `"x"`, Custom: true},
+		workflow.FieldDef{Name: "picked", Type: workflow.TypeRef, Computed: "choose(select)", Custom: true},
+	))
+	for _, tc := range []struct {
+		def  workflow.FieldDef
+		want string
+	}{
+		{workflow.FieldDef{Name: "label", Type: workflow.TypeInt, Computed: `"x"`}, `computed field "label"`},
+		{workflow.FieldDef{Name: "picked", Type: workflow.TypeRef, Computed: "choose(select)"}, "not available in computed fields"},
+	} {
+		_, err := store.CompileComputedFields(schema, []workflow.FieldDef{tc.def})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", tc.def.Name, err, tc.want)
+		}
+	}
+}
+
+func TestRegisterWorkflowFields_RejectsComputedCycle(t *testing.T) {
+	t.Cleanup(teststatuses.Init)
+	err := teststatuses.InitWith([]workflow.FieldDef{
+		{Name: "a", Type: workflow.TypeInt, Computed: "b + 1"},
+		{Name: "b", Type: workflow.TypeInt, Computed: `count(select where a > 0) -- "a" in a comment is fine`},
+	})
+	if err == nil || !strings.Contains(err.Error(), "a → b → a") {
+		t.Fatalf("err = %v, want a cycle error", err)
+	}
+}
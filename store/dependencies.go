@@ -0,0 +1,314 @@
+package store
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// DependencyGraph is an immutable snapshot of the dependency edges between
+// tikis, built from the workflow's dependencies: section. A tiki is finished
+// when its status is one of the configured terminal values; every other tiki
+// blocks the tikis that depend on it. References to unknown ids are ignored.
+type DependencyGraph struct {
+	cfg        config.DependencyConfig
+	deps       map[string][]string // id -> direct dependencies, declaration order
+	dependents map[string][]string // id -> tikis that list it directly
+	done       map[string]bool
+	depth      map[string]int // memoized Depth results
+	mu         sync.Mutex     // guards depth
+}
+
+// NewDependencyGraph builds the graph over tikis.
+func NewDependencyGraph(tikis []*tikipkg.Tiki, cfg config.DependencyConfig) *DependencyGraph {
+	g := &DependencyGraph{
+		cfg:        cfg,
+		deps:       make(map[string][]string, len(tikis)),
+		dependents: make(map[string][]string),
+		done:       make(map[string]bool, len(tikis)),
+		depth:      make(map[string]int),
+	}
+	for _, tk := range tikis {
+		if tk == nil {
+			continue
+		}
+		g.done[tk.ID()] = g.isDoneTiki(tk)
+	}
+	for _, tk := range tikis {
+		if tk == nil {
+			continue
+		}
+		refs := g.refsOf(tk)
+		g.deps[tk.ID()] = refs
+		for _, dep := range refs {
+			g.dependents[dep] = append(g.dependents[dep], tk.ID())
+		}
+	}
+	return g
+}
+
+// Config returns the dependencies section the graph was built with.
+func (g *DependencyGraph) Config() config.DependencyConfig { return g.cfg }
+
+// Has reports whether id is a tiki known to the graph.
+func (g *DependencyGraph) Has(id string) bool {
+	_, ok := g.done[id]
+	return ok
+}
+
+// Dependencies returns the direct dependencies of id in declaration order.
+func (g *DependencyGraph) Dependencies(id string) []string {
+	return append([]string(nil), g.deps[id]...)
+}
+
+// IsDone reports whether id is finished. Unknown ids count as finished so a
+// dangling reference never blocks anything.
+func (g *DependencyGraph) IsDone(id string) bool {
+	done, ok := g.done[id]
+	return !ok || done
+}
+
+// Blockers returns the unfinished direct dependencies of id.
+func (g *DependencyGraph) Blockers(id string) []string {
+	return g.unfinished(g.deps[id])
+}
+
+// Blocked reports whether id has at least one unfinished dependency.
+func (g *DependencyGraph) Blocked(id string) bool {
+	return len(g.Blockers(id)) > 0
+}
+
+// Depth returns the length of the longest chain of unfinished dependencies
+// below id: 0 when nothing blocks it, 1 when only tikis that are themselves
+// unblocked do, and so on. Edges that close a cycle are not followed.
+func (g *DependencyGraph) Depth(id string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.depthLocked(id, map[string]bool{})
+}
+
+// depthOf is Depth for a list of direct dependencies that need not belong to
+// a tiki in the graph (e.g. a candidate tiki that is not stored yet).
+func (g *DependencyGraph) depthOf(deps []string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	best := 0
+	for _, b := range g.unfinished(deps) {
+		if d := g.depthLocked(b, map[string]bool{}) + 1; d > best {
+			best = d
+		}
+	}
+	return best
+}
+
+func (g *DependencyGraph) depthLocked(id string, visiting map[string]bool) int {
+	if d, ok := g.depth[id]; ok {
+		return d
+	}
+	if visiting[id] {
+		return 0
+	}
+	visiting[id] = true
+	best := 0
+	for _, b := range g.Blockers(id) {
+		if d := g.depthLocked(b, visiting) + 1; d > best {
+			best = d
+		}
+	}
+	delete(visiting, id)
+	g.depth[id] = best
+	return best
+}
+
+// Dependents returns every tiki that depends on id directly or
+// transitively, sorted by id.
+func (g *DependencyGraph) Dependents(id string) []string {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	var out []string
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, d := range g.dependents[cur] {
+			if seen[d] {
+				continue
+			}
+			seen[d] = true
+			out = append(out, d)
+			queue = append(queue, d)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// CriticalPath returns the longest chain of unfinished dependencies below
+// id, ordered from the tiki that has to be finished first up to a direct
+// dependency of id. Ties go to the dependency declared first. Empty when id
+// is not blocked.
+func (g *DependencyGraph) CriticalPath(id string) []string {
+	var path []string
+	seen := map[string]bool{id: true}
+	cur := id
+	for {
+		next, best := "", -1
+		for _, b := range g.Blockers(cur) {
+			if seen[b] {
+				continue
+			}
+			if d := g.Depth(b); d > best {
+				next, best = b, d
+			}
+		}
+		if next == "" {
+			break
+		}
+		seen[next] = true
+		path = append(path, next)
+		cur = next
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// FindCycle returns a dependency cycle through id as a closed walk
+// (id, …, id), or nil when id is not part of a cycle.
+func (g *DependencyGraph) FindCycle(id string) []string {
+	visited := map[string]bool{}
+	var path []string
+	var walk func(cur string) bool
+	walk = func(cur string) bool {
+		for _, dep := range g.deps[cur] {
+			if dep == id {
+				path = append(path, dep)
+				return true
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			path = append(path, dep)
+			if walk(dep) {
+				return true
+			}
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+	path = append(path, id)
+	if walk(id) {
+		return path
+	}
+	return nil
+}
+
+// refsOf returns the dependency references of tk that name known tikis,
+// without duplicates.
+func (g *DependencyGraph) refsOf(tk *tikipkg.Tiki) []string {
+	raw, _, _ := tk.StringSliceField(g.cfg.Field)
+	if len(raw) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(raw))
+	out := make([]string, 0, len(raw))
+	for _, ref := range raw {
+		ref = normalizeTikiID(ref)
+		if seen[ref] || !g.Has(ref) {
+			continue
+		}
+		seen[ref] = true
+		out = append(out, ref)
+	}
+	return out
+}
+
+func (g *DependencyGraph) unfinished(deps []string) []string {
+	out := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if !g.IsDone(dep) {
+			out = append(out, dep)
+		}
+	}
+	return out
+}
+
+func (g *DependencyGraph) isDoneTiki(tk *tikipkg.Tiki) bool {
+	status, _, _ := tk.StringField(g.cfg.StatusField)
+	return g.cfg.IsDone(status)
+}
+
+// DependencyIndex keeps a DependencyGraph over a store current. The graph is
+// dropped on every store change and rebuilt on the next read, so bursts of
+// mutations cost one rebuild.
+type DependencyIndex struct {
+	store      ReadStore
+	cfg        config.DependencyConfig
+	mu         sync.Mutex
+	graph      *DependencyGraph
+	listenerID int
+}
+
+// NewDependencyIndex creates an index over s and subscribes it to changes.
+func NewDependencyIndex(s ReadStore, cfg config.DependencyConfig) *DependencyIndex {
+	x := &DependencyIndex{store: s, cfg: cfg}
+	x.listenerID = s.AddListener(x.invalidate)
+	return x
+}
+
+// InstallDependencyIndex creates an index over s and installs it as the
+// resolver for the derived blocked/blockers/depth fields, so ruki queries
+// see them on every tiki.
+func InstallDependencyIndex(s ReadStore, cfg config.DependencyConfig) *DependencyIndex {
+	x := NewDependencyIndex(s, cfg)
+	tikipkg.SetDerivedResolver(workflow.DerivedFieldNames(), x.Resolve)
+	return x
+}
+
+// Graph returns the current graph, rebuilding it after a store change.
+func (x *DependencyIndex) Graph() *DependencyGraph {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.graph == nil {
+		x.graph = NewDependencyGraph(x.store.GetAllTikis(), x.cfg)
+	}
+	return x.graph
+}
+
+// Resolve computes a derived dependency field for tk. The tiki's own
+// dependency list is used rather than the stored one, so candidate tikis
+// inside triggers and mutations see values matching their pending state.
+func (x *DependencyIndex) Resolve(tk *tikipkg.Tiki, name string) (interface{}, bool) {
+	g := x.Graph()
+	deps, _, _ := tk.StringSliceField(x.cfg.Field)
+	refs := make([]string, 0, len(deps))
+	for _, ref := range deps {
+		if ref = normalizeTikiID(ref); ref != tk.ID() {
+			refs = append(refs, ref)
+		}
+	}
+	switch name {
+	case workflow.FieldBlocked:
+		return len(g.unfinished(refs)) > 0, true
+	case workflow.FieldBlockers:
+		return g.unfinished(refs), true
+	case workflow.FieldDepth:
+		return g.depthOf(refs), true
+	}
+	return nil, false
+}
+
+// Close unsubscribes the index from the store.
+func (x *DependencyIndex) Close() {
+	x.store.RemoveListener(x.listenerID)
+}
+
+func (x *DependencyIndex) invalidate() {
+	x.mu.Lock()
+	x.graph = nil
+	x.mu.Unlock()
+}
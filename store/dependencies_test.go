@@ -0,0 +1,117 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+var testDependencyConfig = config.DependencyConfig{
+	Field:      "dependsOn",
+	DoneStatus: config.DoneStatus{StatusField: "status", Done: []string{"done"}},
+}
+
+// release depends on A and B; A depends on C, C on D; B is done.
+func dependencyFixture() []*tikipkg.Tiki {
+	return []*tikipkg.Tiki{
+		newWorkflowTiki("REL001", "Release", "ready", nil, []string{"AAA001", "BBB001"}),
+		newWorkflowTiki("AAA001", "A", "inProgress", nil, []string{"CCC001"}),
+		newWorkflowTiki("BBB001", "B", "done", nil, nil),
+		newWorkflowTiki("CCC001", "C", "ready", nil, []string{"DDD001"}),
+		newWorkflowTiki("DDD001", "D", "inbox", nil, nil),
+	}
+}
+
+func TestDependencyGraph_BlockersAndDepth(t *testing.T) {
+	g := NewDependencyGraph(dependencyFixture(), testDependencyConfig)
+
+	if got := g.Blockers("REL001"); !reflect.DeepEqual(got, []string{"AAA001"}) {
+		t.Errorf("Blockers(REL001) = %v, want [AAA001]", got)
+	}
+	if !g.Blocked("REL001") || g.Blocked("DDD001") || g.Blocked("BBB001") {
+		t.Error("blocked state wrong: want only tikis with unfinished dependencies blocked")
+	}
+	depths := map[string]int{"REL001": 3, "AAA001": 2, "CCC001": 1, "DDD001": 0, "BBB001": 0}
+	for id, want := range depths {
+		if got := g.Depth(id); got != want {
+			t.Errorf("Depth(%s) = %d, want %d", id, got, want)
+		}
+	}
+}
+
+func TestDependencyGraph_CriticalPathAndDependents(t *testing.T) {
+	g := NewDependencyGraph(dependencyFixture(), testDependencyConfig)
+
+	if got := g.CriticalPath("REL001"); !reflect.DeepEqual(got, []string{"DDD001", "CCC001", "AAA001"}) {
+		t.Errorf("CriticalPath(REL001) = %v, want [DDD001 CCC001 AAA001]", got)
+	}
+	if got := g.CriticalPath("DDD001"); len(got) != 0 {
+		t.Errorf("CriticalPath of an unblocked tiki = %v, want empty", got)
+	}
+	if got := g.Dependents("DDD001"); !reflect.DeepEqual(got, []string{"AAA001", "CCC001", "REL001"}) {
+		t.Errorf("Dependents(DDD001) = %v, want [AAA001 CCC001 REL001]", got)
+	}
+}
+
+func TestDependencyGraph_FindCycle(t *testing.T) {
+	tikis := dependencyFixture()
+	if c := NewDependencyGraph(tikis, testDependencyConfig).FindCycle("DDD001"); c != nil {
+		t.Fatalf("acyclic graph reported cycle %v", c)
+	}
+	tikis[4].Set("dependsOn", []string{"REL001"})
+	g := NewDependencyGraph(tikis, testDependencyConfig)
+	want := []string{"DDD001", "REL001", "AAA001", "CCC001", "DDD001"}
+	if got := g.FindCycle("DDD001"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindCycle(DDD001) = %v, want %v", got, want)
+	}
+	// depth stays finite when a cycle exists
+	if d := g.Depth("REL001"); d < 1 {
+		t.Errorf("Depth on a cyclic graph = %d, want >= 1", d)
+	}
+}
+
+func TestDependencyGraph_IgnoresUnknownReferences(t *testing.T) {
+	tikis := []*tikipkg.Tiki{newWorkflowTiki("AAA001", "A", "ready", nil, []string{"ZZZ999"})}
+	g := NewDependencyGraph(tikis, testDependencyConfig)
+	if g.Blocked("AAA001") {
+		t.Error("a dangling reference must not block")
+	}
+}
+
+func TestDependencyIndex_ResolveTracksStoreChanges(t *testing.T) {
+	s := NewInMemoryStore()
+	for _, tk := range dependencyFixture() {
+		if err := s.CreateTiki(tk); err != nil {
+			t.Fatalf("CreateTiki: %v", err)
+		}
+	}
+	x := NewDependencyIndex(s, testDependencyConfig)
+	defer x.Close()
+
+	rel := s.GetTiki("REL001")
+	if v, _ := x.Resolve(rel, workflow.FieldBlocked); v != true {
+		t.Fatalf("blocked = %v, want true", v)
+	}
+
+	a := s.GetTiki("AAA001").Clone()
+	a.Set("status", "done")
+	if err := s.UpdateTiki(a); err != nil {
+		t.Fatalf("UpdateTiki: %v", err)
+	}
+	if v, _ := x.Resolve(rel, workflow.FieldBlocked); v != false {
+		t.Errorf("blocked after finishing the blocker = %v, want false", v)
+	}
+	if v, _ := x.Resolve(rel, workflow.FieldDepth); v != 0 {
+		t.Errorf("depth after finishing the blocker = %v, want 0", v)
+	}
+
+	// a candidate tiki is resolved from its own, pending dependency list
+	candidate := rel.Clone()
+	candidate.Set("dependsOn", []string{"CCC001"})
+	if v, _ := x.Resolve(candidate, workflow.FieldBlockers); !reflect.DeepEqual(v, []string{"CCC001"}) {
+		t.Errorf("blockers of candidate = %v, want [CCC001]", v)
+	}
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"github.com/boolean-maybe/tiki/document"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// DisplayID returns the id shown for tk: qualified with the prefix of its
+// workspace (`BE:ABC123`) while config.yaml mounts workspaces, the bare id
+// otherwise. The prefix comes from the derived workspace field, so views do
+// not need the store to render it.
+func DisplayID(tk *tikipkg.Tiki) string {
+	if tk == nil {
+		return ""
+	}
+	if v, ok, _ := tikipkg.DerivedValue(tk, workflow.FieldWorkspace); ok {
+		if prefix, _ := v.(string); prefix != "" {
+			return document.QualifyID(prefix, tk.ID())
+		}
+	}
+	return tk.ID()
+}
@@ -131,6 +131,13 @@ func (s *selector) AllFileVersionsSince(dirPattern string, since time.Time, incl
 	return s.backend.AllFileVersionsSince(dirPattern, since, includePrior)
 }
 
+func (s *selector) AllFileHistorySince(dirPattern string, since time.Time, includePrior bool) (map[string][]FileVersion, error) {
+	if err := s.ensureBackend(); err != nil {
+		return nil, err
+	}
+	return s.backend.AllFileHistorySince(dirPattern, since, includePrior)
+}
+
 func (s *selector) AllUsers() ([]string, error) {
 	if err := s.ensureBackend(); err != nil {
 		return nil, err
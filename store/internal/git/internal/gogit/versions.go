@@ -67,9 +67,34 @@ func (g *Util) FileVersionsSince(filePath string, since time.Time, includePrior
 	return versions, nil
 }
 
-// AllFileVersionsSince returns file versions for all files matching dirPattern since the given time.
+// AllFileVersionsSince returns file versions for all files matching dirPattern since the given time,
+// each file's in chronological order with the prior version (if requested) first.
 // Only includes commits where a "status:" line was added or removed (matching shell's -G^status: behavior).
 func (g *Util) AllFileVersionsSince(dirPattern string, since time.Time, includePrior bool) (map[string][]types.FileVersion, error) {
+	history, err := g.fileHistorySince(dirPattern, since, includePrior, statusChangedFiles)
+	if err != nil {
+		return nil, err
+	}
+	return types.WithoutDeletions(history), nil
+}
+
+// AllFileHistorySince returns file versions for all files matching dirPattern since the given time,
+// from every commit that changed them, each file's in chronological order with the prior version
+// (if requested) first. A commit that deleted a file yields a version with Deleted set and no content.
+func (g *Util) AllFileHistorySince(dirPattern string, since time.Time, includePrior bool) (map[string][]types.FileVersion, error) {
+	return g.fileHistorySince(dirPattern, since, includePrior, changedFiles)
+}
+
+// fileChange is a file matching the pattern that a commit changed.
+type fileChange struct {
+	name    string
+	deleted bool
+}
+
+// fileHistorySince walks the commits since the given time (and the prior one
+// per file, if requested) and reads each file at every commit touched reports.
+func (g *Util) fileHistorySince(dirPattern string, since time.Time, includePrior bool,
+	touched func(c *object.Commit, dirPattern string) ([]fileChange, error)) (map[string][]types.FileVersion, error) {
 	dirPattern = g.toRelativePattern(dirPattern)
 
 	pathFilter := func(path string) bool {
@@ -83,6 +108,7 @@ func (g *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 		author     string
 		email      string
 		when       time.Time
+		deleted    bool
 	}
 	fileCommits := make(map[string][]fileCommitEntry)
 
@@ -97,16 +123,17 @@ func (g *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 	}
 
 	err = sinceCommitIter.ForEach(func(c *object.Commit) error {
-		touched, err := statusChangedFiles(c, dirPattern)
+		changes, err := touched(c, dirPattern)
 		if err != nil {
 			return err
 		}
-		for _, name := range touched {
-			fileCommits[name] = append(fileCommits[name], fileCommitEntry{
+		for _, change := range changes {
+			fileCommits[change.name] = append(fileCommits[change.name], fileCommitEntry{
 				commitHash: c.Hash.String(),
 				author:     c.Author.Name,
 				email:      c.Author.Email,
 				when:       c.Author.When,
+				deleted:    change.deleted,
 			})
 		}
 		return nil
@@ -127,17 +154,18 @@ func (g *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 		})
 		if err == nil {
 			_ = priorCommitIter.ForEach(func(c *object.Commit) error {
-				touched, err := statusChangedFiles(c, dirPattern)
+				changes, err := touched(c, dirPattern)
 				if err != nil {
 					return err
 				}
-				for _, name := range touched {
-					if _, exists := priorCommits[name]; !exists {
-						priorCommits[name] = fileCommitEntry{
+				for _, change := range changes {
+					if _, exists := priorCommits[change.name]; !exists {
+						priorCommits[change.name] = fileCommitEntry{
 							commitHash: c.Hash.String(),
 							author:     c.Author.Name,
 							email:      c.Author.Email,
 							when:       c.Author.When,
+							deleted:    change.deleted,
 						}
 					}
 				}
@@ -147,34 +175,77 @@ func (g *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 		}
 	}
 
+	// commits from go-git are newest-first; reverse each file's to
+	// chronological order
+	for _, entries := range fileCommits {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+
 	// prepend prior commits
 	for file, prior := range priorCommits {
 		fileCommits[file] = append([]fileCommitEntry{prior}, fileCommits[file]...)
 	}
 
-	// fetch blob content for each commit
+	// fetch blob content for each commit; a deletion has none
 	for file, entries := range fileCommits {
 		for _, entry := range entries {
-			content, err := g.readBlobAt(entry.commitHash, file)
-			if err != nil {
-				continue
-			}
-			result[file] = append(result[file], types.FileVersion{
+			version := types.FileVersion{
 				Hash:    entry.commitHash,
 				Author:  entry.author,
 				Email:   entry.email,
 				When:    entry.when,
-				Content: content,
-			})
+				Deleted: entry.deleted,
+			}
+			if !entry.deleted {
+				content, err := g.readBlobAt(entry.commitHash, file)
+				if err != nil {
+					continue
+				}
+				version.Content = content
+			}
+			result[file] = append(result[file], version)
 		}
 	}
 
 	return result, nil
 }
 
-// statusChangedFiles returns the names of files matching dirPattern where a
+// changedFiles returns the files matching dirPattern that the given commit
+// added, modified or deleted relative to its parent.
+func changedFiles(c *object.Commit, dirPattern string) ([]fileChange, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	pTree, err := parentTree(c)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffTrees(pTree, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []fileChange
+	for _, ch := range changes {
+		change := fileChange{name: ch.To.Name}
+		if change.name == "" {
+			change = fileChange{name: ch.From.Name, deleted: true}
+		}
+		if matchesPattern(change.name, dirPattern) {
+			matched = append(matched, change)
+		}
+	}
+	return matched, nil
+}
+
+// statusChangedFiles returns the files matching dirPattern where a
 // "status:" line was touched in the given commit's diff against its parent.
-func statusChangedFiles(c *object.Commit, dirPattern string) ([]string, error) {
+func statusChangedFiles(c *object.Commit, dirPattern string) ([]fileChange, error) {
 	tree, err := c.Tree()
 	if err != nil {
 		return nil, err
@@ -195,26 +266,23 @@ func statusChangedFiles(c *object.Commit, dirPattern string) ([]string, error) {
 		return nil, err
 	}
 
-	var matched []string
+	var matched []fileChange
 	for _, fp := range patch.FilePatches() {
-		_, to := fp.Files()
-		var name string
+		from, to := fp.Files()
+		var change fileChange
 		if to != nil {
-			name = to.Path()
-		} else {
-			from, _ := fp.Files()
-			if from != nil {
-				name = from.Path()
-			}
+			change.name = to.Path()
+		} else if from != nil {
+			change = fileChange{name: from.Path(), deleted: true}
 		}
-		if name == "" {
+		if change.name == "" {
 			continue
 		}
-		if !matchesPattern(name, dirPattern) {
+		if !matchesPattern(change.name, dirPattern) {
 			continue
 		}
 		if statusLineTouched(fp) {
-			matched = append(matched, name)
+			matched = append(matched, change)
 		}
 	}
 	return matched, nil
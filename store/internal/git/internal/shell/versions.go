@@ -89,121 +89,126 @@ func (u *Util) buildFileVersion(logLine string, relPath string) (types.FileVersi
 	}, nil
 }
 
-// AllFileVersionsSince returns file versions for all files matching dirPattern since the given time.
+// AllFileVersionsSince returns file versions for all files matching dirPattern since the given time,
+// each file's in chronological order with the prior version (if requested) first.
+// Only includes commits where a "status:" line was added or removed.
 func (u *Util) AllFileVersionsSince(dirPattern string, since time.Time, includePrior bool) (map[string][]types.FileVersion, error) {
-	sinceStr := since.Format(time.RFC3339)
-	result := make(map[string][]types.FileVersion)
+	history, err := u.fileHistorySince(dirPattern, since, includePrior, "-G^status:")
+	if err != nil {
+		return nil, err
+	}
+	return types.WithoutDeletions(history), nil
+}
+
+// AllFileHistorySince returns file versions for all files matching dirPattern since the given time,
+// from every commit that changed them, each file's in chronological order with the prior version
+// (if requested) first. A commit that deleted a file yields a version with Deleted set and no content.
+func (u *Util) AllFileHistorySince(dirPattern string, since time.Time, includePrior bool) (map[string][]types.FileVersion, error) {
+	return u.fileHistorySince(dirPattern, since, includePrior)
+}
+
+type fileCommit struct {
+	hash    string
+	author  string
+	email   string
+	when    time.Time
+	deleted bool
+}
+
+type commitInfo struct {
+	fileCommit
+	files []fileChange
+}
+
+// fileChange is a file a commit changed, as listed by --name-status.
+type fileChange struct {
+	name    string
+	deleted bool
+}
 
+// logFileCommits runs git log over dirPattern with --name-status and returns
+// its commits, newest first. window is the --since or --before bound.
+func (u *Util) logFileCommits(dirPattern string, window []string, filter []string) ([]commitInfo, error) {
+	args := append([]string{"log", "--all", "--full-history"}, filter...)
+	args = append(args, "--format=%H|%an|%ae|%aI", "--name-status", "--no-renames")
+	args = append(args, window...)
+	args = append(args, "--", dirPattern)
 	//nolint:gosec // G204: git command with controlled directory pattern and timestamp
-	cmd := exec.Command("git", "log", "--all", "--full-history", "-G^status:",
-		"--format=%H|%an|%ae|%aI", "--name-only", "--since", sinceStr, "--", dirPattern)
+	cmd := exec.Command("git", args...)
 	cmd.Dir = u.repoPath
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get git log for %s: %w", dirPattern, err)
-	}
-
-	type commitInfo struct {
-		hash   string
-		author string
-		email  string
-		when   time.Time
-		files  []string
+		return nil, err
 	}
 
 	var commits []commitInfo
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var currentCommit *commitInfo
-
-	for _, line := range lines {
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
 
-		if strings.Contains(line, "|") {
-			parts := strings.SplitN(line, "|", 4)
-			if len(parts) < 4 {
-				continue
+		// --name-status lines are "<status>\t<path>"; anything else is a header
+		if status, file, ok := strings.Cut(line, "\t"); ok {
+			if len(commits) > 0 {
+				last := &commits[len(commits)-1]
+				last.files = append(last.files, fileChange{name: file, deleted: status == "D"})
 			}
-			when, err := parseGitTime(parts[3])
-			if err != nil {
-				continue
-			}
-			currentCommit = &commitInfo{
-				hash:   parts[0],
-				author: parts[1],
-				email:  parts[2],
-				when:   when,
-				files:  []string{},
-			}
-			commits = append(commits, *currentCommit)
-		} else if currentCommit != nil {
-			currentCommit.files = append(currentCommit.files, line)
-			commits[len(commits)-1] = *currentCommit
+			continue
+		}
+
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		when, err := parseGitTime(parts[3])
+		if err != nil {
+			continue
 		}
+		commits = append(commits, commitInfo{fileCommit: fileCommit{
+			hash:   parts[0],
+			author: parts[1],
+			email:  parts[2],
+			when:   when,
+		}})
 	}
+	return commits, nil
+}
 
-	type fileCommit struct {
-		hash   string
-		author string
-		email  string
-		when   time.Time
+// fileHistorySince reads each file matching dirPattern at every commit since
+// the given time (and the prior one per file, if requested) that git log
+// lists with the extra filter arguments.
+func (u *Util) fileHistorySince(dirPattern string, since time.Time, includePrior bool, filter ...string) (map[string][]types.FileVersion, error) {
+	sinceStr := since.Format(time.RFC3339)
+	result := make(map[string][]types.FileVersion)
+
+	commits, err := u.logFileCommits(dirPattern, []string{"--since", sinceStr}, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git log for %s: %w", dirPattern, err)
 	}
+
 	fileCommits := make(map[string][]fileCommit)
 
-	for _, commit := range commits {
+	// git log lists newest first; walk it backwards so each file's commits
+	// come out oldest first, after the prior commit
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
 		for _, file := range commit.files {
-			fileCommits[file] = append(fileCommits[file], fileCommit{
-				hash:   commit.hash,
-				author: commit.author,
-				email:  commit.email,
-				when:   commit.when,
-			})
+			fc := commit.fileCommit
+			fc.deleted = file.deleted
+			fileCommits[file.name] = append(fileCommits[file.name], fc)
 		}
 	}
 
 	if includePrior {
-		//nolint:gosec // G204: git command with controlled directory pattern and timestamp
-		cmd := exec.Command("git", "log", "--all", "--full-history", "-G^status:",
-			"--format=%H|%an|%ae|%aI", "--name-only", "--before", sinceStr, "--", dirPattern)
-		cmd.Dir = u.repoPath
-		if output, err := cmd.Output(); err == nil {
-			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-			var currentCommit *commitInfo
+		if prior, err := u.logFileCommits(dirPattern, []string{"--before", sinceStr}, filter); err == nil {
 			priorCommits := make(map[string]fileCommit)
-
-			for _, line := range lines {
-				line = strings.TrimSpace(line)
-				if line == "" {
-					continue
-				}
-
-				if strings.Contains(line, "|") {
-					parts := strings.SplitN(line, "|", 4)
-					if len(parts) < 4 {
-						continue
-					}
-					when, err := parseGitTime(parts[3])
-					if err != nil {
-						continue
-					}
-					currentCommit = &commitInfo{
-						hash:   parts[0],
-						author: parts[1],
-						email:  parts[2],
-						when:   when,
-						files:  []string{},
-					}
-				} else if currentCommit != nil {
-					file := line
-					if _, alreadyHave := priorCommits[file]; !alreadyHave {
-						priorCommits[file] = fileCommit{
-							hash:   currentCommit.hash,
-							author: currentCommit.author,
-							email:  currentCommit.email,
-							when:   currentCommit.when,
-						}
+			for _, commit := range prior {
+				for _, file := range commit.files {
+					if _, alreadyHave := priorCommits[file.name]; !alreadyHave {
+						fc := commit.fileCommit
+						fc.deleted = file.deleted
+						priorCommits[file.name] = fc
 					}
 				}
 			}
@@ -216,19 +221,21 @@ func (u *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 
 	type blobRequest struct {
 		file   string
+		index  int
 		commit fileCommit
 	}
 
 	type blobResult struct {
 		file    string
+		index   int
 		version types.FileVersion
 		err     error
 	}
 
 	var requests []blobRequest
 	for file, commits := range fileCommits {
-		for _, commit := range commits {
-			requests = append(requests, blobRequest{file: file, commit: commit})
+		for i, commit := range commits {
+			requests = append(requests, blobRequest{file: file, index: i, commit: commit})
 		}
 	}
 
@@ -247,6 +254,19 @@ func (u *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 		go func() {
 			defer wg.Done()
 			for req := range requestChan {
+				version := types.FileVersion{
+					Hash:    req.commit.hash,
+					Author:  req.commit.author,
+					Email:   req.commit.email,
+					When:    req.commit.when,
+					Deleted: req.commit.deleted,
+				}
+				// a deletion has no blob to read
+				if req.commit.deleted {
+					resultChan <- blobResult{file: req.file, index: req.index, version: version}
+					continue
+				}
+
 				showTarget := fmt.Sprintf("%s:%s", req.commit.hash, req.file)
 				//nolint:gosec // G204: git command with controlled commit hash and file path
 				showCmd := exec.Command("git", "show", showTarget)
@@ -254,20 +274,12 @@ func (u *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 				content, err := showCmd.Output()
 
 				if err != nil {
-					resultChan <- blobResult{file: req.file, err: err}
+					resultChan <- blobResult{file: req.file, index: req.index, err: err}
 					continue
 				}
 
-				resultChan <- blobResult{
-					file: req.file,
-					version: types.FileVersion{
-						Hash:    req.commit.hash,
-						Author:  req.commit.author,
-						Email:   req.commit.email,
-						When:    req.commit.when,
-						Content: string(content),
-					},
-				}
+				version.Content = string(content)
+				resultChan <- blobResult{file: req.file, index: req.index, version: version}
 			}
 		}()
 	}
@@ -277,11 +289,25 @@ func (u *Util) AllFileVersionsSince(dirPattern string, since time.Time, includeP
 		close(resultChan)
 	}()
 
+	// blobs arrive in any order; slot them back by commit so each file's
+	// versions stay chronological, dropping the ones that failed
+	fetched := make(map[string][]*types.FileVersion, len(fileCommits))
+	for file, commits := range fileCommits {
+		fetched[file] = make([]*types.FileVersion, len(commits))
+	}
 	for res := range resultChan {
 		if res.err != nil {
 			continue
 		}
-		result[res.file] = append(result[res.file], res.version)
+		version := res.version
+		fetched[res.file][res.index] = &version
+	}
+	for file, versions := range fetched {
+		for _, v := range versions {
+			if v != nil {
+				result[file] = append(result[file], *v)
+			}
+		}
 	}
 
 	return result, nil
@@ -11,11 +11,27 @@ type AuthorInfo struct {
 	Message    string
 }
 
-// FileVersion represents the content of a file at a specific commit
+// FileVersion represents the content of a file at a specific commit.
+// Deleted is set, and Content empty, when the commit removed the file.
 type FileVersion struct {
 	Hash    string
 	Author  string
 	Email   string
 	When    time.Time
 	Content string
+	Deleted bool
+}
+
+// WithoutDeletions drops the deleted versions from history, and the files
+// left with none.
+func WithoutDeletions(history map[string][]FileVersion) map[string][]FileVersion {
+	result := make(map[string][]FileVersion, len(history))
+	for file, versions := range history {
+		for _, v := range versions {
+			if !v.Deleted {
+				result[file] = append(result[file], v)
+			}
+		}
+	}
+	return result
 }
@@ -14,6 +14,7 @@ type GitOps interface {
 	CurrentBranch() (string, error)
 	FileVersionsSince(filePath string, since time.Time, includePrior bool) ([]FileVersion, error)
 	AllFileVersionsSince(dirPattern string, since time.Time, includePrior bool) (map[string][]FileVersion, error)
+	AllFileHistorySince(dirPattern string, since time.Time, includePrior bool) (map[string][]FileVersion, error)
 	AllUsers() ([]string, error)
 }
 
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"testing"
 	"time"
@@ -391,9 +392,15 @@ func TestParity_AllFileVersionsSince(t *testing.T) {
 			sv := shellResult[key]
 			gv := gogitResult[key]
 
-			// sort versions by time then hash for stable comparison
-			sortVersions(sv)
-			sortVersions(gv)
+			// both backends return each file's versions oldest first
+			for _, versions := range [][]types.FileVersion{sv, gv} {
+				for i := 1; i < len(versions); i++ {
+					if versions[i].When.Before(versions[i-1].When) {
+						t.Errorf("AllFileVersionsSince(prior=%v)[%s] not chronological: %v after %v",
+							includePrior, key, versions[i].When, versions[i-1].When)
+					}
+				}
+			}
 
 			if len(sv) != len(gv) {
 				t.Errorf("AllFileVersionsSince(prior=%v)[%s] version count: shell=%d gogit=%d",
@@ -413,6 +420,118 @@ func TestParity_AllFileVersionsSince(t *testing.T) {
 	}
 }
 
+// addUnfilteredHistory extends the parity repo with a commit that leaves
+// status alone and one that deletes a tiki.
+func addUnfilteredHistory(t *testing.T, dir string) {
+	t.Helper()
+	repo, err := gogitlib.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("PlainOpen: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// commit 4: dave retitles tiki-002
+	if err := os.WriteFile(filepath.Join(dir, "tikis/tiki-002.md"), []byte("---\ntitle: renamed\nstatus: open\n---\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := wt.Add("tikis/tiki-002.md"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := wt.Commit("dave retitles tiki 2", &gogitlib.CommitOptions{
+		Author: &object.Signature{Name: "dave", Email: "dave@test.com", When: base.Add(70 * 24 * time.Hour)},
+	}); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	// commit 5: erin deletes tiki-003
+	if _, err := wt.Remove("tikis/tiki-003.md"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := wt.Commit("erin deletes tiki 3", &gogitlib.CommitOptions{
+		Author: &object.Signature{Name: "erin", Email: "erin@test.com", When: base.Add(80 * 24 * time.Hour)},
+	}); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+}
+
+func TestParity_AllFileHistorySince(t *testing.T) {
+	requireShellGit(t)
+	dir := setupParityRepo(t)
+	addUnfilteredHistory(t, dir)
+
+	sh, err := shell.NewUtil(dir)
+	if err != nil {
+		t.Fatalf("shell.NewUtil: %v", err)
+	}
+	gg, err := gogit.NewUtil(dir)
+	if err != nil {
+		t.Fatalf("gogit.NewUtil: %v", err)
+	}
+
+	since := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	pattern := filepath.Join(dir, "tikis", "*.md")
+
+	type version struct {
+		author  string
+		deleted bool
+	}
+	want := map[bool]map[string][]version{
+		false: {
+			"tikis/tiki-001.md": {{"bob", false}},
+			"tikis/tiki-002.md": {{"dave", false}},
+			"tikis/tiki-003.md": {{"charlie", false}, {"erin", true}},
+		},
+		true: {
+			"tikis/tiki-001.md": {{"alice", false}, {"bob", false}},
+			"tikis/tiki-002.md": {{"alice", false}, {"dave", false}},
+			"tikis/tiki-003.md": {{"charlie", false}, {"erin", true}},
+		},
+	}
+
+	for _, includePrior := range []bool{false, true} {
+		for name, backend := range map[string]interface {
+			AllFileHistorySince(string, time.Time, bool) (map[string][]types.FileVersion, error)
+		}{"shell": sh, "gogit": gg} {
+			result, err := backend.AllFileHistorySince(pattern, since, includePrior)
+			if err != nil {
+				t.Fatalf("%s.AllFileHistorySince(prior=%v): %v", name, includePrior, err)
+			}
+			got := make(map[string][]version)
+			for file, versions := range result {
+				for _, v := range versions {
+					if v.Deleted != (v.Content == "") {
+						t.Errorf("%s[%s] at %s: deleted=%v with content %q", name, file, v.Author, v.Deleted, v.Content)
+					}
+					got[file] = append(got[file], version{v.Author, v.Deleted})
+				}
+			}
+			if !reflect.DeepEqual(got, want[includePrior]) {
+				t.Errorf("%s.AllFileHistorySince(prior=%v) = %v, want %v", name, includePrior, got, want[includePrior])
+			}
+		}
+	}
+
+	// the status-filtered history still skips the retitle and the deletion
+	for name, backend := range map[string]interface {
+		AllFileVersionsSince(string, time.Time, bool) (map[string][]types.FileVersion, error)
+	}{"shell": sh, "gogit": gg} {
+		result, err := backend.AllFileVersionsSince(pattern, since, false)
+		if err != nil {
+			t.Fatalf("%s.AllFileVersionsSince: %v", name, err)
+		}
+		if got := sortedVersionKeys(result); !reflect.DeepEqual(got, []string{"tikis/tiki-001.md", "tikis/tiki-003.md"}) {
+			t.Errorf("%s.AllFileVersionsSince files = %v", name, got)
+		}
+		if n := len(result["tikis/tiki-003.md"]); n != 1 {
+			t.Errorf("%s.AllFileVersionsSince tiki-003 versions = %d, want 1", name, n)
+		}
+	}
+}
+
 func TestParity_Init(t *testing.T) {
 	requireShellGit(t)
 
@@ -469,12 +588,3 @@ func sortedVersionKeys(m map[string][]types.FileVersion) []string {
 	sort.Strings(keys)
 	return keys
 }
-
-func sortVersions(v []types.FileVersion) {
-	sort.SliceStable(v, func(i, j int) bool {
-		if v[i].When.Equal(v[j].When) {
-			return v[i].Hash < v[j].Hash
-		}
-		return v[i].When.Before(v[j].When)
-	})
-}
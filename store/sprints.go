@@ -0,0 +1,185 @@
+package store
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// Sprints returns every sprint of cfg sorted by start date: the ones listed
+// in workflow.yaml plus, when cfg.Tikis is set, one per sprint tiki. A sprint
+// tiki without both dates, or whose title names a listed sprint, is skipped.
+func Sprints(tikis []*tikipkg.Tiki, cfg config.SprintConfig) config.SprintList {
+	sprints := append([]config.Sprint(nil), cfg.List...)
+	if src := cfg.Tikis; src != nil {
+		listed := make(map[string]bool, len(cfg.List))
+		for _, s := range cfg.List {
+			listed[s.Name] = true
+		}
+		for _, tk := range tikis {
+			if typ, _, _ := tk.StringField("type"); typ != src.Type {
+				continue
+			}
+			name := strings.TrimSpace(tk.Title())
+			start, okStart, _ := tk.TimeField(src.StartField)
+			end, okEnd, _ := tk.TimeField(src.EndField)
+			if name == "" || listed[name] || !okStart || !okEnd || end.Before(start) {
+				continue
+			}
+			s := config.Sprint{Name: name, Start: start, End: end, ID: tk.ID()}
+			if src.CapacityField != "" {
+				s.Capacity, _ = Points(tk, src.CapacityField)
+			}
+			listed[name] = true
+			sprints = append(sprints, s)
+		}
+	}
+	return config.SortSprints(sprints)
+}
+
+// Points reads a numeric estimate from tk's field: an integer, a number or
+// an enum whose values are numerals (e.g. "1", "3", "7").
+func Points(tk *tikipkg.Tiki, field string) (float64, bool) {
+	v, ok := tk.Get(field)
+	if !ok {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// FormatPoints renders a point total without a trailing ".0".
+func FormatPoints(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// SprintPlan is one sprint with the tikis committed to it. Committed sums
+// the points of every committed tiki and Completed those of the finished
+// ones; tikis without an estimate count as zero.
+type SprintPlan struct {
+	Sprint    config.Sprint
+	Committed float64
+	Completed float64
+	Done      []*tikipkg.Tiki
+	Open      []*tikipkg.Tiki
+}
+
+// Tikis returns the finished tikis followed by the open ones.
+func (p SprintPlan) Tikis() []*tikipkg.Tiki {
+	return append(append([]*tikipkg.Tiki(nil), p.Done...), p.Open...)
+}
+
+// PlanSprints groups tikis by the sprint named in cfg.Field and totals their
+// points, one plan per sprint in sprint order. Tikis naming an unknown
+// sprint are left out. Within a plan, tikis are ordered by id.
+func PlanSprints(tikis []*tikipkg.Tiki, cfg config.SprintConfig, sprints config.SprintList) []SprintPlan {
+	plans := make([]SprintPlan, len(sprints))
+	index := make(map[string]int, len(sprints))
+	for i, s := range sprints {
+		plans[i].Sprint = s
+		index[s.Name] = i
+	}
+	sorted := append([]*tikipkg.Tiki(nil), tikis...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID() < sorted[j].ID() })
+	for _, tk := range sorted {
+		name, _, _ := tk.StringField(cfg.Field)
+		i, ok := index[name]
+		if !ok {
+			continue
+		}
+		points, _ := Points(tk, cfg.PointsField)
+		plans[i].Committed += points
+		status, _, _ := tk.StringField(cfg.StatusField)
+		if cfg.IsDone(status) {
+			plans[i].Completed += points
+			plans[i].Done = append(plans[i].Done, tk)
+		} else {
+			plans[i].Open = append(plans[i].Open, tk)
+		}
+	}
+	return plans
+}
+
+// SprintIndex keeps the sprint list over a store current and answers the
+// derived currentSprint field. The list is dropped on every store change and
+// rebuilt on the next read, since sprint tikis may come and go.
+type SprintIndex struct {
+	store      ReadStore
+	cfg        config.SprintConfig
+	now        func() time.Time
+	mu         sync.Mutex
+	sprints    config.SprintList
+	built      bool
+	listenerID int
+}
+
+// NewSprintIndex creates an index over s and subscribes it to changes.
+func NewSprintIndex(s ReadStore, cfg config.SprintConfig) *SprintIndex {
+	x := &SprintIndex{store: s, cfg: cfg, now: time.Now}
+	x.listenerID = s.AddListener(x.invalidate)
+	return x
+}
+
+// InstallSprintIndex creates an index over s and installs it as the
+// resolver for the derived currentSprint field.
+func InstallSprintIndex(s ReadStore, cfg config.SprintConfig) *SprintIndex {
+	x := NewSprintIndex(s, cfg)
+	tikipkg.SetDerivedResolver([]string{workflow.FieldCurrentSprint}, x.Resolve)
+	return x
+}
+
+// Config returns the sprints section the index was built from.
+func (x *SprintIndex) Config() config.SprintConfig { return x.cfg }
+
+// Sprints returns the current sprint list, rebuilding it after a store
+// change.
+func (x *SprintIndex) Sprints() config.SprintList {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if !x.built {
+		x.sprints = Sprints(x.store.GetAllTikis(), x.cfg)
+		x.built = true
+	}
+	return x.sprints
+}
+
+// Resolve answers currentSprint: the name of the sprint containing today,
+// the same for every tiki. It is unset between sprints.
+func (x *SprintIndex) Resolve(_ *tikipkg.Tiki, name string) (interface{}, bool) {
+	if name != workflow.FieldCurrentSprint {
+		return nil, false
+	}
+	s, ok := x.Sprints().Current(x.now())
+	if !ok {
+		return nil, false
+	}
+	return s.Name, true
+}
+
+// Close unsubscribes the index from the store.
+func (x *SprintIndex) Close() {
+	x.store.RemoveListener(x.listenerID)
+}
+
+func (x *SprintIndex) invalidate() {
+	x.mu.Lock()
+	x.sprints = nil
+	x.built = false
+	x.mu.Unlock()
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func testSprintConfig() config.SprintConfig {
+	return config.SprintConfig{
+		Field:       "sprint",
+		PointsField: "points",
+		DoneStatus:  config.DoneStatus{StatusField: "status", Done: []string{"done"}},
+		List: []config.Sprint{
+			{Name: "S1", Start: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC), Capacity: 10},
+		},
+		Tikis: &config.SprintTikiSource{Type: "sprint", StartField: "start", EndField: "due", CapacityField: "points"},
+	}
+}
+
+func newSprintTiki(id, status, sprint string, points interface{}) *tikipkg.Tiki {
+	tk := newWorkflowTiki(id, id, status, nil, nil)
+	if sprint != "" {
+		tk.Set("sprint", sprint)
+	}
+	if points != nil {
+		tk.Set("points", points)
+	}
+	return tk
+}
+
+// sprintFixture has S1 from the workflow and S2 as a sprint tiki.
+func sprintFixture() []*tikipkg.Tiki {
+	s2 := tikipkg.New()
+	s2.SetID("SPR002")
+	s2.SetTitle("S2")
+	s2.Set("type", "sprint")
+	s2.Set("start", time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC))
+	s2.Set("due", "2026-01-30")
+	s2.Set("points", 8)
+	return []*tikipkg.Tiki{
+		s2,
+		newSprintTiki("AAA001", "done", "S1", "3"),
+		newSprintTiki("BBB001", "ready", "S1", 7),
+		newSprintTiki("CCC001", "ready", "S1", nil),
+		newSprintTiki("DDD001", "ready", "S2", 2.5),
+		newSprintTiki("EEE001", "ready", "S9", 5),
+	}
+}
+
+func TestSprints_MergesListedAndSprintTikis(t *testing.T) {
+	sprints := Sprints(sprintFixture(), testSprintConfig())
+	if len(sprints) != 2 || sprints[0].Name != "S1" || sprints[1].Name != "S2" {
+		t.Fatalf("sprints = %+v, want S1 then S2", sprints)
+	}
+	if s := sprints[1]; s.ID != "SPR002" || s.Capacity != 8 || !s.End.Equal(time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("sprint tiki = %+v", s)
+	}
+}
+
+func TestSprints_SkipsIncompleteAndShadowedSprintTikis(t *testing.T) {
+	noEnd := tikipkg.New()
+	noEnd.SetID("SPR003")
+	noEnd.SetTitle("S3")
+	noEnd.Set("type", "sprint")
+	noEnd.Set("start", time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC))
+	shadow := tikipkg.New()
+	shadow.SetID("SPR001")
+	shadow.SetTitle("S1")
+	shadow.Set("type", "sprint")
+	shadow.Set("start", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+	shadow.Set("due", time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC))
+
+	sprints := Sprints([]*tikipkg.Tiki{noEnd, shadow}, testSprintConfig())
+	if len(sprints) != 1 || sprints[0].ID != "" {
+		t.Fatalf("sprints = %+v, want only the listed S1", sprints)
+	}
+}
+
+func TestPlanSprints_TotalsCommittedAndCompleted(t *testing.T) {
+	tikis := sprintFixture()
+	cfg := testSprintConfig()
+	plans := PlanSprints(tikis, cfg, Sprints(tikis, cfg))
+
+	s1 := plans[0]
+	if s1.Committed != 10 || s1.Completed != 3 {
+		t.Errorf("S1 committed/completed = %v/%v, want 10/3", s1.Committed, s1.Completed)
+	}
+	if len(s1.Done) != 1 || len(s1.Open) != 2 || s1.Open[0].ID() != "BBB001" {
+		t.Errorf("S1 done/open = %d/%d", len(s1.Done), len(s1.Open))
+	}
+	if s2 := plans[1]; s2.Committed != 2.5 || len(s2.Open) != 1 {
+		t.Errorf("S2 = %+v", s2)
+	}
+}
+
+func TestSprintIndex_ResolvesCurrentSprint(t *testing.T) {
+	s := NewInMemoryStore()
+	for _, tk := range sprintFixture() {
+		if err := s.CreateTiki(tk); err != nil {
+			t.Fatalf("CreateTiki: %v", err)
+		}
+	}
+	x := NewSprintIndex(s, testSprintConfig())
+	defer x.Close()
+	tk := s.GetTiki("AAA001")
+
+	x.now = func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.Local) }
+	if v, ok := x.Resolve(tk, workflow.FieldCurrentSprint); !ok || v != "S2" {
+		t.Errorf("currentSprint = %v %v, want S2", v, ok)
+	}
+
+	// moving the sprint tiki's dates is picked up on the next read
+	s2 := s.GetTiki("SPR002").Clone()
+	s2.Set("start", time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC))
+	if err := s.UpdateTiki(s2); err != nil {
+		t.Fatalf("UpdateTiki: %v", err)
+	}
+	if v, ok := x.Resolve(tk, workflow.FieldCurrentSprint); ok {
+		t.Errorf("currentSprint between sprints = %v, want unset", v)
+	}
+}
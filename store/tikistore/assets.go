@@ -0,0 +1,78 @@
+package tikistore
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/boolean-maybe/tiki/document"
+)
+
+// removeAssetsLocked deletes the attachment folder of a document that was
+// just deleted from path. A missing folder is the common case and is not an
+// error; a failed removal is logged but never resurrects the document.
+//
+// Caller must hold s.mu lock.
+func (s *TikiStore) removeAssetsLocked(path, id string) {
+	dir := document.AssetDir(path, id)
+	if _, err := os.Stat(dir); err != nil {
+		return
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		slog.Error("failed to remove attachments of deleted tiki", "tiki_id", id, "dir", dir, "error", err)
+		return
+	}
+	pruneEmptyAssetRoot(dir)
+	slog.Debug("removed attachments of deleted tiki", "tiki_id", id, "dir", dir)
+}
+
+// relocateAssetsLocked follows documents that moved to another directory
+// between two loads. previous maps ids to the paths they were loaded from
+// before the reload.
+//
+// Caller must hold s.mu lock.
+func (s *TikiStore) relocateAssetsLocked(previous map[string]string) {
+	for id, tk := range s.tikis {
+		s.moveAssetsLocked(id, previous[id], tk.Path())
+	}
+}
+
+// moveAssetsLocked moves the attachment folder of a document whose markdown
+// file moved from oldPath to newPath into another directory, so the relative
+// attachment links keep resolving. A folder already present at the
+// destination is left alone rather than merged.
+//
+// Caller must hold s.mu lock.
+func (s *TikiStore) moveAssetsLocked(id, oldPath, newPath string) {
+	if oldPath == "" || newPath == "" || filepath.Dir(oldPath) == filepath.Dir(newPath) {
+		return
+	}
+	from := document.AssetDir(oldPath, id)
+	to := document.AssetDir(newPath, id)
+	if _, err := os.Stat(from); err != nil {
+		return
+	}
+	if _, err := os.Stat(to); err == nil {
+		slog.Warn("attachment folder already exists at destination, leaving source in place",
+			"tiki_id", id, "from", from, "to", to)
+		return
+	}
+	//nolint:gosec // G301: 0755 matches the document directory permissions
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		slog.Error("failed to create attachment folder for moved tiki", "tiki_id", id, "dir", to, "error", err)
+		return
+	}
+	if err := os.Rename(from, to); err != nil {
+		slog.Error("failed to move attachments of moved tiki", "tiki_id", id, "from", from, "to", to, "error", err)
+		return
+	}
+	pruneEmptyAssetRoot(from)
+	slog.Info("moved attachments with tiki", "tiki_id", id, "from", from, "to", to)
+}
+
+// pruneEmptyAssetRoot removes the `.assets` directory that contained
+// assetDir once its last per-document folder is gone. os.Remove refuses
+// non-empty directories, so other documents' attachments are never touched.
+func pruneEmptyAssetRoot(assetDir string) {
+	_ = os.Remove(filepath.Dir(assetDir))
+}
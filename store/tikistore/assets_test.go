@@ -0,0 +1,109 @@
+package tikistore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/document"
+)
+
+func writeAsset(t *testing.T, docPath, id, name string) string {
+	t.Helper()
+	dir := document.AssetDir(docPath, id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestDeleteTiki_RemovesAssets(t *testing.T) {
+	s := newSlugTestStore(t)
+	if err := s.CreateTiki(newTiki("ABC123", "Crash on save")); err != nil {
+		t.Fatal(err)
+	}
+	docPath := s.PathForID("ABC123")
+	asset := writeAsset(t, docPath, "ABC123", "shot.png")
+
+	s.DeleteTiki("ABC123")
+
+	if _, err := os.Stat(asset); !os.IsNotExist(err) {
+		t.Fatalf("asset should be removed, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(s.dir, document.AssetDirName)); !os.IsNotExist(err) {
+		t.Errorf("empty .assets root should be pruned, stat err = %v", err)
+	}
+}
+
+func TestDeleteTiki_KeepsOtherTikisAssets(t *testing.T) {
+	s := newSlugTestStore(t)
+	for _, tk := range []struct{ id, title string }{{"ABC123", "One"}, {"DEF456", "Two"}} {
+		if err := s.CreateTiki(newTiki(tk.id, tk.title)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeAsset(t, s.PathForID("ABC123"), "ABC123", "a.png")
+	other := writeAsset(t, s.PathForID("DEF456"), "DEF456", "b.png")
+
+	s.DeleteTiki("ABC123")
+
+	if _, err := os.Stat(other); err != nil {
+		t.Fatalf("other tiki's asset must survive: %v", err)
+	}
+}
+
+func TestReload_MovesAssetsWithTiki(t *testing.T) {
+	s := newSlugTestStore(t)
+	if err := s.CreateTiki(newTiki("ABC123", "Crash on save")); err != nil {
+		t.Fatal(err)
+	}
+	oldPath := s.PathForID("ABC123")
+	writeAsset(t, oldPath, "ABC123", "shot.png")
+
+	// move the markdown file externally, then reload
+	newDir := filepath.Join(s.dir, "bugs")
+	if err := os.MkdirAll(newDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	newPath := filepath.Join(newDir, filepath.Base(oldPath))
+	if err := os.Rename(oldPath, newPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(newDir, document.AssetDirName, "ABC123", "shot.png")); err != nil {
+		t.Fatalf("asset should follow the tiki: %v", err)
+	}
+	if _, err := os.Stat(document.AssetDir(oldPath, "ABC123")); !os.IsNotExist(err) {
+		t.Errorf("old asset folder should be gone, stat err = %v", err)
+	}
+}
+
+func TestUpdateTiki_MovesAssetsWithNewPath(t *testing.T) {
+	s := newSlugTestStore(t)
+	if err := s.CreateTiki(newTiki("ABC123", "Crash on save")); err != nil {
+		t.Fatal(err)
+	}
+	oldPath := s.PathForID("ABC123")
+	writeAsset(t, oldPath, "ABC123", "shot.png")
+
+	moved := s.GetTiki("ABC123").Clone()
+	newPath := filepath.Join(s.dir, "bugs", filepath.Base(oldPath))
+	moved.SetPath(newPath)
+	if err := s.UpdateTiki(moved); err != nil {
+		t.Fatalf("UpdateTiki: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(s.dir, "bugs", document.AssetDirName, "ABC123", "shot.png")); err != nil {
+		t.Fatalf("asset should follow the saved tiki: %v", err)
+	}
+	if _, err := os.Stat(document.AssetDir(oldPath, "ABC123")); !os.IsNotExist(err) {
+		t.Errorf("old asset folder should be gone, stat err = %v", err)
+	}
+}
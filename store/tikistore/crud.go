@@ -149,6 +149,8 @@ func (s *TikiStore) updateTikiCore(tk *tikipkg.Tiki, _ bool) error {
 		slog.Error("failed to save updated tiki", "tiki_id", tk.ID(), "error", err)
 		return fmt.Errorf("failed to save tiki: %w", err)
 	}
+	// a save to another directory takes the attachments along
+	s.moveAssetsLocked(tk.ID(), old.Path(), tk.Path())
 	return nil
 }
 
@@ -188,20 +190,28 @@ func (s *TikiStore) deleteTikiLocked(id string) bool {
 		slog.Error("file deletion failed, tiki preserved in memory", "tiki_id", id, "path", path, "error", err)
 		return false
 	}
+	s.removeAssetsLocked(path, id)
 
 	delete(s.tikis, id)
 	return true
 }
 
 // validateTikiIDListFieldsLocked checks that references in every workflow-
-// declared tikiIdList field resolve to loaded documents. References may target
-// plain or workflow documents. Caller must hold s.mu lock.
+// declared tikiId and tikiIdList field resolve to loaded documents. References
+// may target plain or workflow documents. Caller must hold s.mu lock.
 func (s *TikiStore) validateTikiIDListFieldsLocked(tk *tikipkg.Tiki) error {
 	for _, field := range workflow.WorkflowFields() {
-		if field.Type != workflow.TypeListRef {
+		var references []string
+		switch field.Type {
+		case workflow.TypeListRef:
+			references, _, _ = tk.StringSliceField(field.Name)
+		case workflow.TypeRef:
+			if ref, _, _ := tk.StringField(field.Name); ref != "" {
+				references = []string{ref}
+			}
+		default:
 			continue
 		}
-		references, _, _ := tk.StringSliceField(field.Name)
 		for _, referenceID := range references {
 			normalized := normalizeTikiID(referenceID)
 			if !idfmt.IsValidID(normalized) {
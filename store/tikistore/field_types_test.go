@@ -0,0 +1,120 @@
+package tikistore
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/internal/teststatuses"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func initLinkAndMeasureFields(t *testing.T) {
+	t.Helper()
+	if err := teststatuses.InitWith([]workflow.FieldDef{
+		{Name: "epic", Type: workflow.TypeRef},
+		{Name: "components", Type: workflow.TypeEnumList, EnumValues: []workflow.EnumValue{
+			{Value: "api"}, {Value: "ui"}, {Value: "docs"},
+		}},
+		{Name: "spec", Type: workflow.TypeURL},
+		{Name: "cost", Type: workflow.TypeNumber, Unit: "h"},
+	}); err != nil {
+		t.Fatalf("register fields: %v", err)
+	}
+	t.Cleanup(teststatuses.Init)
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestLinkAndMeasureFields_RoundTrip(t *testing.T) {
+	initLinkAndMeasureFields(t)
+	dir := t.TempDir()
+	s, err := NewTikiStore(dir)
+	if err != nil {
+		t.Fatalf("NewTikiStore: %v", err)
+	}
+
+	epic := tikipkg.New()
+	epic.SetID("EPIC01")
+	epic.SetTitle("epic")
+	if err := s.CreateTiki(epic); err != nil {
+		t.Fatalf("CreateTiki epic: %v", err)
+	}
+
+	tk := tikipkg.New()
+	tk.SetID("STORY1")
+	tk.SetTitle("story")
+	tk.Set("epic", "EPIC01")
+	tk.Set("components", []string{"docs", "api"})
+	tk.Set("spec", "https://example.com/spec")
+	tk.Set("cost", "2.5") // the shape a ruki assignment leaves behind
+	if err := s.CreateTiki(tk); err != nil {
+		t.Fatalf("CreateTiki: %v", err)
+	}
+
+	raw, err := os.ReadFile(s.GetTiki("STORY1").Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"cost: 2.5\n", "components:\n    - api\n    - docs\n", "epic: EPIC01\n"} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("file lacks %q:\n%s", want, raw)
+		}
+	}
+
+	if err := s.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	got := s.GetTiki("STORY1")
+	if v, _ := got.Get("cost"); v != 2.5 {
+		t.Errorf("cost = %#v, want 2.5", v)
+	}
+	if v, _ := got.Get("components"); !reflect.DeepEqual(v, []string{"api", "docs"}) {
+		t.Errorf("components = %#v", v)
+	}
+	if len(got.StaleKeys()) != 0 {
+		t.Errorf("unexpected stale keys: %v", got.StaleKeys())
+	}
+}
+
+func TestTikiIDFieldRejectsMissingTarget(t *testing.T) {
+	initLinkAndMeasureFields(t)
+	s, err := NewTikiStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewTikiStore: %v", err)
+	}
+	tk := tikipkg.New()
+	tk.SetID("ORPHAN")
+	tk.SetTitle("orphan")
+	tk.Set("epic", "ZZZZZZ")
+	err = s.CreateTiki(tk)
+	if err == nil || !strings.Contains(err.Error(), "epic references non-existent document") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestCoerceCustomValue_LinkAndMeasureTypes(t *testing.T) {
+	components := workflow.FieldDef{Name: "components", Type: workflow.TypeEnumList,
+		EnumValues: []workflow.EnumValue{{Value: "api"}, {Value: "ui"}}}
+	if v, err := coerceCustomValue(components, []interface{}{"UI", "api", "ui"}); err != nil ||
+		!reflect.DeepEqual(v, []string{"ui", "api"}) {
+		t.Errorf("enumList = %#v, %v", v, err)
+	}
+	if _, err := coerceCustomValue(components, []interface{}{"db"}); err == nil {
+		t.Error("enumList accepted an undeclared value")
+	}
+
+	cost := workflow.FieldDef{Name: "cost", Type: workflow.TypeNumber}
+	if v, err := coerceCustomValue(cost, 3); err != nil || v != 3.0 {
+		t.Errorf("number from int = %#v, %v", v, err)
+	}
+	if _, err := coerceCustomValue(cost, "lots"); err == nil {
+		t.Error("number accepted text")
+	}
+
+	spec := workflow.FieldDef{Name: "spec", Type: workflow.TypeURL}
+	if _, err := coerceCustomValue(spec, "not a url"); err == nil {
+		t.Error("url accepted a value without a scheme")
+	}
+}
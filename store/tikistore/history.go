@@ -0,0 +1,68 @@
+package tikistore
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+// TikiVersionsSince implements store.HistoryReader from git. Every root is
+// read with one batch call covering every commit that changed a tiki file;
+// versions that no longer parse as tikis are skipped, and a root whose
+// history cannot be read is logged and left out, the same way the loader
+// treats git failures. A commit deleting a file is reported as a deleted
+// version of the tiki last read from it.
+func (s *TikiStore) TikiVersionsSince(since time.Time) ([]store.TikiVersion, error) {
+	var versions []store.TikiVersion
+	for _, root := range s.rootList() {
+		if root.gitUtil == nil {
+			continue
+		}
+		byPath, err := root.gitUtil.AllFileHistorySince(root.dir, since, true)
+		if err != nil {
+			slog.Warn("failed to read tiki history", "dir", root.dir, "error", err)
+			continue
+		}
+		for path, fileVersions := range byPath {
+			var last *tikipkg.Tiki
+			for _, fv := range fileVersions {
+				author := fv.Author
+				if author == "" {
+					author = fv.Email
+				}
+				if fv.Deleted {
+					if last != nil {
+						versions = append(versions, store.TikiVersion{
+							Tiki:    last,
+							Author:  author,
+							When:    fv.When,
+							Hash:    fv.Hash,
+							Deleted: true,
+						})
+					}
+					last = nil
+					continue
+				}
+				parsed, err := loadTikiFromBytes(path, []byte(fv.Content))
+				if err != nil {
+					if !errors.Is(err, errSkipNoID) {
+						slog.Debug("skipping unparsable tiki version", "file", path, "commit", fv.Hash, "error", err)
+					}
+					continue
+				}
+				s.unqualifyRefs(parsed.t)
+				last = parsed.t
+				versions = append(versions, store.TikiVersion{
+					Tiki:   parsed.t,
+					Author: author,
+					When:   fv.When,
+					Hash:   fv.Hash,
+				})
+			}
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,113 @@
+package tikistore
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	gitops "github.com/boolean-maybe/tiki/store/internal/git"
+)
+
+// TestTikiVersionsSince commits a tiki twice and checks that both versions
+// come back parsed, attributed to their authors.
+func TestTikiVersionsSince(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "testuser")
+
+	path := filepath.Join(dir, "abc123.md")
+	commit := func(status, author string) {
+		content := "---\nid: ABC123\ntitle: history\ntype: story\nstatus: " + status + "\npriority: medium\n---\nbody\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		runGit(t, dir, "add", "abc123.md")
+		runGit(t, dir, "-c", "user.name="+author, "commit", "-m", "status "+status)
+	}
+	commit("inbox", "alice")
+	commit("ready", "bob")
+
+	s, err := NewTikiStore(dir)
+	if err != nil {
+		t.Fatalf("NewTikiStore: %v", err)
+	}
+	gu, err := gitops.NewGitOps(dir)
+	if err != nil {
+		t.Fatalf("NewGitOps: %v", err)
+	}
+	s.gitUtil = gu
+
+	versions, err := s.TikiVersionsSince(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("TikiVersionsSince: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("versions = %+v, want 2", versions)
+	}
+	var authors, statuses []string
+	for _, v := range versions {
+		if v.Tiki.ID() != "ABC123" || v.Hash == "" {
+			t.Errorf("version = %+v", v)
+		}
+		status, _, _ := v.Tiki.StringField("status")
+		authors = append(authors, v.Author)
+		statuses = append(statuses, status)
+	}
+	// commits within the same second may sort either way
+	slices.Sort(authors)
+	slices.Sort(statuses)
+	if !slices.Equal(authors, []string{"alice", "bob"}) || !slices.Equal(statuses, []string{"inbox", "ready"}) {
+		t.Errorf("authors = %v, statuses = %v", authors, statuses)
+	}
+}
+
+// TestTikiVersionsSince_EditsAndDeletions checks that commits leaving the
+// status alone are read, and that a git rm comes back as a deleted version of
+// the tiki last committed.
+func TestTikiVersionsSince_EditsAndDeletions(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "testuser")
+
+	path := filepath.Join(dir, "abc123.md")
+	commit := func(title, author string) {
+		content := "---\nid: ABC123\ntitle: " + title + "\ntype: story\nstatus: ready\npriority: medium\n---\nbody\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		runGit(t, dir, "add", "abc123.md")
+		runGit(t, dir, "-c", "user.name="+author, "commit", "-m", "title "+title)
+	}
+	commit("first", "alice")
+	commit("second", "bob")
+	runGit(t, dir, "rm", "-q", "abc123.md")
+	runGit(t, dir, "-c", "user.name=carol", "commit", "-m", "drop")
+
+	s, err := NewTikiStore(dir)
+	if err != nil {
+		t.Fatalf("NewTikiStore: %v", err)
+	}
+	gu, err := gitops.NewGitOps(dir)
+	if err != nil {
+		t.Fatalf("NewGitOps: %v", err)
+	}
+	s.gitUtil = gu
+
+	versions, err := s.TikiVersionsSince(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("TikiVersionsSince: %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("versions = %+v, want 3", versions)
+	}
+	if v := versions[1]; v.Author != "bob" || v.Tiki.Title() != "second" || v.Deleted {
+		t.Errorf("retitle = %+v", v)
+	}
+	if v := versions[2]; v.Author != "carol" || !v.Deleted || v.Tiki.ID() != "ABC123" || v.Tiki.Title() != "second" {
+		t.Errorf("deletion = %+v", v)
+	}
+}
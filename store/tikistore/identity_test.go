@@ -45,6 +45,9 @@ func (f *fakeGitOps) FileVersionsSince(_ string, _ time.Time, _ bool) ([]git.Fil
 func (f *fakeGitOps) AllFileVersionsSince(_ string, _ time.Time, _ bool) (map[string][]git.FileVersion, error) {
 	return nil, nil
 }
+func (f *fakeGitOps) AllFileHistorySince(_ string, _ time.Time, _ bool) (map[string][]git.FileVersion, error) {
+	return nil, nil
+}
 func (f *fakeGitOps) AllUsers() ([]string, error) { return f.users, f.usersErr }
 
 // isolateConfig puts the test in a clean config sandbox:
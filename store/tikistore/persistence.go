@@ -16,9 +16,11 @@ import (
 	"github.com/boolean-maybe/tiki/store/internal/git"
 	"github.com/boolean-maybe/tiki/tiki"
 	"github.com/boolean-maybe/tiki/workflow"
+	valuepkg "github.com/boolean-maybe/tiki/workflow/value"
 )
 
-// loadLocked reads all tiki files from the document root, scanning
+// loadLocked reads all tiki files from the document root (every mounted
+// root, with workspaces configured), scanning
 // recursively so documents organized in subdirectories load alongside
 // flat ones. Phase 2 generalizes the store from a single flat tiki
 // directory to `.doc/**/*.md`; excluded files (config.yaml, workflow.yaml,
@@ -27,17 +29,36 @@ import (
 //
 // Caller must hold s.mu lock.
 func (s *TikiStore) loadLocked() error {
-	slog.Debug("loading documents from directory", "dir", s.dir)
+	// reset diagnostics for this load cycle so callers see a fresh report.
+	s.diagnostics = newLoadDiagnostics()
+	idIndex := document.NewIndex()
+	// with workspaces mounted every root loads into the one id space, so a
+	// duplicate id across repos is reported like a duplicate within one.
+	for _, root := range s.rootList() {
+		if err := s.loadRootLocked(root, idIndex); err != nil {
+			return err
+		}
+	}
+	slog.Info("finished loading tikis", "num_tikis", len(s.tikis), "rejections", len(s.diagnostics.Rejections()))
+	return nil
+}
+
+// loadRootLocked loads the documents of one root into s.tikis, using the
+// root's own git history for authors and commit times.
+//
+// Caller must hold s.mu lock.
+func (s *TikiStore) loadRootLocked(root *workspaceRoot, idIndex *document.Index) error {
+	slog.Debug("loading documents from directory", "dir", root.dir, "workspace", root.prefix)
 	// create directory if it doesn't exist
 	//nolint:gosec // G301: 0755 is appropriate for document storage directory
-	if err := os.MkdirAll(s.dir, 0755); err != nil {
-		slog.Error("failed to create document directory", "dir", s.dir, "error", err)
+	if err := os.MkdirAll(root.dir, 0755); err != nil {
+		slog.Error("failed to create document directory", "dir", root.dir, "error", err)
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
-	docPaths, err := s.collectDocumentPaths()
+	docPaths, err := document.WalkDocuments(root.dir)
 	if err != nil {
-		slog.Error("failed to walk document directory", "dir", s.dir, "error", err)
+		slog.Error("failed to walk document directory", "dir", root.dir, "error", err)
 		return fmt.Errorf("walking directory: %w", err)
 	}
 
@@ -48,23 +69,20 @@ func (s *TikiStore) loadLocked() error {
 	// nested layouts identically.
 	var authorMap map[string]*git.AuthorInfo
 	var lastCommitMap map[string]time.Time
-	if s.gitUtil != nil {
-		if authors, err := s.gitUtil.AllAuthors(s.dir); err == nil {
+	if root.gitUtil != nil {
+		if authors, err := root.gitUtil.AllAuthors(root.dir); err == nil {
 			authorMap = authors
 		} else {
 			slog.Warn("failed to batch fetch authors", "error", err)
 		}
 
-		if lastCommits, err := s.gitUtil.AllLastCommitTimes(s.dir); err == nil {
+		if lastCommits, err := root.gitUtil.AllLastCommitTimes(root.dir); err == nil {
 			lastCommitMap = lastCommits
 		} else {
 			slog.Warn("failed to batch fetch last commit times", "error", err)
 		}
 	}
 
-	// reset diagnostics for this load cycle so callers see a fresh report.
-	s.diagnostics = newLoadDiagnostics()
-	idIndex := document.NewIndex()
 	for _, filePath := range docPaths {
 		tk, err := s.loadTikiFile(filePath, authorMap, lastCommitMap)
 		if err != nil {
@@ -96,7 +114,6 @@ func (s *TikiStore) loadLocked() error {
 		s.tikis[tk.ID()] = tk
 		slog.Debug("loaded tiki", "tiki_id", tk.ID(), "file", filePath)
 	}
-	slog.Info("finished loading tikis", "num_tikis", len(s.tikis), "rejections", len(s.diagnostics.Rejections()))
 	return nil
 }
 
@@ -143,10 +160,14 @@ func (s *TikiStore) loadTikiFile(path string, authorMap map[string]*git.AuthorIn
 		tk.MarkStaleForPersistence(k)
 	}
 
+	// references to other workspaces are qualified on disk, bare in memory
+	s.unqualifyRefs(tk)
+
 	// compute UpdatedAt as max(file_mtime, last_git_commit_time)
+	root := s.rootForPath(absPath)
 	tk.SetUpdatedAt(info.ModTime())
 	if lastCommitMap != nil {
-		relPath := relPathForLookup(s.dir, path)
+		relPath := root.lookupKey(path)
 		if lastCommit, exists := lastCommitMap[relPath]; exists {
 			if lastCommit.After(tk.UpdatedAt()) {
 				tk.SetUpdatedAt(lastCommit)
@@ -157,7 +178,7 @@ func (s *TikiStore) loadTikiFile(path string, authorMap map[string]*git.AuthorIn
 	// Populate CreatedAt/CreatedBy from author map (already fetched in batch).
 	// CreatedBy is stored in Fields so ruki's identity-field reads work.
 	if authorMap != nil {
-		relPath := relPathForLookup(s.dir, path)
+		relPath := root.lookupKey(path)
 		if author, exists := authorMap[relPath]; exists {
 			switch {
 			case author.Name != "":
@@ -172,7 +193,7 @@ func (s *TikiStore) loadTikiFile(path string, authorMap map[string]*git.AuthorIn
 	// Fallback to file metadata when git history is not available.
 	if tk.CreatedAt().IsZero() {
 		tk.SetCreatedAt(info.ModTime())
-		if name, email, err := s.GetCurrentUser(); err == nil {
+		if name, email, err := root.currentUser(); err == nil {
 			switch {
 			case name != "":
 				tk.Set("createdBy", name)
@@ -205,6 +226,10 @@ func (s *TikiStore) Reload() error {
 	slog.Info("reloading tikis from disk")
 	start := time.Now()
 	s.mu.Lock()
+	previous := make(map[string]string, len(s.tikis))
+	for id, tk := range s.tikis {
+		previous[id] = tk.Path()
+	}
 	s.tikis = make(map[string]*tiki.Tiki)
 
 	if err := s.loadLocked(); err != nil {
@@ -212,6 +237,7 @@ func (s *TikiStore) Reload() error {
 		slog.Error("error reloading tikis from disk", "error", err)
 		return err
 	}
+	s.relocateAssetsLocked(previous)
 	s.mu.Unlock()
 
 	slog.Info("tikis reloaded successfully", "duration", time.Since(start).Round(time.Millisecond))
@@ -238,14 +264,14 @@ func (s *TikiStore) ReloadTiki(tikiID string) error {
 		filePath = s.tikiFilePath(normalizedID)
 	}
 
-	// Fetch git info for this single file
+	// Fetch git info for this single file from the repo it lives in
 	var authorMap map[string]*git.AuthorInfo
 	var lastCommitMap map[string]time.Time
-	if s.gitUtil != nil {
-		if authors, err := s.gitUtil.AllAuthors(filePath); err == nil {
+	if gitUtil := s.rootForPath(filePath).gitUtil; gitUtil != nil {
+		if authors, err := gitUtil.AllAuthors(filePath); err == nil {
 			authorMap = authors
 		}
-		if lastCommits, err := s.gitUtil.AllLastCommitTimes(filePath); err == nil {
+		if lastCommits, err := gitUtil.AllLastCommitTimes(filePath); err == nil {
 			lastCommitMap = lastCommits
 		}
 	}
@@ -337,6 +363,28 @@ func validateCustomFieldEntry(k string, v interface{}, fd workflow.FieldDef) err
 		if _, err := coerceStringList(v); err != nil {
 			return fmt.Errorf("invalid list value for %q: %w", k, err)
 		}
+	case workflow.TypeEnumList:
+		ss, err := coerceStringList(v)
+		if err != nil {
+			return fmt.Errorf("invalid list value for %q: %w", k, err)
+		}
+		for _, s := range ss {
+			if !fd.IsValidEnum(s) {
+				return fmt.Errorf("invalid enum value %q for field %q (allowed: %v)", s, k, fd.AllowedValues())
+			}
+		}
+	case workflow.TypeNumber:
+		if _, ok := valuepkg.NumberOf(v); !ok {
+			return fmt.Errorf("number field %q must be a number, got %v", k, v)
+		}
+	case workflow.TypeURL:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("url field %q must be a string, got %T", k, v)
+		}
+		if err := valuepkg.ValidateURL(s); err != nil {
+			return fmt.Errorf("field %q: %w", k, err)
+		}
 	case workflow.TypeEnum:
 		s, ok := v.(string)
 		if !ok {
@@ -384,7 +432,7 @@ func (s *TikiStore) saveTiki(tk *tiki.Tiki) error {
 		}
 	}
 
-	yamlBytes, err := marshalTikiFrontmatter(tk)
+	yamlBytes, err := marshalTikiFrontmatter(s.qualifiedForSave(tk, path))
 	if err != nil {
 		slog.Error("failed to marshal frontmatter for tiki", "tiki_id", tk.ID(), "error", err)
 		return fmt.Errorf("marshaling frontmatter: %w", err)
@@ -419,8 +467,8 @@ func (s *TikiStore) saveTiki(tk *tiki.Tiki) error {
 	if info, err := os.Stat(path); err == nil {
 		tk.LoadedMtime = info.ModTime()
 		tk.SetUpdatedAt(info.ModTime())
-		if s.gitUtil != nil {
-			if lastCommit, err := s.gitUtil.LastCommitTime(path); err == nil {
+		if gitUtil := s.rootForPath(path).gitUtil; gitUtil != nil {
+			if lastCommit, err := gitUtil.LastCommitTime(path); err == nil {
 				if lastCommit.After(tk.UpdatedAt()) {
 					tk.SetUpdatedAt(lastCommit)
 				}
@@ -457,7 +505,11 @@ func (s *TikiStore) slugFilePath(tk *tiki.Tiki) (string, error) {
 	if slug == "" {
 		return "", ErrEmptyTitleForSlug
 	}
-	candidate := filepath.Join(s.dir, slug+".md")
+	dir, err := s.creationDir(tk)
+	if err != nil {
+		return "", err
+	}
+	candidate := filepath.Join(dir, slug+".md")
 	for i := 2; ; i++ {
 		if _, err := os.Stat(candidate); os.IsNotExist(err) {
 			return candidate, nil
@@ -465,10 +517,26 @@ func (s *TikiStore) slugFilePath(tk *tiki.Tiki) (string, error) {
 		if i > maxGenerateRetries+1 {
 			return "", fmt.Errorf("failed to find free filename for slug %q after %d attempts", slug, maxGenerateRetries)
 		}
-		candidate = filepath.Join(s.dir, fmt.Sprintf("%s-%d.md", slug, i))
+		candidate = filepath.Join(dir, fmt.Sprintf("%s-%d.md", slug, i))
 	}
 }
 
+// creationDir returns the directory a brand-new tiki is written to: the tiki
+// directory, or the subfolder named by the tiki's Folder hint (set by named
+// templates). a folder that resolves outside the tiki directory is rejected.
+func (s *TikiStore) creationDir(tk *tiki.Tiki) (string, error) {
+	folder := tk.Folder()
+	if folder == "" {
+		return s.dir, nil
+	}
+	dir := filepath.Join(s.dir, folder)
+	rel, err := filepath.Rel(s.dir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(folder) {
+		return "", fmt.Errorf("folder %q is outside the tiki directory", folder)
+	}
+	return dir, nil
+}
+
 // tikiFilePath returns the id-derived path <dir>/<ID>.md. new tikis are named
 // by slugFilePath, so this is no longer the creation generator — but it remains
 // the only sensible fallback when a path must be guessed from an id alone, with
@@ -478,13 +546,6 @@ func (s *TikiStore) tikiFilePath(id string) string {
 	return filepath.Join(s.dir, id+".md")
 }
 
-// collectDocumentPaths delegates to the shared document.WalkDocuments so
-// every caller — runtime store loader, future maintenance tooling — sees
-// the same "managed document" set and cannot drift apart.
-func (s *TikiStore) collectDocumentPaths() ([]string, error) {
-	return document.WalkDocuments(s.dir)
-}
-
 // pathForTiki returns the on-disk path for an operation on tk. a loaded tiki
 // (non-empty Path) keeps its path so rename/move are preserved; a brand-new
 // tiki is named by slugFilePath. the legacy id-derived tikiFilePath is no
@@ -520,10 +581,11 @@ func extractCustomFields(fmMap map[string]interface{}) (customFields, unknownFie
 			registryChecked = true
 		}
 		fd, ok := workflow.Field(key)
-		if ok && !fd.Custom {
+		if ok && (!fd.Custom || fd.IsComputed()) {
 			// registered built-in (e.g. synthetic read-only fields like
-			// filepath). These are derived, never persisted — drop whatever
-			// was in the file so stale values don't survive a round-trip.
+			// filepath) or a computed field. These are derived, never
+			// persisted — drop whatever was in the file so stale values
+			// don't survive a round-trip.
 			slog.Debug("dropping synthetic built-in field from frontmatter", "field", key)
 			continue
 		}
@@ -641,6 +703,46 @@ func coerceCustomValue(fd workflow.FieldDef, raw interface{}) (interface{}, erro
 		}
 		return collectionutil.NormalizeRefSet(ss), nil
 
+	case workflow.TypeEnumList:
+		ss, err := coerceStringList(raw)
+		if err != nil {
+			return nil, err
+		}
+		allowed := fd.AllowedValues()
+		out := make([]string, 0, len(ss))
+		for _, s := range ss {
+			canonical := ""
+			for _, av := range allowed {
+				if strings.EqualFold(s, av) {
+					canonical = av
+					break
+				}
+			}
+			if canonical == "" {
+				return nil, fmt.Errorf("value %q not in allowed values %v", s, allowed)
+			}
+			out = append(out, canonical)
+		}
+		return collectionutil.NormalizeStringSet(out), nil
+
+	case workflow.TypeURL:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string for url, got %T", raw)
+		}
+		s = strings.TrimSpace(s)
+		if err := valuepkg.ValidateURL(s); err != nil {
+			return nil, err
+		}
+		return s, nil
+
+	case workflow.TypeNumber:
+		f, ok := valuepkg.NumberOf(raw)
+		if !ok {
+			return nil, fmt.Errorf("expected number, got %v", raw)
+		}
+		return f, nil
+
 	default:
 		return raw, nil
 	}
@@ -137,3 +137,38 @@ func TestCreateTiki_WritesSlugFilename(t *testing.T) {
 		t.Fatalf("expected fix-login-bug.md on disk: %v", err)
 	}
 }
+
+func TestSlugFilePath_TemplateFolder(t *testing.T) {
+	s := newSlugTestStore(t)
+	tk := newTiki("ABC123", "Crash On Login")
+	tk.SetFolder(filepath.Join("bugs", "triage"))
+	got, err := s.slugFilePath(tk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := filepath.Join(s.dir, "bugs", "triage", "crash-on-login.md")
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSlugFilePath_FolderEscapeRejected(t *testing.T) {
+	s := newSlugTestStore(t)
+	tk := newTiki("ABC123", "Escape")
+	tk.SetFolder(filepath.Join("..", "outside"))
+	if _, err := s.slugFilePath(tk); err == nil {
+		t.Fatal("expected folder outside the tiki directory to be rejected")
+	}
+}
+
+func TestCreateTiki_WritesIntoTemplateFolder(t *testing.T) {
+	s := newSlugTestStore(t)
+	tk := newTiki("", "Crash On Login")
+	tk.SetFolder("bugs")
+	if err := s.CreateTiki(tk); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(s.dir, "bugs", "crash-on-login.md")); err != nil {
+		t.Fatalf("expected bugs/crash-on-login.md on disk: %v", err)
+	}
+}
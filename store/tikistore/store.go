@@ -34,19 +34,14 @@ type TikiStore struct {
 	gitUtil        git.GitOps        // git utility for auto-staging modified files
 	identity       *identityResolver // resolves current Tiki identity (config→git→OS)
 	diagnostics    *LoadDiagnostics  // rejections from the most recent load/reload cycle
+	roots          []*workspaceRoot  // mounted workspaces; nil for a single-root store
 }
 
 // NewTikiStore creates a new TikiStore.
 // dir: directory containing tiki markdown files
 func NewTikiStore(dir string) (*TikiStore, error) {
 	slog.Debug("creating new TikiStore", "dir", dir)
-	s := &TikiStore{
-		dir:            dir,
-		tikis:          make(map[string]*tiki.Tiki),
-		listeners:      make(map[int]store.ChangeListener),
-		nextListenerID: 1, // Start at 1 to avoid conflict with zero-value sentinel
-		diagnostics:    newLoadDiagnostics(),
-	}
+	s := newEmptyTikiStore(dir)
 
 	// git integration is automatic and read-only: when cwd is a repo the store
 	// reads history/authors; when it is not, the git methods fail gracefully and
@@ -59,16 +54,34 @@ func NewTikiStore(dir string) (*TikiStore, error) {
 	}
 	s.identity = newIdentityResolver(s.gitUtil)
 
+	if err := s.initialLoad(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newEmptyTikiStore(dir string) *TikiStore {
+	return &TikiStore{
+		dir:            dir,
+		tikis:          make(map[string]*tiki.Tiki),
+		listeners:      make(map[int]store.ChangeListener),
+		nextListenerID: 1, // Start at 1 to avoid conflict with zero-value sentinel
+		diagnostics:    newLoadDiagnostics(),
+	}
+}
+
+// initialLoad performs the first load of a freshly constructed store.
+func (s *TikiStore) initialLoad() error {
 	s.mu.Lock()
 	if err := s.loadLocked(); err != nil {
 		s.mu.Unlock()
-		slog.Error("failed to load tikis during store initialization", "dir", dir, "error", err)
-		return nil, fmt.Errorf("loading tikis: %w", err)
+		slog.Error("failed to load tikis during store initialization", "dir", s.dir, "error", err)
+		return fmt.Errorf("loading tikis: %w", err)
 	}
 	s.mu.Unlock()
 
-	slog.Info("tikiStore initialized", "dir", dir, "num_tikis", len(s.tikis))
-	return s, nil
+	slog.Info("tikiStore initialized", "dir", s.dir, "workspaces", len(s.roots), "num_tikis", len(s.tikis))
+	return nil
 }
 
 // LoadDiagnostics returns the rejections accumulated during the most recent
@@ -134,11 +147,23 @@ func (s *TikiStore) GetGitOps() git.GitOps {
 // In git mode, merges the configured identity with git's commit-author list.
 // In no-git mode, returns the resolved identity (configured or OS user).
 func (s *TikiStore) GetAllUsers() ([]string, error) {
-	// No lock needed - identity is immutable after initialization
+	// No lock needed - identity and roots are immutable after initialization
 	if s.identity == nil {
 		return nil, nil
 	}
-	return s.identity.allUsers()
+	users, err := s.identity.allUsers()
+	if err != nil || len(s.roots) < 2 {
+		return users, err
+	}
+	// with workspaces mounted, authors of every repo are candidates
+	for _, r := range s.roots[1:] {
+		more, err := r.identity.allUsers()
+		if err != nil {
+			return nil, err
+		}
+		users = mergeUnique("", append(users, more...))
+	}
+	return users, nil
 }
 
 // ensure TikiStore implements Store
@@ -245,6 +245,22 @@ func encodeFieldByType(fd workflow.FieldDef, value interface{}) ([]byte, error)
 		sort.Strings(v)
 		return yaml.Marshal(map[string]interface{}{key: v})
 
+	case workflow.TypeEnumList:
+		ss, err := coerceStringList(value)
+		if err != nil {
+			return yaml.Marshal(map[string]interface{}{key: value})
+		}
+		return yaml.Marshal(map[string]interface{}{key: sortByDeclaration(ss, fd.AllowedValues())})
+
+	case workflow.TypeNumber:
+		// a ruki assignment leaves the decimal string in memory; store the
+		// number itself so the file holds `cost: 2.5`, not `cost: "2.5"`.
+		f, ok := valuepkg.NumberOf(value)
+		if !ok {
+			return yaml.Marshal(map[string]interface{}{key: value})
+		}
+		return yaml.Marshal(map[string]interface{}{key: f})
+
 	case workflow.TypeDate:
 		tv, ok := coerceTimeForYAML(value)
 		if !ok {
@@ -271,11 +287,34 @@ func encodeFieldByType(fd workflow.FieldDef, value interface{}) ([]byte, error)
 		return yaml.Marshal(map[string]interface{}{key: n})
 	}
 
-	// All other types (string, bool, enum, recurrence, ref, id) pass through
-	// yaml.Marshal verbatim. Validators upstream guarantee shape correctness.
+	// All other types (string, bool, enum, recurrence, ref, id, url) pass
+	// through yaml.Marshal verbatim. Validators upstream guarantee shape
+	// correctness.
 	return yaml.Marshal(map[string]interface{}{key: value})
 }
 
+// sortByDeclaration orders enumList keys the way the workflow declares them,
+// so the file diff stays stable however the values were picked. Keys that
+// are not declared (stale values) keep their relative order at the end.
+func sortByDeclaration(keys, declared []string) []string {
+	rank := make(map[string]int, len(declared))
+	for i, k := range declared {
+		rank[k] = i
+	}
+	out := collectionutil.NormalizeStringSet(keys)
+	sort.SliceStable(out, func(i, j int) bool {
+		ri, iok := rank[out[i]]
+		rj, jok := rank[out[j]]
+		switch {
+		case iok && jok:
+			return ri < rj
+		default:
+			return iok && !jok
+		}
+	})
+	return out
+}
+
 // coerceIntForYAML accepts the numeric shapes yaml.v3 emits, plus plain int.
 func coerceIntForYAML(v interface{}) (int, bool) {
 	switch n := v.(type) {
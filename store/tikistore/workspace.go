@@ -0,0 +1,234 @@
+package tikistore
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/document"
+	"github.com/boolean-maybe/tiki/store/internal/git"
+	"github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// workspaceRoot is one document root mounted into a multi-workspace store.
+// Each root keeps its own git utility and identity resolver, so history,
+// authors and attribution come from the repo the document lives in.
+type workspaceRoot struct {
+	prefix   string
+	dir      string
+	repoDir  string // git top-level containing dir; empty when unknown
+	gitUtil  git.GitOps
+	identity *identityResolver
+}
+
+// NewWorkspaceTikiStore creates a TikiStore over several document roots.
+// Tiki ids stay bare and unique across all of them; the first workspace is
+// the home workspace, where new tikis are created. References between
+// workspaces are written to disk qualified with the target's prefix
+// (`FE:ABC123`) so each repo on its own still shows where a link points.
+func NewWorkspaceTikiStore(workspaces []config.Workspace) (*TikiStore, error) {
+	if len(workspaces) == 0 {
+		return nil, errors.New("no workspaces configured")
+	}
+	roots := make([]*workspaceRoot, 0, len(workspaces))
+	for _, ws := range workspaces {
+		root := &workspaceRoot{prefix: ws.Prefix, dir: ws.Dir, repoDir: findRepoDir(ws.Dir)}
+		if gitUtil, err := git.NewGitOps(ws.Dir); err == nil {
+			root.gitUtil = gitUtil
+		} else {
+			slog.Debug("git utility not initialized for workspace", "workspace", ws.Prefix, "error", err)
+		}
+		root.identity = newIdentityResolver(root.gitUtil)
+		roots = append(roots, root)
+	}
+	slog.Debug("creating new multi-workspace TikiStore", "workspaces", len(roots))
+
+	home := roots[0]
+	s := newEmptyTikiStore(home.dir)
+	s.gitUtil = home.gitUtil
+	s.identity = home.identity
+	s.roots = roots
+	if err := s.initialLoad(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// findRepoDir walks up from dir to the directory holding `.git`, the root
+// git reports history paths against. Returns "" outside a repo.
+func findRepoDir(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return ""
+		}
+		d = parent
+	}
+}
+
+// rootList returns the mounted roots. A single-root store answers with one
+// unprefixed root built from its own dir, git utility and identity.
+func (s *TikiStore) rootList() []*workspaceRoot {
+	if len(s.roots) > 0 {
+		return s.roots
+	}
+	return []*workspaceRoot{{dir: s.dir, gitUtil: s.gitUtil, identity: s.identity}}
+}
+
+// rootForPath returns the root containing path, or the home root when path
+// is empty (a tiki not saved yet) or lies outside every root.
+func (s *TikiStore) rootForPath(path string) *workspaceRoot {
+	roots := s.rootList()
+	if path != "" && len(roots) > 1 {
+		for _, r := range roots {
+			if pathWithin(r.dir, path) {
+				return r
+			}
+		}
+	}
+	return roots[0]
+}
+
+// rootForPrefix returns the workspace with the given (upper-case) prefix.
+func (s *TikiStore) rootForPrefix(prefix string) *workspaceRoot {
+	for _, r := range s.roots {
+		if r.prefix == prefix {
+			return r
+		}
+	}
+	return nil
+}
+
+// lookupKey returns the key path has in the maps built by the root's
+// AllAuthors/AllLastCommitTimes — git reports paths relative to the repo's
+// top level.
+func (r *workspaceRoot) lookupKey(path string) string {
+	if r.repoDir != "" {
+		if rel, err := filepath.Rel(r.repoDir, path); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return relPathForLookup(r.dir, path)
+}
+
+// currentUser resolves the identity new attribution in this root uses.
+func (r *workspaceRoot) currentUser() (name string, email string, err error) {
+	if r.identity == nil {
+		return "", "", nil
+	}
+	return r.identity.currentUser()
+}
+
+func pathWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Workspaces returns the prefixes of the mounted workspaces in declaration
+// order, or nil for a single-root store.
+func (s *TikiStore) Workspaces() []string {
+	if len(s.roots) == 0 {
+		return nil
+	}
+	out := make([]string, len(s.roots))
+	for i, r := range s.roots {
+		out[i] = r.prefix
+	}
+	return out
+}
+
+// WorkspaceOf returns the prefix of the workspace tk lives in; new tikis
+// belong to the home workspace. Empty for a single-root store.
+func (s *TikiStore) WorkspaceOf(tk *tiki.Tiki) string {
+	if tk == nil || len(s.roots) == 0 {
+		return ""
+	}
+	return s.rootForPath(tk.Path()).prefix
+}
+
+// ResolveWorkspace answers the derived workspace field; install it with
+// tiki.SetDerivedResolver.
+func (s *TikiStore) ResolveWorkspace(tk *tiki.Tiki, _ string) (interface{}, bool) {
+	ws := s.WorkspaceOf(tk)
+	return ws, ws != ""
+}
+
+// unqualifyRefs strips workspace prefixes from the references a loaded tiki
+// holds, so ids are bare in memory as ruki and the validators expect. A
+// prefix naming no mounted workspace is left in place.
+func (s *TikiStore) unqualifyRefs(tk *tiki.Tiki) {
+	if len(s.roots) == 0 {
+		return
+	}
+	stale := tk.StaleKeys()
+	rewriteRefFields(tk, stale, func(ref string) string {
+		prefix, id, ok := document.SplitQualifiedID(ref)
+		if !ok || prefix == "" || s.rootForPrefix(prefix) == nil {
+			return ref
+		}
+		return id
+	})
+}
+
+// qualifiedForSave returns tk as it is written to path: references to tikis
+// in another workspace carry that workspace's prefix. tk itself is returned
+// when nothing needs qualifying. Caller must hold s.mu lock.
+func (s *TikiStore) qualifiedForSave(tk *tiki.Tiki, path string) *tiki.Tiki {
+	if len(s.roots) == 0 {
+		return tk
+	}
+	home := s.rootForPath(path)
+	out := tk.Clone()
+	changed := false
+	rewriteRefFields(out, tk.StaleKeys(), func(ref string) string {
+		target, ok := s.tikis[normalizeTikiID(ref)]
+		if !ok {
+			return ref
+		}
+		if r := s.rootForPath(target.Path()); r != home {
+			changed = true
+			return document.QualifyID(r.prefix, ref)
+		}
+		return ref
+	})
+	if !changed {
+		return tk
+	}
+	return out
+}
+
+// rewriteRefFields maps every reference held in tk's tikiId and tikiIdList
+// fields through fn. Stale values round-trip untouched.
+func rewriteRefFields(tk *tiki.Tiki, stale map[string]struct{}, fn func(string) string) {
+	for _, fd := range workflow.WorkflowFields() {
+		if _, isStale := stale[fd.Name]; isStale {
+			continue
+		}
+		switch fd.Type {
+		case workflow.TypeListRef:
+			refs, present, _ := tk.StringSliceField(fd.Name)
+			if !present || len(refs) == 0 {
+				continue
+			}
+			out := make([]string, len(refs))
+			for i, ref := range refs {
+				out[i] = fn(ref)
+			}
+			tk.Set(fd.Name, out)
+		case workflow.TypeRef:
+			if ref, present, _ := tk.StringField(fd.Name); present && ref != "" {
+				tk.Set(fd.Name, fn(ref))
+			}
+		}
+	}
+}
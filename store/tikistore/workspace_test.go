@@ -0,0 +1,92 @@
+package tikistore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func newWorkspaceStore(t *testing.T) (*TikiStore, string, string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	be, fe := t.TempDir(), t.TempDir()
+	mustWrite(t, be, "API001.md", "---\nid: API001\ntitle: api\ndependsOn:\n    - FE:UI0001\n---\n")
+	mustWrite(t, fe, "UI0001.md", "---\nid: UI0001\ntitle: ui\n---\n")
+	s, err := NewWorkspaceTikiStore([]config.Workspace{{Prefix: "BE", Dir: be}, {Prefix: "FE", Dir: fe}})
+	if err != nil {
+		t.Fatalf("NewWorkspaceTikiStore: %v", err)
+	}
+	return s, be, fe
+}
+
+func TestWorkspaceStore_QualifiedRefsOnDiskOnly(t *testing.T) {
+	s, be, fe := newWorkspaceStore(t)
+
+	api := s.GetTiki("API001")
+	if api == nil || s.GetTiki("UI0001") == nil {
+		t.Fatal("tikis from both workspaces should load")
+	}
+	if deps, _, _ := api.StringSliceField("dependsOn"); len(deps) != 1 || deps[0] != "UI0001" {
+		t.Errorf("dependsOn in memory = %v, want bare [UI0001]", deps)
+	}
+	if got := s.WorkspaceOf(api); got != "BE" {
+		t.Errorf("WorkspaceOf(API001) = %q, want BE", got)
+	}
+	if v, ok := s.ResolveWorkspace(s.GetTiki("UI0001"), ""); !ok || v != "FE" {
+		t.Errorf("ResolveWorkspace(UI0001) = %v, %v, want FE", v, ok)
+	}
+
+	api = api.Clone()
+	api.SetTitle("api v2")
+	if err := s.UpdateTiki(api); err != nil {
+		t.Fatalf("UpdateTiki: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(be, "API001.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), "- FE:UI0001") {
+		t.Errorf("cross-workspace ref should be written qualified:\n%s", raw)
+	}
+
+	ui := tikipkg.New()
+	ui.SetID("UI0002")
+	ui.SetTitle("ui 2")
+	ui.Set("dependsOn", []string{"UI0001"})
+	if err := s.CreateTiki(ui); err != nil {
+		t.Fatalf("CreateTiki: %v", err)
+	}
+	created := s.GetTiki("UI0002")
+	if !pathWithin(be, created.Path()) {
+		t.Errorf("new tiki written to %s, want the home workspace %s", created.Path(), be)
+	}
+	raw, _ = os.ReadFile(created.Path())
+	if !strings.Contains(string(raw), "- FE:UI0001") {
+		t.Errorf("new home tiki should qualify its ref into FE:\n%s", raw)
+	}
+	if _, err := os.Stat(filepath.Join(fe, "UI0002.md")); err == nil {
+		t.Error("new tiki should not land in the FE workspace")
+	}
+}
+
+func TestWorkspaceStore_DuplicateIDAcrossWorkspaces(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	be, fe := t.TempDir(), t.TempDir()
+	mustWrite(t, be, "a.md", "---\nid: SAME01\ntitle: backend\n---\n")
+	mustWrite(t, fe, "a.md", "---\nid: SAME01\ntitle: frontend\n---\n")
+	s, err := NewWorkspaceTikiStore([]config.Workspace{{Prefix: "BE", Dir: be}, {Prefix: "FE", Dir: fe}})
+	if err != nil {
+		t.Fatalf("NewWorkspaceTikiStore: %v", err)
+	}
+	if got := s.GetTiki("SAME01"); got == nil || got.Title() != "backend" {
+		t.Errorf("GetTiki(SAME01) = %v, want the home workspace's tiki", got)
+	}
+	rejections := s.LoadDiagnostics().Rejections()
+	if len(rejections) != 1 || rejections[0].Reason != LoadReasonDuplicateID {
+		t.Errorf("rejections = %+v, want one duplicate id", rejections)
+	}
+}
@@ -309,6 +309,22 @@ func (ta *TestApp) SendKey(key tcell.Key, ch rune, mod tcell.ModMask) {
 	ta.Draw()
 }
 
+// SendMouse delivers a mouse action at (x, y) the way the event loop does:
+// through the application's mouse capture, then the root's mouse handler.
+func (ta *TestApp) SendMouse(action tview.MouseAction, x, y int) {
+	event := tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone)
+	if capture := ta.App.GetMouseCapture(); capture != nil {
+		if event, action = capture(event, action); event == nil {
+			ta.Draw()
+			return
+		}
+	}
+	if handler := ta.pages.MouseHandler(); handler != nil {
+		handler(action, event, func(p tview.Primitive) { ta.App.SetFocus(p) })
+	}
+	ta.Draw()
+}
+
 // GetTextAt extracts text from a screen region starting at (x, y) with given width
 func (ta *TestApp) GetTextAt(x, y, width int) string {
 	contents, screenWidth, _ := ta.Screen.GetContents()
@@ -544,10 +560,16 @@ func (ta *TestApp) LoadPlugins() error {
 		dc.SetSelectedTikiID(selectedTikiID)
 		return dc
 	})
+	// Mouse gestures replay keys through the router, as in bootstrap
+	dispatchKey := func(event *tcell.EventKey) {
+		ta.InputRouter.HandleInput(event, ta.NavController.CurrentView())
+	}
+	viewFactory.SetKeyDispatcher(dispatchKey)
 	ta.ViewFactory = viewFactory
 
 	// Recreate RootLayout with new view factory
 	headerWidget := header.NewHeaderWidget(ta.headerConfig, ta.viewContext)
+	headerWidget.SetKeyDispatcher(dispatchKey)
 	ta.RootLayout.Cleanup()
 	slConfig := model.NewStatuslineConfig()
 	slWidget := statusline.NewStatuslineWidget(slConfig)
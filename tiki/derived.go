@@ -0,0 +1,84 @@
+package tiki
+
+import "sync"
+
+// DerivedResolver computes the value of a derived field for t. Derived fields
+// are read-only values computed from store-wide state (e.g. the dependency
+// graph or a computed-field expression); they never live in Fields and are
+// never persisted. ok is false when the field has no value for t.
+type DerivedResolver func(t *Tiki, name string) (value interface{}, ok bool)
+
+var (
+	derivedMu        sync.RWMutex
+	derivedResolvers map[string]DerivedResolver
+)
+
+// SetDerivedResolver installs fn as the source of the named derived fields,
+// replacing any resolver previously installed for those names; resolvers of
+// other names are kept, so independent sources (dependencies, computed
+// fields) can coexist. Doc.Get and Doc.Has consult them, so ruki queries see
+// the computed values while the Tiki model and the store's persistence paths
+// stay unaware of them. A nil fn removes the resolver for the names.
+func SetDerivedResolver(names []string, fn DerivedResolver) {
+	derivedMu.Lock()
+	defer derivedMu.Unlock()
+	for _, n := range names {
+		if fn == nil {
+			delete(derivedResolvers, n)
+			continue
+		}
+		if derivedResolvers == nil {
+			derivedResolvers = make(map[string]DerivedResolver)
+		}
+		derivedResolvers[n] = fn
+	}
+}
+
+// DerivedValue resolves a derived field for t. handled is false when name
+// has no installed resolver.
+func DerivedValue(t *Tiki, name string) (value interface{}, ok bool, handled bool) {
+	return lookupDerived(t, name)
+}
+
+// lookupDerived resolves name through its installed resolver. handled is
+// false when name is not a derived field, in which case the caller falls back
+// to the tiki's own fields.
+func lookupDerived(t *Tiki, name string) (value interface{}, ok bool, handled bool) {
+	derivedMu.RLock()
+	fn := derivedResolvers[name]
+	derivedMu.RUnlock()
+	if fn == nil || t == nil {
+		return nil, false, false
+	}
+	value, ok = fn(t, name)
+	return value, ok, true
+}
+
+// WithDerived returns a clone of t whose Fields also carry the value of every
+// installed derived field, for renderers that read a tiki's fields directly
+// (the detail view). t itself is returned when no derived field resolves. The
+// clone is for display only — saving it would be rejected by the validators.
+func WithDerived(t *Tiki) *Tiki {
+	if t == nil {
+		return nil
+	}
+	derivedMu.RLock()
+	resolvers := make(map[string]DerivedResolver, len(derivedResolvers))
+	for name, fn := range derivedResolvers {
+		resolvers[name] = fn
+	}
+	derivedMu.RUnlock()
+
+	out := t
+	for name, fn := range resolvers {
+		value, ok := fn(t, name)
+		if !ok {
+			continue
+		}
+		if out == t {
+			out = t.Clone()
+		}
+		out.Set(name, value)
+	}
+	return out
+}
@@ -11,19 +11,34 @@ import (
 // and unwraps on the way out.
 type Doc struct{ T *Tiki }
 
-func (d Doc) ID() string                       { return d.T.ID() }
-func (d Doc) Title() string                    { return d.T.Title() }
-func (d Doc) Body() string                     { return d.T.Body() }
-func (d Doc) Path() string                     { return d.T.Path() }
-func (d Doc) CreatedAt() time.Time             { return d.T.CreatedAt() }
-func (d Doc) UpdatedAt() time.Time             { return d.T.UpdatedAt() }
-func (d Doc) SetTitle(v string)                { d.T.SetTitle(v) }
-func (d Doc) SetBody(v string)                 { d.T.SetBody(v) }
-func (d Doc) Get(n string) (interface{}, bool) { return d.T.Get(n) }
-func (d Doc) Set(n string, v interface{})      { d.T.Set(n, v) }
-func (d Doc) Has(n string) bool                { return d.T.Has(n) }
-func (d Doc) Delete(n string)                  { d.T.Delete(n) }
-func (d Doc) Clone() ruki.Document             { return Doc{T: d.T.Clone()} }
+func (d Doc) ID() string                  { return d.T.ID() }
+func (d Doc) Title() string               { return d.T.Title() }
+func (d Doc) Body() string                { return d.T.Body() }
+func (d Doc) Path() string                { return d.T.Path() }
+func (d Doc) CreatedAt() time.Time        { return d.T.CreatedAt() }
+func (d Doc) UpdatedAt() time.Time        { return d.T.UpdatedAt() }
+func (d Doc) SetTitle(v string)           { d.T.SetTitle(v) }
+func (d Doc) SetBody(v string)            { d.T.SetBody(v) }
+func (d Doc) Set(n string, v interface{}) { d.T.Set(n, v) }
+func (d Doc) Delete(n string)             { d.T.Delete(n) }
+func (d Doc) Clone() ruki.Document        { return Doc{T: d.T.Clone()} }
+
+// Get reads a field, answering derived fields (see SetDerivedResolver) from
+// the installed resolver rather than the tiki's own field map.
+func (d Doc) Get(n string) (interface{}, bool) {
+	if v, ok, handled := lookupDerived(d.T, n); handled {
+		return v, ok
+	}
+	return d.T.Get(n)
+}
+
+// Has reports field presence with the same derived-field routing as Get.
+func (d Doc) Has(n string) bool {
+	if _, ok, handled := lookupDerived(d.T, n); handled {
+		return ok
+	}
+	return d.T.Has(n)
+}
 
 // WrapDoc / WrapDocs / UnwrapDoc / UnwrapDocs bridge *Tiki and ruki.Document.
 //
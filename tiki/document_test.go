@@ -24,3 +24,59 @@ func TestUnwrapDoc_Nil(t *testing.T) {
 		t.Fatalf("UnwrapDoc(nil) = %v, want nil", got)
 	}
 }
+
+func TestDoc_DerivedFieldsRouteThroughResolver(t *testing.T) {
+	t.Cleanup(func() { SetDerivedResolver([]string{"blocked"}, nil) })
+	SetDerivedResolver([]string{"blocked"}, func(tk *Tiki, name string) (interface{}, bool) {
+		return tk.ID() == "ABC123", true
+	})
+
+	tk := New()
+	tk.SetID("ABC123")
+	tk.Set("status", "ready")
+	doc := WrapDoc(tk)
+
+	if v, ok := doc.Get("blocked"); !ok || v != true {
+		t.Errorf("Get(blocked) = %v, %v; want true, true", v, ok)
+	}
+	if !doc.Has("blocked") {
+		t.Error("Has(blocked) = false, want true")
+	}
+	if _, ok := tk.Get("blocked"); ok {
+		t.Error("derived value leaked into the tiki's own fields")
+	}
+	if v, _ := doc.Get("status"); v != "ready" {
+		t.Errorf("Get(status) = %v, want ready", v)
+	}
+}
+
+func TestSetDerivedResolver_SourcesCoexist(t *testing.T) {
+	names := []string{"blocked", "score"}
+	t.Cleanup(func() { SetDerivedResolver(names, nil) })
+	SetDerivedResolver(names[:1], func(*Tiki, string) (interface{}, bool) { return true, true })
+	SetDerivedResolver(names[1:], func(*Tiki, string) (interface{}, bool) { return 7, true })
+
+	doc := WrapDoc(New())
+	if v, _ := doc.Get("blocked"); v != true {
+		t.Errorf("Get(blocked) = %v, want true", v)
+	}
+	if v, _ := doc.Get("score"); v != 7 {
+		t.Errorf("Get(score) = %v, want 7", v)
+	}
+	tk := New()
+	shown := WithDerived(tk)
+	if v, _ := shown.Get("score"); v != 7 || shown == tk {
+		t.Errorf("WithDerived score = %v (clone %v), want 7 on a clone", v, shown != tk)
+	}
+	if _, ok := tk.Get("score"); ok {
+		t.Error("WithDerived modified the original tiki")
+	}
+
+	SetDerivedResolver(names[1:], nil)
+	if doc.Has("score") {
+		t.Error("score still resolved after its resolver was removed")
+	}
+	if v, _ := doc.Get("blocked"); v != true {
+		t.Error("removing one resolver dropped another")
+	}
+}
@@ -33,6 +33,11 @@ type Tiki struct {
 	// saves; mutable as the user moves the file around. Read via Path().
 	path string
 
+	// folder is a creation-time hint: a path relative to the tiki directory
+	// where a brand-new tiki is written (set by named templates). Ignored once
+	// the tiki has a path. Read via Folder().
+	folder string
+
 	// LoadedMtime is the file mtime at load time; used for optimistic locking.
 	LoadedMtime time.Time
 
@@ -73,6 +78,7 @@ func (t *Tiki) ID() string           { return t.id }
 func (t *Tiki) Title() string        { return t.title }
 func (t *Tiki) Body() string         { return t.body }
 func (t *Tiki) Path() string         { return t.path }
+func (t *Tiki) Folder() string       { return t.folder }
 func (t *Tiki) CreatedAt() time.Time { return t.createdAt }
 func (t *Tiki) UpdatedAt() time.Time { return t.updatedAt }
 
@@ -81,6 +87,7 @@ func (t *Tiki) SetID(v string)           { t.id = v }
 func (t *Tiki) SetTitle(v string)        { t.title = v }
 func (t *Tiki) SetBody(v string)         { t.body = v }
 func (t *Tiki) SetPath(v string)         { t.path = v }
+func (t *Tiki) SetFolder(v string)       { t.folder = v }
 func (t *Tiki) SetCreatedAt(v time.Time) { t.createdAt = v }
 func (t *Tiki) SetUpdatedAt(v time.Time) { t.updatedAt = v }
 
@@ -96,6 +103,7 @@ func (t *Tiki) Clone() *Tiki {
 		title:       t.title,
 		body:        t.body,
 		path:        t.path,
+		folder:      t.folder,
 		LoadedMtime: t.LoadedMtime,
 		createdAt:   t.createdAt,
 		updatedAt:   t.updatedAt,
@@ -18,6 +18,16 @@ func GetDefaultEditor() string {
 	return editor
 }
 
+// EditorAvailable reports whether the editor GetDefaultEditor names can be
+// run, and returns that name so callers can say which command is missing.
+// It is false when VISUAL or EDITOR names a command that is not on PATH, or
+// when neither is set and vi is not installed, which is common in containers.
+func EditorAvailable() (string, bool) {
+	editor := GetDefaultEditor()
+	_, err := exec.LookPath(editor)
+	return editor, err == nil
+}
+
 // OpenInEditor opens the specified file in the user's default editor.
 // The function blocks until the editor exits.
 // Returns any error that occurred while running the editor.
@@ -0,0 +1,15 @@
+package util
+
+import "testing"
+
+func TestEditorAvailable_NamesMissingCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "tiki-no-such-editor")
+	editor, ok := EditorAvailable()
+	if ok {
+		t.Fatal("an editor that is not on PATH should not be available")
+	}
+	if editor != "tiki-no-such-editor" {
+		t.Errorf("editor = %q, want the command named by EDITOR", editor)
+	}
+}
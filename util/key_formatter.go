@@ -15,6 +15,7 @@ import (
 //   - FormatKeyBinding(tcell.KeyEnter, 0, tcell.ModShift) → "Shift+Enter"
 //   - FormatKeyBinding(tcell.KeyEscape, 0, 0) → "Esc"
 //   - FormatKeyBinding(tcell.KeyRune, 's', tcell.ModCtrl) → "Ctrl+s"
+//   - FormatKeyBinding(tcell.KeyRune, ' ', 0) → "Space"
 func FormatKeyBinding(key tcell.Key, ch rune, mod tcell.ModMask) string {
 	if key == 0 && ch == 0 {
 		return ""
@@ -32,6 +33,9 @@ func FormatKeyBinding(key tcell.Key, ch rune, mod tcell.ModMask) string {
 		if mod&tcell.ModAlt != 0 {
 			prefix += "Alt+"
 		}
+		if ch == ' ' {
+			return prefix + "Space"
+		}
 		return prefix + string(ch)
 	}
 
@@ -0,0 +1,347 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	nav "github.com/boolean-maybe/navidown/navidown"
+	navtview "github.com/boolean-maybe/navidown/navidown/tview"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/view/markdown"
+	"github.com/boolean-maybe/tiki/workflow"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ActivitySource supplies kind: activity views: the committed history and
+// this session's changes since a time, and notice of each session change.
+// service.ActivitySource implements it.
+type ActivitySource interface {
+	Committed(since time.Time) []store.ActivityEvent
+	Session(since time.Time) []store.ActivityEvent
+	AddListener(listener store.ChangeListener) int
+	RemoveListener(id int)
+}
+
+// ActivityView backs `kind: activity`: a generated markdown feed of recent
+// changes, rendered by the wiki viewer so Enter on a `[[ID]]` link jumps to
+// the changed tiki. The filters start as the view is configured and change
+// through the filter actions. While the view is focused the feed follows
+// the workspace: a store change (an edit, or a reload that picks up new
+// commits) reads git again, and a change recorded in the session re-merges
+// the session's changes.
+type ActivityView struct {
+	*WikiView
+	activityDef     *plugin.ActivityPlugin
+	source          ActivitySource // nil renders an empty feed
+	user            string
+	field           string
+	since           string // as written: "today", a duration or a date
+	until           string // empty means now
+	committed       []store.ActivityEvent
+	inputHelper     *InputHelper
+	storeListenerID int
+	feedListenerID  int
+}
+
+// NewActivityView creates an activity view. The committed history is read
+// when the view takes focus.
+func NewActivityView(
+	pluginDef *plugin.ActivityPlugin,
+	imageManager *navtview.ImageManager,
+	mermaidOpts *nav.MermaidOptions,
+	globalActions []plugin.PluginAction,
+	tikiStore store.ReadStore,
+	source ActivitySource,
+	selectedTikiID string,
+) *ActivityView {
+	av := &ActivityView{
+		activityDef: pluginDef,
+		source:      source,
+		user:        pluginDef.User,
+		field:       pluginDef.Field,
+		since:       pluginDef.Since,
+	}
+	av.WikiView = &WikiView{
+		pluginDef:       &plugin.WikiPlugin{BasePlugin: pluginDef.BasePlugin},
+		registry:        controller.NewActionRegistry(),
+		imageManager:    imageManager,
+		mermaidOpts:     mermaidOpts,
+		tikiStore:       tikiStore,
+		surfacedGlobals: surfacedGlobalActions(globalActions, pluginDef.GetName()),
+		selectedTikiID:  selectedTikiID,
+		viewActions:     activityFilterActions(),
+		generated:       av.feedMarkdown,
+	}
+	av.build()
+
+	// input helper - focus returns to the feed
+	av.inputHelper = NewInputHelper(av.md.View())
+	av.inputHelper.SetCancelHandler(func() {
+		av.CancelInputBox()
+	})
+	av.inputHelper.SetCloseHandler(av.rebuildLayout)
+	return av
+}
+
+// activityFilterActions are the filter actions shown in the header; the
+// controller registers the same keys in ActivityViewActions.
+func activityFilterActions() []controller.Action {
+	return []controller.Action{
+		{ID: controller.ActionActivityUser, Key: tcell.KeyRune, Rune: 'u', Label: "User", ShowInHeader: true},
+		{ID: controller.ActionActivityField, Key: tcell.KeyRune, Rune: 'f', Label: "Field", ShowInHeader: true},
+		{ID: controller.ActionActivitySince, Key: tcell.KeyRune, Rune: 't', Label: "Since", ShowInHeader: true},
+		{ID: controller.ActionActivityUntil, Key: tcell.KeyRune, Rune: 'T', Label: "Until", ShowInHeader: true},
+	}
+}
+
+// filter resolves the current filters against now. since and until were
+// validated when they were set.
+func (av *ActivityView) filter(now time.Time) store.ActivityFilter {
+	filter := store.ActivityFilter{User: av.user, Field: av.field}
+	filter.Since, _ = store.ParseActivityTime(av.since, now)
+	if av.until != "" {
+		filter.Until, _ = store.ParseActivityTime(av.until, now)
+	}
+	if strings.EqualFold(filter.User, "me") && av.tikiStore != nil {
+		filter.User, _ = store.CurrentUserDisplay(av.tikiStore)
+	}
+	return filter
+}
+
+// feedMarkdown renders the cached committed history merged with the
+// session's changes under the current filters.
+func (av *ActivityView) feedMarkdown() string {
+	filter := av.filter(time.Now())
+	var events []store.ActivityEvent
+	if av.source != nil {
+		events = service.MergeActivity(av.source.Session(filter.Since), av.committed)
+		events = store.FilterActivity(events, filter)
+	}
+	exists := func(id string) bool { return av.tikiStore != nil && av.tikiStore.GetTiki(id) != nil }
+	return activityMarkdown(events, filter, exists)
+}
+
+// reload reads the committed history again and renders the feed.
+func (av *ActivityView) reload() {
+	if av.source != nil {
+		av.committed = av.source.Committed(av.filter(time.Now()).Since)
+	}
+	av.render()
+}
+
+// render shows the feed again. After following a link the viewer shows a
+// tiki rather than the feed, which is left alone until the user comes back.
+func (av *ActivityView) render() {
+	if av.md.SourceFilePath() != "" {
+		return
+	}
+	resolver := &markdown.StoreResolver{Store: av.tikiStore}
+	av.md.SetMarkdown(markdown.RewriteWikilinks(av.generated(), resolver))
+}
+
+// SetActivityUser implements controller.ActivityView.
+func (av *ActivityView) SetActivityUser(text string) {
+	av.user = text
+	if text == "" {
+		av.user = av.activityDef.User
+	}
+	av.render()
+}
+
+// SetActivityField implements controller.ActivityView. The field must be
+// known to the workflow.
+func (av *ActivityView) SetActivityField(text string) error {
+	if text == "" {
+		av.field = av.activityDef.Field
+		av.render()
+		return nil
+	}
+	fd, ok := workflow.Field(text)
+	if !ok {
+		return fmt.Errorf("unknown field %q", text)
+	}
+	av.field = fd.Name
+	av.render()
+	return nil
+}
+
+// SetActivitySince implements controller.ActivityView. An earlier start
+// needs history that was not read, so git is read again.
+func (av *ActivityView) SetActivitySince(text string) error {
+	if text == "" {
+		text = av.activityDef.Since
+	}
+	if _, err := store.ParseActivityTime(text, time.Now()); err != nil {
+		return fmt.Errorf("since: %w", err)
+	}
+	av.since = text
+	av.reload()
+	return nil
+}
+
+// SetActivityUntil implements controller.ActivityView.
+func (av *ActivityView) SetActivityUntil(text string) error {
+	if text != "" {
+		if _, err := store.ParseActivityTime(text, time.Now()); err != nil {
+			return fmt.Errorf("until: %w", err)
+		}
+	}
+	av.until = text
+	av.render()
+	return nil
+}
+
+// OnFocus reads the feed and follows the store and the session while the
+// view is active.
+func (av *ActivityView) OnFocus() {
+	if av.tikiStore != nil {
+		av.storeListenerID = av.tikiStore.AddListener(av.reload)
+	}
+	if av.source != nil {
+		av.feedListenerID = av.source.AddListener(av.render)
+	}
+	av.reload()
+}
+
+// OnBlur stops following changes.
+func (av *ActivityView) OnBlur() {
+	if av.tikiStore != nil {
+		av.tikiStore.RemoveListener(av.storeListenerID)
+	}
+	if av.source != nil {
+		av.source.RemoveListener(av.feedListenerID)
+	}
+	av.WikiView.OnBlur()
+}
+
+// rebuildLayout lays out the title bar, the input box while a filter is
+// being edited, and the feed.
+func (av *ActivityView) rebuildLayout() {
+	av.root.Clear()
+	av.root.AddItem(av.titleBar, 1, 0, false)
+	if av.inputHelper.IsVisible() {
+		av.root.AddItem(av.inputHelper.GetInputBox(), config.InputBoxHeight, 0, true)
+		av.root.AddItem(av.md.View(), 0, 1, false)
+		return
+	}
+	av.root.AddItem(av.md.View(), 0, 1, true)
+}
+
+// ShowInputBox displays the input box for editing a filter.
+func (av *ActivityView) ShowInputBox(prompt, initial string) tview.Primitive {
+	wasVisible := av.inputHelper.IsVisible()
+	inputBox := av.inputHelper.Show(prompt, initial, inputModeActionInput)
+	if !wasVisible {
+		av.rebuildLayout()
+	}
+	return inputBox
+}
+
+// ShowSearchBox is not used: the feed is searched with the document search.
+func (av *ActivityView) ShowSearchBox() tview.Primitive { return nil }
+
+// HideInputBox hides the input box.
+func (av *ActivityView) HideInputBox() {
+	if !av.inputHelper.IsVisible() {
+		return
+	}
+	av.inputHelper.Hide()
+	av.rebuildLayout()
+}
+
+// CancelInputBox closes the input box, leaving the filter unchanged.
+func (av *ActivityView) CancelInputBox() {
+	av.inputHelper.finishInput()
+}
+
+// IsInputBoxVisible returns whether the input box is currently visible
+func (av *ActivityView) IsInputBoxVisible() bool { return av.inputHelper.IsVisible() }
+
+// IsInputBoxFocused returns whether the input box currently has focus
+func (av *ActivityView) IsInputBoxFocused() bool { return av.inputHelper.HasFocus() }
+
+// IsSearchPassive always returns false: the feed has no board search.
+func (av *ActivityView) IsSearchPassive() bool { return false }
+
+// SetInputSubmitHandler sets the callback for when input is submitted
+func (av *ActivityView) SetInputSubmitHandler(handler func(text string) controller.InputSubmitResult) {
+	av.inputHelper.SetSubmitHandler(handler)
+}
+
+// SetInputCancelHandler sets the callback for when input is cancelled
+func (av *ActivityView) SetInputCancelHandler(handler func()) {
+	av.inputHelper.SetCancelHandler(handler)
+}
+
+// SetFocusSetter sets the focus callback of both the input box and the
+// document search and outline.
+func (av *ActivityView) SetFocusSetter(setter func(p tview.Primitive)) {
+	av.inputHelper.SetFocusSetter(setter)
+	av.WikiView.SetFocusSetter(setter)
+}
+
+// activityMarkdown renders the feed grouped by local day, one line per
+// change: time, actor, tiki and what changed. Tikis that no longer exist
+// are named by id and title instead of linked.
+func activityMarkdown(events []store.ActivityEvent, filter store.ActivityFilter, exists func(id string) bool) string {
+	var b strings.Builder
+	b.WriteString("# Activity\n\n")
+	fmt.Fprintf(&b, "%s\n\n", activityScope(filter))
+	if len(events) == 0 {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+
+	day := ""
+	for _, e := range events {
+		when := e.When.Local()
+		if d := when.Format("Monday, Jan 2 2006"); d != day {
+			day = d
+			fmt.Fprintf(&b, "## %s\n\n", day)
+		}
+		actor := e.Actor
+		if actor == "" {
+			actor = "unknown"
+		}
+		subject := fmt.Sprintf("[[%s]]", e.TikiID)
+		if !exists(e.TikiID) {
+			subject = fmt.Sprintf("%s *%s*", e.TikiID, e.Title)
+		}
+		fmt.Fprintf(&b, "- %s · %s · %s · %s", when.Format("15:04"), actor, subject, activityChange(e))
+		if !e.Committed {
+			b.WriteString(" · not committed")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// activityScope describes the time range and filters the feed covers.
+func activityScope(filter store.ActivityFilter) string {
+	scope := "Changes since " + filter.Since.Local().Format("Jan 2 15:04")
+	if !filter.Until.IsZero() {
+		scope += " until " + filter.Until.Local().Format("Jan 2 15:04")
+	}
+	if filter.User != "" {
+		scope += " by " + filter.User
+	}
+	if filter.Field != "" {
+		scope += " to " + filter.Field
+	}
+	return scope + "."
+}
+
+// activityChange says what an event did: the kind, and for updates the
+// fields it changed.
+func activityChange(e store.ActivityEvent) string {
+	if e.Kind == store.ActivityUpdated && len(e.Fields) > 0 {
+		return "updated " + strings.Join(e.Fields, ", ")
+	}
+	return string(e.Kind)
+}
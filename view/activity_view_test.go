@@ -0,0 +1,136 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/store"
+)
+
+func TestActivityMarkdown(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 5, 0, 0, time.Local) }
+	events := []store.ActivityEvent{
+		{When: at(3, 9), Actor: "alice", TikiID: "AAA001", Title: "Login", Kind: store.ActivityUpdated, Fields: []string{"priority", "status"}},
+		{When: at(2, 16), Actor: "bob", TikiID: "BBB001", Title: "Old idea", Kind: store.ActivityDeleted, Committed: true},
+		{When: at(2, 11), TikiID: "AAA001", Title: "Login", Kind: store.ActivityCreated, Committed: true},
+	}
+	filter := store.ActivityFilter{User: "alice", Since: at(1, 0)}
+	md := activityMarkdown(events, filter, func(id string) bool { return id == "AAA001" })
+
+	for _, want := range []string{
+		"Changes since Mar 1 00:05 by alice.",
+		"## Tuesday, Mar 3 2026\n\n- 09:05 · alice · [[AAA001]] · updated priority, status · not committed\n",
+		"## Monday, Mar 2 2026\n\n- 16:05 · bob · BBB001 *Old idea* · deleted\n",
+		"- 11:05 · unknown · [[AAA001]] · created\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("feed missing %q:\n%s", want, md)
+		}
+	}
+
+	if md := activityMarkdown(nil, filter, nil); !strings.Contains(md, "No changes.") {
+		t.Errorf("empty feed:\n%s", md)
+	}
+}
+
+// fakeActivitySource serves fixed events and counts reads of the committed
+// history.
+type fakeActivitySource struct {
+	committed, session []store.ActivityEvent
+	committedReads     int
+	listeners          map[int]store.ChangeListener
+	nextID             int
+}
+
+func (f *fakeActivitySource) Committed(time.Time) []store.ActivityEvent {
+	f.committedReads++
+	return f.committed
+}
+
+func (f *fakeActivitySource) Session(time.Time) []store.ActivityEvent { return f.session }
+
+func (f *fakeActivitySource) AddListener(l store.ChangeListener) int {
+	f.nextID++
+	f.listeners[f.nextID] = l
+	return f.nextID
+}
+
+func (f *fakeActivitySource) RemoveListener(id int) { delete(f.listeners, id) }
+
+func (f *fakeActivitySource) record(e store.ActivityEvent) {
+	f.session = append(f.session, e)
+	for _, l := range f.listeners {
+		l()
+	}
+}
+
+func TestActivityView_FiltersAndLiveRefresh(t *testing.T) {
+	s := store.NewInMemoryStore()
+	now := time.Now()
+	src := &fakeActivitySource{
+		committed: []store.ActivityEvent{
+			{When: now.Add(-2 * time.Hour), Actor: "alice", TikiID: "AAA001", Title: "Login", Kind: store.ActivityUpdated, Fields: []string{"status"}, Committed: true},
+			{When: now.Add(-time.Hour), Actor: "bob", TikiID: "BBB001", Title: "Signup", Kind: store.ActivityDeleted, Committed: true},
+		},
+		listeners: make(map[int]store.ChangeListener),
+	}
+	def := &plugin.ActivityPlugin{BasePlugin: plugin.BasePlugin{Name: "Activity"}, Since: "1week"}
+	av := NewActivityView(def, nil, nil, nil, s, src, "")
+
+	av.OnFocus()
+	if src.committedReads != 1 {
+		t.Fatalf("committed reads on focus = %d, want 1", src.committedReads)
+	}
+	if md := av.feedMarkdown(); !strings.Contains(md, "alice") || !strings.Contains(md, "BBB001 *Signup* · deleted") {
+		t.Errorf("feed:\n%s", md)
+	}
+
+	// a store change reads git again; a session change only re-merges
+	if err := s.CreateTiki(newDependencyTestTiki("CCC001", "Fresh", "ready")); err != nil {
+		t.Fatal(err)
+	}
+	if src.committedReads != 2 {
+		t.Errorf("committed reads after a store change = %d, want 2", src.committedReads)
+	}
+	src.record(store.ActivityEvent{When: now, Actor: "carol", TikiID: "CCC001", Title: "Fresh", Kind: store.ActivityCreated})
+	if md := av.feedMarkdown(); !strings.Contains(md, "carol · [[CCC001]] · created · not committed") {
+		t.Errorf("session change missing:\n%s", md)
+	}
+
+	av.SetActivityUser("bob")
+	if md := av.feedMarkdown(); strings.Contains(md, "alice") || !strings.Contains(md, "by bob") {
+		t.Errorf("user filter:\n%s", md)
+	}
+	av.SetActivityUser("")
+	if err := av.SetActivityField("nope"); err == nil {
+		t.Error("unknown field accepted")
+	}
+	if err := av.SetActivityField("status"); err != nil {
+		t.Fatalf("field: %v", err)
+	}
+	if md := av.feedMarkdown(); !strings.Contains(md, "alice") || strings.Contains(md, "bob") {
+		t.Errorf("field filter:\n%s", md)
+	}
+	if err := av.SetActivitySince("someday"); err == nil {
+		t.Error("invalid since accepted")
+	}
+	if err := av.SetActivitySince("30min"); err != nil {
+		t.Fatalf("since: %v", err)
+	}
+	if src.committedReads != 3 {
+		t.Errorf("committed reads after changing since = %d, want 3", src.committedReads)
+	}
+	if err := av.SetActivityUntil("90min"); err != nil {
+		t.Fatalf("until: %v", err)
+	}
+	if md := av.feedMarkdown(); !strings.Contains(md, "No changes.") || !strings.Contains(md, " until ") {
+		t.Errorf("range filter:\n%s", md)
+	}
+
+	av.OnBlur()
+	if len(src.listeners) != 0 {
+		t.Errorf("listeners left after blur: %d", len(src.listeners))
+	}
+}
@@ -0,0 +1,300 @@
+package view
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/theme"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// calendarWeeks is the number of week rows of the month grid; six rows fit
+// every month however its first day falls.
+const calendarWeeks = 6
+
+// CalendarView renders `kind: calendar`: a month grid or a week agenda of the
+// tikis placed on their date field by the controller.
+type CalendarView struct {
+	root                *tview.Flex
+	titleBar            *GradientCaptionRow
+	grid                *tview.Box
+	ctrl                *controller.CalendarController
+	tikiStore           store.Store
+	pluginDef           *plugin.CalendarPlugin
+	storeListenerID     int
+	selectionListenerID int
+	actionChangeHandler func()
+}
+
+// NewCalendarView creates a calendar view backed by ctrl.
+func NewCalendarView(tikiStore store.Store, ctrl *controller.CalendarController) *CalendarView {
+	cv := &CalendarView{
+		ctrl:      ctrl,
+		tikiStore: tikiStore,
+		pluginDef: ctrl.GetPluginDef(),
+	}
+	cv.build()
+	return cv
+}
+
+func (cv *CalendarView) build() {
+	pair := theme.Roles().PluginCaptions().At(cv.pluginDef.ConfigIndex)
+	bgColor := theme.NewColor(pair.Bg().TCell())
+	textColor := theme.NewColor(pair.Fg().TCell())
+	cv.titleBar = NewGradientCaptionRow([]string{""}, []int{1}, theme.NewColorRoleAdapter(bgColor), textColor)
+
+	cv.grid = tview.NewBox()
+	cv.grid.SetDrawFunc(func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+		if cv.ctrl.GetState().IsWeekMode() {
+			cv.drawWeek(screen, x, y, width, height)
+		} else {
+			cv.drawMonth(screen, x, y, width, height)
+		}
+		return x, y, width, height
+	})
+
+	cv.root = tview.NewFlex().SetDirection(tview.FlexRow)
+	cv.root.AddItem(cv.titleBar, 1, 0, false)
+	cv.root.AddItem(cv.grid, 0, 1, true)
+	cv.refresh()
+}
+
+func (cv *CalendarView) refresh() {
+	cv.titleBar.SetCaptions([]string{calendarCaption(cv.ctrl.GetState())}, nil)
+	if cv.actionChangeHandler != nil {
+		cv.actionChangeHandler()
+	}
+}
+
+// calendarCaption is the title of the period on screen, e.g. "October 2026"
+// or "Week of Mon 19 Oct 2026".
+func calendarCaption(state *model.CalendarState) string {
+	day := state.GetDay()
+	if state.IsWeekMode() {
+		return "Week of " + weekStart(day).Format("Mon 2 Jan 2006")
+	}
+	return day.Format("January 2006")
+}
+
+// weekStart returns the Monday on or before day.
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// drawMonth draws the weekday header and six week rows starting with the
+// week of the first of the focused month. Each cell shows the day number and
+// as many entry titles as fit, then "+N more".
+func (cv *CalendarView) drawMonth(screen tcell.Screen, x, y, width, height int) {
+	if width < 7 || height < 2 {
+		return
+	}
+	focused := cv.ctrl.GetState().GetDay()
+	first := time.Date(focused.Year(), focused.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := weekStart(first)
+	end := start.AddDate(0, 0, 7*calendarWeeks)
+	entries := cv.ctrl.Entries(start, end)
+	today := cv.ctrl.Today()
+
+	starts, ends := computeLaneBoundaries([]int{1, 1, 1, 1, 1, 1, 1}, 7, width)
+	header := tcell.StyleDefault.Foreground(theme.Roles().TextLabel().TCell())
+	for col := range 7 {
+		name := start.AddDate(0, 0, col).Format("Mon")
+		drawCalendarText(screen, x+starts[col]+1, y, ends[col]-starts[col]-1, name, header)
+	}
+
+	rowHeight := (height - 1) / calendarWeeks
+	if rowHeight < 1 {
+		return
+	}
+	for week := range calendarWeeks {
+		for col := range 7 {
+			day := start.AddDate(0, 0, 7*week+col)
+			cv.drawDay(screen, day, entries[day], x+starts[col], y+1+week*rowHeight,
+				ends[col]-starts[col], rowHeight, day.Month() == focused.Month(), day.Equal(today))
+		}
+	}
+}
+
+// drawWeek draws the agenda of the focused week: one band per day with its
+// entries listed under the day label.
+func (cv *CalendarView) drawWeek(screen tcell.Screen, x, y, width, height int) {
+	if width < 1 || height < 7 {
+		return
+	}
+	start := weekStart(cv.ctrl.GetState().GetDay())
+	entries := cv.ctrl.Entries(start, start.AddDate(0, 0, 7))
+	today := cv.ctrl.Today()
+	rowHeight := height / 7
+	for i := range 7 {
+		day := start.AddDate(0, 0, i)
+		cv.drawDay(screen, day, entries[day], x, y+i*rowHeight, width, rowHeight, true, day.Equal(today))
+	}
+}
+
+// drawDay draws one day: its label on the first line, entries below. The
+// focused day's label and the selected entry are highlighted.
+func (cv *CalendarView) drawDay(screen tcell.Screen, day time.Time, entries []controller.CalendarEntry,
+	x, y, width, height int, inPeriod, isToday bool) {
+	if width < 2 || height < 1 {
+		return
+	}
+	roles := theme.Roles()
+	state := cv.ctrl.GetState()
+	focused := day.Equal(state.GetDay())
+
+	label := day.Format("2")
+	if state.IsWeekMode() {
+		label = day.Format("Mon 2 Jan")
+	}
+	labelStyle := tcell.StyleDefault.Foreground(roles.TextPrimary().TCell())
+	switch {
+	case focused:
+		labelStyle = labelStyle.Background(roles.SurfaceSelection().TCell()).Bold(true)
+	case isToday:
+		labelStyle = labelStyle.Foreground(roles.AccentAction().TCell()).Bold(true)
+	case !inPeriod:
+		labelStyle = labelStyle.Foreground(roles.TextMuted().TCell())
+	}
+	if isToday {
+		label += " •"
+	}
+	drawCalendarText(screen, x+1, y, width-1, label, labelStyle)
+
+	lines := height - 1
+	if lines <= 0 || len(entries) == 0 {
+		return
+	}
+	selected := -1
+	if focused {
+		selected = min(state.GetIndex(), len(entries)-1)
+	}
+	// indexes of the entries drawn; an overflowing day keeps its last line
+	// for "+N more" and swaps the selected entry into the final slot
+	visible := make([]int, 0, lines)
+	for i := range entries {
+		visible = append(visible, i)
+	}
+	if len(entries) > lines {
+		visible = visible[:lines-1]
+		if selected >= len(visible) && len(visible) > 0 {
+			visible[len(visible)-1] = selected
+		}
+	}
+
+	entryStyle := tcell.StyleDefault.Foreground(roles.TextSecondary().TCell())
+	if !inPeriod {
+		entryStyle = entryStyle.Foreground(roles.TextMuted().TCell())
+	}
+	selectedStyle := tcell.StyleDefault.Foreground(roles.Highlight().TCell()).Background(roles.SurfaceSelection().TCell())
+	for row, i := range visible {
+		style := entryStyle
+		if i == selected {
+			style = selectedStyle
+		}
+		drawCalendarText(screen, x+1, y+1+row, width-1, calendarEntryText(entries[i], state.IsWeekMode()), style)
+	}
+	if hidden := len(entries) - len(visible); hidden > 0 {
+		drawCalendarText(screen, x+1, y+1+len(visible), width-1, fmt.Sprintf("+%d more", hidden),
+			tcell.StyleDefault.Foreground(roles.TextMuted().TCell()))
+	}
+}
+
+// calendarEntryText renders an entry; the agenda has room for the ID and
+// occurrences of recurring tikis are marked.
+func calendarEntryText(e controller.CalendarEntry, agenda bool) string {
+	text := e.Tiki.Title()
+	if agenda {
+		text = store.DisplayID(e.Tiki) + "  " + text
+	}
+	if e.Occurrence {
+		text = "↻ " + text
+	}
+	return text
+}
+
+// drawCalendarText prints text clipped to width, ending in "…" when cut.
+func drawCalendarText(screen tcell.Screen, x, y, width int, text string, style tcell.Style) {
+	if width <= 0 {
+		return
+	}
+	runes := []rune(text)
+	if len(runes) > width {
+		runes = append(runes[:width-1], '…')
+	}
+	for i, r := range runes {
+		screen.SetContent(x+i, y, r, nil, style)
+	}
+}
+
+// GetPrimitive returns the root tview primitive
+func (cv *CalendarView) GetPrimitive() tview.Primitive { return cv.root }
+
+// GetActionRegistry returns the view's action registry
+func (cv *CalendarView) GetActionRegistry() *controller.ActionRegistry {
+	return cv.ctrl.GetActionRegistry()
+}
+
+// ShowNavigation returns whether plugin navigation keys should be shown in the header.
+func (cv *CalendarView) ShowNavigation() bool { return cv.ctrl.ShowNavigation() }
+
+// GetViewName returns the plugin name for the header info section
+func (cv *CalendarView) GetViewName() string { return cv.pluginDef.GetName() }
+
+// GetViewDescription returns the plugin description for the header info section
+func (cv *CalendarView) GetViewDescription() string { return cv.pluginDef.GetDescription() }
+
+// GetViewID returns the view identifier
+func (cv *CalendarView) GetViewID() model.ViewID {
+	return model.MakePluginViewID(cv.pluginDef.Name)
+}
+
+// GetSelectedID returns the ID of the selected tiki
+func (cv *CalendarView) GetSelectedID() string { return cv.ctrl.GetSelectedTikiID() }
+
+// SetSelectedID focuses the tiki's date and selects it
+func (cv *CalendarView) SetSelectedID(id string) { cv.ctrl.SelectTikiByID(id) }
+
+// SetActionChangeHandler sets the callback run when the selection changes,
+// so the header can refresh the actions that depend on it.
+func (cv *CalendarView) SetActionChangeHandler(handler func()) {
+	cv.actionChangeHandler = handler
+}
+
+// OnFocus is called when the view becomes active
+func (cv *CalendarView) OnFocus() {
+	cv.storeListenerID = cv.tikiStore.AddListener(cv.refresh)
+	cv.selectionListenerID = cv.ctrl.GetState().AddListener(cv.refresh)
+	cv.refresh()
+}
+
+// OnBlur is called when the view becomes inactive
+func (cv *CalendarView) OnBlur() {
+	cv.tikiStore.RemoveListener(cv.storeListenerID)
+	cv.ctrl.GetState().RemoveListener(cv.selectionListenerID)
+}
+
+// GetStats returns stats for the header and statusline (tikis in the period)
+func (cv *CalendarView) GetStats() []store.Stat {
+	state := cv.ctrl.GetState()
+	day := state.GetDay()
+	from := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	if state.IsWeekMode() {
+		from = weekStart(day)
+		to = from.AddDate(0, 0, 7)
+	}
+	total := 0
+	for _, entries := range cv.ctrl.Entries(from, to) {
+		total += len(entries)
+	}
+	return []store.Stat{
+		{Name: "Total", Value: fmt.Sprintf("%d", total), Order: 5},
+	}
+}
@@ -0,0 +1,102 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/controller"
+	rukiRuntime "github.com/boolean-maybe/tiki/internal/ruki/runtime"
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/theme"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func screenText(t *testing.T, cv *CalendarView, width, height int) string {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("init simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(width, height)
+	p := cv.GetPrimitive()
+	p.SetRect(0, 0, width, height)
+	p.Draw(screen)
+
+	var b strings.Builder
+	for y := range height {
+		for x := range width {
+			r, _, _, _ := screen.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
+
+func TestCalendarView_MonthAndWeek(t *testing.T) {
+	theme.SetTheme(theme.LoadByName("dark"))
+	tikiStore := store.NewInMemoryStore()
+	for i, title := range []string{"Alpha", "Bravo", "Charlie", "Delta"} {
+		tk := tikipkg.New()
+		tk.SetID("0000T" + string(rune('1'+i)))
+		tk.SetTitle(title)
+		tk.Set("status", "ready")
+		tk.Set("due", time.Date(2026, time.October, 21, 0, 0, 0, 0, time.UTC))
+		if err := tikiStore.CreateTiki(tk); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	gate := service.NewTikiMutationGate()
+	gate.SetStore(tikiStore)
+	def := &plugin.CalendarPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "Calendar", Kind: plugin.KindCalendar},
+		DateField:  "due",
+		Mode:       plugin.CalendarMonth,
+	}
+	cc := controller.NewCalendarController(def, nil, nil, nil, nil, tikiStore, gate, rukiRuntime.NewSchema())
+	cv := NewCalendarView(tikiStore, cc)
+	cv.SetSelectedID("0000T4")
+	if cv.GetSelectedID() != "0000T4" {
+		t.Fatalf("selected = %q, want 0000T4", cv.GetSelectedID())
+	}
+
+	cv.refresh()
+	// four rows per week leave room for two entries and "+N more"
+	month := screenText(t, cv, 140, 26)
+	for _, want := range []string{"October 2026", "Mon", "Sun", "Alpha", "+2 more", "Delta"} {
+		if !strings.Contains(month, want) {
+			t.Errorf("month grid is missing %q:\n%s", want, month)
+		}
+	}
+	if strings.Contains(month, "Bravo") {
+		t.Errorf("overflowing day should swap Bravo for the selected Delta:\n%s", month)
+	}
+
+	cc.HandleAction(controller.ActionCalendarToggleMode)
+	cv.refresh()
+	week := screenText(t, cv, 100, 42)
+	for _, want := range []string{"Week of Mon 19 Oct 2026", "Wed 21 Oct", "0000T3  Charlie"} {
+		if !strings.Contains(week, want) {
+			t.Errorf("agenda is missing %q:\n%s", want, week)
+		}
+	}
+	if got := cv.GetViewID(); got != model.MakePluginViewID("Calendar") {
+		t.Errorf("view id = %q", got)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	for _, day := range []int{19, 21, 25} {
+		got := weekStart(time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC))
+		if got.Day() != 19 || got.Weekday() != time.Monday {
+			t.Errorf("weekStart(Oct %d) = %s, want Mon Oct 19", day, got.Format(time.DateOnly))
+		}
+	}
+}
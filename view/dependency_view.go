@@ -0,0 +1,156 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	nav "github.com/boolean-maybe/navidown/navidown"
+	navtview "github.com/boolean-maybe/navidown/navidown/tview"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/store"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// NewDependencyView creates the view backing `kind: dependencies`: a
+// generated markdown report of the selected tiki's critical path, dependency
+// tree and dependents, rendered by the wiki viewer so the `[[ID]]` links in
+// it navigate to the referenced tikis. The report is a snapshot taken when
+// the view opens.
+func NewDependencyView(
+	pluginDef *plugin.DependencyPlugin,
+	imageManager *navtview.ImageManager,
+	mermaidOpts *nav.MermaidOptions,
+	globalActions []plugin.PluginAction,
+	tikiStore store.ReadStore,
+	selectedTikiID string,
+) *WikiView {
+	dv := &WikiView{
+		pluginDef:       &plugin.WikiPlugin{BasePlugin: pluginDef.BasePlugin},
+		registry:        controller.NewActionRegistry(),
+		imageManager:    imageManager,
+		mermaidOpts:     mermaidOpts,
+		tikiStore:       tikiStore,
+		surfacedGlobals: surfacedGlobalActions(globalActions, pluginDef.GetName()),
+		selectedTikiID:  selectedTikiID,
+	}
+	dv.generated = func() string {
+		cfg, ok := config.WorkflowDependencies()
+		if !ok || tikiStore == nil {
+			return "## No dependencies configured\n\nAdd a `dependencies:` section to workflow.yaml to track what blocks what."
+		}
+		graph := store.NewDependencyGraph(tikiStore.GetAllTikis(), cfg)
+		return dependencyMarkdown(graph, tikiStore, selectedTikiID)
+	}
+	dv.build()
+	return dv
+}
+
+// dependencyMarkdown renders the dependency report for rootID: a summary
+// line, the critical path (the longest chain of unfinished dependencies, in
+// the order it has to be worked), the full dependency tree and the tikis
+// that wait on rootID.
+func dependencyMarkdown(g *store.DependencyGraph, rs store.ReadStore, rootID string) string {
+	root := rs.GetTiki(rootID)
+	if root == nil {
+		return "(no tiki selected)"
+	}
+	cfg := g.Config()
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Dependencies of %s\n\n", root.Title())
+
+	deps := g.Dependencies(rootID)
+	blockers := g.Blockers(rootID)
+	fmt.Fprintf(&b, "**%s** · %s · %d of %d direct dependencies unfinished · depth %d\n\n",
+		rootID, dependencyStatus(g, rs, cfg, rootID), len(blockers), len(deps), g.Depth(rootID))
+
+	b.WriteString("## Critical path\n\n")
+	path := g.CriticalPath(rootID)
+	if len(path) == 0 {
+		b.WriteString("Nothing is holding this up.\n\n")
+	} else {
+		b.WriteString("Finish these in order to unblock it:\n\n")
+		for i, id := range path {
+			fmt.Fprintf(&b, "%d. [[%s]] · %s\n", i+1, id, dependencyStatus(g, rs, cfg, id))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Dependency tree\n\n")
+	if len(deps) == 0 {
+		b.WriteString("No dependencies.\n\n")
+	} else {
+		writeDependencyTree(&b, g, rs, cfg, rootID, 0, map[string]bool{rootID: true}, map[string]bool{})
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Dependents\n\n")
+	dependents := g.Dependents(rootID)
+	if len(dependents) == 0 {
+		b.WriteString("Nothing depends on this tiki.\n")
+	} else {
+		for _, id := range dependents {
+			fmt.Fprintf(&b, "- [[%s]] · %s\n", id, dependencyStatus(g, rs, cfg, id))
+		}
+	}
+	return b.String()
+}
+
+// writeDependencyTree writes the dependencies of id as a nested list.
+// Subtrees already written elsewhere are not repeated, and a dependency that
+// leads back up the current branch is marked instead of followed.
+func writeDependencyTree(b *strings.Builder, g *store.DependencyGraph, rs store.ReadStore, cfg config.DependencyConfig,
+	id string, level int, branch, expanded map[string]bool) {
+	indent := strings.Repeat("  ", level)
+	for _, dep := range g.Dependencies(id) {
+		fmt.Fprintf(b, "%s- %s [[%s]] · %s", indent, dependencyMarker(g, dep), dep, dependencyStatus(g, rs, cfg, dep))
+		switch {
+		case branch[dep]:
+			b.WriteString(" · *cycle*\n")
+			continue
+		case expanded[dep] && len(g.Dependencies(dep)) > 0:
+			b.WriteString(" · *see above*\n")
+			continue
+		}
+		b.WriteString("\n")
+		expanded[dep] = true
+		branch[dep] = true
+		writeDependencyTree(b, g, rs, cfg, dep, level+1, branch, expanded)
+		delete(branch, dep)
+	}
+}
+
+// dependencyMarker is the glyph in front of a tree entry: done, blocked by
+// its own dependencies, or ready to be worked.
+func dependencyMarker(g *store.DependencyGraph, id string) string {
+	switch {
+	case g.IsDone(id):
+		return "✅"
+	case g.Blocked(id):
+		return "⛔"
+	default:
+		return "⏳"
+	}
+}
+
+// dependencyStatus returns the status label of id, "blocked" appended when
+// unfinished dependencies hold it up.
+func dependencyStatus(g *store.DependencyGraph, rs store.ReadStore, cfg config.DependencyConfig, id string) string {
+	tk := rs.GetTiki(id)
+	if tk == nil {
+		return "unknown"
+	}
+	status, _, _ := tk.StringField(cfg.StatusField)
+	label := status
+	if fd, ok := workflow.Field(cfg.StatusField); ok {
+		label = fd.EnumLabel(status)
+	}
+	if label == "" {
+		label = "no " + cfg.StatusField
+	}
+	if !g.IsDone(id) && g.Blocked(id) {
+		label += " (blocked)"
+	}
+	return label
+}
@@ -0,0 +1,64 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func newDependencyTestTiki(id, title, status string, deps ...string) *tikipkg.Tiki {
+	tk := tikipkg.New()
+	tk.SetID(id)
+	tk.SetTitle(title)
+	tk.Set("status", status)
+	if len(deps) > 0 {
+		tk.Set("dependsOn", deps)
+	}
+	return tk
+}
+
+func TestDependencyMarkdown_ReportsPathTreeAndDependents(t *testing.T) {
+	s := store.NewInMemoryStore()
+	for _, tk := range []*tikipkg.Tiki{
+		newDependencyTestTiki("REL001", "Release", "ready", "AAA001", "BBB001"),
+		newDependencyTestTiki("AAA001", "Backend", "inProgress", "CCC001"),
+		newDependencyTestTiki("BBB001", "Docs", "done"),
+		newDependencyTestTiki("CCC001", "Schema", "ready"),
+		newDependencyTestTiki("ANN001", "Announce", "inbox", "REL001"),
+	} {
+		if err := s.CreateTiki(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := config.DependencyConfig{Field: "dependsOn", DoneStatus: config.DoneStatus{StatusField: "status", Done: []string{"done"}}}
+	md := dependencyMarkdown(store.NewDependencyGraph(s.GetAllTikis(), cfg), s, "REL001")
+
+	for _, want := range []string{
+		"# Dependencies of Release",
+		"1 of 2 direct dependencies unfinished · depth 2",
+		"1. [[CCC001]] · Ready\n2. [[AAA001]] · In Progress (blocked)",
+		"- ⛔ [[AAA001]]",
+		"  - ⏳ [[CCC001]]",
+		"- ✅ [[BBB001]] · Done",
+		"## Dependents\n\n- [[ANN001]]",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("report missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestDependencyMarkdown_UnblockedTiki(t *testing.T) {
+	s := store.NewInMemoryStore()
+	if err := s.CreateTiki(newDependencyTestTiki("AAA001", "Solo", "ready")); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DependencyConfig{Field: "dependsOn", DoneStatus: config.DoneStatus{StatusField: "status", Done: []string{"done"}}}
+	md := dependencyMarkdown(store.NewDependencyGraph(s.GetAllTikis(), cfg), s, "AAA001")
+	if !strings.Contains(md, "Nothing is holding this up.") || !strings.Contains(md, "No dependencies.") {
+		t.Errorf("unexpected report for an unblocked tiki:\n%s", md)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/util"
 	"github.com/boolean-maybe/tiki/view/tikidetail"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 // ViewFactory instantiates views by ID, injecting required dependencies.
@@ -42,6 +44,11 @@ type ViewFactory struct {
 	// the most recent navigation overwrites the selection of every earlier
 	// detail view of the same plugin.
 	detailControllerFactory func(pluginDef *plugin.DetailPlugin, selectedTikiID string) *controller.DetailController
+	// dispatchKey feeds a synthetic key through the input router, so mouse
+	// gestures run the same actions as their keyboard equivalents.
+	dispatchKey func(event *tcell.EventKey)
+	// activitySource supplies kind: activity views; nil renders an empty feed.
+	activitySource ActivitySource
 }
 
 // NewViewFactory creates a view factory
@@ -102,6 +109,18 @@ func (f *ViewFactory) SetDetailControllerFactory(fn func(pluginDef *plugin.Detai
 	f.detailControllerFactory = fn
 }
 
+// SetActivitySource registers the source of kind: activity views — this
+// session's changes and committed history.
+func (f *ViewFactory) SetActivitySource(source ActivitySource) {
+	f.activitySource = source
+}
+
+// SetKeyDispatcher registers the function views use to replay a key through
+// the input router (e.g. Enter on a double-clicked card).
+func (f *ViewFactory) SetKeyDispatcher(fn func(event *tcell.EventKey)) {
+	f.dispatchKey = fn
+}
+
 // CreateView instantiates a view by ID with optional parameters.
 // Plugin views are the only views the factory builds; built-in view IDs no
 // longer route through here.
@@ -137,7 +156,7 @@ func (f *ViewFactory) CreateView(viewID model.ViewID, params map[string]interfac
 			slog.Error("plugin controller does not implement TikiViewProvider", "plugin", pluginName)
 			return nil
 		}
-		return NewPluginView(
+		pv := NewPluginView(
 			f.tikiStore,
 			pluginConfig,
 			tikiPlugin,
@@ -146,6 +165,22 @@ func (f *ViewFactory) CreateView(viewID model.ViewID, params map[string]interfac
 			tikiCtrl.GetActionRegistry(),
 			tikiCtrl.ShowNavigation(),
 		)
+		pv.SetMoveHandler(tikiCtrl.MoveSelectedTikiToLane)
+		if mg, ok := tikiCtrl.(controller.MoveGuard); ok {
+			pv.SetMoveGuard(mg.BlockedMoves)
+		}
+		if hp, ok := tikiCtrl.(controller.LaneHeaderProvider); ok {
+			pv.SetLaneHeaderProvider(hp.GetLaneHeaders)
+		}
+		if sp, ok := tikiCtrl.(controller.SwimlaneProvider); ok && sp.HasSwimlanes() {
+			pv.SetSwimlaneProvider(sp)
+		}
+		if dispatch := f.dispatchKey; dispatch != nil {
+			pv.SetActivateHandler(func() {
+				dispatch(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+			})
+		}
+		return pv
 	case plugin.KindWiki:
 		wikiPlugin, ok := pluginDef.(*plugin.WikiPlugin)
 		if !ok {
@@ -171,6 +206,47 @@ func (f *ViewFactory) CreateView(viewID model.ViewID, params map[string]interfac
 			dc.SetSelectedTikiID(pluginParams.TikiID)
 		}
 		return NewWikiView(effective, f.imageManager, f.mermaidOpts, f.globalActions, f.tikiStore, pluginParams.TikiID)
+	case plugin.KindDependencies:
+		depPlugin, ok := pluginDef.(*plugin.DependencyPlugin)
+		if !ok {
+			slog.Error("dependencies plugin is not a DependencyPlugin", "plugin", pluginName)
+			return nil
+		}
+		pluginParams := model.DecodePluginViewParams(params)
+		// same fresh-controller-per-navigation rule as kind: wiki; the
+		// dependency view is a wiki view over a generated report.
+		if f.wikiControllerFactory != nil {
+			f.pluginControllers[pluginName] = f.wikiControllerFactory(pluginDef, pluginParams.TikiID)
+		} else if dc, ok := pluginControllerInterface.(*controller.WikiController); ok {
+			dc.SetSelectedTikiID(pluginParams.TikiID)
+		}
+		return NewDependencyView(depPlugin, f.imageManager, f.mermaidOpts, f.globalActions, f.tikiStore, pluginParams.TikiID)
+	case plugin.KindSprints:
+		sprintPlugin, ok := pluginDef.(*plugin.SprintPlugin)
+		if !ok {
+			slog.Error("sprints plugin is not a SprintPlugin", "plugin", pluginName)
+			return nil
+		}
+		pluginParams := model.DecodePluginViewParams(params)
+		if f.wikiControllerFactory != nil {
+			f.pluginControllers[pluginName] = f.wikiControllerFactory(pluginDef, pluginParams.TikiID)
+		} else if dc, ok := pluginControllerInterface.(*controller.WikiController); ok {
+			dc.SetSelectedTikiID(pluginParams.TikiID)
+		}
+		return NewSprintView(sprintPlugin, f.imageManager, f.mermaidOpts, f.globalActions, f.tikiStore, pluginParams.TikiID)
+	case plugin.KindActivity:
+		activityPlugin, ok := pluginDef.(*plugin.ActivityPlugin)
+		if !ok {
+			slog.Error("activity plugin is not an ActivityPlugin", "plugin", pluginName)
+			return nil
+		}
+		pluginParams := model.DecodePluginViewParams(params)
+		if f.wikiControllerFactory != nil {
+			f.pluginControllers[pluginName] = f.wikiControllerFactory(pluginDef, pluginParams.TikiID)
+		} else if dc, ok := pluginControllerInterface.(*controller.WikiController); ok {
+			dc.SetSelectedTikiID(pluginParams.TikiID)
+		}
+		return NewActivityView(activityPlugin, f.imageManager, f.mermaidOpts, f.globalActions, f.tikiStore, f.activitySource, pluginParams.TikiID)
 	case plugin.KindDetail:
 		detailPlugin, ok := pluginDef.(*plugin.DetailPlugin)
 		if !ok {
@@ -211,6 +287,13 @@ func (f *ViewFactory) CreateView(viewID model.ViewID, params map[string]interfac
 			dc.ApplyDetailMode(pluginParams.Mode, pluginParams.Focus, pluginParams.Draft)
 		}
 		return cv
+	case plugin.KindCalendar:
+		cc, ok := pluginControllerInterface.(*controller.CalendarController)
+		if !ok {
+			slog.Error("calendar plugin has no CalendarController", "plugin", pluginName)
+			return nil
+		}
+		return NewCalendarView(f.tikiStore, cc)
 	default:
 		slog.Error("unknown plugin kind", "plugin", pluginName, "kind", pluginDef.GetKind())
 		return nil
@@ -27,13 +27,17 @@ const (
 	SemanticRecurrence SemanticType = "recurrence"
 	SemanticStringList SemanticType = "string_list"
 	SemanticTikiIDList SemanticType = "tiki_id_list"
+	SemanticTikiID     SemanticType = "tiki_id"
+	SemanticEnumList   SemanticType = "enum_list"
+	SemanticURL        SemanticType = "url"
+	SemanticNumber     SemanticType = "number"
 )
 
 // ForValueType bridges the workflow catalog's ValueType to a SemanticType, so
 // classification resolves even for catalog-only fields that have no static
 // descriptor (e.g. user-declared enums or a custom datetime like dueBy). The
-// string family (TypeString/TypeID/TypeRef/TypeDuration) and any unmapped type
-// fall through to SemanticText.
+// string family (TypeString/TypeID/TypeDuration) and any unmapped type fall
+// through to SemanticText.
 func ForValueType(t workflow.ValueType) SemanticType {
 	switch t {
 	case workflow.TypeEnum:
@@ -54,6 +58,14 @@ func ForValueType(t workflow.ValueType) SemanticType {
 		return SemanticStringList
 	case workflow.TypeListRef:
 		return SemanticTikiIDList
+	case workflow.TypeRef:
+		return SemanticTikiID
+	case workflow.TypeEnumList:
+		return SemanticEnumList
+	case workflow.TypeURL:
+		return SemanticURL
+	case workflow.TypeNumber:
+		return SemanticNumber
 	}
 	return SemanticText
 }
@@ -77,6 +89,10 @@ var editableSemantics = map[SemanticType]bool{
 	SemanticRecurrence: true,
 	SemanticStringList: true,
 	SemanticTikiIDList: false,
+	SemanticTikiID:     true,
+	SemanticEnumList:   true,
+	SemanticURL:        true,
+	SemanticNumber:     true,
 }
 
 // readOnlyFields names the fields that must never be edited. They are the
@@ -97,8 +113,14 @@ func SemanticEditable(sem SemanticType) bool {
 }
 
 // FieldIsReadOnly reports whether the named field must never be edited.
+// Besides the computed system fields, that covers the dependency-derived
+// fields and workflow fields declared with a computed: expression.
 func FieldIsReadOnly(name string) bool {
-	return readOnlyFields[name]
+	if readOnlyFields[name] {
+		return true
+	}
+	wfd, ok := workflow.Field(name)
+	return ok && (wfd.Derived || wfd.IsComputed())
 }
 
 // FieldHasEditor reports whether the user can focus and edit the named field.
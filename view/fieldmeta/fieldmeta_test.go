@@ -22,6 +22,10 @@ func TestForValueType(t *testing.T) {
 		{"recurrence", workflow.TypeRecurrence, SemanticRecurrence},
 		{"string list", workflow.TypeListString, SemanticStringList},
 		{"tiki id list", workflow.TypeListRef, SemanticTikiIDList},
+		{"tiki id", workflow.TypeRef, SemanticTikiID},
+		{"enum list", workflow.TypeEnumList, SemanticEnumList},
+		{"url", workflow.TypeURL, SemanticURL},
+		{"number", workflow.TypeNumber, SemanticNumber},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
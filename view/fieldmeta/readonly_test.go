@@ -0,0 +1,28 @@
+package fieldmeta_test
+
+import (
+	"testing"
+
+	"github.com/boolean-maybe/tiki/internal/teststatuses"
+	"github.com/boolean-maybe/tiki/view/fieldmeta"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+func TestFieldIsReadOnly_ComputedFields(t *testing.T) {
+	t.Cleanup(teststatuses.Init)
+	if err := teststatuses.InitWith([]workflow.FieldDef{
+		{Name: "effort", Type: workflow.TypeInt},
+		{Name: "score", Type: workflow.TypeInt, Computed: "effort + 1"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]bool{"createdAt": true, "score": true, "effort": false} {
+		if got := fieldmeta.FieldIsReadOnly(name); got != want {
+			t.Errorf("FieldIsReadOnly(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if fieldmeta.FieldHasEditor("score") {
+		t.Error("computed field must not have an editor")
+	}
+}
@@ -17,6 +17,7 @@ type GradientCaptionRow struct {
 	*tview.Box
 	laneNames  []string
 	laneWidths []int
+	laneWarn   []bool // lanes drawn on the warning color instead of the gradient
 	paint      theme.PositionPaint
 	textColor  theme.Color
 }
@@ -41,6 +42,13 @@ func NewGradientCaptionRow(laneNames []string, laneWidths []int, bgRole theme.Ro
 	}
 }
 
+// SetCaptions replaces the lane captions. Lanes flagged in warn (e.g. over
+// their WIP limit) are drawn on the theme's warning color.
+func (gcr *GradientCaptionRow) SetCaptions(laneNames []string, warn []bool) {
+	gcr.laneNames = laneNames
+	gcr.laneWarn = warn
+}
+
 // Draw renders all lane captions with a screen-wide gradient background.
 func (gcr *GradientCaptionRow) Draw(screen tcell.Screen) {
 	gcr.DrawForSubclass(screen, gcr)
@@ -77,6 +85,9 @@ func (gcr *GradientCaptionRow) Draw(screen tcell.Screen) {
 		}
 
 		bgColor := gcr.bgColorAt(t)
+		if laneIndex < len(gcr.laneWarn) && gcr.laneWarn[laneIndex] {
+			bgColor = theme.Roles().StatusWarn().TCell()
+		}
 
 		currentLaneWidth := laneEnds[laneIndex] - laneStarts[laneIndex]
 		posInLane := col - laneStarts[laneIndex]
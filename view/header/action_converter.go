@@ -16,6 +16,7 @@ func modelActionToControllerAction(a model.HeaderAction) controller.Action {
 		Label:        a.Label,
 		Modifier:     a.Modifier,
 		ShowInHeader: a.ShowInHeader,
+		Then:         a.Then,
 	}
 }
 
@@ -7,9 +7,9 @@ import (
 	"github.com/boolean-maybe/tiki/controller"
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/theme"
-	"github.com/boolean-maybe/tiki/util"
 	"github.com/boolean-maybe/tiki/view/grid"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -21,6 +21,7 @@ type cellData struct {
 	labelLen  int
 	colorType int // 0=global, 1=plugin, 2=view
 	enabled   bool
+	strokes   []model.KeyStroke // keys replayed when the cell is clicked
 }
 
 const (
@@ -33,6 +34,13 @@ const (
 type ContextHelpWidget struct {
 	*tview.TextView
 	width int // calculated visible width of content
+
+	// click geometry of the last render: cells by row and column, and each
+	// column's start offset and width in screen cells
+	cells       [][]cellData
+	colStarts   []int
+	colWidths   []int
+	dispatchKey func(event *tcell.EventKey)
 }
 
 // NewContextHelpWidget creates a new context help display widget
@@ -73,6 +81,43 @@ func (chw *ContextHelpWidget) SetActionsFromModel(globalActions, viewActions, pl
 	return chw.renderActionsGridWithEnabled(globalConverted, pluginConverted, viewConverted, globalEnabled, pluginEnabled, viewEnabled)
 }
 
+// SetKeyDispatcher sets the function clicked actions are replayed through.
+func (chw *ContextHelpWidget) SetKeyDispatcher(fn func(event *tcell.EventKey)) {
+	chw.dispatchKey = fn
+}
+
+// ClickAt runs the action whose cell is at the given screen position by
+// replaying its key(s). Disabled actions and gaps between cells are ignored.
+func (chw *ContextHelpWidget) ClickAt(x, y int) bool {
+	cell, ok := chw.cellAt(x, y)
+	if !ok || chw.dispatchKey == nil {
+		return false
+	}
+	for _, ks := range cell.strokes {
+		chw.dispatchKey(tcell.NewEventKey(ks.Key, ks.Rune, ks.Modifier))
+	}
+	return true
+}
+
+func (chw *ContextHelpWidget) cellAt(x, y int) (cellData, bool) {
+	left, top, _, _ := chw.GetInnerRect()
+	row, offset := y-top, x-left
+	if row < 0 || row >= len(chw.cells) {
+		return cellData{}, false
+	}
+	for col, start := range chw.colStarts {
+		if offset < start || offset >= start+chw.colWidths[col] {
+			continue
+		}
+		cell := chw.cells[row][col]
+		if cell.key == "" || !cell.enabled || offset >= start+cell.keyLen+1+cell.labelLen {
+			return cellData{}, false
+		}
+		return cell, true
+	}
+	return cellData{}, false
+}
+
 // Primitive returns the underlying tview primitive
 func (chw *ContextHelpWidget) Primitive() tview.Primitive {
 	return chw.TextView
@@ -96,6 +141,7 @@ func (chw *ContextHelpWidget) renderActionsGridWithEnabled(
 	if dims.totalCols == 0 {
 		chw.SetText("")
 		chw.width = 0
+		chw.cells, chw.colStarts, chw.colWidths = nil, nil, nil
 		return 0
 	}
 
@@ -113,6 +159,17 @@ func (chw *ContextHelpWidget) renderActionsGridWithEnabled(
 	lines := buildOutputLines(gridData, maxKeyLenPerCol, maxLabelLenPerCol, numRows, dims.totalCols)
 	chw.SetText(" " + strings.Join(lines, "\n "))
 
+	// Record where each column landed (after the leading space) for clicks
+	chw.cells = gridData
+	chw.colStarts = make([]int, dims.totalCols)
+	chw.colWidths = make([]int, dims.totalCols)
+	start := 1
+	for col := 0; col < dims.totalCols; col++ {
+		chw.colStarts[col] = start
+		chw.colWidths[col] = maxKeyLenPerCol[col] + 1 + maxLabelLenPerCol[col]
+		start += chw.colWidths[col] + HeaderColumnSpacing
+	}
+
 	// Calculate and store width
 	chw.width = calculateMaxLineWidth(lines) + 1
 	return chw.width
@@ -166,7 +223,7 @@ func fillGridSectionWithEnabled(gridData [][]cellData, actions []controller.Acti
 
 		col := colOffset + i/numRows
 		row := i % numRows
-		keyStr := util.FormatKeyBinding(action.Key, action.Rune, action.Modifier)
+		keyStr := action.KeyString()
 
 		enabled := true
 		if e, ok := enabledMap[action.ID]; ok {
@@ -180,6 +237,7 @@ func fillGridSectionWithEnabled(gridData [][]cellData, actions []controller.Acti
 			labelLen:  len([]rune(action.Label)),
 			colorType: colorType,
 			enabled:   enabled,
+			strokes:   append([]model.KeyStroke{{Key: action.Key, Rune: action.Rune, Modifier: action.Modifier}}, action.Then...),
 		}
 	}
 }
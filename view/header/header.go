@@ -85,11 +85,29 @@ func NewHeaderWidget(headerConfig *model.HeaderConfig, viewContext *model.ViewCo
 		hw.viewContextListenerID = viewContext.AddListener(hw.rebuild)
 	}
 
+	// the header is display-only apart from clickable action cells; swallow
+	// every other mouse event so clicks never take focus from the view
+	flex.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if !flex.InRect(event.Position()) {
+			return action, event
+		}
+		if action == tview.MouseLeftClick {
+			contextHelp.ClickAt(event.Position())
+		}
+		return tview.MouseConsumed, nil
+	})
+
 	hw.rebuild()
 	hw.rebuildLayout(0)
 	return hw
 }
 
+// SetKeyDispatcher sets the function clicked header actions are replayed
+// through (normally the input router).
+func (h *HeaderWidget) SetKeyDispatcher(fn func(event *tcell.EventKey)) {
+	h.contextHelp.SetKeyDispatcher(fn)
+}
+
 // rebuild reads data from ViewContext (view info + actions).
 func (h *HeaderWidget) rebuild() {
 	if h.viewContext != nil {
@@ -0,0 +1,502 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boolean-maybe/tiki/theme"
+
+	nav "github.com/boolean-maybe/navidown/navidown"
+	navtview "github.com/boolean-maybe/navidown/navidown/tview"
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/tview"
+)
+
+// Heading is one entry of a document's outline.
+type Heading struct {
+	Text  string
+	Level int
+	Slug  string
+	Line  int // rendered line the heading starts on
+}
+
+// searchMatch is one occurrence of the search query, in screen cells of a
+// rendered line.
+type searchMatch struct {
+	line, col, width int
+}
+
+// ansiEscapePattern matches the SGR and OSC sequences the renderer emits.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;:]*[A-Za-z]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// plainLine returns a rendered line as it appears on screen: no escape
+// sequences and no navidown position markers.
+func plainLine(line string) string {
+	return nav.StripMarkers(ansiEscapePattern.ReplaceAllString(line, ""))
+}
+
+// findMatches returns every occurrence of query in lines. The search is
+// case-insensitive unless the query contains an upper-case letter.
+func findMatches(lines []string, query string) []searchMatch {
+	if query == "" {
+		return nil
+	}
+	fold := !strings.ContainsFunc(query, unicode.IsUpper)
+	needle := []rune(query)
+	if fold {
+		needle = []rune(strings.ToLower(query))
+	}
+	var matches []searchMatch
+	for i, line := range lines {
+		hay := []rune(plainLine(line))
+		cells := make([]int, len(hay)+1)
+		for j, r := range hay {
+			cells[j+1] = cells[j] + runewidth.RuneWidth(r)
+			if fold {
+				hay[j] = unicode.ToLower(r)
+			}
+		}
+		for j := 0; j+len(needle) <= len(hay); j++ {
+			if runesEqual(hay[j:j+len(needle)], needle) {
+				matches = append(matches, searchMatch{line: i, col: cells[j], width: cells[j+len(needle)] - cells[j]})
+				j += len(needle) - 1
+			}
+		}
+	}
+	return matches
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameLines reports whether a and b are the same rendering; the session
+// replaces its line slice on every re-render.
+func sameLines(a, b []string) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// View returns the primitive to embed for a searchable document: the viewer
+// with search matches highlighted, the outline pane beside it when shown and
+// the search prompt below it while typing.
+func (nm *NavigableMarkdown) View() tview.Primitive {
+	return nm.layout
+}
+
+// SetFocusFunc sets how the search prompt and the outline take and hand back
+// focus (tview.Application.SetFocus).
+func (nm *NavigableMarkdown) SetFocusFunc(focus func(p tview.Primitive)) {
+	nm.focus = focus
+}
+
+func (nm *NavigableMarkdown) setFocus(p tview.Primitive) {
+	if nm.focus != nil {
+		nm.focus(p)
+	}
+}
+
+// arrange lays out the outline, the viewer and the search prompt.
+func (nm *NavigableMarkdown) arrange() {
+	nm.body.Clear()
+	if nm.showOutline {
+		nm.body.AddItem(nm.outline, outlineWidth, 0, false)
+	}
+	nm.body.AddItem(nm.pane, 0, 1, true)
+	nm.layout.Clear()
+	nm.layout.AddItem(nm.body, 0, 1, true)
+	if nm.searching {
+		nm.layout.AddItem(nm.prompt, 1, 0, false)
+	}
+}
+
+// StartSearch opens the search prompt. Matches are highlighted as the query
+// is typed; Enter keeps them, Esc restores the previous query and position.
+func (nm *NavigableMarkdown) StartSearch() {
+	nm.searching = true
+	nm.searchOrigin = nm.viewer.Core().ScrollOffset()
+	nm.previousQuery = nm.query
+	nm.prompt.SetText("")
+	nm.arrange()
+	nm.setFocus(nm.prompt)
+}
+
+func (nm *NavigableMarkdown) closePrompt() {
+	nm.searching = false
+	nm.arrange()
+	nm.setFocus(nm.pane)
+}
+
+// Search highlights every occurrence of query and scrolls to the first one
+// at or below the top of the view. An empty query clears the search. It
+// returns the number of matches.
+func (nm *NavigableMarkdown) Search(query string) int {
+	nm.query = query
+	nm.matchedLines = nil
+	nm.refreshMatches()
+	nm.current = -1
+	top := nm.viewer.Core().ScrollOffset()
+	for i, m := range nm.matches {
+		if m.line >= top {
+			nm.current = i
+			break
+		}
+	}
+	if nm.current < 0 && len(nm.matches) > 0 {
+		nm.current = 0
+	}
+	nm.showCurrentMatch()
+	return len(nm.matches)
+}
+
+// ClearSearch drops the query and its highlighting.
+func (nm *NavigableMarkdown) ClearSearch() {
+	nm.Search("")
+}
+
+// NextMatch moves to the next match, wrapping at the end of the document.
+func (nm *NavigableMarkdown) NextMatch() bool {
+	return nm.stepMatch(1)
+}
+
+// PrevMatch moves to the previous match, wrapping at the start.
+func (nm *NavigableMarkdown) PrevMatch() bool {
+	return nm.stepMatch(-1)
+}
+
+func (nm *NavigableMarkdown) stepMatch(delta int) bool {
+	nm.refreshMatches()
+	if len(nm.matches) == 0 {
+		return false
+	}
+	if nm.current < 0 && delta < 0 {
+		nm.current = 0 // so N starts from the last match
+	}
+	nm.current = (nm.current + delta + len(nm.matches)) % len(nm.matches)
+	nm.showCurrentMatch()
+	return true
+}
+
+// SearchStatus returns the active query, the 1-based position of the current
+// match and the number of matches.
+func (nm *NavigableMarkdown) SearchStatus() (query string, current, total int) {
+	nm.refreshMatches()
+	return nm.query, nm.current + 1, len(nm.matches)
+}
+
+// refreshMatches re-runs the query when the document was re-rendered
+// (resize, link followed, history) since the matches were found.
+func (nm *NavigableMarkdown) refreshMatches() {
+	lines := nm.viewer.Core().RenderedLines()
+	if sameLines(lines, nm.matchedLines) {
+		return
+	}
+	nm.matchedLines = lines
+	nm.markedLine = -1
+	nm.matches = findMatches(lines, nm.query)
+	if nm.current >= len(nm.matches) {
+		nm.current = len(nm.matches) - 1
+	}
+}
+
+// showCurrentMatch scrolls the current match into view, a third of the way
+// down so the lines before it give context.
+func (nm *NavigableMarkdown) showCurrentMatch() {
+	if nm.current >= 0 && nm.current < len(nm.matches) {
+		line := nm.matches[nm.current].line
+		_, _, _, height := nm.viewer.GetInnerRect()
+		top := nm.viewer.Core().ScrollOffset()
+		if line < top || line >= top+height {
+			nm.scrollToLine(max(line-height/3, 0))
+		}
+		nm.markLine(line)
+	}
+	nm.stateChanged()
+}
+
+// scrollToLine moves both the session and the text view so row is the top
+// line, clamped to the document.
+func (nm *NavigableMarkdown) scrollToLine(row int) {
+	_, _, _, height := nm.viewer.GetInnerRect()
+	core := nm.viewer.Core()
+	for core.ScrollOffset() > row && core.ScrollUp(height) {
+	}
+	for core.ScrollOffset() < row && core.ScrollDown(height) {
+	}
+	nm.viewer.ScrollTo(core.ScrollOffset(), 0)
+}
+
+// Headings returns the document's headings in order.
+func (nm *NavigableMarkdown) Headings() []Heading {
+	var out []Heading
+	for _, elem := range nm.viewer.Core().Elements() {
+		if elem.Type == nav.NavElementHeader && elem.Text != "" {
+			out = append(out, Heading{Text: elem.Text, Level: elem.Level, Slug: elem.Slug, Line: elem.StartLine})
+		}
+	}
+	return out
+}
+
+// Breadcrumb returns the headings enclosing the line being read, outermost
+// first: the line a search or outline jump landed on, or the top line of the
+// view once it has been scrolled.
+func (nm *NavigableMarkdown) Breadcrumb() []Heading {
+	nm.refreshMatches()
+	line := nm.viewer.Core().ScrollOffset()
+	if nm.markedLine >= 0 && nm.markedTop == line {
+		line = nm.markedLine
+	}
+	return breadcrumbAt(nm.Headings(), line)
+}
+
+// markLine records the line a jump landed on for the breadcrumb, valid until
+// the view scrolls away.
+func (nm *NavigableMarkdown) markLine(line int) {
+	nm.markedLine = line
+	nm.markedTop = nm.viewer.Core().ScrollOffset()
+}
+
+func breadcrumbAt(headings []Heading, line int) []Heading {
+	var trail []Heading
+	for _, h := range headings {
+		if h.Line > line {
+			break
+		}
+		for len(trail) > 0 && trail[len(trail)-1].Level >= h.Level {
+			trail = trail[:len(trail)-1]
+		}
+		trail = append(trail, h)
+	}
+	return trail
+}
+
+// BreadcrumbText joins the breadcrumb for a statusline.
+func (nm *NavigableMarkdown) BreadcrumbText() string {
+	trail := nm.Breadcrumb()
+	parts := make([]string, len(trail))
+	for i, h := range trail {
+		parts[i] = h.Text
+	}
+	return strings.Join(parts, " › ")
+}
+
+// JumpToHeading scrolls the heading to the top of the view.
+func (nm *NavigableMarkdown) JumpToHeading(h Heading) {
+	nm.scrollToLine(h.Line)
+	nm.markLine(h.Line)
+	nm.stateChanged()
+}
+
+// ToggleOutline opens the outline and moves focus to it. With the outline
+// open it moves focus there from the document, and closes it when the
+// outline already has focus.
+func (nm *NavigableMarkdown) ToggleOutline() {
+	switch {
+	case !nm.showOutline:
+		nm.showOutline = true
+		nm.fillOutline()
+		nm.arrange()
+		nm.setFocus(nm.outline)
+	case !nm.outline.HasFocus():
+		nm.setFocus(nm.outline)
+	default:
+		nm.closeOutline()
+	}
+}
+
+// IsOutlineShown reports whether the outline pane is open.
+func (nm *NavigableMarkdown) IsOutlineShown() bool {
+	return nm.showOutline
+}
+
+func (nm *NavigableMarkdown) closeOutline() {
+	nm.showOutline = false
+	nm.arrange()
+	nm.setFocus(nm.pane)
+}
+
+// fillOutline lists the headings, indented by level, with the current
+// section selected.
+func (nm *NavigableMarkdown) fillOutline() {
+	nm.outline.headings = nm.Headings()
+	nm.outline.Clear()
+	for _, h := range nm.outline.headings {
+		nm.outline.AddItem(strings.Repeat("  ", max(h.Level-1, 0))+h.Text, "", 0, nil)
+	}
+	nm.syncOutline()
+}
+
+// syncOutline selects the section the view is in.
+func (nm *NavigableMarkdown) syncOutline() {
+	if !nm.showOutline || nm.outline.HasFocus() {
+		return
+	}
+	if trail := nm.Breadcrumb(); len(trail) > 0 {
+		last := trail[len(trail)-1]
+		for i, h := range nm.outline.headings {
+			if h == last {
+				nm.outline.SetCurrentItem(i)
+				break
+			}
+		}
+	}
+}
+
+// stateChanged runs after the session moved (scroll, link, history): the
+// outline follows the document and the owner refreshes its statusline.
+func (nm *NavigableMarkdown) stateChanged() {
+	if nm.showOutline && !sameHeadings(nm.outline.headings, nm.Headings()) {
+		nm.fillOutline()
+	}
+	nm.syncOutline()
+	if nm.onStateChange != nil {
+		nm.onStateChange()
+	}
+}
+
+func sameHeadings(a, b []Heading) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// outlineWidth is the width of the outline pane in cells.
+const outlineWidth = 32
+
+// outlinePane lists the document's headings. Enter jumps to the selected
+// heading and returns to the document; Esc closes the pane.
+type outlinePane struct {
+	*tview.List
+	headings []Heading
+	nm       *NavigableMarkdown
+}
+
+func newOutlinePane(nm *NavigableMarkdown) *outlinePane {
+	roles := theme.Roles()
+	list := tview.NewList().ShowSecondaryText(false).SetHighlightFullLine(true)
+	list.SetMainTextColor(roles.TextSecondary().TCell())
+	list.SetSelectedTextColor(roles.TextPrimary().TCell())
+	list.SetSelectedBackgroundColor(roles.SurfaceSelection().TCell())
+	list.SetBackgroundColor(roles.SurfaceCanvas().TCell())
+	list.SetBorder(true).SetTitle(" Outline ")
+	list.SetBorderColor(roles.BorderIdle().TCell())
+	o := &outlinePane{List: list, nm: nm}
+	list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		if i < len(o.headings) {
+			nm.JumpToHeading(o.headings[i])
+			nm.setFocus(nm.pane)
+		}
+	})
+	return o
+}
+
+// CapturesKey claims Esc (close the outline) and Enter (jump) ahead of the
+// view's own bindings.
+func (o *outlinePane) CapturesKey(event *tcell.EventKey) bool {
+	return event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter
+}
+
+// InputHandler adds j/k and Esc to the list's own keys.
+func (o *outlinePane) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return o.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			o.nm.closeOutline()
+			return
+		case event.Key() == tcell.KeyRune && event.Rune() == 'j':
+			event = tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+		case event.Key() == tcell.KeyRune && event.Rune() == 'k':
+			event = tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+		}
+		if handler := o.List.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// searchPrompt is the `/` line under the document. Every key is typing
+// while it has focus.
+type searchPrompt struct {
+	*tview.InputField
+}
+
+func newSearchPrompt(nm *NavigableMarkdown) *searchPrompt {
+	roles := theme.Roles()
+	field := tview.NewInputField().SetLabel("/")
+	field.SetLabelColor(roles.TextPrimary().TCell())
+	field.SetFieldBackgroundColor(roles.SurfaceCanvas().TCell())
+	field.SetFieldTextColor(roles.TextPrimary().TCell())
+	field.SetBackgroundColor(roles.SurfaceCanvas().TCell())
+	field.SetChangedFunc(func(text string) {
+		if nm.searching {
+			nm.Search(text)
+		}
+	})
+	field.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			nm.closePrompt()
+		case tcell.KeyEscape:
+			nm.searching = false
+			nm.Search(nm.previousQuery)
+			nm.scrollToLine(nm.searchOrigin)
+			nm.closePrompt()
+			nm.stateChanged()
+		}
+	})
+	return &searchPrompt{InputField: field}
+}
+
+// CapturesKey claims every key: the prompt is a text field.
+func (p *searchPrompt) CapturesKey(*tcell.EventKey) bool { return true }
+
+// searchPane draws the viewer and then marks the search matches on top of
+// it, so the highlighting survives navidown rebuilding its text.
+type searchPane struct {
+	*navtview.TextViewViewer
+	nm *NavigableMarkdown
+}
+
+// Draw renders the document, then restyles the visible matches.
+func (p *searchPane) Draw(screen tcell.Screen) {
+	p.TextViewViewer.Draw(screen)
+	p.nm.refreshMatches()
+	if len(p.nm.matches) == 0 {
+		return
+	}
+	roles := theme.Roles()
+	x, y, width, height := p.GetInnerRect()
+	row, col := p.GetScrollOffset()
+	for i, m := range p.nm.matches {
+		if m.line < row || m.line >= row+height {
+			continue
+		}
+		style := tcell.StyleDefault.Background(roles.SurfaceSelection().TCell()).Foreground(roles.TextPrimary().TCell())
+		if i == p.nm.current {
+			style = tcell.StyleDefault.Background(roles.Highlight().TCell()).Foreground(roles.SurfaceCanvas().TCell()).Bold(true)
+		}
+		sy := y + m.line - row
+		for cell := m.col; cell < m.col+m.width; {
+			sx := x + cell - col
+			if sx < x || sx >= x+width {
+				cell++
+				continue
+			}
+			str, _, w := screen.Get(sx, sy)
+			screen.Put(sx, sy, str, style)
+			cell += max(w, 1)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/theme"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFindMatches_SmartCaseAndCells(t *testing.T) {
+	lines := []string{"\x1b[1mFoo\x1b[0m foo", "日本 foo"}
+	got := findMatches(lines, "foo")
+	want := []searchMatch{{0, 0, 3}, {0, 4, 3}, {1, 5, 3}}
+	if len(got) != len(want) {
+		t.Fatalf("matches = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if got := findMatches(lines, "Foo"); len(got) != 1 {
+		t.Errorf("an upper-case query is case-sensitive, got %+v", got)
+	}
+}
+
+func TestBreadcrumbAt(t *testing.T) {
+	headings := []Heading{
+		{Text: "Design", Level: 1, Line: 0},
+		{Text: "Storage", Level: 2, Line: 10},
+		{Text: "Files", Level: 3, Line: 20},
+		{Text: "API", Level: 2, Line: 30},
+	}
+	names := func(hs []Heading) string {
+		parts := make([]string, len(hs))
+		for i, h := range hs {
+			parts[i] = h.Text
+		}
+		return strings.Join(parts, "/")
+	}
+	for line, want := range map[int]string{0: "Design", 15: "Design/Storage", 25: "Design/Storage/Files", 35: "Design/API"} {
+		if got := names(breadcrumbAt(headings, line)); got != want {
+			t.Errorf("breadcrumb at %d = %q, want %q", line, got, want)
+		}
+	}
+}
+
+// longDocument has "needle" once near the top and once under a later
+// heading, well below the first screen.
+func longDocument() string {
+	var b strings.Builder
+	b.WriteString("# Guide\n\nfind the needle here\n\n## Setup\n\n")
+	for i := 0; i < 40; i++ {
+		b.WriteString("filler paragraph\n\n")
+	}
+	b.WriteString("## Usage\n\nanother needle\n")
+	return b.String()
+}
+
+func TestNavigableMarkdown_SearchMovesBetweenMatches(t *testing.T) {
+	theme.SetTheme(theme.LoadByName("dark"))
+	nm := NewNavigableMarkdown(NavigableMarkdownConfig{})
+	nm.View().SetRect(0, 0, 60, 10)
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(60, 10)
+	nm.View().Draw(screen)
+	nm.SetMarkdown(longDocument())
+
+	if n := nm.Search("needle"); n != 2 {
+		t.Fatalf("Search found %d matches, want 2", n)
+	}
+	if _, current, _ := nm.SearchStatus(); current != 1 {
+		t.Errorf("current = %d, want the first match", current)
+	}
+	nm.NextMatch()
+	second := nm.matches[1].line
+	top := nm.viewer.Core().ScrollOffset()
+	if second < top || second >= top+10 {
+		t.Errorf("second match on line %d is outside the view at %d", second, top)
+	}
+	if got := nm.BreadcrumbText(); !strings.HasSuffix(got, "Usage") {
+		t.Errorf("breadcrumb at the second match = %q, want the Usage section", got)
+	}
+	nm.NextMatch()
+	if _, current, _ := nm.SearchStatus(); current != 1 {
+		t.Errorf("n past the last match should wrap to the first, got %d", current)
+	}
+	nm.PrevMatch()
+	if _, current, _ := nm.SearchStatus(); current != 2 {
+		t.Errorf("N before the first match should wrap to the last, got %d", current)
+	}
+
+	// the pane restyles the current match after the text is drawn
+	nm.View().Draw(screen)
+	m := nm.matches[nm.current]
+	_, style, _ := screen.Get(m.col, m.line-nm.viewer.Core().ScrollOffset())
+	if _, bg, _ := style.Decompose(); bg != theme.Roles().Highlight().TCell() {
+		t.Errorf("current match not highlighted, style %+v", style)
+	}
+
+	nm.ClearSearch()
+	if query, _, total := nm.SearchStatus(); query != "" || total != 0 {
+		t.Errorf("ClearSearch left %q with %d matches", query, total)
+	}
+}
+
+func TestNavigableMarkdown_OutlineJumpsToHeading(t *testing.T) {
+	theme.SetTheme(theme.LoadByName("dark"))
+	nm := NewNavigableMarkdown(NavigableMarkdownConfig{})
+	nm.View().SetRect(0, 0, 80, 10)
+	nm.View().Draw(tcell.NewSimulationScreen(""))
+	nm.SetMarkdown(longDocument())
+	headings := nm.Headings()
+	if len(headings) != 3 || headings[2].Text != "Usage" {
+		t.Fatalf("headings = %+v", headings)
+	}
+
+	nm.ToggleOutline()
+	if !nm.IsOutlineShown() || nm.outline.GetItemCount() != 3 {
+		t.Fatalf("outline shown=%v with %d items", nm.IsOutlineShown(), nm.outline.GetItemCount())
+	}
+	nm.outline.SetCurrentItem(2)
+	nm.outline.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	if top := nm.viewer.Core().ScrollOffset(); top == 0 {
+		t.Error("Enter in the outline did not scroll to the heading")
+	}
+	if got := nm.BreadcrumbText(); got != "Guide › Usage" {
+		t.Errorf("breadcrumb after the jump = %q", got)
+	}
+
+	nm.outline.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), nil)
+	if nm.IsOutlineShown() {
+		t.Error("Esc should close the outline")
+	}
+}
@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/boolean-maybe/tiki/config"
@@ -9,6 +10,8 @@ import (
 	nav "github.com/boolean-maybe/navidown/navidown"
 	navtview "github.com/boolean-maybe/navidown/navidown/tview"
 	navutil "github.com/boolean-maybe/navidown/util"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 )
 
 // NavigableMarkdown wraps navidown TextViewViewer with link/anchor handling.
@@ -17,6 +20,26 @@ type NavigableMarkdown struct {
 	provider      nav.ContentProvider
 	searchRoots   []string
 	onStateChange func()
+	openExternal  func(path string)
+	externalDir   string
+
+	// in-document search and outline; see document_nav.go
+	layout        *tview.Flex
+	body          *tview.Flex
+	pane          *searchPane
+	outline       *outlinePane
+	prompt        *searchPrompt
+	focus         func(p tview.Primitive)
+	showOutline   bool
+	searching     bool
+	query         string
+	previousQuery string
+	searchOrigin  int
+	matches       []searchMatch
+	matchedLines  []string
+	current       int
+	markedLine    int
+	markedTop     int
 }
 
 // NavigableMarkdownConfig configures a NavigableMarkdown component.
@@ -26,6 +49,12 @@ type NavigableMarkdownConfig struct {
 	OnStateChange  func() // called on navigation state changes
 	ImageManager   *navtview.ImageManager
 	MermaidOptions *nav.MermaidOptions // nil = disabled
+	// OpenExternal opens a linked local non-markdown file (an attachment)
+	// inside ExternalDir with the system's default application. nil or an
+	// empty ExternalDir = such links are fetched like any other cross-file
+	// link.
+	OpenExternal func(path string)
+	ExternalDir  string
 }
 
 // NewNavigableMarkdown creates a new navigable markdown viewer.
@@ -35,6 +64,9 @@ func NewNavigableMarkdown(cfg NavigableMarkdownConfig) *NavigableMarkdown {
 		provider:      cfg.Provider,
 		searchRoots:   cfg.SearchRoots,
 		onStateChange: cfg.OnStateChange,
+		openExternal:  cfg.OpenExternal,
+		externalDir:   cfg.ExternalDir,
+		markedLine:    -1,
 	}
 	nm.viewer.SetAnsiConverter(navutil.NewAnsiConverter(true))
 	renderer := nav.NewANSIRendererWithStyle(config.GetNavidownStyle())
@@ -53,14 +85,20 @@ func NewNavigableMarkdown(cfg NavigableMarkdownConfig) *NavigableMarkdown {
 		nm.viewer.SetImageManager(cfg.ImageManager)
 	}
 	nm.viewer.SetStateChangedHandler(func(_ *navtview.TextViewViewer) {
-		if nm.onStateChange != nil {
-			nm.onStateChange()
-		}
+		nm.stateChanged()
 	})
 	if cfg.MermaidOptions != nil {
 		nm.viewer.Core().SetMermaidOptions(cfg.MermaidOptions)
 	}
-	nm.setupSelectHandler()
+	nm.viewer.SetSelectHandler(nm.followLink)
+	nm.viewer.SetMouseCapture(nm.handleMouse)
+
+	nm.pane = &searchPane{TextViewViewer: nm.viewer, nm: nm}
+	nm.outline = newOutlinePane(nm)
+	nm.prompt = newSearchPrompt(nm)
+	nm.body = tview.NewFlex()
+	nm.layout = tview.NewFlex().SetDirection(tview.FlexRow)
+	nm.arrange()
 	return nm
 }
 
@@ -69,35 +107,79 @@ func (nm *NavigableMarkdown) Close() {
 	nm.viewer.Core().Close()
 }
 
-func (nm *NavigableMarkdown) setupSelectHandler() {
-	nm.viewer.SetSelectHandler(func(v *navtview.TextViewViewer, elem nav.NavElement) {
-		if elem.Type != nav.NavElementURL {
-			return
-		}
-		// Internal anchor (same file)
-		if elem.IsInternalLink() {
-			v.ScrollToAnchor(elem.AnchorTarget(), true)
+// followLink opens a link selected with Enter or clicked with the mouse.
+func (nm *NavigableMarkdown) followLink(v *navtview.TextViewViewer, elem nav.NavElement) {
+	if elem.Type != nav.NavElementURL {
+		return
+	}
+	// Internal anchor (same file)
+	if elem.IsInternalLink() {
+		v.ScrollToAnchor(elem.AnchorTarget(), true)
+		return
+	}
+	// Cross-file (possibly with anchor)
+	path, fragment := splitURLFragment(elem.URL)
+	if nm.openExternal != nil {
+		if local, ok := externalLinkTarget(path, elem.SourceFilePath, nm.externalDir); ok {
+			nm.openExternal(local)
 			return
 		}
-		// Cross-file (possibly with anchor)
-		path, fragment := splitURLFragment(elem.URL)
-		content, err := nm.provider.FetchContent(nav.NavElement{
-			URL:            path,
-			SourceFilePath: elem.SourceFilePath,
-			Type:           elem.Type,
-		})
-		if err != nil {
-			v.SetMarkdown(FormatErrorContent(err))
-			return
+	}
+	content, err := nm.provider.FetchContent(nav.NavElement{
+		URL:            path,
+		SourceFilePath: elem.SourceFilePath,
+		Type:           elem.Type,
+	})
+	if err != nil {
+		v.SetMarkdown(FormatErrorContent(err))
+		return
+	}
+	if content == "" {
+		return
+	}
+	v.SetMarkdownWithSource(content, nm.resolveSourcePath(path, elem.SourceFilePath), true)
+	if fragment != "" {
+		v.ScrollToAnchor(fragment, false)
+	}
+}
+
+// handleMouse follows clicked links and turns the wheel into Up/Down so
+// navidown's own scroll position stays in step with the text view.
+func (nm *NavigableMarkdown) handleMouse(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	if !nm.viewer.InRect(event.Position()) {
+		return action, event
+	}
+	switch action {
+	case tview.MouseScrollUp, tview.MouseScrollDown:
+		key := tcell.KeyDown
+		if action == tview.MouseScrollUp {
+			key = tcell.KeyUp
 		}
-		if content == "" {
-			return
+		nm.viewer.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), func(tview.Primitive) {})
+		return tview.MouseConsumed, nil
+	case tview.MouseLeftClick:
+		if elem, ok := nm.linkAt(event.Position()); ok {
+			nm.followLink(nm.viewer, elem)
+			return tview.MouseConsumed, nil
 		}
-		v.SetMarkdownWithSource(content, nm.resolveSourcePath(path, elem.SourceFilePath), true)
-		if fragment != "" {
-			v.ScrollToAnchor(fragment, false)
+	}
+	return action, event
+}
+
+// linkAt returns the link rendered at the given screen position.
+func (nm *NavigableMarkdown) linkAt(x, y int) (nav.NavElement, bool) {
+	left, top, width, height := nm.viewer.GetInnerRect()
+	if x < left || x >= left+width || y < top || y >= top+height {
+		return nav.NavElement{}, false
+	}
+	row, col := nm.viewer.GetScrollOffset()
+	line, offset := row+y-top, col+x-left
+	for _, elem := range nm.viewer.Core().Elements() {
+		if elem.Type == nav.NavElementURL && elem.StartLine == line && offset >= elem.StartCol && offset < elem.EndCol {
+			return elem, true
 		}
-	})
+	}
+	return nav.NavElement{}, false
 }
 
 func (nm *NavigableMarkdown) resolveSourcePath(url, sourceFile string) string {
@@ -111,7 +193,8 @@ func (nm *NavigableMarkdown) resolveSourcePath(url, sourceFile string) string {
 	return resolved
 }
 
-// Viewer returns the underlying TextViewViewer for layout embedding.
+// Viewer returns the underlying TextViewViewer. Embed View() instead where
+// the document should be searchable.
 func (nm *NavigableMarkdown) Viewer() *navtview.TextViewViewer {
 	return nm.viewer
 }
@@ -152,6 +235,30 @@ func splitURLFragment(url string) (path, fragment string) {
 	return path, fragment
 }
 
+// externalLinkTarget resolves a link to a local file that the markdown
+// viewer cannot display (anything but markdown), relative to the linking
+// file's directory. ok is false for URLs with a scheme, markdown targets,
+// links without a source file to resolve against, and files outside dir, so
+// a document cannot hand arbitrary files to the system opener.
+func externalLinkTarget(url, sourceFile, dir string) (string, bool) {
+	if url == "" || sourceFile == "" || dir == "" || strings.Contains(url, "://") || strings.HasPrefix(url, "mailto:") {
+		return "", false
+	}
+	ext := strings.ToLower(filepath.Ext(url))
+	if ext == "" || ext == ".md" || ext == ".markdown" {
+		return "", false
+	}
+	target := filepath.FromSlash(url)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(sourceFile), target)
+	}
+	rel, err := filepath.Rel(dir, target)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return target, true
+}
+
 // FormatErrorContent formats an error as markdown content.
 func FormatErrorContent(err error) string {
 	return "# Error\n\n```\n" + err.Error() + "\n```"
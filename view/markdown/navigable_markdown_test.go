@@ -0,0 +1,83 @@
+package markdown
+
+import (
+	"path/filepath"
+	"testing"
+
+	nav "github.com/boolean-maybe/navidown/navidown"
+	"github.com/boolean-maybe/tiki/theme"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestExternalLinkTarget(t *testing.T) {
+	source := filepath.Join("root", "bugs", "crash.md")
+	assets := filepath.Join("root", "bugs", ".assets", "ABC123")
+	tests := []struct {
+		url    string
+		want   string
+		wantOK bool
+	}{
+		{".assets/ABC123/log.txt", filepath.Join("root", "bugs", ".assets", "ABC123", "log.txt"), true},
+		{"other.md", "", false},
+		{"https://example.com/a.pdf", "", false},
+		{"mailto:a@b.c", "", false},
+		{"README", "", false},
+		{"../secrets/key.pem", "", false},
+		{"run.sh", "", false},
+		{".assets/DEF456/log.txt", "", false},
+		{".assets/ABC123/../../run.sh", "", false},
+		{"/etc/passwd.txt", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := externalLinkTarget(tt.url, source, assets)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("externalLinkTarget(%q) = %q, %v; want %q, %v", tt.url, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if _, ok := externalLinkTarget("a.pdf", "", assets); ok {
+		t.Error("links without a source file must not open externally")
+	}
+	if _, ok := externalLinkTarget(".assets/ABC123/log.txt", source, ""); ok {
+		t.Error("links must not open externally without an attachment folder")
+	}
+}
+
+type recordingProvider struct {
+	fetched []string
+}
+
+func (p *recordingProvider) FetchContent(elem nav.NavElement) (string, error) {
+	p.fetched = append(p.fetched, elem.URL)
+	return "# Other", nil
+}
+
+func TestNavigableMarkdown_ClickFollowsLink(t *testing.T) {
+	theme.SetTheme(theme.LoadByName("dark"))
+	provider := &recordingProvider{}
+	nm := NewNavigableMarkdown(NavigableMarkdownConfig{Provider: provider})
+	nm.Viewer().SetRect(0, 0, 60, 10)
+	nm.SetMarkdownWithSource("intro\n\nsee [other](other.md) here", filepath.Join("docs", "a.md"), false)
+
+	var link nav.NavElement
+	for _, elem := range nm.Viewer().Core().Elements() {
+		if elem.Type == nav.NavElementURL {
+			link = elem
+		}
+	}
+	if link.URL != "other.md" {
+		t.Fatalf("rendered elements = %+v, want a link to other.md", nm.Viewer().Core().Elements())
+	}
+
+	click := func(x, y int) {
+		nm.handleMouse(tview.MouseLeftClick, tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+	}
+	click(link.StartCol-1, link.StartLine)
+	if len(provider.fetched) != 0 {
+		t.Fatalf("click beside the link fetched %v", provider.fetched)
+	}
+	click(link.StartCol, link.StartLine)
+	if len(provider.fetched) != 1 || provider.fetched[0] != "other.md" {
+		t.Fatalf("fetched = %v, want [other.md]", provider.fetched)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/boolean-maybe/tiki/controller"
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/theme"
-	"github.com/boolean-maybe/tiki/util"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -361,7 +360,7 @@ func (ap *ActionPalette) renderList() {
 			continue
 		}
 
-		keyStr := util.FormatKeyBinding(row.action.Key, row.action.Rune, row.action.Modifier)
+		keyStr := row.action.KeyString()
 		label := row.action.Label
 
 		// truncate label if needed
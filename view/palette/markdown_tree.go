@@ -131,15 +131,27 @@ func (mt *MarkdownTree) GetFilterInput() tview.Primitive { return mt.filterInput
 // SetDocMarker installs a predicate that marks files backed by a tiki doc.
 func (mt *MarkdownTree) SetDocMarker(fn func(string) bool) { mt.isDoc = fn }
 
-// OnShow receives the freshly scanned tree and rebuilds the view.
+// OnShow receives the freshly scanned tree and rebuilds the view, expanding
+// folders the way the user left them (see RememberExpansion).
 func (mt *MarkdownTree) OnShow(root *store.MarkdownDir) {
 	mt.scanned = root
 	mt.filtering = false
-	mt.manualExpand = map[string]bool{}
+	mt.manualExpand = mt.cfg.GetExpandedDirs()
 	mt.filterInput.SetText("")
 	mt.rebuild("")
 }
 
+// RememberExpansion stores the current folder expand state in the config so
+// the next OnShow — in this session or, through the saved session, the next
+// launch — reopens the tree the same way. While a filter is active the
+// state from before the first keystroke is kept, not the forced expansion.
+func (mt *MarkdownTree) RememberExpansion() {
+	if !mt.filtering {
+		mt.captureManualExpand()
+	}
+	mt.cfg.SetExpandedDirs(mt.manualExpand)
+}
+
 // SetChangedFunc wires the filter input's change handler.
 func (mt *MarkdownTree) SetChangedFunc() {
 	mt.filterInput.SetChangedFunc(func(text string) {
@@ -241,10 +253,12 @@ func (mt *MarkdownTree) applyExpansion(node *tview.TreeNode, relPath, query stri
 	switch {
 	case query != "":
 		node.SetExpanded(forceExpand[relPath])
-	case len(mt.manualExpand) > 0:
-		node.SetExpanded(mt.manualExpand[relPath])
 	default:
-		node.SetExpanded(depth == 0) // top level expanded, deeper collapsed
+		expanded, known := mt.manualExpand[relPath]
+		if !known {
+			expanded = depth == 0 // top level expanded, deeper collapsed
+		}
+		node.SetExpanded(expanded)
 	}
 }
 
@@ -92,3 +92,26 @@ func TestMarkdownTree_ClearingFilterRestoresManualExpand(t *testing.T) {
 		t.Fatal("docs should be restored to collapsed after clearing filter")
 	}
 }
+
+func TestMarkdownTree_ExpansionSurvivesReopen(t *testing.T) {
+	cfg := model.NewMarkdownTreeConfig()
+	mt := NewMarkdownTree(cfg)
+	mt.SetChangedFunc()
+	mt.OnShow(sampleTree())
+
+	ruki := filepath.Join("docs", "ruki")
+	mt.setDirExpandedForTest("docs", false)
+	mt.setDirExpandedForTest(ruki, true)
+	mt.onFilterChanged("scratch") // the forced expansion must not be remembered
+	mt.RememberExpansion()
+
+	if dirs := cfg.GetExpandedDirs(); dirs["docs"] || !dirs[ruki] {
+		t.Fatalf("remembered dirs = %v, want docs collapsed and docs/ruki expanded", dirs)
+	}
+
+	reopened := NewMarkdownTree(cfg)
+	reopened.OnShow(sampleTree())
+	if reopened.dirExpandedForTest("docs") || !reopened.dirExpandedForTest(ruki) {
+		t.Fatal("reopened tree did not restore the folder state")
+	}
+}
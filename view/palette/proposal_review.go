@@ -0,0 +1,148 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/theme"
+)
+
+// ProposalReview is the modal overlay that shows the changes an AI agent
+// proposed, each with its diff, and lets the user pick which to apply.
+type ProposalReview struct {
+	root     *tview.Flex
+	listView *tview.TextView
+	hintView *tview.TextView
+	cfg      *model.ProposalReviewConfig
+}
+
+// NewProposalReview creates the review widget.
+func NewProposalReview(cfg *model.ProposalReviewConfig) *ProposalReview {
+	roles := theme.Roles()
+
+	pr := &ProposalReview{cfg: cfg}
+
+	pr.listView = tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	pr.listView.SetBackgroundColor(roles.SurfaceCanvas().TCell())
+	pr.listView.SetInputCapture(pr.handleInput)
+
+	pr.hintView = tview.NewTextView().SetDynamicColors(true)
+	pr.hintView.SetBackgroundColor(roles.SurfaceCanvas().TCell())
+	pr.hintView.SetText(fmt.Sprintf(" [%s]↑↓ Select  Space Toggle  ⏎ Apply  Esc Discard", roles.TextMuted().Hex()))
+
+	pr.root = tview.NewFlex().SetDirection(tview.FlexRow)
+	pr.root.SetBackgroundColor(roles.SurfaceCanvas().TCell())
+	pr.root.SetBorder(true)
+	pr.root.SetBorderColor(roles.BorderIdle().TCell())
+	pr.root.SetTitleColor(roles.TextPrimary().TCell())
+	pr.root.AddItem(pr.listView, 0, 1, true)
+	pr.root.AddItem(pr.hintView, 1, 0, false)
+
+	return pr
+}
+
+// GetPrimitive returns the root tview primitive for embedding in a Pages overlay.
+func (pr *ProposalReview) GetPrimitive() tview.Primitive {
+	return pr.root
+}
+
+// GetFocusTarget returns the primitive that should receive focus when the
+// overlay opens.
+func (pr *ProposalReview) GetFocusTarget() tview.Primitive {
+	return pr.listView
+}
+
+// OnShow renders the proposal currently held by the config.
+func (pr *ProposalReview) OnShow() {
+	pr.root.SetTitle(" " + tview.Escape(pr.cfg.Title()) + " ")
+	pr.render()
+}
+
+// render lists every change: a checkbox and summary line, then its diff.
+// The focused change is highlighted and scrolled into view.
+func (pr *ProposalReview) render() {
+	roles := theme.Roles()
+	items := pr.cfg.Items()
+	cursor := pr.cfg.Cursor()
+
+	var buf strings.Builder
+	cursorLine := 0
+	line := 0
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteString("\n")
+			line++
+		}
+		mark := "[ ]"
+		if pr.cfg.IsAccepted(i) {
+			mark = "[x]"
+		}
+		header := fmt.Sprintf(" %s %s", tview.Escape(mark), tview.Escape(item.Summary))
+		if i == cursor {
+			cursorLine = line
+			header = fmt.Sprintf("[%s:%s:b]%s[-:-:-]", roles.Highlight().Hex(), roles.SurfaceSelection().Hex(), header)
+		} else {
+			header = fmt.Sprintf("[%s::b]%s[-::-]", roles.TextPrimary().Hex(), header)
+		}
+		buf.WriteString(header + "\n")
+		line++
+		for _, d := range item.Diff {
+			fmt.Fprintf(&buf, "[%s]     %s[-]\n", diffLineColor(d), tview.Escape(d))
+			line++
+		}
+	}
+	pr.listView.SetText(buf.String())
+	pr.listView.ScrollTo(cursorLine, 0)
+}
+
+// diffLineColor colors added lines green, removed lines red and context
+// lines muted.
+func diffLineColor(line string) string {
+	roles := theme.Roles()
+	switch {
+	case strings.HasPrefix(line, "+"):
+		return roles.StatusOk().Hex()
+	case strings.HasPrefix(line, "-"):
+		return roles.StatusDanger().Hex()
+	default:
+		return roles.TextMuted().Hex()
+	}
+}
+
+func (pr *ProposalReview) handleInput(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		pr.cfg.Cancel()
+	case tcell.KeyEnter:
+		pr.cfg.Apply()
+	case tcell.KeyUp:
+		pr.cfg.MoveCursor(-1)
+		pr.render()
+	case tcell.KeyDown, tcell.KeyTab:
+		pr.cfg.MoveCursor(1)
+		pr.render()
+	case tcell.KeyBacktab:
+		pr.cfg.MoveCursor(-1)
+		pr.render()
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case ' ':
+			pr.cfg.ToggleCurrent()
+			pr.render()
+		case 'k':
+			pr.cfg.MoveCursor(-1)
+			pr.render()
+		case 'j':
+			pr.cfg.MoveCursor(1)
+			pr.render()
+		}
+	case tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyHome, tcell.KeyEnd:
+		// let the text view scroll through long diffs
+		return event
+	}
+	return nil
+}
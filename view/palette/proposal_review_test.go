@@ -0,0 +1,40 @@
+package palette
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/boolean-maybe/tiki/model"
+)
+
+func TestProposalReview_KeysToggleAndApply(t *testing.T) {
+	cfg := model.NewProposalReviewConfig()
+	pr := NewProposalReview(cfg)
+
+	var got []bool
+	cfg.Show("Split: two subtasks", []model.ReviewItem{
+		{Summary: "Update ABC123 Checkout", Diff: []string{"- status: ready", "+ status: inProgress"}},
+		{Summary: "Create Cart page", Diff: []string{"+ title: Cart page"}},
+	}, func(accepted []bool) { got = accepted }, nil)
+	pr.OnShow()
+
+	text := pr.listView.GetText(true)
+	for _, want := range []string{"[x] Update ABC123 Checkout", "- status: ready", "+ title: Cart page"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("review text does not contain %q:\n%s", want, text)
+		}
+	}
+
+	pr.handleInput(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	pr.handleInput(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone))
+	if !strings.Contains(pr.listView.GetText(true), "[ ] Create Cart page") {
+		t.Errorf("space should uncheck the focused change:\n%s", pr.listView.GetText(true))
+	}
+
+	pr.handleInput(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if cfg.IsVisible() || len(got) != 2 || !got[0] || got[1] {
+		t.Errorf("after Enter: visible %v, accepted %v; want hidden and [true false]", cfg.IsVisible(), got)
+	}
+}
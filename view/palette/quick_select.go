@@ -9,6 +9,7 @@ import (
 
 	"github.com/boolean-maybe/tiki/component"
 	"github.com/boolean-maybe/tiki/model"
+	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/theme"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
 )
@@ -123,7 +124,7 @@ func (qs *QuickSelect) filterTikis() {
 	}
 	var matches []scored
 	for _, tk := range qs.candidateTikis {
-		text := tk.ID() + " " + tk.Title()
+		text := store.DisplayID(tk) + " " + tk.Title()
 		matched, score := fuzzyMatch(query, text)
 		if matched {
 			matches = append(matches, scored{tk, score})
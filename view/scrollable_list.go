@@ -11,6 +11,7 @@ type ScrollableList struct {
 	*tview.Box
 
 	items          []tview.Primitive
+	heights        []int // per-item height; 0 uses itemHeight
 	itemHeight     int
 	scrollOffset   int
 	selectionIndex int
@@ -21,6 +22,7 @@ func NewScrollableList() *ScrollableList {
 	return &ScrollableList{
 		Box:            tview.NewBox(),
 		items:          make([]tview.Primitive, 0),
+		heights:        make([]int, 0),
 		itemHeight:     1, // default, should be set by caller
 		scrollOffset:   0,
 		selectionIndex: -1,
@@ -35,13 +37,29 @@ func (s *ScrollableList) SetItemHeight(height int) *ScrollableList {
 
 // AddItem adds a primitive to the list
 func (s *ScrollableList) AddItem(item tview.Primitive) *ScrollableList {
+	return s.AddItemWithHeight(item, 0)
+}
+
+// AddItemWithHeight adds a primitive that is height rows tall instead of
+// the list's item height (e.g. a one-row section header among cards).
+func (s *ScrollableList) AddItemWithHeight(item tview.Primitive, height int) *ScrollableList {
 	s.items = append(s.items, item)
+	s.heights = append(s.heights, height)
 	return s
 }
 
+// heightOf returns the height of item i.
+func (s *ScrollableList) heightOf(i int) int {
+	if h := s.heights[i]; h > 0 {
+		return h
+	}
+	return s.itemHeight
+}
+
 // Clear removes all items from the list
 func (s *ScrollableList) Clear() *ScrollableList {
 	s.items = make([]tview.Primitive, 0)
+	s.heights = make([]int, 0)
 	// Keep scrollOffset to preserve position during refresh
 	s.selectionIndex = -1
 	return s
@@ -58,6 +76,33 @@ func (s *ScrollableList) GetScrollOffset() int {
 	return s.scrollOffset
 }
 
+// SetScrollOffset positions the viewport (e.g. restoring a saved session);
+// the next selection change still scrolls to keep the selection visible.
+func (s *ScrollableList) SetScrollOffset(offset int) {
+	s.scrollOffset = max(offset, 0)
+}
+
+// ItemAt returns the index of the item drawn at screen row y, or -1 when the
+// row is outside the list or below the last visible item.
+func (s *ScrollableList) ItemAt(y int) int {
+	_, top, _, height := s.GetInnerRect()
+	if s.itemHeight <= 0 || y < top || y >= top+height {
+		return -1
+	}
+	itemY := top
+	for idx := s.scrollOffset; idx < len(s.items); idx++ {
+		h := s.heightOf(idx)
+		if itemY+h > top+height {
+			return -1
+		}
+		if y < itemY+h {
+			return idx
+		}
+		itemY += h
+	}
+	return -1
+}
+
 // ResetScrollOffset resets the scroll offset to 0
 func (s *ScrollableList) ResetScrollOffset() {
 	s.scrollOffset = 0
@@ -84,43 +129,45 @@ func (s *ScrollableList) ensureSelectionVisible() {
 
 	// Calculate view dimensions
 	_, _, _, height := s.GetInnerRect()
-	if height <= 0 {
-		return
-	}
-
-	maxVisible := height / s.itemHeight
-	if maxVisible <= 0 {
+	if height <= 0 || height/s.itemHeight <= 0 {
 		return
 	}
 
-	// Calculate the last visible index
-	lastVisibleIndex := s.scrollOffset + maxVisible - 1
-	if lastVisibleIndex >= len(s.items) {
-		lastVisibleIndex = len(s.items) - 1
-	}
-
 	// Adjust scroll offset if selection is out of view
 	// When scrolling up: only adjust when selection goes ABOVE the first visible item
 	if s.selectionIndex < s.scrollOffset {
 		s.scrollOffset = s.selectionIndex
-	} else if s.selectionIndex > lastVisibleIndex {
+	} else {
 		// When scrolling down: adjust to show the selected item at the bottom
-		s.scrollOffset = s.selectionIndex - maxVisible + 1
+		last := min(s.selectionIndex, len(s.items)-1)
+		for s.scrollOffset < last && s.spanHeight(s.scrollOffset, last) > height {
+			s.scrollOffset++
+		}
 	}
 
-	// Ensure valid bounds for scrollOffset
+	// Ensure valid bounds for scrollOffset: never scroll past the point
+	// where the last item sits at the bottom of the view
 	if s.scrollOffset < 0 {
 		s.scrollOffset = 0
 	}
-	maxScrollOffset := len(s.items) - maxVisible
-	if maxScrollOffset < 0 {
-		maxScrollOffset = 0
+	maxScrollOffset := len(s.items)
+	for used := 0; maxScrollOffset > 0 && used+s.heightOf(maxScrollOffset-1) <= height; maxScrollOffset-- {
+		used += s.heightOf(maxScrollOffset - 1)
 	}
 	if s.scrollOffset > maxScrollOffset {
 		s.scrollOffset = maxScrollOffset
 	}
 }
 
+// spanHeight returns the total height of items first..last inclusive.
+func (s *ScrollableList) spanHeight(first, last int) int {
+	total := 0
+	for i := first; i <= last; i++ {
+		total += s.heightOf(i)
+	}
+	return total
+}
+
 // Draw draws this primitive onto the screen
 func (s *ScrollableList) Draw(screen tcell.Screen) {
 	s.DrawForSubclass(screen, s)
@@ -133,23 +180,22 @@ func (s *ScrollableList) Draw(screen tcell.Screen) {
 	// Re-run scroll calculation in case height changed (resize)
 	s.ensureSelectionVisible()
 
-	maxVisible := height / s.itemHeight
-
-	// Loop through visible items
-	for i := 0; i < maxVisible; i++ {
-		itemIndex := s.scrollOffset + i
-		if itemIndex >= len(s.items) {
+	// Loop through the items that fit entirely
+	itemY := y
+	for itemIndex := s.scrollOffset; itemIndex < len(s.items); itemIndex++ {
+		h := s.heightOf(itemIndex)
+		if itemY+h > y+height {
 			break
 		}
 
 		item := s.items[itemIndex]
 
 		// set position and size for the item
-		itemY := y + (i * s.itemHeight)
-		item.SetRect(x, itemY, width, s.itemHeight)
+		item.SetRect(x, itemY, width, h)
 
 		// draw the item
 		item.Draw(screen)
+		itemY += h
 	}
 }
 
@@ -385,3 +385,27 @@ func TestGetScrollOffset(t *testing.T) {
 			list.GetScrollOffset(), list.scrollOffset)
 	}
 }
+
+func TestItemAt(t *testing.T) {
+	// 10 items of height 5 in a 22-row viewport: 4 full rows, 2 spare rows
+	list := createTestList(10, 5)
+	list.SetRect(0, 3, 100, 22)
+	list.SetSelection(6) // scrolls so items 3-6 are visible
+
+	tests := []struct {
+		y    int
+		want int
+	}{
+		{2, -1},  // above the list
+		{3, 3},   // first visible item
+		{12, 4},  // inside the second item
+		{22, 6},  // last full item
+		{23, -1}, // partial row below the last full item
+		{30, -1}, // below the list
+	}
+	for _, tt := range tests {
+		if got := list.ItemAt(tt.y); got != tt.want {
+			t.Errorf("ItemAt(%d) = %d, want %d", tt.y, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	nav "github.com/boolean-maybe/navidown/navidown"
+	navtview "github.com/boolean-maybe/navidown/navidown/tview"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/workflow"
+)
+
+// sprintBarCells is the width of the committed-vs-capacity bar.
+const sprintBarCells = 20
+
+// NewSprintView creates the view backing `kind: sprints`: a generated
+// markdown report of every sprint's committed points against its capacity,
+// rendered by the wiki viewer so the `[[ID]]` links in it navigate to the
+// committed tikis. The report is a snapshot taken when the view opens.
+func NewSprintView(
+	pluginDef *plugin.SprintPlugin,
+	imageManager *navtview.ImageManager,
+	mermaidOpts *nav.MermaidOptions,
+	globalActions []plugin.PluginAction,
+	tikiStore store.ReadStore,
+	selectedTikiID string,
+) *WikiView {
+	sv := &WikiView{
+		pluginDef:       &plugin.WikiPlugin{BasePlugin: pluginDef.BasePlugin},
+		registry:        controller.NewActionRegistry(),
+		imageManager:    imageManager,
+		mermaidOpts:     mermaidOpts,
+		tikiStore:       tikiStore,
+		surfacedGlobals: surfacedGlobalActions(globalActions, pluginDef.GetName()),
+		selectedTikiID:  selectedTikiID,
+	}
+	sv.generated = func() string {
+		cfg, ok := config.WorkflowSprints()
+		if !ok || tikiStore == nil {
+			return "## No sprints configured\n\nAdd a `sprints:` section to workflow.yaml to plan work in iterations."
+		}
+		return sprintMarkdown(tikiStore.GetAllTikis(), cfg, time.Now())
+	}
+	sv.build()
+	return sv
+}
+
+// sprintMarkdown renders the planning report: per sprint its dates, a bar of
+// committed points against capacity and the committed tikis, followed by the
+// open points no sprint has taken yet.
+func sprintMarkdown(tikis []*tikipkg.Tiki, cfg config.SprintConfig, now time.Time) string {
+	sprints := store.Sprints(tikis, cfg)
+	var b strings.Builder
+	b.WriteString("# Sprint planning\n\n")
+	if len(sprints) == 0 {
+		b.WriteString("No sprints yet.\n")
+		return b.String()
+	}
+	current, hasCurrent := sprints.Current(now)
+
+	for _, plan := range store.PlanSprints(tikis, cfg, sprints) {
+		s := plan.Sprint
+		heading := s.Name
+		if s.ID != "" {
+			heading = fmt.Sprintf("[[%s]]", s.ID)
+		}
+		switch {
+		case hasCurrent && s.Name == current.Name:
+			heading += " · current"
+		case s.End.Before(now) && !s.Contains(now):
+			heading += " · ended"
+		}
+		fmt.Fprintf(&b, "## %s\n\n", heading)
+		fmt.Fprintf(&b, "%s → %s · %s\n\n", s.Start.Format("Jan 2"), s.End.Format("Jan 2, 2006"), sprintLoad(plan))
+		if s.Capacity > 0 {
+			fmt.Fprintf(&b, "`%s`\n\n", sprintBar(plan.Committed, s.Capacity))
+		}
+		if len(plan.Done)+len(plan.Open) == 0 {
+			b.WriteString("Nothing committed.\n\n")
+			continue
+		}
+		for _, tk := range plan.Tikis() {
+			fmt.Fprintf(&b, "- %s [[%s]] · %s\n", sprintMarker(tk, cfg), tk.ID(), sprintTikiPoints(tk, cfg))
+		}
+		b.WriteString("\n")
+	}
+
+	if count, points := unplannedWork(tikis, cfg, sprints); count > 0 {
+		fmt.Fprintf(&b, "## Unplanned\n\n%d open tikis (%s points) are not in a sprint.\n", count, store.FormatPoints(points))
+	}
+	return b.String()
+}
+
+// sprintLoad summarises a plan: committed of capacity, completed, and how far
+// over capacity the sprint is.
+func sprintLoad(plan store.SprintPlan) string {
+	load := fmt.Sprintf("%s of %s points committed · %s completed",
+		store.FormatPoints(plan.Committed), store.FormatPoints(plan.Sprint.Capacity), store.FormatPoints(plan.Completed))
+	if over := plan.Committed - plan.Sprint.Capacity; over > 0 {
+		load += fmt.Sprintf(" · **%s over capacity**", store.FormatPoints(over))
+	}
+	return load
+}
+
+// sprintBar draws committed against capacity; an overcommitted sprint fills
+// the bar and marks the excess.
+func sprintBar(committed, capacity float64) string {
+	filled := min(int(committed/capacity*sprintBarCells+0.5), sprintBarCells)
+	bar := strings.Repeat("▰", filled) + strings.Repeat("▱", sprintBarCells-filled)
+	if committed > capacity {
+		bar += " +"
+	}
+	return bar
+}
+
+// sprintMarker is the glyph in front of a committed tiki: done or open.
+func sprintMarker(tk *tikipkg.Tiki, cfg config.SprintConfig) string {
+	status, _, _ := tk.StringField(cfg.StatusField)
+	if cfg.IsDone(status) {
+		return "✅"
+	}
+	return "⏳"
+}
+
+// sprintTikiPoints labels a committed tiki with its status and estimate.
+func sprintTikiPoints(tk *tikipkg.Tiki, cfg config.SprintConfig) string {
+	status, _, _ := tk.StringField(cfg.StatusField)
+	label := status
+	if fd, ok := workflow.Field(cfg.StatusField); ok {
+		label = fd.EnumLabel(status)
+	}
+	if label == "" {
+		label = "no " + cfg.StatusField
+	}
+	if points, ok := store.Points(tk, cfg.PointsField); ok {
+		return fmt.Sprintf("%s · %s pts", label, store.FormatPoints(points))
+	}
+	return label + " · unestimated"
+}
+
+// unplannedWork counts the unfinished tikis (those with a status) that are
+// not committed to any sprint, and their points.
+func unplannedWork(tikis []*tikipkg.Tiki, cfg config.SprintConfig, sprints config.SprintList) (int, float64) {
+	count := 0
+	total := 0.0
+	for _, tk := range tikis {
+		status, ok, _ := tk.StringField(cfg.StatusField)
+		if !ok || cfg.IsDone(status) {
+			continue
+		}
+		if name, _, _ := tk.StringField(cfg.Field); name != "" {
+			if _, known := sprints.Find(name); known {
+				continue
+			}
+		}
+		if isSprintTiki(tk, sprints) {
+			continue
+		}
+		count++
+		points, _ := store.Points(tk, cfg.PointsField)
+		total += points
+	}
+	return count, total
+}
+
+// isSprintTiki reports whether tk declares one of the sprints.
+func isSprintTiki(tk *tikipkg.Tiki, sprints config.SprintList) bool {
+	for _, s := range sprints {
+		if s.ID == tk.ID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolean-maybe/tiki/config"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+)
+
+func newSprintViewTiki(id, status, sprint, points string) *tikipkg.Tiki {
+	tk := tikipkg.New()
+	tk.SetID(id)
+	tk.SetTitle(id)
+	tk.Set("status", status)
+	if sprint != "" {
+		tk.Set("sprint", sprint)
+	}
+	tk.Set("points", points)
+	return tk
+}
+
+func TestSprintMarkdown_CommittedAgainstCapacity(t *testing.T) {
+	cfg := config.SprintConfig{
+		Field: "sprint", PointsField: "points", DoneStatus: config.DoneStatus{StatusField: "status", Done: []string{"done"}},
+		List: []config.Sprint{
+			{Name: "S1", Start: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC), Capacity: 10},
+			{Name: "S2", Start: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC), Capacity: 4},
+		},
+	}
+	tikis := []*tikipkg.Tiki{
+		newSprintViewTiki("AAA001", "done", "S1", "3"),
+		newSprintViewTiki("BBB001", "ready", "S1", "2"),
+		newSprintViewTiki("CCC001", "ready", "S2", "7"),
+		newSprintViewTiki("DDD001", "inbox", "", "1"),
+	}
+	md := sprintMarkdown(tikis, cfg, time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC))
+
+	for _, want := range []string{
+		"## S1 · current",
+		"5 of 10 points committed · 3 completed",
+		"`▰▰▰▰▰▰▰▰▰▰▱▱▱▱▱▱▱▱▱▱`",
+		"- ✅ [[AAA001]] · Done · 3 pts\n- ⏳ [[BBB001]]",
+		"7 of 4 points committed · 0 completed · **3 over capacity**",
+		"`▰▰▰▰▰▰▰▰▰▰▰▰▰▰▰▰▰▰▰▰ +`",
+		"1 open tikis (1 points) are not in a sprint.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("report missing %q:\n%s", want, md)
+		}
+	}
+}
@@ -27,6 +27,9 @@ type StatuslineWidget struct {
 	listenerID int
 	lastWidth  int
 
+	// onStatClick receives the key of a clicked left stat
+	onStatClick func(key string)
+
 	// animFrame advances each time the widget renders an active indeterminate
 	// progress bar, driving the moving-segment animation. The ProgressHub
 	// ticker forces those redraws; here we only advance and consume the value.
@@ -44,12 +47,47 @@ func NewStatuslineWidget(cfg *model.StatuslineConfig) *StatuslineWidget {
 		TextView: tv,
 		config:   cfg,
 	}
+	// swallow clicks so they never take focus from the view; a click on a
+	// left stat goes to the stat click handler
+	tv.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if !tv.InRect(event.Position()) {
+			return action, event
+		}
+		if action == tview.MouseLeftClick && sw.onStatClick != nil {
+			x, _ := event.Position()
+			rectX, _, _, _ := tv.GetRect()
+			if key := sw.leftStatAt(x - rectX); key != "" {
+				sw.onStatClick(key)
+			}
+		}
+		return tview.MouseConsumed, nil
+	})
 
 	sw.listenerID = cfg.AddListener(sw.rebuild)
 	sw.rebuild()
 	return sw
 }
 
+// SetStatClickHandler registers the function called with the key of a left
+// stat when it is clicked.
+func (sw *StatuslineWidget) SetStatClickHandler(fn func(key string)) {
+	sw.onStatClick = fn
+}
+
+// leftStatAt returns the key of the left stat drawn at column x, counted
+// from the widget's left edge, or "".
+func (sw *StatuslineWidget) leftStatAt(x int) string {
+	sepWidth := runewidth.StringWidth(separatorRight)
+	end := 0
+	for _, seg := range sortedSegments(sw.config.GetLeftStats()) {
+		end += runewidth.StringWidth(seg.value) + 2 + sepWidth
+		if x < end {
+			return seg.key
+		}
+	}
+	return ""
+}
+
 // Draw overrides to detect width changes and re-render with proper alignment
 func (sw *StatuslineWidget) Draw(screen tcell.Screen) {
 	_, _, width, _ := sw.GetRect()
@@ -74,6 +112,7 @@ func (sw *StatuslineWidget) rebuild() {
 
 // statSegment holds a single stat for rendering
 type statSegment struct {
+	key   string
 	value string
 	order int
 }
@@ -257,11 +296,14 @@ func messageColors(level model.MessageLevel, roles *theme.Theme) (theme.Role, th
 // sortedSegments converts a stat map to a sorted slice of segments
 func sortedSegments(stats map[string]model.StatValue) []statSegment {
 	segments := make([]statSegment, 0, len(stats))
-	for _, v := range stats {
-		segments = append(segments, statSegment{value: v.Value, order: v.Priority})
+	for k, v := range stats {
+		segments = append(segments, statSegment{key: k, value: v.Value, order: v.Priority})
 	}
 	sort.Slice(segments, func(i, j int) bool {
-		return segments[i].order < segments[j].order
+		if segments[i].order != segments[j].order {
+			return segments[i].order < segments[j].order
+		}
+		return segments[i].key < segments[j].key
 	})
 	return segments
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/theme"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -330,3 +331,25 @@ func TestStatuslineWidget_MessageWhenNoProgress(t *testing.T) {
 		t.Fatalf("message should render when no progress active: %q", mid)
 	}
 }
+
+func TestStatuslineWidget_StatClick(t *testing.T) {
+	cfg := model.NewStatuslineConfig()
+	cfg.SetLeftStat("Version", "1.0", 0) // cells 0-5: " 1.0 ▶"
+	cfg.SetLeftStat("Overdue", "⚑ 2", 5) // cells 6-11: " ⚑ 2 ▶"
+	sw := NewStatuslineWidget(cfg)
+	sw.SetRect(10, 0, 80, 1)
+
+	var clicked []string
+	sw.SetStatClickHandler(func(key string) { clicked = append(clicked, key) })
+	click := func(x int) {
+		event := tcell.NewEventMouse(x, 0, tcell.Button1, tcell.ModNone)
+		sw.MouseHandler()(tview.MouseLeftClick, event, func(tview.Primitive) {})
+	}
+	click(10 + 2)
+	click(10 + 8)
+	click(10 + 40)
+
+	if len(clicked) != 2 || clicked[0] != "Version" || clicked[1] != "Overdue" {
+		t.Errorf("clicked = %v, want [Version Overdue] and nothing for the padding", clicked)
+	}
+}
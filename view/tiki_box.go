@@ -70,6 +70,8 @@ func tikiBoxItemHeight(spec gridlayout.GridSpec) int {
 // in the same way long titles are. For multi-row rendering, declare the
 // field on a `kind: detail` view instead.
 func CreateTikiBox(tk *tikipkg.Tiki, spec gridlayout.GridSpec, selected bool, roles *theme.Theme) tview.Primitive {
+	// derived fields (checklist progress, blocked, …) render like stored ones
+	tk = tikipkg.WithDerived(tk)
 	primitives := buildTikiBoxPrimitives(spec, tk, roles)
 	heightOf := func(gridlayout.Anchor, int) int { return 1 }
 	measure := tikiBoxMeasure(tk, roles)
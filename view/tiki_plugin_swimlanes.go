@@ -0,0 +1,169 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/theme"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// bandRow describes one item of a lane list on a swimlane board. Every lane
+// list holds the same rows, so lanes scroll together and band headers line
+// up across the board.
+type bandRow struct {
+	band   int
+	header bool
+	row    int // card row within the band
+}
+
+// bandHeader draws a band's title row in one lane: a rule across the lane
+// with the band name (first lane only) and the lane's card count.
+type bandHeader struct {
+	*tview.Box
+	label string
+	count int
+}
+
+func newBandHeader(label string, count int) *bandHeader {
+	return &bandHeader{Box: tview.NewBox(), label: label, count: count}
+}
+
+// Draw draws the header row.
+func (h *bandHeader) Draw(screen tcell.Screen) {
+	x, y, width, _ := h.GetRect()
+	if width <= 0 {
+		return
+	}
+	roles := theme.Roles()
+	rule := tcell.StyleDefault.Foreground(roles.BorderIdle().TCell())
+	for i := range width {
+		screen.SetContent(x+i, y, '─', nil, rule)
+	}
+	count := fmt.Sprintf(" %d ", h.count)
+	tview.Print(screen, tview.Escape(count), x, y, width-1, tview.AlignRight, roles.TextMuted().TCell())
+	if h.label != "" {
+		tview.Print(screen, roles.TextPrimary().BoldTag()+" "+tview.Escape(h.label)+" ", x+1, y, width-len(count)-2, tview.AlignLeft, roles.TextPrimary().TCell())
+	}
+}
+
+// bandLabel is the title shown in the first lane: fold marker, name and the
+// band's total across lanes.
+func bandLabel(band controller.SwimlaneBand) string {
+	marker := "▾"
+	if band.Collapsed {
+		marker = "▸"
+	}
+	return fmt.Sprintf("%s %s (%d)", marker, band.Name, band.Count())
+}
+
+// refreshSwimlanes fills the lane lists band by band. A band is as tall as
+// its fullest lane; shorter lanes are padded so every lane has the same rows.
+func (pv *PluginView) refreshSwimlanes(itemHeight int) {
+	bands := pv.swimlanes.GetSwimlaneBands()
+	pv.bands = bands
+	laneCount := len(pv.pluginDef.Lanes)
+	selectedLane := pv.pluginConfig.GetSelectedLane()
+	pv.pluginConfig.ClampSelection(len(pv.getLaneTikis(selectedLane)))
+	selectedIndex := pv.pluginConfig.GetSelectedIndexForLane(selectedLane)
+
+	pv.bandRows = pv.bandRows[:0]
+	pv.bandStarts = make([][]int, len(bands))
+	starts := make([]int, laneCount)
+	selectedRow := -1
+	for b, band := range bands {
+		pv.bandStarts[b] = append([]int(nil), starts...)
+		pv.bandRows = append(pv.bandRows, bandRow{band: b, header: true})
+		rows := 0
+		if !band.Collapsed {
+			for lane, tikis := range band.Lanes {
+				columns := pv.pluginConfig.GetColumnsForLane(lane)
+				rows = max(rows, (len(tikis)+columns-1)/columns)
+			}
+		}
+		for r := range rows {
+			pv.bandRows = append(pv.bandRows, bandRow{band: b, row: r})
+		}
+		if band.Collapsed {
+			continue
+		}
+		local := selectedIndex - starts[selectedLane]
+		if local >= 0 && local < len(band.Lanes[selectedLane]) {
+			columns := pv.pluginConfig.GetColumnsForLane(selectedLane)
+			selectedRow = len(pv.bandRows) - rows + local/columns
+		}
+		for lane, tikis := range band.Lanes {
+			starts[lane] += len(tikis)
+		}
+	}
+
+	for lane := range laneCount {
+		list := pv.laneBoxes[lane]
+		list.SetItemHeight(itemHeight)
+		list.Clear()
+		pv.lanes.AddItem(list, 0, pv.pluginConfig.GetWidthForLane(lane), lane == selectedLane)
+
+		columns := pv.pluginConfig.GetColumnsForLane(lane)
+		for _, br := range pv.bandRows {
+			band := bands[br.band]
+			if br.header {
+				label := ""
+				if lane == 0 {
+					label = bandLabel(band)
+				}
+				list.AddItemWithHeight(newBandHeader(label, len(band.Lanes[lane])), 1)
+				continue
+			}
+			rowFlex := tview.NewFlex().SetDirection(tview.FlexColumn)
+			for col := range columns {
+				local := br.row*columns + col
+				if local >= len(band.Lanes[lane]) {
+					rowFlex.AddItem(tview.NewBox(), 0, 1, false)
+					continue
+				}
+				isSelected := lane == selectedLane && pv.bandStarts[br.band][lane]+local == selectedIndex
+				rowFlex.AddItem(CreateTikiBox(band.Lanes[lane][local], pv.pluginDef.Layout, isSelected, theme.Roles()), 0, 1, false)
+			}
+			list.AddItem(rowFlex)
+		}
+
+		// every lane follows the selected row so the lanes scroll together
+		list.SetSelection(selectedRow)
+		pv.pluginConfig.SetScrollOffsetForLane(lane, list.GetScrollOffset())
+	}
+}
+
+// swimlaneTikiAt maps a list row and column in a lane to the band under it
+// and the in-lane tiki index (-1 over headers and padding).
+func (pv *PluginView) swimlaneTikiAt(lane, item, x int) (band, index int) {
+	if item < 0 || item >= len(pv.bandRows) {
+		return -1, -1
+	}
+	br := pv.bandRows[item]
+	if br.header {
+		return br.band, -1
+	}
+	left, _, width, _ := pv.laneBoxes[lane].GetInnerRect()
+	columns := pv.pluginConfig.GetColumnsForLane(lane)
+	local := br.row*columns + (x-left)*columns/width
+	if local >= len(pv.bands[br.band].Lanes[lane]) {
+		return br.band, -1
+	}
+	return br.band, pv.bandStarts[br.band][lane] + local
+}
+
+// bandHeaderAt returns the band whose header is under the pointer, or -1.
+func (pv *PluginView) bandHeaderAt(x, y int) int {
+	for _, box := range pv.laneBoxes {
+		if !box.InRect(x, y) {
+			continue
+		}
+		item := box.ItemAt(y)
+		if item >= 0 && item < len(pv.bandRows) && pv.bandRows[item].header {
+			return pv.bandRows[item].band
+		}
+	}
+	return -1
+}
@@ -11,13 +11,14 @@ import (
 	"github.com/boolean-maybe/tiki/theme"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
 // PluginView renders a filtered/sorted list of tikis across lanes
 type PluginView struct {
 	root                *tview.Flex
-	titleBar            tview.Primitive
+	titleBar            *GradientCaptionRow
 	inputHelper         *InputHelper
 	lanes               *tview.Flex
 	laneBoxes           []*ScrollableList
@@ -29,8 +30,18 @@ type PluginView struct {
 	storeListenerID     int
 	selectionListenerID int
 	getLaneTikis        func(lane int) []*tikipkg.Tiki // injected from controller
+	getLaneHeaders      func() []controller.LaneHeader // injected from controller; nil shows bare lane names
 	ensureSelection     func() bool                    // injected from controller
 	actionChangeHandler func()
+	moveHandler         func(lane int) bool          // drag-and-drop target
+	blockedMoves        func() []controller.ActionID // injected from controller; nil blocks nothing
+	activateHandler     func()                       // double-click on a card
+	dragLane            int                          // lane a card drag started in, -1 when idle
+	dragBand            int                          // swimlane band a card drag started in
+	swimlanes           controller.SwimlaneProvider
+	bands               []controller.SwimlaneBand // bands as last rendered
+	bandRows            []bandRow                 // rows of every lane list on a swimlane board
+	bandStarts          [][]int                   // per band, per lane: index of the band's first tiki
 }
 
 // NewPluginView creates a plugin view
@@ -51,6 +62,8 @@ func NewPluginView(
 		showNavigation:  showNavigation,
 		getLaneTikis:    getLaneTikis,
 		ensureSelection: ensureSelection,
+		dragLane:        -1,
+		dragBand:        -1,
 	}
 
 	pv.build()
@@ -91,6 +104,7 @@ func (pv *PluginView) build() {
 
 	// root layout
 	pv.root = tview.NewFlex().SetDirection(tview.FlexRow)
+	pv.root.SetMouseCapture(pv.handleMouse)
 	pv.rebuildLayout()
 
 	pv.refresh()
@@ -124,12 +138,24 @@ func (pv *PluginView) refresh() {
 
 	if len(pv.laneBoxes) != len(pv.pluginDef.Lanes) {
 		pv.laneBoxes = make([]*ScrollableList, 0, len(pv.pluginDef.Lanes))
-		for range pv.pluginDef.Lanes {
-			pv.laneBoxes = append(pv.laneBoxes, NewScrollableList())
+		for laneIdx := range pv.pluginDef.Lanes {
+			// a new view starts where the lane was left (restored sessions)
+			list := NewScrollableList()
+			list.SetScrollOffset(pv.pluginConfig.GetScrollOffsetForLane(laneIdx))
+			pv.laneBoxes = append(pv.laneBoxes, list)
 		}
 	}
 
 	pv.lanes.Clear()
+	pv.refreshCaptions()
+
+	if pv.swimlanes != nil {
+		pv.refreshSwimlanes(itemHeight)
+		if pv.actionChangeHandler != nil {
+			pv.actionChangeHandler()
+		}
+		return
+	}
 
 	for laneIdx := range pv.pluginDef.Lanes {
 		laneContainer := pv.laneBoxes[laneIdx]
@@ -212,6 +238,157 @@ func (pv *PluginView) SetActionChangeHandler(handler func()) {
 	pv.actionChangeHandler = handler
 }
 
+// SetMoveHandler sets the callback run when a card is dropped on another
+// lane; it moves the selected tiki into that lane.
+func (pv *PluginView) SetMoveHandler(handler func(lane int) bool) {
+	pv.moveHandler = handler
+}
+
+// SetMoveGuard installs the controller's answer to which Shift-arrow moves
+// the workflow's permissions deny the selected tiki.
+func (pv *PluginView) SetMoveGuard(blocked func() []controller.ActionID) {
+	pv.blockedMoves = blocked
+}
+
+// BlockedMoves implements controller.MoveGuard, so BuildAppContext greys out
+// the denied moves.
+func (pv *PluginView) BlockedMoves() []controller.ActionID {
+	if pv.blockedMoves == nil {
+		return nil
+	}
+	return pv.blockedMoves()
+}
+
+// SetSwimlaneProvider splits the lanes into the provider's swimlane bands.
+// Boards without swimlanes never get one.
+func (pv *PluginView) SetSwimlaneProvider(provider controller.SwimlaneProvider) {
+	pv.swimlanes = provider
+	pv.refresh()
+}
+
+// SetLaneHeaderProvider shows WIP counts and summaries in the lane captions.
+func (pv *PluginView) SetLaneHeaderProvider(provider func() []controller.LaneHeader) {
+	pv.getLaneHeaders = provider
+	pv.refreshCaptions()
+}
+
+// refreshCaptions recomputes the lane captions, flagging lanes over their
+// WIP limit.
+func (pv *PluginView) refreshCaptions() {
+	if pv.getLaneHeaders == nil {
+		return
+	}
+	headers := pv.getLaneHeaders()
+	names := make([]string, len(headers))
+	warn := make([]bool, len(headers))
+	for i, h := range headers {
+		names[i] = h.Caption()
+		warn[i] = h.OverLimit()
+	}
+	pv.titleBar.SetCaptions(names, warn)
+}
+
+// SetActivateHandler sets the callback run when a card is double-clicked,
+// after the card has been selected.
+func (pv *PluginView) SetActivateHandler(handler func()) {
+	pv.activateHandler = handler
+}
+
+// handleMouse turns pointer events over the lanes into selection, drag to
+// another lane or swimlane band, band folding and activation. Everything else on the view is swallowed so a
+// click never takes keyboard focus away from the lanes; the input box still
+// receives its own clicks.
+func (pv *PluginView) handleMouse(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	x, y := event.Position()
+	if !pv.root.InRect(x, y) {
+		return action, event
+	}
+	if pv.inputHelper.IsVisible() && pv.inputHelper.GetInputBox().InRect(x, y) {
+		return action, event
+	}
+
+	lane, band, index := pv.tikiAt(x, y)
+	switch action {
+	case tview.MouseLeftDown:
+		pv.dragLane, pv.dragBand = -1, -1
+		if index >= 0 {
+			pv.pluginConfig.SetSelectedLaneAndIndex(lane, index)
+			pv.dragLane, pv.dragBand = lane, band
+		}
+	case tview.MouseLeftUp:
+		if pv.dragLane >= 0 && lane >= 0 {
+			if lane != pv.dragLane && pv.moveHandler != nil {
+				pv.moveHandler(lane)
+			}
+			if band >= 0 && band != pv.dragBand {
+				pv.swimlanes.MoveSelectedTikiToBand(band)
+			}
+		}
+		pv.dragLane, pv.dragBand = -1, -1
+	case tview.MouseLeftClick:
+		if pv.swimlanes != nil {
+			if b := pv.bandHeaderAt(x, y); b >= 0 && b < len(pv.bands) {
+				pv.swimlanes.ToggleBand(pv.bands[b].Name)
+			}
+		}
+	case tview.MouseLeftDoubleClick:
+		if index >= 0 && pv.activateHandler != nil {
+			pv.pluginConfig.SetSelectedLaneAndIndex(lane, index)
+			pv.activateHandler()
+		}
+	case tview.MouseScrollUp:
+		pv.scrollLane(lane, -1)
+	case tview.MouseScrollDown:
+		pv.scrollLane(lane, 1)
+	}
+	return tview.MouseConsumed, nil
+}
+
+// tikiAt returns the lane, swimlane band and in-lane tiki index under the
+// given screen position. lane is -1 outside all lanes; band is -1 on boards
+// without swimlanes; index is -1 over empty space and band headers.
+func (pv *PluginView) tikiAt(x, y int) (lane, band, index int) {
+	for i, box := range pv.laneBoxes {
+		if !box.InRect(x, y) {
+			continue
+		}
+		row := box.ItemAt(y)
+		left, _, width, _ := box.GetInnerRect()
+		if row < 0 || width <= 0 || x < left || x >= left+width {
+			return i, -1, -1
+		}
+		if pv.swimlanes != nil {
+			band, index := pv.swimlaneTikiAt(i, row, x)
+			return i, band, index
+		}
+		columns := pv.pluginConfig.GetColumnsForLane(i)
+		idx := row*columns + (x-left)*columns/width
+		if idx >= len(pv.getLaneTikis(i)) {
+			return i, -1, -1
+		}
+		return i, -1, idx
+	}
+	return -1, -1, -1
+}
+
+// scrollLane moves the selection one row up or down in the lane under the
+// pointer. Wheeling over another lane selects it at its remembered card.
+func (pv *PluginView) scrollLane(lane, delta int) {
+	if lane < 0 {
+		return
+	}
+	count := len(pv.getLaneTikis(lane))
+	if count == 0 {
+		return
+	}
+	idx := pv.pluginConfig.GetSelectedIndexForLane(lane)
+	if lane == pv.pluginConfig.GetSelectedLane() {
+		idx += delta * pv.pluginConfig.GetColumnsForLane(lane)
+	}
+	idx = min(max(idx, 0), count-1)
+	pv.pluginConfig.SetSelectedLaneAndIndex(lane, idx)
+}
+
 // GetPrimitive returns the root tview primitive
 func (pv *PluginView) GetPrimitive() tview.Primitive {
 	return pv.root
@@ -10,6 +10,9 @@ import (
 	"github.com/boolean-maybe/tiki/plugin"
 	"github.com/boolean-maybe/tiki/store"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 )
 
 func TestPluginViewRefreshResetsNonSelectedLaneScrollOffset(t *testing.T) {
@@ -281,3 +284,185 @@ func TestPluginViewRefreshPreservesScrollOffset(t *testing.T) {
 		t.Fatalf("expected scrollOffset to remain %d, got %d", expectedScrollOffset, lane.scrollOffset)
 	}
 }
+
+func TestPluginViewMouse(t *testing.T) {
+	tikiStore := store.NewInMemoryStore()
+	pluginConfig := model.NewPluginConfig("TestPlugin")
+	pluginConfig.SetLaneLayout([]int{2, 1}, nil)
+
+	pluginDef := &plugin.WorkflowPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "TestPlugin"},
+		Lanes: []plugin.TikiLane{
+			{Name: "Lane0", Columns: 2},
+			{Name: "Lane1", Columns: 1},
+		},
+		Layout: testPluginLayout(t),
+	}
+
+	laneTikis := [][]*tikipkg.Tiki{make([]*tikipkg.Tiki, 4), make([]*tikipkg.Tiki, 2)}
+	for lane, tikis := range laneTikis {
+		for i := range tikis {
+			tk := tikipkg.New()
+			tk.SetID(fmt.Sprintf("T-%d-%d", lane, i))
+			tikis[i] = tk
+		}
+	}
+
+	pv := NewPluginView(tikiStore, pluginConfig, pluginDef, func(lane int) []*tikipkg.Tiki {
+		return laneTikis[lane]
+	}, nil, controller.PluginViewActions(), true)
+
+	var movedTo []int
+	activated := 0
+	pv.SetMoveHandler(func(lane int) bool {
+		movedTo = append(movedTo, lane)
+		return true
+	})
+	pv.SetActivateHandler(func() { activated++ })
+
+	// item height is 5: lane 0 spans x 0-39 in two columns, lane 1 x 40-79
+	pv.root.SetRect(0, 0, 80, 25)
+	pv.laneBoxes[0].SetRect(0, 0, 40, 25)
+	pv.laneBoxes[1].SetRect(40, 0, 40, 25)
+
+	mouse := func(action tview.MouseAction, x, y int) {
+		t.Helper()
+		if got, ev := pv.handleMouse(action, tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone)); got != tview.MouseConsumed || ev != nil {
+			t.Fatalf("%v at (%d,%d) was not consumed", action, x, y)
+		}
+	}
+	selected := func() (int, int) {
+		lane := pluginConfig.GetSelectedLane()
+		return lane, pluginConfig.GetSelectedIndexForLane(lane)
+	}
+
+	// press on the second card of the second row, release over lane 1
+	mouse(tview.MouseLeftDown, 25, 6)
+	if lane, idx := selected(); lane != 0 || idx != 3 {
+		t.Fatalf("after press: lane %d index %d, want lane 0 index 3", lane, idx)
+	}
+	mouse(tview.MouseLeftUp, 45, 1)
+	if len(movedTo) != 1 || movedTo[0] != 1 {
+		t.Fatalf("moves = %v, want [1]", movedTo)
+	}
+
+	// a press on empty space starts no drag
+	mouse(tview.MouseLeftDown, 45, 12)
+	mouse(tview.MouseLeftUp, 5, 1)
+	if len(movedTo) != 1 {
+		t.Fatalf("drag from empty space moved: %v", movedTo)
+	}
+
+	mouse(tview.MouseLeftDoubleClick, 45, 6)
+	if lane, idx := selected(); lane != 1 || idx != 1 || activated != 1 {
+		t.Fatalf("double-click: lane %d index %d activated %d, want lane 1 index 1 activated 1", lane, idx, activated)
+	}
+
+	// wheel over another lane selects it, then moves a row (two cards) at a time
+	mouse(tview.MouseScrollDown, 5, 1)
+	if lane, idx := selected(); lane != 0 || idx != 3 {
+		t.Fatalf("wheel over lane 0: lane %d index %d, want lane 0 index 3", lane, idx)
+	}
+	mouse(tview.MouseScrollUp, 5, 1)
+	if _, idx := selected(); idx != 1 {
+		t.Fatalf("wheel up: index %d, want 1", idx)
+	}
+}
+
+// fakeSwimlanes serves fixed bands and records what the view asks of it.
+type fakeSwimlanes struct {
+	bands   []controller.SwimlaneBand
+	toggled []string
+	movedTo []int
+}
+
+func (f *fakeSwimlanes) HasSwimlanes() bool                          { return true }
+func (f *fakeSwimlanes) GetSwimlaneBands() []controller.SwimlaneBand { return f.bands }
+func (f *fakeSwimlanes) ToggleBand(name string)                      { f.toggled = append(f.toggled, name) }
+func (f *fakeSwimlanes) MoveSelectedTikiToBand(index int) bool {
+	f.movedTo = append(f.movedTo, index)
+	return true
+}
+
+func (f *fakeSwimlanes) laneTikis(lane int) []*tikipkg.Tiki {
+	var out []*tikipkg.Tiki
+	for _, b := range f.bands {
+		if !b.Collapsed {
+			out = append(out, b.Lanes[lane]...)
+		}
+	}
+	return out
+}
+
+func TestPluginViewSwimlanes(t *testing.T) {
+	pluginConfig := model.NewPluginConfig("TestPlugin")
+	pluginConfig.SetLaneLayout([]int{1, 1}, nil)
+	pluginDef := &plugin.WorkflowPlugin{
+		BasePlugin: plugin.BasePlugin{Name: "TestPlugin"},
+		Lanes:      []plugin.TikiLane{{Name: "Lane0", Columns: 1}, {Name: "Lane1", Columns: 1}},
+		Layout:     testPluginLayout(t),
+	}
+	card := func(id string) *tikipkg.Tiki {
+		tk := tikipkg.New()
+		tk.SetID(id)
+		return tk
+	}
+	sw := &fakeSwimlanes{bands: []controller.SwimlaneBand{
+		{Name: "alice", Lanes: [][]*tikipkg.Tiki{{card("A1"), card("A2")}, {card("A3")}}},
+		{Name: "bob", Lanes: [][]*tikipkg.Tiki{nil, {card("B1")}}},
+	}}
+
+	pv := NewPluginView(store.NewInMemoryStore(), pluginConfig, pluginDef, sw.laneTikis, nil, controller.PluginViewActions(), true)
+	var movedTo []int
+	pv.SetMoveHandler(func(lane int) bool {
+		movedTo = append(movedTo, lane)
+		return true
+	})
+	pv.SetSwimlaneProvider(sw)
+
+	// both lanes get the same rows: alice's header and two card rows (lane 1
+	// padded), then bob's header and one card row
+	for lane, list := range pv.laneBoxes {
+		if len(list.items) != 5 {
+			t.Fatalf("lane %d has %d rows, want 5", lane, len(list.items))
+		}
+	}
+	if h, ok := pv.laneBoxes[0].items[0].(*bandHeader); !ok || h.label != "▾ alice (3)" || h.count != 2 {
+		t.Fatalf("lane 0 header = %+v, want alice with total 3 and lane count 2", pv.laneBoxes[0].items[0])
+	}
+	if h := pv.laneBoxes[1].items[3].(*bandHeader); h.label != "" || h.count != 1 {
+		t.Fatalf("lane 1 bob header = %+v, want unlabeled count 1", h)
+	}
+
+	// headers are one row, cards five: alice header y0, cards y1-5 and
+	// y6-10, bob header y11, bob cards y12-16
+	pv.root.SetRect(0, 0, 80, 25)
+	pv.laneBoxes[0].SetRect(0, 0, 40, 25)
+	pv.laneBoxes[1].SetRect(40, 0, 40, 25)
+	mouse := func(action tview.MouseAction, x, y int) {
+		t.Helper()
+		pv.handleMouse(action, tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+	}
+
+	mouse(tview.MouseLeftClick, 45, 11)
+	if len(sw.toggled) != 1 || sw.toggled[0] != "bob" {
+		t.Fatalf("toggled = %v, want [bob]", sw.toggled)
+	}
+
+	// drag A2 from lane 0 / alice onto B1 in lane 1 / bob
+	mouse(tview.MouseLeftDown, 5, 7)
+	if lane, idx := pluginConfig.GetSelectedLane(), pluginConfig.GetSelectedIndexForLane(0); lane != 0 || idx != 1 {
+		t.Fatalf("after press: lane %d index %d, want lane 0 index 1", lane, idx)
+	}
+	mouse(tview.MouseLeftUp, 45, 13)
+	if len(movedTo) != 1 || movedTo[0] != 1 || len(sw.movedTo) != 1 || sw.movedTo[0] != 1 {
+		t.Fatalf("lane moves %v, band moves %v; want [1] and [1]", movedTo, sw.movedTo)
+	}
+
+	// a drop within the same band moves lanes only
+	mouse(tview.MouseLeftDown, 5, 2)
+	mouse(tview.MouseLeftUp, 45, 8)
+	if len(movedTo) != 2 || len(sw.movedTo) != 1 {
+		t.Fatalf("lane moves %v, band moves %v after same-band drop", movedTo, sw.movedTo)
+	}
+}
@@ -2,14 +2,15 @@ package tikidetail
 
 import (
 	"fmt"
-	"path/filepath"
+	"log/slog"
 	"strings"
 
-	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/document"
 	"github.com/boolean-maybe/tiki/gridlayout"
 	"github.com/boolean-maybe/tiki/model"
 	"github.com/boolean-maybe/tiki/plugin"
+	"github.com/boolean-maybe/tiki/service"
 	"github.com/boolean-maybe/tiki/store"
 	"github.com/boolean-maybe/tiki/theme"
 	tikipkg "github.com/boolean-maybe/tiki/tiki"
@@ -63,6 +64,9 @@ type ConfigurableDetailView struct {
 	navMarkdown *markdown.NavigableMarkdown
 	listenerID  int
 
+	// prompt is the action-input bar (Attach file); nil until first shown.
+	prompt *inputPrompt
+
 	// progressHub reports image-resolution progress to the statusline; redraw
 	// runs a func on the UI goroutine (app.QueueUpdateDraw). Both may be nil
 	// in tests / minimal fixtures, in which case rendering stays synchronous.
@@ -261,7 +265,8 @@ func (cv *ConfigurableDetailView) refresh() {
 	roles := theme.Roles()
 
 	if !cv.fullscreen {
-		metadataBox := cv.buildMetadataBox(tk, roles)
+		// derived and computed fields live outside the tiki's own fields
+		metadataBox := cv.buildMetadataBox(tikipkg.WithDerived(tk), roles)
 		cv.content.AddItem(metadataBox, cv.spec.Rows+gridbox.DetailBoxOverhead, 0, false)
 	}
 
@@ -278,7 +283,7 @@ func (cv *ConfigurableDetailView) refresh() {
 }
 
 // buildDescriptionSection returns either the read-only markdown viewer or
-// the inline description TextArea editor, depending on whether edit mode
+// the inline description editor, depending on whether edit mode
 // is active and description has focus. Caching the editor on cv.editors
 // (keyed by descriptionFieldName) means typed content survives
 // inter-field refreshes — the same contract metadata editors rely on.
@@ -289,7 +294,7 @@ func (cv *ConfigurableDetailView) buildDescriptionSection(tk *tikipkg.Tiki) tvie
 	return cv.buildDescription(tk)
 }
 
-// ensureDescriptionEditor returns the cached description TextArea editor,
+// ensureDescriptionEditor returns the cached description editor,
 // constructing it on first focus. The widget seeds from tk.Body and
 // pushes every change through cv.onEditFieldChange[descriptionFieldName]
 // (wired by the controller to TikiEditSession.SaveDescription) so the
@@ -299,20 +304,7 @@ func (cv *ConfigurableDetailView) ensureDescriptionEditor(tk *tikipkg.Tiki) tvie
 	if w, ok := cv.editors[descriptionFieldName]; ok && w != nil {
 		return w
 	}
-	textArea := tview.NewTextArea()
-	textArea.SetText(tk.Body(), false)
-	// Match the read-only markdown viewer's framing: no border, equivalent
-	// padding so the cursor lines up with the rendered prose. The metadata
-	// box above already provides a visual seam between the two sections.
-	textArea.SetBorder(false)
-	textArea.SetBorderPadding(1, 1, 2, 2)
-	handler := cv.onEditFieldChange[descriptionFieldName]
-	if handler != nil {
-		textArea.SetChangedFunc(func() {
-			handler(textArea.GetText())
-		})
-	}
-	adapter := &descriptionEditAdapter{TextArea: textArea}
+	adapter := newDescriptionEditAdapter(tk, cv.tikiStore, cv.onEditFieldChange[descriptionFieldName])
 	cv.editors[descriptionFieldName] = adapter
 	return adapter
 }
@@ -414,7 +406,7 @@ func (cv *ConfigurableDetailView) buildMetadataBox(tk *tikipkg.Tiki, roles *them
 
 	frame := tview.NewFrame(container).SetBorders(0, 0, 0, 0, 0, 0)
 	frame.SetBorder(true).SetTitle(
-		fmt.Sprintf(" %s ", renderTikiIDGradient(tk.ID(), roles)),
+		fmt.Sprintf(" %s ", renderTikiIDGradient(store.DisplayID(tk), roles)),
 	).SetBorderColor(roles.BorderIdle().TCell())
 	frame.SetBorderPadding(1, 0, 2, 2)
 	return frame
@@ -518,7 +510,7 @@ func compositeSegmentContent(seg gridlayout.Segment, tk *tikipkg.Tiki, ctx Field
 	case "title":
 		return expandFieldText(tk.Title(), ctx.Roles)
 	case "id":
-		return renderTikiIDGradient(tk.ID(), ctx.Roles)
+		return renderTikiIDGradient(store.DisplayID(tk), ctx.Roles)
 	}
 	if wfd, ok := workflow.Field(seg.Name); ok {
 		// inside a composite, an empty/absent list field contributes no value
@@ -743,12 +735,12 @@ func (cv *ConfigurableDetailView) ensureEditor(name string, tk *tikipkg.Tiki, ct
 // image resolution stay identical.
 func (cv *ConfigurableDetailView) buildDescription(tk *tikipkg.Tiki) tview.Primitive {
 	desc := defaultString(tk.Body(), "(No description)")
+	if attachments, _, _ := tk.StringSliceField(service.AttachmentsField); len(attachments) > 0 {
+		desc += "\n\n" + service.AttachmentsMarkdown(attachments)
+	}
 	tikiSourcePath := tikiSourcePathFor(tk)
 
-	searchRoots := []string{config.GetDocDir()}
-	if tikiSourcePath != "" {
-		searchRoots = append([]string{filepath.Dir(tikiSourcePath)}, searchRoots...)
-	}
+	searchRoots := descriptionSearchRoots(tikiSourcePath)
 
 	resolver := &markdown.StoreResolver{Store: cv.tikiStore}
 	wrapped := markdown.NewWikilinkProvider(
@@ -760,6 +752,8 @@ func (cv *ConfigurableDetailView) buildDescription(tk *tikipkg.Tiki) tview.Primi
 		SearchRoots:    searchRoots,
 		ImageManager:   cv.imageManager,
 		MermaidOptions: cv.mermaidOpts,
+		OpenExternal:   openAttachment,
+		ExternalDir:    attachmentDirFor(tk, tikiSourcePath),
 	})
 	desc = markdown.RewriteWikilinks(desc, resolver)
 	cv.navMarkdown.Viewer().SetBorderPadding(1, 1, 2, 2)
@@ -768,6 +762,23 @@ func (cv *ConfigurableDetailView) buildDescription(tk *tikipkg.Tiki) tview.Primi
 	return cv.navMarkdown.Viewer()
 }
 
+// attachmentDirFor returns the tiki's attachment folder, the only place
+// description links may open with the system opener; "" for a tiki with no
+// file yet.
+func attachmentDirFor(tk *tikipkg.Tiki, sourcePath string) string {
+	if sourcePath == "" {
+		return ""
+	}
+	return document.AssetDir(sourcePath, tk.ID())
+}
+
+// openAttachment hands a linked non-markdown file to the system opener.
+func openAttachment(path string) {
+	if err := service.OpenExternal(path); err != nil {
+		slog.Error("failed to open attachment", "path", path, "error", err)
+	}
+}
+
 // renderDescription paints the markdown. When a progress hub and redraw are
 // wired, image resolution runs off the UI goroutine (statusline bar animates)
 // and the cache-warm render is applied on the UI goroutine afterward. Without
@@ -1220,7 +1231,7 @@ func (cv *ConfigurableDetailView) isEditableLayoutField(name string) bool {
 // skipped in edit mode. The tiki source mirrors what specForTiki/buildMetadataBox
 // actually render (getTiki prefers the in-flight editing copy in edit mode).
 func (cv *ConfigurableDetailView) isHiddenForCurrentTiki(name string) bool {
-	tk := cv.getTiki()
+	tk := tikipkg.WithDerived(cv.getTiki())
 	if tk == nil {
 		return false
 	}
@@ -0,0 +1,138 @@
+package tikidetail
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boolean-maybe/tiki/component"
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/store"
+	tikipkg "github.com/boolean-maybe/tiki/tiki"
+	"github.com/boolean-maybe/tiki/view/markdown"
+
+	"github.com/rivo/tview"
+)
+
+// descriptionEditAdapter is the inline body editor surfaced when Tab lands
+// on the description pseudo-field: a component.MarkdownEditor with a live
+// rendered preview beside it (Alt-P hides or shows the preview).
+// Non-cyclable; GetText returns the editor buffer.
+type descriptionEditAdapter struct {
+	*tview.Flex
+	editor      *component.MarkdownEditor
+	preview     *markdown.NavigableMarkdown
+	resolver    *markdown.StoreResolver
+	source      string
+	showPreview bool
+}
+
+// newDescriptionEditAdapter seeds the editor from tk's body and pushes every
+// edit through onChange (TikiEditSession.SaveDescription via the controller).
+// The editor follows `keys.profile`: vim gets modal editing, every other
+// profile the emacs motions.
+func newDescriptionEditAdapter(tk *tikipkg.Tiki, tikiStore store.Store, onChange func(string)) *descriptionEditAdapter {
+	source := tikiSourcePathFor(tk)
+	searchRoots := descriptionSearchRoots(source)
+	resolver := &markdown.StoreResolver{Store: tikiStore}
+
+	editor := component.NewMarkdownEditor().SetVimKeys(config.GetKeyProfile() == "vim")
+	editor.SetText(tk.Body())
+	// same framing as the read-only viewer so the text does not jump when
+	// the description switches between viewing and editing
+	editor.SetBorderPadding(1, 1, 2, 2)
+	if tikiStore != nil {
+		editor.SetCompleteFunc(wikilinkCompletions(tikiStore, tk.ID()))
+	}
+
+	// the preview renders text only: images and diagrams are resolved by the
+	// read-only viewer once the edit is saved
+	preview := markdown.NewNavigableMarkdown(markdown.NavigableMarkdownConfig{
+		Provider:    markdown.NewWikilinkProvider(newTikiDescriptionProvider(tikiStore, searchRoots), resolver),
+		SearchRoots: searchRoots,
+	})
+	preview.Viewer().SetBorderPadding(1, 1, 2, 2)
+
+	a := &descriptionEditAdapter{
+		Flex:        tview.NewFlex(),
+		editor:      editor,
+		preview:     preview,
+		resolver:    resolver,
+		source:      source,
+		showPreview: true,
+	}
+	editor.SetChangedFunc(func() {
+		if onChange != nil {
+			onChange(editor.GetText())
+		}
+		a.renderPreview()
+	})
+	editor.SetTogglePreviewFunc(a.togglePreview)
+	a.arrange()
+	a.renderPreview()
+	return a
+}
+
+func (a *descriptionEditAdapter) CycleValue(int) bool { return false }
+
+// GetText returns the editor content (the full description body).
+func (a *descriptionEditAdapter) GetText() string {
+	return a.editor.GetText()
+}
+
+// arrange lays out the editor, and the preview when shown, side by side.
+// The editor keeps focus either way.
+func (a *descriptionEditAdapter) arrange() {
+	a.Clear()
+	a.AddItem(a.editor, 0, 1, true)
+	if a.showPreview {
+		a.AddItem(a.preview.Viewer(), 0, 1, false)
+	}
+}
+
+func (a *descriptionEditAdapter) togglePreview() {
+	a.showPreview = !a.showPreview
+	a.arrange()
+	a.renderPreview()
+}
+
+func (a *descriptionEditAdapter) renderPreview() {
+	if !a.showPreview {
+		return
+	}
+	body := markdown.RewriteWikilinks(a.editor.GetText(), a.resolver)
+	a.preview.SetMarkdownWithSource(body, a.source, false)
+}
+
+// descriptionSearchRoots returns the directories relative links in a body
+// resolve against: the tiki's own directory first, then the doc root.
+func descriptionSearchRoots(source string) []string {
+	roots := []string{config.GetDocDir()}
+	if source != "" {
+		roots = append([]string{filepath.Dir(source)}, roots...)
+	}
+	return roots
+}
+
+// wikilinkCompletions offers the tikis whose id starts with, or whose title
+// contains, what was typed after `[[`. Id matches come first; the tiki being
+// edited is left out.
+func wikilinkCompletions(rs store.ReadStore, selfID string) func(string) []component.Completion {
+	return func(prefix string) []component.Completion {
+		q := strings.ToLower(prefix)
+		idMatch := func(tk *tikipkg.Tiki) bool {
+			return strings.HasPrefix(strings.ToLower(tk.ID()), q)
+		}
+		found := rs.SearchTikis("", func(tk *tikipkg.Tiki) bool {
+			return tk.ID() != selfID && (idMatch(tk) || strings.Contains(strings.ToLower(tk.Title()), q))
+		})
+		sort.SliceStable(found, func(i, j int) bool {
+			return idMatch(found[i]) && !idMatch(found[j])
+		})
+		out := make([]component.Completion, 0, len(found))
+		for _, tk := range found {
+			out = append(out, component.Completion{ID: tk.ID(), Title: tk.Title()})
+		}
+		return out
+	}
+}
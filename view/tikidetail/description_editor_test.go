@@ -0,0 +1,64 @@
+package tikidetail
+
+import (
+	"testing"
+
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/store"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TestDescriptionEditor_EditsFlowToHandlerAndComplete pins that the inline
+// body editor pushes every keystroke through the description change handler
+// and completes `[[` from the store, leaving out the tiki being edited.
+func TestDescriptionEditor_EditsFlowToHandlerAndComplete(t *testing.T) {
+	s := store.NewInMemoryStore()
+	tk := newTestViewTiki("TIKI201")
+	tk.SetBody("Body")
+	other := newTestViewTiki("TIKI202")
+	other.SetTitle("Login page")
+	if err := s.CreateTiki(tk); err != nil {
+		t.Fatalf("CreateTiki: %v", err)
+	}
+	if err := s.CreateTiki(other); err != nil {
+		t.Fatalf("CreateTiki: %v", err)
+	}
+	cv := NewConfigurableDetailView(
+		s, tk.ID(), detailPluginFromFields([]string{"status"}),
+		controller.DetailViewActions(),
+		nil, nil,
+		nil, nil,
+	)
+	cv.SetEditModeRegistry(controller.DetailEditModeActions())
+	var saved string
+	cv.SetEditFieldChangeHandler(descriptionFieldName, func(v string) { saved = v })
+	if !cv.EnterEditMode() || !cv.FocusNextField() || cv.GetFocusedFieldName() != descriptionFieldName {
+		t.Fatalf("could not reach the description editor, focus=%q", cv.GetFocusedFieldName())
+	}
+	adapter, ok := cv.editors[descriptionFieldName].(*descriptionEditAdapter)
+	if !ok {
+		t.Fatalf("description editor is %T, want *descriptionEditAdapter", cv.editors[descriptionFieldName])
+	}
+
+	handle := adapter.editor.InputHandler()
+	for _, r := range "See [[TIKI" {
+		handle(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), nil)
+	}
+	if saved != "See [[TIKIBody" {
+		t.Errorf("handler got %q, want the edited body", saved)
+	}
+	got := adapter.editor.Completions()
+	if len(got) != 1 || got[0].ID != "TIKI202" || got[0].Title != "Login page" {
+		t.Errorf("completions = %+v, want only TIKI202", got)
+	}
+	handle(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	if adapter.GetText() != "See [[TIKI202]]Body" {
+		t.Errorf("after accepting = %q", adapter.GetText())
+	}
+
+	adapter.togglePreview()
+	if adapter.GetItemCount() != 1 {
+		t.Errorf("hidden preview leaves %d items, want just the editor", adapter.GetItemCount())
+	}
+}
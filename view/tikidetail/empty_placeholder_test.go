@@ -240,8 +240,8 @@ func TestEmptyPlaceholder_ResolutionOrder(t *testing.T) {
 }
 
 // TestSemanticForValueType pins the catalog-type → registry-semantic bridge,
-// including the string-family fallback (TypeID/TypeRef/TypeDuration/TypeString
-// all → SemanticText).
+// including the string-family fallback (TypeID/TypeDuration/TypeString all →
+// SemanticText). TypeRef is the tikiId field type and gets its own picker.
 func TestSemanticForValueType(t *testing.T) {
 	cases := []struct {
 		in   workflow.ValueType
@@ -258,7 +258,10 @@ func TestSemanticForValueType(t *testing.T) {
 		{workflow.TypeUser, SemanticUser},
 		{workflow.TypeString, SemanticText},
 		{workflow.TypeID, SemanticText},
-		{workflow.TypeRef, SemanticText},
+		{workflow.TypeRef, SemanticTikiID},
+		{workflow.TypeEnumList, SemanticEnumList},
+		{workflow.TypeURL, SemanticURL},
+		{workflow.TypeNumber, SemanticNumber},
 		{workflow.TypeDuration, SemanticText},
 	}
 	for _, c := range cases {
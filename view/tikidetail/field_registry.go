@@ -2,6 +2,7 @@ package tikidetail
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -45,6 +46,10 @@ const (
 	SemanticRecurrence = fieldmeta.SemanticRecurrence
 	SemanticStringList = fieldmeta.SemanticStringList
 	SemanticTikiIDList = fieldmeta.SemanticTikiIDList
+	SemanticTikiID     = fieldmeta.SemanticTikiID
+	SemanticEnumList   = fieldmeta.SemanticEnumList
+	SemanticURL        = fieldmeta.SemanticURL
+	SemanticNumber     = fieldmeta.SemanticNumber
 )
 
 // EditorCapability tracks whether the type UI registry supports in-place
@@ -210,6 +215,13 @@ func MeasureFieldValue(name string, tk *tikipkg.Tiki, ctx FieldRenderContext) in
 		return measureStringListField(name, tk)
 	case workflow.TypeListRef:
 		return measureTikiIDListField(name, tk, ctx.Store)
+	case workflow.TypeRef:
+		// a single reference renders the same "ID title" row as one entry
+		// of a tikiIdList, so it measures the same way.
+		if fieldIsEmpty(tk, fd) {
+			return scalarCellWidth(emptyPlaceholder(name, SemanticTikiID))
+		}
+		return measureTikiIDListField(name, tk, ctx.Store)
 	case workflow.TypeEnum:
 		// the in-place enum editor cycles labels without the grid re-solving, so
 		// the column must fit the WIDEST declared label — not just the stored
@@ -566,6 +578,33 @@ func registerBuiltinTypes() {
 		IsEmpty:          listFieldEmpty,
 		EmptyPlaceholder: "(none)",
 	}
+	typeRegistry[SemanticTikiID] = TypeUI{
+		Render:           renderTikiIDValue,
+		Edit:             editTikiIDValue,
+		HeightFn:         singleRowHeight,
+		IsEmpty:          stringFieldEmpty,
+		EmptyPlaceholder: "(none)",
+	}
+	typeRegistry[SemanticEnumList] = TypeUI{
+		Render:           renderEnumListValue,
+		Edit:             editEnumListValue,
+		HeightFn:         singleRowHeight,
+		IsEmpty:          listFieldEmpty,
+		EmptyPlaceholder: "(none)",
+	}
+	typeRegistry[SemanticURL] = TypeUI{
+		Render:   renderURLValue,
+		Edit:     editTextValue,
+		HeightFn: singleRowHeight,
+		IsEmpty:  stringFieldEmpty,
+	}
+	typeRegistry[SemanticNumber] = TypeUI{
+		Render:           renderNumberValue,
+		Edit:             editNumberValue,
+		HeightFn:         singleRowHeight,
+		IsEmpty:          numberFieldEmpty,
+		EmptyPlaceholder: "─",
+	}
 	deriveCapabilities()
 }
 
@@ -623,6 +662,11 @@ func intFieldEmpty(tk *tikipkg.Tiki, name string) bool {
 	_, present, _ := tk.IntField(name)
 	return !present
 }
+func numberFieldEmpty(tk *tikipkg.Tiki, name string) bool {
+	raw, _ := tk.Get(name)
+	_, ok := value.NumberOf(raw)
+	return !ok
+}
 
 // semanticForValueType bridges the workflow catalog's ValueType to the
 // detail-view registry's SemanticType, so emptiness/placeholder traits resolve
@@ -743,6 +787,14 @@ func renderConfiguredField(name string, tk *tikipkg.Tiki, ctx FieldRenderContext
 			return renderStringListValue(tk, ctx)
 		case workflow.TypeListRef:
 			return renderTikiIDListValue(tk, ctx)
+		case workflow.TypeRef:
+			return renderTikiIDValue(tk, ctx)
+		case workflow.TypeEnumList:
+			return renderEnumListValue(tk, ctx)
+		case workflow.TypeURL:
+			return renderURLValue(tk, ctx)
+		case workflow.TypeNumber:
+			return renderNumberValue(tk, ctx)
 		}
 	}
 	if fd, ok := LookupField(name); ok {
@@ -807,6 +859,16 @@ func genericFieldValueString(fd workflow.FieldDef, tk *tikipkg.Tiki, ctx FieldRe
 		return "—"
 	}
 	switch fd.Type {
+	case workflow.TypeEnumList:
+		keys, _, _ := tk.StringSliceField(fd.Name)
+		if len(keys) == 0 {
+			return "—"
+		}
+		return enumListDisplay(fd, keys, ctx)
+	case workflow.TypeNumber:
+		if f, ok := value.NumberOf(raw); ok {
+			return tview.Escape(value.FormatNumberUnit(f, fd.Unit))
+		}
 	case workflow.TypeListString, workflow.TypeListRef:
 		ss, _, _ := tk.StringSliceField(fd.Name)
 		if len(ss) == 0 {
@@ -862,6 +924,10 @@ func genericFieldValueString(fd workflow.FieldDef, tk *tikipkg.Tiki, ctx FieldRe
 			}
 			return t.Format(value.DateTimeFormat)
 		}
+	case workflow.TypeDuration:
+		if d, ok := raw.(time.Duration); ok {
+			return value.FormatDuration(d)
+		}
 	}
 	switch v := raw.(type) {
 	case string:
@@ -1151,6 +1217,66 @@ func renderTikiIDListValue(tk *tikipkg.Tiki, ctx FieldRenderContext) tview.Primi
 	return tikiIDListColumn(ids, ctx.Store)
 }
 
+// renderTikiIDValue renders a single tikiId reference as one "ID title" row,
+// the same link-style row a tikiIdList entry gets. An id that does not
+// resolve renders with an "(unresolved)" title; no store → the bare id.
+func renderTikiIDValue(tk *tikipkg.Tiki, ctx FieldRenderContext) tview.Primitive {
+	id, _, _ := tk.StringField(ctx.FieldName)
+	if id == "" {
+		return valueOnlyLine(emptyPlaceholder(ctx.FieldName, SemanticTikiID), ctx.Roles)
+	}
+	if ctx.Store == nil {
+		return valueOnlyLine(tview.Escape(id), ctx.Roles)
+	}
+	return tikiIDListColumn([]string{id}, ctx.Store)
+}
+
+// renderEnumListValue renders an enumList as one comma-separated line of its
+// values: each value's visual in a `.visual` cell, its label otherwise.
+func renderEnumListValue(tk *tikipkg.Tiki, ctx FieldRenderContext) tview.Primitive {
+	wfd, ok := workflow.Field(ctx.FieldName)
+	keys, _, _ := tk.StringSliceField(ctx.FieldName)
+	if !ok || len(keys) == 0 {
+		return valueOnlyLine(emptyPlaceholder(ctx.FieldName, SemanticEnumList), ctx.Roles)
+	}
+	return valueOnlyLine(enumListDisplay(wfd, keys, ctx), ctx.Roles)
+}
+
+// enumListDisplay joins the display form of each enumList key, in stored
+// order. Shared by the renderer and the width measure.
+func enumListDisplay(fd workflow.FieldDef, keys []string, ctx FieldRenderContext) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = enumValueDisplay(fd, k, ctx)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderURLValue renders a url field in the action accent so it reads as a
+// link; `o` in the detail view opens it.
+func renderURLValue(tk *tikipkg.Tiki, ctx FieldRenderContext) tview.Primitive {
+	u, _, _ := tk.StringField(ctx.FieldName)
+	if u == "" {
+		return valueOnlyLine(emptyPlaceholder(ctx.FieldName, SemanticURL), ctx.Roles)
+	}
+	return gridbox.NewTruncatingTextView().SetText(ctx.Roles.AccentAction().Tag() + tview.Escape(u))
+}
+
+// renderNumberValue renders a number field in its shortest decimal form,
+// followed by the field's unit when one is declared.
+func renderNumberValue(tk *tikipkg.Tiki, ctx FieldRenderContext) tview.Primitive {
+	display := emptyPlaceholder(ctx.FieldName, SemanticNumber)
+	raw, _ := tk.Get(ctx.FieldName)
+	if f, ok := value.NumberOf(raw); ok {
+		unit := ""
+		if wfd, ok := workflow.Field(ctx.FieldName); ok {
+			unit = wfd.Unit
+		}
+		display = tview.Escape(value.FormatNumberUnit(f, unit))
+	}
+	return valueOnlyLine(display, ctx.Roles)
+}
+
 // --- editor factories ---
 
 // editTitleValue builds a plain text input for the title field.
@@ -1252,6 +1378,108 @@ func acceptSignedInteger(textToCheck string, _ rune) bool {
 	return true
 }
 
+// editNumberValue is the in-place editor for a number field: a free-type
+// input filtered to a signed decimal. The unit is display-only and is not
+// part of the edited text.
+func editNumberValue(tk *tikipkg.Tiki, ctx FieldRenderContext, onChange func(string)) FieldEditorWidget {
+	input := tview.NewInputField()
+	roles := ctx.Roles
+	input.SetFieldBackgroundColor(roles.SurfaceCanvas().TCell())
+	input.SetFieldTextColor(roles.TextPrimary().TCell())
+	input.SetLabel(getFocusMarker(ctx.Roles))
+	input.SetBorder(false)
+	input.SetAcceptanceFunc(acceptSignedDecimal)
+	raw, _ := tk.Get(ctx.FieldName)
+	if f, ok := value.NumberOf(raw); ok {
+		input.SetText(value.FormatNumber(f))
+	}
+	input.SetChangedFunc(func(text string) {
+		if onChange != nil {
+			onChange(text)
+		}
+	})
+	return &intEditAdapter{InputField: input}
+}
+
+// acceptSignedDecimal extends acceptSignedInteger with at most one decimal
+// point, so "2.", "-0." and ".5" are accepted while typing.
+func acceptSignedDecimal(textToCheck string, r rune) bool {
+	whole, frac, hasPoint := strings.Cut(textToCheck, ".")
+	if !hasPoint {
+		return acceptSignedInteger(textToCheck, r)
+	}
+	if strings.Contains(frac, ".") || strings.Contains(frac, "-") {
+		return false
+	}
+	return acceptSignedInteger(whole, r) && acceptSignedInteger(frac, r)
+}
+
+// editTikiIDValue is the picker for a tikiId field: Up/Down cycle through
+// every other tiki as "ID title", and the id can also be typed directly. The
+// adapter reports just the id — qualified (`BE:ABC123`) with workspaces
+// mounted — so the save path never sees the title.
+func editTikiIDValue(tk *tikipkg.Tiki, ctx FieldRenderContext, onChange func(string)) FieldEditorWidget {
+	current, _, _ := tk.StringField(ctx.FieldName)
+	var options []string
+	initial := current
+	if ctx.Store != nil {
+		others := ctx.Store.GetAllTikis()
+		sort.Slice(others, func(i, j int) bool { return others[i].ID() < others[j].ID() })
+		for _, other := range others {
+			if other.ID() == tk.ID() {
+				continue
+			}
+			option := store.DisplayID(other) + " " + other.Title()
+			if other.ID() == current {
+				initial = option
+			}
+			options = append(options, option)
+		}
+	}
+	editor := component.NewEditSelectList(options, true)
+	editor.SetLabel(getFocusMarker(ctx.Roles))
+	editor.SetInitialValue(initial)
+	editor.SetSubmitHandler(func(text string) {
+		if onChange != nil {
+			onChange(tikiIDFromOption(text))
+		}
+	})
+	return &tikiIDSelectAdapter{selectListAdapter: selectListAdapter{EditSelectList: editor}}
+}
+
+// tikiIDFromOption extracts the id from a picker option ("ABC123 Title") or
+// from a typed id.
+func tikiIDFromOption(text string) string {
+	id, _, _ := strings.Cut(strings.TrimSpace(text), " ")
+	return strings.ToUpper(id)
+}
+
+// editEnumListValue edits an enumList as space-separated value keys, the
+// same transport the stringList editor uses. Tab-completion is not bound
+// (Tab moves between fields), so the placeholder lists the allowed keys.
+func editEnumListValue(tk *tikipkg.Tiki, ctx FieldRenderContext, onChange func(string)) FieldEditorWidget {
+	wfd, ok := workflow.Field(ctx.FieldName)
+	if !ok || wfd.Type != workflow.TypeEnumList {
+		return nil
+	}
+	input := tview.NewInputField()
+	roles := ctx.Roles
+	input.SetFieldBackgroundColor(roles.SurfaceCanvas().TCell())
+	input.SetFieldTextColor(roles.TextPrimary().TCell())
+	input.SetLabel(getFocusMarker(ctx.Roles))
+	input.SetBorder(false)
+	input.SetPlaceholder(strings.Join(wfd.AllowedValues(), " "))
+	input.SetPlaceholderStyle(tcell.StyleDefault.Foreground(roles.TextMuted().TCell()))
+	keys, _, _ := tk.StringSliceField(ctx.FieldName)
+	input.SetText(strings.Join(keys, " "))
+	input.SetChangedFunc(func(text string) {
+		if onChange != nil {
+			onChange(text)
+		}
+	})
+	return &textInputEditAdapter{InputField: input}
+}
+
 // editBooleanValue is the generic in-place editor for any SemanticBoolean
 // field. It is a two-value cycle (false↔true) surfaced through a select-list so
 // the grid's Up/Down cycle dispatch works uniformly. Absent seeds "false".
@@ -1410,6 +1638,16 @@ func (a *enumSelectAdapter) GetText() string {
 	return label
 }
 
+// tikiIDSelectAdapter wraps the select-list adapter for the tikiId picker;
+// GetText returns the bare id rather than the "ID title" option text.
+type tikiIDSelectAdapter struct {
+	selectListAdapter
+}
+
+func (a *tikiIDSelectAdapter) GetText() string {
+	return tikiIDFromOption(a.selectListAdapter.GetText())
+}
+
 // selectListAdapter delegates CycleValue to MoveToNext/MoveToPrevious.
 type selectListAdapter struct {
 	*component.EditSelectList
@@ -1534,17 +1772,3 @@ func (a *titleEditAdapter) CycleValue(int) bool { return false }
 func (a *titleEditAdapter) GetText() string {
 	return a.InputField.GetText()
 }
-
-// descriptionEditAdapter wraps tview.TextArea for the inline description
-// editor surfaced when Tab lands on the description pseudo-field. Mirrors
-// stringListEditAdapter — non-cyclable, GetText returns the textarea content.
-type descriptionEditAdapter struct {
-	*tview.TextArea
-}
-
-func (a *descriptionEditAdapter) CycleValue(int) bool { return false }
-
-// GetText returns the textarea content (the full description body).
-func (a *descriptionEditAdapter) GetText() string {
-	return a.TextArea.GetText()
-}
@@ -0,0 +1,122 @@
+package tikidetail
+
+import (
+	"github.com/boolean-maybe/tiki/config"
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/theme"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Compile-time interface check: detail views host the action-input bar
+// (e.g. the Attach prompt) above the metadata box.
+var _ controller.InputableView = (*ConfigurableDetailView)(nil)
+
+// inputPrompt is the single-line action-input bar of a detail view. Detail
+// views have no search, so unlike the board InputHelper it only knows the
+// closed and action-input states.
+type inputPrompt struct {
+	field    *tview.InputField
+	visible  bool
+	onSubmit func(text string) controller.InputSubmitResult
+	onCancel func()
+}
+
+// newInputPrompt builds the bar with the same styling as the board input box.
+func newInputPrompt() *inputPrompt {
+	roles := theme.Roles()
+	field := tview.NewInputField()
+	field.SetLabelColor(roles.TextPrimary().TCell())
+	field.SetFieldBackgroundColor(roles.SurfaceTransparent().TCell())
+	field.SetFieldTextColor(roles.TextPrimary().TCell())
+	field.SetBorder(true)
+	field.SetBorderColor(roles.BorderIdle().TCell())
+	p := &inputPrompt{field: field}
+	field.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			if p.onSubmit != nil {
+				p.onSubmit(field.GetText())
+			}
+		case tcell.KeyEscape:
+			if p.onCancel != nil {
+				p.onCancel()
+			}
+		}
+	})
+	return p
+}
+
+// ensurePrompt lazily creates the input bar.
+func (cv *ConfigurableDetailView) ensurePrompt() *inputPrompt {
+	if cv.prompt != nil {
+		return cv.prompt
+	}
+	cv.prompt = newInputPrompt()
+	return cv.prompt
+}
+
+// ShowInputBox opens the action-input bar above the detail content.
+func (cv *ConfigurableDetailView) ShowInputBox(prompt, initial string) tview.Primitive {
+	p := cv.ensurePrompt()
+	p.field.SetLabel(prompt)
+	p.field.SetText(initial)
+	if !p.visible {
+		p.visible = true
+		cv.root.Clear()
+		cv.root.AddItem(p.field, config.InputBoxHeight, 0, true)
+		cv.root.AddItem(cv.content, 0, 1, false)
+	}
+	return p.field
+}
+
+// ShowSearchBox is a no-op: detail views have no search.
+func (cv *ConfigurableDetailView) ShowSearchBox() tview.Primitive { return nil }
+
+// HideInputBox closes the bar and returns focus to the description.
+func (cv *ConfigurableDetailView) HideInputBox() {
+	if cv.prompt == nil || !cv.prompt.visible {
+		return
+	}
+	cv.prompt.visible = false
+	cv.prompt.field.SetText("")
+	cv.root.Clear()
+	cv.root.AddItem(cv.content, 0, 1, true)
+	if cv.focusSetter != nil && cv.descView != nil {
+		cv.focusSetter(cv.descView)
+	}
+}
+
+// CancelInputBox closes the bar; there is no passive search to restore.
+func (cv *ConfigurableDetailView) CancelInputBox() { cv.HideInputBox() }
+
+// IsInputBoxVisible reports whether the action-input bar is shown.
+func (cv *ConfigurableDetailView) IsInputBoxVisible() bool {
+	return cv.prompt != nil && cv.prompt.visible
+}
+
+// IsInputBoxFocused reports whether the action-input bar has focus.
+func (cv *ConfigurableDetailView) IsInputBoxFocused() bool {
+	return cv.IsInputBoxVisible() && cv.prompt.field.HasFocus()
+}
+
+// IsSearchPassive is always false: detail views have no search.
+func (cv *ConfigurableDetailView) IsSearchPassive() bool { return false }
+
+// SetInputSubmitHandler sets the callback for Enter in the input bar.
+func (cv *ConfigurableDetailView) SetInputSubmitHandler(handler func(text string) controller.InputSubmitResult) {
+	p := cv.ensurePrompt()
+	p.onSubmit = func(text string) controller.InputSubmitResult {
+		result := handler(text)
+		if result != controller.InputKeepEditing {
+			cv.HideInputBox()
+		}
+		return result
+	}
+}
+
+// SetInputCancelHandler sets the callback for Esc in the input bar.
+func (cv *ConfigurableDetailView) SetInputCancelHandler(handler func()) {
+	cv.ensurePrompt().onCancel = handler
+}
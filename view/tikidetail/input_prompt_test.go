@@ -0,0 +1,67 @@
+package tikidetail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boolean-maybe/tiki/controller"
+	"github.com/boolean-maybe/tiki/store"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func newPromptTestView(t *testing.T) *ConfigurableDetailView {
+	t.Helper()
+	s := store.NewInMemoryStore()
+	tk := newTestViewTiki("TIKI001")
+	if err := s.CreateTiki(tk); err != nil {
+		t.Fatalf("CreateTiki: %v", err)
+	}
+	return NewConfigurableDetailView(s, tk.ID(), detailPluginFromFields([]string{"status"}),
+		controller.DetailViewActions(), nil, nil, nil, nil)
+}
+
+func TestConfigurableDetailView_InputBoxSubmitProtocol(t *testing.T) {
+	cv := newPromptTestView(t)
+	var submitted []string
+	result := controller.InputKeepEditing
+	cv.SetInputSubmitHandler(func(text string) controller.InputSubmitResult {
+		submitted = append(submitted, text)
+		return result
+	})
+	if cv.IsInputBoxVisible() {
+		t.Fatal("input box must start hidden")
+	}
+	box := cv.ShowInputBox("attach file> ", "shot.png")
+	if box == nil || !cv.IsInputBoxVisible() {
+		t.Fatal("ShowInputBox must show the bar")
+	}
+
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	box.InputHandler()(enter, nil)
+	if !cv.IsInputBoxVisible() {
+		t.Error("InputKeepEditing must keep the bar open")
+	}
+
+	result = controller.InputClose
+	box.InputHandler()(enter, nil)
+	if cv.IsInputBoxVisible() {
+		t.Error("InputClose must hide the bar")
+	}
+	if strings.Join(submitted, ",") != "shot.png,shot.png" {
+		t.Errorf("submitted = %v", submitted)
+	}
+}
+
+func TestConfigurableDetailView_InputBoxCancel(t *testing.T) {
+	cv := newPromptTestView(t)
+	cv.SetInputCancelHandler(cv.CancelInputBox)
+	box := cv.ShowInputBox("> ", "")
+	box.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), nil)
+	if cv.IsInputBoxVisible() {
+		t.Error("Esc must hide the bar")
+	}
+	if cv.ShowSearchBox() != nil || cv.IsSearchPassive() {
+		t.Error("detail views have no search")
+	}
+}
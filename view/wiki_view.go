@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"strings"
 
 	"github.com/boolean-maybe/tiki/config"
 	"github.com/boolean-maybe/tiki/controller"
@@ -21,7 +23,8 @@ import (
 )
 
 // WikiView renders a documentation plugin (navigable markdown). It backs
-// `kind: wiki` views; `kind: detail` is rendered by the configurable detail
+// `kind: wiki` views and, through NewDependencyView, the generated report of
+// `kind: dependencies`; `kind: detail` is rendered by the configurable detail
 // view in view/tikidetail and no longer flows through this type.
 // surfacedGlobals carries the workflow-level global actions (both
 // `kind: view` and `kind: ruki`) so the header and action palette (which
@@ -45,7 +48,10 @@ type WikiView struct {
 	surfacedGlobals     []plugin.PluginAction
 	selectedTikiID      string // selection carried in via PluginViewParams; surfaced through GetSelectedID() for action `require:` gates
 	actionChangeHandler func()
-	resolvedTitle       string // Ctrl-O markdown viewer only: document title (frontmatter/H1/filename), resolved from loaded content
+	resolvedTitle       string              // Ctrl-O markdown viewer only: document title (frontmatter/H1/filename), resolved from loaded content
+	openedSource        string              // source path of the document the view was opened with
+	generated           func() string       // generated reports (dependencies, sprints, activity): produce the markdown shown instead of a file
+	viewActions         []controller.Action // kind-specific actions listed after the document ones (activity filters)
 }
 
 // NewWikiView creates a wiki view. globalActions is the workflow's top-level
@@ -176,6 +182,10 @@ func (dv *WikiView) build() {
 	provider := markdown.NewWikilinkProvider(fileProvider, resolver)
 
 	switch {
+	case dv.generated != nil:
+		// generated reports (kind: dependencies) carry `[[ID]]` links that
+		// the provider would otherwise only rewrite on fetched pages.
+		content = markdown.RewriteWikilinks(dv.generated(), resolver)
 	case dv.pluginDef.DocumentPath != "":
 		content, sourcePath = loadWikiContent(provider, dv.pluginDef.DocumentPath, searchRoots)
 	case dv.pluginDef.GetKind() == plugin.KindDetail && dv.selectedTikiID != "" && dv.tikiStore != nil:
@@ -217,6 +227,7 @@ func (dv *WikiView) build() {
 	} else {
 		dv.md.SetMarkdown(content)
 	}
+	dv.openedSource = dv.md.SourceFilePath()
 
 	// root layout
 	dv.root = tview.NewFlex().SetDirection(tview.FlexRow)
@@ -257,7 +268,7 @@ func wikiEmptyState(docPath string) string {
 func (dv *WikiView) rebuildLayout() {
 	dv.root.Clear()
 	dv.root.AddItem(dv.titleBar, 1, 0, false)
-	dv.root.AddItem(dv.md.Viewer(), 0, 1, true)
+	dv.root.AddItem(dv.md.View(), 0, 1, true)
 }
 
 // GetSelectedID implements controller.SelectableView. A kind:wiki view
@@ -317,6 +328,21 @@ func (dv *WikiView) isMarkdownFileViewer() bool {
 // GetViewDescription returns the plugin description for the header info section
 func (dv *WikiView) GetViewDescription() string { return dv.pluginDef.GetDescription() }
 
+// CurrentDocumentPath returns the document the view shows after following
+// links, relative to the document root, or "" while it still shows the
+// document it was opened with. Saved with the session so a reopened wiki
+// view lands on the last page read.
+func (dv *WikiView) CurrentDocumentPath() string {
+	current := dv.md.SourceFilePath()
+	if current == "" || current == dv.openedSource {
+		return ""
+	}
+	if rel, err := filepath.Rel(config.GetDocDir(), current); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return current
+}
+
 // DocumentPath returns the effective document path this view renders.
 // Exposed for tests that verify per-navigation path overrides.
 func (dv *WikiView) DocumentPath() string {
@@ -349,6 +375,53 @@ func (dv *WikiView) SetActionChangeHandler(handler func()) {
 	dv.actionChangeHandler = handler
 }
 
+// SetFocusSetter implements controller.FocusSettable: the search prompt and
+// the outline take focus through it.
+func (dv *WikiView) SetFocusSetter(setter func(p tview.Primitive)) {
+	dv.md.SetFocusFunc(setter)
+}
+
+// StartDocumentSearch implements controller.DocumentView.
+func (dv *WikiView) StartDocumentSearch() {
+	dv.md.StartSearch()
+}
+
+// FindNext implements controller.DocumentView.
+func (dv *WikiView) FindNext() bool {
+	return dv.md.NextMatch()
+}
+
+// FindPrevious implements controller.DocumentView.
+func (dv *WikiView) FindPrevious() bool {
+	return dv.md.PrevMatch()
+}
+
+// ToggleOutline implements controller.DocumentView.
+func (dv *WikiView) ToggleOutline() {
+	dv.md.ToggleOutline()
+}
+
+// GetStats puts the section being read, and the search position while a
+// search is active, in the statusline.
+func (dv *WikiView) GetStats() []store.Stat {
+	var stats []store.Stat
+	if crumb := dv.md.BreadcrumbText(); crumb != "" {
+		stats = append(stats, store.Stat{Name: "Section", Value: crumb, Order: 5})
+	}
+	if query, current, total := dv.md.SearchStatus(); query != "" {
+		stats = append(stats, store.Stat{Name: "Match", Value: searchPosition(current, total), Order: 6})
+	}
+	return stats
+}
+
+// searchPosition formats the current match for the statusline.
+func searchPosition(current, total int) string {
+	if total == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%d/%d", current, total)
+}
+
 // UpdateNavigationActions updates the registry to reflect current navigation state
 func (dv *WikiView) UpdateNavigationActions() {
 	// Clear and rebuild the registry
@@ -390,6 +463,42 @@ func (dv *WikiView) UpdateNavigationActions() {
 		})
 	}
 
+	// in-document search and the heading outline; next/previous match
+	// only while a search is active
+	dv.registry.Register(controller.Action{
+		ID:           controller.ActionFindInDocument,
+		Key:          tcell.KeyRune,
+		Rune:         '/',
+		Label:        "Find",
+		ShowInHeader: true,
+	})
+	if query, _, _ := dv.md.SearchStatus(); query != "" {
+		dv.registry.Register(controller.Action{
+			ID:           controller.ActionFindNext,
+			Key:          tcell.KeyRune,
+			Rune:         'n',
+			Label:        "Next match",
+			ShowInHeader: true,
+		})
+		dv.registry.Register(controller.Action{
+			ID:           controller.ActionFindPrevious,
+			Key:          tcell.KeyRune,
+			Rune:         'N',
+			Label:        "Previous match",
+			ShowInHeader: true,
+		})
+	}
+	dv.registry.Register(controller.Action{
+		ID:           controller.ActionToggleOutline,
+		Key:          tcell.KeyRune,
+		Rune:         'o',
+		Label:        "Outline",
+		ShowInHeader: true,
+	})
+	for _, a := range dv.viewActions {
+		dv.registry.Register(a)
+	}
+
 	// Surface workflow-level `kind: view` actions so the header and action
 	// palette show them alongside the built-in navigation actions. Without
 	// this, globals would fire on keystroke (via the controller) but stay
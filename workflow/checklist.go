@@ -0,0 +1,49 @@
+package workflow
+
+// Derived checklist fields. They are computed from the GitHub-style task
+// list (`- [ ] item`) in a tiki's body, are read-only in ruki, are never
+// persisted and are always part of the field catalog; workflow.yaml may not
+// declare fields with these names.
+const (
+	FieldChecklistTotal    = "checklistTotal"    // number of task list items
+	FieldChecklistDone     = "checklistDone"     // number of checked items
+	FieldChecklistProgress = "checklistProgress" // progress bar text, e.g. "▰▰▰▱▱ 3/5"
+)
+
+// checklistFieldCatalog lists the checklist fields in catalog order.
+var checklistFieldCatalog = []FieldDef{
+	{Name: FieldChecklistTotal, Type: TypeInt, Caption: "Checklist items", Derived: true},
+	{Name: FieldChecklistDone, Type: TypeInt, Caption: "Checked items", Derived: true},
+	{Name: FieldChecklistProgress, Type: TypeString, Caption: "Checklist", Derived: true},
+}
+
+var checklistFieldByName = func() map[string]FieldDef {
+	m := make(map[string]FieldDef, len(checklistFieldCatalog))
+	for _, f := range checklistFieldCatalog {
+		m[f.Name] = f
+	}
+	return m
+}()
+
+// ChecklistFields returns a copy of the checklist field catalog.
+func ChecklistFields() []FieldDef {
+	out := make([]FieldDef, len(checklistFieldCatalog))
+	copy(out, checklistFieldCatalog)
+	return out
+}
+
+// ChecklistFieldNames returns the names of the checklist fields in catalog
+// order.
+func ChecklistFieldNames() []string {
+	names := make([]string, len(checklistFieldCatalog))
+	for i, f := range checklistFieldCatalog {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// IsChecklistFieldName reports whether name is one of the checklist fields.
+func IsChecklistFieldName(name string) bool {
+	_, ok := checklistFieldByName[name]
+	return ok
+}
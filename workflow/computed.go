@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Computed fields are workflow fields declared with a `computed:` ruki
+// expression. Their value is evaluated per tiki on read (see
+// store.ComputedIndex); they are read-only and never persisted.
+
+// IsComputed reports whether the field's value comes from its Computed
+// expression rather than from the tiki's frontmatter.
+func (f FieldDef) IsComputed() bool {
+	return f.Computed != ""
+}
+
+// ComputedFields returns the registered computed fields in declaration order.
+func ComputedFields() []FieldDef {
+	var out []FieldDef
+	for _, f := range WorkflowFields() {
+		if f.IsComputed() {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// IsComputedField reports whether name is a registered computed field.
+func IsComputedField(name string) bool {
+	f, ok := Field(name)
+	return ok && f.IsComputed()
+}
+
+// identRE matches identifier-shaped tokens in a ruki expression.
+var identRE = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// validateComputedFields checks that duration fields are computed and that
+// no computed expression refers to itself, directly or through other
+// computed fields. References are found textually (string literals and `--`
+// comments stripped), so a mention inside a subquery counts too: a field
+// whose value depends on other tikis' values of the same field could never
+// finish evaluating.
+func validateComputedFields(defs []FieldDef) error {
+	computed := make(map[string]bool)
+	for _, d := range defs {
+		if d.Type == TypeDuration && !d.IsComputed() {
+			return fmt.Errorf("workflow field %q: duration fields must be computed", d.Name)
+		}
+		if d.IsComputed() {
+			computed[d.Name] = true
+		}
+	}
+	refs := make(map[string][]string, len(computed))
+	for _, d := range defs {
+		if d.IsComputed() {
+			refs[d.Name] = computedRefs(d.Computed, computed)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(refs))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("computed field cycle: %s → %s", strings.Join(path, " → "), name)
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, ref := range refs[name] {
+			if err := visit(ref); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+	for _, d := range defs {
+		if d.IsComputed() {
+			if err := visit(d.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// computedRefs returns the names in fields that expr mentions, in order of
+// first mention.
+func computedRefs(expr string, fields map[string]bool) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, tok := range identRE.FindAllString(stripLiterals(expr), -1) {
+		if fields[tok] && !seen[tok] {
+			seen[tok] = true
+			out = append(out, tok)
+		}
+	}
+	return out
+}
+
+// stripLiterals blanks out string literals and `--` line comments.
+func stripLiterals(expr string) string {
+	var b strings.Builder
+	inString, inComment := false, false
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\n':
+			inComment = false
+			b.WriteByte(c)
+		case inComment:
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			b.WriteByte(' ')
+		case c == '-' && i+1 < len(expr) && expr[i+1] == '-':
+			inComment = true
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package workflow
+
+import "fmt"
+
+// Derived dependency fields. They are computed by the store from the
+// dependency graph (see store.DependencyIndex), are read-only in ruki and are
+// never persisted. They are only part of the field catalog while the loaded
+// workflow declares a `dependencies:` section.
+const (
+	FieldBlocked  = "blocked"  // true when at least one dependency is unfinished
+	FieldBlockers = "blockers" // ids of the unfinished direct dependencies
+	FieldDepth    = "depth"    // length of the longest chain of unfinished dependencies
+)
+
+// derivedFieldCatalog lists the derived fields in catalog order.
+var derivedFieldCatalog = []FieldDef{
+	{Name: FieldBlocked, Type: TypeBool, Caption: "Blocked", Derived: true},
+	{Name: FieldBlockers, Type: TypeListRef, Caption: "Blockers", Derived: true},
+	{Name: FieldDepth, Type: TypeInt, Caption: "Depth", Derived: true},
+}
+
+// derived field state — enabled by config.LoadWorkflowFields() when the
+// workflow declares dependencies. Guarded by workflowMu.
+var (
+	derivedFields      []FieldDef
+	derivedFieldByName map[string]FieldDef
+)
+
+// DerivedFields returns a copy of the derived field catalog, whether or not
+// it is currently enabled. Used when validating a candidate workflow.
+func DerivedFields() []FieldDef {
+	out := make([]FieldDef, len(derivedFieldCatalog))
+	copy(out, derivedFieldCatalog)
+	return out
+}
+
+// DerivedFieldNames returns the names of the derived fields in catalog order.
+func DerivedFieldNames() []string {
+	names := make([]string, len(derivedFieldCatalog))
+	for i, f := range derivedFieldCatalog {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// IsDerivedFieldName reports whether name is one of the derived field names,
+// regardless of whether derived fields are enabled.
+func IsDerivedFieldName(name string) bool {
+	for _, f := range derivedFieldCatalog {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EnableDerivedFields adds the derived fields to the catalog. Fails when a
+// registered workflow field already uses one of their names.
+func EnableDerivedFields() error {
+	workflowMu.Lock()
+	defer workflowMu.Unlock()
+	byName := make(map[string]FieldDef, len(derivedFieldCatalog))
+	for _, f := range derivedFieldCatalog {
+		if _, taken := workflowFieldByName[f.Name]; taken {
+			return fmt.Errorf("workflow field %q collides with derived dependency field; rename it or remove the dependencies: section", f.Name)
+		}
+		byName[f.Name] = f
+	}
+	derivedFields = DerivedFields()
+	derivedFieldByName = byName
+	return nil
+}
+
+// DisableDerivedFields removes the derived fields from the catalog.
+func DisableDerivedFields() {
+	workflowMu.Lock()
+	derivedFields = nil
+	derivedFieldByName = nil
+	workflowMu.Unlock()
+}
+
+// DerivedFieldsEnabled reports whether the derived fields are in the catalog.
+func DerivedFieldsEnabled() bool {
+	workflowMu.RLock()
+	defer workflowMu.RUnlock()
+	return len(derivedFields) > 0
+}
+
+// FieldWorkspace is the derived field naming the workspace a tiki was loaded
+// from (its prefix in config.yaml `workspaces:`). It is computed by the store,
+// read-only in ruki, never persisted, and only part of the field catalog while
+// workspaces are mounted.
+const FieldWorkspace = "workspace"
+
+var workspaceFieldDef = FieldDef{Name: FieldWorkspace, Type: TypeString, Caption: "Workspace", Derived: true}
+
+// workspace field state — enabled by the store bootstrap when config.yaml
+// mounts workspaces. Guarded by workflowMu.
+var workspaceFieldEnabled bool
+
+// EnableWorkspaceField adds the workspace field to the catalog. Fails when a
+// registered workflow field already uses its name.
+func EnableWorkspaceField() error {
+	workflowMu.Lock()
+	defer workflowMu.Unlock()
+	if _, taken := workflowFieldByName[FieldWorkspace]; taken {
+		return fmt.Errorf("workflow field %q collides with the derived workspace field; rename it or remove the workspaces: section", FieldWorkspace)
+	}
+	workspaceFieldEnabled = true
+	return nil
+}
+
+// DisableWorkspaceField removes the workspace field from the catalog.
+func DisableWorkspaceField() {
+	workflowMu.Lock()
+	workspaceFieldEnabled = false
+	workflowMu.Unlock()
+}
+
+// FieldCurrentSprint is the derived field naming the sprint whose dates
+// contain today, so `where sprint = currentSprint` selects the current
+// iteration. It is computed by the store, read-only in ruki, never persisted,
+// and only part of the field catalog while the loaded workflow declares a
+// `sprints:` section. Tikis outside any sprint's dates see it unset.
+const FieldCurrentSprint = "currentSprint"
+
+var sprintFieldDef = FieldDef{Name: FieldCurrentSprint, Type: TypeString, Caption: "Current sprint", Derived: true}
+
+// sprint field state — enabled by config.LoadWorkflowFields() when the
+// workflow declares sprints. Guarded by workflowMu.
+var sprintFieldEnabled bool
+
+// SprintFields returns the derived sprint field catalog, whether or not it
+// is currently enabled. Used when validating a candidate workflow.
+func SprintFields() []FieldDef {
+	return []FieldDef{sprintFieldDef}
+}
+
+// EnableSprintField adds the currentSprint field to the catalog. Fails when
+// a registered workflow field already uses its name.
+func EnableSprintField() error {
+	workflowMu.Lock()
+	defer workflowMu.Unlock()
+	if _, taken := workflowFieldByName[FieldCurrentSprint]; taken {
+		return fmt.Errorf("workflow field %q collides with the derived current sprint field; rename it or remove the sprints: section", FieldCurrentSprint)
+	}
+	sprintFieldEnabled = true
+	return nil
+}
+
+// DisableSprintField removes the currentSprint field from the catalog.
+func DisableSprintField() {
+	workflowMu.Lock()
+	sprintFieldEnabled = false
+	workflowMu.Unlock()
+}
@@ -17,7 +17,7 @@ const (
 	TypeInt                  // numeric
 	TypeDate                 // midnight-UTC date
 	TypeTimestamp            // full timestamp (e.g. createdAt, updatedAt)
-	TypeDuration             // reserved for future use
+	TypeDuration             // elapsed time; only produced by computed fields
 	TypeBool                 // reserved for future use
 	TypeID                   // bare document identifier (^[A-Z0-9]{6}$)
 	TypeRef                  // reference to another document ID
@@ -26,15 +26,25 @@ const (
 	TypeListRef              // []string of document ID references
 	TypeEnum                 // enum field with EnumValues metadata
 	TypeUser                 // string with user-picker editor semantics
+	TypeEnumList             // []string of EnumValues keys (multi-select enum)
+	TypeURL                  // absolute URL string, openable from the detail view
+	TypeNumber               // float64, optionally displayed with a Unit
 )
 
 // IsList reports whether the type is one of the list-valued types
-// (TypeListString or TypeListRef). Both store a []string; the distinction is
-// whether the elements are free strings or document-ID references. Several
+// (TypeListString, TypeListRef or TypeEnumList). All store a []string; the
+// distinction is whether the elements are free strings, document-ID
+// references or enum keys. Several
 // renderers/measurers/validators branch on list-ness, so the predicate lives
 // here rather than being re-spelled at each site.
 func (t ValueType) IsList() bool {
-	return t == TypeListString || t == TypeListRef
+	return t == TypeListString || t == TypeListRef || t == TypeEnumList
+}
+
+// IsEnum reports whether the type draws its values from EnumValues
+// (TypeEnum or TypeEnumList).
+func (t ValueType) IsEnum() bool {
+	return t == TypeEnum || t == TypeEnumList
 }
 
 // EnumValue describes one allowed value of a TypeEnum field with display
@@ -50,15 +60,18 @@ type EnumValue struct {
 }
 
 // FieldDef describes a single document field's name and semantic type.
-// EnumValues is non-empty only for TypeEnum fields; AllowedValues() returns
-// just the keys for ruki schema construction.
+// EnumValues is non-empty only for TypeEnum and TypeEnumList fields;
+// AllowedValues() returns just the keys for ruki schema construction.
 type FieldDef struct {
 	Name         string
 	Type         ValueType
 	Custom       bool        // true for fields loaded from workflow.yaml
 	Caption      string      // optional display caption; falls back to Name via DisplayCaption()
-	EnumValues   []EnumValue // populated only for TypeEnum
+	EnumValues   []EnumValue // populated only for TypeEnum and TypeEnumList
+	Unit         string      // optional display unit for TypeNumber (e.g. "h", "EUR")
 	DefaultValue interface{} // creation default for non-enum fields; for enum, derived from EnumValues[i].Default
+	Derived      bool        // true for read-only fields computed by the store (see derived.go)
+	Computed     string      // ruki expression for a read-only computed field (see computed.go)
 }
 
 // DisplayCaption returns the field's display caption, falling back to the
@@ -70,10 +83,10 @@ func (f FieldDef) DisplayCaption() string {
 	return f.Name
 }
 
-// AllowedValues returns the value keys for an enum FieldDef in declaration
-// order. Returns nil for non-enum fields.
+// AllowedValues returns the value keys for an enum or enumList FieldDef in
+// declaration order. Returns nil for other fields.
 func (f FieldDef) AllowedValues() []string {
-	if f.Type != TypeEnum {
+	if !f.Type.IsEnum() {
 		return nil
 	}
 	out := make([]string, len(f.EnumValues))
@@ -159,9 +172,10 @@ func (f FieldDef) EnumParseLabel(label string) (string, bool) {
 	return "", false
 }
 
-// IsValidEnum reports whether key is a recognized value for this enum.
+// IsValidEnum reports whether key is a recognized value for this enum (or
+// one element of this enumList).
 func (f FieldDef) IsValidEnum(key string) bool {
-	if f.Type != TypeEnum {
+	if !f.Type.IsEnum() {
 		return false
 	}
 	for _, v := range f.EnumValues {
@@ -236,6 +250,18 @@ func Field(name string) (FieldDef, bool) {
 	if f, ok := workflowFieldByName[name]; ok {
 		return deepCopyFieldDef(f), true
 	}
+	if f, ok := derivedFieldByName[name]; ok {
+		return f, true
+	}
+	if workspaceFieldEnabled && name == FieldWorkspace {
+		return workspaceFieldDef, true
+	}
+	if sprintFieldEnabled && name == FieldCurrentSprint {
+		return sprintFieldDef, true
+	}
+	if f, ok := checklistFieldByName[name]; ok {
+		return f, true
+	}
 	return FieldDef{}, false
 }
 
@@ -256,18 +282,27 @@ func ValidateFieldName(name string) error {
 }
 
 // Fields returns the ordered list of all DSL-visible document fields
-// (system + loaded workflow fields). Returns deep copies so callers cannot
-// mutate registry state.
+// (system + loaded workflow fields + enabled derived fields + checklist
+// fields). Returns deep
+// copies so callers cannot mutate registry state.
 func Fields() []FieldDef {
 	workflowMu.RLock()
 	defer workflowMu.RUnlock()
-	result := make([]FieldDef, 0, len(systemFieldCatalog)+len(workflowFields))
+	result := make([]FieldDef, 0, len(systemFieldCatalog)+len(workflowFields)+len(derivedFields)+len(checklistFieldCatalog))
 	for _, f := range systemFieldCatalog {
 		result = append(result, f) // system fields have no mutable slices
 	}
 	for _, f := range workflowFields {
 		result = append(result, deepCopyFieldDef(f))
 	}
+	result = append(result, derivedFields...) // derived fields have no mutable slices
+	if workspaceFieldEnabled {
+		result = append(result, workspaceFieldDef)
+	}
+	if sprintFieldEnabled {
+		result = append(result, sprintFieldDef)
+	}
+	result = append(result, checklistFieldCatalog...)
 	return result
 }
 
@@ -284,9 +319,22 @@ func WorkflowFields() []FieldDef {
 	return result
 }
 
+// FieldsOfType returns the workflow-declared fields of type t, in
+// declaration order.
+func FieldsOfType(t ValueType) []FieldDef {
+	var out []FieldDef
+	for _, f := range WorkflowFields() {
+		if f.Type == t {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
 // ValidateWorkflowFields checks workflow field definitions for collisions
-// with system fields, case-insensitive duplicates, valid identifiers, and
-// well-formed enum values, without modifying global state.
+// with system and checklist fields, case-insensitive duplicates, valid
+// identifiers, well-formed enum values and acyclic computed fields, without
+// modifying global state.
 func ValidateWorkflowFields(defs []FieldDef) error {
 	systemLower := make(map[string]string, len(systemFieldByName))
 	for name := range systemFieldByName {
@@ -303,17 +351,20 @@ func ValidateWorkflowFields(defs []FieldDef) error {
 		if sysName, ok := systemLower[lower]; ok {
 			return fmt.Errorf("workflow field %q collides with reserved system field %q", d.Name, sysName)
 		}
+		if _, ok := checklistFieldByName[d.Name]; ok {
+			return fmt.Errorf("workflow field %q collides with the derived checklist field of the same name", d.Name)
+		}
 		if prev, ok := seenLower[lower]; ok {
 			return fmt.Errorf("workflow field %q collides with %q (case-insensitive)", d.Name, prev)
 		}
 		seenLower[lower] = d.Name
-		if d.Type == TypeEnum {
+		if d.Type.IsEnum() {
 			if err := validateEnumValues(d.Name, d.EnumValues); err != nil {
 				return err
 			}
 		}
 	}
-	return nil
+	return validateComputedFields(defs)
 }
 
 // validateEnumValues checks that an enum field has at least one value, all
@@ -404,6 +455,10 @@ func ClearWorkflowFields() {
 	workflowMu.Lock()
 	workflowFields = nil
 	workflowFieldByName = nil
+	derivedFields = nil
+	derivedFieldByName = nil
+	workspaceFieldEnabled = false
+	sprintFieldEnabled = false
 	workflowMu.Unlock()
 }
 
@@ -185,6 +185,19 @@ func TestRegisterWorkflowFields_RejectsSystemFieldCollision(t *testing.T) {
 	}
 }
 
+func TestRegisterWorkflowFields_RejectsChecklistFieldCollision(t *testing.T) {
+	t.Cleanup(func() { ClearWorkflowFields() })
+
+	for _, name := range ChecklistFieldNames() {
+		if err := RegisterWorkflowFields([]FieldDef{{Name: name, Type: TypeInt}}); err == nil {
+			t.Errorf("expected error for checklist field collision %q", name)
+		}
+	}
+	if fd, ok := Field(FieldChecklistDone); !ok || !fd.Derived || fd.Type != TypeInt {
+		t.Errorf("Field(%q) = %+v, %v; want a derived int", FieldChecklistDone, fd, ok)
+	}
+}
+
 func TestRegisterWorkflowFields_CaseInsensitiveSystemCollision(t *testing.T) {
 	t.Cleanup(func() { ClearWorkflowFields() })
 
@@ -0,0 +1,18 @@
+package workflow
+
+import "github.com/boolean-maybe/ruki"
+
+// NumberSchema is implemented by ruki schemas built from the workflow
+// fields. ruki has no decimal type, so number fields are display-only: the
+// schema leaves them out of ruki, where naming one is an unknown-field
+// error, and reports them here for the host-side checks (view layouts, lane
+// summaries) that accept them.
+type NumberSchema interface {
+	NumberField(name string) bool
+}
+
+// IsNumberField reports whether schema declares name as a number field.
+func IsNumberField(schema ruki.Schema, name string) bool {
+	ns, ok := schema.(NumberSchema)
+	return ok && ns.NumberField(name)
+}
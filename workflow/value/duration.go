@@ -0,0 +1,37 @@
+package value
+
+import (
+	"strconv"
+	"time"
+)
+
+// FormatDuration renders a duration-typed computed value in its two largest
+// units ("3d 4h", "2h 5m", "45m", "30s"). Negative durations keep their sign.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+	for i, u := range units {
+		if d < u.size {
+			continue
+		}
+		out := strconv.FormatInt(int64(d/u.size), 10) + u.suffix
+		if i+1 < len(units) {
+			next := units[i+1]
+			if rest := (d % u.size) / next.size; rest > 0 {
+				out += " " + strconv.FormatInt(int64(rest), 10) + next.suffix
+			}
+		}
+		return out
+	}
+	return "0s"
+}
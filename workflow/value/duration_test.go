@@ -0,0 +1,24 @@
+package value
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                                 "0s",
+		500 * time.Millisecond:            "0s",
+		30 * time.Second:                  "30s",
+		45 * time.Minute:                  "45m",
+		2*time.Hour + 5*time.Minute:       "2h 5m",
+		3*24*time.Hour + 4*time.Hour + 59: "3d 4h",
+		48 * time.Hour:                    "2d",
+		-90 * time.Minute:                 "-1h 30m",
+	}
+	for d, want := range tests {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package value
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseNumber parses the decimal text of a number-typed workflow field.
+// Surrounding whitespace is ignored; NaN and infinities are rejected so a
+// stored number always round-trips through YAML and FormatNumber.
+func ParseNumber(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// NumberOf extracts a number from the in-memory shapes a number field can
+// hold: float64 (loaded from YAML), int (an integer YAML scalar or a Go
+// caller) or the decimal string a ruki assignment produces.
+func NumberOf(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, false
+		}
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		return ParseNumber(v)
+	}
+	return 0, false
+}
+
+// FormatNumber renders a number in its shortest exact decimal form, without
+// an exponent ("2.5", "1000000", "0.125").
+func FormatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// FormatNumberUnit renders a number followed by its display unit, when one
+// is declared ("2.5 h").
+func FormatNumberUnit(f float64, unit string) string {
+	if unit == "" {
+		return FormatNumber(f)
+	}
+	return FormatNumber(f) + " " + unit
+}
@@ -0,0 +1,57 @@
+package value
+
+import "testing"
+
+func TestNumberOf(t *testing.T) {
+	tests := []struct {
+		raw  interface{}
+		want float64
+		ok   bool
+	}{
+		{2.5, 2.5, true},
+		{3, 3, true},
+		{" 0.125 ", 0.125, true},
+		{"-4", -4, true},
+		{"1e3", 1000, true},
+		{"NaN", 0, false},
+		{"Inf", 0, false},
+		{"twelve", 0, false},
+		{true, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := NumberOf(tt.raw)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("NumberOf(%#v) = %v, %v; want %v, %v", tt.raw, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := map[float64]string{
+		2.5:     "2.5",
+		1000000: "1000000",
+		0.1:     "0.1",
+		-3:      "-3",
+	}
+	for in, want := range tests {
+		if got := FormatNumber(in); got != want {
+			t.Errorf("FormatNumber(%v) = %q, want %q", in, got, want)
+		}
+	}
+	if got := FormatNumberUnit(1.5, "h"); got != "1.5 h" {
+		t.Errorf("FormatNumberUnit = %q", got)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	for _, ok := range []string{"", "https://example.com/a?b=c", "http://localhost:8080", "mailto:team@example.com"} {
+		if err := ValidateURL(ok); err != nil {
+			t.Errorf("ValidateURL(%q) = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"example.com", "https://", "/relative/path", "http://[::1"} {
+		if err := ValidateURL(bad); err == nil {
+			t.Errorf("ValidateURL(%q) accepted", bad)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package value
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidateURL checks the value of a url-typed workflow field: it must be an
+// absolute URL with a scheme and, for hierarchical schemes such as http(s),
+// a host ("mailto:" and other opaque URLs need no host). An empty string is
+// valid and means "no link".
+func ValidateURL(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q", s)
+	}
+	if u.Scheme == "" {
+		return fmt.Errorf("URL %q has no scheme (expected e.g. https://)", s)
+	}
+	if u.Opaque == "" && u.Host == "" {
+		return fmt.Errorf("URL %q has no host", s)
+	}
+	return nil
+}